@@ -0,0 +1,8 @@
+module github.com/rajveermalviya/go-wayland/cmd/go-wayland-headless
+
+go 1.21
+
+require (
+	github.com/rajveermalviya/go-wayland/wayland v0.0.0-20261018214816-a5b246148021
+	golang.org/x/sys v0.4.0
+)
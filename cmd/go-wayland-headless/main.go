@@ -0,0 +1,212 @@
+// Command go-wayland-headless is a minimal wayland compositor without
+// any display, for running wayland clients in CI.
+//
+// It implements wl_compositor, wl_subcompositor, wl_shm, wl_seat,
+// wl_output and xdg_wm_base. Committed shm buffers are composited in
+// software onto virtual outputs, which can be dumped to PNG files every
+// time their contents change. There are no input devices: the newest
+// toplevel has the keyboard focus and the pointer rests in the middle of
+// the first output.
+//
+// Usage:
+//
+//	go-wayland-headless [flags] [command [args...]]
+//
+// When a command is given it is run with WAYLAND_DISPLAY pointing to the
+// compositor, which exits with the command's exit status once it's done.
+//
+//	go-wayland-headless -outputs 800x600,1920x1080@2 -frames out imageviewer cat.jpg
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"image/color"
+	"log"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+var (
+	socketName string
+	outputList string
+	frameDir   string
+	refresh    float64
+	background string
+
+	// runtimeDir is the private XDG_RUNTIME_DIR created when there is
+	// none, removed on exit.
+	runtimeDir string
+)
+
+func init() {
+	flag.StringVar(&socketName, "socket", "wayland-headless", "Socket name in XDG_RUNTIME_DIR, or path of the socket")
+	flag.StringVar(&outputList, "outputs", "1280x720", "Comma separated virtual outputs, as WIDTHxHEIGHT[@SCALE]")
+	flag.StringVar(&frameDir, "frames", "", "Directory to write a PNG of every changed frame of each output to")
+	flag.Float64Var(&refresh, "refresh", 60, "Refresh rate of the outputs in Hz")
+	flag.StringVar(&background, "background", "202020", "Background color as RRGGBB")
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("go-wayland-headless: ")
+	flag.Parse()
+
+	outputs, err := parseOutputs(outputList)
+	if err != nil {
+		log.Fatal(err)
+	}
+	bg, err := parseColor(background)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if refresh <= 0 {
+		log.Fatalf("invalid refresh rate %v", refresh)
+	}
+	if frameDir != "" {
+		if err := os.MkdirAll(frameDir, 0o755); err != nil {
+			log.Fatalf("unable to create frames directory: %v", err)
+		}
+	}
+
+	socketPath, err := socketPathFor(socketName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	l, err := listen(socketPath)
+	if err != nil {
+		log.Fatalf("unable to listen on %s: %v", socketPath, err)
+	}
+	defer l.Close()
+	log.Printf("listening on %s", socketPath)
+
+	comp := newCompositor(outputs, bg)
+	comp.frameDir = frameDir
+
+	stop := make(chan struct{})
+	go comp.run(time.Duration(float64(time.Second)/refresh), stop)
+	go func() {
+		if err := comp.serve(l); err != nil {
+			log.Fatalf("unable to accept client: %v", err)
+		}
+	}()
+
+	status := 0
+	if flag.NArg() > 0 {
+		status = runCommand(flag.Args(), socketPath)
+	} else {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		<-signals
+	}
+
+	close(stop)
+	l.Close()
+	if runtimeDir != "" {
+		os.RemoveAll(runtimeDir)
+	}
+	os.Exit(status)
+}
+
+// socketPathFor resolves the socket name like libwayland does. Without
+// XDG_RUNTIME_DIR a private runtime directory is created, which is
+// passed on to the command.
+func socketPathFor(name string) (string, error) {
+	if strings.Contains(name, "/") {
+		return filepath.Abs(name)
+	}
+
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" {
+		var err error
+		runtimeDir, err = os.MkdirTemp("", "go-wayland-headless-")
+		if err != nil {
+			return "", fmt.Errorf("unable to create runtime directory: %w", err)
+		}
+		if err := os.Setenv("XDG_RUNTIME_DIR", runtimeDir); err != nil {
+			return "", err
+		}
+		dir = runtimeDir
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// listen creates the socket, replacing a stale one left behind by a
+// crashed compositor.
+func listen(path string) (*net.UnixListener, error) {
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil, errors.New("socket is in use")
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+
+	l, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	l.SetUnlinkOnClose(true)
+	return l, nil
+}
+
+// runCommand runs a client and returns its exit status.
+func runCommand(args []string, socketPath string) int {
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	// XDG_RUNTIME_DIR is only overridden for a socket outside of it,
+	// other clients of the runtime directory are left undisturbed
+	cmd.Env = append(os.Environ(), "WAYLAND_DISPLAY="+filepath.Base(socketPath))
+	if filepath.Dir(socketPath) != os.Getenv("XDG_RUNTIME_DIR") {
+		cmd.Env = append(cmd.Env, "XDG_RUNTIME_DIR="+filepath.Dir(socketPath))
+	}
+
+	if err := cmd.Start(); err != nil {
+		log.Printf("unable to start %s: %v", args[0], err)
+		return 127
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		for sig := range signals {
+			_ = cmd.Process.Signal(sig)
+		}
+	}()
+
+	err := cmd.Wait()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		// Like a shell, report a command killed by a signal as
+		// 128+signal rather than the -1 of ExitCode
+		if ws, ok := exitErr.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+			return 128 + int(ws.Signal())
+		}
+		return exitErr.ExitCode()
+	}
+	if err != nil {
+		log.Printf("unable to run %s: %v", args[0], err)
+		return 1
+	}
+	return 0
+}
+
+func parseColor(s string) (color.RGBA, error) {
+	v, err := strconv.ParseUint(strings.TrimPrefix(s, "#"), 16, 32)
+	if err != nil || len(strings.TrimPrefix(s, "#")) != 6 {
+		return color.RGBA{}, fmt.Errorf("invalid color %q, want RRGGBB", s)
+	}
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}, nil
+}
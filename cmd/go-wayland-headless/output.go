@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"strconv"
+	"strings"
+)
+
+// outputConfig describes a virtual output, parsed from WIDTHxHEIGHT[@SCALE].
+type outputConfig struct {
+	width, height int32
+	scale         int32
+}
+
+func parseOutputs(s string) ([]outputConfig, error) {
+	var outputs []outputConfig
+	for _, spec := range strings.Split(s, ",") {
+		cfg := outputConfig{scale: 1}
+
+		size, scale, hasScale := strings.Cut(strings.TrimSpace(spec), "@")
+		if hasScale {
+			v, err := strconv.ParseInt(scale, 10, 32)
+			if err != nil || v < 1 {
+				return nil, fmt.Errorf("invalid output scale %q", scale)
+			}
+			cfg.scale = int32(v)
+		}
+
+		w, h, ok := strings.Cut(size, "x")
+		width, err1 := strconv.ParseInt(w, 10, 32)
+		height, err2 := strconv.ParseInt(h, 10, 32)
+		if !ok || err1 != nil || err2 != nil || width < 1 || height < 1 {
+			return nil, fmt.Errorf("invalid output size %q, want WIDTHxHEIGHT", size)
+		}
+		cfg.width, cfg.height = int32(width), int32(height)
+
+		outputs = append(outputs, cfg)
+	}
+	return outputs, nil
+}
+
+// output is a virtual monitor. Outputs are laid out left to right in
+// the global (logical) coordinate space.
+type output struct {
+	comp *compositor
+	name string
+
+	x             int32
+	width, height int32 // mode, in pixels
+	scale         int32
+
+	resources []*outputResource
+	frame     *image.RGBA
+}
+
+func (o *output) logicalWidth() int32  { return o.width / o.scale }
+func (o *output) logicalHeight() int32 { return o.height / o.scale }
+
+// bounds returns the area covered by the output, in global coordinates.
+func (o *output) bounds() image.Rectangle {
+	return image.Rect(int(o.x), 0, int(o.x+o.logicalWidth()), int(o.logicalHeight()))
+}
+
+type outputResource struct {
+	resource
+	output *output
+}
+
+func (o *output) bind(c *client, id, version uint32) object {
+	r := &outputResource{
+		resource: resource{c: c, id: id, iface: "wl_output", version: version},
+		output:   o,
+	}
+	o.resources = append(o.resources, r)
+
+	// geometry: 96 dpi, subpixel unknown, transform normal
+	r.send(0, o.x, int32(0),
+		o.width*254/960, o.height*254/960,
+		int32(0), "go-wayland", "headless", int32(0))
+	// mode: current | preferred
+	r.send(1, uint32(0x1|0x2), o.width, o.height, int32(60000))
+	if version >= 2 {
+		r.send(3, o.scale) // scale
+	}
+	if version >= 4 {
+		r.send(4, o.name)                                    // name
+		r.send(5, fmt.Sprintf("Headless output %s", o.name)) // description
+	}
+	if version >= 2 {
+		r.send(2) // done
+	}
+	return r
+}
+
+// resourcesFor returns the wl_output objects c bound for o.
+func (o *output) resourcesFor(c *client) []*outputResource {
+	var resources []*outputResource
+	for _, r := range o.resources {
+		if r.c == c {
+			resources = append(resources, r)
+		}
+	}
+	return resources
+}
+
+func (r *outputResource) dispatch(opcode uint16, d *decoder) error {
+	switch opcode {
+	case 0: // release
+		r.c.destroy(r)
+		return nil
+	}
+	return r.invalidOpcode(opcode)
+}
+
+func (r *outputResource) destroy() {
+	o := r.output
+	for i, res := range o.resources {
+		if res == r {
+			o.resources = append(o.resources[:i], o.resources[i+1:]...)
+			break
+		}
+	}
+}
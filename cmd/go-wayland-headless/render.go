@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// primaryOutput returns the output new toplevels are placed on.
+func (comp *compositor) primaryOutput() *output {
+	if len(comp.outputs) == 0 {
+		return nil
+	}
+	return comp.outputs[0]
+}
+
+// outputsAt returns the outputs overlapping r, in global coordinates.
+func (comp *compositor) outputsAt(r image.Rectangle) []*output {
+	var outputs []*output
+	for _, o := range comp.outputs {
+		if r.Overlaps(o.bounds()) {
+			outputs = append(outputs, o)
+		}
+	}
+	return outputs
+}
+
+// placeToplevel positions a newly mapped toplevel: fullscreen and
+// maximized windows cover the primary output, others are centered on it.
+func (comp *compositor) placeToplevel(xs *xdgSurface) {
+	o := comp.primaryOutput()
+	if o == nil {
+		return
+	}
+	b := o.bounds()
+	t := xs.toplevel
+	if t.fullscreen || t.maximized {
+		xs.x, xs.y = b.Min.X, b.Min.Y
+		return
+	}
+	size := xs.windowSize()
+	xs.x = b.Min.X + max(0, (b.Dx()-size.X)/2)
+	xs.y = b.Min.Y + max(0, (b.Dy()-size.Y)/2)
+}
+
+// frame repaints the outputs if anything changed, writes them out when
+// frame dumping is enabled and fires the pending frame callbacks.
+func (comp *compositor) frame() {
+	if comp.dirty {
+		comp.dirty = false
+		comp.frameSeq++
+		for _, o := range comp.outputs {
+			comp.repaint(o)
+			if comp.frameDir != "" {
+				if err := o.writeFrame(comp.frameDir, comp.frameSeq); err != nil {
+					log.Printf("unable to write frame: %v", err)
+				}
+			}
+		}
+	}
+
+	callbacks := comp.frameCallbacks
+	comp.frameCallbacks = nil
+	now := comp.now()
+	for _, cb := range callbacks {
+		cb.done(now)
+	}
+}
+
+// repaint composites the mapped windows onto the output.
+func (comp *compositor) repaint(o *output) {
+	if o.frame == nil {
+		o.frame = image.NewRGBA(image.Rect(0, 0, int(o.width), int(o.height)))
+	}
+	draw.Draw(o.frame, o.frame.Bounds(), image.NewUniform(comp.background), image.Point{}, draw.Src)
+
+	for _, xs := range comp.windows {
+		drawSurface(o, xs.surface, xs.origin())
+	}
+}
+
+// drawSurface draws s and its subsurfaces, with s at pos in global
+// coordinates.
+func drawSurface(o *output, s *surface, pos image.Point) {
+	for _, child := range s.stack {
+		if child != s {
+			drawSurface(o, child, pos.Add(image.Pt(int(child.sub.x), int(child.sub.y))))
+			continue
+		}
+		if s.content == nil {
+			continue
+		}
+
+		// convert from global coordinates to output pixels
+		size := s.size().Mul(int(o.scale))
+		min := pos.Sub(image.Pt(int(o.x), 0)).Mul(int(o.scale))
+		dst := image.Rectangle{Min: min, Max: min.Add(size)}
+		if !dst.Overlaps(o.frame.Bounds()) {
+			continue
+		}
+
+		src := s.content
+		if src.Bounds().Size() != size {
+			src = scaleNearest(src, size)
+		}
+		draw.Draw(o.frame, dst, src, image.Point{}, draw.Over)
+	}
+}
+
+// scaleNearest resizes img to size using nearest neighbour sampling,
+// which is exact for the integer scale factors wayland deals with.
+func scaleNearest(img *image.RGBA, size image.Point) *image.RGBA {
+	dst := image.NewRGBA(image.Rectangle{Max: size})
+	sw, sh := img.Bounds().Dx(), img.Bounds().Dy()
+	for y := 0; y < size.Y; y++ {
+		sy := y * sh / size.Y
+		for x := 0; x < size.X; x++ {
+			sx := x * sw / size.X
+			copy(dst.Pix[y*dst.Stride+x*4:][:4], img.Pix[sy*img.Stride+sx*4:][:4])
+		}
+	}
+	return dst
+}
+
+// writeFrame saves the last repaint of the output as a PNG file.
+func (o *output) writeFrame(dir string, seq int) error {
+	name := filepath.Join(dir, fmt.Sprintf("%s-%05d.png", o.name, seq))
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, o.frame); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
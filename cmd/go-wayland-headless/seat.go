@@ -0,0 +1,271 @@
+package main
+
+import (
+	"image"
+	"log"
+
+	"golang.org/x/sys/unix"
+)
+
+// wl_seat capabilities and wl_keyboard keymap formats
+const (
+	seatCapabilityPointer  = 1
+	seatCapabilityKeyboard = 2
+
+	keymapFormatNoKeymap = 0
+)
+
+// There are no input devices, the seat exists so clients find the
+// interfaces they expect. Keyboard focus follows the most recently mapped
+// toplevel and the pointer rests in the middle of the first output.
+
+type seatResource struct {
+	resource
+}
+
+func (comp *compositor) bindSeat(c *client, id, version uint32) object {
+	s := &seatResource{resource{c: c, id: id, iface: "wl_seat", version: version}}
+	s.send(0, uint32(seatCapabilityPointer|seatCapabilityKeyboard)) // capabilities
+	if version >= 2 {
+		s.send(1, "seat0") // name
+	}
+	return s
+}
+
+func (s *seatResource) dispatch(opcode uint16, d *decoder) error {
+	comp := s.c.comp
+
+	switch opcode {
+	case 0: // get_pointer
+		id := d.newID()
+		if d.Err != nil {
+			return d.Err
+		}
+		p := &pointer{resource: resource{c: s.c, id: id, iface: "wl_pointer", version: s.version}}
+		s.c.add(p)
+		comp.pointers = append(comp.pointers, p)
+		if f := comp.pointerFocus; f != nil && f.c == s.c {
+			p.enter(f, comp.nextSerial())
+		}
+		return nil
+
+	case 1: // get_keyboard
+		id := d.newID()
+		if d.Err != nil {
+			return d.Err
+		}
+		k := &keyboard{resource{c: s.c, id: id, iface: "wl_keyboard", version: s.version}}
+		s.c.add(k)
+		comp.keyboards = append(comp.keyboards, k)
+		if err := k.sendKeymap(); err != nil {
+			return err
+		}
+		if k.version >= 4 {
+			k.send(5, int32(25), int32(600)) // repeat_info
+		}
+		if f := comp.focus; f != nil && f.c == s.c {
+			k.enter(f.surface, comp.nextSerial())
+		}
+		return nil
+
+	case 2: // get_touch
+		id := d.newID()
+		if d.Err != nil {
+			return d.Err
+		}
+		s.c.add(&touch{resource{c: s.c, id: id, iface: "wl_touch", version: s.version}})
+		return nil
+
+	case 3: // release
+		s.c.destroy(s)
+		return nil
+	}
+	return s.invalidOpcode(opcode)
+}
+
+type keyboard struct {
+	resource
+}
+
+// sendKeymap tells the client keycodes are raw evdev codes, with an empty
+// memfd as the mandatory keymap fd.
+func (k *keyboard) sendKeymap() error {
+	fd, err := unix.MemfdCreate("go-wayland-headless-keymap", unix.MFD_CLOEXEC)
+	if err != nil {
+		return err
+	}
+	k.send(0, uint32(keymapFormatNoKeymap), fdArg(fd), uint32(0))
+	if err := unix.Close(fd); err != nil {
+		log.Printf("unable to close keymap fd: %v", err)
+	}
+	return nil
+}
+
+func (k *keyboard) enter(s *surface, serial uint32) {
+	k.send(1, serial, s.id, []byte{})                             // enter
+	k.send(4, serial, uint32(0), uint32(0), uint32(0), uint32(0)) // modifiers
+}
+
+func (k *keyboard) dispatch(opcode uint16, d *decoder) error {
+	switch opcode {
+	case 0: // release
+		k.c.destroy(k)
+		return nil
+	}
+	return k.invalidOpcode(opcode)
+}
+
+func (k *keyboard) destroy() {
+	comp := k.c.comp
+	for i, v := range comp.keyboards {
+		if v == k {
+			comp.keyboards = append(comp.keyboards[:i], comp.keyboards[i+1:]...)
+			break
+		}
+	}
+}
+
+// setFocus moves the keyboard focus to the toplevel xs, or nowhere, and
+// updates the activated state of the toplevels involved.
+func (comp *compositor) setFocus(xs *xdgSurface) {
+	old := comp.focus
+	if old == xs {
+		return
+	}
+	comp.focus = xs
+
+	serial := comp.nextSerial()
+	if old != nil && old.surface != nil {
+		for _, k := range comp.keyboards {
+			if k.c == old.c {
+				k.send(2, serial, old.surface.id) // leave
+			}
+		}
+		if old.toplevel != nil && old.mapped {
+			old.sendConfigure()
+		}
+	}
+	if xs != nil {
+		for _, k := range comp.keyboards {
+			if k.c == xs.c {
+				k.enter(xs.surface, serial)
+			}
+		}
+		xs.sendConfigure()
+	}
+}
+
+type pointer struct {
+	resource
+}
+
+func (p *pointer) dispatch(opcode uint16, d *decoder) error {
+	switch opcode {
+	case 0: // set_cursor
+		d.Uint32()
+		s, _ := d.object().(*surface)
+		d.Int32()
+		d.Int32()
+		if d.Err != nil {
+			return d.Err
+		}
+		if s != nil {
+			// cursors are not drawn
+			return s.setRole("cursor", &p.resource, 0)
+		}
+		return nil
+
+	case 1: // release
+		p.c.destroy(p)
+		return nil
+	}
+	return p.invalidOpcode(opcode)
+}
+
+func (p *pointer) enter(xs *xdgSurface, serial uint32) {
+	pos := p.c.comp.pointerPosition().Sub(xs.origin())
+	p.send(0, serial, xs.surface.id, fixed(pos.X), fixed(pos.Y)) // enter
+	if p.version >= 5 {
+		p.send(5) // frame
+	}
+}
+
+func (p *pointer) leave(xs *xdgSurface, serial uint32) {
+	p.send(1, serial, xs.surface.id) // leave
+	if p.version >= 5 {
+		p.send(5) // frame
+	}
+}
+
+func (p *pointer) destroy() {
+	comp := p.c.comp
+	for i, v := range comp.pointers {
+		if v == p {
+			comp.pointers = append(comp.pointers[:i], comp.pointers[i+1:]...)
+			break
+		}
+	}
+}
+
+// pointerPosition returns where the pointer is, in global coordinates.
+func (comp *compositor) pointerPosition() image.Point {
+	o := comp.primaryOutput()
+	if o == nil {
+		return image.Point{}
+	}
+	b := o.bounds()
+	return image.Pt((b.Min.X+b.Max.X)/2, (b.Min.Y+b.Max.Y)/2)
+}
+
+// updatePointer gives the pointer focus to the topmost window under the
+// pointer.
+func (comp *compositor) updatePointer() {
+	var focus *xdgSurface
+	pos := comp.pointerPosition()
+	for i := len(comp.windows) - 1; i >= 0; i-- {
+		if pos.In(comp.windows[i].bounds()) {
+			focus = comp.windows[i]
+			break
+		}
+	}
+
+	old := comp.pointerFocus
+	if old == focus {
+		return
+	}
+	comp.pointerFocus = focus
+
+	serial := comp.nextSerial()
+	for _, p := range comp.pointers {
+		if old != nil && old.surface != nil && p.c == old.c {
+			p.leave(old, serial)
+		}
+		if focus != nil && p.c == focus.c {
+			p.enter(focus, serial)
+		}
+	}
+}
+
+// surfaceDestroyed drops the keyboard and pointer focus when their
+// surface goes away, without sending leave events for a dead object.
+func (comp *compositor) surfaceDestroyed(s *surface) {
+	if comp.focus != nil && comp.focus.surface == s {
+		comp.focus = nil
+	}
+	if comp.pointerFocus != nil && comp.pointerFocus.surface == s {
+		comp.pointerFocus = nil
+	}
+}
+
+type touch struct {
+	resource
+}
+
+func (t *touch) dispatch(opcode uint16, d *decoder) error {
+	switch opcode {
+	case 0: // release
+		t.c.destroy(t)
+		return nil
+	}
+	return t.invalidOpcode(opcode)
+}
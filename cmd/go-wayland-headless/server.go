@@ -0,0 +1,455 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/rajveermalviya/go-wayland/wayland/wire"
+	"golang.org/x/sys/unix"
+)
+
+// wl_display error codes
+const (
+	errorInvalidObject  = 0
+	errorInvalidMethod  = 1
+	errorImplementation = 3
+)
+
+// firstServerID is the first object id allocated by the server side.
+const firstServerID = 0xff000000
+
+// object is the server side of a protocol object.
+type object interface {
+	res() *resource
+	dispatch(opcode uint16, d *decoder) error
+}
+
+// destroyer is implemented by objects which need to release state when
+// they are destroyed, either by request or because the client went away.
+type destroyer interface {
+	destroy()
+}
+
+// resource holds what every protocol object has in common.
+type resource struct {
+	c       *client
+	id      uint32
+	iface   string
+	version uint32
+}
+
+func (r *resource) res() *resource {
+	return r
+}
+
+func (r *resource) send(opcode uint16, args ...any) {
+	r.c.send(r.id, opcode, args...)
+}
+
+func (r *resource) String() string {
+	return fmt.Sprintf("%s@%d", r.iface, r.id)
+}
+
+// errorf returns a protocol error raised on r.
+func (r *resource) errorf(code uint32, format string, args ...any) error {
+	return &protocolError{
+		id:   r.id,
+		code: code,
+		msg:  fmt.Sprintf("%s: %s", r, fmt.Sprintf(format, args...)),
+	}
+}
+
+func (r *resource) invalidOpcode(opcode uint16) error {
+	return r.c.display.errorf(errorInvalidMethod, "invalid opcode %d for %s", opcode, r)
+}
+
+// protocolError is sent to the client as a wl_display.error event, after
+// which the client is disconnected.
+type protocolError struct {
+	id   uint32
+	code uint32
+	msg  string
+}
+
+func (e *protocolError) Error() string {
+	return e.msg
+}
+
+type global struct {
+	name    uint32
+	iface   string
+	version uint32
+	bind    func(c *client, id, version uint32) object
+}
+
+type compositor struct {
+	mu sync.Mutex
+
+	start      time.Time
+	serial     uint32
+	background color.RGBA
+
+	globals []*global
+	clients map[*client]struct{}
+	outputs []*output
+
+	// windows holds the mapped toplevels and popups, bottom first.
+	windows      []*xdgSurface
+	focus        *xdgSurface
+	pointerFocus *xdgSurface
+	keyboards    []*keyboard
+	pointers     []*pointer
+
+	// frameCallbacks are fired after the next repaint.
+	frameCallbacks []*callback
+
+	dirty    bool
+	frameDir string
+	frameSeq int
+}
+
+func newCompositor(outputs []outputConfig, background color.RGBA) *compositor {
+	comp := &compositor{
+		start:      time.Now(),
+		background: background,
+		clients:    map[*client]struct{}{},
+		dirty:      true,
+	}
+
+	comp.addGlobal("wl_compositor", 5, bindCompositor)
+	comp.addGlobal("wl_subcompositor", 1, bindSubcompositor)
+	comp.addGlobal("wl_shm", 1, bindShm)
+	comp.addGlobal("wl_seat", 7, comp.bindSeat)
+	comp.addGlobal("xdg_wm_base", 5, bindWmBase)
+
+	x := int32(0)
+	for i, cfg := range outputs {
+		o := &output{
+			comp:  comp,
+			name:  fmt.Sprintf("HEADLESS-%d", i+1),
+			x:     x,
+			width: cfg.width, height: cfg.height,
+			scale: cfg.scale,
+		}
+		x += o.logicalWidth()
+		comp.outputs = append(comp.outputs, o)
+		comp.addGlobal("wl_output", 4, o.bind)
+	}
+	return comp
+}
+
+func (comp *compositor) addGlobal(iface string, version uint32, bind func(c *client, id, version uint32) object) {
+	comp.globals = append(comp.globals, &global{
+		name:    uint32(len(comp.globals) + 1),
+		iface:   iface,
+		version: version,
+		bind:    bind,
+	})
+}
+
+func (comp *compositor) nextSerial() uint32 {
+	comp.serial++
+	return comp.serial
+}
+
+// now returns the timestamp used in events, in milliseconds.
+func (comp *compositor) now() uint32 {
+	return uint32(time.Since(comp.start).Milliseconds())
+}
+
+// serve accepts clients until the listener is closed.
+func (comp *compositor) serve(l *net.UnixListener) error {
+	for {
+		conn, err := l.AcceptUnix()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		comp.addClient(conn)
+	}
+}
+
+// addClient starts serving a connected client.
+func (comp *compositor) addClient(conn *net.UnixConn) *client {
+	c := &client{
+		comp:    comp,
+		conn:    conn,
+		objects: map[uint32]object{},
+	}
+	c.display = &display{resource{c: c, id: 1, iface: "wl_display", version: 1}}
+	c.objects[1] = c.display
+
+	comp.mu.Lock()
+	comp.clients[c] = struct{}{}
+	comp.mu.Unlock()
+
+	go c.serve()
+	return c
+}
+
+// run repaints the outputs and fires frame callbacks at the given
+// refresh rate until stop is closed.
+func (comp *compositor) run(refresh time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(refresh)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			comp.mu.Lock()
+			comp.frame()
+			comp.mu.Unlock()
+		}
+	}
+}
+
+type client struct {
+	comp    *compositor
+	conn    *net.UnixConn
+	display *display
+	objects map[uint32]object
+	buf     []byte
+	fds     []int
+	failed  bool
+}
+
+func (c *client) serve() {
+	defer c.disconnect()
+
+	buf := make([]byte, 4096)
+	oob := make([]byte, unix.CmsgSpace(28*4))
+	for {
+		n, oobn, _, _, err := c.conn.ReadMsgUnix(buf, oob)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) && !errors.Is(err, unix.ECONNRESET) {
+				log.Printf("unable to read from client: %v", err)
+			}
+			return
+		}
+		if n == 0 && oobn == 0 {
+			return
+		}
+
+		var fds []int
+		if oobn > 0 {
+			fds, err = parseFds(oob[:oobn])
+			if err != nil {
+				log.Printf("unable to read fds from client: %v", err)
+				return
+			}
+		}
+
+		c.comp.mu.Lock()
+		c.fds = append(c.fds, fds...)
+		c.buf = append(c.buf, buf[:n]...)
+		err = c.process()
+		c.comp.mu.Unlock()
+
+		if err != nil {
+			c.postError(err)
+			return
+		}
+	}
+}
+
+func parseFds(oob []byte) ([]int, error) {
+	scms, err := unix.ParseSocketControlMessage(oob)
+	if err != nil {
+		return nil, err
+	}
+	var fds []int
+	for i := range scms {
+		rights, err := unix.ParseUnixRights(&scms[i])
+		if err != nil {
+			return nil, err
+		}
+		fds = append(fds, rights...)
+	}
+	return fds, nil
+}
+
+// process dispatches every complete message in the input buffer.
+func (c *client) process() error {
+	for len(c.buf) >= wire.HeaderSize && !c.failed {
+		sender, opcode, size, _ := wire.Header(c.buf)
+		if size < wire.HeaderSize || size%4 != 0 {
+			return c.display.errorf(errorInvalidMethod, "invalid message size %d", size)
+		}
+		if len(c.buf) < size {
+			break
+		}
+
+		obj := c.objects[sender]
+		if obj == nil {
+			return c.display.errorf(errorInvalidObject, "invalid object %d", sender)
+		}
+		d := &decoder{Decoder: wire.NewDecoder(c.buf[wire.HeaderSize:size], &c.fds), c: c}
+		if err := obj.dispatch(opcode, d); err != nil {
+			var perr *protocolError
+			if !errors.As(err, &perr) {
+				err = c.display.errorf(errorInvalidMethod, "invalid arguments for %s opcode %d: %v", obj.res(), opcode, err)
+			}
+			return err
+		}
+		c.buf = c.buf[size:]
+	}
+	c.buf = append([]byte(nil), c.buf...)
+	return nil
+}
+
+func (c *client) postError(err error) {
+	c.comp.mu.Lock()
+	defer c.comp.mu.Unlock()
+
+	var perr *protocolError
+	if !errors.As(err, &perr) {
+		perr = &protocolError{id: 1, code: errorImplementation, msg: err.Error()}
+	}
+	log.Printf("client error: %v", perr)
+	c.send(1, 0, perr.id, perr.code, perr.msg)
+}
+
+func (c *client) send(id uint32, opcode uint16, args ...any) {
+	if c.failed {
+		return
+	}
+
+	buf, fds := encodeEvent(id, opcode, args)
+	var oob []byte
+	if len(fds) > 0 {
+		oob = unix.UnixRights(fds...)
+	}
+	_ = c.conn.SetWriteDeadline(time.Now().Add(time.Second))
+	if _, _, err := c.conn.WriteMsgUnix(buf, oob, nil); err != nil {
+		// the read loop notices the closed connection and cleans up
+		log.Printf("unable to write to client: %v", err)
+		c.failed = true
+		c.conn.Close()
+	}
+}
+
+func (c *client) add(obj object) {
+	c.objects[obj.res().id] = obj
+}
+
+// destroy removes obj after a destructor request or event.
+func (c *client) destroy(obj object) {
+	r := obj.res()
+	delete(c.objects, r.id)
+	if d, ok := obj.(destroyer); ok {
+		d.destroy()
+	}
+	if r.id < firstServerID {
+		c.send(1, 1, r.id)
+	}
+}
+
+func (c *client) disconnect() {
+	c.comp.mu.Lock()
+	defer c.comp.mu.Unlock()
+
+	c.failed = true
+	c.conn.Close()
+	for _, obj := range c.objects {
+		if d, ok := obj.(destroyer); ok {
+			d.destroy()
+		}
+	}
+	c.objects = nil
+	for _, fd := range c.fds {
+		unix.Close(fd)
+	}
+	c.fds = nil
+	delete(c.comp.clients, c)
+	c.comp.dirty = true
+}
+
+// display is the wl_display singleton, object 1 of every client.
+type display struct {
+	resource
+}
+
+func (d *display) dispatch(opcode uint16, args *decoder) error {
+	switch opcode {
+	case 0: // sync
+		id := args.newID()
+		if args.Err != nil {
+			return args.Err
+		}
+		cb := &callback{resource{c: d.c, id: id, iface: "wl_callback", version: 1}}
+		d.c.add(cb)
+		cb.done(d.c.comp.serial)
+		return nil
+
+	case 1: // get_registry
+		id := args.newID()
+		if args.Err != nil {
+			return args.Err
+		}
+		r := &registry{resource{c: d.c, id: id, iface: "wl_registry", version: 1}}
+		d.c.add(r)
+		for _, g := range d.c.comp.globals {
+			r.send(0, g.name, g.iface, g.version)
+		}
+		return nil
+	}
+	return d.invalidOpcode(opcode)
+}
+
+type registry struct {
+	resource
+}
+
+func (r *registry) dispatch(opcode uint16, d *decoder) error {
+	if opcode != 0 {
+		return r.invalidOpcode(opcode)
+	}
+
+	// bind
+	name := d.Uint32()
+	iface, _ := d.String()
+	version := d.Uint32()
+	id := d.newID()
+	if d.Err != nil {
+		return d.Err
+	}
+
+	for _, g := range r.c.comp.globals {
+		if g.name != name {
+			continue
+		}
+		if g.iface != iface {
+			return r.c.display.errorf(errorInvalidObject, "invalid interface for global %d: have %s, wanted %s", name, iface, g.iface)
+		}
+		if version == 0 || version > g.version {
+			return r.c.display.errorf(errorInvalidObject, "invalid version for global %s (%d): have %d, wanted %d", iface, name, version, g.version)
+		}
+		r.c.add(g.bind(r.c, id, version))
+		return nil
+	}
+	return r.c.display.errorf(errorInvalidObject, "invalid global %s (%d)", iface, name)
+}
+
+type callback struct {
+	resource
+}
+
+func (cb *callback) dispatch(opcode uint16, d *decoder) error {
+	return cb.invalidOpcode(opcode)
+}
+
+// done fires the callback, which destroys it.
+func (cb *callback) done(data uint32) {
+	cb.send(0, data)
+	cb.c.destroy(cb)
+}
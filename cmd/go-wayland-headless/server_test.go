@@ -0,0 +1,195 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	wlclient "github.com/rajveermalviya/go-wayland/wayland/client"
+	wlshm "github.com/rajveermalviya/go-wayland/wayland/shm"
+	xdg_shell "github.com/rajveermalviya/go-wayland/wayland/stable/xdg-shell"
+	"golang.org/x/sys/unix"
+)
+
+// connect starts a compositor repainting every millisecond and returns
+// a display connected to it.
+func connect(t *testing.T, outputs []outputConfig, background color.RGBA) (*compositor, *wlclient.Display) {
+	t.Helper()
+
+	fds, err := unix.Socketpair(unix.AF_UNIX, unix.SOCK_STREAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn := func(fd int, name string) *net.UnixConn {
+		f := os.NewFile(uintptr(fd), name)
+		defer f.Close()
+		c, err := net.FileConn(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c.(*net.UnixConn)
+	}
+	clientConn, serverConn := conn(fds[0], "client"), conn(fds[1], "server")
+
+	comp := newCompositor(outputs, background)
+	comp.addClient(serverConn)
+	stop := make(chan struct{})
+	go comp.run(time.Millisecond, stop)
+
+	display := wlclient.ConnectConn(clientConn)
+	display.SetErrorHandler(func(e wlclient.DisplayErrorEvent) {
+		t.Fatalf("protocol error %d: %s", e.Code, e.Message)
+	})
+	t.Cleanup(func() {
+		close(stop)
+		display.Context().Close()
+	})
+	return comp, display
+}
+
+// dispatchUntil dispatches events until done returns true.
+func dispatchUntil(t *testing.T, display *wlclient.Display, done func() bool) {
+	t.Helper()
+	for !done() {
+		if err := display.Context().Dispatch(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func roundtrip(t *testing.T, display *wlclient.Display) {
+	t.Helper()
+	callback, err := display.Sync()
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := false
+	callback.SetDoneHandler(func(wlclient.CallbackDoneEvent) {
+		done = true
+	})
+	dispatchUntil(t, display, func() bool { return done })
+}
+
+// TestFrame drives a client through its first frame: it maps a toplevel
+// with a buffer of a single color and waits for the frame callback, by
+// which time the output is repainted.
+func TestFrame(t *testing.T) {
+	background := color.RGBA{0x20, 0x20, 0x20, 0xff}
+	comp, display := connect(t, []outputConfig{{width: 64, height: 48, scale: 1}}, background)
+
+	registry, err := display.GetRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		compositor *wlclient.Compositor
+		wlShm      *wlclient.Shm
+		wmBase     *xdg_shell.WmBase
+	)
+	registry.SetGlobalHandler(func(e wlclient.RegistryGlobalEvent) {
+		var err error
+		switch e.Interface {
+		case "wl_compositor":
+			compositor, err = wlclient.Bind[wlclient.Compositor](registry, e.Name, e.Version)
+		case "wl_shm":
+			wlShm, err = wlclient.Bind[wlclient.Shm](registry, e.Name, e.Version)
+		case "xdg_wm_base":
+			wmBase, err = wlclient.Bind[xdg_shell.WmBase](registry, e.Name, e.Version)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	roundtrip(t, display)
+	if compositor == nil || wlShm == nil || wmBase == nil {
+		t.Fatal("missing globals")
+	}
+
+	surface, err := compositor.CreateSurface()
+	if err != nil {
+		t.Fatal(err)
+	}
+	xdgSurface, err := wmBase.GetXdgSurface(surface)
+	if err != nil {
+		t.Fatal(err)
+	}
+	toplevel, err := xdgSurface.GetToplevel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	configured := false
+	xdgSurface.SetConfigureHandler(func(e xdg_shell.SurfaceConfigureEvent) {
+		if err := xdgSurface.AckConfigure(e.Serial); err != nil {
+			t.Fatal(err)
+		}
+		configured = true
+	})
+	if err := toplevel.SetTitle("frame"); err != nil {
+		t.Fatal(err)
+	}
+	if err := surface.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	dispatchUntil(t, display, func() bool { return configured })
+
+	// The toplevel isn't given a size, it picks its own
+	const width, height = 16, 8
+	pool, err := wlshm.NewPool(wlShm, width*height*4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Destroy()
+	buf, err := pool.Alloc(width, height, width*4, wlclient.ShmFormatXrgb8888)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Data()
+	for i := 0; i < len(data); i += 4 {
+		// BGRX in memory
+		data[i+0], data[i+1], data[i+2] = 0x30, 0x20, 0xc0
+	}
+	if err := buf.Attach(surface, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	callback, err := surface.Frame()
+	if err != nil {
+		t.Fatal(err)
+	}
+	drawn := false
+	callback.SetDoneHandler(func(wlclient.CallbackDoneEvent) {
+		drawn = true
+	})
+	if err := surface.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	dispatchUntil(t, display, func() bool { return drawn })
+
+	comp.mu.Lock()
+	defer comp.mu.Unlock()
+
+	if len(comp.windows) != 1 {
+		t.Fatalf("got %d windows, want 1", len(comp.windows))
+	}
+	if title := comp.windows[0].toplevel.title; title != "frame" {
+		t.Errorf("got title %q, want %q", title, "frame")
+	}
+
+	// The window is centered on the output
+	frame := comp.outputs[0].frame
+	window := image.Rect(24, 20, 24+width, 20+height)
+	want := color.RGBA{0xc0, 0x20, 0x30, 0xff}
+	for _, p := range []image.Point{window.Min, window.Max.Sub(image.Pt(1, 1))} {
+		if got := frame.RGBAAt(p.X, p.Y); got != want {
+			t.Errorf("got %v at %v, want the window %v", got, p, want)
+		}
+	}
+	for _, p := range []image.Point{{}, window.Min.Sub(image.Pt(1, 1)), window.Max} {
+		if got := frame.RGBAAt(p.X, p.Y); got != background {
+			t.Errorf("got %v at %v, want the background %v", got, p, background)
+		}
+	}
+}
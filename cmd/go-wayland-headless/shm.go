@@ -0,0 +1,186 @@
+package main
+
+import (
+	"image"
+
+	"golang.org/x/sys/unix"
+)
+
+// wl_shm formats and errors
+const (
+	shmFormatARGB8888 = 0
+	shmFormatXRGB8888 = 1
+
+	shmErrorInvalidFormat = 0
+	shmErrorInvalidStride = 1
+	shmErrorInvalidFd     = 2
+)
+
+type shm struct {
+	resource
+}
+
+func bindShm(c *client, id, version uint32) object {
+	s := &shm{resource{c: c, id: id, iface: "wl_shm", version: version}}
+	s.send(0, uint32(shmFormatARGB8888))
+	s.send(0, uint32(shmFormatXRGB8888))
+	return s
+}
+
+func (s *shm) dispatch(opcode uint16, d *decoder) error {
+	switch opcode {
+	case 0: // create_pool
+		id := d.newID()
+		fd := d.Fd()
+		size := d.Int32()
+		if d.Err != nil {
+			return d.Err
+		}
+		if size <= 0 {
+			unix.Close(fd)
+			return s.errorf(shmErrorInvalidStride, "invalid size (%d)", size)
+		}
+		data, err := unix.Mmap(fd, 0, int(size), unix.PROT_READ, unix.MAP_SHARED)
+		if err != nil {
+			unix.Close(fd)
+			return s.errorf(shmErrorInvalidFd, "failed mmap fd %d: %v", fd, err)
+		}
+		s.c.add(&shmPool{
+			resource: resource{c: s.c, id: id, iface: "wl_shm_pool", version: s.version},
+			fd:       fd,
+			data:     data,
+			refs:     1,
+		})
+		return nil
+	}
+	return s.invalidOpcode(opcode)
+}
+
+// shmPool keeps its mapping alive until the pool and all of its buffers
+// are destroyed.
+type shmPool struct {
+	resource
+	fd   int
+	data []byte
+	refs int
+}
+
+func (p *shmPool) dispatch(opcode uint16, d *decoder) error {
+	switch opcode {
+	case 0: // create_buffer
+		id := d.newID()
+		offset := d.Int32()
+		width := d.Int32()
+		height := d.Int32()
+		stride := d.Int32()
+		format := d.Uint32()
+		if d.Err != nil {
+			return d.Err
+		}
+		if format != shmFormatARGB8888 && format != shmFormatXRGB8888 {
+			return p.errorf(shmErrorInvalidFormat, "invalid format 0x%x", format)
+		}
+		if offset < 0 || width <= 0 || height <= 0 || stride < width*4 ||
+			int64(offset)+int64(stride)*int64(height) > int64(len(p.data)) {
+			return p.errorf(shmErrorInvalidStride, "invalid width, height or stride (%dx%d, %d)", width, height, stride)
+		}
+		p.refs++
+		p.c.add(&buffer{
+			resource: resource{c: p.c, id: id, iface: "wl_buffer", version: 1},
+			pool:     p,
+			offset:   offset,
+			width:    width,
+			height:   height,
+			stride:   stride,
+			format:   format,
+		})
+		return nil
+
+	case 1: // destroy
+		p.c.destroy(p)
+		return nil
+
+	case 2: // resize
+		size := d.Int32()
+		if d.Err != nil {
+			return d.Err
+		}
+		if int(size) < len(p.data) {
+			return p.errorf(shmErrorInvalidStride, "shrinking pool invalid")
+		}
+		data, err := unix.Mmap(p.fd, 0, int(size), unix.PROT_READ, unix.MAP_SHARED)
+		if err != nil {
+			return p.errorf(shmErrorInvalidFd, "failed mmap fd %d: %v", p.fd, err)
+		}
+		unix.Munmap(p.data)
+		p.data = data
+		return nil
+	}
+	return p.invalidOpcode(opcode)
+}
+
+func (p *shmPool) destroy() {
+	p.unref()
+}
+
+func (p *shmPool) unref() {
+	p.refs--
+	if p.refs == 0 {
+		unix.Munmap(p.data)
+		unix.Close(p.fd)
+		p.data = nil
+	}
+}
+
+type buffer struct {
+	resource
+	pool      *shmPool
+	offset    int32
+	width     int32
+	height    int32
+	stride    int32
+	format    uint32
+	destroyed bool
+}
+
+func (b *buffer) dispatch(opcode uint16, d *decoder) error {
+	switch opcode {
+	case 0: // destroy
+		b.c.destroy(b)
+		return nil
+	}
+	return b.invalidOpcode(opcode)
+}
+
+func (b *buffer) destroy() {
+	b.destroyed = true
+	b.pool.unref()
+}
+
+// image copies the buffer contents, converting them from little endian
+// (premultiplied) ARGB to RGBA.
+func (b *buffer) image() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, int(b.width), int(b.height)))
+	for y := 0; y < int(b.height); y++ {
+		src := b.pool.data[int(b.offset)+y*int(b.stride):][:b.width*4]
+		dst := img.Pix[y*img.Stride:][:b.width*4]
+		for x := 0; x < len(src); x += 4 {
+			dst[x+0] = src[x+2]
+			dst[x+1] = src[x+1]
+			dst[x+2] = src[x+0]
+			if b.format == shmFormatXRGB8888 {
+				dst[x+3] = 0xff
+			} else {
+				dst[x+3] = src[x+3]
+			}
+		}
+	}
+	return img
+}
+
+// release tells the client the compositor is done with the buffer.
+func (b *buffer) release() {
+	if !b.destroyed {
+		b.send(0)
+	}
+}
@@ -0,0 +1,517 @@
+package main
+
+import (
+	"image"
+)
+
+// wl_surface, wl_subcompositor and wl_subsurface errors
+const (
+	surfaceErrorInvalidScale     = 0
+	surfaceErrorInvalidTransform = 1
+
+	subcompositorErrorBadSurface = 0
+
+	subsurfaceErrorBadSurface = 0
+)
+
+type compositorResource struct {
+	resource
+}
+
+func bindCompositor(c *client, id, version uint32) object {
+	return &compositorResource{resource{c: c, id: id, iface: "wl_compositor", version: version}}
+}
+
+func (r *compositorResource) dispatch(opcode uint16, d *decoder) error {
+	switch opcode {
+	case 0: // create_surface
+		id := d.newID()
+		if d.Err != nil {
+			return d.Err
+		}
+		s := &surface{
+			resource: resource{c: r.c, id: id, iface: "wl_surface", version: r.version},
+			scale:    1,
+		}
+		s.pending.scale = 1
+		s.stack = []*surface{s}
+		s.pendingStack = []*surface{s}
+		r.c.add(s)
+		return nil
+
+	case 1: // create_region
+		id := d.newID()
+		if d.Err != nil {
+			return d.Err
+		}
+		r.c.add(&region{resource{c: r.c, id: id, iface: "wl_region", version: 1}})
+		return nil
+	}
+	return r.invalidOpcode(opcode)
+}
+
+// region is accepted but not tracked, input and opaque regions do not
+// matter when nothing is interactive.
+type region struct {
+	resource
+}
+
+func (r *region) dispatch(opcode uint16, d *decoder) error {
+	switch opcode {
+	case 0: // destroy
+		r.c.destroy(r)
+		return nil
+
+	case 1, 2: // add, subtract
+		d.Int32()
+		d.Int32()
+		d.Int32()
+		d.Int32()
+		return d.Err
+	}
+	return r.invalidOpcode(opcode)
+}
+
+// surfaceState is the double-buffered state of a surface.
+type surfaceState struct {
+	attached bool
+	buffer   *buffer
+	scale    int32
+	frames   []*callback
+
+	// geometry is only meaningful for xdg surfaces.
+	hasGeometry bool
+	geometry    image.Rectangle
+}
+
+type surface struct {
+	resource
+
+	pending surfaceState
+	// cached holds the state committed by a synchronized subsurface,
+	// applied when its parent is committed.
+	cached    surfaceState
+	hasCached bool
+
+	// content is a copy of the last committed buffer, which is released
+	// right away.
+	content *image.RGBA
+	scale   int32
+
+	// role is one of "", "xdg_surface", "subsurface" and "cursor". Once
+	// set it can't change.
+	role string
+	xdg  *xdgSurface
+	sub  *subsurface
+
+	// stack is the stacking order of the surface and its subsurfaces,
+	// bottom first. pendingStack is applied on commit.
+	stack        []*surface
+	pendingStack []*surface
+
+	outputs []*output
+}
+
+// setRole assigns the role of the surface, failing if it already has a
+// different one.
+func (s *surface) setRole(role string, on *resource, code uint32) error {
+	if s.role != "" && s.role != role {
+		return on.errorf(code, "%s already has role %s", s, s.role)
+	}
+	s.role = role
+	return nil
+}
+
+func (s *surface) dispatch(opcode uint16, d *decoder) error {
+	switch opcode {
+	case 0: // destroy
+		s.c.destroy(s)
+		return nil
+
+	case 1: // attach
+		obj := d.object()
+		d.Int32() // dx and dy are ignored
+		d.Int32()
+		if d.Err != nil {
+			return d.Err
+		}
+		s.pending.attached = true
+		s.pending.buffer = nil
+		if obj != nil {
+			b, ok := obj.(*buffer)
+			if !ok {
+				return s.c.display.errorf(errorInvalidObject, "%s is not a wl_buffer", obj.res())
+			}
+			s.pending.buffer = b
+		}
+		return nil
+
+	case 2, 9: // damage, damage_buffer
+		d.Int32()
+		d.Int32()
+		d.Int32()
+		d.Int32()
+		return d.Err
+
+	case 3: // frame
+		id := d.newID()
+		if d.Err != nil {
+			return d.Err
+		}
+		cb := &callback{resource{c: s.c, id: id, iface: "wl_callback", version: 1}}
+		s.c.add(cb)
+		s.pending.frames = append(s.pending.frames, cb)
+		return nil
+
+	case 4, 5: // set_opaque_region, set_input_region
+		d.object()
+		return d.Err
+
+	case 6: // commit
+		return s.commit()
+
+	case 7: // set_buffer_transform
+		transform := d.Int32()
+		if d.Err != nil {
+			return d.Err
+		}
+		if transform != 0 {
+			return s.errorf(surfaceErrorInvalidTransform, "buffer transform %d is not supported", transform)
+		}
+		return nil
+
+	case 8: // set_buffer_scale
+		scale := d.Int32()
+		if d.Err != nil {
+			return d.Err
+		}
+		if scale < 1 {
+			return s.errorf(surfaceErrorInvalidScale, "buffer scale %d is invalid", scale)
+		}
+		s.pending.scale = scale
+		return nil
+
+	case 10: // offset
+		d.Int32()
+		d.Int32()
+		return d.Err
+	}
+	return s.invalidOpcode(opcode)
+}
+
+func (s *surface) commit() error {
+	if s.xdg != nil {
+		if err := s.xdg.precommit(); err != nil {
+			return err
+		}
+	}
+
+	if s.sub != nil && s.sub.synchronized() {
+		s.cached.merge(&s.pending)
+		s.hasCached = true
+		return nil
+	}
+	return s.apply(&s.pending)
+}
+
+// merge moves the committed state in other on top of st.
+func (st *surfaceState) merge(other *surfaceState) {
+	if other.attached {
+		st.attached = true
+		st.buffer = other.buffer
+	}
+	st.scale = other.scale
+	st.frames = append(st.frames, other.frames...)
+	if other.hasGeometry {
+		st.hasGeometry = true
+		st.geometry = other.geometry
+	}
+
+	scale := other.scale
+	*other = surfaceState{scale: scale}
+}
+
+// apply makes state the current state of the surface, along with the
+// cached state of synchronized subsurfaces.
+func (s *surface) apply(state *surfaceState) error {
+	comp := s.c.comp
+
+	if state.attached {
+		s.content = nil
+		if b := state.buffer; b != nil && !b.destroyed {
+			s.content = b.image()
+			b.release()
+		}
+	}
+	s.scale = state.scale
+	comp.frameCallbacks = append(comp.frameCallbacks, state.frames...)
+	geometry, hasGeometry := state.geometry, state.hasGeometry
+
+	scale := state.scale
+	*state = surfaceState{scale: scale}
+
+	s.stack = append(s.stack[:0], s.pendingStack...)
+	for _, child := range s.stack {
+		if child == s {
+			continue
+		}
+		sub := child.sub
+		sub.x, sub.y = sub.pendingX, sub.pendingY
+		if child.hasCached {
+			child.hasCached = false
+			if err := child.apply(&child.cached); err != nil {
+				return err
+			}
+		}
+	}
+
+	comp.dirty = true
+
+	if s.xdg != nil {
+		if hasGeometry {
+			s.xdg.geometry = geometry
+			s.xdg.hasGeometry = true
+		}
+		return s.xdg.committed()
+	}
+	return nil
+}
+
+// size returns the size of the surface in logical coordinates.
+func (s *surface) size() image.Point {
+	if s.content == nil {
+		return image.Point{}
+	}
+	return s.content.Bounds().Size().Div(int(s.scale))
+}
+
+// bounds returns the area covered by the surface and its subsurfaces,
+// relative to the surface.
+func (s *surface) bounds() image.Rectangle {
+	var r image.Rectangle
+	for _, child := range s.stack {
+		if child == s {
+			r = r.Union(image.Rectangle{Max: s.size()})
+		} else if child.content != nil {
+			r = r.Union(child.bounds().Add(image.Pt(int(child.sub.x), int(child.sub.y))))
+		}
+	}
+	return r
+}
+
+// enterOutputs sends wl_surface.enter and leave events so the surface is
+// on the given outputs.
+func (s *surface) enterOutputs(outputs []*output) {
+	for _, o := range s.outputs {
+		if !containsOutput(outputs, o) {
+			for _, r := range o.resourcesFor(s.c) {
+				s.send(1, r.id) // leave
+			}
+		}
+	}
+	for _, o := range outputs {
+		if !containsOutput(s.outputs, o) {
+			for _, r := range o.resourcesFor(s.c) {
+				s.send(0, r.id) // enter
+			}
+		}
+	}
+	s.outputs = append(s.outputs[:0], outputs...)
+
+	for _, child := range s.stack {
+		if child != s {
+			child.enterOutputs(outputs)
+		}
+	}
+}
+
+func containsOutput(outputs []*output, o *output) bool {
+	for _, v := range outputs {
+		if v == o {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *surface) destroy() {
+	s.c.comp.surfaceDestroyed(s)
+	if s.xdg != nil {
+		s.xdg.surfaceDestroyed()
+	}
+	if s.sub != nil {
+		s.sub.unlink()
+	}
+	for _, child := range s.pendingStack {
+		if child != s {
+			child.sub.parent = nil
+		}
+	}
+	s.c.comp.dirty = true
+}
+
+type subcompositorResource struct {
+	resource
+}
+
+func bindSubcompositor(c *client, id, version uint32) object {
+	return &subcompositorResource{resource{c: c, id: id, iface: "wl_subcompositor", version: version}}
+}
+
+func (r *subcompositorResource) dispatch(opcode uint16, d *decoder) error {
+	switch opcode {
+	case 0: // destroy
+		r.c.destroy(r)
+		return nil
+
+	case 1: // get_subsurface
+		id := d.newID()
+		s, _ := d.object().(*surface)
+		parent, _ := d.object().(*surface)
+		if d.Err != nil {
+			return d.Err
+		}
+		if s == nil || parent == nil {
+			return r.errorf(subcompositorErrorBadSurface, "surface and parent must be wl_surfaces")
+		}
+		if s == parent || s.isAncestorOf(parent) {
+			return r.errorf(subcompositorErrorBadSurface, "%s is an ancestor of parent %s", s, parent)
+		}
+		if err := s.setRole("subsurface", &r.resource, subcompositorErrorBadSurface); err != nil {
+			return err
+		}
+		if s.sub != nil {
+			return r.errorf(subcompositorErrorBadSurface, "%s already is a subsurface", s)
+		}
+
+		sub := &subsurface{
+			resource: resource{c: r.c, id: id, iface: "wl_subsurface", version: r.version},
+			surface:  s,
+			parent:   parent,
+			sync:     true,
+		}
+		s.sub = sub
+		parent.pendingStack = append(parent.pendingStack, s)
+		r.c.add(sub)
+		return nil
+	}
+	return r.invalidOpcode(opcode)
+}
+
+// isAncestorOf reports whether s is a parent of other, directly or not.
+func (s *surface) isAncestorOf(other *surface) bool {
+	for other.sub != nil && other.sub.parent != nil {
+		other = other.sub.parent
+		if other == s {
+			return true
+		}
+	}
+	return false
+}
+
+type subsurface struct {
+	resource
+	surface *surface
+	parent  *surface
+
+	x, y               int32
+	pendingX, pendingY int32
+	sync               bool
+}
+
+// synchronized reports whether the subsurface or any of its parents is
+// in synchronized mode.
+func (sub *subsurface) synchronized() bool {
+	for ; sub != nil; sub = sub.parent.sub {
+		if sub.sync {
+			return true
+		}
+		if sub.parent == nil {
+			break
+		}
+	}
+	return false
+}
+
+func (sub *subsurface) dispatch(opcode uint16, d *decoder) error {
+	switch opcode {
+	case 0: // destroy
+		sub.c.destroy(sub)
+		return nil
+
+	case 1: // set_position
+		sub.pendingX = d.Int32()
+		sub.pendingY = d.Int32()
+		return d.Err
+
+	case 2, 3: // place_above, place_below
+		sibling, _ := d.object().(*surface)
+		if d.Err != nil {
+			return d.Err
+		}
+		if sub.parent == nil {
+			return nil
+		}
+		stack := sub.parent.pendingStack
+		if sibling == nil || sibling == sub.surface || !containsSurface(stack, sibling) {
+			return sub.errorf(subsurfaceErrorBadSurface, "%v is not a sibling or the parent", sibling)
+		}
+		stack = removeSurface(stack, sub.surface)
+		i := indexSurface(stack, sibling)
+		if opcode == 2 {
+			i++
+		}
+		stack = append(stack[:i], append([]*surface{sub.surface}, stack[i:]...)...)
+		sub.parent.pendingStack = stack
+		return nil
+
+	case 4: // set_sync
+		sub.sync = true
+		return nil
+
+	case 5: // set_desync
+		sub.sync = false
+		if !sub.synchronized() && sub.surface.hasCached {
+			sub.surface.hasCached = false
+			return sub.surface.apply(&sub.surface.cached)
+		}
+		return nil
+	}
+	return sub.invalidOpcode(opcode)
+}
+
+func (sub *subsurface) destroy() {
+	sub.unlink()
+	sub.surface.sub = nil
+}
+
+// unlink removes the surface from its parent, which unmaps it.
+func (sub *subsurface) unlink() {
+	if sub.parent == nil {
+		return
+	}
+	sub.parent.stack = removeSurface(sub.parent.stack, sub.surface)
+	sub.parent.pendingStack = removeSurface(sub.parent.pendingStack, sub.surface)
+	sub.parent = nil
+	sub.c.comp.dirty = true
+}
+
+func indexSurface(stack []*surface, s *surface) int {
+	for i, v := range stack {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
+
+func containsSurface(stack []*surface, s *surface) bool {
+	return indexSurface(stack, s) != -1
+}
+
+func removeSurface(stack []*surface, s *surface) []*surface {
+	if i := indexSurface(stack, s); i != -1 {
+		return append(stack[:i], stack[i+1:]...)
+	}
+	return stack
+}
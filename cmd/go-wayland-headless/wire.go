@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+
+	wlclient "github.com/rajveermalviya/go-wayland/wayland/client"
+	"github.com/rajveermalviya/go-wayland/wayland/wire"
+)
+
+// fdArg is a file descriptor event argument.
+type fdArg int
+
+// fixed converts a position to a wl_fixed_t event argument, positions in
+// the compositor are well within its range.
+func fixed(v int) wlclient.Fixed {
+	f, _ := wlclient.FixedFromInt(v)
+	return f
+}
+
+// decoder reads the arguments of a request, with the objects of the
+// client for new_id and object arguments.
+type decoder struct {
+	*wire.Decoder
+	c *client
+}
+
+// newID reads a new_id argument, making sure the id is free.
+func (d *decoder) newID() uint32 {
+	id := d.Uint32()
+	if d.Err != nil {
+		return 0
+	}
+	if id == 0 || d.c.objects[id] != nil {
+		d.Err = fmt.Errorf("invalid new id %d", id)
+	}
+	return id
+}
+
+// object reads an object argument, returning nil for a null object.
+func (d *decoder) object() object {
+	id := d.Uint32()
+	if d.Err != nil || id == 0 {
+		return nil
+	}
+	obj := d.c.objects[id]
+	if obj == nil {
+		d.Err = fmt.Errorf("unknown object %d", id)
+	}
+	return obj
+}
+
+// encodeEvent builds an event message. Arguments are encoded from their
+// Go type: int32, uint32 (also object ids), wlclient.Fixed, string,
+// []byte and fdArg, which is returned separately to be sent as ancillary
+// data.
+func encodeEvent(sender uint32, opcode uint16, args []any) (buf []byte, fds []int) {
+	e := &wire.Encoder{}
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case int32:
+			e.Int32(v)
+		case uint32:
+			e.Uint32(v)
+		case wlclient.Fixed:
+			e.Fixed(v)
+		case string:
+			e.String(v)
+		case []byte:
+			e.Array(v)
+		case fdArg:
+			e.Fd(int(v))
+		default:
+			panic(fmt.Sprintf("unsupported event argument type %T", arg))
+		}
+	}
+	return e.Message(sender, opcode)
+}
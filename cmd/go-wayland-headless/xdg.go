@@ -0,0 +1,637 @@
+package main
+
+import (
+	"image"
+
+	wlclient "github.com/rajveermalviya/go-wayland/wayland/client"
+)
+
+// xdg_wm_base, xdg_positioner and xdg_surface errors
+const (
+	wmBaseErrorRole                = 0
+	wmBaseErrorDefunctSurfaces     = 1
+	wmBaseErrorInvalidPopupParent  = 3
+	wmBaseErrorInvalidSurfaceState = 4
+	wmBaseErrorInvalidPositioner   = 5
+
+	positionerErrorInvalidInput = 0
+
+	xdgSurfaceErrorNotConstructed     = 1
+	xdgSurfaceErrorAlreadyConstructed = 2
+	xdgSurfaceErrorUnconfiguredBuffer = 3
+	xdgSurfaceErrorInvalidSerial      = 4
+)
+
+// xdg_toplevel states and wm capabilities
+const (
+	toplevelStateMaximized  = 1
+	toplevelStateFullscreen = 2
+	toplevelStateActivated  = 4
+
+	wmCapabilityMaximize   = 2
+	wmCapabilityFullscreen = 3
+)
+
+type wmBase struct {
+	resource
+	surfaces int
+}
+
+func bindWmBase(c *client, id, version uint32) object {
+	return &wmBase{resource: resource{c: c, id: id, iface: "xdg_wm_base", version: version}}
+}
+
+func (wm *wmBase) dispatch(opcode uint16, d *decoder) error {
+	switch opcode {
+	case 0: // destroy
+		if wm.surfaces > 0 {
+			return wm.errorf(wmBaseErrorDefunctSurfaces, "%d xdg_surfaces still exist", wm.surfaces)
+		}
+		wm.c.destroy(wm)
+		return nil
+
+	case 1: // create_positioner
+		id := d.newID()
+		if d.Err != nil {
+			return d.Err
+		}
+		wm.c.add(&positioner{resource: resource{c: wm.c, id: id, iface: "xdg_positioner", version: wm.version}})
+		return nil
+
+	case 2: // get_xdg_surface
+		id := d.newID()
+		s, _ := d.object().(*surface)
+		if d.Err != nil {
+			return d.Err
+		}
+		if s == nil {
+			return wm.c.display.errorf(errorInvalidObject, "get_xdg_surface needs a wl_surface")
+		}
+		if err := s.setRole("xdg_surface", &wm.resource, wmBaseErrorRole); err != nil {
+			return err
+		}
+		if s.xdg != nil {
+			return wm.errorf(wmBaseErrorRole, "%s already has an xdg_surface", s)
+		}
+		if s.content != nil || s.pending.buffer != nil {
+			return wm.errorf(xdgSurfaceErrorUnconfiguredBuffer, "%s already has a buffer", s)
+		}
+		xs := &xdgSurface{
+			resource: resource{c: wm.c, id: id, iface: "xdg_surface", version: wm.version},
+			wm:       wm,
+			surface:  s,
+		}
+		s.xdg = xs
+		wm.surfaces++
+		wm.c.add(xs)
+		return nil
+
+	case 3: // pong
+		d.Uint32()
+		return d.Err
+	}
+	return wm.invalidOpcode(opcode)
+}
+
+// positioner rules are applied without constraint adjustment, popups are
+// placed exactly where they are asked to be.
+type positioner struct {
+	resource
+	size          image.Point
+	anchorRect    image.Rectangle
+	hasAnchorRect bool
+	anchor        uint32
+	gravity       uint32
+	offset        image.Point
+}
+
+func (p *positioner) dispatch(opcode uint16, d *decoder) error {
+	switch opcode {
+	case 0: // destroy
+		p.c.destroy(p)
+		return nil
+
+	case 1: // set_size
+		w, h := d.Int32(), d.Int32()
+		if d.Err != nil {
+			return d.Err
+		}
+		if w <= 0 || h <= 0 {
+			return p.errorf(positionerErrorInvalidInput, "invalid size %dx%d", w, h)
+		}
+		p.size = image.Pt(int(w), int(h))
+		return nil
+
+	case 2: // set_anchor_rect
+		x, y, w, h := d.Int32(), d.Int32(), d.Int32(), d.Int32()
+		if d.Err != nil {
+			return d.Err
+		}
+		if w < 0 || h < 0 {
+			return p.errorf(positionerErrorInvalidInput, "invalid anchor rect size %dx%d", w, h)
+		}
+		p.anchorRect = image.Rect(int(x), int(y), int(x+w), int(y+h))
+		p.hasAnchorRect = true
+		return nil
+
+	case 3: // set_anchor
+		p.anchor = d.Uint32()
+		return d.Err
+
+	case 4: // set_gravity
+		p.gravity = d.Uint32()
+		return d.Err
+
+	case 5: // set_constraint_adjustment
+		d.Uint32()
+		return d.Err
+
+	case 6: // set_offset
+		x, y := d.Int32(), d.Int32()
+		p.offset = image.Pt(int(x), int(y))
+		return d.Err
+
+	case 7: // set_reactive
+		return nil
+
+	case 8: // set_parent_size
+		d.Int32()
+		d.Int32()
+		return d.Err
+
+	case 9: // set_parent_configure
+		d.Uint32()
+		return d.Err
+	}
+	return p.invalidOpcode(opcode)
+}
+
+// geometry returns the popup geometry relative to the parent window
+// geometry.
+func (p *positioner) geometry() image.Rectangle {
+	r := p.anchorRect
+	var pt image.Point
+	switch p.anchor {
+	case 1: // top
+		pt = image.Pt((r.Min.X+r.Max.X)/2, r.Min.Y)
+	case 2: // bottom
+		pt = image.Pt((r.Min.X+r.Max.X)/2, r.Max.Y)
+	case 3: // left
+		pt = image.Pt(r.Min.X, (r.Min.Y+r.Max.Y)/2)
+	case 4: // right
+		pt = image.Pt(r.Max.X, (r.Min.Y+r.Max.Y)/2)
+	case 5: // top_left
+		pt = r.Min
+	case 6: // bottom_left
+		pt = image.Pt(r.Min.X, r.Max.Y)
+	case 7: // top_right
+		pt = image.Pt(r.Max.X, r.Min.Y)
+	case 8: // bottom_right
+		pt = r.Max
+	default:
+		pt = image.Pt((r.Min.X+r.Max.X)/2, (r.Min.Y+r.Max.Y)/2)
+	}
+	pt = pt.Add(p.offset)
+
+	// gravity is the direction the popup extends to from the anchor point
+	w, h := p.size.X, p.size.Y
+	switch p.gravity {
+	case 1: // top
+		pt = pt.Sub(image.Pt(w/2, h))
+	case 2: // bottom
+		pt = pt.Sub(image.Pt(w/2, 0))
+	case 3: // left
+		pt = pt.Sub(image.Pt(w, h/2))
+	case 4: // right
+		pt = pt.Sub(image.Pt(0, h/2))
+	case 5: // top_left
+		pt = pt.Sub(image.Pt(w, h))
+	case 6: // bottom_left
+		pt = pt.Sub(image.Pt(w, 0))
+	case 7: // top_right
+		pt = pt.Sub(image.Pt(0, h))
+	case 8: // bottom_right
+	default:
+		pt = pt.Sub(image.Pt(w/2, h/2))
+	}
+	return image.Rectangle{Min: pt, Max: pt.Add(p.size)}
+}
+
+type xdgSurface struct {
+	resource
+	wm      *wmBase
+	surface *surface
+
+	toplevel *toplevel
+	popup    *popup
+
+	// configured is set once the client acked a configure, mapped once
+	// it then committed a buffer.
+	configureSerial uint32
+	configured      bool
+	initialCommit   bool
+	mapped          bool
+
+	hasGeometry bool
+	geometry    image.Rectangle
+
+	// x and y are the position of the window geometry in the global
+	// coordinate space.
+	x, y int
+}
+
+func (xs *xdgSurface) dispatch(opcode uint16, d *decoder) error {
+	switch opcode {
+	case 0: // destroy
+		if xs.toplevel != nil || xs.popup != nil {
+			return xs.wm.errorf(wmBaseErrorDefunctSurfaces, "%s destroyed before its role object", xs)
+		}
+		xs.c.destroy(xs)
+		return nil
+
+	case 1: // get_toplevel
+		id := d.newID()
+		if d.Err != nil {
+			return d.Err
+		}
+		if xs.toplevel != nil || xs.popup != nil {
+			return xs.errorf(xdgSurfaceErrorAlreadyConstructed, "%s already has a role object", xs)
+		}
+		xs.toplevel = &toplevel{
+			resource: resource{c: xs.c, id: id, iface: "xdg_toplevel", version: xs.version},
+			xdg:      xs,
+		}
+		xs.c.add(xs.toplevel)
+		return nil
+
+	case 2: // get_popup
+		id := d.newID()
+		parentObj := d.object()
+		p, _ := d.object().(*positioner)
+		if d.Err != nil {
+			return d.Err
+		}
+		if xs.toplevel != nil || xs.popup != nil {
+			return xs.errorf(xdgSurfaceErrorAlreadyConstructed, "%s already has a role object", xs)
+		}
+		if p == nil || p.size == (image.Point{}) || !p.hasAnchorRect {
+			return xs.wm.errorf(wmBaseErrorInvalidPositioner, "incomplete positioner")
+		}
+		parent, _ := parentObj.(*xdgSurface)
+		if parentObj != nil && parent == nil {
+			return xs.wm.errorf(wmBaseErrorInvalidPopupParent, "popup parent must be an xdg_surface")
+		}
+		xs.popup = &popup{
+			resource: resource{c: xs.c, id: id, iface: "xdg_popup", version: xs.version},
+			xdg:      xs,
+			parent:   parent,
+			geometry: p.geometry(),
+		}
+		xs.c.add(xs.popup)
+		return nil
+
+	case 3: // set_window_geometry
+		x, y, w, h := d.Int32(), d.Int32(), d.Int32(), d.Int32()
+		if d.Err != nil {
+			return d.Err
+		}
+		if w <= 0 || h <= 0 {
+			return xs.wm.errorf(wmBaseErrorInvalidSurfaceState, "invalid window geometry size %dx%d", w, h)
+		}
+		xs.surface.pending.hasGeometry = true
+		xs.surface.pending.geometry = image.Rect(int(x), int(y), int(x+w), int(y+h))
+		return nil
+
+	case 4: // ack_configure
+		serial := d.Uint32()
+		if d.Err != nil {
+			return d.Err
+		}
+		if serial == 0 || serial > xs.configureSerial {
+			return xs.errorf(xdgSurfaceErrorInvalidSerial, "invalid configure serial %d", serial)
+		}
+		xs.configured = true
+		return nil
+	}
+	return xs.invalidOpcode(opcode)
+}
+
+// precommit validates a commit of the wl_surface.
+func (xs *xdgSurface) precommit() error {
+	if xs.toplevel == nil && xs.popup == nil {
+		return xs.errorf(xdgSurfaceErrorNotConstructed, "%s committed without a role object", xs)
+	}
+	if xs.surface.pending.buffer != nil && !xs.configured {
+		return xs.errorf(xdgSurfaceErrorUnconfiguredBuffer, "%s committed a buffer before acking a configure", xs)
+	}
+	return nil
+}
+
+// committed maps and unmaps the window after a commit.
+func (xs *xdgSurface) committed() error {
+	comp := xs.c.comp
+
+	if !xs.initialCommit {
+		xs.initialCommit = true
+		xs.sendConfigure()
+		return nil
+	}
+
+	switch {
+	case xs.surface.content == nil && xs.mapped:
+		xs.unmap()
+		xs.initialCommit = false
+		xs.configured = false
+
+	case xs.surface.content != nil && !xs.mapped:
+		xs.mapped = true
+		if xs.toplevel != nil {
+			comp.placeToplevel(xs)
+		} else if xs.popup.parent != nil {
+			parent := xs.popup.parent
+			xs.x = parent.x + xs.popup.geometry.Min.X
+			xs.y = parent.y + xs.popup.geometry.Min.Y
+		}
+		comp.windows = append(comp.windows, xs)
+		if xs.toplevel != nil {
+			comp.setFocus(xs)
+		}
+		comp.updatePointer()
+	}
+
+	if xs.mapped {
+		xs.surface.enterOutputs(comp.outputsAt(xs.bounds()))
+	}
+	return nil
+}
+
+// bounds returns the area covered by the surface tree, in global
+// coordinates.
+func (xs *xdgSurface) bounds() image.Rectangle {
+	return xs.surface.bounds().Add(xs.origin())
+}
+
+// origin returns the position of the surface in global coordinates.
+func (xs *xdgSurface) origin() image.Point {
+	p := image.Pt(xs.x, xs.y)
+	if xs.hasGeometry {
+		p = p.Sub(xs.geometry.Min)
+	}
+	return p
+}
+
+// windowSize returns the size of the window geometry.
+func (xs *xdgSurface) windowSize() image.Point {
+	if xs.hasGeometry {
+		return xs.geometry.Size()
+	}
+	return xs.surface.bounds().Size()
+}
+
+func (xs *xdgSurface) sendConfigure() {
+	if xs.toplevel != nil {
+		xs.toplevel.sendConfigure()
+	} else if xs.popup != nil {
+		g := xs.popup.geometry
+		xs.popup.send(0, int32(g.Min.X), int32(g.Min.Y), int32(g.Dx()), int32(g.Dy()))
+	}
+	xs.configureSerial = xs.c.comp.nextSerial()
+	xs.send(0, xs.configureSerial)
+}
+
+func (xs *xdgSurface) unmap() {
+	comp := xs.c.comp
+	if !xs.mapped {
+		return
+	}
+	xs.mapped = false
+
+	for i, w := range comp.windows {
+		if w == xs {
+			comp.windows = append(comp.windows[:i], comp.windows[i+1:]...)
+			break
+		}
+	}
+	// popups go away along with their parent
+	for _, w := range append([]*xdgSurface(nil), comp.windows...) {
+		if w.popup != nil && w.popup.parent == xs {
+			w.popup.dismiss()
+		}
+	}
+	xs.surface.enterOutputs(nil)
+
+	if comp.focus == xs {
+		comp.setFocus(nil)
+	}
+	if comp.focus == nil {
+		for i := len(comp.windows) - 1; i >= 0; i-- {
+			if comp.windows[i].toplevel != nil {
+				comp.setFocus(comp.windows[i])
+				break
+			}
+		}
+	}
+	comp.updatePointer()
+	comp.dirty = true
+}
+
+func (xs *xdgSurface) surfaceDestroyed() {
+	xs.unmap()
+	xs.surface = nil
+}
+
+func (xs *xdgSurface) destroy() {
+	xs.wm.surfaces--
+	if xs.surface != nil {
+		xs.unmap()
+		xs.surface.xdg = nil
+	}
+}
+
+type toplevel struct {
+	resource
+	xdg *xdgSurface
+
+	title, appID string
+	maximized    bool
+	fullscreen   bool
+	// announced is set once configure_bounds and wm_capabilities were
+	// sent, they precede the first configure.
+	announced bool
+}
+
+func (t *toplevel) dispatch(opcode uint16, d *decoder) error {
+	switch opcode {
+	case 0: // destroy
+		t.c.destroy(t)
+		return nil
+
+	case 1: // set_parent
+		d.object()
+		return d.Err
+
+	case 2: // set_title
+		t.title, _ = d.String()
+		return d.Err
+
+	case 3: // set_app_id
+		t.appID, _ = d.String()
+		return d.Err
+
+	case 4: // show_window_menu
+		d.object()
+		d.Uint32()
+		d.Int32()
+		d.Int32()
+		return d.Err
+
+	case 5: // move
+		d.object()
+		d.Uint32()
+		return d.Err
+
+	case 6: // resize
+		d.object()
+		d.Uint32()
+		d.Uint32()
+		return d.Err
+
+	case 7, 8: // set_max_size, set_min_size
+		w, h := d.Int32(), d.Int32()
+		if d.Err != nil {
+			return d.Err
+		}
+		if w < 0 || h < 0 {
+			return t.errorf(0, "invalid size %dx%d", w, h)
+		}
+		return nil
+
+	case 9, 10: // set_maximized, unset_maximized
+		t.maximized = opcode == 9
+		t.reconfigure()
+		return nil
+
+	case 11: // set_fullscreen
+		d.object()
+		if d.Err != nil {
+			return d.Err
+		}
+		t.fullscreen = true
+		t.reconfigure()
+		return nil
+
+	case 12: // unset_fullscreen
+		t.fullscreen = false
+		t.reconfigure()
+		return nil
+
+	case 13: // set_minimized
+		return nil
+	}
+	return t.invalidOpcode(opcode)
+}
+
+// reconfigure sends a new configure if the initial one was sent.
+func (t *toplevel) reconfigure() {
+	if t.xdg.initialCommit {
+		t.xdg.sendConfigure()
+	}
+}
+
+func (t *toplevel) sendConfigure() {
+	comp := t.c.comp
+
+	var width, height int32
+	var states []uint32
+	if t.fullscreen || t.maximized {
+		if o := comp.primaryOutput(); o != nil {
+			width, height = o.logicalWidth(), o.logicalHeight()
+		}
+		if t.fullscreen {
+			states = append(states, toplevelStateFullscreen)
+		} else {
+			states = append(states, toplevelStateMaximized)
+		}
+	}
+	if comp.focus == t.xdg {
+		states = append(states, toplevelStateActivated)
+	}
+
+	if !t.announced {
+		t.announced = true
+		if t.version >= 4 {
+			if o := comp.primaryOutput(); o != nil {
+				t.send(2, o.logicalWidth(), o.logicalHeight()) // configure_bounds
+			}
+		}
+		if t.version >= 5 {
+			t.send(3, wlclient.ArrayBytes([]uint32{wmCapabilityMaximize, wmCapabilityFullscreen})) // wm_capabilities
+		}
+	}
+	t.send(0, width, height, wlclient.ArrayBytes(states))
+}
+
+func (t *toplevel) destroy() {
+	t.xdg.unmap()
+	t.xdg.toplevel = nil
+	t.xdg.initialCommit = false
+	t.xdg.configured = false
+}
+
+type popup struct {
+	resource
+	xdg      *xdgSurface
+	parent   *xdgSurface
+	geometry image.Rectangle
+	done     bool
+}
+
+func (p *popup) dispatch(opcode uint16, d *decoder) error {
+	switch opcode {
+	case 0: // destroy
+		p.c.destroy(p)
+		return nil
+
+	case 1: // grab
+		d.object()
+		d.Uint32()
+		return d.Err
+
+	case 2: // reposition
+		pos, _ := d.object().(*positioner)
+		token := d.Uint32()
+		if d.Err != nil {
+			return d.Err
+		}
+		if pos == nil {
+			return p.xdg.wm.errorf(wmBaseErrorInvalidPositioner, "reposition needs an xdg_positioner")
+		}
+		p.geometry = pos.geometry()
+		p.send(2, token) // repositioned
+		p.xdg.sendConfigure()
+		if p.xdg.mapped && p.parent != nil {
+			p.xdg.x = p.parent.x + p.geometry.Min.X
+			p.xdg.y = p.parent.y + p.geometry.Min.Y
+			p.c.comp.dirty = true
+		}
+		return nil
+	}
+	return p.invalidOpcode(opcode)
+}
+
+// dismiss unmaps the popup and tells the client it is gone.
+func (p *popup) dismiss() {
+	if p.done {
+		return
+	}
+	p.done = true
+	p.xdg.unmap()
+	p.send(1) // popup_done
+}
+
+func (p *popup) destroy() {
+	p.xdg.unmap()
+	p.xdg.popup = nil
+	p.xdg.initialCommit = false
+	p.xdg.configured = false
+}
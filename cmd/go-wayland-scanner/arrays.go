@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// arrayMapping types the elements of an array argument.
+//
+// It is written as one line of whitespace separated fields:
+//
+//	interface.message.arg type
+//
+// Type is a Go integer type or the name of an enum, qualified with its
+// interface when it belongs to another one.
+type arrayMapping struct {
+	arg  string
+	elem string
+}
+
+// defaultArrayMappings are used after the ones given with -array and
+// -arrays.
+var defaultArrayMappings = []arrayMapping{
+	{arg: "wl_keyboard.enter.keys", elem: "uint32"},
+	{arg: "xdg_toplevel.configure.states", elem: "state"},
+	{arg: "xdg_toplevel.wm_capabilities.capabilities", elem: "wm_capabilities"},
+	{arg: "zxdg_toplevel_v6.configure.states", elem: "state"},
+	{arg: "zwp_linux_dmabuf_feedback_v1.tranche_formats.indices", elem: "uint16"},
+	{arg: "zwp_tablet_pad_group_v2.buttons.buttons", elem: "uint32"},
+}
+
+var arrayMappings []arrayMapping
+
+// arrayElementTypes are the Go types the elements can be decoded to.
+var arrayElementTypes = map[string]bool{
+	"int8": true, "uint8": true,
+	"int16": true, "uint16": true,
+	"int32": true, "uint32": true,
+	"int64": true, "uint64": true,
+}
+
+func parseArrayMapping(s string) (arrayMapping, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 || strings.Count(fields[0], ".") != 2 {
+		return arrayMapping{}, fmt.Errorf("invalid array mapping %q, want \"interface.message.arg type\"", s)
+	}
+	return arrayMapping{arg: fields[0], elem: fields[1]}, nil
+}
+
+// arrayFlag adds the mapping of each -array flag.
+type arrayFlag struct{}
+
+func (arrayFlag) String() string {
+	return ""
+}
+
+func (arrayFlag) Set(s string) error {
+	m, err := parseArrayMapping(s)
+	if err != nil {
+		return err
+	}
+	arrayMappings = append(arrayMappings, m)
+	return nil
+}
+
+// loadArrayMappings reads a file of array mappings, one per line.
+// Blank lines and lines starting with '#' are ignored.
+func loadArrayMappings(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		m, err := parseArrayMapping(line)
+		if err != nil {
+			return fmt.Errorf("%s:%d: %w", file, n, err)
+		}
+		arrayMappings = append(arrayMappings, m)
+	}
+	return scanner.Err()
+}
+
+// arrayElemType returns the Go type of the elements of an array
+// argument, or "" when the argument stays a []byte.
+func arrayElemType(iface string, message string, arg Arg) string {
+	key := iface + "." + message + "." + arg.Name
+	for _, mappings := range [][]arrayMapping{arrayMappings, defaultArrayMappings} {
+		for _, m := range mappings {
+			if m.arg != key {
+				continue
+			}
+			if arrayElementTypes[m.elem] {
+				return m.elem
+			}
+			enum := m.elem
+			if !strings.Contains(enum, ".") {
+				enum = iface + "." + enum
+			}
+			return argType(Arg{Type: "uint", Enum: enum})
+		}
+	}
+	return ""
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// defaultDestructorEvents are events after which the server considers
+// their object destroyed, although the protocol XML doesn't mark them
+// with type="destructor". They are used after the ones given with
+// -destructor-event and -destructor-events.
+var defaultDestructorEvents = []string{
+	"wl_callback.done",
+	"wp_presentation_feedback.presented",
+	"wp_presentation_feedback.discarded",
+	"zwp_linux_buffer_release_v1.fenced_release",
+	"zwp_linux_buffer_release_v1.immediate_release",
+}
+
+var destructorEvents []string
+
+func parseDestructorEvent(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if strings.Count(s, ".") != 1 || strings.ContainsAny(s, " \t") {
+		return "", fmt.Errorf("invalid destructor event %q, want \"interface.event\"", s)
+	}
+	return s, nil
+}
+
+// destructorFlag adds the event of each -destructor-event flag.
+type destructorFlag struct{}
+
+func (destructorFlag) String() string {
+	return ""
+}
+
+func (destructorFlag) Set(s string) error {
+	e, err := parseDestructorEvent(s)
+	if err != nil {
+		return err
+	}
+	destructorEvents = append(destructorEvents, e)
+	return nil
+}
+
+// loadDestructorEvents reads a file of destructor events, one per line.
+// Blank lines and lines starting with '#' are ignored.
+func loadDestructorEvents(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		e, err := parseDestructorEvent(line)
+		if err != nil {
+			return fmt.Errorf("%s:%d: %w", file, n, err)
+		}
+		destructorEvents = append(destructorEvents, e)
+	}
+	return scanner.Err()
+}
+
+// markDestructorEvents sets type="destructor" on the configured events,
+// so the rest of the generator only has to look at the event type.
+func markDestructorEvents() {
+	for i := range protocol.Interfaces {
+		v := &protocol.Interfaces[i]
+		for j := range v.Events {
+			e := &v.Events[j]
+			key := v.Name + "." + e.Name
+			for _, list := range [][]string{destructorEvents, defaultDestructorEvents} {
+				for _, d := range list {
+					if d == key {
+						e.Type = "destructor"
+					}
+				}
+			}
+		}
+	}
+}
@@ -1,9 +1,11 @@
 module github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner
 
-go 1.19
+go 1.21
 
 require (
 	github.com/iancoleman/strcase v0.2.0
+	github.com/rajveermalviya/go-wayland/wayland v0.0.0-20261018214816-a5b246148021
+	golang.org/x/sys v0.4.0
 	golang.org/x/tools v0.5.0
 	mvdan.cc/gofumpt v0.4.0
 )
@@ -11,5 +13,4 @@ require (
 require (
 	github.com/google/go-cmp v0.5.8 // indirect
 	golang.org/x/mod v0.7.0 // indirect
-	golang.org/x/sys v0.4.0 // indirect
 )
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+const clientImportPath = "github.com/rajveermalviya/go-wayland/wayland/client"
+
+// importMapping maps interfaces of other protocols to the Go package
+// they are generated in.
+//
+// It is written as one line of whitespace separated fields:
+//
+//	pattern package import/path [prefix [suffix]]
+//
+// Pattern is an interface name, or a prefix of interface names ending
+// with '*'. Prefix and suffix are the ones the package was generated
+// with, they are trimmed from interface names to get the Go type names.
+type importMapping struct {
+	pattern string
+	pkg     string
+	path    string
+	prefix  string
+	suffix  string
+}
+
+// defaultImportMappings are used after the ones given with -import and
+// -imports.
+var defaultImportMappings = []importMapping{
+	{pattern: "wl_*", pkg: "client", path: clientImportPath, prefix: "wl"},
+	{pattern: "xdg_*", pkg: "xdg_shell", path: "github.com/rajveermalviya/go-wayland/wayland/stable/xdg-shell", prefix: "xdg"},
+}
+
+var (
+	importMappings []importMapping
+	// usedImports holds the package name of each import path the
+	// generated code refers to.
+	usedImports = map[string]string{}
+)
+
+func parseImportMapping(s string) (importMapping, error) {
+	fields := strings.Fields(s)
+	if len(fields) < 3 || len(fields) > 5 {
+		return importMapping{}, fmt.Errorf("invalid import mapping %q, want \"pattern package import/path [prefix [suffix]]\"", s)
+	}
+
+	m := importMapping{pattern: fields[0], pkg: fields[1], path: fields[2]}
+	if len(fields) > 3 {
+		m.prefix = fields[3]
+	}
+	if len(fields) > 4 {
+		m.suffix = fields[4]
+	}
+	return m, nil
+}
+
+// importFlag adds the mapping of each -import flag.
+type importFlag struct{}
+
+func (importFlag) String() string {
+	return ""
+}
+
+func (importFlag) Set(s string) error {
+	m, err := parseImportMapping(s)
+	if err != nil {
+		return err
+	}
+	importMappings = append(importMappings, m)
+	return nil
+}
+
+// loadImportMappings reads a file of import mappings, one per line.
+// Blank lines and lines starting with '#' are ignored.
+func loadImportMappings(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		m, err := parseImportMapping(line)
+		if err != nil {
+			return fmt.Errorf("%s:%d: %w", file, n, err)
+		}
+		importMappings = append(importMappings, m)
+	}
+	return scanner.Err()
+}
+
+// lookupImportMapping returns the mapping of a foreign interface. Exact
+// names win over patterns and longer patterns over shorter ones, the
+// mappings given by the user over the default ones.
+func lookupImportMapping(iface string) (importMapping, bool) {
+	for _, mappings := range [][]importMapping{importMappings, defaultImportMappings} {
+		best := -1
+		for i, m := range mappings {
+			if m.pattern == iface {
+				return m, true
+			}
+			if !strings.HasSuffix(m.pattern, "*") || !strings.HasPrefix(iface, strings.TrimSuffix(m.pattern, "*")) {
+				continue
+			}
+			if best == -1 || len(m.pattern) > len(mappings[best].pattern) {
+				best = i
+			}
+		}
+		if best != -1 {
+			return mappings[best], true
+		}
+	}
+	return importMapping{}, false
+}
+
+// foreignType returns the qualified Go type name of an interface from
+// another protocol and records the import it needs.
+func foreignType(iface string) string {
+	m, ok := lookupImportMapping(iface)
+	if !ok {
+		log.Fatalf("interface %s is not defined in %s, map it to its Go package with -import or -imports", iface, protocol.Name)
+	}
+
+	useImport(m.pkg, m.path)
+	return m.pkg + "." + toCamelTrim(iface, m.prefix, m.suffix)
+}
+
+// foreignEnumType returns the qualified Go type name of an enum of an
+// interface from another protocol.
+func foreignEnumType(iface string, enum string) string {
+	m, _ := lookupImportMapping(iface)
+	return foreignType(iface) + toCamelTrim(enum, m.prefix, m.suffix)
+}
+
+// ifaceType returns the Go type name of an interface, qualified when it
+// comes from another protocol.
+func ifaceType(iface string) string {
+	if isLocalInterface(iface) {
+		return toCamel(iface)
+	}
+	return foreignType(iface)
+}
+
+// ifaceConstructor returns the New function of an interface.
+func ifaceConstructor(iface string) string {
+	t := ifaceType(iface)
+	if i := strings.LastIndexByte(t, '.'); i != -1 {
+		return t[:i+1] + "New" + t[i+1:]
+	}
+	return "New" + t
+}
+
+// ifaceAPI returns the API interface of an interface, generated with -api.
+func ifaceAPI(iface string) string {
+	return ifaceType(iface) + "API"
+}
+
+// ifaceFake returns the fake of an interface, generated with -api.
+func ifaceFake(iface string) string {
+	t := ifaceType(iface)
+	if i := strings.LastIndexByte(t, '.'); i != -1 {
+		return t[:i+1] + "Fake" + t[i+1:]
+	}
+	return "Fake" + t
+}
+
+func useImport(pkg, importPath string) {
+	usedImports[importPath] = pkg
+}
+
+// importSpecs returns the specs of the imports recorded while generating
+// the code, sorted by path.
+func importSpecs() []string {
+	paths := make([]string, 0, len(usedImports))
+	for p := range usedImports {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	specs := make([]string, len(paths))
+	for i, p := range paths {
+		specs[i] = importSpec(p)
+	}
+	return specs
+}
+
+func importSpec(p string) string {
+	if pkg := usedImports[p]; pkg != path.Base(p) {
+		return fmt.Sprintf("%s %q", pkg, p)
+	}
+	return fmt.Sprintf("%q", p)
+}
@@ -0,0 +1,167 @@
+// Generated by go-wayland-scanner
+// https://github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner
+// XML file : testdata/fixed.xml
+//
+// fixed Protocol Copyright:
+//
+// Protocol of the go-wayland-scanner tests of -fixed, in the public domain.
+
+package fixed
+
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+)
+
+// FixedPointer : sends fixed arguments
+//
+// Generated with -fixed, the arguments are client.Fixed.
+type FixedPointer struct {
+	client.BaseProxy
+	motionHandler FixedPointerMotionHandlerFunc
+}
+
+// NewFixedPointer : sends fixed arguments
+//
+// Generated with -fixed, the arguments are client.Fixed.
+func NewFixedPointer(ctx *client.Context) *FixedPointer {
+	fixedPointer := &FixedPointer{}
+	ctx.Register(fixedPointer)
+	return fixedPointer
+}
+
+// FixedPointerInterface describes fixed_pointer at runtime.
+var FixedPointerInterface = &client.Interface{
+	Name:    "fixed_pointer",
+	Version: 1,
+	New:     func() client.Proxy { return &FixedPointer{} },
+	Requests: []client.Message{
+		{
+			Name: "warp",
+			Args: []client.Arg{
+				{Name: "x", Type: "fixed"},
+				{Name: "y", Type: "fixed"},
+			},
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "motion",
+			Args: []client.Arg{
+				{Name: "time", Type: "uint"},
+				{Name: "x", Type: "fixed"},
+				{Name: "y", Type: "fixed"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of fixed_pointer.
+func (i *FixedPointer) Interface() *client.Interface {
+	return FixedPointerInterface
+}
+
+// Warp : move the pointer
+//
+//	x: surface-local x
+//	y: surface-local y
+func (i *FixedPointer) Warp(x, y client.Fixed) error {
+	const opcode = 0
+	const _reqBufLen = 8 + 4 + 4
+	var _reqBuf [_reqBufLen]byte
+	l := 0
+	client.PutUint32(_reqBuf[l:4], i.ID())
+	l += 4
+	client.PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
+	l += 4
+	client.PutUint32(_reqBuf[l:l+4], uint32(x))
+	l += 4
+	client.PutUint32(_reqBuf[l:l+4], uint32(y))
+	l += 4
+	err := i.Context().WriteMsg(_reqBuf[:], nil)
+	return err
+}
+
+func (i *FixedPointer) Destroy() error {
+	i.Context().Unregister(i)
+	return nil
+}
+
+// FixedPointerMotionEvent : pointer moved
+type FixedPointerMotionEvent struct {
+	Time uint32
+	X    client.Fixed
+	Y    client.Fixed
+}
+type FixedPointerMotionHandlerFunc func(FixedPointerMotionEvent)
+
+// SetMotionHandler : sets handler for FixedPointerMotionEvent
+func (i *FixedPointer) SetMotionHandler(f FixedPointerMotionHandlerFunc) {
+	i.motionHandler = f
+}
+
+// String formats the event as fixed_pointer.motion(argument=value, ...).
+func (e FixedPointerMotionEvent) String() string {
+	return "fixed_pointer.motion(" +
+		"time=" + strconv.FormatUint(uint64(e.Time), 10) +
+		", x=" + e.X.String() +
+		", y=" + e.Y.String() +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e FixedPointerMotionEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("time", uint64(e.Time)),
+		slog.Float64("x", e.X.Float64()),
+		slog.Float64("y", e.Y.Float64()),
+	)
+}
+
+// FixedPointerListener receives all the events of FixedPointer, embed
+// FixedPointerNoopListener to implement only some of them.
+type FixedPointerListener interface {
+	Motion(e FixedPointerMotionEvent)
+}
+
+// FixedPointerNoopListener is a FixedPointerListener ignoring
+// all the events, it closes the file descriptors they carry.
+type FixedPointerNoopListener struct{}
+
+func (FixedPointerNoopListener) Motion(FixedPointerMotionEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *FixedPointer) SetListener(l FixedPointerListener) {
+	if l == nil {
+		i.motionHandler = nil
+		return
+	}
+	i.motionHandler = l.Motion
+}
+
+func (i *FixedPointer) Dispatch(opcode uint32, fd int, data []byte) {
+	switch opcode {
+	case 0:
+		if i.motionHandler == nil {
+			return
+		}
+		var e FixedPointerMotionEvent
+		l := 0
+		e.Time = client.Uint32(data[l : l+4])
+		l += 4
+		e.X = client.Fixed(client.Uint32(data[l : l+4]))
+		l += 4
+		e.Y = client.Fixed(client.Uint32(data[l : l+4]))
+		l += 4
+
+		i.motionHandler(e)
+	}
+}
+
+func init() {
+	client.RegisterInterface(FixedPointerInterface)
+}
@@ -0,0 +1,52 @@
+package fixed_test
+
+import (
+	"testing"
+
+	"github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner/internal/fixed"
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+	"github.com/rajveermalviya/go-wayland/wayland/waylandtest"
+)
+
+func TestFixed(t *testing.T) {
+	s := waylandtest.NewServer(t)
+	s.RegisterInterface(&waylandtest.Interface{
+		Name:     "fixed_pointer",
+		Version:  1,
+		Requests: []waylandtest.Message{{Name: "warp", Signature: "ff"}},
+		Events:   []waylandtest.Message{{Name: "motion", Signature: "uff"}},
+	})
+	s.AddGlobal("fixed_pointer", 1)
+
+	registry, err := s.Display().GetRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pointer *fixed.FixedPointer
+	registry.SetGlobalHandler(func(e client.RegistryGlobalEvent) {
+		pointer, _ = client.Bind[fixed.FixedPointer](registry, e.Name, e.Version)
+	})
+	s.Roundtrip()
+	if pointer == nil {
+		t.Fatal("fixed_pointer was not bound")
+	}
+	s.Expect("wl_display", "get_registry", registry)
+	s.Expect("wl_registry", "bind", waylandtest.Any, "fixed_pointer", uint32(1), pointer)
+
+	if err := pointer.Warp(client.Fixed(384), client.MinFixed); err != nil {
+		t.Fatal(err)
+	}
+	s.Expect("fixed_pointer", "warp", 1.5, -8388608.0)
+
+	var got fixed.FixedPointerMotionEvent
+	pointer.SetMotionHandler(func(e fixed.FixedPointerMotionEvent) {
+		got = e
+	})
+	s.Send(pointer, "motion", 7, -3.25, client.MaxFixed)
+	s.Roundtrip()
+
+	want := fixed.FixedPointerMotionEvent{Time: 7, X: -832, Y: client.MaxFixed}
+	if got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,144 @@
+package fixture_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner/internal/fixture"
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+	"github.com/rajveermalviya/go-wayland/wayland/protocol"
+)
+
+// configure is code under test, written against the API interfaces.
+func configure(m fixture.FixtureManagerAPI) (fixture.FixtureThingAPI, error) {
+	thing, err := m.CreateThing(fixture.FixtureThingFlagsA | fixture.FixtureThingFlagsC)
+	if err != nil {
+		return nil, err
+	}
+	if err := thing.SetFlags(fixture.FixtureThingFlagsB); err != nil {
+		return nil, err
+	}
+	if err := m.Objects(thing, nil, nil); err != nil {
+		return nil, err
+	}
+	m.SetScalarsHandler(func(e fixture.FixtureManagerScalarsEvent) {
+		m.Scalars(e.Number+1, e.Count, e.Scale, e.Label, e.Mode)
+	})
+	return thing, nil
+}
+
+func TestFake(t *testing.T) {
+	m := &fixture.FakeFixtureManager{}
+
+	thing, err := configure(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.EmitScalars(fixture.FixtureManagerScalarsEvent{Number: 1, Label: "label", Mode: fixture.FixtureManagerModeAuto})
+	if err := m.Destroy(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []client.FakeCall{
+		{Request: "create_thing", Args: []any{thing, fixture.FixtureThingFlagsA | fixture.FixtureThingFlagsC}},
+		{Request: "objects", Args: []any{thing, fixture.FixtureThingAPI(nil), client.SurfaceAPI(nil)}},
+		{Request: "scalars", Args: []any{int32(2), uint32(0), 0.0, "label", fixture.FixtureManagerModeAuto}},
+		{Request: "destroy"},
+	}
+	if !reflect.DeepEqual(m.Calls, want) {
+		t.Errorf("got calls %v, want %v", m.Calls, want)
+	}
+	if !m.Destroyed {
+		t.Error("destroy request didn't mark the fake destroyed")
+	}
+
+	// The created object is a fake too
+	fake, ok := thing.(*fixture.FakeFixtureThing)
+	if !ok {
+		t.Fatalf("create_thing returned a %T, want a *FakeFixtureThing", thing)
+	}
+	if err := thing.Destroy(); err != nil {
+		t.Fatal(err)
+	}
+	wantThing := []client.FakeCall{
+		{Request: "set_flags", Args: []any{fixture.FixtureThingFlagsB}},
+		{Request: "destroy"},
+	}
+	if !reflect.DeepEqual(fake.Calls, wantThing) {
+		t.Errorf("got thing calls %v, want %v", fake.Calls, wantThing)
+	}
+}
+
+func TestAPI(t *testing.T) {
+	p, err := protocol.Load("../../testdata/fixture.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := setup(t, p)
+
+	// The same code sends requests through the API of the proxies
+	thing, err := configure(e.manager.API())
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy, err := client.APIProxy[*fixture.FixtureThing](thing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.s.Expect("fixture_manager", "create_thing", proxy, fixture.FixtureThingFlagsA|fixture.FixtureThingFlagsC)
+	e.s.Expect("fixture_thing", "set_flags", fixture.FixtureThingFlagsB)
+	e.s.Expect("fixture_manager", "objects", proxy, nil, nil)
+
+	if err := thing.Destroy(); err != nil {
+		t.Fatal(err)
+	}
+	e.s.Expect("fixture_thing", "destroy")
+
+	// Fakes can't be sent to the compositor
+	if err := e.manager.API().Objects(&fixture.FakeFixtureThing{}, nil, nil); err == nil {
+		t.Error("a fake was sent as an object argument")
+	}
+}
+
+func TestFakeDestructorEvent(t *testing.T) {
+	thing := &fixture.FakeFixtureThing{}
+
+	var reason uint32
+	thing.SetDoneHandler(func(e fixture.FixtureThingDoneEvent) {
+		reason = e.Reason
+	})
+	thing.EmitDone(fixture.FixtureThingDoneEvent{Reason: 3})
+
+	if reason != 3 {
+		t.Errorf("got reason %d, want 3", reason)
+	}
+	if !thing.Destroyed {
+		t.Error("destructor event didn't mark the fake destroyed")
+	}
+}
+
+// TestFakeBind checks that fakes can be bound, as they are proxies, and
+// that the generated Destroy returns Err like the requests.
+func TestFakeBind(t *testing.T) {
+	registry := &client.FakeRegistry{}
+	thing := &fixture.FakeFixtureThing{}
+	if err := registry.Bind(3, "fixture_thing", 1, thing); err != nil {
+		t.Fatal(err)
+	}
+	want := []client.FakeCall{
+		{Request: "bind", Args: []any{uint32(3), "fixture_thing", uint32(1), thing}},
+	}
+	if !reflect.DeepEqual(registry.Calls, want) {
+		t.Errorf("got calls %v, want %v", registry.Calls, want)
+	}
+
+	errFake := errors.New("fake error")
+	callback := &client.FakeCallback{Err: errFake}
+	if err := callback.Destroy(); err != errFake {
+		t.Errorf("got error %v, want %v", err, errFake)
+	}
+	if !callback.Destroyed {
+		t.Error("Destroy didn't mark the fake destroyed")
+	}
+}
@@ -0,0 +1,1173 @@
+// Generated by go-wayland-scanner
+// https://github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner
+// XML file : testdata/fixture.xml
+//
+// fixture Protocol Copyright:
+//
+// Fixture protocol of the go-wayland-scanner tests, in the public domain.
+
+package fixture
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+	"golang.org/x/sys/unix"
+)
+
+// FixtureManager : exercises every argument type
+//
+// Every request of fixture_manager has a matching event with the same
+// arguments, so the tests can send values in both directions.
+type FixtureManager struct {
+	client.BaseProxy
+	scalarsHandler     FixtureManagerScalarsHandlerFunc
+	objectsHandler     FixtureManagerObjectsHandlerFunc
+	arraysHandler      FixtureManagerArraysHandlerFunc
+	fdHandler          FixtureManagerFdHandlerFunc
+	createThingHandler FixtureManagerCreateThingHandlerFunc
+	bindHandler        FixtureManagerBindHandlerFunc
+	stringsHandler     FixtureManagerStringsHandlerFunc
+}
+
+// NewFixtureManager : exercises every argument type
+//
+// Every request of fixture_manager has a matching event with the same
+// arguments, so the tests can send values in both directions.
+func NewFixtureManager(ctx *client.Context) *FixtureManager {
+	fixtureManager := &FixtureManager{}
+	ctx.Register(fixtureManager)
+	return fixtureManager
+}
+
+// FixtureManagerInterface describes fixture_manager at runtime.
+var FixtureManagerInterface = &client.Interface{
+	Name:    "fixture_manager",
+	Version: 2,
+	New:     func() client.Proxy { return &FixtureManager{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "create_thing",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "fixture_thing"},
+				{Name: "flags", Type: "uint", Enum: "fixture_thing.flags"},
+			},
+		},
+		{
+			Name:            "bind",
+			DeprecatedSince: 2,
+			Args: []client.Arg{
+				{Name: "name", Type: "uint"},
+				{Name: "id", Type: "new_id"},
+			},
+		},
+		{
+			Name: "scalars",
+			Args: []client.Arg{
+				{Name: "number", Type: "int"},
+				{Name: "count", Type: "uint"},
+				{Name: "scale", Type: "fixed"},
+				{Name: "label", Type: "string"},
+				{Name: "mode", Type: "int", Enum: "fixture_manager.mode"},
+			},
+		},
+		{
+			Name: "objects",
+			Args: []client.Arg{
+				{Name: "thing", Type: "object", Interface: "fixture_thing"},
+				{Name: "other", Type: "object", Interface: "fixture_thing", Nullable: true},
+				{Name: "surface", Type: "object", Interface: "wl_surface", Nullable: true},
+			},
+		},
+		{
+			Name:  "arrays",
+			Since: 2,
+			Args: []client.Arg{
+				{Name: "data", Type: "array"},
+				{Name: "keys", Type: "array"},
+				{Name: "after", Type: "uint"},
+			},
+		},
+		{
+			Name:  "send_fd",
+			Since: 2,
+			Args: []client.Arg{
+				{Name: "mime_type", Type: "string"},
+				{Name: "fd", Type: "fd"},
+			},
+		},
+		{
+			Name:  "strings",
+			Since: 2,
+			Args: []client.Arg{
+				{Name: "label", Type: "string"},
+				{Name: "hint", Type: "string", Nullable: true},
+				{Name: "after", Type: "uint"},
+			},
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "scalars",
+			Args: []client.Arg{
+				{Name: "number", Type: "int"},
+				{Name: "count", Type: "uint"},
+				{Name: "scale", Type: "fixed"},
+				{Name: "label", Type: "string"},
+				{Name: "mode", Type: "int", Enum: "fixture_manager.mode"},
+			},
+		},
+		{
+			Name:            "objects",
+			DeprecatedSince: 2,
+			Args: []client.Arg{
+				{Name: "thing", Type: "object", Interface: "fixture_thing"},
+				{Name: "other", Type: "object", Interface: "fixture_thing", Nullable: true},
+			},
+		},
+		{
+			Name:  "arrays",
+			Since: 2,
+			Args: []client.Arg{
+				{Name: "data", Type: "array"},
+				{Name: "keys", Type: "array"},
+				{Name: "after", Type: "uint"},
+			},
+		},
+		{
+			Name:  "fd",
+			Since: 2,
+			Args: []client.Arg{
+				{Name: "fd", Type: "fd"},
+				{Name: "size", Type: "uint"},
+			},
+		},
+		{
+			Name:  "create_thing",
+			Since: 2,
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "fixture_thing"},
+				{Name: "flags", Type: "uint", Enum: "fixture_thing.flags"},
+			},
+		},
+		{
+			Name:  "bind",
+			Since: 2,
+			Args: []client.Arg{
+				{Name: "name", Type: "uint"},
+				{Name: "id", Type: "new_id"},
+			},
+		},
+		{
+			Name:  "strings",
+			Since: 2,
+			Args: []client.Arg{
+				{Name: "label", Type: "string"},
+				{Name: "hint", Type: "string", Nullable: true},
+				{Name: "after", Type: "uint"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "mode",
+			Entries: []client.EnumEntry{
+				{Name: "off", Value: 0, DeprecatedSince: 2},
+				{Name: "on", Value: 1},
+				{Name: "auto", Value: 2},
+			},
+		},
+	},
+}
+
+// Interface returns the description of fixture_manager.
+func (i *FixtureManager) Interface() *client.Interface {
+	return FixtureManagerInterface
+}
+
+// Destroy : destroy the manager
+func (i *FixtureManager) Destroy() error {
+	defer i.Context().Unregister(i)
+	const opcode = 0
+	const _reqBufLen = 8
+	var _reqBuf [_reqBufLen]byte
+	l := 0
+	client.PutUint32(_reqBuf[l:4], i.ID())
+	l += 4
+	client.PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
+	l += 4
+	err := i.Context().WriteMsg(_reqBuf[:], nil)
+	return err
+}
+
+// CreateThing : create a thing
+//
+// Creates a new fixture_thing with the given flags.
+//
+//	flags: initial flags
+func (i *FixtureManager) CreateThing(flags FixtureThingFlags) (*FixtureThing, error) {
+	id := NewFixtureThing(i.Context())
+	id.SetVersion(i.Version())
+	const opcode = 1
+	const _reqBufLen = 8 + 4 + 4
+	var _reqBuf [_reqBufLen]byte
+	l := 0
+	client.PutUint32(_reqBuf[l:4], i.ID())
+	l += 4
+	client.PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
+	l += 4
+	client.PutUint32(_reqBuf[l:l+4], id.ID())
+	l += 4
+	client.PutUint32(_reqBuf[l:l+4], uint32(flags))
+	l += 4
+	err := i.Context().WriteMsg(_reqBuf[:], nil)
+	return id, err
+}
+
+// Bind : bind an object of any interface
+//
+//	name: numeric name
+//
+// Deprecated: fixture_manager.bind is deprecated since version 2.
+func (i *FixtureManager) Bind(name uint32, iface string, version uint32, id client.Proxy) error {
+	id.SetVersion(version)
+	const opcode = 2
+	ifaceLen := client.PaddedLen(len(iface) + 1)
+	_reqBufLen := 8 + 4 + (4 + ifaceLen) + 4 + 4
+	_reqBuf := make([]byte, _reqBufLen)
+	l := 0
+	client.PutUint32(_reqBuf[l:4], i.ID())
+	l += 4
+	client.PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
+	l += 4
+	client.PutUint32(_reqBuf[l:l+4], uint32(name))
+	l += 4
+	client.PutString(_reqBuf[l:l+(4+ifaceLen)], iface)
+	l += (4 + ifaceLen)
+	client.PutUint32(_reqBuf[l:l+4], uint32(version))
+	l += 4
+	client.PutUint32(_reqBuf[l:l+4], id.ID())
+	l += 4
+	err := i.Context().WriteMsg(_reqBuf, nil)
+	return err
+}
+
+// Scalars : send scalar arguments
+//
+//	number: signed integer
+//	count: unsigned integer
+//	scale: fixed point number
+//	label: string
+//	mode: signed enum
+func (i *FixtureManager) Scalars(number int32, count uint32, scale float64, label string, mode FixtureManagerMode) error {
+	const opcode = 3
+	labelLen := client.PaddedLen(len(label) + 1)
+	_reqBufLen := 8 + 4 + 4 + 4 + (4 + labelLen) + 4
+	_reqBuf := make([]byte, _reqBufLen)
+	l := 0
+	client.PutUint32(_reqBuf[l:4], i.ID())
+	l += 4
+	client.PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
+	l += 4
+	client.PutUint32(_reqBuf[l:l+4], uint32(number))
+	l += 4
+	client.PutUint32(_reqBuf[l:l+4], uint32(count))
+	l += 4
+	client.PutFixed(_reqBuf[l:l+4], scale)
+	l += 4
+	client.PutString(_reqBuf[l:l+(4+labelLen)], label)
+	l += (4 + labelLen)
+	client.PutUint32(_reqBuf[l:l+4], uint32(mode))
+	l += 4
+	err := i.Context().WriteMsg(_reqBuf, nil)
+	return err
+}
+
+// Objects : send object arguments
+func (i *FixtureManager) Objects(thing, other *FixtureThing, surface *client.Surface) error {
+	const opcode = 4
+	const _reqBufLen = 8 + 4 + 4 + 4
+	var _reqBuf [_reqBufLen]byte
+	l := 0
+	client.PutUint32(_reqBuf[l:4], i.ID())
+	l += 4
+	client.PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
+	l += 4
+	client.PutUint32(_reqBuf[l:l+4], thing.ID())
+	l += 4
+	if other == nil {
+		client.PutUint32(_reqBuf[l:l+4], 0)
+		l += 4
+	} else {
+		client.PutUint32(_reqBuf[l:l+4], other.ID())
+		l += 4
+	}
+	if surface == nil {
+		client.PutUint32(_reqBuf[l:l+4], 0)
+		l += 4
+	} else {
+		client.PutUint32(_reqBuf[l:l+4], surface.ID())
+		l += 4
+	}
+	err := i.Context().WriteMsg(_reqBuf[:], nil)
+	return err
+}
+
+// Arrays : send array arguments
+//
+//	data: raw bytes
+//	keys: typed elements
+//	after: follows the arrays
+func (i *FixtureManager) Arrays(data []byte, keys []uint32, after uint32) error {
+	if v := i.Version(); v != 0 && v < 2 {
+		return &client.VersionError{Interface: "fixture_manager", Request: "arrays", Since: 2, Version: v}
+	}
+	const opcode = 5
+	dataLen := client.PaddedLen(len(data))
+	keysArray := client.ArrayBytes(keys)
+	keysLen := client.PaddedLen(len(keysArray))
+	_reqBufLen := 8 + (4 + dataLen) + (4 + keysLen) + 4
+	_reqBuf := make([]byte, _reqBufLen)
+	l := 0
+	client.PutUint32(_reqBuf[l:4], i.ID())
+	l += 4
+	client.PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
+	l += 4
+	client.PutArray(_reqBuf[l:l+(4+dataLen)], data)
+	l += (4 + dataLen)
+	client.PutArray(_reqBuf[l:l+(4+keysLen)], keysArray)
+	l += (4 + keysLen)
+	client.PutUint32(_reqBuf[l:l+4], uint32(after))
+	l += 4
+	err := i.Context().WriteMsg(_reqBuf, nil)
+	return err
+}
+
+// SendFd : send a file descriptor
+func (i *FixtureManager) SendFd(mimeType string, fd int) error {
+	if v := i.Version(); v != 0 && v < 2 {
+		return &client.VersionError{Interface: "fixture_manager", Request: "send_fd", Since: 2, Version: v}
+	}
+	const opcode = 6
+	mimeTypeLen := client.PaddedLen(len(mimeType) + 1)
+	_reqBufLen := 8 + (4 + mimeTypeLen)
+	_reqBuf := make([]byte, _reqBufLen)
+	l := 0
+	client.PutUint32(_reqBuf[l:4], i.ID())
+	l += 4
+	client.PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
+	l += 4
+	client.PutString(_reqBuf[l:l+(4+mimeTypeLen)], mimeType)
+	l += (4 + mimeTypeLen)
+	oob := unix.UnixRights(int(fd))
+	err := i.Context().WriteMsg(_reqBuf, oob)
+	return err
+}
+
+// Strings : send string arguments
+//
+//	label: string
+//	hint: nullable string
+//	after: follows the strings
+func (i *FixtureManager) Strings(label string, hint *string, after uint32) error {
+	if v := i.Version(); v != 0 && v < 2 {
+		return &client.VersionError{Interface: "fixture_manager", Request: "strings", Since: 2, Version: v}
+	}
+	const opcode = 7
+	labelLen := client.PaddedLen(len(label) + 1)
+	var hintLen int
+	if hint != nil {
+		hintLen = client.PaddedLen(len(*hint) + 1)
+	}
+	_reqBufLen := 8 + (4 + labelLen) + (4 + hintLen) + 4
+	_reqBuf := make([]byte, _reqBufLen)
+	l := 0
+	client.PutUint32(_reqBuf[l:4], i.ID())
+	l += 4
+	client.PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
+	l += 4
+	client.PutString(_reqBuf[l:l+(4+labelLen)], label)
+	l += (4 + labelLen)
+	if hint == nil {
+		client.PutUint32(_reqBuf[l:l+4], 0)
+		l += 4
+	} else {
+		client.PutString(_reqBuf[l:l+(4+hintLen)], *hint)
+		l += (4 + hintLen)
+	}
+	client.PutUint32(_reqBuf[l:l+4], uint32(after))
+	l += 4
+	err := i.Context().WriteMsg(_reqBuf, nil)
+	return err
+}
+
+type FixtureManagerMode uint32
+
+// FixtureManagerMode : a regular enum
+const (
+	// Deprecated: fixture_manager.mode.off is deprecated since version 2.
+	FixtureManagerModeOff FixtureManagerMode = 0
+	// FixtureManagerModeOn : turned on
+	FixtureManagerModeOn FixtureManagerMode = 1
+	// FixtureManagerModeAuto : decided by the compositor
+	FixtureManagerModeAuto FixtureManagerMode = 2
+)
+
+func (e FixtureManagerMode) Name() string {
+	switch e {
+	case FixtureManagerModeOff:
+		return "off"
+	case FixtureManagerModeOn:
+		return "on"
+	case FixtureManagerModeAuto:
+		return "auto"
+	default:
+		return ""
+	}
+}
+
+func (e FixtureManagerMode) Value() string {
+	switch e {
+	case FixtureManagerModeOff:
+		return "0"
+	case FixtureManagerModeOn:
+		return "1"
+	case FixtureManagerModeAuto:
+		return "2"
+	default:
+		return ""
+	}
+}
+
+func (e FixtureManagerMode) String() string {
+	return e.Name() + "=" + e.Value()
+}
+
+// FixtureManagerScalarsEvent : scalar arguments
+type FixtureManagerScalarsEvent struct {
+	Number int32
+	Count  uint32
+	Scale  float64
+	Label  string
+	Mode   FixtureManagerMode
+}
+type FixtureManagerScalarsHandlerFunc func(FixtureManagerScalarsEvent)
+
+// SetScalarsHandler : sets handler for FixtureManagerScalarsEvent
+func (i *FixtureManager) SetScalarsHandler(f FixtureManagerScalarsHandlerFunc) {
+	i.scalarsHandler = f
+}
+
+// String formats the event as fixture_manager.scalars(argument=value, ...).
+func (e FixtureManagerScalarsEvent) String() string {
+	return "fixture_manager.scalars(" +
+		"number=" + strconv.FormatInt(int64(e.Number), 10) +
+		", count=" + strconv.FormatUint(uint64(e.Count), 10) +
+		", scale=" + strconv.FormatFloat(e.Scale, 'g', -1, 64) +
+		", label=" + strconv.Quote(e.Label) +
+		", mode=" + client.EnumString(e.Mode.Name(), uint32(e.Mode)) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e FixtureManagerScalarsEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int64("number", int64(e.Number)),
+		slog.Uint64("count", uint64(e.Count)),
+		slog.Float64("scale", e.Scale),
+		slog.String("label", e.Label),
+		slog.String("mode", client.EnumString(e.Mode.Name(), uint32(e.Mode))),
+	)
+}
+
+// FixtureManagerObjectsEvent : object arguments
+//
+// Deprecated: fixture_manager.objects is deprecated since version 2.
+type FixtureManagerObjectsEvent struct {
+	Thing *FixtureThing
+	Other *FixtureThing
+}
+type FixtureManagerObjectsHandlerFunc func(FixtureManagerObjectsEvent)
+
+// SetObjectsHandler : sets handler for FixtureManagerObjectsEvent
+//
+// Deprecated: fixture_manager.objects is deprecated since version 2.
+func (i *FixtureManager) SetObjectsHandler(f FixtureManagerObjectsHandlerFunc) {
+	i.objectsHandler = f
+}
+
+// String formats the event as fixture_manager.objects(argument=value, ...).
+func (e FixtureManagerObjectsEvent) String() string {
+	return "fixture_manager.objects(" +
+		"thing=" + client.ObjectString(e.Thing) +
+		", other=" + client.ObjectString(e.Other) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e FixtureManagerObjectsEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("thing", client.ObjectString(e.Thing)),
+		slog.String("other", client.ObjectString(e.Other)),
+	)
+}
+
+// FixtureManagerArraysEvent : array arguments
+type FixtureManagerArraysEvent struct {
+	Data  []byte
+	Keys  []uint32
+	After uint32
+}
+type FixtureManagerArraysHandlerFunc func(FixtureManagerArraysEvent)
+
+// SetArraysHandler : sets handler for FixtureManagerArraysEvent
+func (i *FixtureManager) SetArraysHandler(f FixtureManagerArraysHandlerFunc) {
+	i.arraysHandler = f
+}
+
+// String formats the event as fixture_manager.arrays(argument=value, ...).
+func (e FixtureManagerArraysEvent) String() string {
+	return "fixture_manager.arrays(" +
+		"data=" + "array[" + strconv.Itoa(len(e.Data)) + "]" +
+		", keys=" + fmt.Sprint(e.Keys) +
+		", after=" + strconv.FormatUint(uint64(e.After), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e FixtureManagerArraysEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Any("data", e.Data),
+		slog.Any("keys", e.Keys),
+		slog.Uint64("after", uint64(e.After)),
+	)
+}
+
+// FixtureManagerFdEvent : a file descriptor
+type FixtureManagerFdEvent struct {
+	Fd   int
+	Size uint32
+}
+type FixtureManagerFdHandlerFunc func(FixtureManagerFdEvent)
+
+// SetFdHandler : sets handler for FixtureManagerFdEvent
+func (i *FixtureManager) SetFdHandler(f FixtureManagerFdHandlerFunc) {
+	i.fdHandler = f
+}
+
+// String formats the event as fixture_manager.fd(argument=value, ...).
+func (e FixtureManagerFdEvent) String() string {
+	return "fixture_manager.fd(" +
+		"fd=" + strconv.Itoa(e.Fd) +
+		", size=" + strconv.FormatUint(uint64(e.Size), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e FixtureManagerFdEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int("fd", e.Fd),
+		slog.Uint64("size", uint64(e.Size)),
+	)
+}
+
+// FixtureManagerCreateThingEvent : a thing created by the compositor
+type FixtureManagerCreateThingEvent struct {
+	Id    *FixtureThing
+	Flags FixtureThingFlags
+}
+type FixtureManagerCreateThingHandlerFunc func(FixtureManagerCreateThingEvent)
+
+// SetCreateThingHandler : sets handler for FixtureManagerCreateThingEvent
+func (i *FixtureManager) SetCreateThingHandler(f FixtureManagerCreateThingHandlerFunc) {
+	i.createThingHandler = f
+}
+
+// String formats the event as fixture_manager.create_thing(argument=value, ...).
+func (e FixtureManagerCreateThingEvent) String() string {
+	return "fixture_manager.create_thing(" +
+		"id=" + client.ObjectString(e.Id) +
+		", flags=" + e.Flags.String() +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e FixtureManagerCreateThingEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", client.ObjectString(e.Id)),
+		slog.String("flags", e.Flags.String()),
+	)
+}
+
+// FixtureManagerBindEvent : an object of any interface created by the compositor
+type FixtureManagerBindEvent struct {
+	Name uint32
+	Id   client.Proxy
+}
+type FixtureManagerBindHandlerFunc func(FixtureManagerBindEvent)
+
+// SetBindHandler : sets handler for FixtureManagerBindEvent
+func (i *FixtureManager) SetBindHandler(f FixtureManagerBindHandlerFunc) {
+	i.bindHandler = f
+}
+
+// String formats the event as fixture_manager.bind(argument=value, ...).
+func (e FixtureManagerBindEvent) String() string {
+	return "fixture_manager.bind(" +
+		"name=" + strconv.FormatUint(uint64(e.Name), 10) +
+		", id=" + client.ObjectString(e.Id) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e FixtureManagerBindEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("name", uint64(e.Name)),
+		slog.String("id", client.ObjectString(e.Id)),
+	)
+}
+
+// FixtureManagerStringsEvent : string arguments
+type FixtureManagerStringsEvent struct {
+	Label string
+	Hint  *string
+	After uint32
+}
+type FixtureManagerStringsHandlerFunc func(FixtureManagerStringsEvent)
+
+// SetStringsHandler : sets handler for FixtureManagerStringsEvent
+func (i *FixtureManager) SetStringsHandler(f FixtureManagerStringsHandlerFunc) {
+	i.stringsHandler = f
+}
+
+// String formats the event as fixture_manager.strings(argument=value, ...).
+func (e FixtureManagerStringsEvent) String() string {
+	return "fixture_manager.strings(" +
+		"label=" + strconv.Quote(e.Label) +
+		", hint=" + client.NullableString(e.Hint) +
+		", after=" + strconv.FormatUint(uint64(e.After), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e FixtureManagerStringsEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("label", e.Label),
+		slog.Any("hint", client.NullableValue(e.Hint)),
+		slog.Uint64("after", uint64(e.After)),
+	)
+}
+
+// FixtureManagerListener receives all the events of FixtureManager, embed
+// FixtureManagerNoopListener to implement only some of them.
+type FixtureManagerListener interface {
+	Scalars(e FixtureManagerScalarsEvent)
+	Objects(e FixtureManagerObjectsEvent)
+	Arrays(e FixtureManagerArraysEvent)
+	Fd(e FixtureManagerFdEvent)
+	CreateThing(e FixtureManagerCreateThingEvent)
+	Bind(e FixtureManagerBindEvent)
+	Strings(e FixtureManagerStringsEvent)
+}
+
+// FixtureManagerNoopListener is a FixtureManagerListener ignoring
+// all the events, it closes the file descriptors they carry.
+type FixtureManagerNoopListener struct{}
+
+func (FixtureManagerNoopListener) Scalars(FixtureManagerScalarsEvent) {
+}
+
+func (FixtureManagerNoopListener) Objects(FixtureManagerObjectsEvent) {
+}
+
+func (FixtureManagerNoopListener) Arrays(FixtureManagerArraysEvent) {
+}
+
+func (FixtureManagerNoopListener) Fd(e FixtureManagerFdEvent) {
+	unix.Close(e.Fd)
+}
+
+func (FixtureManagerNoopListener) CreateThing(FixtureManagerCreateThingEvent) {
+}
+
+func (FixtureManagerNoopListener) Bind(FixtureManagerBindEvent) {
+}
+
+func (FixtureManagerNoopListener) Strings(FixtureManagerStringsEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *FixtureManager) SetListener(l FixtureManagerListener) {
+	if l == nil {
+		i.scalarsHandler = nil
+		i.objectsHandler = nil
+		i.arraysHandler = nil
+		i.fdHandler = nil
+		i.createThingHandler = nil
+		i.bindHandler = nil
+		i.stringsHandler = nil
+		return
+	}
+	i.scalarsHandler = l.Scalars
+	i.objectsHandler = l.Objects
+	i.arraysHandler = l.Arrays
+	i.fdHandler = l.Fd
+	i.createThingHandler = l.CreateThing
+	i.bindHandler = l.Bind
+	i.stringsHandler = l.Strings
+}
+
+func (i *FixtureManager) Dispatch(opcode uint32, fd int, data []byte) {
+	switch opcode {
+	case 0:
+		if i.scalarsHandler == nil {
+			return
+		}
+		var e FixtureManagerScalarsEvent
+		l := 0
+		e.Number = int32(client.Uint32(data[l : l+4]))
+		l += 4
+		e.Count = client.Uint32(data[l : l+4])
+		l += 4
+		e.Scale = client.FixedFloat64(data[l : l+4])
+		l += 4
+		labelLen := client.PaddedLen(int(client.Uint32(data[l : l+4])))
+		l += 4
+		e.Label = client.String(data[l : l+labelLen])
+		l += labelLen
+		e.Mode = FixtureManagerMode(client.Uint32(data[l : l+4]))
+		l += 4
+
+		i.scalarsHandler(e)
+	case 1:
+		if i.objectsHandler == nil {
+			return
+		}
+		var e FixtureManagerObjectsEvent
+		l := 0
+		e.Thing, _ = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*FixtureThing)
+		l += 4
+		e.Other, _ = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*FixtureThing)
+		l += 4
+
+		i.objectsHandler(e)
+	case 2:
+		if i.arraysHandler == nil {
+			return
+		}
+		var e FixtureManagerArraysEvent
+		l := 0
+		dataLen := int(client.Uint32(data[l : l+4]))
+		l += 4
+		e.Data = make([]byte, dataLen)
+		copy(e.Data, data[l:l+dataLen])
+		l += client.PaddedLen(dataLen)
+		keysLen := int(client.Uint32(data[l : l+4]))
+		l += 4
+		e.Keys = client.Array[uint32](data[l : l+keysLen])
+		l += client.PaddedLen(keysLen)
+		e.After = client.Uint32(data[l : l+4])
+		l += 4
+
+		i.arraysHandler(e)
+	case 3:
+		if i.fdHandler == nil {
+			if fd != -1 {
+				unix.Close(fd)
+			}
+			return
+		}
+		var e FixtureManagerFdEvent
+		l := 0
+		e.Fd = fd
+		e.Size = client.Uint32(data[l : l+4])
+		l += 4
+
+		i.fdHandler(e)
+	case 4:
+		var e FixtureManagerCreateThingEvent
+		l := 0
+		e.Id = &FixtureThing{}
+		i.Context().RegisterWithID(e.Id, client.Uint32(data[l:l+4]))
+		e.Id.SetVersion(i.Version())
+		l += 4
+		e.Flags = FixtureThingFlags(client.Uint32(data[l : l+4]))
+		l += 4
+
+		if i.createThingHandler != nil {
+			i.createThingHandler(e)
+		}
+	case 5:
+		var e FixtureManagerBindEvent
+		l := 0
+		e.Name = client.Uint32(data[l : l+4])
+		l += 4
+		idInterfaceLen := client.PaddedLen(int(client.Uint32(data[l : l+4])))
+		l += 4
+		idInterface := client.String(data[l : l+idInterfaceLen])
+		l += idInterfaceLen
+		idVersion := client.Uint32(data[l : l+4])
+		l += 4
+		e.Id = client.NewProxy(idInterface)
+		if e.Id == nil {
+			e.Id = &client.UnknownProxy{Name: idInterface}
+		}
+		i.Context().RegisterWithID(e.Id, client.Uint32(data[l:l+4]))
+		e.Id.SetVersion(idVersion)
+		l += 4
+
+		if i.bindHandler != nil {
+			i.bindHandler(e)
+		}
+	case 6:
+		if i.stringsHandler == nil {
+			return
+		}
+		var e FixtureManagerStringsEvent
+		l := 0
+		labelLen := client.PaddedLen(int(client.Uint32(data[l : l+4])))
+		l += 4
+		e.Label = client.String(data[l : l+labelLen])
+		l += labelLen
+		hintLen := client.PaddedLen(int(client.Uint32(data[l : l+4])))
+		l += 4
+		if hintLen != 0 {
+			hint := client.String(data[l : l+hintLen])
+			e.Hint = &hint
+		}
+		l += hintLen
+		e.After = client.Uint32(data[l : l+4])
+		l += 4
+
+		i.stringsHandler(e)
+	}
+}
+
+// FixtureThing : object created by the manager
+type FixtureThing struct {
+	client.BaseProxy
+	flagsHandler   FixtureThingFlagsHandlerFunc
+	doneHandler    FixtureThingDoneHandlerFunc
+	expiredHandler FixtureThingExpiredHandlerFunc
+}
+
+// NewFixtureThing : object created by the manager
+func NewFixtureThing(ctx *client.Context) *FixtureThing {
+	fixtureThing := &FixtureThing{}
+	ctx.Register(fixtureThing)
+	return fixtureThing
+}
+
+// FixtureThingInterface describes fixture_thing at runtime.
+var FixtureThingInterface = &client.Interface{
+	Name:    "fixture_thing",
+	Version: 1,
+	New:     func() client.Proxy { return &FixtureThing{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "set_flags",
+			Args: []client.Arg{
+				{Name: "flags", Type: "uint", Enum: "fixture_thing.flags"},
+			},
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "flags",
+			Args: []client.Arg{
+				{Name: "flags", Type: "uint", Enum: "fixture_thing.flags"},
+				{Name: "mode", Type: "int", Enum: "fixture_manager.mode"},
+			},
+		},
+		{
+			Name:       "done",
+			Destructor: true,
+			Args: []client.Arg{
+				{Name: "reason", Type: "uint"},
+			},
+		},
+		{
+			Name:       "expired",
+			Destructor: true,
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name:     "flags",
+			Bitfield: true,
+			Entries: []client.EnumEntry{
+				{Name: "none", Value: 0},
+				{Name: "a", Value: 1},
+				{Name: "b", Value: 0x2},
+				{Name: "c", Value: 4},
+			},
+		},
+	},
+}
+
+// Interface returns the description of fixture_thing.
+func (i *FixtureThing) Interface() *client.Interface {
+	return FixtureThingInterface
+}
+
+// Destroy : destroy the thing
+func (i *FixtureThing) Destroy() error {
+	defer i.Context().Unregister(i)
+	const opcode = 0
+	const _reqBufLen = 8
+	var _reqBuf [_reqBufLen]byte
+	l := 0
+	client.PutUint32(_reqBuf[l:4], i.ID())
+	l += 4
+	client.PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
+	l += 4
+	err := i.Context().WriteMsg(_reqBuf[:], nil)
+	return err
+}
+
+// SetFlags : change the flags
+func (i *FixtureThing) SetFlags(flags FixtureThingFlags) error {
+	const opcode = 1
+	const _reqBufLen = 8 + 4
+	var _reqBuf [_reqBufLen]byte
+	l := 0
+	client.PutUint32(_reqBuf[l:4], i.ID())
+	l += 4
+	client.PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
+	l += 4
+	client.PutUint32(_reqBuf[l:l+4], uint32(flags))
+	l += 4
+	err := i.Context().WriteMsg(_reqBuf[:], nil)
+	return err
+}
+
+type FixtureThingFlags uint32
+
+// FixtureThingFlags : a bitfield
+const (
+	FixtureThingFlagsNone FixtureThingFlags = 0
+	FixtureThingFlagsA    FixtureThingFlags = 1
+	FixtureThingFlagsB    FixtureThingFlags = 0x2
+	FixtureThingFlagsC    FixtureThingFlags = 4
+)
+
+func (e FixtureThingFlags) Name() string {
+	switch e {
+	case FixtureThingFlagsNone:
+		return "none"
+	case FixtureThingFlagsA:
+		return "a"
+	case FixtureThingFlagsB:
+		return "b"
+	case FixtureThingFlagsC:
+		return "c"
+	default:
+		return ""
+	}
+}
+
+func (e FixtureThingFlags) Value() string {
+	switch e {
+	case FixtureThingFlagsNone:
+		return "0"
+	case FixtureThingFlagsA:
+		return "1"
+	case FixtureThingFlagsB:
+		return "0x2"
+	case FixtureThingFlagsC:
+		return "4"
+	default:
+		return ""
+	}
+}
+
+func (e FixtureThingFlags) Has(f FixtureThingFlags) bool {
+	return e&f == f
+}
+
+func (e FixtureThingFlags) Set(f FixtureThingFlags) FixtureThingFlags {
+	return e | f
+}
+
+func (e FixtureThingFlags) String() string {
+	if e == 0 {
+		return "none"
+	}
+	var names []string
+	for _, f := range []FixtureThingFlags{FixtureThingFlagsA, FixtureThingFlagsB, FixtureThingFlagsC} {
+		if e.Has(f) {
+			names = append(names, f.Name())
+			e &^= f
+		}
+	}
+	if e != 0 {
+		names = append(names, "0x"+strconv.FormatUint(uint64(e), 16))
+	}
+	return strings.Join(names, "|")
+}
+
+// FixtureThingFlagsEvent : current flags and mode
+type FixtureThingFlagsEvent struct {
+	Flags FixtureThingFlags
+	Mode  FixtureManagerMode
+}
+type FixtureThingFlagsHandlerFunc func(FixtureThingFlagsEvent)
+
+// SetFlagsHandler : sets handler for FixtureThingFlagsEvent
+func (i *FixtureThing) SetFlagsHandler(f FixtureThingFlagsHandlerFunc) {
+	i.flagsHandler = f
+}
+
+// String formats the event as fixture_thing.flags(argument=value, ...).
+func (e FixtureThingFlagsEvent) String() string {
+	return "fixture_thing.flags(" +
+		"flags=" + e.Flags.String() +
+		", mode=" + client.EnumString(e.Mode.Name(), uint32(e.Mode)) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e FixtureThingFlagsEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("flags", e.Flags.String()),
+		slog.String("mode", client.EnumString(e.Mode.Name(), uint32(e.Mode))),
+	)
+}
+
+// FixtureThingDoneEvent : the thing is destroyed
+//
+// The server destroys the thing after this event.
+type FixtureThingDoneEvent struct {
+	Reason uint32
+}
+type FixtureThingDoneHandlerFunc func(FixtureThingDoneEvent)
+
+// SetDoneHandler : sets handler for FixtureThingDoneEvent
+func (i *FixtureThing) SetDoneHandler(f FixtureThingDoneHandlerFunc) {
+	i.doneHandler = f
+}
+
+// String formats the event as fixture_thing.done(argument=value, ...).
+func (e FixtureThingDoneEvent) String() string {
+	return "fixture_thing.done(" +
+		"reason=" + strconv.FormatUint(uint64(e.Reason), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e FixtureThingDoneEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("reason", uint64(e.Reason)),
+	)
+}
+
+// FixtureThingExpiredEvent : the thing expired
+//
+// Destroys the thing like done, the scanner is told so with
+// -destructor-event as for protocols written before type="destructor"
+// was allowed on events.
+type FixtureThingExpiredEvent struct{}
+type FixtureThingExpiredHandlerFunc func(FixtureThingExpiredEvent)
+
+// SetExpiredHandler : sets handler for FixtureThingExpiredEvent
+func (i *FixtureThing) SetExpiredHandler(f FixtureThingExpiredHandlerFunc) {
+	i.expiredHandler = f
+}
+
+// String formats the event as fixture_thing.expired(argument=value, ...).
+func (e FixtureThingExpiredEvent) String() string {
+	return "fixture_thing.expired()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e FixtureThingExpiredEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
+// FixtureThingListener receives all the events of FixtureThing, embed
+// FixtureThingNoopListener to implement only some of them.
+type FixtureThingListener interface {
+	Flags(e FixtureThingFlagsEvent)
+	Done(e FixtureThingDoneEvent)
+	Expired(e FixtureThingExpiredEvent)
+}
+
+// FixtureThingNoopListener is a FixtureThingListener ignoring
+// all the events, it closes the file descriptors they carry.
+type FixtureThingNoopListener struct{}
+
+func (FixtureThingNoopListener) Flags(FixtureThingFlagsEvent) {
+}
+
+func (FixtureThingNoopListener) Done(FixtureThingDoneEvent) {
+}
+
+func (FixtureThingNoopListener) Expired(FixtureThingExpiredEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *FixtureThing) SetListener(l FixtureThingListener) {
+	if l == nil {
+		i.flagsHandler = nil
+		i.doneHandler = nil
+		i.expiredHandler = nil
+		return
+	}
+	i.flagsHandler = l.Flags
+	i.doneHandler = l.Done
+	i.expiredHandler = l.Expired
+}
+
+func (i *FixtureThing) Dispatch(opcode uint32, fd int, data []byte) {
+	switch opcode {
+	case 0:
+		if i.flagsHandler == nil {
+			return
+		}
+		var e FixtureThingFlagsEvent
+		l := 0
+		e.Flags = FixtureThingFlags(client.Uint32(data[l : l+4]))
+		l += 4
+		e.Mode = FixtureManagerMode(client.Uint32(data[l : l+4]))
+		l += 4
+
+		i.flagsHandler(e)
+	case 1:
+		defer i.Context().Unregister(i)
+		if i.doneHandler == nil {
+			return
+		}
+		var e FixtureThingDoneEvent
+		l := 0
+		e.Reason = client.Uint32(data[l : l+4])
+		l += 4
+
+		i.doneHandler(e)
+	case 2:
+		defer i.Context().Unregister(i)
+		if i.expiredHandler == nil {
+			return
+		}
+		var e FixtureThingExpiredEvent
+
+		i.expiredHandler(e)
+	}
+}
+
+func init() {
+	client.RegisterInterface(FixtureManagerInterface)
+	client.RegisterInterface(FixtureThingInterface)
+}
@@ -0,0 +1,362 @@
+// Generated by go-wayland-scanner
+// https://github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner
+// XML file : testdata/fixture.xml
+//
+// fixture Protocol Copyright:
+//
+// Fixture protocol of the go-wayland-scanner tests, in the public domain.
+
+package fixture
+
+import "github.com/rajveermalviya/go-wayland/wayland/client"
+
+// FixtureManagerAPI is the interface of the methods of FixtureManager, to
+// replace it with a FakeFixtureManager in tests. FixtureManager.API returns the
+// FixtureManagerAPI of a proxy.
+type FixtureManagerAPI interface {
+	Destroy() error
+	CreateThing(flags FixtureThingFlags) (FixtureThingAPI, error)
+	Bind(name uint32, iface string, version uint32, id client.Proxy) error
+	Scalars(number int32, count uint32, scale float64, label string, mode FixtureManagerMode) error
+	Objects(thing, other FixtureThingAPI, surface client.SurfaceAPI) error
+	Arrays(data []byte, keys []uint32, after uint32) error
+	SendFd(mimeType string, fd int) error
+	Strings(label string, hint *string, after uint32) error
+	SetScalarsHandler(f FixtureManagerScalarsHandlerFunc)
+	SetObjectsHandler(f FixtureManagerObjectsHandlerFunc)
+	SetArraysHandler(f FixtureManagerArraysHandlerFunc)
+	SetFdHandler(f FixtureManagerFdHandlerFunc)
+	SetCreateThingHandler(f FixtureManagerCreateThingHandlerFunc)
+	SetBindHandler(f FixtureManagerBindHandlerFunc)
+	SetStringsHandler(f FixtureManagerStringsHandlerFunc)
+	SetListener(l FixtureManagerListener)
+}
+
+// API returns the FixtureManager as a FixtureManagerAPI, whose requests
+// take and return API interfaces.
+func (i *FixtureManager) API() FixtureManagerAPI {
+	return fixtureManagerAPI{i}
+}
+
+// fixtureManagerAPI is the FixtureManagerAPI of a FixtureManager.
+type fixtureManagerAPI struct {
+	*FixtureManager
+}
+
+var _ FixtureManagerAPI = fixtureManagerAPI{}
+
+// Unwrap returns the proxy, for client.APIProxy.
+func (a fixtureManagerAPI) Unwrap() client.Proxy {
+	return a.FixtureManager
+}
+
+func (a fixtureManagerAPI) CreateThing(flags FixtureThingFlags) (FixtureThingAPI, error) {
+	id, err := a.FixtureManager.CreateThing(flags)
+	if id == nil {
+		return nil, err
+	}
+	return id.API(), err
+}
+
+func (a fixtureManagerAPI) Objects(thing, other FixtureThingAPI, surface client.SurfaceAPI) error {
+	thingProxy, err := client.APIProxy[*FixtureThing](thing)
+	if err != nil {
+		return err
+	}
+	otherProxy, err := client.APIProxy[*FixtureThing](other)
+	if err != nil {
+		return err
+	}
+	surfaceProxy, err := client.APIProxy[*client.Surface](surface)
+	if err != nil {
+		return err
+	}
+	return a.FixtureManager.Objects(thingProxy, otherProxy, surfaceProxy)
+}
+
+// FakeFixtureManager is a FixtureManagerAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// client.Proxy, to be bound with the bind request of a fake registry.
+type FakeFixtureManager struct {
+	client.BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []client.FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+
+	scalarsHandler     FixtureManagerScalarsHandlerFunc
+	objectsHandler     FixtureManagerObjectsHandlerFunc
+	arraysHandler      FixtureManagerArraysHandlerFunc
+	fdHandler          FixtureManagerFdHandlerFunc
+	createThingHandler FixtureManagerCreateThingHandlerFunc
+	bindHandler        FixtureManagerBindHandlerFunc
+	stringsHandler     FixtureManagerStringsHandlerFunc
+}
+
+var _ FixtureManagerAPI = (*FakeFixtureManager)(nil)
+
+// Destroy records the destroy request.
+func (i *FakeFixtureManager) Destroy() error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "destroy"})
+	i.Destroyed = true
+	return i.Err
+}
+
+// CreateThing records the create_thing request.
+func (i *FakeFixtureManager) CreateThing(flags FixtureThingFlags) (FixtureThingAPI, error) {
+	id := &FakeFixtureThing{}
+	i.Calls = append(i.Calls, client.FakeCall{Request: "create_thing", Args: []any{id, flags}})
+	return id, i.Err
+}
+
+// Bind records the bind request.
+func (i *FakeFixtureManager) Bind(name uint32, iface string, version uint32, id client.Proxy) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "bind", Args: []any{name, iface, version, id}})
+	return i.Err
+}
+
+// Scalars records the scalars request.
+func (i *FakeFixtureManager) Scalars(number int32, count uint32, scale float64, label string, mode FixtureManagerMode) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "scalars", Args: []any{number, count, scale, label, mode}})
+	return i.Err
+}
+
+// Objects records the objects request.
+func (i *FakeFixtureManager) Objects(thing, other FixtureThingAPI, surface client.SurfaceAPI) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "objects", Args: []any{thing, other, surface}})
+	return i.Err
+}
+
+// Arrays records the arrays request.
+func (i *FakeFixtureManager) Arrays(data []byte, keys []uint32, after uint32) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "arrays", Args: []any{data, keys, after}})
+	return i.Err
+}
+
+// SendFd records the send_fd request.
+func (i *FakeFixtureManager) SendFd(mimeType string, fd int) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "send_fd", Args: []any{mimeType, fd}})
+	return i.Err
+}
+
+// Strings records the strings request.
+func (i *FakeFixtureManager) Strings(label string, hint *string, after uint32) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "strings", Args: []any{label, hint, after}})
+	return i.Err
+}
+
+func (i *FakeFixtureManager) SetScalarsHandler(f FixtureManagerScalarsHandlerFunc) {
+	i.scalarsHandler = f
+}
+
+// EmitScalars calls the handler of scalars as if the event was received.
+func (i *FakeFixtureManager) EmitScalars(e FixtureManagerScalarsEvent) {
+	if i.scalarsHandler != nil {
+		i.scalarsHandler(e)
+	}
+}
+
+func (i *FakeFixtureManager) SetObjectsHandler(f FixtureManagerObjectsHandlerFunc) {
+	i.objectsHandler = f
+}
+
+// EmitObjects calls the handler of objects as if the event was received.
+func (i *FakeFixtureManager) EmitObjects(e FixtureManagerObjectsEvent) {
+	if i.objectsHandler != nil {
+		i.objectsHandler(e)
+	}
+}
+
+func (i *FakeFixtureManager) SetArraysHandler(f FixtureManagerArraysHandlerFunc) {
+	i.arraysHandler = f
+}
+
+// EmitArrays calls the handler of arrays as if the event was received.
+func (i *FakeFixtureManager) EmitArrays(e FixtureManagerArraysEvent) {
+	if i.arraysHandler != nil {
+		i.arraysHandler(e)
+	}
+}
+
+func (i *FakeFixtureManager) SetFdHandler(f FixtureManagerFdHandlerFunc) {
+	i.fdHandler = f
+}
+
+// EmitFd calls the handler of fd as if the event was received.
+func (i *FakeFixtureManager) EmitFd(e FixtureManagerFdEvent) {
+	if i.fdHandler != nil {
+		i.fdHandler(e)
+	}
+}
+
+func (i *FakeFixtureManager) SetCreateThingHandler(f FixtureManagerCreateThingHandlerFunc) {
+	i.createThingHandler = f
+}
+
+// EmitCreateThing calls the handler of create_thing as if the event was received.
+func (i *FakeFixtureManager) EmitCreateThing(e FixtureManagerCreateThingEvent) {
+	if i.createThingHandler != nil {
+		i.createThingHandler(e)
+	}
+}
+
+func (i *FakeFixtureManager) SetBindHandler(f FixtureManagerBindHandlerFunc) {
+	i.bindHandler = f
+}
+
+// EmitBind calls the handler of bind as if the event was received.
+func (i *FakeFixtureManager) EmitBind(e FixtureManagerBindEvent) {
+	if i.bindHandler != nil {
+		i.bindHandler(e)
+	}
+}
+
+func (i *FakeFixtureManager) SetStringsHandler(f FixtureManagerStringsHandlerFunc) {
+	i.stringsHandler = f
+}
+
+// EmitStrings calls the handler of strings as if the event was received.
+func (i *FakeFixtureManager) EmitStrings(e FixtureManagerStringsEvent) {
+	if i.stringsHandler != nil {
+		i.stringsHandler(e)
+	}
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *FakeFixtureManager) SetListener(l FixtureManagerListener) {
+	if l == nil {
+		i.scalarsHandler = nil
+		i.objectsHandler = nil
+		i.arraysHandler = nil
+		i.fdHandler = nil
+		i.createThingHandler = nil
+		i.bindHandler = nil
+		i.stringsHandler = nil
+		return
+	}
+	i.scalarsHandler = l.Scalars
+	i.objectsHandler = l.Objects
+	i.arraysHandler = l.Arrays
+	i.fdHandler = l.Fd
+	i.createThingHandler = l.CreateThing
+	i.bindHandler = l.Bind
+	i.stringsHandler = l.Strings
+}
+
+// FixtureThingAPI is the interface of the methods of FixtureThing, to
+// replace it with a FakeFixtureThing in tests. FixtureThing.API returns the
+// FixtureThingAPI of a proxy.
+type FixtureThingAPI interface {
+	Destroy() error
+	SetFlags(flags FixtureThingFlags) error
+	SetFlagsHandler(f FixtureThingFlagsHandlerFunc)
+	SetDoneHandler(f FixtureThingDoneHandlerFunc)
+	SetExpiredHandler(f FixtureThingExpiredHandlerFunc)
+	SetListener(l FixtureThingListener)
+}
+
+// API returns the FixtureThing as a FixtureThingAPI, whose requests
+// take and return API interfaces.
+func (i *FixtureThing) API() FixtureThingAPI {
+	return fixtureThingAPI{i}
+}
+
+// fixtureThingAPI is the FixtureThingAPI of a FixtureThing.
+type fixtureThingAPI struct {
+	*FixtureThing
+}
+
+var _ FixtureThingAPI = fixtureThingAPI{}
+
+// Unwrap returns the proxy, for client.APIProxy.
+func (a fixtureThingAPI) Unwrap() client.Proxy {
+	return a.FixtureThing
+}
+
+// FakeFixtureThing is a FixtureThingAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// client.Proxy, to be bound with the bind request of a fake registry.
+type FakeFixtureThing struct {
+	client.BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []client.FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+
+	flagsHandler   FixtureThingFlagsHandlerFunc
+	doneHandler    FixtureThingDoneHandlerFunc
+	expiredHandler FixtureThingExpiredHandlerFunc
+}
+
+var _ FixtureThingAPI = (*FakeFixtureThing)(nil)
+
+// Destroy records the destroy request.
+func (i *FakeFixtureThing) Destroy() error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "destroy"})
+	i.Destroyed = true
+	return i.Err
+}
+
+// SetFlags records the set_flags request.
+func (i *FakeFixtureThing) SetFlags(flags FixtureThingFlags) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "set_flags", Args: []any{flags}})
+	return i.Err
+}
+
+func (i *FakeFixtureThing) SetFlagsHandler(f FixtureThingFlagsHandlerFunc) {
+	i.flagsHandler = f
+}
+
+// EmitFlags calls the handler of flags as if the event was received.
+func (i *FakeFixtureThing) EmitFlags(e FixtureThingFlagsEvent) {
+	if i.flagsHandler != nil {
+		i.flagsHandler(e)
+	}
+}
+
+func (i *FakeFixtureThing) SetDoneHandler(f FixtureThingDoneHandlerFunc) {
+	i.doneHandler = f
+}
+
+// EmitDone calls the handler of done as if the event was received.
+func (i *FakeFixtureThing) EmitDone(e FixtureThingDoneEvent) {
+	i.Destroyed = true
+	if i.doneHandler != nil {
+		i.doneHandler(e)
+	}
+}
+
+func (i *FakeFixtureThing) SetExpiredHandler(f FixtureThingExpiredHandlerFunc) {
+	i.expiredHandler = f
+}
+
+// EmitExpired calls the handler of expired as if the event was received.
+func (i *FakeFixtureThing) EmitExpired(e FixtureThingExpiredEvent) {
+	i.Destroyed = true
+	if i.expiredHandler != nil {
+		i.expiredHandler(e)
+	}
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *FakeFixtureThing) SetListener(l FixtureThingListener) {
+	if l == nil {
+		i.flagsHandler = nil
+		i.doneHandler = nil
+		i.expiredHandler = nil
+		return
+	}
+	i.flagsHandler = l.Flags
+	i.doneHandler = l.Done
+	i.expiredHandler = l.Expired
+}
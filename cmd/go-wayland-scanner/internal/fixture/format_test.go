@@ -0,0 +1,80 @@
+package fixture_test
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner/internal/fixture"
+)
+
+func TestEventFormat(t *testing.T) {
+	thing := &fixture.FixtureThing{}
+	thing.SetID(5)
+	hint := "hint"
+
+	tests := []struct {
+		event  fmt.Stringer
+		str    string
+		logged string
+	}{
+		{
+			event:  fixture.FixtureManagerScalarsEvent{Number: -42, Count: 7, Scale: 2.25, Label: "a \"b\"", Mode: fixture.FixtureManagerModeAuto},
+			str:    `fixture_manager.scalars(number=-42, count=7, scale=2.25, label="a \"b\"", mode=auto)`,
+			logged: `event.number=-42 event.count=7 event.scale=2.25 event.label="a \"b\"" event.mode=auto`,
+		},
+		{
+			event:  fixture.FixtureManagerObjectsEvent{Thing: thing},
+			str:    `fixture_manager.objects(thing=fixture_thing@5, other=nil)`,
+			logged: `event.thing=fixture_thing@5 event.other=nil`,
+		},
+		{
+			event:  fixture.FixtureManagerArraysEvent{Data: []byte{1, 2, 3}, Keys: []uint32{4, 5}, After: 6},
+			str:    `fixture_manager.arrays(data=array[3], keys=[4 5], after=6)`,
+			logged: `event.data="\x01\x02\x03" event.keys="[4 5]" event.after=6`,
+		},
+		{
+			event:  fixture.FixtureManagerStringsEvent{Label: "label", Hint: &hint},
+			str:    `fixture_manager.strings(label="label", hint="hint", after=0)`,
+			logged: `event.label=label event.hint=hint event.after=0`,
+		},
+		{
+			event:  fixture.FixtureManagerStringsEvent{},
+			str:    `fixture_manager.strings(label="", hint=nil, after=0)`,
+			logged: `event.label="" event.hint=<nil> event.after=0`,
+		},
+		{
+			// Values without a name are printed as numbers
+			event:  fixture.FixtureThingFlagsEvent{Flags: fixture.FixtureThingFlagsA | fixture.FixtureThingFlagsC | 8, Mode: 9},
+			str:    `fixture_thing.flags(flags=a|c|0x8, mode=9)`,
+			logged: `event.flags=a|c|0x8 event.mode=9`,
+		},
+		{
+			event:  fixture.FixtureThingExpiredEvent{},
+			str:    `fixture_thing.expired()`,
+			logged: ``,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.event.String(); got != tt.str {
+			t.Errorf("got %s, want %s", got, tt.str)
+		}
+
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey || a.Key == slog.MessageKey) {
+					return slog.Attr{}
+				}
+				return a
+			},
+		}))
+		logger.Info("", "event", tt.event)
+		if got := strings.TrimSpace(buf.String()); got != tt.logged {
+			t.Errorf("%s logged as %s, want %s", tt.str, got, tt.logged)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package fixture_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner/internal/fixture"
+	"github.com/rajveermalviya/go-wayland/wayland/protocol"
+)
+
+// thingListener handles flags and leaves the other events to the no-op
+// listener.
+type thingListener struct {
+	fixture.FixtureThingNoopListener
+	flags []fixture.FixtureThingFlags
+}
+
+func (l *thingListener) Flags(e fixture.FixtureThingFlagsEvent) {
+	l.flags = append(l.flags, e.Flags)
+}
+
+func TestListener(t *testing.T) {
+	p, err := protocol.Load("../../testdata/fixture.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := setup(t, p)
+	thing := e.thing(t)
+
+	l := &thingListener{}
+	thing.SetListener(l)
+	e.s.Send(thing, "flags", fixture.FixtureThingFlagsA, fixture.FixtureManagerModeOff)
+	e.s.Roundtrip()
+
+	thing.SetListener(nil)
+	e.s.Send(thing, "flags", fixture.FixtureThingFlagsB, fixture.FixtureManagerModeOff)
+	e.s.Send(thing, "expired")
+	e.s.Roundtrip()
+
+	want := []fixture.FixtureThingFlags{fixture.FixtureThingFlagsA}
+	if !reflect.DeepEqual(l.flags, want) {
+		t.Errorf("got flags %v, want %v", l.flags, want)
+	}
+	if p := e.manager.Context().GetProxy(thing.ID()); p != nil {
+		t.Errorf("%v still registered after expired", p)
+	}
+}
+
+func TestFakeListener(t *testing.T) {
+	thing := &fixture.FakeFixtureThing{}
+
+	l := &thingListener{}
+	thing.SetListener(l)
+	thing.EmitFlags(fixture.FixtureThingFlagsEvent{Flags: fixture.FixtureThingFlagsC})
+	thing.EmitDone(fixture.FixtureThingDoneEvent{})
+
+	want := []fixture.FixtureThingFlags{fixture.FixtureThingFlagsC}
+	if !reflect.DeepEqual(l.flags, want) {
+		t.Errorf("got flags %v, want %v", l.flags, want)
+	}
+}
@@ -0,0 +1,632 @@
+package fixture_test
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner/internal/fixture"
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+	"github.com/rajveermalviya/go-wayland/wayland/protocol"
+	"github.com/rajveermalviya/go-wayland/wayland/waylandtest"
+)
+
+// signatures maps XML argument types to waylandtest signature characters.
+var signatures = map[string]string{
+	"int":    "i",
+	"uint":   "u",
+	"fixed":  "f",
+	"string": "s",
+	"object": "o",
+	"new_id": "n",
+	"array":  "a",
+	"fd":     "h",
+}
+
+// destructorEvents are the events TestGolden gives to the scanner with
+// -destructor-event.
+var destructorEvents = map[string]bool{
+	"fixture_thing.expired": true,
+}
+
+// interfaces describes the wire format of the fixture protocol straight
+// from its XML, independently of the generated code under test.
+func interfaces(t *testing.T, p *protocol.Protocol) []*waylandtest.Interface {
+	t.Helper()
+
+	message := func(name, typ string, args []protocol.Arg) waylandtest.Message {
+		m := waylandtest.Message{Name: name, Destructor: typ == "destructor"}
+		for _, arg := range args {
+			sig, ok := signatures[arg.Type]
+			if !ok {
+				t.Fatalf("%s: unknown argument type %q", name, arg.Type)
+			}
+			if arg.AllowNull {
+				sig = "?" + sig
+			}
+			m.Signature += sig
+			m.Types = append(m.Types, arg.Interface)
+		}
+		return m
+	}
+
+	var ifaces []*waylandtest.Interface
+	for _, v := range p.Interfaces {
+		iface := &waylandtest.Interface{Name: v.Name, Version: uint32(v.Version)}
+		for _, r := range v.Requests {
+			iface.Requests = append(iface.Requests, message(r.Name, r.Type, r.Args))
+		}
+		for _, e := range v.Events {
+			m := message(e.Name, e.Type, e.Args)
+			m.Destructor = m.Destructor || destructorEvents[v.Name+"."+e.Name]
+			iface.Events = append(iface.Events, m)
+		}
+		ifaces = append(ifaces, iface)
+	}
+	return ifaces
+}
+
+type env struct {
+	s       *waylandtest.Server
+	manager *fixture.FixtureManager
+}
+
+func setup(t *testing.T, p *protocol.Protocol) *env {
+	t.Helper()
+
+	s := waylandtest.NewServer(t)
+	s.RegisterInterface(interfaces(t, p)...)
+	s.AddGlobal("fixture_manager", 2)
+
+	registry, err := s.Display().GetRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := &env{s: s}
+	registry.SetGlobalHandler(func(g client.RegistryGlobalEvent) {
+		if g.Interface == "fixture_manager" {
+			e.manager = fixture.NewFixtureManager(registry.Context())
+			_ = registry.Bind(g.Name, g.Interface, g.Version, e.manager)
+		}
+	})
+	s.Roundtrip()
+	if e.manager == nil {
+		t.Fatal("fixture_manager was not bound")
+	}
+
+	s.Expect("wl_display", "get_registry", registry)
+	s.Expect("wl_registry", "bind", waylandtest.Any, "fixture_manager", uint32(2), e.manager)
+	return e
+}
+
+// thing creates a fixture_thing known to both sides.
+func (e *env) thing(t *testing.T) *fixture.FixtureThing {
+	t.Helper()
+
+	thing, err := e.manager.CreateThing(fixture.FixtureThingFlagsNone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.s.Expect("fixture_manager", "create_thing", thing, 0)
+	return thing
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func pipe(t *testing.T) (r, w *os.File) {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		r.Close()
+		w.Close()
+	})
+	return r, w
+}
+
+// requests marshal every request with the generated code and check the
+// arguments the server decodes.
+var requests = map[string]func(t *testing.T, e *env){
+	"fixture_manager.destroy": func(t *testing.T, e *env) {
+		if err := e.manager.Destroy(); err != nil {
+			t.Fatal(err)
+		}
+		e.s.Expect("fixture_manager", "destroy")
+		if obj := e.s.Object(e.manager.ID()); obj != nil {
+			t.Errorf("destroyed object %v still alive", obj)
+		}
+	},
+
+	"fixture_manager.create_thing": func(t *testing.T, e *env) {
+		thing, err := e.manager.CreateThing(fixture.FixtureThingFlagsA | fixture.FixtureThingFlagsC)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := e.s.Expect("fixture_manager", "create_thing", thing, uint32(5))
+		if iface := r.Args[0].(*waylandtest.Object).Interface; iface != "fixture_thing" {
+			t.Errorf("created a %s, want a fixture_thing", iface)
+		}
+	},
+
+	"fixture_manager.bind": func(t *testing.T, e *env) {
+		thing := fixture.NewFixtureThing(e.manager.Context())
+		if err := e.manager.Bind(7, "fixture_thing", 1, thing); err != nil {
+			t.Fatal(err)
+		}
+		e.s.Expect("fixture_manager", "bind", uint32(7), "fixture_thing", uint32(1), thing)
+	},
+
+	"fixture_manager.scalars": func(t *testing.T, e *env) {
+		if err := e.manager.Scalars(-42, 0xdeadbeef, -2.25, "fixture", fixture.FixtureManagerModeAuto); err != nil {
+			t.Fatal(err)
+		}
+		e.s.Expect("fixture_manager", "scalars", int32(-42), uint32(0xdeadbeef), -2.25, "fixture", int32(2))
+
+		// Strings whose NUL terminator lands on a word boundary
+		for _, s := range []string{"", "abc", "abcd"} {
+			if err := e.manager.Scalars(0, 0, 0, s, fixture.FixtureManagerModeOff); err != nil {
+				t.Fatal(err)
+			}
+			e.s.Expect("fixture_manager", "scalars", int32(0), uint32(0), 0.0, s, int32(0))
+		}
+	},
+
+	"fixture_manager.objects": func(t *testing.T, e *env) {
+		thing := e.thing(t)
+		if err := e.manager.Objects(thing, nil, nil); err != nil {
+			t.Fatal(err)
+		}
+		e.s.Expect("fixture_manager", "objects", thing, nil, nil)
+		if err := e.manager.Objects(thing, thing, nil); err != nil {
+			t.Fatal(err)
+		}
+		e.s.Expect("fixture_manager", "objects", thing, thing, nil)
+	},
+
+	"fixture_manager.arrays": func(t *testing.T, e *env) {
+		keys := []uint32{1, 2, 0xffffffff}
+		if err := e.manager.Arrays([]byte{1, 2, 3, 4, 5}, keys, 9); err != nil {
+			t.Fatal(err)
+		}
+		e.s.Expect("fixture_manager", "arrays", []byte{1, 2, 3, 4, 5}, client.ArrayBytes(keys), uint32(9))
+		if err := e.manager.Arrays(nil, nil, 10); err != nil {
+			t.Fatal(err)
+		}
+		e.s.Expect("fixture_manager", "arrays", []byte{}, []byte{}, uint32(10))
+	},
+
+	"fixture_manager.send_fd": func(t *testing.T, e *env) {
+		r, w := pipe(t)
+		if err := e.manager.SendFd("text/plain", int(w.Fd())); err != nil {
+			t.Fatal(err)
+		}
+		req := e.s.Expect("fixture_manager", "send_fd", "text/plain", waylandtest.Any)
+
+		f := os.NewFile(uintptr(req.Args[1].(int)), "fd")
+		defer f.Close()
+		if _, err := f.WriteString("data"); err != nil {
+			t.Fatal(err)
+		}
+		buf := make([]byte, 4)
+		if _, err := r.Read(buf); err != nil || string(buf) != "data" {
+			t.Errorf("got %q (%v) through the fd, want %q", buf, err, "data")
+		}
+	},
+
+	"fixture_manager.strings": func(t *testing.T, e *env) {
+		// A null string is a zero length, an empty one has its NUL
+		for _, hint := range []*string{nil, ptr(""), ptr("hint")} {
+			if err := e.manager.Strings("label", hint, 3); err != nil {
+				t.Fatal(err)
+			}
+			var want any
+			if hint != nil {
+				want = *hint
+			}
+			e.s.Expect("fixture_manager", "strings", "label", want, uint32(3))
+		}
+	},
+
+	"fixture_thing.destroy": func(t *testing.T, e *env) {
+		thing := e.thing(t)
+		if err := thing.Destroy(); err != nil {
+			t.Fatal(err)
+		}
+		e.s.Expect("fixture_thing", "destroy")
+		if obj := e.s.Object(thing.ID()); obj != nil {
+			t.Errorf("destroyed object %v still alive", obj)
+		}
+	},
+
+	"fixture_thing.set_flags": func(t *testing.T, e *env) {
+		thing := e.thing(t)
+		if err := thing.SetFlags(fixture.FixtureThingFlagsB | fixture.FixtureThingFlagsC); err != nil {
+			t.Fatal(err)
+		}
+		e.s.Expect("fixture_thing", "set_flags", uint32(6))
+	},
+}
+
+// events are encoded by the server and decoded by the generated
+// dispatchers.
+var events = map[string]func(t *testing.T, e *env){
+	"fixture_manager.scalars": func(t *testing.T, e *env) {
+		var got []fixture.FixtureManagerScalarsEvent
+		e.manager.SetScalarsHandler(func(ev fixture.FixtureManagerScalarsEvent) {
+			got = append(got, ev)
+		})
+		e.s.Send(e.manager, "scalars", -42, uint32(0xdeadbeef), -2.25, "fixture", fixture.FixtureManagerModeOn)
+		e.s.Send(e.manager, "scalars", 0, 0, 0.0, "abc", fixture.FixtureManagerModeOff)
+		e.s.Roundtrip()
+
+		want := []fixture.FixtureManagerScalarsEvent{
+			{Number: -42, Count: 0xdeadbeef, Scale: -2.25, Label: "fixture", Mode: fixture.FixtureManagerModeOn},
+			{Label: "abc", Mode: fixture.FixtureManagerModeOff},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+	},
+
+	"fixture_manager.objects": func(t *testing.T, e *env) {
+		thing := e.thing(t)
+		var got []fixture.FixtureManagerObjectsEvent
+		e.manager.SetObjectsHandler(func(ev fixture.FixtureManagerObjectsEvent) {
+			got = append(got, ev)
+		})
+		e.s.Send(e.manager, "objects", thing, nil)
+		e.s.Send(e.manager, "objects", thing, thing)
+		e.s.Roundtrip()
+
+		want := []fixture.FixtureManagerObjectsEvent{
+			{Thing: thing},
+			{Thing: thing, Other: thing},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+	},
+
+	"fixture_manager.arrays": func(t *testing.T, e *env) {
+		var got []fixture.FixtureManagerArraysEvent
+		e.manager.SetArraysHandler(func(ev fixture.FixtureManagerArraysEvent) {
+			got = append(got, ev)
+		})
+		e.s.Send(e.manager, "arrays", []byte{1, 2, 3, 4, 5}, client.ArrayBytes([]uint32{1, 2, 0xffffffff}), 9)
+		e.s.Send(e.manager, "arrays", []byte{}, []byte{}, 10)
+		e.s.Roundtrip()
+
+		want := []fixture.FixtureManagerArraysEvent{
+			{Data: []byte{1, 2, 3, 4, 5}, Keys: []uint32{1, 2, 0xffffffff}, After: 9},
+			{Data: []byte{}, Keys: []uint32{}, After: 10},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+	},
+
+	"fixture_manager.fd": func(t *testing.T, e *env) {
+		r, w := pipe(t)
+		if _, err := w.WriteString("data"); err != nil {
+			t.Fatal(err)
+		}
+		var data []byte
+		var size uint32
+		e.manager.SetFdHandler(func(ev fixture.FixtureManagerFdEvent) {
+			f := os.NewFile(uintptr(ev.Fd), "fd")
+			defer f.Close()
+			size = ev.Size
+			data = make([]byte, ev.Size)
+			_, _ = f.Read(data)
+		})
+		e.s.Send(e.manager, "fd", int(r.Fd()), 4)
+		e.s.Roundtrip()
+
+		if size != 4 || string(data) != "data" {
+			t.Errorf("got %q of size %d through the fd, want %q", data, size, "data")
+		}
+	},
+
+	"fixture_manager.create_thing": func(t *testing.T, e *env) {
+		// Objects created by the compositor are registered even without
+		// handler
+		first := e.s.NewObject("fixture_thing", 2)
+		e.s.Send(e.manager, "create_thing", first, 0)
+		e.s.Roundtrip()
+		if _, ok := e.manager.Context().GetProxy(first.ID).(*fixture.FixtureThing); !ok {
+			t.Errorf("object %d created without handler is not registered", first.ID)
+		}
+
+		var got fixture.FixtureManagerCreateThingEvent
+		e.manager.SetCreateThingHandler(func(ev fixture.FixtureManagerCreateThingEvent) {
+			got = ev
+		})
+		obj := e.s.NewObject("fixture_thing", 2)
+		e.s.Send(e.manager, "create_thing", obj, fixture.FixtureThingFlagsC)
+		e.s.Roundtrip()
+		if got.Id == nil || got.Id.ID() != obj.ID || got.Flags != fixture.FixtureThingFlagsC {
+			t.Fatalf("got %+v, want thing %d with flags c", got, obj.ID)
+		}
+		if v := got.Id.Version(); v != 2 {
+			t.Errorf("thing has version %d, want the version 2 of the manager", v)
+		}
+
+		// and receive events
+		var flags fixture.FixtureThingFlags
+		got.Id.SetFlagsHandler(func(ev fixture.FixtureThingFlagsEvent) {
+			flags = ev.Flags
+		})
+		e.s.Send(obj, "flags", fixture.FixtureThingFlagsA, fixture.FixtureManagerModeOn)
+		e.s.Roundtrip()
+		if flags != fixture.FixtureThingFlagsA {
+			t.Errorf("got flags %v, want %v", flags, fixture.FixtureThingFlagsA)
+		}
+	},
+
+	"fixture_manager.bind": func(t *testing.T, e *env) {
+		var got fixture.FixtureManagerBindEvent
+		e.manager.SetBindHandler(func(ev fixture.FixtureManagerBindEvent) {
+			got = ev
+		})
+		obj := e.s.NewObject("fixture_thing", 1)
+		e.s.Send(e.manager, "bind", 7, obj)
+		e.s.Roundtrip()
+		thing, ok := got.Id.(*fixture.FixtureThing)
+		if got.Name != 7 || !ok || thing.ID() != obj.ID {
+			t.Fatalf("got %+v, want fixture_thing %d", got, obj.ID)
+		}
+		if v := thing.Version(); v != 1 {
+			t.Errorf("thing has version %d, want the version 1 sent with it", v)
+		}
+
+		// Objects of interfaces no package registered get a placeholder,
+		// which drops their events
+		e.s.RegisterInterface(&waylandtest.Interface{
+			Name:    "fixture_unknown",
+			Version: 1,
+			Events:  []waylandtest.Message{{Name: "ping", Signature: "u"}},
+		})
+		unknown := e.s.NewObject("fixture_unknown", 1)
+		e.s.Send(e.manager, "bind", 8, unknown)
+		e.s.Send(unknown, "ping", 1)
+		e.s.Roundtrip()
+		placeholder, ok := got.Id.(*client.UnknownProxy)
+		if got.Name != 8 || !ok || placeholder.ID() != unknown.ID || placeholder.Name != "fixture_unknown" {
+			t.Fatalf("got %+v, want a placeholder for fixture_unknown %d", got, unknown.ID)
+		}
+		if p := e.manager.Context().GetProxy(unknown.ID); p != placeholder {
+			t.Errorf("got %v registered, want the placeholder", p)
+		}
+	},
+
+	"fixture_manager.strings": func(t *testing.T, e *env) {
+		var got []fixture.FixtureManagerStringsEvent
+		e.manager.SetStringsHandler(func(ev fixture.FixtureManagerStringsEvent) {
+			got = append(got, ev)
+		})
+		e.s.Send(e.manager, "strings", "label", nil, 1)
+		e.s.Send(e.manager, "strings", "", "", 2)
+		e.s.Send(e.manager, "strings", "label", "hint", 3)
+		e.s.Roundtrip()
+
+		want := []fixture.FixtureManagerStringsEvent{
+			{Label: "label", After: 1},
+			{Label: "", Hint: ptr(""), After: 2},
+			{Label: "label", Hint: ptr("hint"), After: 3},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+	},
+
+	"fixture_thing.flags": func(t *testing.T, e *env) {
+		thing := e.thing(t)
+		var got []fixture.FixtureThingFlagsEvent
+		thing.SetFlagsHandler(func(ev fixture.FixtureThingFlagsEvent) {
+			got = append(got, ev)
+		})
+		e.s.Send(thing, "flags", fixture.FixtureThingFlagsA|fixture.FixtureThingFlagsB, fixture.FixtureManagerModeAuto)
+		e.s.Roundtrip()
+
+		want := []fixture.FixtureThingFlagsEvent{
+			{Flags: fixture.FixtureThingFlagsA | fixture.FixtureThingFlagsB, Mode: fixture.FixtureManagerModeAuto},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+	},
+
+	"fixture_thing.done": destroyedBy("done",
+		func(e *env, thing *fixture.FixtureThing) { e.s.Send(thing, "done", 3) },
+		func(thing *fixture.FixtureThing, handled *bool) {
+			thing.SetDoneHandler(func(ev fixture.FixtureThingDoneEvent) {
+				*handled = ev.Reason == 3
+			})
+		}),
+
+	"fixture_thing.expired": destroyedBy("expired",
+		func(e *env, thing *fixture.FixtureThing) { e.s.Send(thing, "expired") },
+		func(thing *fixture.FixtureThing, handled *bool) {
+			thing.SetExpiredHandler(func(fixture.FixtureThingExpiredEvent) {
+				*handled = true
+			})
+		}),
+}
+
+// destroyedBy checks that a destructor event unregisters its object,
+// whether it has a handler or not.
+func destroyedBy(event string, send func(e *env, thing *fixture.FixtureThing), handle func(thing *fixture.FixtureThing, handled *bool)) func(t *testing.T, e *env) {
+	return func(t *testing.T, e *env) {
+		thing := e.thing(t)
+		handled := false
+		handle(thing, &handled)
+		send(e, thing)
+		e.s.Roundtrip()
+		if !handled {
+			t.Errorf("%s was not handled", event)
+		}
+		if p := e.manager.Context().GetProxy(thing.ID()); p != nil {
+			t.Errorf("%v still registered after %s", p, event)
+		}
+
+		unhandled := e.thing(t)
+		send(e, unhandled)
+		e.s.Roundtrip()
+		if p := e.manager.Context().GetProxy(unhandled.ID()); p != nil {
+			t.Errorf("%v without handler still registered after %s", p, event)
+		}
+	}
+}
+
+func TestRoundtrip(t *testing.T) {
+	p, err := protocol.Load("../../testdata/fixture.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, v := range p.Interfaces {
+		for _, r := range v.Requests {
+			name := v.Name + "." + r.Name
+			t.Run("request "+name, func(t *testing.T) {
+				test, ok := requests[name]
+				if !ok {
+					t.Fatalf("no round-trip test for request %s", name)
+				}
+				test(t, setup(t, p))
+			})
+		}
+		for _, ev := range v.Events {
+			name := v.Name + "." + ev.Name
+			t.Run("event "+name, func(t *testing.T) {
+				test, ok := events[name]
+				if !ok {
+					t.Fatalf("no round-trip test for event %s", name)
+				}
+				test(t, setup(t, p))
+			})
+		}
+	}
+}
+
+// signature returns the libwayland signature of a message of the XML.
+func signature(since int, args []protocol.Arg) string {
+	sig := ""
+	if since > 1 {
+		sig = strconv.Itoa(since)
+	}
+	for _, arg := range args {
+		if arg.AllowNull {
+			sig += "?"
+		}
+		if arg.Type == "new_id" && arg.Interface == "" {
+			sig += "su"
+		}
+		sig += signatures[arg.Type]
+	}
+	return sig
+}
+
+// TestMetadata checks the registered runtime descriptions against the
+// XML.
+func TestMetadata(t *testing.T) {
+	p, err := protocol.Load("../../testdata/fixture.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := (&fixture.FixtureManager{}).Interface(); got != fixture.FixtureManagerInterface {
+		t.Errorf("FixtureManager.Interface() is %p, want %p", got, fixture.FixtureManagerInterface)
+	}
+
+	checkMessage := func(iface string, got client.Message, name, typ string, since, deprecatedSince int, args []protocol.Arg) {
+		want := signature(since, args)
+		destructor := typ == "destructor" || destructorEvents[iface+"."+name]
+		if got.Name != name || got.Destructor != destructor || got.Signature() != want || got.DeprecatedSince != uint32(deprecatedSince) {
+			t.Errorf("%s: got message %s (destructor %v, %q, deprecated since %d), want %s (%q, %q, deprecated since %d)",
+				iface, got.Name, got.Destructor, got.Signature(), got.DeprecatedSince, name, typ, want, deprecatedSince)
+		}
+	}
+
+	for _, v := range p.Interfaces {
+		got := client.LookupInterface(v.Name)
+		if got == nil {
+			t.Errorf("%s is not registered", v.Name)
+			continue
+		}
+		if got.Version != uint32(v.Version) {
+			t.Errorf("%s: got version %d, want %d", v.Name, got.Version, v.Version)
+		}
+
+		if len(got.Requests) != len(v.Requests) || len(got.Events) != len(v.Events) || len(got.Enums) != len(v.Enums) {
+			t.Errorf("%s: got %d requests, %d events and %d enums, want %d, %d and %d", v.Name,
+				len(got.Requests), len(got.Events), len(got.Enums), len(v.Requests), len(v.Events), len(v.Enums))
+			continue
+		}
+		for i, r := range v.Requests {
+			checkMessage(v.Name, got.Requests[i], r.Name, r.Type, r.Since, r.DeprecatedSince, r.Args)
+		}
+		for i, e := range v.Events {
+			checkMessage(v.Name, got.Events[i], e.Name, e.Type, e.Since, e.DeprecatedSince, e.Args)
+		}
+		for i, e := range v.Enums {
+			g := got.Enums[i]
+			if g.Name != e.Name || g.Bitfield != e.Bitfield || len(g.Entries) != len(e.Entries) {
+				t.Errorf("%s: got enum %+v, want %s", v.Name, g, e.Name)
+				continue
+			}
+			for j, entry := range e.Entries {
+				value, err := strconv.ParseUint(entry.Value, 0, 32)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if g.Entries[j].Name != entry.Name || g.Entries[j].Value != uint32(value) || g.Entries[j].DeprecatedSince != uint32(entry.DeprecatedSince) {
+					t.Errorf("%s.%s: got entry %+v, want %s = %d, deprecated since %d", v.Name, e.Name, g.Entries[j], entry.Name, value, entry.DeprecatedSince)
+				}
+			}
+		}
+	}
+}
+
+// TestVersion checks that objects get the version they are bound with or
+// the one of their parent, and that requests newer than it fail.
+func TestVersion(t *testing.T) {
+	p, err := protocol.Load("../../testdata/fixture.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := setup(t, p)
+
+	if v := e.manager.Version(); v != 2 {
+		t.Errorf("manager has version %d, want the bound version 2", v)
+	}
+	if v := e.thing(t).Version(); v != 2 {
+		t.Errorf("created thing has version %d, want the version of its parent 2", v)
+	}
+	bound := fixture.NewFixtureThing(e.manager.Context())
+	if err := e.manager.Bind(7, "fixture_thing", 1, bound); err != nil {
+		t.Fatal(err)
+	}
+	e.s.Expect("fixture_manager", "bind", uint32(7), "fixture_thing", uint32(1), bound)
+	if v := bound.Version(); v != 1 {
+		t.Errorf("bound thing has version %d, want 1", v)
+	}
+
+	e.manager.SetVersion(1)
+	err = e.manager.Arrays(nil, nil, 0)
+	var verr *client.VersionError
+	if !errors.As(err, &verr) || verr.Request != "arrays" || verr.Since != 2 || verr.Version != 1 {
+		t.Errorf("got error %v from a request newer than the object, want a VersionError", err)
+	}
+	if err := e.manager.Scalars(0, 0, 0, "", fixture.FixtureManagerModeOn); err != nil {
+		t.Fatal(err)
+	}
+	// the arrays request must not have been sent
+	e.s.Expect("fixture_manager", "scalars", int32(0), uint32(0), 0.0, "", int32(1))
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// manifestEntry is one protocol of a manifest, a JSON array of them.
+// Paths are relative to the directory of the manifest.
+type manifestEntry struct {
+	// Name of the protocol, as in its XML file.
+	Name string `json:"name"`
+	// XML is the path of the vendored protocol XML file.
+	XML string `json:"xml"`
+	// URL the XML file was vendored from, downloaded again with -fetch.
+	URL string `json:"url"`
+	// Output is the path of the generated Go file.
+	Output string `json:"output"`
+	// API is the path of the generated file of -api, if any.
+	API     string `json:"api,omitempty"`
+	Package string `json:"package"`
+	Prefix  string `json:"prefix,omitempty"`
+	Suffix  string `json:"suffix,omitempty"`
+	// Imports, Arrays and DestructorEvents are added to the ones of
+	// -import, -array and -destructor-event for this protocol, in the
+	// same format.
+	Imports          []string `json:"imports,omitempty"`
+	Arrays           []string `json:"arrays,omitempty"`
+	DestructorEvents []string `json:"destructor_events,omitempty"`
+	// Fixed uses client.Fixed for fixed arguments, as with -fixed.
+	Fixed bool `json:"fixed,omitempty"`
+}
+
+func loadManifest(file string) ([]manifestEntry, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []manifestEntry
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return entries, nil
+}
+
+// generateManifest generates every protocol of the manifest, in order.
+func generateManifest(file string) error {
+	entries, err := loadManifest(file)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(file)
+
+	globalImports := importMappings
+	globalArrays := arrayMappings
+	globalDestructors := destructorEvents
+	globalFixed := fixedType
+
+	for _, e := range entries {
+		xmlFile := filepath.Join(dir, e.XML)
+		if fetch {
+			if err := fetchXML(e.URL, xmlFile); err != nil {
+				return fmt.Errorf("%s: unable to fetch %s: %w", e.Name, e.URL, err)
+			}
+		}
+		if _, err := os.Stat(xmlFile); err != nil {
+			return fmt.Errorf("%s: %w, download it with -fetch", e.Name, err)
+		}
+
+		importMappings = append([]importMapping(nil), globalImports...)
+		for _, s := range e.Imports {
+			m, err := parseImportMapping(s)
+			if err != nil {
+				return fmt.Errorf("%s: %w", e.Name, err)
+			}
+			importMappings = append(importMappings, m)
+		}
+		arrayMappings = append([]arrayMapping(nil), globalArrays...)
+		for _, s := range e.Arrays {
+			m, err := parseArrayMapping(s)
+			if err != nil {
+				return fmt.Errorf("%s: %w", e.Name, err)
+			}
+			arrayMappings = append(arrayMappings, m)
+		}
+		destructorEvents = append([]string(nil), globalDestructors...)
+		for _, s := range e.DestructorEvents {
+			d, err := parseDestructorEvent(s)
+			if err != nil {
+				return fmt.Errorf("%s: %w", e.Name, err)
+			}
+			destructorEvents = append(destructorEvents, d)
+		}
+
+		fixedType = globalFixed || e.Fixed
+		packageName, prefix, suffix = e.Package, e.Prefix, e.Suffix
+		apiFile = ""
+		if e.API != "" {
+			apiFile = filepath.Join(dir, e.API)
+		}
+		protocol = Protocol{}
+
+		source := e.URL
+		if source == "" {
+			source = e.XML
+		}
+		generate(xmlFile, source, filepath.Join(dir, e.Output))
+	}
+	return nil
+}
+
+func fetchXML(url string, file string) error {
+	src, err := getInputFile(url)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return err
+	}
+	dst, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
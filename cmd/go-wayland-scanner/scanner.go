@@ -6,7 +6,6 @@ import (
 	"encoding/xml"
 	"flag"
 	"fmt"
-	"go/doc"
 	"io"
 	"log"
 	"net/http"
@@ -15,142 +14,159 @@ import (
 	"strings"
 
 	"github.com/iancoleman/strcase"
+	wlprotocol "github.com/rajveermalviya/go-wayland/wayland/protocol"
 	"golang.org/x/tools/imports"
 	gofumpt "mvdan.cc/gofumpt/format"
 )
 
 var (
-	inputFile   string
-	outputFile  string
-	packageName string
-	prefix      string
-	suffix      string
+	inputFile    string
+	outputFile   string
+	apiFile      string
+	packageName  string
+	prefix       string
+	suffix       string
+	importsFile  string
+	arraysFile   string
+	destructors  string
+	manifestFile string
+	fixedType    bool
+	fetch        bool
+	check        bool
+	// checkFailed is set by generate when -check finds errors
+	checkFailed bool
 )
 
 func init() {
 	flag.StringVar(&inputFile, "i", "", "Remote url or local path of the protocol xml file")
 	flag.StringVar(&outputFile, "o", "", "Path of the generated output go file")
+	flag.StringVar(&apiFile, "api", "", "Path of an additional go file with an interface and a recording fake of every wayland interface")
 	flag.StringVar(&packageName, "pkg", "", "Go package name")
 	flag.StringVar(&prefix, "prefix", "", "Specifiy prefix to trim")
 	flag.StringVar(&suffix, "suffix", "", "Specifiy suffix to trim")
+	flag.Var(importFlag{}, "import", "Map interfaces of another protocol to a Go package, as \"pattern package import/path [prefix [suffix]]\", can be repeated")
+	flag.StringVar(&importsFile, "imports", "", "File of import mappings, one per line in the format of -import")
+	flag.Var(arrayFlag{}, "array", "Decode the elements of an array argument as a Go integer type or enum, as \"interface.message.arg type\", can be repeated")
+	flag.StringVar(&arraysFile, "arrays", "", "File of array mappings, one per line in the format of -array")
+	flag.Var(destructorFlag{}, "destructor-event", "Treat an event as a destructor of its object, as \"interface.event\", for protocols older than type=\"destructor\" on events, can be repeated")
+	flag.StringVar(&destructors, "destructor-events", "", "File of destructor events, one per line in the format of -destructor-event")
+	flag.StringVar(&manifestFile, "manifest", "", "Generate every protocol listed in the manifest file instead of -i")
+	flag.BoolVar(&fixedType, "fixed", false, "Use client.Fixed instead of float64 for fixed arguments")
+	flag.StringVar(&templatesDir, "templates", "", "Directory of *.tmpl files redefining or adding to the default templates of the generated code")
+	flag.BoolVar(&fetch, "fetch", false, "Download the XML files listed in the manifest from their url")
+	flag.BoolVar(&check, "check", false, "Only validate the protocol XML files, exit with status 1 on errors")
 }
 
-type Protocol struct {
-	XMLName    xml.Name    `xml:"protocol"`
-	Name       string      `xml:"name,attr"`
-	Copyright  string      `xml:"copyright"`
-	Interfaces []Interface `xml:"interface"`
-}
-
-type Interface struct {
-	XMLName     xml.Name    `xml:"interface"`
-	Name        string      `xml:"name,attr"`
-	Description Description `xml:"description"`
-	Requests    []Request   `xml:"request"`
-	Events      []Event     `xml:"event"`
-	Enums       []Enum      `xml:"enum"`
-	Version     int         `xml:"version,attr"`
-}
-
-type Request struct {
-	XMLName     xml.Name    `xml:"request"`
-	Name        string      `xml:"name,attr"`
-	Type        string      `xml:"type,attr"`
-	Description Description `xml:"description"`
-	Args        []Arg       `xml:"arg"`
-	Since       int         `xml:"since,attr"`
-}
-
-type Event struct {
-	XMLName     xml.Name    `xml:"event"`
-	Name        string      `xml:"name,attr"`
-	Type        string      `xml:"type,attr"`
-	Description Description `xml:"description"`
-	Args        []Arg       `xml:"arg"`
-	Since       int         `xml:"since,attr"`
-}
-
-type Enum struct {
-	XMLName     xml.Name    `xml:"enum"`
-	Name        string      `xml:"name,attr"`
-	Description Description `xml:"description"`
-	Entries     []Entry     `xml:"entry"`
-	Since       int         `xml:"since,attr"`
-	Bitfield    bool        `xml:"bitfield,attr"`
-}
-
-type Entry struct {
-	XMLName     xml.Name    `xml:"entry"`
-	Name        string      `xml:"name,attr"`
-	Value       string      `xml:"value,attr"`
-	Summary     string      `xml:"summary,attr"`
-	Description Description `xml:"description"`
-	Since       int         `xml:"since,attr"`
-}
-
-type Arg struct {
-	XMLName     xml.Name    `xml:"arg"`
-	Name        string      `xml:"name,attr"`
-	Type        string      `xml:"type,attr"`
-	Summary     string      `xml:"summary,attr"`
-	Interface   string      `xml:"interface,attr"`
-	Enum        string      `xml:"enum,attr"`
-	Description Description `xml:"description"`
-	AllowNull   bool        `xml:"allow-null,attr"`
-}
-
-type Description struct {
-	XMLName xml.Name `xml:"description"`
-	Text    string   `xml:",chardata"`
-	Summary string   `xml:"summary,attr"`
-}
+// The protocol XML model is shared with the other tools of this module.
+type (
+	Protocol    = wlprotocol.Protocol
+	Interface   = wlprotocol.Interface
+	Request     = wlprotocol.Request
+	Event       = wlprotocol.Event
+	Enum        = wlprotocol.Enum
+	Entry       = wlprotocol.Entry
+	Arg         = wlprotocol.Arg
+	Description = wlprotocol.Description
+)
 
 var protocol Protocol
 
 func main() {
 	flag.Parse()
 
-	if inputFile == "" || outputFile == "" {
+	if (inputFile == "" || (outputFile == "" && !check)) && manifestFile == "" {
 		flag.Usage()
 		return
 	}
 
+	if importsFile != "" {
+		if err := loadImportMappings(importsFile); err != nil {
+			log.Fatalf("unable to load import mappings: %v", err)
+		}
+	}
+	if arraysFile != "" {
+		if err := loadArrayMappings(arraysFile); err != nil {
+			log.Fatalf("unable to load array mappings: %v", err)
+		}
+	}
+	if destructors != "" {
+		if err := loadDestructorEvents(destructors); err != nil {
+			log.Fatalf("unable to load destructor events: %v", err)
+		}
+	}
+
+	if manifestFile != "" {
+		if err := generateManifest(manifestFile); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		generate(inputFile, inputFile, outputFile)
+	}
+
+	if checkFailed {
+		os.Exit(1)
+	}
+}
+
+// generate writes the Go code of the protocol in inputFile to
+// outputFile, and its interfaces and fakes to apiFile when set. Source is
+// the location of the XML recorded in the header. With -check it only
+// validates the protocol.
+func generate(inputFile string, source string, outputFile string) {
 	src, err := getInputFile(inputFile)
 	if err != nil {
 		log.Fatalf("unable to get input file: %v", err)
 	}
-
-	if err1 := xml.NewDecoder(src).Decode(&protocol); err1 != nil {
-		log.Fatalf("unable to decode protocol xml: %v", err1)
+	data, err := io.ReadAll(src)
+	if err != nil {
+		log.Fatalf("unable to read input file: %v", err)
 	}
 	if err2 := src.Close(); err2 != nil {
 		log.Printf("unable to close input file: %v", err2)
 	}
 
-	w := &bytes.Buffer{}
-
-	// Header
-	fmt.Fprintf(w, "// Generated by go-wayland-scanner\n")
-	fmt.Fprintf(w, "// https://github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner\n")
-	fmt.Fprintf(w, "// XML file : %s\n", inputFile)
-	fmt.Fprintf(w, "//\n")
-	fmt.Fprintf(w, "// %s Protocol Copyright: \n", protocol.Name)
-	fmt.Fprint(w, comment(protocol.Copyright))
-	fmt.Fprintf(w, "\n\n")
-	fmt.Fprintf(w, "package %s\n", packageName)
+	if err1 := xml.Unmarshal(data, &protocol); err1 != nil {
+		log.Fatalf("unable to decode protocol xml: %v", err1)
+	}
 
-	// Imports
-	if protocol.Name != "wayland" {
-		fmt.Fprintf(w, "import \"github.com/rajveermalviya/go-wayland/wayland/client\"\n")
-		fmt.Fprintf(w, "import xdg_shell \"github.com/rajveermalviya/go-wayland/wayland/stable/xdg-shell\"\n")
+	if !validateInput(inputFile, data) {
+		if !check {
+			log.Fatalf("%s: invalid protocol, no code generated", inputFile)
+		}
+		checkFailed = true
+	}
+	if check {
+		return
 	}
 
-	// Intefaces
-	for _, v := range protocol.Interfaces {
-		writeInterface(w, v)
+	qualifyEnums()
+	markDestructorEvents()
+
+	writeFile(outputFile, source, "protocol")
+	if apiFile != "" {
+		writeFile(apiFile, source, "api")
 	}
+}
+
+// writeFile writes a Go file whose body is generated by the named
+// template, executed with the protocol.
+func writeFile(file string, source string, body string) {
+	usedImports = map[string]string{}
 
-	dst, err := os.Create(outputFile)
+	// The body is generated first to know which imports it uses
+	b := &bytes.Buffer{}
+	executeTemplate(b, body, &protocol)
+
+	w := &bytes.Buffer{}
+	executeTemplate(w, "file", fileData{
+		Protocol: &protocol,
+		Source:   source,
+		Package:  packageName,
+		Imports:  importSpecs(),
+		Body:     b.String(),
+	})
+
+	dst, err := os.Create(file)
 	if err != nil {
 		log.Fatalf("unable to create output file: %v", err)
 	}
@@ -164,12 +180,31 @@ func main() {
 	}
 }
 
+// validateInput prints the diagnostics of the protocol decoded from
+// data and reports whether it has none.
+func validateInput(file string, data []byte) bool {
+	lines, err := elementLines(data)
+	if err != nil {
+		log.Fatalf("unable to decode protocol xml: %v", err)
+	}
+
+	diags := validate(&protocol, lines)
+	for _, d := range diags {
+		fmt.Fprintln(os.Stderr, d.format(file))
+	}
+	return len(diags) == 0
+}
+
 func getInputFile(file string) (io.ReadCloser, error) {
 	if strings.HasPrefix(file, "http") {
 		resp, err := http.Get(file)
 		if err != nil {
 			return nil, err
 		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("%s: %s", file, resp.Status)
+		}
 
 		return resp.Body, nil
 	}
@@ -212,558 +247,14 @@ var typeToGoTypeMap map[string]string = map[string]string{
 	"fd":     "int",
 }
 
-func writeInterface(w io.Writer, v Interface) {
-	ifaceName := toCamel(v.Name)
-	ifaceNameLower := toLowerCamel(v.Name)
-
-	// Interface struct
-	fmt.Fprintf(w, "// %s : %s\n", ifaceName, doc.Synopsis(v.Description.Summary))
-	fmt.Fprint(w, comment(v.Description.Text))
-	fmt.Fprintf(w, "type %s struct {\n", ifaceName)
-	if protocol.Name != "wayland" {
-		fmt.Fprintf(w, "client.BaseProxy\n")
-	} else {
-		fmt.Fprintf(w, "BaseProxy\n")
-	}
-	for _, event := range v.Events {
-		fmt.Fprintf(w, "%sHandler %s%sHandlerFunc\n", toLowerCamel(event.Name), ifaceName, toCamel(event.Name))
-	}
-	fmt.Fprintf(w, "}\n")
-
-	// Constructor
-	fmt.Fprintf(w, "// New%s : %s\n", ifaceName, doc.Synopsis(v.Description.Summary))
-	fmt.Fprint(w, comment(v.Description.Text))
-	if protocol.Name != "wayland" {
-		fmt.Fprintf(w, "func New%s(ctx *client.Context) *%s {\n", ifaceName, ifaceName)
-	} else {
-		fmt.Fprintf(w, "func New%s(ctx *Context) *%s {\n", ifaceName, ifaceName)
-	}
-	fmt.Fprintf(w, "%s := &%s{}\n", ifaceNameLower, ifaceName)
-	fmt.Fprintf(w, "ctx.Register(%s)\n", ifaceNameLower)
-	fmt.Fprintf(w, "return %s\n", ifaceNameLower)
-	fmt.Fprintf(w, "}\n")
-
-	// Requests
-	for i, r := range v.Requests {
-		writeRequest(w, ifaceName, i, r)
-	}
-
-	if !hasDestructor(v) {
-		fmt.Fprintf(w, "func (i *%s) Destroy() (error) {\n", ifaceName)
-		fmt.Fprintf(w, "i.Context().Unregister(i)\n")
-		fmt.Fprintf(w, "return nil\n")
-		fmt.Fprintf(w, "}\n")
-	}
-
-	// Enums
-	for _, e := range v.Enums {
-		writeEnum(w, ifaceName, e)
-	}
-
-	// Events
-	for _, e := range v.Events {
-		writeEvent(w, ifaceName, e)
-	}
-
-	// Event dispatcher
-	writeEventDispatcher(w, ifaceName, v)
-}
-
-func writeRequest(w io.Writer, ifaceName string, opcode int, r Request) {
-	requestName := toCamel(r.Name)
-
-	// Generate param & returns types
-	params := []string{}
-	returnTypes := []string{}
-	for _, arg := range r.Args {
-		argNameLower := toLowerCamel(arg.Name)
-		argIface := toCamel(arg.Interface)
-
-		if !isLocalInterface(arg.Interface) {
-			if protocol.Name != "wayland" && strings.HasPrefix(arg.Interface, "wl_") {
-				argIface = "client." + toCamelPrefix(arg.Interface, "wl_")
-			} else if protocol.Name != "xdg_shell" && strings.HasPrefix(arg.Interface, "xdg_") {
-				argIface = "xdg_shell." + toCamelPrefix(arg.Interface, "xdg_")
-			}
-		}
-
-		switch arg.Type {
-		case "new_id":
-			if arg.Interface != "" {
-				returnTypes = append(returnTypes, "*"+argIface)
-			} else {
-				// Special for wl_registry.bind
-				params = append(params, "iface string", "version uint32", "id Proxy")
-			}
-
-		case "object":
-			params = append(params, argNameLower+" *"+argIface)
-
-		case "int", "uint", "fixed",
-			"string", "array", "fd":
-			params = append(params, argNameLower+" "+typeToGoTypeMap[arg.Type])
-		}
-	}
-
-	returnTypes = append(returnTypes, "error")
-
-	fmt.Fprintf(w, "// %s : %s\n", requestName, doc.Synopsis(r.Description.Summary))
-	fmt.Fprint(w, comment(r.Description.Text))
-	fmt.Fprintf(w, "//\n")
-	for _, arg := range r.Args {
-		argNameLower := toLowerCamel(arg.Name)
-
-		if arg.Summary != "" && arg.Type != "new_id" {
-			fmt.Fprintf(w, "//  %s: %s\n", argNameLower, doc.Synopsis(arg.Summary))
-		}
-	}
-	fmt.Fprintf(w, "func (i *%s) %s(%s) (%s) {\n", ifaceName, requestName, strings.Join(params, ","), strings.Join(returnTypes, ","))
-	if r.Type == "destructor" {
-		fmt.Fprintf(w, "defer i.Context().Unregister(i)\n")
-	}
-
-	// Create new objects, if any
-	newObjects := []string{}
-	for _, arg := range r.Args {
-		if arg.Type == "new_id" && arg.Interface != "" {
-			argNameLower := toLowerCamel(arg.Name)
-			argIface := toCamel(arg.Interface)
-
-			if isLocalInterface(arg.Interface) {
-				fmt.Fprintf(w, "%s := New%s(i.Context())\n", argNameLower, argIface)
-			} else {
-				if protocol.Name != "wayland" && strings.HasPrefix(arg.Interface, "wl_") {
-					fmt.Fprintf(w, "%s := client.New%s(i.Context())\n", argNameLower, toCamelPrefix(arg.Interface, "wl_"))
-				} else if protocol.Name != "xdg_shell" && strings.HasPrefix(arg.Interface, "xdg_") {
-					fmt.Fprintf(w, "%s := xdg_shell.New%s(i.Context())\n", argNameLower, toCamelPrefix(arg.Interface, "xdg_"))
-				}
-			}
-
-			newObjects = append(newObjects, argNameLower)
-		}
-	}
-
-	// Create request
-	fmt.Fprintf(w, "const opcode = %d\n", opcode)
-
-	// Calculate size
-	sizes := []string{"8"}
-	canBeConst := true
-	for _, arg := range r.Args {
-		argNameLower := toLowerCamel(arg.Name)
-
-		switch arg.Type {
-		case "new_id":
-			if arg.Interface != "" {
-				sizes = append(sizes, "4")
-			} else {
-				canBeConst = false
-				if protocol.Name == "wayland" {
-					fmt.Fprintf(w, "ifaceLen := PaddedLen(len(iface)+1)\n")
-				} else {
-					fmt.Fprintf(w, "ifaceLen := client.PaddedLen(len(iface)+1)\n")
-				}
-				sizes = append(sizes, "(4 + ifaceLen)", "4", "4")
-			}
-
-		case "object", "int", "uint", "fixed":
-			sizes = append(sizes, "4")
-
-		case "string":
-			canBeConst = false
-			if protocol.Name == "wayland" {
-				fmt.Fprintf(w, "%sLen := PaddedLen(len(%s)+1)\n", argNameLower, argNameLower)
-			} else {
-				fmt.Fprintf(w, "%sLen := client.PaddedLen(len(%s)+1)\n", argNameLower, argNameLower)
-			}
-			sizes = append(sizes, fmt.Sprintf("(4 + %sLen)", argNameLower))
-
-		case "array":
-			canBeConst = false
-			fmt.Fprintf(w, "%sLen := len(%s)\n", argNameLower, argNameLower)
-			sizes = append(sizes, fmt.Sprintf("%sLen", argNameLower))
-		}
-	}
-
-	if canBeConst {
-		fmt.Fprintf(w, "const _reqBufLen =  %s\n", strings.Join(sizes, "+"))
-		fmt.Fprintf(w, "var _reqBuf [_reqBufLen]byte\n")
-	} else {
-		fmt.Fprintf(w, "_reqBufLen := %s\n", strings.Join(sizes, "+"))
-		fmt.Fprintf(w, "_reqBuf := make([]byte, _reqBufLen)\n")
-	}
-
-	fmt.Fprintf(w, "l := 0\n")
-	if protocol.Name == "wayland" {
-		fmt.Fprintf(w, "PutUint32(_reqBuf[l:4], i.ID())\n")
-	} else {
-		fmt.Fprintf(w, "client.PutUint32(_reqBuf[l:4], i.ID())\n")
-	}
-	fmt.Fprintf(w, "l += 4\n")
-	if protocol.Name == "wayland" {
-		fmt.Fprintf(w, "PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))\n")
-	} else {
-		fmt.Fprintf(w, "client.PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))\n")
-	}
-	fmt.Fprintf(w, "l += 4\n")
-
-	fdIndex := -1
-	for i, arg := range r.Args {
-		argNameLower := toLowerCamel(arg.Name)
-
-		switch arg.Type {
-		case "object":
-			if arg.AllowNull {
-				fmt.Fprintf(w, "if %s == nil {\n", argNameLower)
-				if protocol.Name == "wayland" {
-					fmt.Fprintf(w, "PutUint32(_reqBuf[l:l+4], 0)\n")
-				} else {
-					fmt.Fprintf(w, "client.PutUint32(_reqBuf[l:l+4], 0)\n")
-				}
-				fmt.Fprintf(w, "l += 4\n")
-				fmt.Fprintf(w, "} else {\n")
-				if protocol.Name == "wayland" {
-					fmt.Fprintf(w, "PutUint32(_reqBuf[l:l+4], %s.ID())\n", argNameLower)
-				} else {
-					fmt.Fprintf(w, "client.PutUint32(_reqBuf[l:l+4], %s.ID())\n", argNameLower)
-				}
-				fmt.Fprintf(w, "l += 4\n")
-				fmt.Fprintf(w, "}\n")
-			} else {
-				if protocol.Name == "wayland" {
-					fmt.Fprintf(w, "PutUint32(_reqBuf[l:l+4], %s.ID())\n", argNameLower)
-				} else {
-					fmt.Fprintf(w, "client.PutUint32(_reqBuf[l:l+4], %s.ID())\n", argNameLower)
-				}
-				fmt.Fprintf(w, "l += 4\n")
-			}
-
-		case "new_id":
-			if arg.Interface != "" {
-				if protocol.Name == "wayland" {
-					fmt.Fprintf(w, "PutUint32(_reqBuf[l:l+4], %s.ID())\n", argNameLower)
-				} else {
-					fmt.Fprintf(w, "client.PutUint32(_reqBuf[l:l+4], %s.ID())\n", argNameLower)
-				}
-				fmt.Fprintf(w, "l += 4\n")
-			} else {
-				if protocol.Name == "wayland" {
-					fmt.Fprintf(w, "PutString(_reqBuf[l:l+(4 + ifaceLen)], iface, ifaceLen)\n")
-				} else {
-					fmt.Fprintf(w, "client.PutString(_reqBuf[l:l+(4 + ifaceLen)], iface, ifaceLen)\n")
-				}
-				fmt.Fprintf(w, "l += (4 + ifaceLen)\n")
-
-				if protocol.Name == "wayland" {
-					fmt.Fprintf(w, "PutUint32(_reqBuf[l:l+4], uint32(version))\n")
-				} else {
-					fmt.Fprintf(w, "client.PutUint32(_reqBuf[l:l+4],  uint32(version))\n")
-				}
-				fmt.Fprintf(w, "l += 4\n")
-
-				if protocol.Name == "wayland" {
-					fmt.Fprintf(w, "PutUint32(_reqBuf[l:l+4], id.ID())\n")
-				} else {
-					fmt.Fprintf(w, "client.PutUint32(_reqBuf[l:l+4], id.ID())\n")
-				}
-				fmt.Fprintf(w, "l += 4\n")
-			}
-
-		case "int", "uint":
-			if protocol.Name == "wayland" {
-				fmt.Fprintf(w, "PutUint32(_reqBuf[l:l+4], uint32(%s))\n", argNameLower)
-			} else {
-				fmt.Fprintf(w, "client.PutUint32(_reqBuf[l:l+4], uint32(%s))\n", argNameLower)
-			}
-			fmt.Fprintf(w, "l += 4\n")
-
-		case "fixed":
-			if protocol.Name == "wayland" {
-				fmt.Fprintf(w, "PutFixed(_reqBuf[l:l+4], %s)\n", argNameLower)
-			} else {
-				fmt.Fprintf(w, "client.PutFixed(_reqBuf[l:l+4], %s)\n", argNameLower)
-			}
-			fmt.Fprintf(w, "l += 4\n")
-
-		case "string":
-			if protocol.Name == "wayland" {
-				fmt.Fprintf(w, "PutString(_reqBuf[l:l+(4 + %sLen)], %s, %sLen)\n", argNameLower, argNameLower, argNameLower)
-			} else {
-				fmt.Fprintf(w, "client.PutString(_reqBuf[l:l+(4 + %sLen)], %s, %sLen)\n", argNameLower, argNameLower, argNameLower)
-			}
-			fmt.Fprintf(w, "l += (4 + %sLen)\n", argNameLower)
-
-		case "array":
-			if protocol.Name == "wayland" {
-				fmt.Fprintf(w, "PutArray(_reqBuf[l:l+(4 + %sLen)], %s)\n", argNameLower, argNameLower)
-			} else {
-				fmt.Fprintf(w, "client.PutArray(_reqBuf[l:l+(4 + %sLen)], %s)\n", argNameLower, argNameLower)
-			}
-			fmt.Fprintf(w, "l += %sLen\n", argNameLower)
-
-		case "fd":
-			fdIndex = i
-		}
-	}
-
-	if fdIndex != -1 {
-		arg := r.Args[fdIndex]
-		argNameLower := toLowerCamel(arg.Name)
-
-		fmt.Fprintf(w, "oob := unix.UnixRights(int(%s))\n", argNameLower)
-
-		if canBeConst {
-			fmt.Fprintf(w, "err := i.Context().WriteMsg(_reqBuf[:], oob)\n")
-		} else {
-			fmt.Fprintf(w, "err := i.Context().WriteMsg(_reqBuf, oob)\n")
-		}
-	} else {
-		if canBeConst {
-			fmt.Fprintf(w, "err := i.Context().WriteMsg(_reqBuf[:], nil)\n")
-		} else {
-			fmt.Fprintf(w, "err := i.Context().WriteMsg(_reqBuf, nil)\n")
-		}
-	}
-
-	fmt.Fprintf(w, "return %s\n", strings.Join(append(newObjects, "err"), ","))
-	fmt.Fprintf(w, "}\n")
-}
-
-func writeEnum(w io.Writer, ifaceName string, e Enum) {
-	enumName := toCamel(e.Name)
-
-	fmt.Fprintf(w, "type %s%s uint32\n", ifaceName, enumName)
-
-	fmt.Fprintf(w, "// %s%s : %s\n", ifaceName, enumName, doc.Synopsis(e.Description.Summary))
-	fmt.Fprint(w, comment(e.Description.Text))
-	fmt.Fprintf(w, "const (\n")
-	for _, entry := range e.Entries {
-		entryName := toCamel(entry.Name)
-
-		if entry.Summary != "" {
-			fmt.Fprintf(w, "// %s%s%s : %s\n", ifaceName, enumName, entryName, doc.Synopsis(entry.Summary))
-		}
-		fmt.Fprintf(w, "%s%s%s %s%s = %s\n", ifaceName, enumName, entryName, ifaceName, enumName, entry.Value)
-	}
-	fmt.Fprintf(w, ")\n")
-
-	fmt.Fprintf(w, "func (e %s%s) Name() string {\n", ifaceName, enumName)
-	fmt.Fprintf(w, "switch e {\n")
-	for _, entry := range e.Entries {
-		entryName := toCamel(entry.Name)
-
-		fmt.Fprintf(w, "case %s%s%s:\n", ifaceName, enumName, entryName)
-		fmt.Fprintf(w, "return \"%s\"\n", entry.Name)
-	}
-	fmt.Fprintf(w, "default:\n")
-	fmt.Fprintf(w, "return \"\"\n")
-	fmt.Fprintf(w, "}\n")
-	fmt.Fprintf(w, "}\n")
-
-	fmt.Fprintf(w, "func (e %s%s) Value() string {\n", ifaceName, enumName)
-	fmt.Fprintf(w, "switch e {\n")
-	for _, entry := range e.Entries {
-		entryName := toCamel(entry.Name)
-
-		fmt.Fprintf(w, "case %s%s%s:\n", ifaceName, enumName, entryName)
-		fmt.Fprintf(w, "return \"%s\"\n", entry.Value)
-	}
-	fmt.Fprintf(w, "default:\n")
-	fmt.Fprintf(w, "return \"\"\n")
-	fmt.Fprintf(w, "}\n")
-	fmt.Fprintf(w, "}\n")
-
-	fmt.Fprintf(w, "func (e %s%s) String() string {\n", ifaceName, enumName)
-	fmt.Fprintf(w, "return  e.Name() + \"=\" +  e.Value()\n")
-	fmt.Fprintf(w, "}\n")
-}
-
-func writeEvent(w io.Writer, ifaceName string, e Event) {
-	eventName := toCamel(e.Name)
-	eventNameLower := toLowerCamel(e.Name)
-
-	// Event struct
-	fmt.Fprintf(w, "// %s%sEvent : %s\n", ifaceName, eventName, doc.Synopsis(e.Description.Summary))
-	fmt.Fprint(w, comment(e.Description.Text))
-	fmt.Fprintf(w, "type %s%sEvent struct {\n", ifaceName, eventName)
-	for _, arg := range e.Args {
-		argName := toCamel(arg.Name)
-
-		if arg.Description.Summary != "" {
-			fmt.Fprintf(w, "// %s %s\n", argName, doc.Synopsis(arg.Description.Summary))
-		}
-		fmt.Fprint(w, comment(arg.Description.Text))
-		switch arg.Type {
-		case "object", "new_id":
-			if arg.Interface != "" {
-				argIface := toCamel(arg.Interface)
-
-				if !isLocalInterface(arg.Interface) {
-					if protocol.Name != "wayland" && strings.HasPrefix(arg.Interface, "wl_") {
-						argIface = "client." + toCamelPrefix(arg.Interface, "wl_")
-					} else if protocol.Name != "xdg_shell" && strings.HasPrefix(arg.Interface, "xdg_") {
-						argIface = "xdg_shell." + toCamelPrefix(arg.Interface, "xdg_")
-					}
-				}
-
-				fmt.Fprintf(w, "%s *%s\n", argName, argIface)
-			} else {
-				fmt.Fprintf(w, "%s Proxy\n", argName)
-			}
-
-		case "int", "uint", "fixed",
-			"string", "array", "fd":
-			fmt.Fprintf(w, "%s %s\n", argName, typeToGoTypeMap[arg.Type])
-		}
-	}
-	fmt.Fprintf(w, "}\n")
-
-	// Event handler interface
-	fmt.Fprintf(w, "type %s%sHandlerFunc func(%s%sEvent)\n", ifaceName, eventName, ifaceName, eventName)
-
-	// Set handler
-	fmt.Fprintf(w, "// Set%sHandler : sets handler for %s%sEvent\n", eventName, ifaceName, eventName)
-	fmt.Fprintf(w, "func (i *%s) Set%sHandler(f %s%sHandlerFunc) {\n", ifaceName, eventName, ifaceName, eventName)
-	fmt.Fprintf(w, "i.%sHandler = f\n", eventNameLower)
-	fmt.Fprintf(w, "}\n")
-}
-
-func writeEventDispatcher(w io.Writer, ifaceName string, v Interface) {
-	if len(v.Events) == 0 {
-		return
-	}
-
-	fmt.Fprintf(w, "func (i *%s) Dispatch(opcode uint32, fd int, data []byte) {\n", ifaceName)
-	fmt.Fprintf(w, "switch opcode {\n")
-	for i, e := range v.Events {
-		eventName := toCamel(e.Name)
-		eventNameLower := toLowerCamel(e.Name)
-
-		hasFd := false
-		for _, arg := range e.Args {
-			if arg.Type == "fd" {
-				hasFd = true
-				break
-			}
-		}
-
-		fmt.Fprintf(w, "case %d:\n", i)
-		fmt.Fprintf(w, "if i.%sHandler == nil {\n", eventNameLower)
-		if hasFd {
-			fmt.Fprintf(w, "if fd != -1 {\n")
-			fmt.Fprintf(w, "unix.Close(fd)\n")
-			fmt.Fprintf(w, "}\n")
-		}
-		fmt.Fprintf(w, "return\n")
-		fmt.Fprintf(w, "}\n")
-
-		fmt.Fprintf(w, "var e  %s%sEvent\n", ifaceName, eventName)
-
-		if len(e.Args) > 0 && (len(e.Args) != 1 || e.Args[0].Type != "fd") {
-			fmt.Fprintf(w, "l := 0\n")
-		}
-
-		for _, arg := range e.Args {
-			argName := toCamel(arg.Name)
-			argNameLower := toLowerCamel(arg.Name)
-
-			switch arg.Type {
-			case "object", "new_id":
-				if arg.Interface != "" {
-					argIface := toCamel(arg.Interface)
-
-					if !isLocalInterface(arg.Interface) {
-						if protocol.Name != "wayland" && strings.HasPrefix(arg.Interface, "wl_") {
-							argIface = "client." + toCamelPrefix(arg.Interface, "wl_")
-						} else if protocol.Name != "xdg_shell" && strings.HasPrefix(arg.Interface, "xdg_") {
-							argIface = "xdg_shell." + toCamelPrefix(arg.Interface, "xdg_")
-						}
-					}
-
-					if protocol.Name == "wayland" {
-						fmt.Fprintf(w, "e.%s = i.Context().GetProxy(Uint32(data[l :l+4])).(*%s)\n", argName, argIface)
-					} else {
-						fmt.Fprintf(w, "e.%s = i.Context().GetProxy(client.Uint32(data[l :l+4])).(*%s)\n", argName, argIface)
-					}
-				} else {
-					if protocol.Name == "wayland" {
-						fmt.Fprintf(w, "e.%s = i.Context().GetProxy(Uint32(data[l :l+4]))\n", argName)
-					} else {
-						fmt.Fprintf(w, "e.%s = i.Context().GetProxy(client.Uint32(data[l :l+4]))\n", argName)
-					}
-				}
-				fmt.Fprintf(w, "l += 4\n")
-
-			case "fd":
-				fmt.Fprintf(w, "e.%s = fd\n", argName)
-
-			case "uint":
-				if protocol.Name == "wayland" {
-					fmt.Fprintf(w, "e.%s = Uint32(data[l : l+4])\n", argName)
-				} else {
-					fmt.Fprintf(w, "e.%s = client.Uint32(data[l : l+4])\n", argName)
-				}
-				fmt.Fprintf(w, "l += 4\n")
-
-			case "int":
-				if protocol.Name == "wayland" {
-					fmt.Fprintf(w, "e.%s = int32(Uint32(data[l : l+4]))\n", argName)
-				} else {
-					fmt.Fprintf(w, "e.%s = int32(client.Uint32(data[l : l+4]))\n", argName)
-				}
-				fmt.Fprintf(w, "l += 4\n")
-
-			case "fixed":
-				if protocol.Name == "wayland" {
-					fmt.Fprintf(w, "e.%s = Fixed(data[l : l+4])\n", argName)
-				} else {
-					fmt.Fprintf(w, "e.%s = client.Fixed(data[l : l+4])\n", argName)
-				}
-				fmt.Fprintf(w, "l += 4\n")
-
-			case "string":
-				if protocol.Name == "wayland" {
-					fmt.Fprintf(w, "%sLen := PaddedLen(int(Uint32(data[l : l+4])))\n", argNameLower)
-				} else {
-					fmt.Fprintf(w, "%sLen := client.PaddedLen(int(client.Uint32(data[l : l+4])))\n", argNameLower)
-				}
-				fmt.Fprintf(w, "l += 4\n")
-				if protocol.Name == "wayland" {
-					fmt.Fprintf(w, "e.%s = String(data[l : l+%sLen])\n", argName, argNameLower)
-				} else {
-					fmt.Fprintf(w, "e.%s = client.String(data[l : l+%sLen])\n", argName, argNameLower)
-				}
-				fmt.Fprintf(w, "l += %sLen\n", argNameLower)
-
-			case "array":
-				if protocol.Name == "wayland" {
-					fmt.Fprintf(w, "%sLen := int(Uint32(data[l : l+4]))\n", argNameLower)
-				} else {
-					fmt.Fprintf(w, "%sLen := int(client.Uint32(data[l : l+4]))\n", argNameLower)
-				}
-				fmt.Fprintf(w, "l += 4\n")
-				fmt.Fprintf(w, "e.%s = make([]byte, %sLen)\n", argName, argNameLower)
-				fmt.Fprintf(w, "copy(e.%s, data[l:l+%sLen])\n", argName, argNameLower)
-				fmt.Fprintf(w, "l += %sLen\n", argNameLower)
-			}
-		}
-
-		fmt.Fprintf(w, "\ni.%sHandler(e)\n", eventNameLower)
-	}
-	fmt.Fprintf(w, "}\n")
-	fmt.Fprintf(w, "}\n")
-}
-
 func toCamel(s string) string {
-	s = strings.TrimPrefix(s, prefix)
-	s = strings.TrimSuffix(s, suffix)
-	s = strcase.ToCamel(s)
-	return s
+	return toCamelTrim(s, prefix, suffix)
 }
 
-// Same as toCamel but with custom prefix to trim
-func toCamelPrefix(s string, prefix string) string {
+// Same as toCamel but with custom prefix and suffix to trim
+func toCamelTrim(s string, prefix string, suffix string) string {
 	s = strings.TrimPrefix(s, prefix)
+	s = strings.TrimSuffix(s, suffix)
 	s = strcase.ToCamel(s)
 	return s
 }
@@ -789,9 +280,11 @@ func comment(s string) string {
 	return strings.TrimSuffix(sb.String(), "// \n")
 }
 
-func hasDestructor(v Interface) bool {
+// hasDestroy reports whether the interface has a destroy request, else a
+// Destroy method is generated.
+func hasDestroy(v Interface) bool {
 	for _, r := range v.Requests {
-		if r.Type == "destructor" {
+		if r.Name == "destroy" {
 			return true
 		}
 	}
@@ -799,6 +292,62 @@ func hasDestructor(v Interface) bool {
 	return false
 }
 
+// releaseRequest returns the destructor request without arguments the
+// generated Destroy method sends, such as wl_shm.release, if any.
+func releaseRequest(v Interface) *Request {
+	for i, r := range v.Requests {
+		if r.Type == "destructor" && len(r.Args) == 0 {
+			return &v.Requests[i]
+		}
+	}
+
+	return nil
+}
+
+// qualifyEnums prefixes the enum references of arguments that name an
+// enum of their own interface with the interface name.
+func qualifyEnums() {
+	for _, v := range protocol.Interfaces {
+		for i := range v.Requests {
+			qualifyArgEnums(v.Name, v.Requests[i].Args)
+		}
+		for i := range v.Events {
+			qualifyArgEnums(v.Name, v.Events[i].Args)
+		}
+	}
+}
+
+func qualifyArgEnums(iface string, args []Arg) {
+	for i, arg := range args {
+		if arg.Enum != "" && !strings.Contains(arg.Enum, ".") {
+			args[i].Enum = iface + "." + arg.Enum
+		}
+	}
+}
+
+// argType returns the Go type of an int or uint argument, the generated
+// enum type when it references one.
+func argType(arg Arg) string {
+	if arg.Enum == "" {
+		return typeToGoTypeMap[arg.Type]
+	}
+
+	iface, enum, _ := strings.Cut(arg.Enum, ".")
+	if isLocalInterface(iface) {
+		return toCamel(iface) + toCamel(enum)
+	}
+	return foreignEnumType(iface, enum)
+}
+
+// proxyType returns the type of object arguments without interface.
+func proxyType() string {
+	if protocol.Name == "wayland" {
+		return "Proxy"
+	}
+	useImport("client", clientImportPath)
+	return "client.Proxy"
+}
+
 func isLocalInterface(iface string) bool {
 	for _, v := range protocol.Interfaces {
 		if v.Name == iface {
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "Update the golden files instead of comparing with them")
+
+// goldenTests are generated from testdata and compared with the
+// committed output, which is a package of its own so that it is also
+// compiled and round-trip tested.
+var goldenTests = []struct {
+	xml    string
+	golden string
+	// api is the golden file of -api, if any
+	api    string
+	pkg    string
+	arrays []arrayMapping
+	// destructors are the events given with -destructor-event
+	destructors []string
+	fixed       bool
+}{
+	{
+		xml:    "testdata/fixture.xml",
+		golden: "internal/fixture/fixture.go",
+		api:    "internal/fixture/fixture_api.go",
+		pkg:    "fixture",
+		arrays: []arrayMapping{
+			{arg: "fixture_manager.arrays.keys", elem: "uint32"},
+		},
+		destructors: []string{"fixture_thing.expired"},
+	},
+	{
+		xml:    "testdata/fixed.xml",
+		golden: "internal/fixed/fixed.go",
+		pkg:    "fixed",
+		fixed:  true,
+	},
+}
+
+func TestGolden(t *testing.T) {
+	for _, tt := range goldenTests {
+		t.Run(tt.pkg, func(t *testing.T) {
+			packageName, prefix, suffix = tt.pkg, "", ""
+			protocol = Protocol{}
+			importMappings = nil
+			arrayMappings = tt.arrays
+			destructorEvents = tt.destructors
+			fixedType = tt.fixed
+
+			dir := t.TempDir()
+			out := filepath.Join(dir, filepath.Base(tt.golden))
+			goldens := map[string]string{out: tt.golden}
+			apiFile = ""
+			if tt.api != "" {
+				apiFile = filepath.Join(dir, filepath.Base(tt.api))
+				goldens[apiFile] = tt.api
+			}
+			generate(tt.xml, tt.xml, out)
+
+			for out, golden := range goldens {
+				compareGolden(t, out, golden)
+			}
+		})
+	}
+}
+
+func compareGolden(t *testing.T, out string, golden string) {
+	t.Helper()
+
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *update {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		gotLines := bytes.Split(got, []byte("\n"))
+		wantLines := bytes.Split(want, []byte("\n"))
+		n := 0
+		for n < len(gotLines) && n < len(wantLines) && bytes.Equal(gotLines[n], wantLines[n]) {
+			n++
+		}
+		t.Errorf("%s:%d: generated code differs, run go test -update if the change is expected", golden, n+1)
+	}
+}
+
+func TestTemplates(t *testing.T) {
+	dir := t.TempDir()
+	custom := `{{define "file"}}// Code generated by go-wayland-scanner. DO NOT EDIT.
+
+package {{.Package}}
+{{range .Imports}}import {{.}}
+{{end}}{{.Body}}{{end}}
+`
+	if err := os.WriteFile(filepath.Join(dir, "file.tmpl"), []byte(custom), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	templatesDir, templates = dir, nil
+	t.Cleanup(func() { templatesDir, templates = "", nil })
+
+	packageName, prefix, suffix = "fixture", "", ""
+	protocol = Protocol{}
+	importMappings = nil
+	arrayMappings = nil
+	destructorEvents = nil
+	apiFile = ""
+
+	out := filepath.Join(t.TempDir(), "fixture.go")
+	generate("testdata/fixture.xml", "testdata/fixture.xml", out)
+
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(got, []byte("// Code generated by go-wayland-scanner. DO NOT EDIT.\n\npackage fixture\n")) {
+		t.Errorf("redefined file template not used, got:\n%s", got[:bytes.IndexByte(got, '{')])
+	}
+	if !bytes.Contains(got, []byte("func (i *FixtureManager) Dispatch(")) {
+		t.Error("default templates not used for the body")
+	}
+}
+
+// TestManifest checks that the generated packages of the module are up to
+// date with their vendored XML files.
+func TestManifest(t *testing.T) {
+	const protocolDir = "../../wayland/protocol"
+
+	entries, err := loadManifest(filepath.Join(protocolDir, "manifest.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The outputs are relative to the manifest, which is copied next to
+	// the vendored XML files so that they are written in dir
+	dir := filepath.Join(t.TempDir(), "protocol")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	xmlDir, err := filepath.Abs(filepath.Join(protocolDir, "xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(xmlDir, filepath.Join(dir, "xml")); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(filepath.Join(protocolDir, "manifest.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "manifest.json"), b, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, e := range entries {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, e.Output)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	importMappings = nil
+	arrayMappings = nil
+	destructorEvents = nil
+	fixedType = false
+	t.Cleanup(func() { apiFile = "" })
+	if err := generateManifest(filepath.Join(dir, "manifest.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, e := range entries {
+		for _, out := range []string{e.Output, e.API} {
+			if out == "" {
+				continue
+			}
+			got, err := os.ReadFile(filepath.Join(dir, out))
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := os.ReadFile(filepath.Join(protocolDir, out))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s is out of date with %s, run go generate in wayland/protocol", out, e.XML)
+			}
+		}
+	}
+}
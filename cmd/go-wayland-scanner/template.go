@@ -0,0 +1,377 @@
+package main
+
+import (
+	"embed"
+	"fmt"
+	"go/doc"
+	"io"
+	"log"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// defaultTemplates generate the code, they can be redefined or completed
+// with -templates.
+//
+// The body of the file is generated first by the "protocol" template,
+// executed with the Protocol, then the "file" template is executed with
+// a fileData holding the body and the imports it uses. The other
+// templates are only called by these two, each one documents what it is
+// executed with.
+//
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+// fileData is the data of the "file" template.
+type fileData struct {
+	Protocol *Protocol
+	// Source is the location of the protocol XML file.
+	Source  string
+	Package string
+	// Imports are the import specs of the packages used by Body.
+	Imports []string
+	Body    string
+}
+
+// templateFuncs are the helpers available to the templates, besides the
+// builtin functions of text/template.
+var templateFuncs = template.FuncMap{
+	// Names
+	"camel":      toCamel,
+	"lowerCamel": toLowerCamel,
+
+	// Documentation
+	"synopsis": doc.Synopsis,
+	"comment":  comment,
+
+	// Types and imports
+	"client":           clientPrefix,
+	"useImport":        func(pkg, importPath string) string { useImport(pkg, importPath); return "" },
+	"proxyType":        proxyType,
+	"ifaceType":        ifaceType,
+	"ifaceConstructor": ifaceConstructor,
+	"ifaceAPI":         ifaceAPI,
+	"ifaceFake":        ifaceFake,
+	"apiType":          apiType,
+	"argType":          argType,
+	"goType":           goType,
+	"arrayElem":        arrayElemType,
+	"fixedType":        func() bool { return fixedType },
+
+	// Messages
+	"hasDestroy":     hasDestroy,
+	"releaseRequest": releaseRequest,
+	"lastFd":         lastFd,
+	"hasNewID":       hasNewID,
+	"constSize":      constSize,
+	"requestSize":    requestSize,
+	"argString":      argString,
+	"argAttr":        argAttr,
+
+	// Enums
+	"bitfieldZero":  bitfieldZero,
+	"bitfieldFlags": bitfieldFlags,
+
+	"dict": dict,
+}
+
+var (
+	templatesDir string
+	templates    *template.Template
+)
+
+// parseTemplates parses the default templates, then the *.tmpl files of
+// dir, if any, which can redefine them.
+func parseTemplates(dir string) (*template.Template, error) {
+	t, err := template.New("").Funcs(templateFuncs).ParseFS(defaultTemplates, "templates/*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	if dir == "" {
+		return t, nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no *.tmpl file in %s", dir)
+	}
+	return t.ParseFiles(files...)
+}
+
+func executeTemplate(w io.Writer, name string, data any) {
+	if templates == nil {
+		t, err := parseTemplates(templatesDir)
+		if err != nil {
+			log.Fatalf("unable to parse templates: %v", err)
+		}
+		templates = t
+	}
+
+	if err := templates.ExecuteTemplate(w, name, data); err != nil {
+		log.Fatalf("unable to execute template: %v", err)
+	}
+}
+
+// clientPrefix returns the qualifier of the identifiers of the client
+// package.
+func clientPrefix() string {
+	if protocol.Name == "wayland" {
+		return ""
+	}
+	useImport("client", clientImportPath)
+	return "client."
+}
+
+// apiType is goType for the API interfaces, which take the API interfaces
+// of objects instead of proxies.
+func apiType(iface string, message string, arg Arg) string {
+	if (arg.Type == "object" || arg.Type == "new_id") && arg.Interface != "" {
+		return ifaceAPI(arg.Interface)
+	}
+	return goType(iface, message, arg)
+}
+
+// goType returns the Go type of an argument of a message, as a request
+// parameter or an event field. Nullable strings are *string, nil being
+// null.
+func goType(iface string, message string, arg Arg) string {
+	switch arg.Type {
+	case "object", "new_id":
+		if arg.Interface != "" {
+			return "*" + ifaceType(arg.Interface)
+		}
+		return proxyType()
+
+	case "int", "uint":
+		return argType(arg)
+
+	case "string":
+		if arg.AllowNull {
+			return "*string"
+		}
+
+	case "fixed":
+		if fixedType {
+			return clientPrefix() + "Fixed"
+		}
+
+	case "array":
+		if elem := arrayElemType(iface, message, arg); elem != "" {
+			return "[]" + elem
+		}
+	}
+	return typeToGoTypeMap[arg.Type]
+}
+
+// argString returns the expression formatting the field of an event
+// argument as a string, for the String method of the event.
+func argString(iface string, message string, arg Arg) string {
+	v := "e." + toCamel(arg.Name)
+	switch arg.Type {
+	case "object", "new_id":
+		return clientPrefix() + "ObjectString(" + v + ")"
+
+	case "int", "uint":
+		if arg.Enum != "" {
+			return enumString(arg, v)
+		}
+		useImport("strconv", "strconv")
+		if arg.Type == "int" {
+			return "strconv.FormatInt(int64(" + v + "), 10)"
+		}
+		return "strconv.FormatUint(uint64(" + v + "), 10)"
+
+	case "fixed":
+		if fixedType {
+			return v + ".String()"
+		}
+		useImport("strconv", "strconv")
+		return "strconv.FormatFloat(" + v + ", 'g', -1, 64)"
+
+	case "string":
+		if arg.AllowNull {
+			return clientPrefix() + "NullableString(" + v + ")"
+		}
+		useImport("strconv", "strconv")
+		return "strconv.Quote(" + v + ")"
+
+	case "array":
+		if arrayElemType(iface, message, arg) != "" {
+			useImport("fmt", "fmt")
+			return "fmt.Sprint(" + v + ")"
+		}
+		useImport("strconv", "strconv")
+		return `"array[" + strconv.Itoa(len(` + v + `)) + "]"`
+
+	case "fd":
+		useImport("strconv", "strconv")
+		return "strconv.Itoa(" + v + ")"
+	}
+	return `""`
+}
+
+// argAttr returns the expression of the slog.Attr of the field of an
+// event argument, for the LogValue method of the event.
+func argAttr(iface string, message string, arg Arg) string {
+	useImport("slog", "log/slog")
+	v := "e." + toCamel(arg.Name)
+	key := strconv.Quote(arg.Name)
+	switch arg.Type {
+	case "object", "new_id":
+		return "slog.String(" + key + ", " + clientPrefix() + "ObjectString(" + v + "))"
+
+	case "int", "uint":
+		if arg.Enum != "" {
+			return "slog.String(" + key + ", " + enumString(arg, v) + ")"
+		}
+		if arg.Type == "int" {
+			return "slog.Int64(" + key + ", int64(" + v + "))"
+		}
+		return "slog.Uint64(" + key + ", uint64(" + v + "))"
+
+	case "fixed":
+		if fixedType {
+			return "slog.Float64(" + key + ", " + v + ".Float64())"
+		}
+		return "slog.Float64(" + key + ", " + v + ")"
+
+	case "string":
+		if arg.AllowNull {
+			return "slog.Any(" + key + ", " + clientPrefix() + "NullableValue(" + v + "))"
+		}
+		return "slog.String(" + key + ", " + v + ")"
+
+	case "fd":
+		return "slog.Int(" + key + ", " + v + ")"
+	}
+	return "slog.Any(" + key + ", " + v + ")"
+}
+
+// enumString returns the expression formatting an enum value v: the set
+// flags of the bitfields of the protocol, the name of the value of the
+// other enums.
+func enumString(arg Arg, v string) string {
+	iface, enum, _ := strings.Cut(arg.Enum, ".")
+	if owner := findInterface(&protocol, iface); owner != nil {
+		for _, e := range owner.Enums {
+			if e.Name == enum && e.Bitfield {
+				return v + ".String()"
+			}
+		}
+	}
+	return clientPrefix() + "EnumString(" + v + ".Name(), uint32(" + v + "))"
+}
+
+// hasNewID reports whether a message creates objects.
+func hasNewID(args []Arg) bool {
+	for _, arg := range args {
+		if arg.Type == "new_id" {
+			return true
+		}
+	}
+	return false
+}
+
+// lastFd returns the last fd argument, the one sent with a request, or
+// nil.
+func lastFd(args []Arg) *Arg {
+	var fd *Arg
+	for i := range args {
+		if args[i].Type == "fd" {
+			fd = &args[i]
+		}
+	}
+	return fd
+}
+
+// constSize reports whether the size of a request is known at compile
+// time.
+func constSize(args []Arg) bool {
+	for _, arg := range args {
+		switch arg.Type {
+		case "string", "array":
+			return false
+		case "new_id":
+			if arg.Interface == "" {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// requestSize returns the expression of the size of a request, using the
+// <arg>Len variables of its strings and arrays.
+func requestSize(args []Arg) string {
+	sizes := []string{"8"}
+	for _, arg := range args {
+		switch arg.Type {
+		case "new_id":
+			if arg.Interface != "" {
+				sizes = append(sizes, "4")
+			} else {
+				sizes = append(sizes, "(4 + ifaceLen)", "4", "4")
+			}
+
+		case "object", "int", "uint", "fixed":
+			sizes = append(sizes, "4")
+
+		case "string", "array":
+			sizes = append(sizes, fmt.Sprintf("(4 + %sLen)", toLowerCamel(arg.Name)))
+		}
+	}
+	return strings.Join(sizes, " + ")
+}
+
+// bitfieldZero returns the name of the entry of a bitfield without any
+// flag set, "0" when there is none.
+func bitfieldZero(e Enum) (string, error) {
+	zero := "0"
+	for _, entry := range e.Entries {
+		v, err := strconv.ParseUint(entry.Value, 0, 32)
+		if err != nil {
+			return "", fmt.Errorf("invalid value %q of %s entry %s", entry.Value, e.Name, entry.Name)
+		}
+		if v == 0 {
+			zero = entry.Name
+		}
+	}
+	return zero, nil
+}
+
+// bitfieldFlags returns the entries of a bitfield with a non-zero value.
+func bitfieldFlags(e Enum) ([]Entry, error) {
+	flags := []Entry{}
+	for _, entry := range e.Entries {
+		v, err := strconv.ParseUint(entry.Value, 0, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q of %s entry %s", entry.Value, e.Name, entry.Name)
+		}
+		if v != 0 {
+			flags = append(flags, entry)
+		}
+	}
+	return flags, nil
+}
+
+// dict builds a map from key and value pairs, to call templates with
+// several values.
+func dict(pairs ...any) (map[string]any, error) {
+	if len(pairs)%2 != 0 {
+		return nil, fmt.Errorf("dict: odd number of arguments")
+	}
+	m := make(map[string]any, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("dict: key %v is not a string", pairs[i])
+		}
+		m[key] = pairs[i+1]
+	}
+	return m, nil
+}
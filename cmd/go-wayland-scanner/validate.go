@@ -0,0 +1,272 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// diagnostic is a problem found in a protocol XML file, which would make
+// the scanner generate broken or incomplete code.
+type diagnostic struct {
+	line int
+	path string
+	text string
+}
+
+// format returns the diagnostic as "file:line: path: text", the line is
+// left out when it is unknown.
+func (d diagnostic) format(file string) string {
+	pos := file
+	if d.line > 0 {
+		pos += ":" + strconv.Itoa(d.line)
+	}
+	return fmt.Sprintf("%s: %s: %s", pos, d.path, d.text)
+}
+
+// elementLines maps the elements of a protocol XML document to the line
+// they start on. They are keyed by their path below the root, each step
+// being the element name and its index among the siblings of that name,
+// such as "interface.1/request.0/arg.2".
+func elementLines(data []byte) (map[string]int, error) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	lines := map[string]int{}
+	var path []string
+	counts := []map[string]int{{}}
+
+	for {
+		// The position before reading a start element is its '<'
+		line, _ := d.InputPos()
+		tok, err := d.Token()
+		if err == io.EOF {
+			return lines, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			siblings := counts[len(counts)-1]
+			path = append(path, t.Name.Local+"."+strconv.Itoa(siblings[t.Name.Local]))
+			siblings[t.Name.Local]++
+			counts = append(counts, map[string]int{})
+			if len(path) > 1 {
+				lines[strings.Join(path[1:], "/")] = line
+			}
+
+		case xml.EndElement:
+			path = path[:len(path)-1]
+			counts = counts[:len(counts)-1]
+		}
+	}
+}
+
+// message is a request or an event.
+type message struct {
+	Kind            string
+	Name            string
+	Type            string
+	Since           int
+	DeprecatedSince int
+	Args            []Arg
+}
+
+type validator struct {
+	protocol *Protocol
+	lines    map[string]int
+	diags    []diagnostic
+}
+
+func (v *validator) errorf(key string, path string, format string, args ...any) {
+	v.diags = append(v.diags, diagnostic{
+		line: v.lines[key],
+		path: path,
+		text: fmt.Sprintf(format, args...),
+	})
+}
+
+// unique reports an element whose name is empty or was already seen
+// among its siblings.
+func (v *validator) unique(seen map[string]bool, key string, path string, kind string, name string) {
+	switch {
+	case name == "":
+		v.errorf(key, path, "%s has no name", kind)
+	case seen[name]:
+		v.errorf(key, path, "duplicate %s %s", kind, name)
+	}
+	seen[name] = true
+}
+
+// validate checks a protocol for what the scanner can't generate code
+// for, lines are the ones returned by elementLines for its XML.
+func validate(p *Protocol, lines map[string]int) []diagnostic {
+	v := &validator{protocol: p, lines: lines}
+
+	ifaces := map[string]bool{}
+	for i := range p.Interfaces {
+		iface := &p.Interfaces[i]
+		key := "interface." + strconv.Itoa(i)
+		v.unique(ifaces, key, p.Name, "interface", iface.Name)
+		if iface.Version < 1 {
+			v.errorf(key, iface.Name, "version %d is not a positive integer", iface.Version)
+		}
+
+		requests := map[string]bool{}
+		for j, r := range iface.Requests {
+			m := message{"request", r.Name, r.Type, r.Since, r.DeprecatedSince, r.Args}
+			v.message(iface, fmt.Sprintf("%s/request.%d", key, j), m, requests)
+		}
+		events := map[string]bool{}
+		for j, e := range iface.Events {
+			m := message{"event", e.Name, e.Type, e.Since, e.DeprecatedSince, e.Args}
+			v.message(iface, fmt.Sprintf("%s/event.%d", key, j), m, events)
+		}
+
+		enums := map[string]bool{}
+		for j := range iface.Enums {
+			v.enum(iface, fmt.Sprintf("%s/enum.%d", key, j), &iface.Enums[j], enums)
+		}
+	}
+
+	return v.diags
+}
+
+func (v *validator) message(iface *Interface, key string, m message, seen map[string]bool) {
+	path := iface.Name + "." + m.Name
+	v.unique(seen, key, iface.Name, m.Kind, m.Name)
+	v.since(iface, key, path, m.Since, m.DeprecatedSince)
+	if m.Type != "" && m.Type != "destructor" {
+		v.errorf(key, path, "unknown %s type %q", m.Kind, m.Type)
+	}
+
+	args := map[string]bool{}
+	untyped := 0
+	for k, arg := range m.Args {
+		argKey := fmt.Sprintf("%s/arg.%d", key, k)
+		argPath := path + "." + arg.Name
+		v.unique(args, argKey, path, "argument", arg.Name)
+
+		switch arg.Type {
+		case "object", "new_id":
+			if arg.Interface != "" {
+				v.ifaceRef(argKey, argPath, arg.Interface)
+				break
+			}
+			if arg.Type == "object" {
+				break
+			}
+			// Requests take the interface name and version of the
+			// object as iface and version parameters
+			untyped++
+			if untyped > 1 {
+				v.errorf(argKey, argPath, "more than one new_id without interface")
+			}
+			for _, other := range m.Args {
+				if m.Kind == "request" && (other.Name == "iface" || other.Name == "version") {
+					v.errorf(argKey, argPath, "new_id without interface with an argument named %s", other.Name)
+				}
+			}
+
+		case "int", "uint", "fixed", "string", "array", "fd":
+			if arg.Interface != "" {
+				v.errorf(argKey, argPath, "interface %s on a %s argument", arg.Interface, arg.Type)
+			}
+
+		case "":
+			v.errorf(argKey, argPath, "argument has no type")
+
+		default:
+			v.errorf(argKey, argPath, "unknown argument type %q", arg.Type)
+		}
+
+		if arg.Enum != "" {
+			if arg.Type != "int" && arg.Type != "uint" {
+				v.errorf(argKey, argPath, "enum %s on a %s argument", arg.Enum, arg.Type)
+			}
+			v.enumRef(iface, argKey, argPath, arg.Enum)
+		}
+		if arg.AllowNull && arg.Type != "object" && arg.Type != "new_id" && arg.Type != "string" && arg.Type != "array" {
+			v.errorf(argKey, argPath, "allow-null on a %s argument", arg.Type)
+		}
+	}
+}
+
+// since checks the since and deprecated-since attributes of a message or
+// an enum entry against the version of its interface.
+func (v *validator) since(iface *Interface, key string, path string, since int, deprecated int) {
+	if since > iface.Version {
+		v.errorf(key, path, "since %d is above the interface version %d", since, iface.Version)
+	}
+	if deprecated == 0 {
+		return
+	}
+	if deprecated > iface.Version {
+		v.errorf(key, path, "deprecated-since %d is above the interface version %d", deprecated, iface.Version)
+	}
+	if since == 0 {
+		since = 1
+	}
+	if deprecated <= since {
+		v.errorf(key, path, "deprecated-since %d is not above since %d", deprecated, since)
+	}
+}
+
+// ifaceRef reports an interface which is neither part of the protocol nor
+// mapped to a Go package.
+func (v *validator) ifaceRef(key string, path string, name string) {
+	if findInterface(v.protocol, name) != nil {
+		return
+	}
+	if _, ok := lookupImportMapping(name); !ok {
+		v.errorf(key, path, "interface %s is not defined in %s, map it to its Go package with -import or -imports", name, v.protocol.Name)
+	}
+}
+
+// enumRef reports a reference to an enum which doesn't exist, enums of
+// other protocols are only checked to come from a mapped interface.
+func (v *validator) enumRef(iface *Interface, key string, path string, ref string) {
+	owner, enum := iface, ref
+	if name, e, ok := strings.Cut(ref, "."); ok {
+		owner, enum = findInterface(v.protocol, name), e
+		if owner == nil {
+			v.ifaceRef(key, path, name)
+			return
+		}
+	}
+	for _, e := range owner.Enums {
+		if e.Name == enum {
+			return
+		}
+	}
+	v.errorf(key, path, "unknown enum %s", ref)
+}
+
+func (v *validator) enum(iface *Interface, key string, e *Enum, seen map[string]bool) {
+	path := iface.Name + "." + e.Name
+	v.unique(seen, key, iface.Name, "enum", e.Name)
+	v.since(iface, key, path, e.Since, 0)
+
+	entries := map[string]bool{}
+	for k, entry := range e.Entries {
+		entryKey := fmt.Sprintf("%s/entry.%d", key, k)
+		entryPath := path + "." + entry.Name
+		v.unique(entries, entryKey, path, "entry", entry.Name)
+		v.since(iface, entryKey, entryPath, entry.Since, entry.DeprecatedSince)
+		if _, err := strconv.ParseUint(entry.Value, 0, 32); err != nil {
+			v.errorf(entryKey, entryPath, "value %q is not an unsigned 32-bit integer", entry.Value)
+		}
+	}
+}
+
+func findInterface(p *Protocol, name string) *Interface {
+	for i := range p.Interfaces {
+		if p.Interfaces[i].Name == name {
+			return &p.Interfaces[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/xml"
+	"os"
+	"strings"
+	"testing"
+)
+
+const invalidXML = `<protocol name="broken">
+  <interface name="broken_manager" version="2">
+    <request name="create">
+      <arg name="id" type="new_id" interface="broken_thing"/>
+      <arg name="size" type="uint32"/>
+    </request>
+    <request name="create" since="3">
+      <arg name="id" type="new_id" interface="other_thing"/>
+    </request>
+    <request name="bind">
+      <arg name="id" type="new_id"/>
+      <arg name="version" type="uint"/>
+    </request>
+    <event name="created">
+      <arg name="id" type="new_id"/>
+      <arg name="mode" type="string" enum="mode"/>
+      <arg name="flags" type="uint" enum="flags"/>
+    </event>
+    <enum name="mode">
+      <entry name="off" value="0" deprecated-since="1"/>
+      <entry name="on" value="on"/>
+    </enum>
+  </interface>
+  <interface name="broken_thing" version="1"/>
+</protocol>`
+
+func TestValidate(t *testing.T) {
+	p := Protocol{}
+	if err := xml.Unmarshal([]byte(invalidXML), &p); err != nil {
+		t.Fatal(err)
+	}
+	lines, err := elementLines([]byte(invalidXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	importMappings = nil
+
+	var got []string
+	for _, d := range validate(&p, lines) {
+		got = append(got, d.format("broken.xml"))
+	}
+	want := []string{
+		`broken.xml:5: broken_manager.create.size: unknown argument type "uint32"`,
+		`broken.xml:7: broken_manager: duplicate request create`,
+		`broken.xml:7: broken_manager.create: since 3 is above the interface version 2`,
+		`broken.xml:8: broken_manager.create.id: interface other_thing is not defined in broken, map it to its Go package with -import or -imports`,
+		`broken.xml:11: broken_manager.bind.id: new_id without interface with an argument named version`,
+		`broken.xml:16: broken_manager.created.mode: enum mode on a string argument`,
+		`broken.xml:17: broken_manager.created.flags: unknown enum flags`,
+		`broken.xml:20: broken_manager.mode.off: deprecated-since 1 is not above since 1`,
+		`broken.xml:21: broken_manager.mode.on: value "on" is not an unsigned 32-bit integer`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+// TestValidateFixture checks that the fixture protocol is valid.
+func TestValidateFixture(t *testing.T) {
+	data, err := os.ReadFile("testdata/fixture.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := Protocol{}
+	if err := xml.Unmarshal(data, &p); err != nil {
+		t.Fatal(err)
+	}
+	lines, err := elementLines(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	importMappings = nil
+
+	for _, d := range validate(&p, lines) {
+		t.Error(d.format("testdata/fixture.xml"))
+	}
+}
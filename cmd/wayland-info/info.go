@@ -0,0 +1,484 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+	presentation_time "github.com/rajveermalviya/go-wayland/wayland/stable/presentation-time"
+	linux_dmabuf "github.com/rajveermalviya/go-wayland/wayland/unstable/linux-dmabuf-v1"
+	xdg_output "github.com/rajveermalviya/go-wayland/wayland/unstable/xdg-output-v1"
+	"golang.org/x/sys/unix"
+)
+
+// Newest versions of the globals this command handles, binding a newer
+// version could bring events it doesn't know about.
+const (
+	shmVersion          = 1
+	outputVersion       = 4
+	seatVersion         = 8
+	xdgOutputVersion    = 3
+	dmabufVersion       = 4
+	presentationVersion = 1
+)
+
+// Info is everything printed by the command.
+type Info struct {
+	Globals []*Global `json:"globals"`
+}
+
+// Global is an advertised global, with the details of the interfaces
+// the command knows about.
+type Global struct {
+	Name      uint32 `json:"name"`
+	Interface string `json:"interface"`
+	Version   uint32 `json:"version"`
+
+	Shm          *ShmInfo          `json:"shm,omitempty"`
+	Output       *OutputInfo       `json:"output,omitempty"`
+	Seat         *SeatInfo         `json:"seat,omitempty"`
+	Dmabuf       *DmabufInfo       `json:"dmabuf,omitempty"`
+	Presentation *PresentationInfo `json:"presentation,omitempty"`
+}
+
+type ShmInfo struct {
+	Formats []Format `json:"formats"`
+}
+
+// Format is a wl_shm or DRM fourcc format code.
+type Format struct {
+	Code uint32 `json:"code"`
+	Name string `json:"name,omitempty"`
+}
+
+type OutputInfo struct {
+	Name           string `json:"name,omitempty"`
+	Description    string `json:"description,omitempty"`
+	Make           string `json:"make"`
+	Model          string `json:"model"`
+	X              int32  `json:"x"`
+	Y              int32  `json:"y"`
+	PhysicalWidth  int32  `json:"physical_width"`
+	PhysicalHeight int32  `json:"physical_height"`
+	Subpixel       string `json:"subpixel"`
+	Transform      string `json:"transform"`
+	Scale          int32  `json:"scale"`
+	Modes          []Mode `json:"modes"`
+
+	// Logical is set when the compositor supports zxdg_output_manager_v1.
+	Logical *LogicalOutput `json:"logical,omitempty"`
+}
+
+type Mode struct {
+	Width     int32 `json:"width"`
+	Height    int32 `json:"height"`
+	Refresh   int32 `json:"refresh_mhz"`
+	Current   bool  `json:"current"`
+	Preferred bool  `json:"preferred"`
+}
+
+type LogicalOutput struct {
+	X           int32  `json:"x"`
+	Y           int32  `json:"y"`
+	Width       int32  `json:"width"`
+	Height      int32  `json:"height"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type SeatInfo struct {
+	Name         string   `json:"name,omitempty"`
+	Capabilities []string `json:"capabilities"`
+}
+
+type DmabufInfo struct {
+	// Formats are advertised by versions older than 4.
+	Formats []DmabufFormat `json:"formats,omitempty"`
+	// Feedback is the default feedback of version 4 and newer.
+	Feedback *DmabufFeedback `json:"feedback,omitempty"`
+}
+
+// DmabufFormat is a format along with the modifiers supported with it.
+type DmabufFormat struct {
+	Format
+	Modifiers []Modifier `json:"modifiers,omitempty"`
+}
+
+type DmabufFeedback struct {
+	MainDevice string    `json:"main_device"`
+	Tranches   []Tranche `json:"tranches"`
+}
+
+type Tranche struct {
+	TargetDevice string         `json:"target_device"`
+	Flags        []string       `json:"flags"`
+	Formats      []DmabufFormat `json:"formats"`
+}
+
+// Modifier is a DRM format modifier, encoded in JSON as a hex string as
+// it doesn't fit in a float64.
+type Modifier uint64
+
+const (
+	modifierLinear  Modifier = 0
+	modifierInvalid Modifier = 0x00ffffffffffffff
+)
+
+func (m Modifier) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("0x%016x", uint64(m))), nil
+}
+
+func (m Modifier) String() string {
+	switch m {
+	case modifierLinear:
+		return "0x0000000000000000 (linear)"
+	case modifierInvalid:
+		return "0x00ffffffffffffff (implicit)"
+	}
+	return fmt.Sprintf("0x%016x", uint64(m))
+}
+
+type PresentationInfo struct {
+	ClockID uint32 `json:"clock_id"`
+	Clock   string `json:"clock"`
+}
+
+// gather lists the globals, binds the known ones and waits until all
+// their initial events are received.
+func gather(display *client.Display) (*Info, error) {
+	info := &Info{}
+
+	registry, err := display.GetRegistry()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get global registry object: %w", err)
+	}
+	registry.SetGlobalHandler(func(e client.RegistryGlobalEvent) {
+		info.Globals = append(info.Globals, &Global{Name: e.Name, Interface: e.Interface, Version: e.Version})
+	})
+	if err := roundtrip(display); err != nil {
+		return nil, err
+	}
+
+	var outputs []*client.Output
+	var outputInfos []*OutputInfo
+	var xdgOutputManager *xdg_output.OutputManager
+	for _, g := range info.Globals {
+		switch g.Interface {
+		case "wl_shm":
+			g.Shm = &ShmInfo{}
+			err = bindShm(registry, g)
+		case "wl_output":
+			g.Output = &OutputInfo{Modes: []Mode{}}
+			var output *client.Output
+			output, err = bindOutput(registry, g)
+			outputs = append(outputs, output)
+			outputInfos = append(outputInfos, g.Output)
+		case "wl_seat":
+			g.Seat = &SeatInfo{Capabilities: []string{}}
+			err = bindSeat(registry, g)
+		case "zwp_linux_dmabuf_v1":
+			g.Dmabuf = &DmabufInfo{}
+			err = bindDmabuf(registry, g)
+		case "wp_presentation":
+			g.Presentation = &PresentationInfo{}
+			err = bindPresentation(registry, g)
+		case "zxdg_output_manager_v1":
+			xdgOutputManager, err = client.Bind[xdg_output.OutputManager](registry, g.Name, minVersion(g.Version, xdgOutputVersion))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to bind %s: %w", g.Interface, err)
+		}
+	}
+
+	if xdgOutputManager != nil {
+		for i, output := range outputs {
+			if err := getXdgOutput(xdgOutputManager, output, outputInfos[i]); err != nil {
+				return nil, fmt.Errorf("unable to get xdg output: %w", err)
+			}
+		}
+	}
+
+	// every object is created by now, their initial events are all sent
+	// before the sync is done
+	if err := roundtrip(display); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func roundtrip(display *client.Display) error {
+	callback, err := display.Sync()
+	if err != nil {
+		return fmt.Errorf("unable to get sync callback: %w", err)
+	}
+
+	done := false
+	callback.SetDoneHandler(func(client.CallbackDoneEvent) {
+		done = true
+	})
+	for !done {
+		if err := display.Context().Dispatch(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func minVersion(advertised, supported uint32) uint32 {
+	if advertised < supported {
+		return advertised
+	}
+	return supported
+}
+
+func bindShm(registry *client.Registry, g *Global) error {
+	shm, err := client.Bind[client.Shm](registry, g.Name, minVersion(g.Version, shmVersion))
+	if err != nil {
+		return err
+	}
+	shm.SetFormatHandler(func(e client.ShmFormatEvent) {
+		g.Shm.Formats = append(g.Shm.Formats, Format{Code: uint32(e.Format), Name: e.Format.Name()})
+	})
+	return nil
+}
+
+func bindOutput(registry *client.Registry, g *Global) (*client.Output, error) {
+	info := g.Output
+	output, err := client.Bind[client.Output](registry, g.Name, minVersion(g.Version, outputVersion))
+	if err != nil {
+		return nil, err
+	}
+	output.SetGeometryHandler(func(e client.OutputGeometryEvent) {
+		info.X, info.Y = e.X, e.Y
+		info.PhysicalWidth, info.PhysicalHeight = e.PhysicalWidth, e.PhysicalHeight
+		info.Subpixel = e.Subpixel.Name()
+		info.Transform = e.Transform.Name()
+		info.Make, info.Model = e.Make, e.Model
+	})
+	output.SetModeHandler(func(e client.OutputModeEvent) {
+		info.Modes = append(info.Modes, Mode{
+			Width:     e.Width,
+			Height:    e.Height,
+			Refresh:   e.Refresh,
+			Current:   e.Flags.Has(client.OutputModeCurrent),
+			Preferred: e.Flags.Has(client.OutputModePreferred),
+		})
+	})
+	output.SetScaleHandler(func(e client.OutputScaleEvent) {
+		info.Scale = e.Factor
+	})
+	output.SetNameHandler(func(e client.OutputNameEvent) {
+		info.Name = e.Name
+	})
+	output.SetDescriptionHandler(func(e client.OutputDescriptionEvent) {
+		info.Description = e.Description
+	})
+	// scale is only sent from version 2, default to 1
+	info.Scale = 1
+	return output, nil
+}
+
+func getXdgOutput(manager *xdg_output.OutputManager, output *client.Output, info *OutputInfo) error {
+	xdgOutput, err := manager.GetXdgOutput(output)
+	if err != nil {
+		return err
+	}
+
+	logical := &LogicalOutput{}
+	info.Logical = logical
+	xdgOutput.SetLogicalPositionHandler(func(e xdg_output.OutputLogicalPositionEvent) {
+		logical.X, logical.Y = e.X, e.Y
+	})
+	xdgOutput.SetLogicalSizeHandler(func(e xdg_output.OutputLogicalSizeEvent) {
+		logical.Width, logical.Height = e.Width, e.Height
+	})
+	xdgOutput.SetNameHandler(func(e xdg_output.OutputNameEvent) {
+		logical.Name = e.Name
+	})
+	xdgOutput.SetDescriptionHandler(func(e xdg_output.OutputDescriptionEvent) {
+		logical.Description = e.Description
+	})
+	return nil
+}
+
+func bindSeat(registry *client.Registry, g *Global) error {
+	info := g.Seat
+	seat, err := client.Bind[client.Seat](registry, g.Name, minVersion(g.Version, seatVersion))
+	if err != nil {
+		return err
+	}
+	seat.SetCapabilitiesHandler(func(e client.SeatCapabilitiesEvent) {
+		info.Capabilities = []string{}
+		for _, c := range []client.SeatCapability{
+			client.SeatCapabilityPointer,
+			client.SeatCapabilityKeyboard,
+			client.SeatCapabilityTouch,
+		} {
+			if e.Capabilities.Has(c) {
+				info.Capabilities = append(info.Capabilities, c.Name())
+			}
+		}
+	})
+	seat.SetNameHandler(func(e client.SeatNameEvent) {
+		info.Name = e.Name
+	})
+	return nil
+}
+
+func bindPresentation(registry *client.Registry, g *Global) error {
+	presentation, err := client.Bind[presentation_time.Presentation](registry, g.Name, minVersion(g.Version, presentationVersion))
+	if err != nil {
+		return err
+	}
+	presentation.SetClockIdHandler(func(e presentation_time.PresentationClockIdEvent) {
+		g.Presentation.ClockID = e.ClkId
+		g.Presentation.Clock = clockName(e.ClkId)
+	})
+	return nil
+}
+
+func clockName(id uint32) string {
+	switch id {
+	case unix.CLOCK_REALTIME:
+		return "CLOCK_REALTIME"
+	case unix.CLOCK_MONOTONIC:
+		return "CLOCK_MONOTONIC"
+	case unix.CLOCK_MONOTONIC_RAW:
+		return "CLOCK_MONOTONIC_RAW"
+	case unix.CLOCK_REALTIME_COARSE:
+		return "CLOCK_REALTIME_COARSE"
+	case unix.CLOCK_MONOTONIC_COARSE:
+		return "CLOCK_MONOTONIC_COARSE"
+	case unix.CLOCK_BOOTTIME:
+		return "CLOCK_BOOTTIME"
+	}
+	return ""
+}
+
+// bindDmabuf collects the format and modifier events of old versions,
+// or the default feedback of version 4.
+func bindDmabuf(registry *client.Registry, g *Global) error {
+	info := g.Dmabuf
+	dmabuf, err := client.Bind[linux_dmabuf.LinuxDmabuf](registry, g.Name, minVersion(g.Version, dmabufVersion))
+	if err != nil {
+		return err
+	}
+	version := dmabuf.Version()
+
+	if version < 4 {
+		dmabuf.SetFormatHandler(func(e linux_dmabuf.LinuxDmabufFormatEvent) {
+			// version 3 sends the modifiers of each format as well
+			if version < 3 {
+				info.Formats = append(info.Formats, DmabufFormat{Format: drmFormat(e.Format)})
+			}
+		})
+		dmabuf.SetModifierHandler(func(e linux_dmabuf.LinuxDmabufModifierEvent) {
+			info.Formats = addModifier(info.Formats, e.Format, Modifier(uint64(e.ModifierHi)<<32|uint64(e.ModifierLo)))
+		})
+		return nil
+	}
+
+	feedback, err := dmabuf.GetDefaultFeedback()
+	if err != nil {
+		return err
+	}
+	info.Feedback = &DmabufFeedback{Tranches: []Tranche{}}
+	collectFeedback(feedback, info.Feedback)
+	return nil
+}
+
+func addModifier(formats []DmabufFormat, format uint32, modifier Modifier) []DmabufFormat {
+	for i := range formats {
+		if formats[i].Code == format {
+			formats[i].Modifiers = append(formats[i].Modifiers, modifier)
+			return formats
+		}
+	}
+	return append(formats, DmabufFormat{Format: drmFormat(format), Modifiers: []Modifier{modifier}})
+}
+
+// formatTableEntry is the layout of an entry of the format table sent
+// with zwp_linux_dmabuf_feedback_v1.format_table.
+type formatTableEntry struct {
+	format   uint32
+	modifier Modifier
+}
+
+func collectFeedback(feedback *linux_dmabuf.LinuxDmabufFeedback, info *DmabufFeedback) {
+	var table []formatTableEntry
+	var tranche *Tranche
+
+	feedback.SetFormatTableHandler(func(e linux_dmabuf.LinuxDmabufFeedbackFormatTableEvent) {
+		defer unix.Close(e.Fd)
+		data, err := unix.Mmap(e.Fd, 0, int(e.Size), unix.PROT_READ, unix.MAP_PRIVATE)
+		if err != nil {
+			return
+		}
+		defer unix.Munmap(data)
+
+		table = table[:0]
+		for i := 0; i+16 <= len(data); i += 16 {
+			table = append(table, formatTableEntry{
+				format:   binary.NativeEndian.Uint32(data[i : i+4]),
+				modifier: Modifier(binary.NativeEndian.Uint64(data[i+8 : i+16])),
+			})
+		}
+	})
+	feedback.SetMainDeviceHandler(func(e linux_dmabuf.LinuxDmabufFeedbackMainDeviceEvent) {
+		info.MainDevice = deviceName(e.Device)
+	})
+	startTranche := func() *Tranche {
+		if tranche == nil {
+			tranche = &Tranche{Flags: []string{}, Formats: []DmabufFormat{}}
+		}
+		return tranche
+	}
+	feedback.SetTrancheTargetDeviceHandler(func(e linux_dmabuf.LinuxDmabufFeedbackTrancheTargetDeviceEvent) {
+		startTranche().TargetDevice = deviceName(e.Device)
+	})
+	feedback.SetTrancheFlagsHandler(func(e linux_dmabuf.LinuxDmabufFeedbackTrancheFlagsEvent) {
+		t := startTranche()
+		if e.Flags.Has(linux_dmabuf.LinuxDmabufFeedbackTrancheFlagsScanout) {
+			t.Flags = append(t.Flags, linux_dmabuf.LinuxDmabufFeedbackTrancheFlagsScanout.Name())
+		}
+	})
+	feedback.SetTrancheFormatsHandler(func(e linux_dmabuf.LinuxDmabufFeedbackTrancheFormatsEvent) {
+		t := startTranche()
+		for _, idx := range e.Indices {
+			if int(idx) < len(table) {
+				t.Formats = addModifier(t.Formats, table[idx].format, table[idx].modifier)
+			}
+		}
+	})
+	feedback.SetTrancheDoneHandler(func(linux_dmabuf.LinuxDmabufFeedbackTrancheDoneEvent) {
+		info.Tranches = append(info.Tranches, *startTranche())
+		tranche = nil
+	})
+	feedback.SetDoneHandler(func(linux_dmabuf.LinuxDmabufFeedbackDoneEvent) {
+		_ = feedback.Destroy()
+	})
+}
+
+// deviceName formats a dev_t as major:minor.
+func deviceName(b []byte) string {
+	if len(b) != 8 {
+		return ""
+	}
+	dev := binary.NativeEndian.Uint64(b)
+	return fmt.Sprintf("%d:%d", unix.Major(dev), unix.Minor(dev))
+}
+
+// drmFormat names a DRM fourcc code. wl_shm uses the same codes except
+// for argb8888 and xrgb8888, which are 0 and 1.
+func drmFormat(code uint32) Format {
+	f := Format{Code: code}
+	switch code {
+	case 0x34325241: // AR24
+		f.Name = client.ShmFormatArgb8888.Name()
+	case 0x34325258: // XR24
+		f.Name = client.ShmFormatXrgb8888.Name()
+	default:
+		f.Name = client.ShmFormat(code).Name()
+	}
+	return f
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+	"github.com/rajveermalviya/go-wayland/wayland/waylandtest"
+	"golang.org/x/sys/unix"
+)
+
+// startGather runs gather on the client side of s while the test plays
+// the compositor with Expect and Send, wait returns its result.
+func startGather(t *testing.T, s *waylandtest.Server) (wait func() *Info) {
+	type result struct {
+		info *Info
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		info, err := gather(s.Display())
+		done <- result{info, err}
+	}()
+	return func() *Info {
+		t.Helper()
+		r := <-done
+		if r.err != nil {
+			t.Fatal(r.err)
+		}
+		return r.info
+	}
+}
+
+// expectBind reads the bind of the next global and returns its object.
+func expectBind(s *waylandtest.Server, name uint32, iface string, version uint32) *waylandtest.Object {
+	return s.Expect("wl_registry", "bind", name, iface, version, waylandtest.Any).Args[3].(*waylandtest.Object)
+}
+
+func TestGather(t *testing.T) {
+	s := waylandtest.NewServer(t)
+	s.AddGlobal("wl_compositor", 6)
+	s.AddGlobal("wl_shm", 2)
+	s.AddGlobal("wl_output", 4)
+	s.AddGlobal("wl_seat", 9)
+	s.AddGlobal("wp_presentation", 1)
+	s.AddGlobal("zxdg_output_manager_v1", 3)
+	wait := startGather(t, s)
+
+	s.Expect("wl_display", "get_registry")
+	s.Expect("wl_display", "sync")
+
+	// Versions newer than the command knows about are lowered
+	shm := expectBind(s, 2, "wl_shm", 1)
+	shm.Send("format", client.ShmFormatArgb8888)
+	shm.Send("format", client.ShmFormatRgb565)
+
+	output := expectBind(s, 3, "wl_output", 4)
+	output.Send("geometry", 10, 20, 300, 200, client.OutputSubpixelHorizontalRgb, "make", "model", client.OutputTransform90)
+	output.Send("mode", client.OutputModeCurrent|client.OutputModePreferred, 1920, 1080, 60000)
+	output.Send("mode", 0, 1280, 720, 30000)
+	output.Send("scale", 2)
+	output.Send("name", "DP-1")
+	output.Send("description", "monitor")
+	output.Send("done")
+
+	seat := expectBind(s, 4, "wl_seat", 8)
+	seat.Send("capabilities", client.SeatCapabilityPointer|client.SeatCapabilityKeyboard)
+	seat.Send("name", "seat0")
+
+	presentation := expectBind(s, 5, "wp_presentation", 1)
+	presentation.Send("clock_id", unix.CLOCK_MONOTONIC)
+
+	expectBind(s, 6, "zxdg_output_manager_v1", 3)
+	xdgOutput := s.Expect("zxdg_output_manager_v1", "get_xdg_output", waylandtest.Any, output).Args[0].(*waylandtest.Object)
+	xdgOutput.Send("logical_position", 5, 6)
+	xdgOutput.Send("logical_size", 960, 540)
+	xdgOutput.Send("name", "DP-1")
+	xdgOutput.Send("description", "logical monitor")
+	s.Expect("wl_display", "sync")
+
+	info := wait()
+	want := &Info{Globals: []*Global{
+		{Name: 1, Interface: "wl_compositor", Version: 6},
+		{Name: 2, Interface: "wl_shm", Version: 2, Shm: &ShmInfo{Formats: []Format{
+			{Code: 0, Name: "argb8888"},
+			{Code: uint32(client.ShmFormatRgb565), Name: "rgb565"},
+		}}},
+		{Name: 3, Interface: "wl_output", Version: 4, Output: &OutputInfo{
+			Name:           "DP-1",
+			Description:    "monitor",
+			Make:           "make",
+			Model:          "model",
+			X:              10,
+			Y:              20,
+			PhysicalWidth:  300,
+			PhysicalHeight: 200,
+			Subpixel:       "horizontal_rgb",
+			Transform:      "90",
+			Scale:          2,
+			Modes: []Mode{
+				{Width: 1920, Height: 1080, Refresh: 60000, Current: true, Preferred: true},
+				{Width: 1280, Height: 720, Refresh: 30000},
+			},
+			Logical: &LogicalOutput{X: 5, Y: 6, Width: 960, Height: 540, Name: "DP-1", Description: "logical monitor"},
+		}},
+		{Name: 4, Interface: "wl_seat", Version: 9, Seat: &SeatInfo{Name: "seat0", Capabilities: []string{"pointer", "keyboard"}}},
+		{Name: 5, Interface: "wp_presentation", Version: 1, Presentation: &PresentationInfo{
+			ClockID: unix.CLOCK_MONOTONIC,
+			Clock:   "CLOCK_MONOTONIC",
+		}},
+		{Name: 6, Interface: "zxdg_output_manager_v1", Version: 3},
+	}}
+	for i := range want.Globals {
+		if i >= len(info.Globals) || !reflect.DeepEqual(info.Globals[i], want.Globals[i]) {
+			t.Errorf("global %d: got %+v, want %+v", i, global(info, i), want.Globals[i])
+		}
+	}
+	if len(info.Globals) != len(want.Globals) {
+		t.Errorf("got %d globals, want %d", len(info.Globals), len(want.Globals))
+	}
+}
+
+func global(info *Info, i int) any {
+	if i >= len(info.Globals) {
+		return nil
+	}
+	return info.Globals[i]
+}
+
+// TestGatherDmabufModifiers checks the formats of version 3, which come
+// as modifier events.
+func TestGatherDmabufModifiers(t *testing.T) {
+	s := waylandtest.NewServer(t)
+	s.AddGlobal("zwp_linux_dmabuf_v1", 3)
+	wait := startGather(t, s)
+
+	s.Expect("wl_display", "get_registry")
+	s.Expect("wl_display", "sync")
+
+	dmabuf := expectBind(s, 1, "zwp_linux_dmabuf_v1", 3)
+	const xr24, nv12 = 0x34325258, 0x3231564e
+	dmabuf.Send("format", xr24)
+	dmabuf.Send("modifier", xr24, 0, 0)
+	dmabuf.Send("modifier", xr24, 0x00ffffff, 0xffffffff)
+	dmabuf.Send("format", nv12)
+	dmabuf.Send("modifier", nv12, 0x01000000, 2)
+	s.Expect("wl_display", "sync")
+
+	info := wait()
+	want := &DmabufInfo{Formats: []DmabufFormat{
+		{Format: Format{Code: xr24, Name: "xrgb8888"}, Modifiers: []Modifier{modifierLinear, modifierInvalid}},
+		{Format: Format{Code: nv12, Name: "nv12"}, Modifiers: []Modifier{0x0100000000000002}},
+	}}
+	if got := info.Globals[0].Dmabuf; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+// TestGatherDmabufFeedback checks the default feedback of version 4, whose
+// formats are indices in a table shared in memory.
+func TestGatherDmabufFeedback(t *testing.T) {
+	s := waylandtest.NewServer(t)
+	s.AddGlobal("zwp_linux_dmabuf_v1", 4)
+	wait := startGather(t, s)
+
+	s.Expect("wl_display", "get_registry")
+	s.Expect("wl_display", "sync")
+
+	expectBind(s, 1, "zwp_linux_dmabuf_v1", 4)
+	feedback := s.Expect("zwp_linux_dmabuf_v1", "get_default_feedback").Args[0].(*waylandtest.Object)
+
+	const xr24, ar24 = 0x34325258, 0x34325241
+	table := make([]byte, 3*16)
+	for i, e := range []formatTableEntry{{xr24, modifierLinear}, {xr24, 7}, {ar24, modifierInvalid}} {
+		binary.NativeEndian.PutUint32(table[i*16:], e.format)
+		binary.NativeEndian.PutUint64(table[i*16+8:], uint64(e.modifier))
+	}
+	fd, err := unix.MemfdCreate("format-table", unix.MFD_CLOEXEC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unix.Close(fd)
+	if _, err := unix.Write(fd, table); err != nil {
+		t.Fatal(err)
+	}
+
+	device := func(major, minor uint32) []byte {
+		b := make([]byte, 8)
+		binary.NativeEndian.PutUint64(b, unix.Mkdev(major, minor))
+		return b
+	}
+	indices := func(idx ...uint16) []byte {
+		b := make([]byte, 2*len(idx))
+		for i, v := range idx {
+			binary.NativeEndian.PutUint16(b[2*i:], v)
+		}
+		return b
+	}
+	feedback.Send("format_table", fd, len(table))
+	feedback.Send("main_device", device(226, 0))
+	feedback.Send("tranche_target_device", device(226, 1))
+	feedback.Send("tranche_flags", 1)
+	feedback.Send("tranche_formats", indices(0, 1))
+	feedback.Send("tranche_done")
+	feedback.Send("tranche_target_device", device(226, 0))
+	feedback.Send("tranche_flags", 0)
+	feedback.Send("tranche_formats", indices(2, 9))
+	feedback.Send("tranche_done")
+	feedback.Send("done")
+	s.Expect("wl_display", "sync")
+
+	info := wait()
+	want := &DmabufInfo{Feedback: &DmabufFeedback{
+		MainDevice: "226:0",
+		Tranches: []Tranche{
+			{
+				TargetDevice: "226:1",
+				Flags:        []string{"scanout"},
+				Formats: []DmabufFormat{
+					{Format: Format{Code: xr24, Name: "xrgb8888"}, Modifiers: []Modifier{modifierLinear, 7}},
+				},
+			},
+			{
+				TargetDevice: "226:0",
+				Flags:        []string{},
+				// out of range indices are ignored
+				Formats: []DmabufFormat{
+					{Format: Format{Code: ar24, Name: "argb8888"}, Modifiers: []Modifier{modifierInvalid}},
+				},
+			},
+		},
+	}}
+	if got := info.Globals[0].Dmabuf; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,60 @@
+// Command wayland-info prints the globals advertised by a compositor,
+// with details for the ones go-wayland knows about: wl_shm formats,
+// wl_output geometry and modes (extended by zxdg_output_v1), wl_seat
+// capabilities, zwp_linux_dmabuf_v1 formats and feedback tranches and
+// the wp_presentation clock.
+//
+// Usage:
+//
+//	wayland-info [-display NAME] [-json]
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+)
+
+var (
+	displayName string
+	jsonOutput  bool
+)
+
+func init() {
+	flag.StringVar(&displayName, "display", "", "Compositor to connect to, defaults to $WAYLAND_DISPLAY or wayland-0")
+	flag.BoolVar(&jsonOutput, "json", false, "Print the information as JSON")
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("wayland-info: ")
+	flag.Parse()
+
+	display, err := client.Connect(displayName)
+	if err != nil {
+		log.Fatalf("unable to connect to wayland server: %v", err)
+	}
+	defer display.Context().Close()
+
+	display.SetErrorHandler(func(e client.DisplayErrorEvent) {
+		log.Fatalf("display error: %v", e)
+	})
+
+	info, err := gather(display)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(info); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	printText(os.Stdout, info)
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// printText prints the information in the layout of weston-info.
+func printText(w io.Writer, info *Info) {
+	for _, g := range info.Globals {
+		fmt.Fprintf(w, "interface: '%s', version: %d, name: %d\n", g.Interface, g.Version, g.Name)
+		switch {
+		case g.Shm != nil:
+			printShm(w, g.Shm)
+		case g.Output != nil:
+			printOutput(w, g.Output)
+		case g.Seat != nil:
+			printSeat(w, g.Seat)
+		case g.Dmabuf != nil:
+			printDmabuf(w, g.Dmabuf)
+		case g.Presentation != nil:
+			fmt.Fprintf(w, "\tpresentation clock id: %d (%s)\n", g.Presentation.ClockID, g.Presentation.Clock)
+		}
+	}
+}
+
+func (f Format) String() string {
+	if f.Name == "" {
+		return fmt.Sprintf("0x%08x", f.Code)
+	}
+	return fmt.Sprintf("0x%08x = '%s'", f.Code, f.Name)
+}
+
+func printShm(w io.Writer, shm *ShmInfo) {
+	fmt.Fprintf(w, "\tformats (fourcc):\n")
+	for _, f := range shm.Formats {
+		fmt.Fprintf(w, "\t  %v\n", f)
+	}
+}
+
+func printOutput(w io.Writer, o *OutputInfo) {
+	if o.Name != "" {
+		fmt.Fprintf(w, "\tname: %s\n", o.Name)
+	}
+	if o.Description != "" {
+		fmt.Fprintf(w, "\tdescription: %s\n", o.Description)
+	}
+	fmt.Fprintf(w, "\tx: %d, y: %d, scale: %d,\n", o.X, o.Y, o.Scale)
+	fmt.Fprintf(w, "\tphysical_width: %d mm, physical_height: %d mm,\n", o.PhysicalWidth, o.PhysicalHeight)
+	fmt.Fprintf(w, "\tmake: '%s', model: '%s',\n", o.Make, o.Model)
+	fmt.Fprintf(w, "\tsubpixel_orientation: %s, output_transform: %s,\n", o.Subpixel, o.Transform)
+	for _, m := range o.Modes {
+		fmt.Fprintf(w, "\tmode:\n")
+		fmt.Fprintf(w, "\t\twidth: %d px, height: %d px, refresh: %.3f Hz,\n", m.Width, m.Height, float64(m.Refresh)/1000)
+		var flags []string
+		if m.Current {
+			flags = append(flags, "current")
+		}
+		if m.Preferred {
+			flags = append(flags, "preferred")
+		}
+		fmt.Fprintf(w, "\t\tflags: %s\n", strings.Join(flags, " "))
+	}
+
+	if l := o.Logical; l != nil {
+		fmt.Fprintf(w, "\txdg_output_v1\n")
+		if l.Name != "" {
+			fmt.Fprintf(w, "\t\tname: '%s'\n", l.Name)
+		}
+		if l.Description != "" {
+			fmt.Fprintf(w, "\t\tdescription: '%s'\n", l.Description)
+		}
+		fmt.Fprintf(w, "\t\tlogical_x: %d, logical_y: %d\n", l.X, l.Y)
+		fmt.Fprintf(w, "\t\tlogical_width: %d, logical_height: %d\n", l.Width, l.Height)
+	}
+}
+
+func printSeat(w io.Writer, s *SeatInfo) {
+	if s.Name != "" {
+		fmt.Fprintf(w, "\tname: %s\n", s.Name)
+	}
+	fmt.Fprintf(w, "\tcapabilities: %s\n", strings.Join(s.Capabilities, " "))
+}
+
+func printDmabuf(w io.Writer, d *DmabufInfo) {
+	if d.Feedback == nil {
+		fmt.Fprintf(w, "\tformats (fourcc) and modifiers:\n")
+		printDmabufFormats(w, "\t  ", d.Formats)
+		return
+	}
+
+	fmt.Fprintf(w, "\tdefault feedback:\n")
+	fmt.Fprintf(w, "\t  main device: %s\n", d.Feedback.MainDevice)
+	for i, t := range d.Feedback.Tranches {
+		fmt.Fprintf(w, "\t  tranche %d:\n", i)
+		fmt.Fprintf(w, "\t    target device: %s\n", t.TargetDevice)
+		if len(t.Flags) > 0 {
+			fmt.Fprintf(w, "\t    flags: %s\n", strings.Join(t.Flags, " "))
+		}
+		fmt.Fprintf(w, "\t    formats (fourcc) and modifiers:\n")
+		printDmabufFormats(w, "\t      ", t.Formats)
+	}
+}
+
+func printDmabufFormats(w io.Writer, indent string, formats []DmabufFormat) {
+	for _, f := range formats {
+		fmt.Fprintf(w, "%s%v\n", indent, f.Format)
+		for _, m := range f.Modifiers {
+			fmt.Fprintf(w, "%s  %v\n", indent, m)
+		}
+	}
+}
@@ -0,0 +1,348 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/rajveermalviya/go-wayland/wayland/protocol"
+)
+
+// Severity tells how a change affects the clients built against the old
+// version of a protocol.
+type Severity int
+
+const (
+	// Info is a compatible change.
+	Info Severity = iota
+	// Warning is a compatible change which doesn't follow the versioning
+	// rules, a new message or enum entry needs a since attribute above the
+	// previous version of its interface.
+	Warning
+	// Breaking is a change which breaks the wire protocol or removes
+	// something clients may use.
+	Breaking
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Info:
+		return "info"
+	case Warning:
+		return "warning"
+	case Breaking:
+		return "breaking"
+	default:
+		return "Severity(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
+// Change is a difference between two versions of a protocol.
+type Change struct {
+	Severity Severity
+	// Path is what changed: "interface", "interface.message",
+	// "interface.message.arg", "interface.enum" or
+	// "interface.enum.entry".
+	Path string
+	Text string
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%-8s %s: %s", c.Severity, c.Path, c.Text)
+}
+
+// message is a request or an event.
+type message struct {
+	Name  string
+	Type  string
+	Since int
+	Args  []protocol.Arg
+}
+
+func requests(v *protocol.Interface) []message {
+	msgs := make([]message, len(v.Requests))
+	for i, r := range v.Requests {
+		msgs[i] = message{Name: r.Name, Type: r.Type, Since: r.Since, Args: r.Args}
+	}
+	return msgs
+}
+
+func events(v *protocol.Interface) []message {
+	msgs := make([]message, len(v.Events))
+	for i, e := range v.Events {
+		msgs[i] = message{Name: e.Name, Type: e.Type, Since: e.Since, Args: e.Args}
+	}
+	return msgs
+}
+
+// since returns the version an element was added in, the since attribute
+// defaults to 1.
+func since(s int) int {
+	if s == 0 {
+		return 1
+	}
+	return s
+}
+
+type differ struct {
+	changes []Change
+}
+
+func (d *differ) add(s Severity, path string, format string, args ...any) {
+	d.changes = append(d.changes, Change{Severity: s, Path: path, Text: fmt.Sprintf(format, args...)})
+}
+
+// diff returns the changes from before to after, in the order of the
+// interfaces of before followed by the ones added in after.
+func diff(before, after *protocol.Protocol) []Change {
+	d := &differ{}
+
+	for i := range before.Interfaces {
+		a := &before.Interfaces[i]
+		b := findInterface(after, a.Name)
+		if b == nil {
+			d.add(Breaking, a.Name, "interface removed")
+			continue
+		}
+		d.diffInterface(a, b)
+	}
+	for i := range after.Interfaces {
+		b := &after.Interfaces[i]
+		if findInterface(before, b.Name) == nil {
+			d.add(Info, b.Name, "interface added, version %d", b.Version)
+		}
+	}
+
+	return d.changes
+}
+
+func findInterface(p *protocol.Protocol, name string) *protocol.Interface {
+	for i := range p.Interfaces {
+		if p.Interfaces[i].Name == name {
+			return &p.Interfaces[i]
+		}
+	}
+	return nil
+}
+
+func (d *differ) diffInterface(a, b *protocol.Interface) {
+	switch {
+	case b.Version < a.Version:
+		d.add(Breaking, a.Name, "version lowered from %d to %d", a.Version, b.Version)
+	case b.Version > a.Version:
+		d.add(Info, a.Name, "version bumped from %d to %d", a.Version, b.Version)
+		for v := a.Version + 1; v <= b.Version; v++ {
+			if !hasSince(b, v) {
+				d.add(Warning, a.Name, "version %d has no request, event or enum entry with since=\"%d\"", v, v)
+			}
+		}
+	}
+
+	d.diffMessages("request", a, b, requests(a), requests(b), false)
+	d.diffMessages("event", a, b, events(a), events(b), true)
+	d.diffEnums(a, b)
+}
+
+// hasSince reports whether anything of an interface was added in the
+// given version.
+func hasSince(v *protocol.Interface, version int) bool {
+	for _, r := range v.Requests {
+		if since(r.Since) == version {
+			return true
+		}
+	}
+	for _, e := range v.Events {
+		if since(e.Since) == version {
+			return true
+		}
+	}
+	for _, e := range v.Enums {
+		for _, entry := range e.Entries {
+			if since(entry.Since) == version {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func findMessage(msgs []message, name string) int {
+	for i, m := range msgs {
+		if m.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// diffMessages compares the requests or the events of an interface,
+// matched by name. Opcodes are their index, so reordering them breaks the
+// wire protocol.
+func (d *differ) diffMessages(kind string, a, b *protocol.Interface, before, after []message, event bool) {
+	for i, m := range before {
+		path := a.Name + "." + m.Name
+		j := findMessage(after, m.Name)
+		if j == -1 {
+			d.add(Breaking, path, "%s removed", kind)
+			continue
+		}
+		n := after[j]
+
+		if i != j {
+			d.add(Breaking, path, "opcode changed from %d to %d", i, j)
+		}
+		if since(m.Since) != since(n.Since) {
+			d.add(Warning, path, "since changed from %d to %d", since(m.Since), since(n.Since))
+		}
+		if (m.Type == "destructor") != (n.Type == "destructor") {
+			if n.Type == "destructor" {
+				d.add(Breaking, path, "%s became a destructor", kind)
+			} else {
+				d.add(Breaking, path, "%s is no longer a destructor", kind)
+			}
+		}
+		d.diffArgs(path, m.Args, n.Args, event)
+	}
+
+	for j, n := range after {
+		if findMessage(before, n.Name) != -1 {
+			continue
+		}
+		path := b.Name + "." + n.Name
+		d.add(Info, path, "%s added, opcode %d, since %d", kind, j, since(n.Since))
+		d.checkSince(path, since(n.Since), a, b)
+	}
+}
+
+// checkSince warns when something added to an interface isn't marked
+// with a version above the previous one.
+func (d *differ) checkSince(path string, s int, a, b *protocol.Interface) {
+	switch {
+	case s <= a.Version:
+		d.add(Warning, path, "since %d is not above the previous interface version %d", s, a.Version)
+	case s > b.Version:
+		d.add(Warning, path, "since %d is above the interface version %d", s, b.Version)
+	}
+}
+
+// diffArgs compares the arguments of a message, matched by position.
+// Requests are sent by clients and events received, so allowing null
+// breaks events while disallowing it breaks requests.
+func (d *differ) diffArgs(path string, before, after []protocol.Arg, event bool) {
+	for i := 0; i < len(before) && i < len(after); i++ {
+		a, b := before[i], after[i]
+		argPath := path + "." + a.Name
+
+		if a.Name != b.Name {
+			d.add(Info, argPath, "argument renamed to %s", b.Name)
+		}
+		if a.Type != b.Type {
+			d.add(Breaking, argPath, "type changed from %s to %s", a.Type, b.Type)
+			continue
+		}
+		if a.Interface != b.Interface {
+			d.add(Breaking, argPath, "interface changed from %s to %s", ifaceName(a.Interface), ifaceName(b.Interface))
+		}
+		if a.AllowNull != b.AllowNull {
+			switch {
+			case event && b.AllowNull:
+				d.add(Breaking, argPath, "may now be null")
+			case !event && a.AllowNull:
+				d.add(Breaking, argPath, "is no longer nullable")
+			case b.AllowNull:
+				d.add(Info, argPath, "is now nullable")
+			default:
+				d.add(Info, argPath, "is no longer nullable")
+			}
+		}
+		if a.Enum != b.Enum {
+			d.add(Info, argPath, "enum changed from %q to %q", a.Enum, b.Enum)
+		}
+	}
+
+	for _, a := range before[min(len(before), len(after)):] {
+		d.add(Breaking, path+"."+a.Name, "argument removed")
+	}
+	for _, b := range after[min(len(before), len(after)):] {
+		d.add(Breaking, path+"."+b.Name, "argument added")
+	}
+}
+
+func ifaceName(name string) string {
+	if name == "" {
+		return "any"
+	}
+	return name
+}
+
+func findEnum(v *protocol.Interface, name string) *protocol.Enum {
+	for i := range v.Enums {
+		if v.Enums[i].Name == name {
+			return &v.Enums[i]
+		}
+	}
+	return nil
+}
+
+func findEntry(e *protocol.Enum, name string) *protocol.Entry {
+	for i := range e.Entries {
+		if e.Entries[i].Name == name {
+			return &e.Entries[i]
+		}
+	}
+	return nil
+}
+
+func (d *differ) diffEnums(a, b *protocol.Interface) {
+	for i := range a.Enums {
+		ea := &a.Enums[i]
+		path := a.Name + "." + ea.Name
+		eb := findEnum(b, ea.Name)
+		if eb == nil {
+			d.add(Breaking, path, "enum removed")
+			continue
+		}
+
+		if ea.Bitfield != eb.Bitfield {
+			if eb.Bitfield {
+				d.add(Info, path, "enum became a bitfield")
+			} else {
+				d.add(Info, path, "enum is no longer a bitfield")
+			}
+		}
+
+		for _, x := range ea.Entries {
+			y := findEntry(eb, x.Name)
+			if y == nil {
+				d.add(Breaking, path+"."+x.Name, "entry removed")
+				continue
+			}
+			if !sameValue(x.Value, y.Value) {
+				d.add(Breaking, path+"."+x.Name, "value changed from %s to %s", x.Value, y.Value)
+			}
+		}
+		for _, y := range eb.Entries {
+			if findEntry(ea, y.Name) != nil {
+				continue
+			}
+			d.add(Info, path+"."+y.Name, "entry added, value %s", y.Value)
+			d.checkSince(path+"."+y.Name, since(y.Since), a, b)
+		}
+	}
+
+	for i := range b.Enums {
+		if findEnum(a, b.Enums[i].Name) == nil {
+			d.add(Info, b.Name+"."+b.Enums[i].Name, "enum added")
+		}
+	}
+}
+
+// sameValue compares enum values numerically, "0x10" and "16" are the
+// same value.
+func sameValue(a, b string) bool {
+	x, err1 := strconv.ParseUint(a, 0, 32)
+	y, err2 := strconv.ParseUint(b, 0, 32)
+	if err1 != nil || err2 != nil {
+		return a == b
+	}
+	return x == y
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rajveermalviya/go-wayland/wayland/protocol"
+)
+
+const before = `<protocol name="test">
+  <interface name="test_manager" version="1">
+    <request name="destroy" type="destructor"/>
+    <request name="create">
+      <arg name="id" type="new_id" interface="test_object"/>
+      <arg name="parent" type="object" interface="test_object" allow-null="true"/>
+    </request>
+    <event name="done">
+      <arg name="serial" type="uint"/>
+      <arg name="name" type="string"/>
+    </event>
+    <enum name="mode">
+      <entry name="off" value="0"/>
+      <entry name="on" value="1"/>
+    </enum>
+  </interface>
+  <interface name="test_object" version="1">
+    <request name="destroy" type="destructor"/>
+  </interface>
+  <interface name="test_legacy" version="1"/>
+</protocol>`
+
+func load(t *testing.T, s string) *protocol.Protocol {
+	t.Helper()
+
+	p, err := protocol.Decode(strings.NewReader(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name  string
+		after string
+		want  []string
+	}{
+		{
+			name:  "same",
+			after: before,
+		},
+		{
+			name: "versioned additions",
+			after: strings.NewReplacer(
+				`"test_manager" version="1"`, `"test_manager" version="2"`,
+				`<enum name="mode">`, `<request name="reset" since="2"/>
+    <enum name="mode">`,
+				`<entry name="on" value="1"/>`, `<entry name="on" value="1"/>
+      <entry name="auto" value="2" since="2"/>`,
+				`</protocol>`, `<interface name="test_extra" version="1"/>
+</protocol>`,
+			).Replace(before),
+			want: []string{
+				"info     test_manager: version bumped from 1 to 2",
+				"info     test_manager.reset: request added, opcode 2, since 2",
+				"info     test_manager.mode.auto: entry added, value 2",
+				"info     test_extra: interface added, version 1",
+			},
+		},
+		{
+			name: "missing since",
+			after: strings.NewReplacer(
+				`"test_manager" version="1"`, `"test_manager" version="3"`,
+				`<enum name="mode">`, `<request name="reset"/>
+    <enum name="mode">`,
+			).Replace(before),
+			want: []string{
+				"info     test_manager: version bumped from 1 to 3",
+				`warning  test_manager: version 2 has no request, event or enum entry with since="2"`,
+				`warning  test_manager: version 3 has no request, event or enum entry with since="3"`,
+				"info     test_manager.reset: request added, opcode 2, since 1",
+				"warning  test_manager.reset: since 1 is not above the previous interface version 1",
+			},
+		},
+		{
+			name: "wire breaking",
+			after: strings.NewReplacer(
+				`<request name="destroy" type="destructor"/>
+    <request name="create">`, `<request name="create">`,
+				`<arg name="parent" type="object" interface="test_object" allow-null="true"/>`, `<arg name="parent" type="object" interface="test_object"/>`,
+				`<arg name="name" type="string"/>`, `<arg name="name" type="string" allow-null="true"/>
+      <arg name="flags" type="uint"/>`,
+				`<entry name="on" value="1"/>`, `<entry name="on" value="0x2"/>`,
+				`<interface name="test_legacy" version="1"/>`, ``,
+			).Replace(before),
+			want: []string{
+				"breaking test_manager.destroy: request removed",
+				"breaking test_manager.create: opcode changed from 1 to 0",
+				"breaking test_manager.create.parent: is no longer nullable",
+				"breaking test_manager.done.name: may now be null",
+				"breaking test_manager.done.flags: argument added",
+				"breaking test_manager.mode.on: value changed from 1 to 0x2",
+				"breaking test_legacy: interface removed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, c := range diff(load(t, before), load(t, tt.after)) {
+				got = append(got, c.String())
+			}
+
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("got:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
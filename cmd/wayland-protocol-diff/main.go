@@ -0,0 +1,80 @@
+// Command wayland-protocol-diff compares two versions of a protocol XML
+// file and reports what changed between them: interfaces, requests,
+// events, arguments, enums and enum entries added or removed, interface
+// version bumps and changes which break the wire protocol.
+//
+// Usage:
+//
+//	wayland-protocol-diff [-strict] [-q] old.xml new.xml
+//
+// Every change is printed on its own line, prefixed by its severity:
+//
+//	info      compatible change, an addition in a new version
+//	warning   versioning mistake, such as a request added without a since
+//	          attribute above the previous interface version
+//	breaking  change which breaks clients built against the old file
+//
+// The exit status is 1 when a breaking change is found, or a warning with
+// -strict, so it can gate CI. It is 2 when the files can't be read.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/rajveermalviya/go-wayland/wayland/protocol"
+)
+
+var (
+	strict bool
+	quiet  bool
+)
+
+func init() {
+	flag.BoolVar(&strict, "strict", false, "Exit with status 1 on warnings too")
+	flag.BoolVar(&quiet, "q", false, "Only print warnings and breaking changes")
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("wayland-protocol-diff: ")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: wayland-protocol-diff [flags] old.xml new.xml\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	before, err := protocol.Load(flag.Arg(0))
+	if err != nil {
+		log.Printf("unable to load %s: %v", flag.Arg(0), err)
+		os.Exit(2)
+	}
+	after, err := protocol.Load(flag.Arg(1))
+	if err != nil {
+		log.Printf("unable to load %s: %v", flag.Arg(1), err)
+		os.Exit(2)
+	}
+
+	changes := diff(before, after)
+
+	failed := false
+	for _, c := range changes {
+		if quiet && c.Severity == Info {
+			continue
+		}
+		fmt.Println(c)
+		if c.Severity == Breaking || (strict && c.Severity == Warning) {
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// logger prints the conversation, as text close to WAYLAND_DEBUG or as
+// one JSON object per line.
+type logger struct {
+	mu    sync.Mutex
+	w     io.Writer
+	json  bool
+	start time.Time
+}
+
+func (l *logger) timestamp(t time.Time) float64 {
+	return float64(t.Sub(l.start).Microseconds()) / 1000
+}
+
+func (l *logger) write(b []byte) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = l.w.Write(b)
+}
+
+func (l *logger) writeJSON(v any) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		b, _ = json.Marshal(map[string]string{"error": err.Error()})
+	}
+	l.write(append(b, '\n'))
+}
+
+// note logs a connection level event.
+func (l *logger) note(client int, format string, args ...any) {
+	now := time.Now()
+	msg := fmt.Sprintf(format, args...)
+	if l.json {
+		l.writeJSON(struct {
+			Time   float64 `json:"time"`
+			Client int     `json:"client"`
+			Note   string  `json:"note"`
+		}{l.timestamp(now), client, msg})
+		return
+	}
+	l.write([]byte(fmt.Sprintf("[%10.3f] {%d} %s\n", l.timestamp(now), client, msg)))
+}
+
+type jsonArg struct {
+	Name      string `json:"name,omitempty"`
+	Type      string `json:"type"`
+	Interface string `json:"interface,omitempty"`
+	Version   uint32 `json:"version,omitempty"`
+	Value     any    `json:"value"`
+}
+
+type jsonMessage struct {
+	Time      float64   `json:"time"`
+	Client    int       `json:"client"`
+	Direction string    `json:"direction"`
+	Interface string    `json:"interface,omitempty"`
+	ID        uint32    `json:"id"`
+	Opcode    uint16    `json:"opcode"`
+	Message   string    `json:"message,omitempty"`
+	Args      []jsonArg `json:"args,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	Raw       string    `json:"raw,omitempty"`
+}
+
+func (l *logger) message(client int, m *message) {
+	if l.json {
+		l.messageJSON(client, m)
+		return
+	}
+
+	sb := &strings.Builder{}
+	arrow := "->"
+	if m.event {
+		arrow = "<-"
+	}
+	fmt.Fprintf(sb, "[%10.3f] {%d} %s ", l.timestamp(m.time), client, arrow)
+
+	iface := m.iface
+	if iface == "" {
+		iface = "unknown"
+	}
+	if m.name != "" {
+		fmt.Fprintf(sb, "%s#%d.%s(", iface, m.sender, m.name)
+	} else {
+		fmt.Fprintf(sb, "%s#%d.[%d](", iface, m.sender, m.opcode)
+	}
+	for i, a := range m.args {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(formatValue(a.value))
+	}
+	sb.WriteString(")")
+	if m.err != nil {
+		fmt.Fprintf(sb, " !! %v, raw %s", m.err, hex.EncodeToString(m.raw))
+	}
+	sb.WriteString("\n")
+	l.write([]byte(sb.String()))
+}
+
+func (l *logger) messageJSON(client int, m *message) {
+	j := jsonMessage{
+		Time:      l.timestamp(m.time),
+		Client:    client,
+		Direction: "request",
+		Interface: m.iface,
+		ID:        m.sender,
+		Opcode:    m.opcode,
+		Message:   m.name,
+	}
+	if m.event {
+		j.Direction = "event"
+	}
+	for _, a := range m.args {
+		arg := jsonArg{Name: a.arg.Name, Type: a.arg.Type, Value: a.value}
+		switch v := a.value.(type) {
+		case objectRef:
+			arg.Interface = v.iface
+			arg.Version = v.version
+			arg.Value = v.id
+		case fdValue:
+			arg.Value = nil
+		case []byte:
+			arg.Value = hex.EncodeToString(v)
+		}
+		j.Args = append(j.Args, arg)
+	}
+	if m.err != nil {
+		j.Error = m.err.Error()
+		j.Raw = hex.EncodeToString(m.raw)
+	}
+	l.writeJSON(j)
+}
+
+func (l *logger) lifetime(client int, lt lifetime) {
+	o := lt.object
+	if l.json {
+		j := struct {
+			Time      float64  `json:"time"`
+			Client    int      `json:"client"`
+			Lifetime  string   `json:"lifetime"`
+			Interface string   `json:"interface"`
+			ID        uint32   `json:"id"`
+			By        string   `json:"by,omitempty"`
+			Age       *float64 `json:"age,omitempty"`
+			Messages  *int     `json:"messages,omitempty"`
+		}{
+			Time:      l.timestamp(lt.time),
+			Client:    client,
+			Lifetime:  "created",
+			Interface: o.iface,
+			ID:        o.id,
+		}
+		if !lt.created {
+			age := lt.time.Sub(o.created).Seconds()
+			j.Lifetime = "destroyed"
+			j.By = lt.destroyer
+			j.Age = &age
+			j.Messages = &o.messages
+		}
+		l.writeJSON(j)
+		return
+	}
+
+	prefix := fmt.Sprintf("[%10.3f] {%d}   ", l.timestamp(lt.time), client)
+	if lt.created {
+		l.write([]byte(fmt.Sprintf("%s %s created\n", prefix, &o)))
+		return
+	}
+	l.write([]byte(fmt.Sprintf("%s %s destroyed by %s after %v, %d messages\n",
+		prefix, &o, lt.destroyer, lt.time.Sub(o.created).Round(time.Millisecond), o.messages)))
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return strconv.Quote(v)
+	case float64:
+		return fixedString(v)
+	case []byte:
+		return fmt.Sprintf("array[%d]", len(v))
+	case fdValue:
+		return "fd"
+	case objectRef:
+		iface := v.iface
+		if iface == "" {
+			iface = "[unknown]"
+		}
+		if !v.isNew {
+			return fmt.Sprintf("%s#%d", iface, v.id)
+		}
+		if v.version != 0 {
+			return fmt.Sprintf("new id %s#%d (version %d)", iface, v.id, v.version)
+		}
+		return fmt.Sprintf("new id %s#%d", iface, v.id)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,229 @@
+// Command wayland-tracer is a proxy between wayland clients and the
+// compositor which decodes and logs all the traffic.
+//
+// It listens on its own socket, connects every client to the real
+// compositor and forwards messages in both directions, fds included.
+// Messages are decoded using protocol XML files, by default the ones
+// installed in /usr/share/wayland and /usr/share/wayland-protocols.
+// Object creation and destruction are annotated with the lifetime of
+// the object.
+//
+// Usage:
+//
+//	wayland-tracer [flags] [command [args...]]
+//
+// When a command is given it is run with WAYLAND_DISPLAY pointing to the
+// tracer, which exits with the command's exit status once it's done.
+// Otherwise clients are traced until the tracer is interrupted.
+//
+//	wayland-tracer -x wayland.xml -x xdg-shell.xml -json -o trace.json imageviewer cat.jpg
+package main
+
+import (
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// listFlag collects the values of a repeated flag.
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
+
+var (
+	socketName  string
+	displayName string
+	outputFile  string
+	jsonOutput  bool
+	xmlPaths    listFlag
+	noDefault   bool
+)
+
+func init() {
+	flag.StringVar(&socketName, "socket", "wayland-tracer", "Socket name in XDG_RUNTIME_DIR, or path of the socket to listen on")
+	flag.StringVar(&displayName, "display", "", "Compositor to connect to, defaults to $WAYLAND_DISPLAY or wayland-0")
+	flag.StringVar(&outputFile, "o", "", "Write the trace to a file instead of stderr")
+	flag.BoolVar(&jsonOutput, "json", false, "Log one JSON object per line")
+	flag.Var(&xmlPaths, "x", "Protocol XML file or directory of XML files, can be repeated")
+	flag.BoolVar(&noDefault, "no-default-protocols", false, "Don't load the protocol XML files installed on the system")
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("wayland-tracer: ")
+	flag.Parse()
+
+	reg := registry{}
+	if !noDefault {
+		if err := reg.loadDefault(); err != nil {
+			log.Fatal(err)
+		}
+	}
+	for _, path := range xmlPaths {
+		if err := reg.load(path); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if reg["wl_display"] == nil {
+		log.Fatal("wayland.xml not found, use -x to load it")
+	}
+
+	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	upstream, err := displayPath(displayName, runtimeDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	socketPath, err := displayPath(socketName, runtimeDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if socketPath == upstream {
+		log.Fatalf("the tracer can't listen on the compositor socket %s", upstream)
+	}
+
+	out := os.Stderr
+	if outputFile != "" {
+		out, err = os.Create(outputFile)
+		if err != nil {
+			log.Fatalf("unable to create output file: %v", err)
+		}
+		defer out.Close()
+	}
+	logger := &logger{w: out, json: jsonOutput, start: time.Now()}
+
+	l, err := listen(socketPath)
+	if err != nil {
+		log.Fatalf("unable to listen on %s: %v", socketPath, err)
+	}
+	log.Printf("listening on %s, forwarding to %s", socketPath, upstream)
+
+	go func() {
+		for id := 1; ; id++ {
+			conn, err := l.AcceptUnix()
+			if err != nil {
+				if !errors.Is(err, net.ErrClosed) {
+					log.Printf("unable to accept client: %v", err)
+				}
+				return
+			}
+			server, err := net.DialUnix("unix", nil, &net.UnixAddr{Name: upstream, Net: "unix"})
+			if err != nil {
+				log.Printf("unable to connect to compositor: %v", err)
+				conn.Close()
+				continue
+			}
+			go newSession(id, reg, logger, conn, server).run()
+		}
+	}()
+
+	status := 0
+	if flag.NArg() > 0 {
+		status = runCommand(flag.Args(), socketPath)
+	} else {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		<-signals
+	}
+
+	l.Close()
+	if outputFile != "" {
+		out.Close()
+	}
+	os.Exit(status)
+}
+
+// displayPath resolves a display name like libwayland does: names
+// containing a slash are paths, others are relative to XDG_RUNTIME_DIR.
+func displayPath(name, runtimeDir string) (string, error) {
+	if name == "" {
+		name = os.Getenv("WAYLAND_DISPLAY")
+	}
+	if name == "" {
+		name = "wayland-0"
+	}
+	if strings.Contains(name, "/") {
+		return filepath.Abs(name)
+	}
+	if runtimeDir == "" {
+		return "", errors.New("env XDG_RUNTIME_DIR not set")
+	}
+	return filepath.Join(runtimeDir, name), nil
+}
+
+// listen creates the socket, replacing a stale one left behind.
+func listen(path string) (*net.UnixListener, error) {
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil, errors.New("socket is in use")
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+
+	l, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	l.SetUnlinkOnClose(true)
+	return l, nil
+}
+
+// runCommand runs a client and returns its exit status.
+func runCommand(args []string, socketPath string) int {
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	// XDG_RUNTIME_DIR is only overridden for a socket outside of it,
+	// other clients of the runtime directory are left undisturbed
+	cmd.Env = append(os.Environ(), "WAYLAND_DISPLAY="+filepath.Base(socketPath))
+	if filepath.Dir(socketPath) != os.Getenv("XDG_RUNTIME_DIR") {
+		cmd.Env = append(cmd.Env, "XDG_RUNTIME_DIR="+filepath.Dir(socketPath))
+	}
+
+	if err := cmd.Start(); err != nil {
+		log.Printf("unable to start %s: %v", args[0], err)
+		return 127
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		for sig := range signals {
+			_ = cmd.Process.Signal(sig)
+		}
+	}()
+
+	err := cmd.Wait()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		// Like a shell, report a command killed by a signal as
+		// 128+signal rather than the -1 of ExitCode
+		if ws, ok := exitErr.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+			return 128 + int(ws.Signal())
+		}
+		return exitErr.ExitCode()
+	}
+	if err != nil {
+		log.Printf("unable to run %s: %v", args[0], err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rajveermalviya/go-wayland/wayland/protocol"
+)
+
+// defaultProtocolPaths are where distributions install the protocol XML
+// files of wayland and wayland-protocols.
+var defaultProtocolPaths = []string{
+	"/usr/share/wayland/wayland.xml",
+	"/usr/share/wayland-protocols",
+}
+
+// registry holds every interface known to the tracer.
+type registry map[string]*protocol.Interface
+
+// load adds the interfaces of a protocol XML file, or of all XML files
+// found under a directory.
+func (reg registry) load(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return reg.loadFile(path)
+	}
+
+	return filepath.WalkDir(path, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".xml") {
+			return nil
+		}
+		return reg.loadFile(path)
+	})
+}
+
+func (reg registry) loadFile(path string) error {
+	p, err := protocol.Load(path)
+	if err != nil {
+		return fmt.Errorf("unable to load %s: %w", path, err)
+	}
+	for i := range p.Interfaces {
+		iface := &p.Interfaces[i]
+		reg[iface.Name] = iface
+	}
+	return nil
+}
+
+// loadDefault loads the protocols installed on the system, ignoring
+// missing paths.
+func (reg registry) loadDefault() error {
+	for _, path := range defaultProtocolPaths {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		if err := reg.load(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// message returns the request or event with the given opcode.
+func (reg registry) message(iface string, opcode uint16, event bool) (name string, args []protocol.Arg, destructor bool, ok bool) {
+	i := reg[iface]
+	if i == nil {
+		return "", nil, false, false
+	}
+	if event {
+		if int(opcode) >= len(i.Events) {
+			return "", nil, false, false
+		}
+		e := &i.Events[opcode]
+		return e.Name, e.Args, e.Type == "destructor", true
+	}
+	if int(opcode) >= len(i.Requests) {
+		return "", nil, false, false
+	}
+	r := &i.Requests[opcode]
+	return r.Name, r.Args, r.Type == "destructor", true
+}
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/rajveermalviya/go-wayland/wayland/protocol"
+	"github.com/rajveermalviya/go-wayland/wayland/wire"
+	"golang.org/x/sys/unix"
+)
+
+// maxFds is the maximum number of fds libwayland sends in one sendmsg.
+const maxFds = 28
+
+// firstServerID is the first object id allocated by the server.
+const firstServerID = 0xff000000
+
+// object is what the tracer knows about a live protocol object.
+type object struct {
+	iface    string
+	id       uint32
+	created  time.Time
+	messages int
+	// destroyed is set by a destructor, client ids stay allocated until
+	// the server's wl_display.delete_id.
+	destroyed bool
+}
+
+func (o *object) String() string {
+	return fmt.Sprintf("%s#%d", o.iface, o.id)
+}
+
+// session is a traced client connection, forwarded to the compositor.
+type session struct {
+	id     int
+	reg    registry
+	log    *logger
+	client *net.UnixConn
+	server *net.UnixConn
+
+	mu      sync.Mutex
+	objects map[uint32]*object
+}
+
+func newSession(id int, reg registry, log *logger, client, server *net.UnixConn) *session {
+	s := &session{
+		id:      id,
+		reg:     reg,
+		log:     log,
+		client:  client,
+		server:  server,
+		objects: map[uint32]*object{},
+	}
+	s.objects[1] = &object{iface: "wl_display", id: 1, created: time.Now()}
+	return s
+}
+
+// run forwards traffic in both directions until either side hangs up.
+func (s *session) run() {
+	s.log.note(s.id, "client connected")
+
+	errs := make(chan error, 2)
+	go func() { errs <- s.forward(s.client, s.server, false) }()
+	go func() { errs <- s.forward(s.server, s.client, true) }()
+
+	err := <-errs
+	s.client.Close()
+	s.server.Close()
+	<-errs
+
+	if err != nil {
+		s.log.note(s.id, "client disconnected: %v", err)
+	} else {
+		s.log.note(s.id, "client disconnected")
+	}
+}
+
+// forward copies messages from one end to the other, decoding each of
+// them. Events are sent by the server, requests by the client.
+func (s *session) forward(from, to *net.UnixConn, events bool) error {
+	var pending []byte
+	var fds []int
+	defer func() {
+		for _, fd := range fds {
+			unix.Close(fd)
+		}
+	}()
+
+	buf := make([]byte, 4096)
+	oob := make([]byte, unix.CmsgSpace(maxFds*4))
+	for {
+		n, oobn, _, _, err := from.ReadMsgUnix(buf, oob)
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) || errors.Is(err, unix.ECONNRESET) {
+				return nil
+			}
+			return err
+		}
+		if n == 0 && oobn == 0 {
+			return nil
+		}
+		if oobn > 0 {
+			received, err := parseFds(oob[:oobn])
+			if err != nil {
+				return err
+			}
+			fds = append(fds, received...)
+		}
+		pending = append(pending, buf[:n]...)
+
+		for {
+			_, _, size, ok := wire.Header(pending)
+			if !ok {
+				break
+			}
+			if size < wire.HeaderSize {
+				return fmt.Errorf("invalid message size %d", size)
+			}
+			if len(pending) < size {
+				break
+			}
+
+			m := s.decode(pending[:size], events)
+			s.log.message(s.id, m)
+
+			// Send the fds along with the message they belong to. Without
+			// a definition the number is unknown, so the whole queue goes.
+			n := len(fds)
+			if m.known && m.fds < n {
+				n = m.fds
+			}
+			var rights []byte
+			if n > 0 {
+				rights = unix.UnixRights(fds[:n]...)
+			}
+			_, _, err := to.WriteMsgUnix(pending[:size], rights, nil)
+			for _, fd := range fds[:n] {
+				unix.Close(fd)
+			}
+			fds = fds[n:]
+			if err != nil {
+				return err
+			}
+
+			for _, l := range m.lifetimes {
+				s.log.lifetime(s.id, l)
+			}
+			pending = pending[size:]
+		}
+		pending = append([]byte(nil), pending...)
+	}
+}
+
+func parseFds(oob []byte) ([]int, error) {
+	scms, err := unix.ParseSocketControlMessage(oob)
+	if err != nil {
+		return nil, err
+	}
+	var fds []int
+	for i := range scms {
+		rights, err := unix.ParseUnixRights(&scms[i])
+		if err != nil {
+			return nil, err
+		}
+		fds = append(fds, rights...)
+	}
+	return fds, nil
+}
+
+// message is a decoded request or event.
+type message struct {
+	time   time.Time
+	event  bool
+	sender uint32
+	opcode uint16
+	size   int
+
+	// known is set when the message was found in the protocols, iface is
+	// empty when the sender itself is unknown.
+	known bool
+	iface string
+	name  string
+	args  []argValue
+	fds   int
+	err   error
+	raw   []byte
+
+	lifetimes []lifetime
+}
+
+// argValue is a decoded argument. Value is an int32, uint32, float64,
+// string, nil (null string or object), []byte, objectRef or fdValue.
+type argValue struct {
+	arg   protocol.Arg
+	value any
+}
+
+type objectRef struct {
+	iface string
+	id    uint32
+	isNew bool
+	// version is set for new_ids without interface.
+	version uint32
+}
+
+type fdValue struct{}
+
+// lifetime records the creation or destruction of an object.
+type lifetime struct {
+	time      time.Time
+	created   bool
+	object    object
+	destroyer string
+}
+
+func (s *session) decode(data []byte, event bool) *message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sender, opcode, _, _ := wire.Header(data)
+	m := &message{
+		time:   time.Now(),
+		event:  event,
+		sender: sender,
+		opcode: opcode,
+		size:   len(data),
+		raw:    data[wire.HeaderSize:],
+	}
+
+	obj := s.objects[m.sender]
+	if obj == nil {
+		m.err = fmt.Errorf("unknown object %d", m.sender)
+		return m
+	}
+	obj.messages++
+	m.iface = obj.iface
+
+	name, args, destructor, ok := s.reg.message(obj.iface, m.opcode, event)
+	if !ok {
+		m.err = fmt.Errorf("unknown opcode %d", m.opcode)
+		return m
+	}
+	m.known = true
+	m.name = name
+
+	d := wire.NewDecoder(data[wire.HeaderSize:], nil)
+	for _, arg := range args {
+		v := decodeArg(d, arg)
+		if d.Err != nil {
+			m.err = d.Err
+			break
+		}
+		if arg.Type == "fd" {
+			m.fds++
+		}
+		m.args = append(m.args, argValue{arg: arg, value: v})
+
+		ref, ok := v.(objectRef)
+		if !ok {
+			continue
+		}
+		if ref.isNew {
+			o := &object{iface: ref.iface, id: ref.id, created: m.time}
+			s.objects[ref.id] = o
+			m.lifetimes = append(m.lifetimes, lifetime{time: m.time, created: true, object: *o})
+		} else if o := s.objects[ref.id]; o != nil {
+			// generic object arguments have no interface in the XML
+			ref.iface = o.iface
+			m.args[len(m.args)-1].value = ref
+		}
+	}
+
+	switch {
+	case event && obj.iface == "wl_display" && name == "delete_id" && len(m.args) == 1:
+		// client ids are only free once the server says so
+		id := m.args[0].value.(uint32)
+		if o := s.objects[id]; o != nil {
+			delete(s.objects, id)
+			if !o.destroyed {
+				m.lifetimes = append(m.lifetimes, lifetime{time: m.time, object: *o, destroyer: "wl_display.delete_id"})
+			}
+		}
+
+	case destructor:
+		obj.destroyed = true
+		if obj.id >= firstServerID {
+			delete(s.objects, obj.id)
+		}
+		m.lifetimes = append(m.lifetimes, lifetime{time: m.time, object: *obj, destroyer: obj.iface + "." + name})
+	}
+
+	return m
+}
+
+// decodeArg reads an argument, the fds are not part of the message and
+// only stand for the one sent along with it.
+func decodeArg(d *wire.Decoder, arg protocol.Arg) any {
+	switch arg.Type {
+	case "int":
+		return d.Int32()
+	case "uint":
+		return d.Uint32()
+	case "fixed":
+		return d.Fixed().Float64()
+	case "string":
+		if s, ok := d.String(); ok {
+			return s
+		}
+		return nil
+	case "array":
+		return d.Array()
+	case "fd":
+		return fdValue{}
+	case "object":
+		id := d.Uint32()
+		if id == 0 {
+			return nil
+		}
+		return objectRef{iface: arg.Interface, id: id}
+
+	case "new_id":
+		if arg.Interface != "" {
+			return objectRef{iface: arg.Interface, id: d.Uint32(), isNew: true}
+		}
+		// new_id without interface, as in wl_registry.bind
+		iface, _ := d.String()
+		version := d.Uint32()
+		return objectRef{iface: iface, id: d.Uint32(), isNew: true, version: version}
+	}
+	d.Err = fmt.Errorf("unknown argument type %q", arg.Type)
+	return nil
+}
+
+// fixedString formats a wl_fixed_t without trailing zeros.
+func fixedString(v float64) string {
+	if v == math.Trunc(v) {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%g", v)
+}
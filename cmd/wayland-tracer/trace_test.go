@@ -0,0 +1,386 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rajveermalviya/go-wayland/wayland/wire"
+	"golang.org/x/sys/unix"
+)
+
+// encode encodes a message like libwayland does.
+func encode(sender uint32, opcode uint16, args ...any) []byte {
+	e := &wire.Encoder{}
+	for _, arg := range args {
+		switch arg := arg.(type) {
+		case uint32:
+			e.Uint32(arg)
+		case int32:
+			e.Int32(arg)
+		case int:
+			e.Int32(int32(arg))
+		case string:
+			e.String(arg)
+		case []byte:
+			e.Array(arg)
+		default:
+			panic("unsupported argument type")
+		}
+	}
+	b, _ := e.Message(sender, opcode)
+	return b
+}
+
+func loadRegistry(t *testing.T) registry {
+	t.Helper()
+	reg := registry{}
+	if err := reg.load("../../wayland/protocol/xml/wayland/wayland.xml"); err != nil {
+		t.Fatal(err)
+	}
+	return reg
+}
+
+// call is a decoded message, summarized for comparisons.
+type call struct {
+	name string
+	args string
+	err  string
+}
+
+func summarize(m *message) call {
+	c := call{name: m.iface + "." + m.name}
+	args := make([]string, len(m.args))
+	for i, a := range m.args {
+		args[i] = formatValue(a.value)
+	}
+	c.args = strings.Join(args, ", ")
+	if m.err != nil {
+		c.err = m.err.Error()
+	}
+	return c
+}
+
+func TestDecode(t *testing.T) {
+	s := newSession(1, loadRegistry(t), nil, nil, nil)
+
+	for _, tt := range []struct {
+		data  []byte
+		event bool
+		want  call
+		fds   int
+		// lifetimes are "created" or "destroyed" followed by the object
+		lifetimes []string
+	}{
+		{
+			data:      encode(1, 1, uint32(2)),
+			want:      call{name: "wl_display.get_registry", args: "new id wl_registry#2"},
+			lifetimes: []string{"created wl_registry#2"},
+		},
+		{
+			data:  encode(2, 0, uint32(1), "wl_shm", uint32(1)),
+			event: true,
+			want:  call{name: "wl_registry.global", args: `1, "wl_shm", 1`},
+		},
+		{
+			// new_id without interface
+			data:      encode(2, 0, uint32(1), "wl_shm", uint32(1), uint32(3)),
+			want:      call{name: "wl_registry.bind", args: "1, new id wl_shm#3 (version 1)"},
+			lifetimes: []string{"created wl_shm#3"},
+		},
+		{
+			// fds are not in the data, only counted
+			data:      encode(3, 0, uint32(4), int32(4096)),
+			want:      call{name: "wl_shm.create_pool", args: "new id wl_shm_pool#4, fd, 4096"},
+			fds:       1,
+			lifetimes: []string{"created wl_shm_pool#4"},
+		},
+		{
+			data:      encode(4, 1),
+			want:      call{name: "wl_shm_pool.destroy"},
+			lifetimes: []string{"destroyed wl_shm_pool#4 by wl_shm_pool.destroy"},
+		},
+		{
+			// the id stays allocated until delete_id
+			data: encode(4, 2, int32(8192)),
+			want: call{name: "wl_shm_pool.resize", args: "8192"},
+		},
+		{
+			data:  encode(1, 1, uint32(4)),
+			event: true,
+			want:  call{name: "wl_display.delete_id", args: "4"},
+		},
+		{
+			data: encode(4, 2, int32(8192)),
+			want: call{name: ".", err: "unknown object 4"},
+		},
+		{
+			// object arguments, null and with an interface
+			data:      encode(1, 1, uint32(5)),
+			want:      call{name: "wl_display.get_registry", args: "new id wl_registry#5"},
+			lifetimes: []string{"created wl_registry#5"},
+		},
+		{
+			data:  encode(1, 0, uint32(5), uint32(3), "invalid"),
+			event: true,
+			want:  call{name: "wl_display.error", args: `wl_registry#5, 3, "invalid"`},
+		},
+		{
+			data:  encode(1, 0, uint32(0), uint32(3), "invalid"),
+			event: true,
+			want:  call{name: "wl_display.error", args: `nil, 3, "invalid"`},
+		},
+		{
+			data: encode(3, 7),
+			want: call{name: "wl_shm.", err: "unknown opcode 7"},
+		},
+		{
+			data: encode(2, 0, uint32(1), "wl_seat"),
+			want: call{name: "wl_registry.bind", args: `1`, err: "message too short"},
+		},
+	} {
+		m := s.decode(tt.data, tt.event)
+		if got := summarize(m); got != tt.want {
+			t.Errorf("got %+v, want %+v", got, tt.want)
+		}
+		if m.fds != tt.fds {
+			t.Errorf("%s: got %d fds, want %d", m.name, m.fds, tt.fds)
+		}
+		var lifetimes []string
+		for _, l := range m.lifetimes {
+			if l.created {
+				lifetimes = append(lifetimes, "created "+l.object.String())
+			} else {
+				lifetimes = append(lifetimes, "destroyed "+l.object.String()+" by "+l.destroyer)
+			}
+		}
+		if !reflect.DeepEqual(lifetimes, tt.lifetimes) {
+			t.Errorf("%s: got lifetimes %q, want %q", m.name, lifetimes, tt.lifetimes)
+		}
+	}
+}
+
+func TestDecodeArgs(t *testing.T) {
+	s := newSession(1, loadRegistry(t), nil, nil, nil)
+	s.objects[3] = &object{iface: "wl_pointer", id: 3}
+	s.objects[4] = &object{iface: "wl_keyboard", id: 4}
+	s.objects[5] = &object{iface: "wl_data_offer", id: 5}
+
+	for _, tt := range []struct {
+		data  []byte
+		event bool
+		want  call
+	}{
+		{
+			// fixed
+			data:  encode(3, 2, uint32(7), int32(10*256+128), int32(-3*256)),
+			event: true,
+			want:  call{name: "wl_pointer.motion", args: "7, 10.5, -3"},
+		},
+		{
+			// array
+			data:  encode(4, 1, uint32(1), uint32(0), []byte{1, 0, 0, 0, 2, 0, 0, 0}),
+			event: true,
+			want:  call{name: "wl_keyboard.enter", args: "1, nil, array[8]"},
+		},
+		{
+			// nullable string
+			data: encode(5, 0, uint32(1), uint32(0)),
+			want: call{name: "wl_data_offer.accept", args: "1, nil"},
+		},
+		{
+			data: encode(5, 0, uint32(1), "text/plain"),
+			want: call{name: "wl_data_offer.accept", args: `1, "text/plain"`},
+		},
+	} {
+		if got := summarize(s.decode(tt.data, tt.event)); got != tt.want {
+			t.Errorf("got %+v, want %+v", got, tt.want)
+		}
+	}
+}
+
+// tracedConns starts a session between two socketpairs and returns the
+// ends of the client and the compositor. wait closes them and returns the
+// log once the session is over.
+func tracedConns(t *testing.T) (client, server *net.UnixConn, wait func() []map[string]any) {
+	t.Helper()
+
+	pair := func(name string) (*net.UnixConn, *net.UnixConn) {
+		fds, err := unix.Socketpair(unix.AF_UNIX, unix.SOCK_STREAM|unix.SOCK_CLOEXEC, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		conn := func(fd int, name string) *net.UnixConn {
+			f := os.NewFile(uintptr(fd), name)
+			defer f.Close()
+			c, err := net.FileConn(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return c.(*net.UnixConn)
+		}
+		return conn(fds[0], name), conn(fds[1], name+"-traced")
+	}
+	client, tracedClient := pair("client")
+	server, tracedServer := pair("server")
+
+	out := &bytes.Buffer{}
+	log := &logger{w: out, json: true, start: time.Now()}
+	session := newSession(1, loadRegistry(t), log, tracedClient, tracedServer)
+	done := make(chan struct{})
+	go func() {
+		session.run()
+		close(done)
+	}()
+
+	return client, server, func() []map[string]any {
+		t.Helper()
+		client.Close()
+		server.Close()
+		<-done
+
+		var lines []map[string]any
+		dec := json.NewDecoder(out)
+		for dec.More() {
+			var line map[string]any
+			if err := dec.Decode(&line); err != nil {
+				t.Fatal(err)
+			}
+			lines = append(lines, line)
+		}
+		return lines
+	}
+}
+
+// send writes data with fds, and receive reads size bytes and the fds sent
+// along with them.
+func send(t *testing.T, c *net.UnixConn, data []byte, fds ...int) {
+	t.Helper()
+	var oob []byte
+	if len(fds) > 0 {
+		oob = unix.UnixRights(fds...)
+	}
+	if _, _, err := c.WriteMsgUnix(data, oob, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func receive(t *testing.T, c *net.UnixConn, size int) ([]byte, []int) {
+	t.Helper()
+	if err := c.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	var data []byte
+	var fds []int
+	for len(data) < size {
+		buf := make([]byte, size-len(data))
+		oob := make([]byte, unix.CmsgSpace(maxFds*4))
+		n, oobn, _, _, err := c.ReadMsgUnix(buf, oob)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data = append(data, buf[:n]...)
+		if oobn > 0 {
+			received, err := parseFds(oob[:oobn])
+			if err != nil {
+				t.Fatal(err)
+			}
+			fds = append(fds, received...)
+		}
+	}
+	return data, fds
+}
+
+// memfd returns a file holding content.
+func memfd(t *testing.T, content string) int {
+	t.Helper()
+	fd, err := unix.MemfdCreate("wayland-tracer-test", unix.MFD_CLOEXEC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { unix.Close(fd) })
+	if _, err := unix.Write(fd, []byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	return fd
+}
+
+// content reads a file received over the socket from its start, and closes
+// it.
+func content(t *testing.T, fd int) string {
+	t.Helper()
+	defer unix.Close(fd)
+	b := make([]byte, 64)
+	n, err := unix.Pread(fd, b, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b[:n])
+}
+
+func TestForward(t *testing.T) {
+	client, server, wait := tracedConns(t)
+
+	// Requests are forwarded unchanged, even when split across writes
+	getRegistry := encode(1, 1, uint32(2))
+	bind := encode(2, 0, uint32(1), "wl_shm", uint32(1), uint32(3))
+	send(t, client, append(getRegistry, bind[:10]...))
+	send(t, client, bind[10:])
+	if got, fds := receive(t, server, len(getRegistry)+len(bind)); !bytes.Equal(got, append(getRegistry, bind...)) || len(fds) != 0 {
+		t.Fatalf("got %x with %d fds, want %x", got, len(fds), append(getRegistry, bind...))
+	}
+
+	// The fds go with the message they belong to: a request without fds
+	// sent along with the ones of the next request doesn't take them
+	resize := encode(4, 2, int32(8192))
+	createPool := encode(3, 0, uint32(4), int32(4096))
+	send(t, client, append(createPool, resize...), memfd(t, "pool"))
+	got, fds := receive(t, server, len(createPool))
+	if !bytes.Equal(got, createPool) || len(fds) != 1 {
+		t.Fatalf("got %x with %d fds, want create_pool with 1 fd", got, len(fds))
+	}
+	if c := content(t, fds[0]); c != "pool" {
+		t.Errorf("got pool fd with %q, want %q", c, "pool")
+	}
+	if got, fds := receive(t, server, len(resize)); !bytes.Equal(got, resize) || len(fds) != 0 {
+		t.Fatalf("got %x with %d fds, want resize without fds", got, len(fds))
+	}
+
+	// Events with fds, the keyboard is created from a seat
+	send(t, client, encode(2, 0, uint32(2), "wl_seat", uint32(7), uint32(5)))
+	send(t, client, encode(5, 1, uint32(6)))
+	receive(t, server, 32+12)
+	keymap := encode(6, 0, uint32(1), uint32(4))
+	send(t, server, keymap, memfd(t, "keymap"))
+	got, fds = receive(t, client, len(keymap))
+	if !bytes.Equal(got, keymap) || len(fds) != 1 {
+		t.Fatalf("got %x with %d fds, want keymap with 1 fd", got, len(fds))
+	}
+	if c := content(t, fds[0]); c != "keymap" {
+		t.Errorf("got keymap fd with %q, want %q", c, "keymap")
+	}
+
+	var messages []string
+	for _, line := range wait() {
+		if name, ok := line["message"].(string); ok {
+			messages = append(messages, line["direction"].(string)+" "+line["interface"].(string)+"."+name)
+		}
+	}
+	want := []string{
+		"request wl_display.get_registry",
+		"request wl_registry.bind",
+		"request wl_shm.create_pool",
+		"request wl_shm_pool.resize",
+		"request wl_registry.bind",
+		"request wl_seat.get_keyboard",
+		"event wl_keyboard.keymap",
+	}
+	if !reflect.DeepEqual(messages, want) {
+		t.Errorf("got messages %q, want %q", messages, want)
+	}
+}
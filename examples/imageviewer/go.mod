@@ -1,10 +1,10 @@
 module github.com/rajveermalviya/go-wayland/examples/imageviewer
 
-go 1.19
+go 1.21
 
 require (
 	github.com/nfnt/resize v0.0.0-20180221191011-83c6a9932646
-	github.com/rajveermalviya/go-wayland/wayland v0.0.0-20230130180959-d756ac1b56f0
+	github.com/rajveermalviya/go-wayland/wayland v0.0.0-20261018214816-a5b246148021
 	golang.org/x/image v0.3.0
 	golang.org/x/sys v0.0.0-20220722155257-8c9f86f7a55f
 )
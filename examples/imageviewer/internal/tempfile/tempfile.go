@@ -1,26 +0,0 @@
-package tempfile
-
-import (
-	"errors"
-	"os"
-)
-
-func Create(size int64) (*os.File, error) {
-	dir := os.Getenv("XDG_RUNTIME_DIR")
-	if dir == "" {
-		return nil, errors.New("XDG_RUNTIME_DIR is not defined in env")
-	}
-	file, err := os.CreateTemp(dir, "wl_shm_go_*")
-	if err != nil {
-		return nil, err
-	}
-	err = file.Truncate(size)
-	if err != nil {
-		return nil, err
-	}
-	err = os.Remove(file.Name())
-	if err != nil {
-		return nil, err
-	}
-	return file, nil
-}
@@ -1,17 +1,17 @@
 package main
 
 import (
+	"errors"
 	"image"
 	"log"
 	"os"
 
 	"github.com/nfnt/resize"
 	"github.com/rajveermalviya/go-wayland/examples/imageviewer/internal/swizzle"
-	"github.com/rajveermalviya/go-wayland/examples/imageviewer/internal/tempfile"
 	"github.com/rajveermalviya/go-wayland/wayland/client"
 	"github.com/rajveermalviya/go-wayland/wayland/cursor"
+	"github.com/rajveermalviya/go-wayland/wayland/shm"
 	xdg_shell "github.com/rajveermalviya/go-wayland/wayland/stable/xdg-shell"
-	"golang.org/x/sys/unix"
 )
 
 // Global app state
@@ -26,6 +26,8 @@ type appState struct {
 	display     *client.Display
 	registry    *client.Registry
 	shm         *client.Shm
+	pool        *shm.Pool
+	buffers     *shm.Slots
 	compositor  *client.Compositor
 	xdgWmBase   *xdg_shell.WmBase
 	seat        *client.Seat
@@ -34,6 +36,9 @@ type appState struct {
 	surface     *client.Surface
 	xdgSurface  *xdg_shell.Surface
 	xdgTopLevel *xdg_shell.Toplevel
+	// frameCallback is requested when a frame is delayed, to draw it
+	// again once the compositor is done with a buffer
+	frameCallback *client.Callback
 
 	keyboard *client.Keyboard
 	pointer  *client.Pointer
@@ -176,15 +181,13 @@ func (app *appState) HandleRegistryGlobal(e client.RegistryGlobalEvent) {
 
 	switch e.Interface {
 	case "wl_compositor":
-		compositor := client.NewCompositor(app.context())
-		err := app.registry.Bind(e.Name, e.Interface, e.Version, compositor)
+		compositor, err := client.Bind[client.Compositor](app.registry, e.Name, e.Version)
 		if err != nil {
 			log.Fatalf("unable to bind wl_compositor interface: %v", err)
 		}
 		app.compositor = compositor
 	case "wl_shm":
-		shm := client.NewShm(app.context())
-		err := app.registry.Bind(e.Name, e.Interface, e.Version, shm)
+		shm, err := client.Bind[client.Shm](app.registry, e.Name, e.Version)
 		if err != nil {
 			log.Fatalf("unable to bind wl_shm interface: %v", err)
 		}
@@ -192,8 +195,7 @@ func (app *appState) HandleRegistryGlobal(e client.RegistryGlobalEvent) {
 
 		shm.SetFormatHandler(app.HandleShmFormat)
 	case "xdg_wm_base":
-		xdgWmBase := xdg_shell.NewWmBase(app.context())
-		err := app.registry.Bind(e.Name, e.Interface, e.Version, xdgWmBase)
+		xdgWmBase, err := client.Bind[xdg_shell.WmBase](app.registry, e.Name, e.Version)
 		if err != nil {
 			log.Fatalf("unable to bind xdg_wm_base interface: %v", err)
 		}
@@ -201,13 +203,12 @@ func (app *appState) HandleRegistryGlobal(e client.RegistryGlobalEvent) {
 		// Add xdg_wmbase ping handler
 		xdgWmBase.SetPingHandler(app.HandleWmBasePing)
 	case "wl_seat":
-		seat := client.NewSeat(app.context())
-		err := app.registry.Bind(e.Name, e.Interface, e.Version, seat)
+		seat, err := client.Bind[client.Seat](app.registry, e.Name, e.Version)
 		if err != nil {
 			log.Fatalf("unable to bind wl_seat interface: %v", err)
 		}
 		app.seat = seat
-		app.seatVersion = e.Version
+		app.seatVersion = seat.Version()
 		// Add Keyboard & Pointer handlers
 		seat.SetCapabilitiesHandler(app.HandleSeatCapabilities)
 		seat.SetNameHandler(app.HandleSeatName)
@@ -224,12 +225,29 @@ func (app *appState) HandleSurfaceConfigure(e xdg_shell.SurfaceConfigureEvent) {
 		log.Fatal("unable to ack xdg surface configure")
 	}
 
-	// Draw frame
-	buffer := app.drawFrame()
+	app.redraw()
+}
 
-	// Attach new frame to the surface
-	if err := app.surface.Attach(buffer, 0, 0); err != nil {
-		log.Fatalf("unable to attach buffer to surface: %v", err)
+// redraw draws the frame of the current size and commits it. When all the
+// buffers are busy, the frame is drawn again from a frame callback, so that
+// the last size configured is not lost.
+func (app *appState) redraw() {
+	// Draw frame
+	if buffer := app.drawFrame(); buffer != nil {
+		// Attach new frame to the surface
+		if err := buffer.Attach(app.surface, 0, 0); err != nil {
+			log.Fatalf("unable to attach buffer to surface: %v", err)
+		}
+	} else if app.frameCallback == nil {
+		callback, err := app.surface.Frame()
+		if err != nil {
+			log.Fatalf("unable to request frame callback: %v", err)
+		}
+		callback.SetDoneHandler(func(client.CallbackDoneEvent) {
+			app.frameCallback = nil
+			app.redraw()
+		})
+		app.frameCallback = callback
 	}
 	// Commit the surface state
 	if err := app.surface.Commit(); err != nil {
@@ -262,63 +280,42 @@ func (app *appState) HandleToplevelConfigure(e xdg_shell.ToplevelConfigureEvent)
 	app.height = height
 }
 
-func (app *appState) drawFrame() *client.Buffer {
+// drawFrame draws the frame in a buffer which isn't read by the
+// compositor, it returns nil when all of them are.
+func (app *appState) drawFrame() *shm.Buffer {
 	logPrintln("drawing frame")
 
-	stride := app.width * 4
-	size := stride * app.height
-
-	file, err := tempfile.Create(int64(size))
-	if err != nil {
-		log.Fatalf("unable to create a temporary file: %v", err)
-	}
-	defer func() {
-		if err2 := file.Close(); err2 != nil {
-			logPrintf("unable to close file: %v", err2)
+	if app.pool == nil {
+		pool, err := shm.NewPool(app.shm, int(app.width*app.height*4))
+		if err != nil {
+			log.Fatalf("unable to create shm pool: %v", err)
 		}
-	}()
-
-	data, err := unix.Mmap(int(file.Fd()), 0, int(size), unix.PROT_READ|unix.PROT_WRITE, unix.MAP_SHARED)
-	if err != nil {
-		log.Fatalf("unable to create mapping: %v", err)
+		app.pool = pool
+		// Double buffering
+		app.buffers = shm.NewSlots(pool, 2)
 	}
-	defer func() {
-		if err2 := unix.Munmap(data); err2 != nil {
-			logPrintf("unable to delete mapping: %v", err2)
-		}
-	}()
 
-	pool, err := app.shm.CreatePool(int(file.Fd()), size)
-	if err != nil {
-		log.Fatalf("unable to create shm pool: %v", err)
+	// Buffers of a previous size are destroyed by Next
+	buf, err := app.buffers.Next(app.width, app.height, app.width*4, client.ShmFormatArgb8888)
+	if errors.Is(err, shm.ErrBusy) {
+		logPrintln("delaying frame, all buffers are busy")
+		return nil
 	}
-	defer func() {
-		if err2 := pool.Destroy(); err2 != nil {
-			logPrintf("unable to destroy shm pool: %v", err2)
-		}
-	}()
-
-	buf, err := pool.CreateBuffer(0, app.width, app.height, stride, uint32(client.ShmFormatArgb8888))
 	if err != nil {
-		log.Fatalf("unable to create client.Buffer from shm pool: %v", err)
+		log.Fatalf("unable to allocate buffer: %v", err)
 	}
 
 	// Convert RGBA to BGRA
+	data := buf.Data()
 	copy(data, app.frame.Pix)
 	swizzle.BGRA(data)
 
-	buf.SetReleaseHandler(func(_ client.BufferReleaseEvent) {
-		if err := buf.Destroy(); err != nil {
-			logPrintf("unable to destroy buffer: %v", err)
-		}
-	})
-
 	logPrintln("drawing frame complete")
 	return buf
 }
 
 func (app *appState) HandleSeatCapabilities(e client.SeatCapabilitiesEvent) {
-	havePointer := (e.Capabilities * uint32(client.SeatCapabilityPointer)) != 0
+	havePointer := e.Capabilities.Has(client.SeatCapabilityPointer)
 
 	if havePointer && app.pointer == nil {
 		app.attachPointer()
@@ -326,7 +323,7 @@ func (app *appState) HandleSeatCapabilities(e client.SeatCapabilitiesEvent) {
 		app.releasePointer()
 	}
 
-	haveKeyboard := (e.Capabilities * uint32(client.SeatCapabilityKeyboard)) != 0
+	haveKeyboard := e.Capabilities.Has(client.SeatCapabilityKeyboard)
 
 	if haveKeyboard && app.keyboard == nil {
 		app.attachKeyboard()
@@ -362,11 +359,6 @@ func (app *appState) displayRoundTrip() {
 	if err != nil {
 		log.Fatalf("unable to get sync callback: %v", err)
 	}
-	defer func() {
-		if err2 := callback.Destroy(); err2 != nil {
-			logPrintln("unable to destroy callback:", err2)
-		}
-	}()
 
 	done := false
 	callback.SetDoneHandler(func(_ client.CallbackDoneEvent) {
@@ -439,6 +431,20 @@ func (app *appState) cleanup() {
 		app.xdgWmBase = nil
 	}
 
+	if app.buffers != nil {
+		if err := app.buffers.Destroy(); err != nil {
+			logPrintln("unable to destroy buffers:", err)
+		}
+		app.buffers = nil
+	}
+
+	if app.pool != nil {
+		if err := app.pool.Destroy(); err != nil {
+			logPrintln("unable to destroy shm pool:", err)
+		}
+		app.pool = nil
+	}
+
 	if app.shm != nil {
 		if err := app.shm.Destroy(); err != nil {
 			logPrintln("unable to destroy wl_shm:", err)
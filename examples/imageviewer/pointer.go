@@ -29,7 +29,8 @@ const (
 type pointerEvent struct {
 	eventMask          int
 	surfaceX, surfaceY uint32
-	button, state      uint32
+	button             uint32
+	state              client.PointerButtonState
 	time               uint32
 	serial             uint32
 	axes               [2]struct {
@@ -37,7 +38,7 @@ type pointerEvent struct {
 		value    int32
 		discrete int32
 	}
-	axisSource uint32
+	axisSource client.PointerAxisSource
 }
 
 func (app *appState) attachPointer() {
@@ -163,7 +164,7 @@ func (app *appState) HandlePointerFrame(_ client.PointerFrameEvent) {
 		}
 	}
 	if (e.eventMask & pointerEventButton) != 0 {
-		if e.state == uint32(client.PointerButtonStateReleased) {
+		if e.state == client.PointerButtonStateReleased {
 			logPrintf("button %d released", e.button)
 		} else {
 			logPrintf("button %d pressed", e.button)
@@ -172,7 +173,7 @@ func (app *appState) HandlePointerFrame(_ client.PointerFrameEvent) {
 			case BtnLeft:
 				edge := componentEdge(uint32(app.width), uint32(app.height), e.surfaceX, e.surfaceY, 8)
 				if edge != xdg_shell.ToplevelResizeEdgeNone {
-					if err := app.xdgTopLevel.Resize(app.seat, e.serial, uint32(edge)); err != nil {
+					if err := app.xdgTopLevel.Resize(app.seat, e.serial, edge); err != nil {
 						logPrintln("unable to start resize")
 					}
 				} else {
@@ -203,7 +204,7 @@ func (app *appState) HandlePointerFrame(_ client.PointerFrameEvent) {
 				logPrintf("discrete %d ", e.axes[i].discrete)
 			}
 			if (e.eventMask & pointerEventAxisSource) != 0 {
-				logPrintf("via %s", client.PointerAxisSource(e.axisSource).Name())
+				logPrintf("via %s", e.axisSource.Name())
 			}
 			if (e.eventMask & pointerEventAxisStop) != 0 {
 				logPrintf("(stopped)")
@@ -1,6 +1,6 @@
 // Generated by go-wayland-scanner
 // https://github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner
-// XML file : https://raw.githubusercontent.com/wayland-project/wayland/1.21.0/protocol/wayland.xml
+// XML file : https://raw.githubusercontent.com/wayland-project/wayland/1.24.0/protocol/wayland.xml
 //
 // wayland Protocol Copyright:
 //
@@ -31,7 +31,14 @@
 
 package client
 
-import "golang.org/x/sys/unix"
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
 
 // Display : core global object
 //
@@ -53,6 +60,59 @@ func NewDisplay(ctx *Context) *Display {
 	return wlDisplay
 }
 
+// DisplayInterface describes wl_display at runtime.
+var DisplayInterface = &Interface{
+	Name:    "wl_display",
+	Version: 1,
+	New:     func() Proxy { return &Display{} },
+	Requests: []Message{
+		{
+			Name: "sync",
+			Args: []Arg{
+				{Name: "callback", Type: "new_id", Interface: "wl_callback"},
+			},
+		},
+		{
+			Name: "get_registry",
+			Args: []Arg{
+				{Name: "registry", Type: "new_id", Interface: "wl_registry"},
+			},
+		},
+	},
+	Events: []Message{
+		{
+			Name: "error",
+			Args: []Arg{
+				{Name: "object_id", Type: "object"},
+				{Name: "code", Type: "uint"},
+				{Name: "message", Type: "string"},
+			},
+		},
+		{
+			Name: "delete_id",
+			Args: []Arg{
+				{Name: "id", Type: "uint"},
+			},
+		},
+	},
+	Enums: []Enum{
+		{
+			Name: "error",
+			Entries: []EnumEntry{
+				{Name: "invalid_object", Value: 0},
+				{Name: "invalid_method", Value: 1},
+				{Name: "no_memory", Value: 2},
+				{Name: "implementation", Value: 3},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wl_display.
+func (i *Display) Interface() *Interface {
+	return DisplayInterface
+}
+
 // Sync : asynchronous roundtrip
 //
 // The sync request asks the server to emit the 'done' event
@@ -68,6 +128,7 @@ func NewDisplay(ctx *Context) *Display {
 // The callback_data passed in the callback is the event serial.
 func (i *Display) Sync() (*Callback, error) {
 	callback := NewCallback(i.Context())
+	callback.SetVersion(i.Version())
 	const opcode = 0
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -95,6 +156,7 @@ func (i *Display) Sync() (*Callback, error) {
 // possible to avoid wasting memory.
 func (i *Display) GetRegistry() (*Registry, error) {
 	registry := NewRegistry(i.Context())
+	registry.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -186,6 +248,24 @@ func (i *Display) SetErrorHandler(f DisplayErrorHandlerFunc) {
 	i.errorHandler = f
 }
 
+// String formats the event as wl_display.error(argument=value, ...).
+func (e DisplayErrorEvent) String() string {
+	return "wl_display.error(" +
+		"object_id=" + ObjectString(e.ObjectId) +
+		", code=" + strconv.FormatUint(uint64(e.Code), 10) +
+		", message=" + strconv.Quote(e.Message) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DisplayErrorEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("object_id", ObjectString(e.ObjectId)),
+		slog.Uint64("code", uint64(e.Code)),
+		slog.String("message", e.Message),
+	)
+}
+
 // DisplayDeleteIdEvent : acknowledge object ID deletion
 //
 // This event is used internally by the object ID management
@@ -203,6 +283,49 @@ func (i *Display) SetDeleteIdHandler(f DisplayDeleteIdHandlerFunc) {
 	i.deleteIdHandler = f
 }
 
+// String formats the event as wl_display.delete_id(argument=value, ...).
+func (e DisplayDeleteIdEvent) String() string {
+	return "wl_display.delete_id(" +
+		"id=" + strconv.FormatUint(uint64(e.Id), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DisplayDeleteIdEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("id", uint64(e.Id)),
+	)
+}
+
+// DisplayListener receives all the events of Display, embed
+// DisplayNoopListener to implement only some of them.
+type DisplayListener interface {
+	Error(e DisplayErrorEvent)
+	DeleteId(e DisplayDeleteIdEvent)
+}
+
+// DisplayNoopListener is a DisplayListener ignoring
+// all the events, it closes the file descriptors they carry.
+type DisplayNoopListener struct{}
+
+func (DisplayNoopListener) Error(DisplayErrorEvent) {
+}
+
+func (DisplayNoopListener) DeleteId(DisplayDeleteIdEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *Display) SetListener(l DisplayListener) {
+	if l == nil {
+		i.errorHandler = nil
+		i.deleteIdHandler = nil
+		return
+	}
+	i.errorHandler = l.Error
+	i.deleteIdHandler = l.DeleteId
+}
+
 func (i *Display) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -290,6 +413,43 @@ func NewRegistry(ctx *Context) *Registry {
 	return wlRegistry
 }
 
+// RegistryInterface describes wl_registry at runtime.
+var RegistryInterface = &Interface{
+	Name:    "wl_registry",
+	Version: 1,
+	New:     func() Proxy { return &Registry{} },
+	Requests: []Message{
+		{
+			Name: "bind",
+			Args: []Arg{
+				{Name: "name", Type: "uint"},
+				{Name: "id", Type: "new_id"},
+			},
+		},
+	},
+	Events: []Message{
+		{
+			Name: "global",
+			Args: []Arg{
+				{Name: "name", Type: "uint"},
+				{Name: "interface", Type: "string"},
+				{Name: "version", Type: "uint"},
+			},
+		},
+		{
+			Name: "global_remove",
+			Args: []Arg{
+				{Name: "name", Type: "uint"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wl_registry.
+func (i *Registry) Interface() *Interface {
+	return RegistryInterface
+}
+
 // Bind : bind an object to the display
 //
 // Binds a new, client-created object to the server using the
@@ -297,6 +457,7 @@ func NewRegistry(ctx *Context) *Registry {
 //
 //	name: unique numeric name of the object
 func (i *Registry) Bind(name uint32, iface string, version uint32, id Proxy) error {
+	id.SetVersion(version)
 	const opcode = 0
 	ifaceLen := PaddedLen(len(iface) + 1)
 	_reqBufLen := 8 + 4 + (4 + ifaceLen) + 4 + 4
@@ -308,7 +469,7 @@ func (i *Registry) Bind(name uint32, iface string, version uint32, id Proxy) err
 	l += 4
 	PutUint32(_reqBuf[l:l+4], uint32(name))
 	l += 4
-	PutString(_reqBuf[l:l+(4+ifaceLen)], iface, ifaceLen)
+	PutString(_reqBuf[l:l+(4+ifaceLen)], iface)
 	l += (4 + ifaceLen)
 	PutUint32(_reqBuf[l:l+4], uint32(version))
 	l += 4
@@ -342,6 +503,24 @@ func (i *Registry) SetGlobalHandler(f RegistryGlobalHandlerFunc) {
 	i.globalHandler = f
 }
 
+// String formats the event as wl_registry.global(argument=value, ...).
+func (e RegistryGlobalEvent) String() string {
+	return "wl_registry.global(" +
+		"name=" + strconv.FormatUint(uint64(e.Name), 10) +
+		", interface=" + strconv.Quote(e.Interface) +
+		", version=" + strconv.FormatUint(uint64(e.Version), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e RegistryGlobalEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("name", uint64(e.Name)),
+		slog.String("interface", e.Interface),
+		slog.Uint64("version", uint64(e.Version)),
+	)
+}
+
 // RegistryGlobalRemoveEvent : announce removal of global object
 //
 // Notify the client of removed global objects.
@@ -364,6 +543,49 @@ func (i *Registry) SetGlobalRemoveHandler(f RegistryGlobalRemoveHandlerFunc) {
 	i.globalRemoveHandler = f
 }
 
+// String formats the event as wl_registry.global_remove(argument=value, ...).
+func (e RegistryGlobalRemoveEvent) String() string {
+	return "wl_registry.global_remove(" +
+		"name=" + strconv.FormatUint(uint64(e.Name), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e RegistryGlobalRemoveEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("name", uint64(e.Name)),
+	)
+}
+
+// RegistryListener receives all the events of Registry, embed
+// RegistryNoopListener to implement only some of them.
+type RegistryListener interface {
+	Global(e RegistryGlobalEvent)
+	GlobalRemove(e RegistryGlobalRemoveEvent)
+}
+
+// RegistryNoopListener is a RegistryListener ignoring
+// all the events, it closes the file descriptors they carry.
+type RegistryNoopListener struct{}
+
+func (RegistryNoopListener) Global(RegistryGlobalEvent) {
+}
+
+func (RegistryNoopListener) GlobalRemove(RegistryGlobalRemoveEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *Registry) SetListener(l RegistryListener) {
+	if l == nil {
+		i.globalHandler = nil
+		i.globalRemoveHandler = nil
+		return
+	}
+	i.globalHandler = l.Global
+	i.globalRemoveHandler = l.GlobalRemove
+}
+
 func (i *Registry) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -414,6 +636,27 @@ func NewCallback(ctx *Context) *Callback {
 	return wlCallback
 }
 
+// CallbackInterface describes wl_callback at runtime.
+var CallbackInterface = &Interface{
+	Name:    "wl_callback",
+	Version: 1,
+	New:     func() Proxy { return &Callback{} },
+	Events: []Message{
+		{
+			Name:       "done",
+			Destructor: true,
+			Args: []Arg{
+				{Name: "callback_data", Type: "uint"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wl_callback.
+func (i *Callback) Interface() *Interface {
+	return CallbackInterface
+}
+
 func (i *Callback) Destroy() error {
 	i.Context().Unregister(i)
 	return nil
@@ -432,9 +675,47 @@ func (i *Callback) SetDoneHandler(f CallbackDoneHandlerFunc) {
 	i.doneHandler = f
 }
 
+// String formats the event as wl_callback.done(argument=value, ...).
+func (e CallbackDoneEvent) String() string {
+	return "wl_callback.done(" +
+		"callback_data=" + strconv.FormatUint(uint64(e.CallbackData), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e CallbackDoneEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("callback_data", uint64(e.CallbackData)),
+	)
+}
+
+// CallbackListener receives all the events of Callback, embed
+// CallbackNoopListener to implement only some of them.
+type CallbackListener interface {
+	Done(e CallbackDoneEvent)
+}
+
+// CallbackNoopListener is a CallbackListener ignoring
+// all the events, it closes the file descriptors they carry.
+type CallbackNoopListener struct{}
+
+func (CallbackNoopListener) Done(CallbackDoneEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *Callback) SetListener(l CallbackListener) {
+	if l == nil {
+		i.doneHandler = nil
+		return
+	}
+	i.doneHandler = l.Done
+}
+
 func (i *Callback) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
+		defer i.Context().Unregister(i)
 		if i.doneHandler == nil {
 			return
 		}
@@ -467,11 +748,38 @@ func NewCompositor(ctx *Context) *Compositor {
 	return wlCompositor
 }
 
+// CompositorInterface describes wl_compositor at runtime.
+var CompositorInterface = &Interface{
+	Name:    "wl_compositor",
+	Version: 6,
+	New:     func() Proxy { return &Compositor{} },
+	Requests: []Message{
+		{
+			Name: "create_surface",
+			Args: []Arg{
+				{Name: "id", Type: "new_id", Interface: "wl_surface"},
+			},
+		},
+		{
+			Name: "create_region",
+			Args: []Arg{
+				{Name: "id", Type: "new_id", Interface: "wl_region"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wl_compositor.
+func (i *Compositor) Interface() *Interface {
+	return CompositorInterface
+}
+
 // CreateSurface : create new surface
 //
 // Ask the compositor to create a new surface.
 func (i *Compositor) CreateSurface() (*Surface, error) {
 	id := NewSurface(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 0
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -491,6 +799,7 @@ func (i *Compositor) CreateSurface() (*Surface, error) {
 // Ask the compositor to create a new region.
 func (i *Compositor) CreateRegion() (*Region, error) {
 	id := NewRegion(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -538,6 +847,41 @@ func NewShmPool(ctx *Context) *ShmPool {
 	return wlShmPool
 }
 
+// ShmPoolInterface describes wl_shm_pool at runtime.
+var ShmPoolInterface = &Interface{
+	Name:    "wl_shm_pool",
+	Version: 1,
+	New:     func() Proxy { return &ShmPool{} },
+	Requests: []Message{
+		{
+			Name: "create_buffer",
+			Args: []Arg{
+				{Name: "id", Type: "new_id", Interface: "wl_buffer"},
+				{Name: "offset", Type: "int"},
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+				{Name: "stride", Type: "int"},
+				{Name: "format", Type: "uint", Enum: "wl_shm.format"},
+			},
+		},
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "resize",
+			Args: []Arg{
+				{Name: "size", Type: "int"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wl_shm_pool.
+func (i *ShmPool) Interface() *Interface {
+	return ShmPoolInterface
+}
+
 // CreateBuffer : create a buffer from the pool
 //
 // Create a wl_buffer object from the pool.
@@ -557,8 +901,9 @@ func NewShmPool(ctx *Context) *ShmPool {
 //	height: buffer height, in pixels
 //	stride: number of bytes from the beginning of one row to the beginning of the next row
 //	format: buffer pixel format
-func (i *ShmPool) CreateBuffer(offset, width, height, stride int32, format uint32) (*Buffer, error) {
+func (i *ShmPool) CreateBuffer(offset, width, height, stride int32, format ShmFormat) (*Buffer, error) {
 	id := NewBuffer(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 0
 	const _reqBufLen = 8 + 4 + 4 + 4 + 4 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -666,6 +1011,164 @@ func NewShm(ctx *Context) *Shm {
 	return wlShm
 }
 
+// ShmInterface describes wl_shm at runtime.
+var ShmInterface = &Interface{
+	Name:    "wl_shm",
+	Version: 2,
+	New:     func() Proxy { return &Shm{} },
+	Requests: []Message{
+		{
+			Name: "create_pool",
+			Args: []Arg{
+				{Name: "id", Type: "new_id", Interface: "wl_shm_pool"},
+				{Name: "fd", Type: "fd"},
+				{Name: "size", Type: "int"},
+			},
+		},
+		{
+			Name:       "release",
+			Since:      2,
+			Destructor: true,
+		},
+	},
+	Events: []Message{
+		{
+			Name: "format",
+			Args: []Arg{
+				{Name: "format", Type: "uint", Enum: "wl_shm.format"},
+			},
+		},
+	},
+	Enums: []Enum{
+		{
+			Name: "error",
+			Entries: []EnumEntry{
+				{Name: "invalid_format", Value: 0},
+				{Name: "invalid_stride", Value: 1},
+				{Name: "invalid_fd", Value: 2},
+			},
+		},
+		{
+			Name: "format",
+			Entries: []EnumEntry{
+				{Name: "argb8888", Value: 0},
+				{Name: "xrgb8888", Value: 1},
+				{Name: "c8", Value: 0x20203843},
+				{Name: "rgb332", Value: 0x38424752},
+				{Name: "bgr233", Value: 0x38524742},
+				{Name: "xrgb4444", Value: 0x32315258},
+				{Name: "xbgr4444", Value: 0x32314258},
+				{Name: "rgbx4444", Value: 0x32315852},
+				{Name: "bgrx4444", Value: 0x32315842},
+				{Name: "argb4444", Value: 0x32315241},
+				{Name: "abgr4444", Value: 0x32314241},
+				{Name: "rgba4444", Value: 0x32314152},
+				{Name: "bgra4444", Value: 0x32314142},
+				{Name: "xrgb1555", Value: 0x35315258},
+				{Name: "xbgr1555", Value: 0x35314258},
+				{Name: "rgbx5551", Value: 0x35315852},
+				{Name: "bgrx5551", Value: 0x35315842},
+				{Name: "argb1555", Value: 0x35315241},
+				{Name: "abgr1555", Value: 0x35314241},
+				{Name: "rgba5551", Value: 0x35314152},
+				{Name: "bgra5551", Value: 0x35314142},
+				{Name: "rgb565", Value: 0x36314752},
+				{Name: "bgr565", Value: 0x36314742},
+				{Name: "rgb888", Value: 0x34324752},
+				{Name: "bgr888", Value: 0x34324742},
+				{Name: "xbgr8888", Value: 0x34324258},
+				{Name: "rgbx8888", Value: 0x34325852},
+				{Name: "bgrx8888", Value: 0x34325842},
+				{Name: "abgr8888", Value: 0x34324241},
+				{Name: "rgba8888", Value: 0x34324152},
+				{Name: "bgra8888", Value: 0x34324142},
+				{Name: "xrgb2101010", Value: 0x30335258},
+				{Name: "xbgr2101010", Value: 0x30334258},
+				{Name: "rgbx1010102", Value: 0x30335852},
+				{Name: "bgrx1010102", Value: 0x30335842},
+				{Name: "argb2101010", Value: 0x30335241},
+				{Name: "abgr2101010", Value: 0x30334241},
+				{Name: "rgba1010102", Value: 0x30334152},
+				{Name: "bgra1010102", Value: 0x30334142},
+				{Name: "yuyv", Value: 0x56595559},
+				{Name: "yvyu", Value: 0x55595659},
+				{Name: "uyvy", Value: 0x59565955},
+				{Name: "vyuy", Value: 0x59555956},
+				{Name: "ayuv", Value: 0x56555941},
+				{Name: "nv12", Value: 0x3231564e},
+				{Name: "nv21", Value: 0x3132564e},
+				{Name: "nv16", Value: 0x3631564e},
+				{Name: "nv61", Value: 0x3136564e},
+				{Name: "yuv410", Value: 0x39565559},
+				{Name: "yvu410", Value: 0x39555659},
+				{Name: "yuv411", Value: 0x31315559},
+				{Name: "yvu411", Value: 0x31315659},
+				{Name: "yuv420", Value: 0x32315559},
+				{Name: "yvu420", Value: 0x32315659},
+				{Name: "yuv422", Value: 0x36315559},
+				{Name: "yvu422", Value: 0x36315659},
+				{Name: "yuv444", Value: 0x34325559},
+				{Name: "yvu444", Value: 0x34325659},
+				{Name: "r8", Value: 0x20203852},
+				{Name: "r16", Value: 0x20363152},
+				{Name: "rg88", Value: 0x38384752},
+				{Name: "gr88", Value: 0x38385247},
+				{Name: "rg1616", Value: 0x32334752},
+				{Name: "gr1616", Value: 0x32335247},
+				{Name: "xrgb16161616f", Value: 0x48345258},
+				{Name: "xbgr16161616f", Value: 0x48344258},
+				{Name: "argb16161616f", Value: 0x48345241},
+				{Name: "abgr16161616f", Value: 0x48344241},
+				{Name: "xyuv8888", Value: 0x56555958},
+				{Name: "vuy888", Value: 0x34325556},
+				{Name: "vuy101010", Value: 0x30335556},
+				{Name: "y210", Value: 0x30313259},
+				{Name: "y212", Value: 0x32313259},
+				{Name: "y216", Value: 0x36313259},
+				{Name: "y410", Value: 0x30313459},
+				{Name: "y412", Value: 0x32313459},
+				{Name: "y416", Value: 0x36313459},
+				{Name: "xvyu2101010", Value: 0x30335658},
+				{Name: "xvyu12_16161616", Value: 0x36335658},
+				{Name: "xvyu16161616", Value: 0x38345658},
+				{Name: "y0l0", Value: 0x304c3059},
+				{Name: "x0l0", Value: 0x304c3058},
+				{Name: "y0l2", Value: 0x324c3059},
+				{Name: "x0l2", Value: 0x324c3058},
+				{Name: "yuv420_8bit", Value: 0x38305559},
+				{Name: "yuv420_10bit", Value: 0x30315559},
+				{Name: "xrgb8888_a8", Value: 0x38415258},
+				{Name: "xbgr8888_a8", Value: 0x38414258},
+				{Name: "rgbx8888_a8", Value: 0x38415852},
+				{Name: "bgrx8888_a8", Value: 0x38415842},
+				{Name: "rgb888_a8", Value: 0x38413852},
+				{Name: "bgr888_a8", Value: 0x38413842},
+				{Name: "rgb565_a8", Value: 0x38413552},
+				{Name: "bgr565_a8", Value: 0x38413542},
+				{Name: "nv24", Value: 0x3432564e},
+				{Name: "nv42", Value: 0x3234564e},
+				{Name: "p210", Value: 0x30313250},
+				{Name: "p010", Value: 0x30313050},
+				{Name: "p012", Value: 0x32313050},
+				{Name: "p016", Value: 0x36313050},
+				{Name: "axbxgxrx106106106106", Value: 0x30314241},
+				{Name: "nv15", Value: 0x3531564e},
+				{Name: "q410", Value: 0x30313451},
+				{Name: "q401", Value: 0x31303451},
+				{Name: "xrgb16161616", Value: 0x38345258},
+				{Name: "xbgr16161616", Value: 0x38344258},
+				{Name: "argb16161616", Value: 0x38345241},
+				{Name: "abgr16161616", Value: 0x38344241},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wl_shm.
+func (i *Shm) Interface() *Interface {
+	return ShmInterface
+}
+
 // CreatePool : create a shm pool
 //
 // Create a new wl_shm_pool object.
@@ -678,6 +1181,7 @@ func NewShm(ctx *Context) *Shm {
 //	size: pool size, in bytes
 func (i *Shm) CreatePool(fd int, size int32) (*ShmPool, error) {
 	id := NewShmPool(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 0
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -695,9 +1199,37 @@ func (i *Shm) CreatePool(fd int, size int32) (*ShmPool, error) {
 	return id, err
 }
 
+// Release : release the shm object
+//
+// Using this request a client can tell the server that it is not going to
+// use the shm object anymore.
+//
+// Objects created via this interface remain unaffected.
+func (i *Shm) Release() error {
+	if v := i.Version(); v != 0 && v < 2 {
+		return &VersionError{Interface: "wl_shm", Request: "release", Since: 2, Version: v}
+	}
+	defer i.Context().Unregister(i)
+	const opcode = 1
+	const _reqBufLen = 8
+	var _reqBuf [_reqBufLen]byte
+	l := 0
+	PutUint32(_reqBuf[l:4], i.ID())
+	l += 4
+	PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
+	l += 4
+	err := i.Context().WriteMsg(_reqBuf[:], nil)
+	return err
+}
+
+// Destroy sends release unless the version of the object is known to
+// predate it, in which case it only forgets the object.
 func (i *Shm) Destroy() error {
-	i.Context().Unregister(i)
-	return nil
+	if v := i.Version(); v != 0 && v < 2 {
+		i.Context().Unregister(i)
+		return nil
+	}
+	return i.Release()
 }
 
 type ShmError uint32
@@ -1423,7 +1955,7 @@ func (e ShmFormat) String() string {
 // can be used for buffers. Known formats include
 // argb8888 and xrgb8888.
 type ShmFormatEvent struct {
-	Format uint32
+	Format ShmFormat
 }
 type ShmFormatHandlerFunc func(ShmFormatEvent)
 
@@ -1432,6 +1964,43 @@ func (i *Shm) SetFormatHandler(f ShmFormatHandlerFunc) {
 	i.formatHandler = f
 }
 
+// String formats the event as wl_shm.format(argument=value, ...).
+func (e ShmFormatEvent) String() string {
+	return "wl_shm.format(" +
+		"format=" + EnumString(e.Format.Name(), uint32(e.Format)) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e ShmFormatEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("format", EnumString(e.Format.Name(), uint32(e.Format))),
+	)
+}
+
+// ShmListener receives all the events of Shm, embed
+// ShmNoopListener to implement only some of them.
+type ShmListener interface {
+	Format(e ShmFormatEvent)
+}
+
+// ShmNoopListener is a ShmListener ignoring
+// all the events, it closes the file descriptors they carry.
+type ShmNoopListener struct{}
+
+func (ShmNoopListener) Format(ShmFormatEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *Shm) SetListener(l ShmListener) {
+	if l == nil {
+		i.formatHandler = nil
+		return
+	}
+	i.formatHandler = l.Format
+}
+
 func (i *Shm) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -1440,7 +2009,7 @@ func (i *Shm) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e ShmFormatEvent
 		l := 0
-		e.Format = Uint32(data[l : l+4])
+		e.Format = ShmFormat(Uint32(data[l : l+4]))
 		l += 4
 
 		i.formatHandler(e)
@@ -1482,6 +2051,29 @@ func NewBuffer(ctx *Context) *Buffer {
 	return wlBuffer
 }
 
+// BufferInterface describes wl_buffer at runtime.
+var BufferInterface = &Interface{
+	Name:    "wl_buffer",
+	Version: 1,
+	New:     func() Proxy { return &Buffer{} },
+	Requests: []Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Events: []Message{
+		{
+			Name: "release",
+		},
+	},
+}
+
+// Interface returns the description of wl_buffer.
+func (i *Buffer) Interface() *Interface {
+	return BufferInterface
+}
+
 // Destroy : destroy a buffer
 //
 // Destroy a buffer. If and how you need to release the backing
@@ -1524,6 +2116,39 @@ func (i *Buffer) SetReleaseHandler(f BufferReleaseHandlerFunc) {
 	i.releaseHandler = f
 }
 
+// String formats the event as wl_buffer.release(argument=value, ...).
+func (e BufferReleaseEvent) String() string {
+	return "wl_buffer.release()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e BufferReleaseEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
+// BufferListener receives all the events of Buffer, embed
+// BufferNoopListener to implement only some of them.
+type BufferListener interface {
+	Release(e BufferReleaseEvent)
+}
+
+// BufferNoopListener is a BufferListener ignoring
+// all the events, it closes the file descriptors they carry.
+type BufferNoopListener struct{}
+
+func (BufferNoopListener) Release(BufferReleaseEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *Buffer) SetListener(l BufferListener) {
+	if l == nil {
+		i.releaseHandler = nil
+		return
+	}
+	i.releaseHandler = l.Release
+}
+
 func (i *Buffer) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -1565,6 +2190,83 @@ func NewDataOffer(ctx *Context) *DataOffer {
 	return wlDataOffer
 }
 
+// DataOfferInterface describes wl_data_offer at runtime.
+var DataOfferInterface = &Interface{
+	Name:    "wl_data_offer",
+	Version: 3,
+	New:     func() Proxy { return &DataOffer{} },
+	Requests: []Message{
+		{
+			Name: "accept",
+			Args: []Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "mime_type", Type: "string", Nullable: true},
+			},
+		},
+		{
+			Name: "receive",
+			Args: []Arg{
+				{Name: "mime_type", Type: "string"},
+				{Name: "fd", Type: "fd"},
+			},
+		},
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name:  "finish",
+			Since: 3,
+		},
+		{
+			Name:  "set_actions",
+			Since: 3,
+			Args: []Arg{
+				{Name: "dnd_actions", Type: "uint", Enum: "wl_data_device_manager.dnd_action"},
+				{Name: "preferred_action", Type: "uint", Enum: "wl_data_device_manager.dnd_action"},
+			},
+		},
+	},
+	Events: []Message{
+		{
+			Name: "offer",
+			Args: []Arg{
+				{Name: "mime_type", Type: "string"},
+			},
+		},
+		{
+			Name:  "source_actions",
+			Since: 3,
+			Args: []Arg{
+				{Name: "source_actions", Type: "uint", Enum: "wl_data_device_manager.dnd_action"},
+			},
+		},
+		{
+			Name:  "action",
+			Since: 3,
+			Args: []Arg{
+				{Name: "dnd_action", Type: "uint", Enum: "wl_data_device_manager.dnd_action"},
+			},
+		},
+	},
+	Enums: []Enum{
+		{
+			Name: "error",
+			Entries: []EnumEntry{
+				{Name: "invalid_finish", Value: 0},
+				{Name: "invalid_action_mask", Value: 1},
+				{Name: "invalid_action", Value: 2},
+				{Name: "invalid_offer", Value: 3},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wl_data_offer.
+func (i *DataOffer) Interface() *Interface {
+	return DataOfferInterface
+}
+
 // Accept : accept one of the offered mime types
 //
 // Indicate that the client can accept the given mime type, or
@@ -1584,9 +2286,12 @@ func NewDataOffer(ctx *Context) *DataOffer {
 //
 //	serial: serial number of the accept request
 //	mimeType: mime type accepted by the client
-func (i *DataOffer) Accept(serial uint32, mimeType string) error {
+func (i *DataOffer) Accept(serial uint32, mimeType *string) error {
 	const opcode = 0
-	mimeTypeLen := PaddedLen(len(mimeType) + 1)
+	var mimeTypeLen int
+	if mimeType != nil {
+		mimeTypeLen = PaddedLen(len(*mimeType) + 1)
+	}
 	_reqBufLen := 8 + 4 + (4 + mimeTypeLen)
 	_reqBuf := make([]byte, _reqBufLen)
 	l := 0
@@ -1596,8 +2301,13 @@ func (i *DataOffer) Accept(serial uint32, mimeType string) error {
 	l += 4
 	PutUint32(_reqBuf[l:l+4], uint32(serial))
 	l += 4
-	PutString(_reqBuf[l:l+(4+mimeTypeLen)], mimeType, mimeTypeLen)
-	l += (4 + mimeTypeLen)
+	if mimeType == nil {
+		PutUint32(_reqBuf[l:l+4], 0)
+		l += 4
+	} else {
+		PutString(_reqBuf[l:l+(4+mimeTypeLen)], *mimeType)
+		l += (4 + mimeTypeLen)
+	}
 	err := i.Context().WriteMsg(_reqBuf, nil)
 	return err
 }
@@ -1632,7 +2342,7 @@ func (i *DataOffer) Receive(mimeType string, fd int) error {
 	l += 4
 	PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
 	l += 4
-	PutString(_reqBuf[l:l+(4+mimeTypeLen)], mimeType, mimeTypeLen)
+	PutString(_reqBuf[l:l+(4+mimeTypeLen)], mimeType)
 	l += (4 + mimeTypeLen)
 	oob := unix.UnixRights(int(fd))
 	err := i.Context().WriteMsg(_reqBuf, oob)
@@ -1673,6 +2383,9 @@ func (i *DataOffer) Destroy() error {
 // If wl_data_offer.finish request is received for a non drag and drop
 // operation, the invalid_finish protocol error is raised.
 func (i *DataOffer) Finish() error {
+	if v := i.Version(); v != 0 && v < 3 {
+		return &VersionError{Interface: "wl_data_offer", Request: "finish", Since: 3, Version: v}
+	}
 	const opcode = 3
 	const _reqBufLen = 8
 	var _reqBuf [_reqBufLen]byte
@@ -1721,7 +2434,10 @@ func (i *DataOffer) Finish() error {
 //
 //	dndActions: actions supported by the destination client
 //	preferredAction: action preferred by the destination client
-func (i *DataOffer) SetActions(dndActions, preferredAction uint32) error {
+func (i *DataOffer) SetActions(dndActions, preferredAction DataDeviceManagerDndAction) error {
+	if v := i.Version(); v != 0 && v < 3 {
+		return &VersionError{Interface: "wl_data_offer", Request: "set_actions", Since: 3, Version: v}
+	}
 	const opcode = 4
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -1800,13 +2516,27 @@ func (i *DataOffer) SetOfferHandler(f DataOfferOfferHandlerFunc) {
 	i.offerHandler = f
 }
 
+// String formats the event as wl_data_offer.offer(argument=value, ...).
+func (e DataOfferOfferEvent) String() string {
+	return "wl_data_offer.offer(" +
+		"mime_type=" + strconv.Quote(e.MimeType) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DataOfferOfferEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("mime_type", e.MimeType),
+	)
+}
+
 // DataOfferSourceActionsEvent : notify the source-side available actions
 //
 // This event indicates the actions offered by the data source. It
 // will be sent right after wl_data_device.enter, or anytime the source
 // side changes its offered actions through wl_data_source.set_actions.
 type DataOfferSourceActionsEvent struct {
-	SourceActions uint32
+	SourceActions DataDeviceManagerDndAction
 }
 type DataOfferSourceActionsHandlerFunc func(DataOfferSourceActionsEvent)
 
@@ -1815,6 +2545,20 @@ func (i *DataOffer) SetSourceActionsHandler(f DataOfferSourceActionsHandlerFunc)
 	i.sourceActionsHandler = f
 }
 
+// String formats the event as wl_data_offer.source_actions(argument=value, ...).
+func (e DataOfferSourceActionsEvent) String() string {
+	return "wl_data_offer.source_actions(" +
+		"source_actions=" + e.SourceActions.String() +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DataOfferSourceActionsEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("source_actions", e.SourceActions.String()),
+	)
+}
+
 // DataOfferActionEvent : notify the selected action
 //
 // This event indicates the action selected by the compositor after
@@ -1853,7 +2597,7 @@ func (i *DataOffer) SetSourceActionsHandler(f DataOfferSourceActionsHandlerFunc)
 // final wl_data_offer.set_actions and wl_data_offer.accept requests
 // must happen before the call to wl_data_offer.finish.
 type DataOfferActionEvent struct {
-	DndAction uint32
+	DndAction DataDeviceManagerDndAction
 }
 type DataOfferActionHandlerFunc func(DataOfferActionEvent)
 
@@ -1862,6 +2606,55 @@ func (i *DataOffer) SetActionHandler(f DataOfferActionHandlerFunc) {
 	i.actionHandler = f
 }
 
+// String formats the event as wl_data_offer.action(argument=value, ...).
+func (e DataOfferActionEvent) String() string {
+	return "wl_data_offer.action(" +
+		"dnd_action=" + e.DndAction.String() +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DataOfferActionEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("dnd_action", e.DndAction.String()),
+	)
+}
+
+// DataOfferListener receives all the events of DataOffer, embed
+// DataOfferNoopListener to implement only some of them.
+type DataOfferListener interface {
+	Offer(e DataOfferOfferEvent)
+	SourceActions(e DataOfferSourceActionsEvent)
+	Action(e DataOfferActionEvent)
+}
+
+// DataOfferNoopListener is a DataOfferListener ignoring
+// all the events, it closes the file descriptors they carry.
+type DataOfferNoopListener struct{}
+
+func (DataOfferNoopListener) Offer(DataOfferOfferEvent) {
+}
+
+func (DataOfferNoopListener) SourceActions(DataOfferSourceActionsEvent) {
+}
+
+func (DataOfferNoopListener) Action(DataOfferActionEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *DataOffer) SetListener(l DataOfferListener) {
+	if l == nil {
+		i.offerHandler = nil
+		i.sourceActionsHandler = nil
+		i.actionHandler = nil
+		return
+	}
+	i.offerHandler = l.Offer
+	i.sourceActionsHandler = l.SourceActions
+	i.actionHandler = l.Action
+}
+
 func (i *DataOffer) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -1882,7 +2675,7 @@ func (i *DataOffer) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e DataOfferSourceActionsEvent
 		l := 0
-		e.SourceActions = Uint32(data[l : l+4])
+		e.SourceActions = DataDeviceManagerDndAction(Uint32(data[l : l+4]))
 		l += 4
 
 		i.sourceActionsHandler(e)
@@ -1892,7 +2685,7 @@ func (i *DataOffer) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e DataOfferActionEvent
 		l := 0
-		e.DndAction = Uint32(data[l : l+4])
+		e.DndAction = DataDeviceManagerDndAction(Uint32(data[l : l+4]))
 		l += 4
 
 		i.actionHandler(e)
@@ -1927,6 +2720,79 @@ func NewDataSource(ctx *Context) *DataSource {
 	return wlDataSource
 }
 
+// DataSourceInterface describes wl_data_source at runtime.
+var DataSourceInterface = &Interface{
+	Name:    "wl_data_source",
+	Version: 3,
+	New:     func() Proxy { return &DataSource{} },
+	Requests: []Message{
+		{
+			Name: "offer",
+			Args: []Arg{
+				{Name: "mime_type", Type: "string"},
+			},
+		},
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name:  "set_actions",
+			Since: 3,
+			Args: []Arg{
+				{Name: "dnd_actions", Type: "uint", Enum: "wl_data_device_manager.dnd_action"},
+			},
+		},
+	},
+	Events: []Message{
+		{
+			Name: "target",
+			Args: []Arg{
+				{Name: "mime_type", Type: "string", Nullable: true},
+			},
+		},
+		{
+			Name: "send",
+			Args: []Arg{
+				{Name: "mime_type", Type: "string"},
+				{Name: "fd", Type: "fd"},
+			},
+		},
+		{
+			Name: "cancelled",
+		},
+		{
+			Name:  "dnd_drop_performed",
+			Since: 3,
+		},
+		{
+			Name:  "dnd_finished",
+			Since: 3,
+		},
+		{
+			Name:  "action",
+			Since: 3,
+			Args: []Arg{
+				{Name: "dnd_action", Type: "uint", Enum: "wl_data_device_manager.dnd_action"},
+			},
+		},
+	},
+	Enums: []Enum{
+		{
+			Name: "error",
+			Entries: []EnumEntry{
+				{Name: "invalid_action_mask", Value: 0},
+				{Name: "invalid_source", Value: 1},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wl_data_source.
+func (i *DataSource) Interface() *Interface {
+	return DataSourceInterface
+}
+
 // Offer : add an offered mime type
 //
 // This request adds a mime type to the set of mime types
@@ -1944,7 +2810,7 @@ func (i *DataSource) Offer(mimeType string) error {
 	l += 4
 	PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
 	l += 4
-	PutString(_reqBuf[l:l+(4+mimeTypeLen)], mimeType, mimeTypeLen)
+	PutString(_reqBuf[l:l+(4+mimeTypeLen)], mimeType)
 	l += (4 + mimeTypeLen)
 	err := i.Context().WriteMsg(_reqBuf, nil)
 	return err
@@ -1984,7 +2850,10 @@ func (i *DataSource) Destroy() error {
 // for drag-and-drop will raise a protocol error.
 //
 //	dndActions: actions supported by the data source
-func (i *DataSource) SetActions(dndActions uint32) error {
+func (i *DataSource) SetActions(dndActions DataDeviceManagerDndAction) error {
+	if v := i.Version(); v != 0 && v < 3 {
+		return &VersionError{Interface: "wl_data_source", Request: "set_actions", Since: 3, Version: v}
+	}
 	const opcode = 2
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -2042,7 +2911,7 @@ func (e DataSourceError) String() string {
 //
 // Used for feedback during drag-and-drop.
 type DataSourceTargetEvent struct {
-	MimeType string
+	MimeType *string
 }
 type DataSourceTargetHandlerFunc func(DataSourceTargetEvent)
 
@@ -2051,6 +2920,20 @@ func (i *DataSource) SetTargetHandler(f DataSourceTargetHandlerFunc) {
 	i.targetHandler = f
 }
 
+// String formats the event as wl_data_source.target(argument=value, ...).
+func (e DataSourceTargetEvent) String() string {
+	return "wl_data_source.target(" +
+		"mime_type=" + NullableString(e.MimeType) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DataSourceTargetEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Any("mime_type", NullableValue(e.MimeType)),
+	)
+}
+
 // DataSourceSendEvent : send the data
 //
 // Request for data from the client.  Send the data as the
@@ -2067,6 +2950,22 @@ func (i *DataSource) SetSendHandler(f DataSourceSendHandlerFunc) {
 	i.sendHandler = f
 }
 
+// String formats the event as wl_data_source.send(argument=value, ...).
+func (e DataSourceSendEvent) String() string {
+	return "wl_data_source.send(" +
+		"mime_type=" + strconv.Quote(e.MimeType) +
+		", fd=" + strconv.Itoa(e.Fd) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DataSourceSendEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("mime_type", e.MimeType),
+		slog.Int("fd", e.Fd),
+	)
+}
+
 // DataSourceCancelledEvent : selection was cancelled
 //
 // This data source is no longer valid. There are several reasons why
@@ -2097,6 +2996,16 @@ func (i *DataSource) SetCancelledHandler(f DataSourceCancelledHandlerFunc) {
 	i.cancelledHandler = f
 }
 
+// String formats the event as wl_data_source.cancelled(argument=value, ...).
+func (e DataSourceCancelledEvent) String() string {
+	return "wl_data_source.cancelled()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DataSourceCancelledEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // DataSourceDndDropPerformedEvent : the drag-and-drop operation physically finished
 //
 // The user performed the drop action. This event does not indicate
@@ -2116,6 +3025,16 @@ func (i *DataSource) SetDndDropPerformedHandler(f DataSourceDndDropPerformedHand
 	i.dndDropPerformedHandler = f
 }
 
+// String formats the event as wl_data_source.dnd_drop_performed(argument=value, ...).
+func (e DataSourceDndDropPerformedEvent) String() string {
+	return "wl_data_source.dnd_drop_performed()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DataSourceDndDropPerformedEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // DataSourceDndFinishedEvent : the drag-and-drop operation concluded
 //
 // The drop destination finished interoperating with this data
@@ -2132,6 +3051,16 @@ func (i *DataSource) SetDndFinishedHandler(f DataSourceDndFinishedHandlerFunc) {
 	i.dndFinishedHandler = f
 }
 
+// String formats the event as wl_data_source.dnd_finished(argument=value, ...).
+func (e DataSourceDndFinishedEvent) String() string {
+	return "wl_data_source.dnd_finished()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DataSourceDndFinishedEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // DataSourceActionEvent : notify the selected action
 //
 // This event indicates the action selected by the compositor after
@@ -2160,7 +3089,7 @@ func (i *DataSource) SetDndFinishedHandler(f DataSourceDndFinishedHandlerFunc) {
 // Clients can trigger cursor surface changes from this point, so
 // they reflect the current action.
 type DataSourceActionEvent struct {
-	DndAction uint32
+	DndAction DataDeviceManagerDndAction
 }
 type DataSourceActionHandlerFunc func(DataSourceActionEvent)
 
@@ -2169,6 +3098,74 @@ func (i *DataSource) SetActionHandler(f DataSourceActionHandlerFunc) {
 	i.actionHandler = f
 }
 
+// String formats the event as wl_data_source.action(argument=value, ...).
+func (e DataSourceActionEvent) String() string {
+	return "wl_data_source.action(" +
+		"dnd_action=" + e.DndAction.String() +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DataSourceActionEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("dnd_action", e.DndAction.String()),
+	)
+}
+
+// DataSourceListener receives all the events of DataSource, embed
+// DataSourceNoopListener to implement only some of them.
+type DataSourceListener interface {
+	Target(e DataSourceTargetEvent)
+	Send(e DataSourceSendEvent)
+	Cancelled(e DataSourceCancelledEvent)
+	DndDropPerformed(e DataSourceDndDropPerformedEvent)
+	DndFinished(e DataSourceDndFinishedEvent)
+	Action(e DataSourceActionEvent)
+}
+
+// DataSourceNoopListener is a DataSourceListener ignoring
+// all the events, it closes the file descriptors they carry.
+type DataSourceNoopListener struct{}
+
+func (DataSourceNoopListener) Target(DataSourceTargetEvent) {
+}
+
+func (DataSourceNoopListener) Send(e DataSourceSendEvent) {
+	unix.Close(e.Fd)
+}
+
+func (DataSourceNoopListener) Cancelled(DataSourceCancelledEvent) {
+}
+
+func (DataSourceNoopListener) DndDropPerformed(DataSourceDndDropPerformedEvent) {
+}
+
+func (DataSourceNoopListener) DndFinished(DataSourceDndFinishedEvent) {
+}
+
+func (DataSourceNoopListener) Action(DataSourceActionEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *DataSource) SetListener(l DataSourceListener) {
+	if l == nil {
+		i.targetHandler = nil
+		i.sendHandler = nil
+		i.cancelledHandler = nil
+		i.dndDropPerformedHandler = nil
+		i.dndFinishedHandler = nil
+		i.actionHandler = nil
+		return
+	}
+	i.targetHandler = l.Target
+	i.sendHandler = l.Send
+	i.cancelledHandler = l.Cancelled
+	i.dndDropPerformedHandler = l.DndDropPerformed
+	i.dndFinishedHandler = l.DndFinished
+	i.actionHandler = l.Action
+}
+
 func (i *DataSource) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -2179,7 +3176,10 @@ func (i *DataSource) Dispatch(opcode uint32, fd int, data []byte) {
 		l := 0
 		mimeTypeLen := PaddedLen(int(Uint32(data[l : l+4])))
 		l += 4
-		e.MimeType = String(data[l : l+mimeTypeLen])
+		if mimeTypeLen != 0 {
+			mimeType := String(data[l : l+mimeTypeLen])
+			e.MimeType = &mimeType
+		}
 		l += mimeTypeLen
 
 		i.targetHandler(e)
@@ -2226,7 +3226,7 @@ func (i *DataSource) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e DataSourceActionEvent
 		l := 0
-		e.DndAction = Uint32(data[l : l+4])
+		e.DndAction = DataDeviceManagerDndAction(Uint32(data[l : l+4]))
 		l += 4
 
 		i.actionHandler(e)
@@ -2263,6 +3263,87 @@ func NewDataDevice(ctx *Context) *DataDevice {
 	return wlDataDevice
 }
 
+// DataDeviceInterface describes wl_data_device at runtime.
+var DataDeviceInterface = &Interface{
+	Name:    "wl_data_device",
+	Version: 3,
+	New:     func() Proxy { return &DataDevice{} },
+	Requests: []Message{
+		{
+			Name: "start_drag",
+			Args: []Arg{
+				{Name: "source", Type: "object", Interface: "wl_data_source", Nullable: true},
+				{Name: "origin", Type: "object", Interface: "wl_surface"},
+				{Name: "icon", Type: "object", Interface: "wl_surface", Nullable: true},
+				{Name: "serial", Type: "uint"},
+			},
+		},
+		{
+			Name: "set_selection",
+			Args: []Arg{
+				{Name: "source", Type: "object", Interface: "wl_data_source", Nullable: true},
+				{Name: "serial", Type: "uint"},
+			},
+		},
+		{
+			Name:       "release",
+			Since:      2,
+			Destructor: true,
+		},
+	},
+	Events: []Message{
+		{
+			Name: "data_offer",
+			Args: []Arg{
+				{Name: "id", Type: "new_id", Interface: "wl_data_offer"},
+			},
+		},
+		{
+			Name: "enter",
+			Args: []Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+				{Name: "x", Type: "fixed"},
+				{Name: "y", Type: "fixed"},
+				{Name: "id", Type: "object", Interface: "wl_data_offer", Nullable: true},
+			},
+		},
+		{
+			Name: "leave",
+		},
+		{
+			Name: "motion",
+			Args: []Arg{
+				{Name: "time", Type: "uint"},
+				{Name: "x", Type: "fixed"},
+				{Name: "y", Type: "fixed"},
+			},
+		},
+		{
+			Name: "drop",
+		},
+		{
+			Name: "selection",
+			Args: []Arg{
+				{Name: "id", Type: "object", Interface: "wl_data_offer", Nullable: true},
+			},
+		},
+	},
+	Enums: []Enum{
+		{
+			Name: "error",
+			Entries: []EnumEntry{
+				{Name: "role", Value: 0},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wl_data_device.
+func (i *DataDevice) Interface() *Interface {
+	return DataDeviceInterface
+}
+
 // StartDrag : start drag-and-drop operation
 //
 // This request asks the compositor to start a drag-and-drop
@@ -2364,6 +3445,9 @@ func (i *DataDevice) SetSelection(source *DataSource, serial uint32) error {
 //
 // This request destroys the data device.
 func (i *DataDevice) Release() error {
+	if v := i.Version(); v != 0 && v < 2 {
+		return &VersionError{Interface: "wl_data_device", Request: "release", Since: 2, Version: v}
+	}
 	defer i.Context().Unregister(i)
 	const opcode = 2
 	const _reqBufLen = 8
@@ -2377,6 +3461,16 @@ func (i *DataDevice) Release() error {
 	return err
 }
 
+// Destroy sends release unless the version of the object is known to
+// predate it, in which case it only forgets the object.
+func (i *DataDevice) Destroy() error {
+	if v := i.Version(); v != 0 && v < 2 {
+		i.Context().Unregister(i)
+		return nil
+	}
+	return i.Release()
+}
+
 type DataDeviceError uint32
 
 // DataDeviceError :
@@ -2426,6 +3520,20 @@ func (i *DataDevice) SetDataOfferHandler(f DataDeviceDataOfferHandlerFunc) {
 	i.dataOfferHandler = f
 }
 
+// String formats the event as wl_data_device.data_offer(argument=value, ...).
+func (e DataDeviceDataOfferEvent) String() string {
+	return "wl_data_device.data_offer(" +
+		"id=" + ObjectString(e.Id) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DataDeviceDataOfferEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", ObjectString(e.Id)),
+	)
+}
+
 // DataDeviceEnterEvent : initiate drag-and-drop session
 //
 // This event is sent when an active drag-and-drop pointer enters
@@ -2446,6 +3554,28 @@ func (i *DataDevice) SetEnterHandler(f DataDeviceEnterHandlerFunc) {
 	i.enterHandler = f
 }
 
+// String formats the event as wl_data_device.enter(argument=value, ...).
+func (e DataDeviceEnterEvent) String() string {
+	return "wl_data_device.enter(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", surface=" + ObjectString(e.Surface) +
+		", x=" + strconv.FormatFloat(e.X, 'g', -1, 64) +
+		", y=" + strconv.FormatFloat(e.Y, 'g', -1, 64) +
+		", id=" + ObjectString(e.Id) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DataDeviceEnterEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.String("surface", ObjectString(e.Surface)),
+		slog.Float64("x", e.X),
+		slog.Float64("y", e.Y),
+		slog.String("id", ObjectString(e.Id)),
+	)
+}
+
 // DataDeviceLeaveEvent : end drag-and-drop session
 //
 // This event is sent when the drag-and-drop pointer leaves the
@@ -2459,6 +3589,16 @@ func (i *DataDevice) SetLeaveHandler(f DataDeviceLeaveHandlerFunc) {
 	i.leaveHandler = f
 }
 
+// String formats the event as wl_data_device.leave(argument=value, ...).
+func (e DataDeviceLeaveEvent) String() string {
+	return "wl_data_device.leave()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DataDeviceLeaveEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // DataDeviceMotionEvent : drag-and-drop session motion
 //
 // This event is sent when the drag-and-drop pointer moves within
@@ -2477,6 +3617,24 @@ func (i *DataDevice) SetMotionHandler(f DataDeviceMotionHandlerFunc) {
 	i.motionHandler = f
 }
 
+// String formats the event as wl_data_device.motion(argument=value, ...).
+func (e DataDeviceMotionEvent) String() string {
+	return "wl_data_device.motion(" +
+		"time=" + strconv.FormatUint(uint64(e.Time), 10) +
+		", x=" + strconv.FormatFloat(e.X, 'g', -1, 64) +
+		", y=" + strconv.FormatFloat(e.Y, 'g', -1, 64) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DataDeviceMotionEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("time", uint64(e.Time)),
+		slog.Float64("x", e.X),
+		slog.Float64("y", e.Y),
+	)
+}
+
 // DataDeviceDropEvent : end drag-and-drop session successfully
 //
 // The event is sent when a drag-and-drop operation is ended
@@ -2500,6 +3658,16 @@ func (i *DataDevice) SetDropHandler(f DataDeviceDropHandlerFunc) {
 	i.dropHandler = f
 }
 
+// String formats the event as wl_data_device.drop(argument=value, ...).
+func (e DataDeviceDropEvent) String() string {
+	return "wl_data_device.drop()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DataDeviceDropEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // DataDeviceSelectionEvent : advertise new selection
 //
 // The selection event is sent out to notify the client of a new
@@ -2524,18 +3692,86 @@ func (i *DataDevice) SetSelectionHandler(f DataDeviceSelectionHandlerFunc) {
 	i.selectionHandler = f
 }
 
+// String formats the event as wl_data_device.selection(argument=value, ...).
+func (e DataDeviceSelectionEvent) String() string {
+	return "wl_data_device.selection(" +
+		"id=" + ObjectString(e.Id) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DataDeviceSelectionEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", ObjectString(e.Id)),
+	)
+}
+
+// DataDeviceListener receives all the events of DataDevice, embed
+// DataDeviceNoopListener to implement only some of them.
+type DataDeviceListener interface {
+	DataOffer(e DataDeviceDataOfferEvent)
+	Enter(e DataDeviceEnterEvent)
+	Leave(e DataDeviceLeaveEvent)
+	Motion(e DataDeviceMotionEvent)
+	Drop(e DataDeviceDropEvent)
+	Selection(e DataDeviceSelectionEvent)
+}
+
+// DataDeviceNoopListener is a DataDeviceListener ignoring
+// all the events, it closes the file descriptors they carry.
+type DataDeviceNoopListener struct{}
+
+func (DataDeviceNoopListener) DataOffer(DataDeviceDataOfferEvent) {
+}
+
+func (DataDeviceNoopListener) Enter(DataDeviceEnterEvent) {
+}
+
+func (DataDeviceNoopListener) Leave(DataDeviceLeaveEvent) {
+}
+
+func (DataDeviceNoopListener) Motion(DataDeviceMotionEvent) {
+}
+
+func (DataDeviceNoopListener) Drop(DataDeviceDropEvent) {
+}
+
+func (DataDeviceNoopListener) Selection(DataDeviceSelectionEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *DataDevice) SetListener(l DataDeviceListener) {
+	if l == nil {
+		i.dataOfferHandler = nil
+		i.enterHandler = nil
+		i.leaveHandler = nil
+		i.motionHandler = nil
+		i.dropHandler = nil
+		i.selectionHandler = nil
+		return
+	}
+	i.dataOfferHandler = l.DataOffer
+	i.enterHandler = l.Enter
+	i.leaveHandler = l.Leave
+	i.motionHandler = l.Motion
+	i.dropHandler = l.Drop
+	i.selectionHandler = l.Selection
+}
+
 func (i *DataDevice) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
-		if i.dataOfferHandler == nil {
-			return
-		}
 		var e DataDeviceDataOfferEvent
 		l := 0
-		e.Id = i.Context().GetProxy(Uint32(data[l : l+4])).(*DataOffer)
+		e.Id = &DataOffer{}
+		i.Context().RegisterWithID(e.Id, Uint32(data[l:l+4]))
+		e.Id.SetVersion(i.Version())
 		l += 4
 
-		i.dataOfferHandler(e)
+		if i.dataOfferHandler != nil {
+			i.dataOfferHandler(e)
+		}
 	case 1:
 		if i.enterHandler == nil {
 			return
@@ -2544,13 +3780,13 @@ func (i *DataDevice) Dispatch(opcode uint32, fd int, data []byte) {
 		l := 0
 		e.Serial = Uint32(data[l : l+4])
 		l += 4
-		e.Surface = i.Context().GetProxy(Uint32(data[l : l+4])).(*Surface)
+		e.Surface, _ = i.Context().GetProxy(Uint32(data[l : l+4])).(*Surface)
 		l += 4
-		e.X = Fixed(data[l : l+4])
+		e.X = FixedFloat64(data[l : l+4])
 		l += 4
-		e.Y = Fixed(data[l : l+4])
+		e.Y = FixedFloat64(data[l : l+4])
 		l += 4
-		e.Id = i.Context().GetProxy(Uint32(data[l : l+4])).(*DataOffer)
+		e.Id, _ = i.Context().GetProxy(Uint32(data[l : l+4])).(*DataOffer)
 		l += 4
 
 		i.enterHandler(e)
@@ -2569,9 +3805,9 @@ func (i *DataDevice) Dispatch(opcode uint32, fd int, data []byte) {
 		l := 0
 		e.Time = Uint32(data[l : l+4])
 		l += 4
-		e.X = Fixed(data[l : l+4])
+		e.X = FixedFloat64(data[l : l+4])
 		l += 4
-		e.Y = Fixed(data[l : l+4])
+		e.Y = FixedFloat64(data[l : l+4])
 		l += 4
 
 		i.motionHandler(e)
@@ -2588,7 +3824,7 @@ func (i *DataDevice) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e DataDeviceSelectionEvent
 		l := 0
-		e.Id = i.Context().GetProxy(Uint32(data[l : l+4])).(*DataOffer)
+		e.Id, _ = i.Context().GetProxy(Uint32(data[l : l+4])).(*DataOffer)
 		l += 4
 
 		i.selectionHandler(e)
@@ -2629,11 +3865,52 @@ func NewDataDeviceManager(ctx *Context) *DataDeviceManager {
 	return wlDataDeviceManager
 }
 
+// DataDeviceManagerInterface describes wl_data_device_manager at runtime.
+var DataDeviceManagerInterface = &Interface{
+	Name:    "wl_data_device_manager",
+	Version: 3,
+	New:     func() Proxy { return &DataDeviceManager{} },
+	Requests: []Message{
+		{
+			Name: "create_data_source",
+			Args: []Arg{
+				{Name: "id", Type: "new_id", Interface: "wl_data_source"},
+			},
+		},
+		{
+			Name: "get_data_device",
+			Args: []Arg{
+				{Name: "id", Type: "new_id", Interface: "wl_data_device"},
+				{Name: "seat", Type: "object", Interface: "wl_seat"},
+			},
+		},
+	},
+	Enums: []Enum{
+		{
+			Name:     "dnd_action",
+			Since:    3,
+			Bitfield: true,
+			Entries: []EnumEntry{
+				{Name: "none", Value: 0},
+				{Name: "copy", Value: 1},
+				{Name: "move", Value: 2},
+				{Name: "ask", Value: 4},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wl_data_device_manager.
+func (i *DataDeviceManager) Interface() *Interface {
+	return DataDeviceManagerInterface
+}
+
 // CreateDataSource : create a new data source
 //
 // Create a new data source.
 func (i *DataDeviceManager) CreateDataSource() (*DataSource, error) {
 	id := NewDataSource(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 0
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -2655,6 +3932,7 @@ func (i *DataDeviceManager) CreateDataSource() (*DataSource, error) {
 //	seat: seat associated with the data device
 func (i *DataDeviceManager) GetDataDevice(seat *Seat) (*DataDevice, error) {
 	id := NewDataDevice(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -2744,16 +4022,37 @@ func (e DataDeviceManagerDndAction) Value() string {
 	}
 }
 
-func (e DataDeviceManagerDndAction) String() string {
-	return e.Name() + "=" + e.Value()
+func (e DataDeviceManagerDndAction) Has(f DataDeviceManagerDndAction) bool {
+	return e&f == f
 }
 
-// Shell : create desktop-style surfaces
-//
-// This interface is implemented by servers that provide
-// desktop-style user interfaces.
-//
-// It allows clients to associate a wl_shell_surface with
+func (e DataDeviceManagerDndAction) Set(f DataDeviceManagerDndAction) DataDeviceManagerDndAction {
+	return e | f
+}
+
+func (e DataDeviceManagerDndAction) String() string {
+	if e == 0 {
+		return "none"
+	}
+	var names []string
+	for _, f := range []DataDeviceManagerDndAction{DataDeviceManagerDndActionCopy, DataDeviceManagerDndActionMove, DataDeviceManagerDndActionAsk} {
+		if e.Has(f) {
+			names = append(names, f.Name())
+			e &^= f
+		}
+	}
+	if e != 0 {
+		names = append(names, "0x"+strconv.FormatUint(uint64(e), 16))
+	}
+	return strings.Join(names, "|")
+}
+
+// Shell : create desktop-style surfaces
+//
+// This interface is implemented by servers that provide
+// desktop-style user interfaces.
+//
+// It allows clients to associate a wl_shell_surface with
 // a basic surface.
 //
 // Note! This protocol is deprecated and not intended for production use.
@@ -2780,6 +4079,35 @@ func NewShell(ctx *Context) *Shell {
 	return wlShell
 }
 
+// ShellInterface describes wl_shell at runtime.
+var ShellInterface = &Interface{
+	Name:    "wl_shell",
+	Version: 1,
+	New:     func() Proxy { return &Shell{} },
+	Requests: []Message{
+		{
+			Name: "get_shell_surface",
+			Args: []Arg{
+				{Name: "id", Type: "new_id", Interface: "wl_shell_surface"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+			},
+		},
+	},
+	Enums: []Enum{
+		{
+			Name: "error",
+			Entries: []EnumEntry{
+				{Name: "role", Value: 0},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wl_shell.
+func (i *Shell) Interface() *Interface {
+	return ShellInterface
+}
+
 // GetShellSurface : create a shell surface from a surface
 //
 // Create a shell surface for an existing surface. This gives
@@ -2791,6 +4119,7 @@ func NewShell(ctx *Context) *Shell {
 //	surface: surface to be given the shell surface role
 func (i *Shell) GetShellSurface(surface *Surface) (*ShellSurface, error) {
 	id := NewShellSurface(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 0
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -2881,6 +4210,142 @@ func NewShellSurface(ctx *Context) *ShellSurface {
 	return wlShellSurface
 }
 
+// ShellSurfaceInterface describes wl_shell_surface at runtime.
+var ShellSurfaceInterface = &Interface{
+	Name:    "wl_shell_surface",
+	Version: 1,
+	New:     func() Proxy { return &ShellSurface{} },
+	Requests: []Message{
+		{
+			Name: "pong",
+			Args: []Arg{
+				{Name: "serial", Type: "uint"},
+			},
+		},
+		{
+			Name: "move",
+			Args: []Arg{
+				{Name: "seat", Type: "object", Interface: "wl_seat"},
+				{Name: "serial", Type: "uint"},
+			},
+		},
+		{
+			Name: "resize",
+			Args: []Arg{
+				{Name: "seat", Type: "object", Interface: "wl_seat"},
+				{Name: "serial", Type: "uint"},
+				{Name: "edges", Type: "uint", Enum: "wl_shell_surface.resize"},
+			},
+		},
+		{
+			Name: "set_toplevel",
+		},
+		{
+			Name: "set_transient",
+			Args: []Arg{
+				{Name: "parent", Type: "object", Interface: "wl_surface"},
+				{Name: "x", Type: "int"},
+				{Name: "y", Type: "int"},
+				{Name: "flags", Type: "uint", Enum: "wl_shell_surface.transient"},
+			},
+		},
+		{
+			Name: "set_fullscreen",
+			Args: []Arg{
+				{Name: "method", Type: "uint", Enum: "wl_shell_surface.fullscreen_method"},
+				{Name: "framerate", Type: "uint"},
+				{Name: "output", Type: "object", Interface: "wl_output", Nullable: true},
+			},
+		},
+		{
+			Name: "set_popup",
+			Args: []Arg{
+				{Name: "seat", Type: "object", Interface: "wl_seat"},
+				{Name: "serial", Type: "uint"},
+				{Name: "parent", Type: "object", Interface: "wl_surface"},
+				{Name: "x", Type: "int"},
+				{Name: "y", Type: "int"},
+				{Name: "flags", Type: "uint", Enum: "wl_shell_surface.transient"},
+			},
+		},
+		{
+			Name: "set_maximized",
+			Args: []Arg{
+				{Name: "output", Type: "object", Interface: "wl_output", Nullable: true},
+			},
+		},
+		{
+			Name: "set_title",
+			Args: []Arg{
+				{Name: "title", Type: "string"},
+			},
+		},
+		{
+			Name: "set_class",
+			Args: []Arg{
+				{Name: "class", Type: "string"},
+			},
+		},
+	},
+	Events: []Message{
+		{
+			Name: "ping",
+			Args: []Arg{
+				{Name: "serial", Type: "uint"},
+			},
+		},
+		{
+			Name: "configure",
+			Args: []Arg{
+				{Name: "edges", Type: "uint", Enum: "wl_shell_surface.resize"},
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+			},
+		},
+		{
+			Name: "popup_done",
+		},
+	},
+	Enums: []Enum{
+		{
+			Name:     "resize",
+			Bitfield: true,
+			Entries: []EnumEntry{
+				{Name: "none", Value: 0},
+				{Name: "top", Value: 1},
+				{Name: "bottom", Value: 2},
+				{Name: "left", Value: 4},
+				{Name: "top_left", Value: 5},
+				{Name: "bottom_left", Value: 6},
+				{Name: "right", Value: 8},
+				{Name: "top_right", Value: 9},
+				{Name: "bottom_right", Value: 10},
+			},
+		},
+		{
+			Name:     "transient",
+			Bitfield: true,
+			Entries: []EnumEntry{
+				{Name: "inactive", Value: 0x1},
+			},
+		},
+		{
+			Name: "fullscreen_method",
+			Entries: []EnumEntry{
+				{Name: "default", Value: 0},
+				{Name: "scale", Value: 1},
+				{Name: "driver", Value: 2},
+				{Name: "fill", Value: 3},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wl_shell_surface.
+func (i *ShellSurface) Interface() *Interface {
+	return ShellSurfaceInterface
+}
+
 // Pong : respond to a ping event
 //
 // A client must respond to a ping event with a pong request or
@@ -2940,7 +4405,7 @@ func (i *ShellSurface) Move(seat *Seat, serial uint32) error {
 //	seat: seat whose pointer is used
 //	serial: serial number of the implicit grab on the pointer
 //	edges: which edge or corner is being dragged
-func (i *ShellSurface) Resize(seat *Seat, serial, edges uint32) error {
+func (i *ShellSurface) Resize(seat *Seat, serial uint32, edges ShellSurfaceResize) error {
 	const opcode = 2
 	const _reqBufLen = 8 + 4 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -2991,7 +4456,7 @@ func (i *ShellSurface) SetToplevel() error {
 //	x: surface-local x coordinate
 //	y: surface-local y coordinate
 //	flags: transient surface behavior
-func (i *ShellSurface) SetTransient(parent *Surface, x, y int32, flags uint32) error {
+func (i *ShellSurface) SetTransient(parent *Surface, x, y int32, flags ShellSurfaceTransient) error {
 	const opcode = 4
 	const _reqBufLen = 8 + 4 + 4 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -3051,7 +4516,7 @@ func (i *ShellSurface) SetTransient(parent *Surface, x, y int32, flags uint32) e
 //	method: method for resolving size conflict
 //	framerate: framerate in mHz
 //	output: output on which the surface is to be fullscreen
-func (i *ShellSurface) SetFullscreen(method, framerate uint32, output *Output) error {
+func (i *ShellSurface) SetFullscreen(method ShellSurfaceFullscreenMethod, framerate uint32, output *Output) error {
 	const opcode = 5
 	const _reqBufLen = 8 + 4 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -3103,7 +4568,7 @@ func (i *ShellSurface) SetFullscreen(method, framerate uint32, output *Output) e
 //	x: surface-local x coordinate
 //	y: surface-local y coordinate
 //	flags: transient surface behavior
-func (i *ShellSurface) SetPopup(seat *Seat, serial uint32, parent *Surface, x, y int32, flags uint32) error {
+func (i *ShellSurface) SetPopup(seat *Seat, serial uint32, parent *Surface, x, y int32, flags ShellSurfaceTransient) error {
 	const opcode = 6
 	const _reqBufLen = 8 + 4 + 4 + 4 + 4 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -3191,7 +4656,7 @@ func (i *ShellSurface) SetTitle(title string) error {
 	l += 4
 	PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
 	l += 4
-	PutString(_reqBuf[l:l+(4+titleLen)], title, titleLen)
+	PutString(_reqBuf[l:l+(4+titleLen)], title)
 	l += (4 + titleLen)
 	err := i.Context().WriteMsg(_reqBuf, nil)
 	return err
@@ -3217,7 +4682,7 @@ func (i *ShellSurface) SetClass(class string) error {
 	l += 4
 	PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
 	l += 4
-	PutString(_reqBuf[l:l+(4+classLen)], class, classLen)
+	PutString(_reqBuf[l:l+(4+classLen)], class)
 	l += (4 + classLen)
 	err := i.Context().WriteMsg(_reqBuf, nil)
 	return err
@@ -3307,8 +4772,29 @@ func (e ShellSurfaceResize) Value() string {
 	}
 }
 
+func (e ShellSurfaceResize) Has(f ShellSurfaceResize) bool {
+	return e&f == f
+}
+
+func (e ShellSurfaceResize) Set(f ShellSurfaceResize) ShellSurfaceResize {
+	return e | f
+}
+
 func (e ShellSurfaceResize) String() string {
-	return e.Name() + "=" + e.Value()
+	if e == 0 {
+		return "none"
+	}
+	var names []string
+	for _, f := range []ShellSurfaceResize{ShellSurfaceResizeTop, ShellSurfaceResizeBottom, ShellSurfaceResizeLeft, ShellSurfaceResizeTopLeft, ShellSurfaceResizeBottomLeft, ShellSurfaceResizeRight, ShellSurfaceResizeTopRight, ShellSurfaceResizeBottomRight} {
+		if e.Has(f) {
+			names = append(names, f.Name())
+			e &^= f
+		}
+	}
+	if e != 0 {
+		names = append(names, "0x"+strconv.FormatUint(uint64(e), 16))
+	}
+	return strings.Join(names, "|")
 }
 
 type ShellSurfaceTransient uint32
@@ -3340,8 +4826,29 @@ func (e ShellSurfaceTransient) Value() string {
 	}
 }
 
+func (e ShellSurfaceTransient) Has(f ShellSurfaceTransient) bool {
+	return e&f == f
+}
+
+func (e ShellSurfaceTransient) Set(f ShellSurfaceTransient) ShellSurfaceTransient {
+	return e | f
+}
+
 func (e ShellSurfaceTransient) String() string {
-	return e.Name() + "=" + e.Value()
+	if e == 0 {
+		return "0"
+	}
+	var names []string
+	for _, f := range []ShellSurfaceTransient{ShellSurfaceTransientInactive} {
+		if e.Has(f) {
+			names = append(names, f.Name())
+			e &^= f
+		}
+	}
+	if e != 0 {
+		names = append(names, "0x"+strconv.FormatUint(uint64(e), 16))
+	}
+	return strings.Join(names, "|")
 }
 
 type ShellSurfaceFullscreenMethod uint32
@@ -3410,6 +4917,20 @@ func (i *ShellSurface) SetPingHandler(f ShellSurfacePingHandlerFunc) {
 	i.pingHandler = f
 }
 
+// String formats the event as wl_shell_surface.ping(argument=value, ...).
+func (e ShellSurfacePingEvent) String() string {
+	return "wl_shell_surface.ping(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e ShellSurfacePingEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+	)
+}
+
 // ShellSurfaceConfigureEvent : suggest resize
 //
 // The configure event asks the client to resize its surface.
@@ -3430,7 +4951,7 @@ func (i *ShellSurface) SetPingHandler(f ShellSurfacePingHandlerFunc) {
 // The width and height arguments specify the size of the window
 // in surface-local coordinates.
 type ShellSurfaceConfigureEvent struct {
-	Edges  uint32
+	Edges  ShellSurfaceResize
 	Width  int32
 	Height int32
 }
@@ -3441,6 +4962,24 @@ func (i *ShellSurface) SetConfigureHandler(f ShellSurfaceConfigureHandlerFunc) {
 	i.configureHandler = f
 }
 
+// String formats the event as wl_shell_surface.configure(argument=value, ...).
+func (e ShellSurfaceConfigureEvent) String() string {
+	return "wl_shell_surface.configure(" +
+		"edges=" + e.Edges.String() +
+		", width=" + strconv.FormatInt(int64(e.Width), 10) +
+		", height=" + strconv.FormatInt(int64(e.Height), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e ShellSurfaceConfigureEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("edges", e.Edges.String()),
+		slog.Int64("width", int64(e.Width)),
+		slog.Int64("height", int64(e.Height)),
+	)
+}
+
 // ShellSurfacePopupDoneEvent : popup interaction is done
 //
 // The popup_done event is sent out when a popup grab is broken,
@@ -3454,6 +4993,51 @@ func (i *ShellSurface) SetPopupDoneHandler(f ShellSurfacePopupDoneHandlerFunc) {
 	i.popupDoneHandler = f
 }
 
+// String formats the event as wl_shell_surface.popup_done(argument=value, ...).
+func (e ShellSurfacePopupDoneEvent) String() string {
+	return "wl_shell_surface.popup_done()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e ShellSurfacePopupDoneEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
+// ShellSurfaceListener receives all the events of ShellSurface, embed
+// ShellSurfaceNoopListener to implement only some of them.
+type ShellSurfaceListener interface {
+	Ping(e ShellSurfacePingEvent)
+	Configure(e ShellSurfaceConfigureEvent)
+	PopupDone(e ShellSurfacePopupDoneEvent)
+}
+
+// ShellSurfaceNoopListener is a ShellSurfaceListener ignoring
+// all the events, it closes the file descriptors they carry.
+type ShellSurfaceNoopListener struct{}
+
+func (ShellSurfaceNoopListener) Ping(ShellSurfacePingEvent) {
+}
+
+func (ShellSurfaceNoopListener) Configure(ShellSurfaceConfigureEvent) {
+}
+
+func (ShellSurfaceNoopListener) PopupDone(ShellSurfacePopupDoneEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *ShellSurface) SetListener(l ShellSurfaceListener) {
+	if l == nil {
+		i.pingHandler = nil
+		i.configureHandler = nil
+		i.popupDoneHandler = nil
+		return
+	}
+	i.pingHandler = l.Ping
+	i.configureHandler = l.Configure
+	i.popupDoneHandler = l.PopupDone
+}
+
 func (i *ShellSurface) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -3472,7 +5056,7 @@ func (i *ShellSurface) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e ShellSurfaceConfigureEvent
 		l := 0
-		e.Edges = Uint32(data[l : l+4])
+		e.Edges = ShellSurfaceResize(Uint32(data[l : l+4]))
 		l += 4
 		e.Width = int32(Uint32(data[l : l+4]))
 		l += 4
@@ -3535,8 +5119,10 @@ func (i *ShellSurface) Dispatch(opcode uint32, fd int, data []byte) {
 // switching is not allowed).
 type Surface struct {
 	BaseProxy
-	enterHandler SurfaceEnterHandlerFunc
-	leaveHandler SurfaceLeaveHandlerFunc
+	enterHandler                    SurfaceEnterHandlerFunc
+	leaveHandler                    SurfaceLeaveHandlerFunc
+	preferredBufferScaleHandler     SurfacePreferredBufferScaleHandlerFunc
+	preferredBufferTransformHandler SurfacePreferredBufferTransformHandlerFunc
 }
 
 // NewSurface : an onscreen surface
@@ -3588,6 +5174,133 @@ func NewSurface(ctx *Context) *Surface {
 	return wlSurface
 }
 
+// SurfaceInterface describes wl_surface at runtime.
+var SurfaceInterface = &Interface{
+	Name:    "wl_surface",
+	Version: 6,
+	New:     func() Proxy { return &Surface{} },
+	Requests: []Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "attach",
+			Args: []Arg{
+				{Name: "buffer", Type: "object", Interface: "wl_buffer", Nullable: true},
+				{Name: "x", Type: "int"},
+				{Name: "y", Type: "int"},
+			},
+		},
+		{
+			Name: "damage",
+			Args: []Arg{
+				{Name: "x", Type: "int"},
+				{Name: "y", Type: "int"},
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+			},
+		},
+		{
+			Name: "frame",
+			Args: []Arg{
+				{Name: "callback", Type: "new_id", Interface: "wl_callback"},
+			},
+		},
+		{
+			Name: "set_opaque_region",
+			Args: []Arg{
+				{Name: "region", Type: "object", Interface: "wl_region", Nullable: true},
+			},
+		},
+		{
+			Name: "set_input_region",
+			Args: []Arg{
+				{Name: "region", Type: "object", Interface: "wl_region", Nullable: true},
+			},
+		},
+		{
+			Name: "commit",
+		},
+		{
+			Name:  "set_buffer_transform",
+			Since: 2,
+			Args: []Arg{
+				{Name: "transform", Type: "int", Enum: "wl_output.transform"},
+			},
+		},
+		{
+			Name:  "set_buffer_scale",
+			Since: 3,
+			Args: []Arg{
+				{Name: "scale", Type: "int"},
+			},
+		},
+		{
+			Name:  "damage_buffer",
+			Since: 4,
+			Args: []Arg{
+				{Name: "x", Type: "int"},
+				{Name: "y", Type: "int"},
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+			},
+		},
+		{
+			Name:  "offset",
+			Since: 5,
+			Args: []Arg{
+				{Name: "x", Type: "int"},
+				{Name: "y", Type: "int"},
+			},
+		},
+	},
+	Events: []Message{
+		{
+			Name: "enter",
+			Args: []Arg{
+				{Name: "output", Type: "object", Interface: "wl_output"},
+			},
+		},
+		{
+			Name: "leave",
+			Args: []Arg{
+				{Name: "output", Type: "object", Interface: "wl_output"},
+			},
+		},
+		{
+			Name:  "preferred_buffer_scale",
+			Since: 6,
+			Args: []Arg{
+				{Name: "factor", Type: "int"},
+			},
+		},
+		{
+			Name:  "preferred_buffer_transform",
+			Since: 6,
+			Args: []Arg{
+				{Name: "transform", Type: "uint", Enum: "wl_output.transform"},
+			},
+		},
+	},
+	Enums: []Enum{
+		{
+			Name: "error",
+			Entries: []EnumEntry{
+				{Name: "invalid_scale", Value: 0},
+				{Name: "invalid_transform", Value: 1},
+				{Name: "invalid_size", Value: 2},
+				{Name: "invalid_offset", Value: 3},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wl_surface.
+func (i *Surface) Interface() *Interface {
+	return SurfaceInterface
+}
+
 // Destroy : delete surface
 //
 // Deletes the surface and invalidates its object ID.
@@ -3776,6 +5489,7 @@ func (i *Surface) Damage(x, y, width, height int32) error {
 // milliseconds, with an undefined base.
 func (i *Surface) Frame() (*Callback, error) {
 	callback := NewCallback(i.Context())
+	callback.SetVersion(i.Version())
 	const opcode = 3
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -3949,7 +5663,10 @@ func (i *Surface) Commit() error {
 // is raised.
 //
 //	transform: transform for interpreting buffer contents
-func (i *Surface) SetBufferTransform(transform int32) error {
+func (i *Surface) SetBufferTransform(transform OutputTransform) error {
+	if v := i.Version(); v != 0 && v < 2 {
+		return &VersionError{Interface: "wl_surface", Request: "set_buffer_transform", Since: 2, Version: v}
+	}
 	const opcode = 7
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -3992,6 +5709,9 @@ func (i *Surface) SetBufferTransform(transform int32) error {
 //
 //	scale: positive scale for interpreting buffer contents
 func (i *Surface) SetBufferScale(scale int32) error {
+	if v := i.Version(); v != 0 && v < 3 {
+		return &VersionError{Interface: "wl_surface", Request: "set_buffer_scale", Since: 3, Version: v}
+	}
 	const opcode = 8
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -4046,6 +5766,9 @@ func (i *Surface) SetBufferScale(scale int32) error {
 //	width: width of damage rectangle
 //	height: height of damage rectangle
 func (i *Surface) DamageBuffer(x, y, width, height int32) error {
+	if v := i.Version(); v != 0 && v < 4 {
+		return &VersionError{Interface: "wl_surface", Request: "damage_buffer", Since: 4, Version: v}
+	}
 	const opcode = 9
 	const _reqBufLen = 8 + 4 + 4 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -4084,6 +5807,9 @@ func (i *Surface) DamageBuffer(x, y, width, height int32) error {
 //	x: surface-local x coordinate
 //	y: surface-local y coordinate
 func (i *Surface) Offset(x, y int32) error {
+	if v := i.Version(); v != 0 && v < 5 {
+		return &VersionError{Interface: "wl_surface", Request: "offset", Since: 5, Version: v}
+	}
 	const opcode = 10
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -4167,6 +5893,20 @@ func (i *Surface) SetEnterHandler(f SurfaceEnterHandlerFunc) {
 	i.enterHandler = f
 }
 
+// String formats the event as wl_surface.enter(argument=value, ...).
+func (e SurfaceEnterEvent) String() string {
+	return "wl_surface.enter(" +
+		"output=" + ObjectString(e.Output) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e SurfaceEnterEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("output", ObjectString(e.Output)),
+	)
+}
+
 // SurfaceLeaveEvent : surface leaves an output
 //
 // This is emitted whenever a surface's creation, movement, or resizing
@@ -4188,6 +5928,134 @@ func (i *Surface) SetLeaveHandler(f SurfaceLeaveHandlerFunc) {
 	i.leaveHandler = f
 }
 
+// String formats the event as wl_surface.leave(argument=value, ...).
+func (e SurfaceLeaveEvent) String() string {
+	return "wl_surface.leave(" +
+		"output=" + ObjectString(e.Output) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e SurfaceLeaveEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("output", ObjectString(e.Output)),
+	)
+}
+
+// SurfacePreferredBufferScaleEvent : preferred buffer scale for the surface
+//
+// This event indicates the preferred buffer scale for this surface. It is
+// sent whenever the compositor's preference changes.
+//
+// Before receiving this event the preferred buffer scale for this surface
+// is 1.
+//
+// It is intended that scaling aware clients use this event to scale their
+// content and use wl_surface.set_buffer_scale to indicate the scale they
+// have rendered with. This allows clients to supply a higher detail
+// buffer.
+//
+// The compositor shall emit a scale value greater than 0.
+type SurfacePreferredBufferScaleEvent struct {
+	Factor int32
+}
+type SurfacePreferredBufferScaleHandlerFunc func(SurfacePreferredBufferScaleEvent)
+
+// SetPreferredBufferScaleHandler : sets handler for SurfacePreferredBufferScaleEvent
+func (i *Surface) SetPreferredBufferScaleHandler(f SurfacePreferredBufferScaleHandlerFunc) {
+	i.preferredBufferScaleHandler = f
+}
+
+// String formats the event as wl_surface.preferred_buffer_scale(argument=value, ...).
+func (e SurfacePreferredBufferScaleEvent) String() string {
+	return "wl_surface.preferred_buffer_scale(" +
+		"factor=" + strconv.FormatInt(int64(e.Factor), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e SurfacePreferredBufferScaleEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int64("factor", int64(e.Factor)),
+	)
+}
+
+// SurfacePreferredBufferTransformEvent : preferred buffer transform for the surface
+//
+// This event indicates the preferred buffer transform for this surface.
+// It is sent whenever the compositor's preference changes.
+//
+// Before receiving this event the preferred buffer transform for this
+// surface is normal.
+//
+// Applying this transformation to the surface buffer contents and using
+// wl_surface.set_buffer_transform might allow the compositor to use the
+// surface buffer more efficiently.
+type SurfacePreferredBufferTransformEvent struct {
+	Transform OutputTransform
+}
+type SurfacePreferredBufferTransformHandlerFunc func(SurfacePreferredBufferTransformEvent)
+
+// SetPreferredBufferTransformHandler : sets handler for SurfacePreferredBufferTransformEvent
+func (i *Surface) SetPreferredBufferTransformHandler(f SurfacePreferredBufferTransformHandlerFunc) {
+	i.preferredBufferTransformHandler = f
+}
+
+// String formats the event as wl_surface.preferred_buffer_transform(argument=value, ...).
+func (e SurfacePreferredBufferTransformEvent) String() string {
+	return "wl_surface.preferred_buffer_transform(" +
+		"transform=" + EnumString(e.Transform.Name(), uint32(e.Transform)) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e SurfacePreferredBufferTransformEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("transform", EnumString(e.Transform.Name(), uint32(e.Transform))),
+	)
+}
+
+// SurfaceListener receives all the events of Surface, embed
+// SurfaceNoopListener to implement only some of them.
+type SurfaceListener interface {
+	Enter(e SurfaceEnterEvent)
+	Leave(e SurfaceLeaveEvent)
+	PreferredBufferScale(e SurfacePreferredBufferScaleEvent)
+	PreferredBufferTransform(e SurfacePreferredBufferTransformEvent)
+}
+
+// SurfaceNoopListener is a SurfaceListener ignoring
+// all the events, it closes the file descriptors they carry.
+type SurfaceNoopListener struct{}
+
+func (SurfaceNoopListener) Enter(SurfaceEnterEvent) {
+}
+
+func (SurfaceNoopListener) Leave(SurfaceLeaveEvent) {
+}
+
+func (SurfaceNoopListener) PreferredBufferScale(SurfacePreferredBufferScaleEvent) {
+}
+
+func (SurfaceNoopListener) PreferredBufferTransform(SurfacePreferredBufferTransformEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *Surface) SetListener(l SurfaceListener) {
+	if l == nil {
+		i.enterHandler = nil
+		i.leaveHandler = nil
+		i.preferredBufferScaleHandler = nil
+		i.preferredBufferTransformHandler = nil
+		return
+	}
+	i.enterHandler = l.Enter
+	i.leaveHandler = l.Leave
+	i.preferredBufferScaleHandler = l.PreferredBufferScale
+	i.preferredBufferTransformHandler = l.PreferredBufferTransform
+}
+
 func (i *Surface) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -4196,7 +6064,7 @@ func (i *Surface) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e SurfaceEnterEvent
 		l := 0
-		e.Output = i.Context().GetProxy(Uint32(data[l : l+4])).(*Output)
+		e.Output, _ = i.Context().GetProxy(Uint32(data[l : l+4])).(*Output)
 		l += 4
 
 		i.enterHandler(e)
@@ -4206,10 +6074,30 @@ func (i *Surface) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e SurfaceLeaveEvent
 		l := 0
-		e.Output = i.Context().GetProxy(Uint32(data[l : l+4])).(*Output)
+		e.Output, _ = i.Context().GetProxy(Uint32(data[l : l+4])).(*Output)
 		l += 4
 
 		i.leaveHandler(e)
+	case 2:
+		if i.preferredBufferScaleHandler == nil {
+			return
+		}
+		var e SurfacePreferredBufferScaleEvent
+		l := 0
+		e.Factor = int32(Uint32(data[l : l+4]))
+		l += 4
+
+		i.preferredBufferScaleHandler(e)
+	case 3:
+		if i.preferredBufferTransformHandler == nil {
+			return
+		}
+		var e SurfacePreferredBufferTransformEvent
+		l := 0
+		e.Transform = OutputTransform(Uint32(data[l : l+4]))
+		l += 4
+
+		i.preferredBufferTransformHandler(e)
 	}
 }
 
@@ -4237,6 +6125,75 @@ func NewSeat(ctx *Context) *Seat {
 	return wlSeat
 }
 
+// SeatInterface describes wl_seat at runtime.
+var SeatInterface = &Interface{
+	Name:    "wl_seat",
+	Version: 10,
+	New:     func() Proxy { return &Seat{} },
+	Requests: []Message{
+		{
+			Name: "get_pointer",
+			Args: []Arg{
+				{Name: "id", Type: "new_id", Interface: "wl_pointer"},
+			},
+		},
+		{
+			Name: "get_keyboard",
+			Args: []Arg{
+				{Name: "id", Type: "new_id", Interface: "wl_keyboard"},
+			},
+		},
+		{
+			Name: "get_touch",
+			Args: []Arg{
+				{Name: "id", Type: "new_id", Interface: "wl_touch"},
+			},
+		},
+		{
+			Name:       "release",
+			Since:      5,
+			Destructor: true,
+		},
+	},
+	Events: []Message{
+		{
+			Name: "capabilities",
+			Args: []Arg{
+				{Name: "capabilities", Type: "uint", Enum: "wl_seat.capability"},
+			},
+		},
+		{
+			Name:  "name",
+			Since: 2,
+			Args: []Arg{
+				{Name: "name", Type: "string"},
+			},
+		},
+	},
+	Enums: []Enum{
+		{
+			Name:     "capability",
+			Bitfield: true,
+			Entries: []EnumEntry{
+				{Name: "pointer", Value: 1},
+				{Name: "keyboard", Value: 2},
+				{Name: "touch", Value: 4},
+			},
+		},
+		{
+			Name: "error",
+			Entries: []EnumEntry{
+				{Name: "missing_capability", Value: 0},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wl_seat.
+func (i *Seat) Interface() *Interface {
+	return SeatInterface
+}
+
 // GetPointer : return pointer object
 //
 // The ID provided will be initialized to the wl_pointer interface
@@ -4249,6 +6206,7 @@ func NewSeat(ctx *Context) *Seat {
 // be sent in this case.
 func (i *Seat) GetPointer() (*Pointer, error) {
 	id := NewPointer(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 0
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -4275,6 +6233,7 @@ func (i *Seat) GetPointer() (*Pointer, error) {
 // be sent in this case.
 func (i *Seat) GetKeyboard() (*Keyboard, error) {
 	id := NewKeyboard(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -4301,6 +6260,7 @@ func (i *Seat) GetKeyboard() (*Keyboard, error) {
 // be sent in this case.
 func (i *Seat) GetTouch() (*Touch, error) {
 	id := NewTouch(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 2
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -4320,6 +6280,9 @@ func (i *Seat) GetTouch() (*Touch, error) {
 // Using this request a client can tell the server that it is not going to
 // use the seat object anymore.
 func (i *Seat) Release() error {
+	if v := i.Version(); v != 0 && v < 5 {
+		return &VersionError{Interface: "wl_seat", Request: "release", Since: 5, Version: v}
+	}
 	defer i.Context().Unregister(i)
 	const opcode = 3
 	const _reqBufLen = 8
@@ -4333,6 +6296,16 @@ func (i *Seat) Release() error {
 	return err
 }
 
+// Destroy sends release unless the version of the object is known to
+// predate it, in which case it only forgets the object.
+func (i *Seat) Destroy() error {
+	if v := i.Version(); v != 0 && v < 5 {
+		i.Context().Unregister(i)
+		return nil
+	}
+	return i.Release()
+}
+
 type SeatCapability uint32
 
 // SeatCapability : seat capability bitmask
@@ -4374,8 +6347,29 @@ func (e SeatCapability) Value() string {
 	}
 }
 
+func (e SeatCapability) Has(f SeatCapability) bool {
+	return e&f == f
+}
+
+func (e SeatCapability) Set(f SeatCapability) SeatCapability {
+	return e | f
+}
+
 func (e SeatCapability) String() string {
-	return e.Name() + "=" + e.Value()
+	if e == 0 {
+		return "0"
+	}
+	var names []string
+	for _, f := range []SeatCapability{SeatCapabilityPointer, SeatCapabilityKeyboard, SeatCapabilityTouch} {
+		if e.Has(f) {
+			names = append(names, f.Name())
+			e &^= f
+		}
+	}
+	if e != 0 {
+		names = append(names, "0x"+strconv.FormatUint(uint64(e), 16))
+	}
+	return strings.Join(names, "|")
 }
 
 type SeatError uint32
@@ -4437,7 +6431,7 @@ func (e SeatError) String() string {
 // The above behavior also applies to wl_keyboard and wl_touch with the
 // keyboard and touch capabilities, respectively.
 type SeatCapabilitiesEvent struct {
-	Capabilities uint32
+	Capabilities SeatCapability
 }
 type SeatCapabilitiesHandlerFunc func(SeatCapabilitiesEvent)
 
@@ -4446,6 +6440,20 @@ func (i *Seat) SetCapabilitiesHandler(f SeatCapabilitiesHandlerFunc) {
 	i.capabilitiesHandler = f
 }
 
+// String formats the event as wl_seat.capabilities(argument=value, ...).
+func (e SeatCapabilitiesEvent) String() string {
+	return "wl_seat.capabilities(" +
+		"capabilities=" + e.Capabilities.String() +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e SeatCapabilitiesEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("capabilities", e.Capabilities.String()),
+	)
+}
+
 // SeatNameEvent : unique identifier for this seat
 //
 // In a multi-seat configuration the seat name can be used by clients to
@@ -4474,6 +6482,49 @@ func (i *Seat) SetNameHandler(f SeatNameHandlerFunc) {
 	i.nameHandler = f
 }
 
+// String formats the event as wl_seat.name(argument=value, ...).
+func (e SeatNameEvent) String() string {
+	return "wl_seat.name(" +
+		"name=" + strconv.Quote(e.Name) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e SeatNameEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("name", e.Name),
+	)
+}
+
+// SeatListener receives all the events of Seat, embed
+// SeatNoopListener to implement only some of them.
+type SeatListener interface {
+	Capabilities(e SeatCapabilitiesEvent)
+	Name(e SeatNameEvent)
+}
+
+// SeatNoopListener is a SeatListener ignoring
+// all the events, it closes the file descriptors they carry.
+type SeatNoopListener struct{}
+
+func (SeatNoopListener) Capabilities(SeatCapabilitiesEvent) {
+}
+
+func (SeatNoopListener) Name(SeatNameEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *Seat) SetListener(l SeatListener) {
+	if l == nil {
+		i.capabilitiesHandler = nil
+		i.nameHandler = nil
+		return
+	}
+	i.capabilitiesHandler = l.Capabilities
+	i.nameHandler = l.Name
+}
+
 func (i *Seat) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -4482,7 +6533,7 @@ func (i *Seat) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e SeatCapabilitiesEvent
 		l := 0
-		e.Capabilities = Uint32(data[l : l+4])
+		e.Capabilities = SeatCapability(Uint32(data[l : l+4]))
 		l += 4
 
 		i.capabilitiesHandler(e)
@@ -4513,16 +6564,17 @@ func (i *Seat) Dispatch(opcode uint32, fd int, data []byte) {
 // and scrolling.
 type Pointer struct {
 	BaseProxy
-	enterHandler        PointerEnterHandlerFunc
-	leaveHandler        PointerLeaveHandlerFunc
-	motionHandler       PointerMotionHandlerFunc
-	buttonHandler       PointerButtonHandlerFunc
-	axisHandler         PointerAxisHandlerFunc
-	frameHandler        PointerFrameHandlerFunc
-	axisSourceHandler   PointerAxisSourceHandlerFunc
-	axisStopHandler     PointerAxisStopHandlerFunc
-	axisDiscreteHandler PointerAxisDiscreteHandlerFunc
-	axisValue120Handler PointerAxisValue120HandlerFunc
+	enterHandler                 PointerEnterHandlerFunc
+	leaveHandler                 PointerLeaveHandlerFunc
+	motionHandler                PointerMotionHandlerFunc
+	buttonHandler                PointerButtonHandlerFunc
+	axisHandler                  PointerAxisHandlerFunc
+	frameHandler                 PointerFrameHandlerFunc
+	axisSourceHandler            PointerAxisSourceHandlerFunc
+	axisStopHandler              PointerAxisStopHandlerFunc
+	axisDiscreteHandler          PointerAxisDiscreteHandlerFunc
+	axisValue120Handler          PointerAxisValue120HandlerFunc
+	axisRelativeDirectionHandler PointerAxisRelativeDirectionHandlerFunc
 }
 
 // NewPointer : pointer input device
@@ -4541,6 +6593,159 @@ func NewPointer(ctx *Context) *Pointer {
 	return wlPointer
 }
 
+// PointerInterface describes wl_pointer at runtime.
+var PointerInterface = &Interface{
+	Name:    "wl_pointer",
+	Version: 10,
+	New:     func() Proxy { return &Pointer{} },
+	Requests: []Message{
+		{
+			Name: "set_cursor",
+			Args: []Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "surface", Type: "object", Interface: "wl_surface", Nullable: true},
+				{Name: "hotspot_x", Type: "int"},
+				{Name: "hotspot_y", Type: "int"},
+			},
+		},
+		{
+			Name:       "release",
+			Since:      3,
+			Destructor: true,
+		},
+	},
+	Events: []Message{
+		{
+			Name: "enter",
+			Args: []Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+				{Name: "surface_x", Type: "fixed"},
+				{Name: "surface_y", Type: "fixed"},
+			},
+		},
+		{
+			Name: "leave",
+			Args: []Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+			},
+		},
+		{
+			Name: "motion",
+			Args: []Arg{
+				{Name: "time", Type: "uint"},
+				{Name: "surface_x", Type: "fixed"},
+				{Name: "surface_y", Type: "fixed"},
+			},
+		},
+		{
+			Name: "button",
+			Args: []Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "time", Type: "uint"},
+				{Name: "button", Type: "uint"},
+				{Name: "state", Type: "uint", Enum: "wl_pointer.button_state"},
+			},
+		},
+		{
+			Name: "axis",
+			Args: []Arg{
+				{Name: "time", Type: "uint"},
+				{Name: "axis", Type: "uint", Enum: "wl_pointer.axis"},
+				{Name: "value", Type: "fixed"},
+			},
+		},
+		{
+			Name:  "frame",
+			Since: 5,
+		},
+		{
+			Name:  "axis_source",
+			Since: 5,
+			Args: []Arg{
+				{Name: "axis_source", Type: "uint", Enum: "wl_pointer.axis_source"},
+			},
+		},
+		{
+			Name:  "axis_stop",
+			Since: 5,
+			Args: []Arg{
+				{Name: "time", Type: "uint"},
+				{Name: "axis", Type: "uint", Enum: "wl_pointer.axis"},
+			},
+		},
+		{
+			Name:            "axis_discrete",
+			Since:           5,
+			DeprecatedSince: 8,
+			Args: []Arg{
+				{Name: "axis", Type: "uint", Enum: "wl_pointer.axis"},
+				{Name: "discrete", Type: "int"},
+			},
+		},
+		{
+			Name:  "axis_value120",
+			Since: 8,
+			Args: []Arg{
+				{Name: "axis", Type: "uint", Enum: "wl_pointer.axis"},
+				{Name: "value_120", Type: "int"},
+			},
+		},
+		{
+			Name:  "axis_relative_direction",
+			Since: 9,
+			Args: []Arg{
+				{Name: "axis", Type: "uint", Enum: "wl_pointer.axis"},
+				{Name: "direction", Type: "uint", Enum: "wl_pointer.axis_relative_direction"},
+			},
+		},
+	},
+	Enums: []Enum{
+		{
+			Name: "error",
+			Entries: []EnumEntry{
+				{Name: "role", Value: 0},
+			},
+		},
+		{
+			Name: "button_state",
+			Entries: []EnumEntry{
+				{Name: "released", Value: 0},
+				{Name: "pressed", Value: 1},
+			},
+		},
+		{
+			Name: "axis",
+			Entries: []EnumEntry{
+				{Name: "vertical_scroll", Value: 0},
+				{Name: "horizontal_scroll", Value: 1},
+			},
+		},
+		{
+			Name: "axis_source",
+			Entries: []EnumEntry{
+				{Name: "wheel", Value: 0},
+				{Name: "finger", Value: 1},
+				{Name: "continuous", Value: 2},
+				{Name: "wheel_tilt", Value: 3, Since: 6},
+			},
+		},
+		{
+			Name: "axis_relative_direction",
+			Entries: []EnumEntry{
+				{Name: "identical", Value: 0},
+				{Name: "inverted", Value: 1},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wl_pointer.
+func (i *Pointer) Interface() *Interface {
+	return PointerInterface
+}
+
 // SetCursor : set the pointer surface
 //
 // Set the pointer surface, i.e., the surface that contains the
@@ -4617,6 +6822,9 @@ func (i *Pointer) SetCursor(serial uint32, surface *Surface, hotspotX, hotspotY
 // This request destroys the pointer proxy object, so clients must not call
 // wl_pointer_destroy() after using this request.
 func (i *Pointer) Release() error {
+	if v := i.Version(); v != 0 && v < 3 {
+		return &VersionError{Interface: "wl_pointer", Request: "release", Since: 3, Version: v}
+	}
 	defer i.Context().Unregister(i)
 	const opcode = 1
 	const _reqBufLen = 8
@@ -4630,6 +6838,16 @@ func (i *Pointer) Release() error {
 	return err
 }
 
+// Destroy sends release unless the version of the object is known to
+// predate it, in which case it only forgets the object.
+func (i *Pointer) Destroy() error {
+	if v := i.Version(); v != 0 && v < 3 {
+		i.Context().Unregister(i)
+		return nil
+	}
+	return i.Release()
+}
+
 type PointerError uint32
 
 // PointerError :
@@ -4802,6 +7020,45 @@ func (e PointerAxisSource) String() string {
 	return e.Name() + "=" + e.Value()
 }
 
+type PointerAxisRelativeDirection uint32
+
+// PointerAxisRelativeDirection : axis relative direction
+//
+// This specifies the direction of the physical motion that caused a
+// wl_pointer.axis event, relative to the wl_pointer.axis direction.
+const (
+	// PointerAxisRelativeDirectionIdentical : physical motion matches axis direction
+	PointerAxisRelativeDirectionIdentical PointerAxisRelativeDirection = 0
+	// PointerAxisRelativeDirectionInverted : physical motion is the inverse of the axis direction
+	PointerAxisRelativeDirectionInverted PointerAxisRelativeDirection = 1
+)
+
+func (e PointerAxisRelativeDirection) Name() string {
+	switch e {
+	case PointerAxisRelativeDirectionIdentical:
+		return "identical"
+	case PointerAxisRelativeDirectionInverted:
+		return "inverted"
+	default:
+		return ""
+	}
+}
+
+func (e PointerAxisRelativeDirection) Value() string {
+	switch e {
+	case PointerAxisRelativeDirectionIdentical:
+		return "0"
+	case PointerAxisRelativeDirectionInverted:
+		return "1"
+	default:
+		return ""
+	}
+}
+
+func (e PointerAxisRelativeDirection) String() string {
+	return e.Name() + "=" + e.Value()
+}
+
 // PointerEnterEvent : enter event
 //
 // Notification that this seat's pointer is focused on a certain
@@ -4823,6 +7080,26 @@ func (i *Pointer) SetEnterHandler(f PointerEnterHandlerFunc) {
 	i.enterHandler = f
 }
 
+// String formats the event as wl_pointer.enter(argument=value, ...).
+func (e PointerEnterEvent) String() string {
+	return "wl_pointer.enter(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", surface=" + ObjectString(e.Surface) +
+		", surface_x=" + strconv.FormatFloat(e.SurfaceX, 'g', -1, 64) +
+		", surface_y=" + strconv.FormatFloat(e.SurfaceY, 'g', -1, 64) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PointerEnterEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.String("surface", ObjectString(e.Surface)),
+		slog.Float64("surface_x", e.SurfaceX),
+		slog.Float64("surface_y", e.SurfaceY),
+	)
+}
+
 // PointerLeaveEvent : leave event
 //
 // Notification that this seat's pointer is no longer focused on
@@ -4841,6 +7118,22 @@ func (i *Pointer) SetLeaveHandler(f PointerLeaveHandlerFunc) {
 	i.leaveHandler = f
 }
 
+// String formats the event as wl_pointer.leave(argument=value, ...).
+func (e PointerLeaveEvent) String() string {
+	return "wl_pointer.leave(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", surface=" + ObjectString(e.Surface) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PointerLeaveEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.String("surface", ObjectString(e.Surface)),
+	)
+}
+
 // PointerMotionEvent : pointer motion event
 //
 // Notification of pointer location change. The arguments
@@ -4858,6 +7151,24 @@ func (i *Pointer) SetMotionHandler(f PointerMotionHandlerFunc) {
 	i.motionHandler = f
 }
 
+// String formats the event as wl_pointer.motion(argument=value, ...).
+func (e PointerMotionEvent) String() string {
+	return "wl_pointer.motion(" +
+		"time=" + strconv.FormatUint(uint64(e.Time), 10) +
+		", surface_x=" + strconv.FormatFloat(e.SurfaceX, 'g', -1, 64) +
+		", surface_y=" + strconv.FormatFloat(e.SurfaceY, 'g', -1, 64) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PointerMotionEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("time", uint64(e.Time)),
+		slog.Float64("surface_x", e.SurfaceX),
+		slog.Float64("surface_y", e.SurfaceY),
+	)
+}
+
 // PointerButtonEvent : pointer button event
 //
 // Mouse button click and release notifications.
@@ -4878,7 +7189,7 @@ type PointerButtonEvent struct {
 	Serial uint32
 	Time   uint32
 	Button uint32
-	State  uint32
+	State  PointerButtonState
 }
 type PointerButtonHandlerFunc func(PointerButtonEvent)
 
@@ -4887,6 +7198,26 @@ func (i *Pointer) SetButtonHandler(f PointerButtonHandlerFunc) {
 	i.buttonHandler = f
 }
 
+// String formats the event as wl_pointer.button(argument=value, ...).
+func (e PointerButtonEvent) String() string {
+	return "wl_pointer.button(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", time=" + strconv.FormatUint(uint64(e.Time), 10) +
+		", button=" + strconv.FormatUint(uint64(e.Button), 10) +
+		", state=" + EnumString(e.State.Name(), uint32(e.State)) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PointerButtonEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.Uint64("time", uint64(e.Time)),
+		slog.Uint64("button", uint64(e.Button)),
+		slog.String("state", EnumString(e.State.Name(), uint32(e.State))),
+	)
+}
+
 // PointerAxisEvent : axis event
 //
 // Scroll and other axis notifications.
@@ -4907,7 +7238,7 @@ func (i *Pointer) SetButtonHandler(f PointerButtonHandlerFunc) {
 // scroll distance.
 type PointerAxisEvent struct {
 	Time  uint32
-	Axis  uint32
+	Axis  PointerAxis
 	Value float64
 }
 type PointerAxisHandlerFunc func(PointerAxisEvent)
@@ -4917,6 +7248,24 @@ func (i *Pointer) SetAxisHandler(f PointerAxisHandlerFunc) {
 	i.axisHandler = f
 }
 
+// String formats the event as wl_pointer.axis(argument=value, ...).
+func (e PointerAxisEvent) String() string {
+	return "wl_pointer.axis(" +
+		"time=" + strconv.FormatUint(uint64(e.Time), 10) +
+		", axis=" + EnumString(e.Axis.Name(), uint32(e.Axis)) +
+		", value=" + strconv.FormatFloat(e.Value, 'g', -1, 64) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PointerAxisEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("time", uint64(e.Time)),
+		slog.String("axis", EnumString(e.Axis.Name(), uint32(e.Axis))),
+		slog.Float64("value", e.Value),
+	)
+}
+
 // PointerFrameEvent : end of a pointer event sequence
 //
 // Indicates the end of a set of events that logically belong together.
@@ -4961,6 +7310,16 @@ func (i *Pointer) SetFrameHandler(f PointerFrameHandlerFunc) {
 	i.frameHandler = f
 }
 
+// String formats the event as wl_pointer.frame(argument=value, ...).
+func (e PointerFrameEvent) String() string {
+	return "wl_pointer.frame()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PointerFrameEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // PointerAxisSourceEvent : axis source event
 //
 // Source information for scroll and other axes.
@@ -4989,7 +7348,7 @@ func (i *Pointer) SetFrameHandler(f PointerFrameHandlerFunc) {
 // The order of wl_pointer.axis_discrete and wl_pointer.axis_source is
 // not guaranteed.
 type PointerAxisSourceEvent struct {
-	AxisSource uint32
+	AxisSource PointerAxisSource
 }
 type PointerAxisSourceHandlerFunc func(PointerAxisSourceEvent)
 
@@ -4998,6 +7357,20 @@ func (i *Pointer) SetAxisSourceHandler(f PointerAxisSourceHandlerFunc) {
 	i.axisSourceHandler = f
 }
 
+// String formats the event as wl_pointer.axis_source(argument=value, ...).
+func (e PointerAxisSourceEvent) String() string {
+	return "wl_pointer.axis_source(" +
+		"axis_source=" + EnumString(e.AxisSource.Name(), uint32(e.AxisSource)) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PointerAxisSourceEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("axis_source", EnumString(e.AxisSource.Name(), uint32(e.AxisSource))),
+	)
+}
+
 // PointerAxisStopEvent : axis stop event
 //
 // Stop notification for scroll and other axes.
@@ -5016,7 +7389,7 @@ func (i *Pointer) SetAxisSourceHandler(f PointerAxisSourceHandlerFunc) {
 // preceding wl_pointer.axis event.
 type PointerAxisStopEvent struct {
 	Time uint32
-	Axis uint32
+	Axis PointerAxis
 }
 type PointerAxisStopHandlerFunc func(PointerAxisStopEvent)
 
@@ -5025,6 +7398,22 @@ func (i *Pointer) SetAxisStopHandler(f PointerAxisStopHandlerFunc) {
 	i.axisStopHandler = f
 }
 
+// String formats the event as wl_pointer.axis_stop(argument=value, ...).
+func (e PointerAxisStopEvent) String() string {
+	return "wl_pointer.axis_stop(" +
+		"time=" + strconv.FormatUint(uint64(e.Time), 10) +
+		", axis=" + EnumString(e.Axis.Name(), uint32(e.Axis)) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PointerAxisStopEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("time", uint64(e.Time)),
+		slog.String("axis", EnumString(e.Axis.Name(), uint32(e.Axis))),
+	)
+}
+
 // PointerAxisDiscreteEvent : axis click event
 //
 // Discrete step information for scroll and other axes.
@@ -5057,17 +7446,37 @@ func (i *Pointer) SetAxisStopHandler(f PointerAxisStopHandlerFunc) {
 //
 // The order of wl_pointer.axis_discrete and wl_pointer.axis_source is
 // not guaranteed.
+//
+// Deprecated: wl_pointer.axis_discrete is deprecated since version 8.
 type PointerAxisDiscreteEvent struct {
-	Axis     uint32
+	Axis     PointerAxis
 	Discrete int32
 }
 type PointerAxisDiscreteHandlerFunc func(PointerAxisDiscreteEvent)
 
 // SetAxisDiscreteHandler : sets handler for PointerAxisDiscreteEvent
+//
+// Deprecated: wl_pointer.axis_discrete is deprecated since version 8.
 func (i *Pointer) SetAxisDiscreteHandler(f PointerAxisDiscreteHandlerFunc) {
 	i.axisDiscreteHandler = f
 }
 
+// String formats the event as wl_pointer.axis_discrete(argument=value, ...).
+func (e PointerAxisDiscreteEvent) String() string {
+	return "wl_pointer.axis_discrete(" +
+		"axis=" + EnumString(e.Axis.Name(), uint32(e.Axis)) +
+		", discrete=" + strconv.FormatInt(int64(e.Discrete), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PointerAxisDiscreteEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("axis", EnumString(e.Axis.Name(), uint32(e.Axis))),
+		slog.Int64("discrete", int64(e.Discrete)),
+	)
+}
+
 // PointerAxisValue120Event : axis high-resolution scroll event
 //
 // Discrete high-resolution scroll information.
@@ -5092,7 +7501,7 @@ func (i *Pointer) SetAxisDiscreteHandler(f PointerAxisDiscreteHandlerFunc) {
 // The order of wl_pointer.axis_value120 and wl_pointer.axis_source is
 // not guaranteed.
 type PointerAxisValue120Event struct {
-	Axis     uint32
+	Axis     PointerAxis
 	Value120 int32
 }
 type PointerAxisValue120HandlerFunc func(PointerAxisValue120Event)
@@ -5102,6 +7511,154 @@ func (i *Pointer) SetAxisValue120Handler(f PointerAxisValue120HandlerFunc) {
 	i.axisValue120Handler = f
 }
 
+// String formats the event as wl_pointer.axis_value120(argument=value, ...).
+func (e PointerAxisValue120Event) String() string {
+	return "wl_pointer.axis_value120(" +
+		"axis=" + EnumString(e.Axis.Name(), uint32(e.Axis)) +
+		", value_120=" + strconv.FormatInt(int64(e.Value120), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PointerAxisValue120Event) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("axis", EnumString(e.Axis.Name(), uint32(e.Axis))),
+		slog.Int64("value_120", int64(e.Value120)),
+	)
+}
+
+// PointerAxisRelativeDirectionEvent : axis relative physical direction event
+//
+// Relative directional information of the entity causing the axis
+// motion.
+//
+// For a wl_pointer.axis event, the wl_pointer.axis_relative_direction
+// event specifies the movement direction of the entity causing the
+// wl_pointer.axis event. For example:
+// - if a user's fingers on a touchpad move down and this
+// causes a wl_pointer.axis vertical_scroll down event, the physical
+// direction is 'identical'
+// - if a user's fingers on a touchpad move down and this causes a
+// wl_pointer.axis vertical_scroll up scroll up event ('natural
+// scrolling'), the physical direction is 'inverted'.
+//
+// A client may use this information to adjust scroll motion of
+// components. Specifically, enabling natural scrolling causes the
+// content to change direction compared to traditional scrolling.
+//
+// This event is sent in the same wl_pointer.frame as the
+// wl_pointer.axis event and must not be sent more than once per frame
+// and axis.
+type PointerAxisRelativeDirectionEvent struct {
+	Axis      PointerAxis
+	Direction PointerAxisRelativeDirection
+}
+type PointerAxisRelativeDirectionHandlerFunc func(PointerAxisRelativeDirectionEvent)
+
+// SetAxisRelativeDirectionHandler : sets handler for PointerAxisRelativeDirectionEvent
+func (i *Pointer) SetAxisRelativeDirectionHandler(f PointerAxisRelativeDirectionHandlerFunc) {
+	i.axisRelativeDirectionHandler = f
+}
+
+// String formats the event as wl_pointer.axis_relative_direction(argument=value, ...).
+func (e PointerAxisRelativeDirectionEvent) String() string {
+	return "wl_pointer.axis_relative_direction(" +
+		"axis=" + EnumString(e.Axis.Name(), uint32(e.Axis)) +
+		", direction=" + EnumString(e.Direction.Name(), uint32(e.Direction)) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PointerAxisRelativeDirectionEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("axis", EnumString(e.Axis.Name(), uint32(e.Axis))),
+		slog.String("direction", EnumString(e.Direction.Name(), uint32(e.Direction))),
+	)
+}
+
+// PointerListener receives all the events of Pointer, embed
+// PointerNoopListener to implement only some of them.
+type PointerListener interface {
+	Enter(e PointerEnterEvent)
+	Leave(e PointerLeaveEvent)
+	Motion(e PointerMotionEvent)
+	Button(e PointerButtonEvent)
+	Axis(e PointerAxisEvent)
+	Frame(e PointerFrameEvent)
+	AxisSource(e PointerAxisSourceEvent)
+	AxisStop(e PointerAxisStopEvent)
+	AxisDiscrete(e PointerAxisDiscreteEvent)
+	AxisValue120(e PointerAxisValue120Event)
+	AxisRelativeDirection(e PointerAxisRelativeDirectionEvent)
+}
+
+// PointerNoopListener is a PointerListener ignoring
+// all the events, it closes the file descriptors they carry.
+type PointerNoopListener struct{}
+
+func (PointerNoopListener) Enter(PointerEnterEvent) {
+}
+
+func (PointerNoopListener) Leave(PointerLeaveEvent) {
+}
+
+func (PointerNoopListener) Motion(PointerMotionEvent) {
+}
+
+func (PointerNoopListener) Button(PointerButtonEvent) {
+}
+
+func (PointerNoopListener) Axis(PointerAxisEvent) {
+}
+
+func (PointerNoopListener) Frame(PointerFrameEvent) {
+}
+
+func (PointerNoopListener) AxisSource(PointerAxisSourceEvent) {
+}
+
+func (PointerNoopListener) AxisStop(PointerAxisStopEvent) {
+}
+
+func (PointerNoopListener) AxisDiscrete(PointerAxisDiscreteEvent) {
+}
+
+func (PointerNoopListener) AxisValue120(PointerAxisValue120Event) {
+}
+
+func (PointerNoopListener) AxisRelativeDirection(PointerAxisRelativeDirectionEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *Pointer) SetListener(l PointerListener) {
+	if l == nil {
+		i.enterHandler = nil
+		i.leaveHandler = nil
+		i.motionHandler = nil
+		i.buttonHandler = nil
+		i.axisHandler = nil
+		i.frameHandler = nil
+		i.axisSourceHandler = nil
+		i.axisStopHandler = nil
+		i.axisDiscreteHandler = nil
+		i.axisValue120Handler = nil
+		i.axisRelativeDirectionHandler = nil
+		return
+	}
+	i.enterHandler = l.Enter
+	i.leaveHandler = l.Leave
+	i.motionHandler = l.Motion
+	i.buttonHandler = l.Button
+	i.axisHandler = l.Axis
+	i.frameHandler = l.Frame
+	i.axisSourceHandler = l.AxisSource
+	i.axisStopHandler = l.AxisStop
+	i.axisDiscreteHandler = l.AxisDiscrete
+	i.axisValue120Handler = l.AxisValue120
+	i.axisRelativeDirectionHandler = l.AxisRelativeDirection
+}
+
 func (i *Pointer) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -5112,11 +7669,11 @@ func (i *Pointer) Dispatch(opcode uint32, fd int, data []byte) {
 		l := 0
 		e.Serial = Uint32(data[l : l+4])
 		l += 4
-		e.Surface = i.Context().GetProxy(Uint32(data[l : l+4])).(*Surface)
+		e.Surface, _ = i.Context().GetProxy(Uint32(data[l : l+4])).(*Surface)
 		l += 4
-		e.SurfaceX = Fixed(data[l : l+4])
+		e.SurfaceX = FixedFloat64(data[l : l+4])
 		l += 4
-		e.SurfaceY = Fixed(data[l : l+4])
+		e.SurfaceY = FixedFloat64(data[l : l+4])
 		l += 4
 
 		i.enterHandler(e)
@@ -5128,7 +7685,7 @@ func (i *Pointer) Dispatch(opcode uint32, fd int, data []byte) {
 		l := 0
 		e.Serial = Uint32(data[l : l+4])
 		l += 4
-		e.Surface = i.Context().GetProxy(Uint32(data[l : l+4])).(*Surface)
+		e.Surface, _ = i.Context().GetProxy(Uint32(data[l : l+4])).(*Surface)
 		l += 4
 
 		i.leaveHandler(e)
@@ -5140,9 +7697,9 @@ func (i *Pointer) Dispatch(opcode uint32, fd int, data []byte) {
 		l := 0
 		e.Time = Uint32(data[l : l+4])
 		l += 4
-		e.SurfaceX = Fixed(data[l : l+4])
+		e.SurfaceX = FixedFloat64(data[l : l+4])
 		l += 4
-		e.SurfaceY = Fixed(data[l : l+4])
+		e.SurfaceY = FixedFloat64(data[l : l+4])
 		l += 4
 
 		i.motionHandler(e)
@@ -5158,7 +7715,7 @@ func (i *Pointer) Dispatch(opcode uint32, fd int, data []byte) {
 		l += 4
 		e.Button = Uint32(data[l : l+4])
 		l += 4
-		e.State = Uint32(data[l : l+4])
+		e.State = PointerButtonState(Uint32(data[l : l+4]))
 		l += 4
 
 		i.buttonHandler(e)
@@ -5170,9 +7727,9 @@ func (i *Pointer) Dispatch(opcode uint32, fd int, data []byte) {
 		l := 0
 		e.Time = Uint32(data[l : l+4])
 		l += 4
-		e.Axis = Uint32(data[l : l+4])
+		e.Axis = PointerAxis(Uint32(data[l : l+4]))
 		l += 4
-		e.Value = Fixed(data[l : l+4])
+		e.Value = FixedFloat64(data[l : l+4])
 		l += 4
 
 		i.axisHandler(e)
@@ -5189,7 +7746,7 @@ func (i *Pointer) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e PointerAxisSourceEvent
 		l := 0
-		e.AxisSource = Uint32(data[l : l+4])
+		e.AxisSource = PointerAxisSource(Uint32(data[l : l+4]))
 		l += 4
 
 		i.axisSourceHandler(e)
@@ -5201,7 +7758,7 @@ func (i *Pointer) Dispatch(opcode uint32, fd int, data []byte) {
 		l := 0
 		e.Time = Uint32(data[l : l+4])
 		l += 4
-		e.Axis = Uint32(data[l : l+4])
+		e.Axis = PointerAxis(Uint32(data[l : l+4]))
 		l += 4
 
 		i.axisStopHandler(e)
@@ -5211,7 +7768,7 @@ func (i *Pointer) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e PointerAxisDiscreteEvent
 		l := 0
-		e.Axis = Uint32(data[l : l+4])
+		e.Axis = PointerAxis(Uint32(data[l : l+4]))
 		l += 4
 		e.Discrete = int32(Uint32(data[l : l+4]))
 		l += 4
@@ -5223,12 +7780,24 @@ func (i *Pointer) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e PointerAxisValue120Event
 		l := 0
-		e.Axis = Uint32(data[l : l+4])
+		e.Axis = PointerAxis(Uint32(data[l : l+4]))
 		l += 4
 		e.Value120 = int32(Uint32(data[l : l+4]))
 		l += 4
 
 		i.axisValue120Handler(e)
+	case 10:
+		if i.axisRelativeDirectionHandler == nil {
+			return
+		}
+		var e PointerAxisRelativeDirectionEvent
+		l := 0
+		e.Axis = PointerAxis(Uint32(data[l : l+4]))
+		l += 4
+		e.Direction = PointerAxisRelativeDirection(Uint32(data[l : l+4]))
+		l += 4
+
+		i.axisRelativeDirectionHandler(e)
 	}
 }
 
@@ -5256,8 +7825,99 @@ func NewKeyboard(ctx *Context) *Keyboard {
 	return wlKeyboard
 }
 
+// KeyboardInterface describes wl_keyboard at runtime.
+var KeyboardInterface = &Interface{
+	Name:    "wl_keyboard",
+	Version: 10,
+	New:     func() Proxy { return &Keyboard{} },
+	Requests: []Message{
+		{
+			Name:       "release",
+			Since:      3,
+			Destructor: true,
+		},
+	},
+	Events: []Message{
+		{
+			Name: "keymap",
+			Args: []Arg{
+				{Name: "format", Type: "uint", Enum: "wl_keyboard.keymap_format"},
+				{Name: "fd", Type: "fd"},
+				{Name: "size", Type: "uint"},
+			},
+		},
+		{
+			Name: "enter",
+			Args: []Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+				{Name: "keys", Type: "array"},
+			},
+		},
+		{
+			Name: "leave",
+			Args: []Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+			},
+		},
+		{
+			Name: "key",
+			Args: []Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "time", Type: "uint"},
+				{Name: "key", Type: "uint"},
+				{Name: "state", Type: "uint", Enum: "wl_keyboard.key_state"},
+			},
+		},
+		{
+			Name: "modifiers",
+			Args: []Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "mods_depressed", Type: "uint"},
+				{Name: "mods_latched", Type: "uint"},
+				{Name: "mods_locked", Type: "uint"},
+				{Name: "group", Type: "uint"},
+			},
+		},
+		{
+			Name:  "repeat_info",
+			Since: 4,
+			Args: []Arg{
+				{Name: "rate", Type: "int"},
+				{Name: "delay", Type: "int"},
+			},
+		},
+	},
+	Enums: []Enum{
+		{
+			Name: "keymap_format",
+			Entries: []EnumEntry{
+				{Name: "no_keymap", Value: 0},
+				{Name: "xkb_v1", Value: 1},
+			},
+		},
+		{
+			Name: "key_state",
+			Entries: []EnumEntry{
+				{Name: "released", Value: 0},
+				{Name: "pressed", Value: 1},
+				{Name: "repeated", Value: 2, Since: 10},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wl_keyboard.
+func (i *Keyboard) Interface() *Interface {
+	return KeyboardInterface
+}
+
 // Release : release the keyboard object
 func (i *Keyboard) Release() error {
+	if v := i.Version(); v != 0 && v < 3 {
+		return &VersionError{Interface: "wl_keyboard", Request: "release", Since: 3, Version: v}
+	}
 	defer i.Context().Unregister(i)
 	const opcode = 0
 	const _reqBufLen = 8
@@ -5271,6 +7931,16 @@ func (i *Keyboard) Release() error {
 	return err
 }
 
+// Destroy sends release unless the version of the object is known to
+// predate it, in which case it only forgets the object.
+func (i *Keyboard) Destroy() error {
+	if v := i.Version(); v != 0 && v < 3 {
+		i.Context().Unregister(i)
+		return nil
+	}
+	return i.Release()
+}
+
 type KeyboardKeymapFormat uint32
 
 // KeyboardKeymapFormat : keyboard mapping format
@@ -5320,6 +7990,8 @@ const (
 	KeyboardKeyStateReleased KeyboardKeyState = 0
 	// KeyboardKeyStatePressed : key is pressed
 	KeyboardKeyStatePressed KeyboardKeyState = 1
+	// KeyboardKeyStateRepeated : key was repeated
+	KeyboardKeyStateRepeated KeyboardKeyState = 2
 )
 
 func (e KeyboardKeyState) Name() string {
@@ -5328,6 +8000,8 @@ func (e KeyboardKeyState) Name() string {
 		return "released"
 	case KeyboardKeyStatePressed:
 		return "pressed"
+	case KeyboardKeyStateRepeated:
+		return "repeated"
 	default:
 		return ""
 	}
@@ -5339,6 +8013,8 @@ func (e KeyboardKeyState) Value() string {
 		return "0"
 	case KeyboardKeyStatePressed:
 		return "1"
+	case KeyboardKeyStateRepeated:
+		return "2"
 	default:
 		return ""
 	}
@@ -5357,7 +8033,7 @@ func (e KeyboardKeyState) String() string {
 // From version 7 onwards, the fd must be mapped with MAP_PRIVATE by
 // the recipient, as MAP_SHARED may fail.
 type KeyboardKeymapEvent struct {
-	Format uint32
+	Format KeyboardKeymapFormat
 	Fd     int
 	Size   uint32
 }
@@ -5368,6 +8044,24 @@ func (i *Keyboard) SetKeymapHandler(f KeyboardKeymapHandlerFunc) {
 	i.keymapHandler = f
 }
 
+// String formats the event as wl_keyboard.keymap(argument=value, ...).
+func (e KeyboardKeymapEvent) String() string {
+	return "wl_keyboard.keymap(" +
+		"format=" + EnumString(e.Format.Name(), uint32(e.Format)) +
+		", fd=" + strconv.Itoa(e.Fd) +
+		", size=" + strconv.FormatUint(uint64(e.Size), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e KeyboardKeymapEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("format", EnumString(e.Format.Name(), uint32(e.Format))),
+		slog.Int("fd", e.Fd),
+		slog.Uint64("size", uint64(e.Size)),
+	)
+}
+
 // KeyboardEnterEvent : enter event
 //
 // Notification that this seat's keyboard focus is on a certain
@@ -5378,7 +8072,7 @@ func (i *Keyboard) SetKeymapHandler(f KeyboardKeymapHandlerFunc) {
 type KeyboardEnterEvent struct {
 	Serial  uint32
 	Surface *Surface
-	Keys    []byte
+	Keys    []uint32
 }
 type KeyboardEnterHandlerFunc func(KeyboardEnterEvent)
 
@@ -5387,6 +8081,24 @@ func (i *Keyboard) SetEnterHandler(f KeyboardEnterHandlerFunc) {
 	i.enterHandler = f
 }
 
+// String formats the event as wl_keyboard.enter(argument=value, ...).
+func (e KeyboardEnterEvent) String() string {
+	return "wl_keyboard.enter(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", surface=" + ObjectString(e.Surface) +
+		", keys=" + fmt.Sprint(e.Keys) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e KeyboardEnterEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.String("surface", ObjectString(e.Surface)),
+		slog.Any("keys", e.Keys),
+	)
+}
+
 // KeyboardLeaveEvent : leave event
 //
 // Notification that this seat's keyboard focus is no longer on
@@ -5408,6 +8120,22 @@ func (i *Keyboard) SetLeaveHandler(f KeyboardLeaveHandlerFunc) {
 	i.leaveHandler = f
 }
 
+// String formats the event as wl_keyboard.leave(argument=value, ...).
+func (e KeyboardLeaveEvent) String() string {
+	return "wl_keyboard.leave(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", surface=" + ObjectString(e.Surface) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e KeyboardLeaveEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.String("surface", ObjectString(e.Surface)),
+	)
+}
+
 // KeyboardKeyEvent : key event
 //
 // A key was pressed or released.
@@ -5423,7 +8151,7 @@ type KeyboardKeyEvent struct {
 	Serial uint32
 	Time   uint32
 	Key    uint32
-	State  uint32
+	State  KeyboardKeyState
 }
 type KeyboardKeyHandlerFunc func(KeyboardKeyEvent)
 
@@ -5432,6 +8160,26 @@ func (i *Keyboard) SetKeyHandler(f KeyboardKeyHandlerFunc) {
 	i.keyHandler = f
 }
 
+// String formats the event as wl_keyboard.key(argument=value, ...).
+func (e KeyboardKeyEvent) String() string {
+	return "wl_keyboard.key(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", time=" + strconv.FormatUint(uint64(e.Time), 10) +
+		", key=" + strconv.FormatUint(uint64(e.Key), 10) +
+		", state=" + EnumString(e.State.Name(), uint32(e.State)) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e KeyboardKeyEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.Uint64("time", uint64(e.Time)),
+		slog.Uint64("key", uint64(e.Key)),
+		slog.String("state", EnumString(e.State.Name(), uint32(e.State))),
+	)
+}
+
 // KeyboardModifiersEvent : modifier and group state
 //
 // Notifies clients that the modifier and/or group state has
@@ -5450,6 +8198,28 @@ func (i *Keyboard) SetModifiersHandler(f KeyboardModifiersHandlerFunc) {
 	i.modifiersHandler = f
 }
 
+// String formats the event as wl_keyboard.modifiers(argument=value, ...).
+func (e KeyboardModifiersEvent) String() string {
+	return "wl_keyboard.modifiers(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", mods_depressed=" + strconv.FormatUint(uint64(e.ModsDepressed), 10) +
+		", mods_latched=" + strconv.FormatUint(uint64(e.ModsLatched), 10) +
+		", mods_locked=" + strconv.FormatUint(uint64(e.ModsLocked), 10) +
+		", group=" + strconv.FormatUint(uint64(e.Group), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e KeyboardModifiersEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.Uint64("mods_depressed", uint64(e.ModsDepressed)),
+		slog.Uint64("mods_latched", uint64(e.ModsLatched)),
+		slog.Uint64("mods_locked", uint64(e.ModsLocked)),
+		slog.Uint64("group", uint64(e.Group)),
+	)
+}
+
 // KeyboardRepeatInfoEvent : repeat rate and delay
 //
 // Informs the client about the keyboard's repeat rate and delay.
@@ -5475,6 +8245,76 @@ func (i *Keyboard) SetRepeatInfoHandler(f KeyboardRepeatInfoHandlerFunc) {
 	i.repeatInfoHandler = f
 }
 
+// String formats the event as wl_keyboard.repeat_info(argument=value, ...).
+func (e KeyboardRepeatInfoEvent) String() string {
+	return "wl_keyboard.repeat_info(" +
+		"rate=" + strconv.FormatInt(int64(e.Rate), 10) +
+		", delay=" + strconv.FormatInt(int64(e.Delay), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e KeyboardRepeatInfoEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int64("rate", int64(e.Rate)),
+		slog.Int64("delay", int64(e.Delay)),
+	)
+}
+
+// KeyboardListener receives all the events of Keyboard, embed
+// KeyboardNoopListener to implement only some of them.
+type KeyboardListener interface {
+	Keymap(e KeyboardKeymapEvent)
+	Enter(e KeyboardEnterEvent)
+	Leave(e KeyboardLeaveEvent)
+	Key(e KeyboardKeyEvent)
+	Modifiers(e KeyboardModifiersEvent)
+	RepeatInfo(e KeyboardRepeatInfoEvent)
+}
+
+// KeyboardNoopListener is a KeyboardListener ignoring
+// all the events, it closes the file descriptors they carry.
+type KeyboardNoopListener struct{}
+
+func (KeyboardNoopListener) Keymap(e KeyboardKeymapEvent) {
+	unix.Close(e.Fd)
+}
+
+func (KeyboardNoopListener) Enter(KeyboardEnterEvent) {
+}
+
+func (KeyboardNoopListener) Leave(KeyboardLeaveEvent) {
+}
+
+func (KeyboardNoopListener) Key(KeyboardKeyEvent) {
+}
+
+func (KeyboardNoopListener) Modifiers(KeyboardModifiersEvent) {
+}
+
+func (KeyboardNoopListener) RepeatInfo(KeyboardRepeatInfoEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *Keyboard) SetListener(l KeyboardListener) {
+	if l == nil {
+		i.keymapHandler = nil
+		i.enterHandler = nil
+		i.leaveHandler = nil
+		i.keyHandler = nil
+		i.modifiersHandler = nil
+		i.repeatInfoHandler = nil
+		return
+	}
+	i.keymapHandler = l.Keymap
+	i.enterHandler = l.Enter
+	i.leaveHandler = l.Leave
+	i.keyHandler = l.Key
+	i.modifiersHandler = l.Modifiers
+	i.repeatInfoHandler = l.RepeatInfo
+}
+
 func (i *Keyboard) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -5486,7 +8326,7 @@ func (i *Keyboard) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e KeyboardKeymapEvent
 		l := 0
-		e.Format = Uint32(data[l : l+4])
+		e.Format = KeyboardKeymapFormat(Uint32(data[l : l+4]))
 		l += 4
 		e.Fd = fd
 		e.Size = Uint32(data[l : l+4])
@@ -5501,13 +8341,12 @@ func (i *Keyboard) Dispatch(opcode uint32, fd int, data []byte) {
 		l := 0
 		e.Serial = Uint32(data[l : l+4])
 		l += 4
-		e.Surface = i.Context().GetProxy(Uint32(data[l : l+4])).(*Surface)
+		e.Surface, _ = i.Context().GetProxy(Uint32(data[l : l+4])).(*Surface)
 		l += 4
 		keysLen := int(Uint32(data[l : l+4]))
 		l += 4
-		e.Keys = make([]byte, keysLen)
-		copy(e.Keys, data[l:l+keysLen])
-		l += keysLen
+		e.Keys = Array[uint32](data[l : l+keysLen])
+		l += PaddedLen(keysLen)
 
 		i.enterHandler(e)
 	case 2:
@@ -5518,7 +8357,7 @@ func (i *Keyboard) Dispatch(opcode uint32, fd int, data []byte) {
 		l := 0
 		e.Serial = Uint32(data[l : l+4])
 		l += 4
-		e.Surface = i.Context().GetProxy(Uint32(data[l : l+4])).(*Surface)
+		e.Surface, _ = i.Context().GetProxy(Uint32(data[l : l+4])).(*Surface)
 		l += 4
 
 		i.leaveHandler(e)
@@ -5534,7 +8373,7 @@ func (i *Keyboard) Dispatch(opcode uint32, fd int, data []byte) {
 		l += 4
 		e.Key = Uint32(data[l : l+4])
 		l += 4
-		e.State = Uint32(data[l : l+4])
+		e.State = KeyboardKeyState(Uint32(data[l : l+4]))
 		l += 4
 
 		i.keyHandler(e)
@@ -5608,8 +8447,83 @@ func NewTouch(ctx *Context) *Touch {
 	return wlTouch
 }
 
+// TouchInterface describes wl_touch at runtime.
+var TouchInterface = &Interface{
+	Name:    "wl_touch",
+	Version: 10,
+	New:     func() Proxy { return &Touch{} },
+	Requests: []Message{
+		{
+			Name:       "release",
+			Since:      3,
+			Destructor: true,
+		},
+	},
+	Events: []Message{
+		{
+			Name: "down",
+			Args: []Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "time", Type: "uint"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+				{Name: "id", Type: "int"},
+				{Name: "x", Type: "fixed"},
+				{Name: "y", Type: "fixed"},
+			},
+		},
+		{
+			Name: "up",
+			Args: []Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "time", Type: "uint"},
+				{Name: "id", Type: "int"},
+			},
+		},
+		{
+			Name: "motion",
+			Args: []Arg{
+				{Name: "time", Type: "uint"},
+				{Name: "id", Type: "int"},
+				{Name: "x", Type: "fixed"},
+				{Name: "y", Type: "fixed"},
+			},
+		},
+		{
+			Name: "frame",
+		},
+		{
+			Name: "cancel",
+		},
+		{
+			Name:  "shape",
+			Since: 6,
+			Args: []Arg{
+				{Name: "id", Type: "int"},
+				{Name: "major", Type: "fixed"},
+				{Name: "minor", Type: "fixed"},
+			},
+		},
+		{
+			Name:  "orientation",
+			Since: 6,
+			Args: []Arg{
+				{Name: "id", Type: "int"},
+				{Name: "orientation", Type: "fixed"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wl_touch.
+func (i *Touch) Interface() *Interface {
+	return TouchInterface
+}
+
 // Release : release the touch object
 func (i *Touch) Release() error {
+	if v := i.Version(); v != 0 && v < 3 {
+		return &VersionError{Interface: "wl_touch", Request: "release", Since: 3, Version: v}
+	}
 	defer i.Context().Unregister(i)
 	const opcode = 0
 	const _reqBufLen = 8
@@ -5623,6 +8537,16 @@ func (i *Touch) Release() error {
 	return err
 }
 
+// Destroy sends release unless the version of the object is known to
+// predate it, in which case it only forgets the object.
+func (i *Touch) Destroy() error {
+	if v := i.Version(); v != 0 && v < 3 {
+		i.Context().Unregister(i)
+		return nil
+	}
+	return i.Release()
+}
+
 // TouchDownEvent : touch down event and beginning of a touch sequence
 //
 // A new touch point has appeared on the surface. This touch point is
@@ -5644,6 +8568,30 @@ func (i *Touch) SetDownHandler(f TouchDownHandlerFunc) {
 	i.downHandler = f
 }
 
+// String formats the event as wl_touch.down(argument=value, ...).
+func (e TouchDownEvent) String() string {
+	return "wl_touch.down(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", time=" + strconv.FormatUint(uint64(e.Time), 10) +
+		", surface=" + ObjectString(e.Surface) +
+		", id=" + strconv.FormatInt(int64(e.Id), 10) +
+		", x=" + strconv.FormatFloat(e.X, 'g', -1, 64) +
+		", y=" + strconv.FormatFloat(e.Y, 'g', -1, 64) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TouchDownEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.Uint64("time", uint64(e.Time)),
+		slog.String("surface", ObjectString(e.Surface)),
+		slog.Int64("id", int64(e.Id)),
+		slog.Float64("x", e.X),
+		slog.Float64("y", e.Y),
+	)
+}
+
 // TouchUpEvent : end of a touch event sequence
 //
 // The touch point has disappeared. No further events will be sent for
@@ -5661,6 +8609,24 @@ func (i *Touch) SetUpHandler(f TouchUpHandlerFunc) {
 	i.upHandler = f
 }
 
+// String formats the event as wl_touch.up(argument=value, ...).
+func (e TouchUpEvent) String() string {
+	return "wl_touch.up(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", time=" + strconv.FormatUint(uint64(e.Time), 10) +
+		", id=" + strconv.FormatInt(int64(e.Id), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TouchUpEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.Uint64("time", uint64(e.Time)),
+		slog.Int64("id", int64(e.Id)),
+	)
+}
+
 // TouchMotionEvent : update of touch point coordinates
 //
 // A touch point has changed coordinates.
@@ -5677,6 +8643,26 @@ func (i *Touch) SetMotionHandler(f TouchMotionHandlerFunc) {
 	i.motionHandler = f
 }
 
+// String formats the event as wl_touch.motion(argument=value, ...).
+func (e TouchMotionEvent) String() string {
+	return "wl_touch.motion(" +
+		"time=" + strconv.FormatUint(uint64(e.Time), 10) +
+		", id=" + strconv.FormatInt(int64(e.Id), 10) +
+		", x=" + strconv.FormatFloat(e.X, 'g', -1, 64) +
+		", y=" + strconv.FormatFloat(e.Y, 'g', -1, 64) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TouchMotionEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("time", uint64(e.Time)),
+		slog.Int64("id", int64(e.Id)),
+		slog.Float64("x", e.X),
+		slog.Float64("y", e.Y),
+	)
+}
+
 // TouchFrameEvent : end of touch frame event
 //
 // Indicates the end of a set of events that logically belong together.
@@ -5695,6 +8681,16 @@ func (i *Touch) SetFrameHandler(f TouchFrameHandlerFunc) {
 	i.frameHandler = f
 }
 
+// String formats the event as wl_touch.frame(argument=value, ...).
+func (e TouchFrameEvent) String() string {
+	return "wl_touch.frame()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TouchFrameEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // TouchCancelEvent : touch session cancelled
 //
 // Sent if the compositor decides the touch stream is a global
@@ -5711,6 +8707,16 @@ func (i *Touch) SetCancelHandler(f TouchCancelHandlerFunc) {
 	i.cancelHandler = f
 }
 
+// String formats the event as wl_touch.cancel(argument=value, ...).
+func (e TouchCancelEvent) String() string {
+	return "wl_touch.cancel()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TouchCancelEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // TouchShapeEvent : update shape of touch point
 //
 // Sent when a touchpoint has changed its shape.
@@ -5750,6 +8756,24 @@ func (i *Touch) SetShapeHandler(f TouchShapeHandlerFunc) {
 	i.shapeHandler = f
 }
 
+// String formats the event as wl_touch.shape(argument=value, ...).
+func (e TouchShapeEvent) String() string {
+	return "wl_touch.shape(" +
+		"id=" + strconv.FormatInt(int64(e.Id), 10) +
+		", major=" + strconv.FormatFloat(e.Major, 'g', -1, 64) +
+		", minor=" + strconv.FormatFloat(e.Minor, 'g', -1, 64) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TouchShapeEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int64("id", int64(e.Id)),
+		slog.Float64("major", e.Major),
+		slog.Float64("minor", e.Minor),
+	)
+}
+
 // TouchOrientationEvent : update orientation of touch point
 //
 // Sent when a touchpoint has changed its orientation.
@@ -5786,6 +8810,81 @@ func (i *Touch) SetOrientationHandler(f TouchOrientationHandlerFunc) {
 	i.orientationHandler = f
 }
 
+// String formats the event as wl_touch.orientation(argument=value, ...).
+func (e TouchOrientationEvent) String() string {
+	return "wl_touch.orientation(" +
+		"id=" + strconv.FormatInt(int64(e.Id), 10) +
+		", orientation=" + strconv.FormatFloat(e.Orientation, 'g', -1, 64) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TouchOrientationEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int64("id", int64(e.Id)),
+		slog.Float64("orientation", e.Orientation),
+	)
+}
+
+// TouchListener receives all the events of Touch, embed
+// TouchNoopListener to implement only some of them.
+type TouchListener interface {
+	Down(e TouchDownEvent)
+	Up(e TouchUpEvent)
+	Motion(e TouchMotionEvent)
+	Frame(e TouchFrameEvent)
+	Cancel(e TouchCancelEvent)
+	Shape(e TouchShapeEvent)
+	Orientation(e TouchOrientationEvent)
+}
+
+// TouchNoopListener is a TouchListener ignoring
+// all the events, it closes the file descriptors they carry.
+type TouchNoopListener struct{}
+
+func (TouchNoopListener) Down(TouchDownEvent) {
+}
+
+func (TouchNoopListener) Up(TouchUpEvent) {
+}
+
+func (TouchNoopListener) Motion(TouchMotionEvent) {
+}
+
+func (TouchNoopListener) Frame(TouchFrameEvent) {
+}
+
+func (TouchNoopListener) Cancel(TouchCancelEvent) {
+}
+
+func (TouchNoopListener) Shape(TouchShapeEvent) {
+}
+
+func (TouchNoopListener) Orientation(TouchOrientationEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *Touch) SetListener(l TouchListener) {
+	if l == nil {
+		i.downHandler = nil
+		i.upHandler = nil
+		i.motionHandler = nil
+		i.frameHandler = nil
+		i.cancelHandler = nil
+		i.shapeHandler = nil
+		i.orientationHandler = nil
+		return
+	}
+	i.downHandler = l.Down
+	i.upHandler = l.Up
+	i.motionHandler = l.Motion
+	i.frameHandler = l.Frame
+	i.cancelHandler = l.Cancel
+	i.shapeHandler = l.Shape
+	i.orientationHandler = l.Orientation
+}
+
 func (i *Touch) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -5798,13 +8897,13 @@ func (i *Touch) Dispatch(opcode uint32, fd int, data []byte) {
 		l += 4
 		e.Time = Uint32(data[l : l+4])
 		l += 4
-		e.Surface = i.Context().GetProxy(Uint32(data[l : l+4])).(*Surface)
+		e.Surface, _ = i.Context().GetProxy(Uint32(data[l : l+4])).(*Surface)
 		l += 4
 		e.Id = int32(Uint32(data[l : l+4]))
 		l += 4
-		e.X = Fixed(data[l : l+4])
+		e.X = FixedFloat64(data[l : l+4])
 		l += 4
-		e.Y = Fixed(data[l : l+4])
+		e.Y = FixedFloat64(data[l : l+4])
 		l += 4
 
 		i.downHandler(e)
@@ -5832,9 +8931,9 @@ func (i *Touch) Dispatch(opcode uint32, fd int, data []byte) {
 		l += 4
 		e.Id = int32(Uint32(data[l : l+4]))
 		l += 4
-		e.X = Fixed(data[l : l+4])
+		e.X = FixedFloat64(data[l : l+4])
 		l += 4
-		e.Y = Fixed(data[l : l+4])
+		e.Y = FixedFloat64(data[l : l+4])
 		l += 4
 
 		i.motionHandler(e)
@@ -5860,9 +8959,9 @@ func (i *Touch) Dispatch(opcode uint32, fd int, data []byte) {
 		l := 0
 		e.Id = int32(Uint32(data[l : l+4]))
 		l += 4
-		e.Major = Fixed(data[l : l+4])
+		e.Major = FixedFloat64(data[l : l+4])
 		l += 4
-		e.Minor = Fixed(data[l : l+4])
+		e.Minor = FixedFloat64(data[l : l+4])
 		l += 4
 
 		i.shapeHandler(e)
@@ -5874,7 +8973,7 @@ func (i *Touch) Dispatch(opcode uint32, fd int, data []byte) {
 		l := 0
 		e.Id = int32(Uint32(data[l : l+4]))
 		l += 4
-		e.Orientation = Fixed(data[l : l+4])
+		e.Orientation = FixedFloat64(data[l : l+4])
 		l += 4
 
 		i.orientationHandler(e)
@@ -5913,11 +9012,116 @@ func NewOutput(ctx *Context) *Output {
 	return wlOutput
 }
 
+// OutputInterface describes wl_output at runtime.
+var OutputInterface = &Interface{
+	Name:    "wl_output",
+	Version: 4,
+	New:     func() Proxy { return &Output{} },
+	Requests: []Message{
+		{
+			Name:       "release",
+			Since:      3,
+			Destructor: true,
+		},
+	},
+	Events: []Message{
+		{
+			Name: "geometry",
+			Args: []Arg{
+				{Name: "x", Type: "int"},
+				{Name: "y", Type: "int"},
+				{Name: "physical_width", Type: "int"},
+				{Name: "physical_height", Type: "int"},
+				{Name: "subpixel", Type: "int", Enum: "wl_output.subpixel"},
+				{Name: "make", Type: "string"},
+				{Name: "model", Type: "string"},
+				{Name: "transform", Type: "int", Enum: "wl_output.transform"},
+			},
+		},
+		{
+			Name: "mode",
+			Args: []Arg{
+				{Name: "flags", Type: "uint", Enum: "wl_output.mode"},
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+				{Name: "refresh", Type: "int"},
+			},
+		},
+		{
+			Name:  "done",
+			Since: 2,
+		},
+		{
+			Name:  "scale",
+			Since: 2,
+			Args: []Arg{
+				{Name: "factor", Type: "int"},
+			},
+		},
+		{
+			Name:  "name",
+			Since: 4,
+			Args: []Arg{
+				{Name: "name", Type: "string"},
+			},
+		},
+		{
+			Name:  "description",
+			Since: 4,
+			Args: []Arg{
+				{Name: "description", Type: "string"},
+			},
+		},
+	},
+	Enums: []Enum{
+		{
+			Name: "subpixel",
+			Entries: []EnumEntry{
+				{Name: "unknown", Value: 0},
+				{Name: "none", Value: 1},
+				{Name: "horizontal_rgb", Value: 2},
+				{Name: "horizontal_bgr", Value: 3},
+				{Name: "vertical_rgb", Value: 4},
+				{Name: "vertical_bgr", Value: 5},
+			},
+		},
+		{
+			Name: "transform",
+			Entries: []EnumEntry{
+				{Name: "normal", Value: 0},
+				{Name: "90", Value: 1},
+				{Name: "180", Value: 2},
+				{Name: "270", Value: 3},
+				{Name: "flipped", Value: 4},
+				{Name: "flipped_90", Value: 5},
+				{Name: "flipped_180", Value: 6},
+				{Name: "flipped_270", Value: 7},
+			},
+		},
+		{
+			Name:     "mode",
+			Bitfield: true,
+			Entries: []EnumEntry{
+				{Name: "current", Value: 0x1},
+				{Name: "preferred", Value: 0x2},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wl_output.
+func (i *Output) Interface() *Interface {
+	return OutputInterface
+}
+
 // Release : release the output object
 //
 // Using this request a client can tell the server that it is not going to
 // use the output object anymore.
 func (i *Output) Release() error {
+	if v := i.Version(); v != 0 && v < 3 {
+		return &VersionError{Interface: "wl_output", Request: "release", Since: 3, Version: v}
+	}
 	defer i.Context().Unregister(i)
 	const opcode = 0
 	const _reqBufLen = 8
@@ -5931,6 +9135,16 @@ func (i *Output) Release() error {
 	return err
 }
 
+// Destroy sends release unless the version of the object is known to
+// predate it, in which case it only forgets the object.
+func (i *Output) Destroy() error {
+	if v := i.Version(); v != 0 && v < 3 {
+		i.Context().Unregister(i)
+		return nil
+	}
+	return i.Release()
+}
+
 type OutputSubpixel uint32
 
 // OutputSubpixel : subpixel geometry information
@@ -6113,8 +9327,29 @@ func (e OutputMode) Value() string {
 	}
 }
 
+func (e OutputMode) Has(f OutputMode) bool {
+	return e&f == f
+}
+
+func (e OutputMode) Set(f OutputMode) OutputMode {
+	return e | f
+}
+
 func (e OutputMode) String() string {
-	return e.Name() + "=" + e.Value()
+	if e == 0 {
+		return "0"
+	}
+	var names []string
+	for _, f := range []OutputMode{OutputModeCurrent, OutputModePreferred} {
+		if e.Has(f) {
+			names = append(names, f.Name())
+			e &^= f
+		}
+	}
+	if e != 0 {
+		names = append(names, "0x"+strconv.FormatUint(uint64(e), 16))
+	}
+	return strings.Join(names, "|")
 }
 
 // OutputGeometryEvent : properties of the output
@@ -6140,10 +9375,10 @@ type OutputGeometryEvent struct {
 	Y              int32
 	PhysicalWidth  int32
 	PhysicalHeight int32
-	Subpixel       int32
+	Subpixel       OutputSubpixel
 	Make           string
 	Model          string
-	Transform      int32
+	Transform      OutputTransform
 }
 type OutputGeometryHandlerFunc func(OutputGeometryEvent)
 
@@ -6152,6 +9387,34 @@ func (i *Output) SetGeometryHandler(f OutputGeometryHandlerFunc) {
 	i.geometryHandler = f
 }
 
+// String formats the event as wl_output.geometry(argument=value, ...).
+func (e OutputGeometryEvent) String() string {
+	return "wl_output.geometry(" +
+		"x=" + strconv.FormatInt(int64(e.X), 10) +
+		", y=" + strconv.FormatInt(int64(e.Y), 10) +
+		", physical_width=" + strconv.FormatInt(int64(e.PhysicalWidth), 10) +
+		", physical_height=" + strconv.FormatInt(int64(e.PhysicalHeight), 10) +
+		", subpixel=" + EnumString(e.Subpixel.Name(), uint32(e.Subpixel)) +
+		", make=" + strconv.Quote(e.Make) +
+		", model=" + strconv.Quote(e.Model) +
+		", transform=" + EnumString(e.Transform.Name(), uint32(e.Transform)) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e OutputGeometryEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int64("x", int64(e.X)),
+		slog.Int64("y", int64(e.Y)),
+		slog.Int64("physical_width", int64(e.PhysicalWidth)),
+		slog.Int64("physical_height", int64(e.PhysicalHeight)),
+		slog.String("subpixel", EnumString(e.Subpixel.Name(), uint32(e.Subpixel))),
+		slog.String("make", e.Make),
+		slog.String("model", e.Model),
+		slog.String("transform", EnumString(e.Transform.Name(), uint32(e.Transform))),
+	)
+}
+
 // OutputModeEvent : advertise available modes for the output
 //
 // The mode event describes an available mode for the output.
@@ -6188,7 +9451,7 @@ func (i *Output) SetGeometryHandler(f OutputGeometryHandlerFunc) {
 // compositors, such as those exposing virtual outputs, might fake the
 // refresh rate or the size.
 type OutputModeEvent struct {
-	Flags   uint32
+	Flags   OutputMode
 	Width   int32
 	Height  int32
 	Refresh int32
@@ -6200,6 +9463,26 @@ func (i *Output) SetModeHandler(f OutputModeHandlerFunc) {
 	i.modeHandler = f
 }
 
+// String formats the event as wl_output.mode(argument=value, ...).
+func (e OutputModeEvent) String() string {
+	return "wl_output.mode(" +
+		"flags=" + e.Flags.String() +
+		", width=" + strconv.FormatInt(int64(e.Width), 10) +
+		", height=" + strconv.FormatInt(int64(e.Height), 10) +
+		", refresh=" + strconv.FormatInt(int64(e.Refresh), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e OutputModeEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("flags", e.Flags.String()),
+		slog.Int64("width", int64(e.Width)),
+		slog.Int64("height", int64(e.Height)),
+		slog.Int64("refresh", int64(e.Refresh)),
+	)
+}
+
 // OutputDoneEvent : sent all information about output
 //
 // This event is sent after all other properties have been
@@ -6215,6 +9498,16 @@ func (i *Output) SetDoneHandler(f OutputDoneHandlerFunc) {
 	i.doneHandler = f
 }
 
+// String formats the event as wl_output.done(argument=value, ...).
+func (e OutputDoneEvent) String() string {
+	return "wl_output.done()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e OutputDoneEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // OutputScaleEvent : output scaling properties
 //
 // This event contains scaling geometry information
@@ -6247,6 +9540,20 @@ func (i *Output) SetScaleHandler(f OutputScaleHandlerFunc) {
 	i.scaleHandler = f
 }
 
+// String formats the event as wl_output.scale(argument=value, ...).
+func (e OutputScaleEvent) String() string {
+	return "wl_output.scale(" +
+		"factor=" + strconv.FormatInt(int64(e.Factor), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e OutputScaleEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int64("factor", int64(e.Factor)),
+	)
+}
+
 // OutputNameEvent : name of this output
 //
 // Many compositors will assign user-friendly names to their outputs, show
@@ -6287,6 +9594,20 @@ func (i *Output) SetNameHandler(f OutputNameHandlerFunc) {
 	i.nameHandler = f
 }
 
+// String formats the event as wl_output.name(argument=value, ...).
+func (e OutputNameEvent) String() string {
+	return "wl_output.name(" +
+		"name=" + strconv.Quote(e.Name) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e OutputNameEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("name", e.Name),
+	)
+}
+
 // OutputDescriptionEvent : human-readable description of this output
 //
 // Many compositors can produce human-readable descriptions of their
@@ -6313,6 +9634,73 @@ func (i *Output) SetDescriptionHandler(f OutputDescriptionHandlerFunc) {
 	i.descriptionHandler = f
 }
 
+// String formats the event as wl_output.description(argument=value, ...).
+func (e OutputDescriptionEvent) String() string {
+	return "wl_output.description(" +
+		"description=" + strconv.Quote(e.Description) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e OutputDescriptionEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("description", e.Description),
+	)
+}
+
+// OutputListener receives all the events of Output, embed
+// OutputNoopListener to implement only some of them.
+type OutputListener interface {
+	Geometry(e OutputGeometryEvent)
+	Mode(e OutputModeEvent)
+	Done(e OutputDoneEvent)
+	Scale(e OutputScaleEvent)
+	Name(e OutputNameEvent)
+	Description(e OutputDescriptionEvent)
+}
+
+// OutputNoopListener is a OutputListener ignoring
+// all the events, it closes the file descriptors they carry.
+type OutputNoopListener struct{}
+
+func (OutputNoopListener) Geometry(OutputGeometryEvent) {
+}
+
+func (OutputNoopListener) Mode(OutputModeEvent) {
+}
+
+func (OutputNoopListener) Done(OutputDoneEvent) {
+}
+
+func (OutputNoopListener) Scale(OutputScaleEvent) {
+}
+
+func (OutputNoopListener) Name(OutputNameEvent) {
+}
+
+func (OutputNoopListener) Description(OutputDescriptionEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *Output) SetListener(l OutputListener) {
+	if l == nil {
+		i.geometryHandler = nil
+		i.modeHandler = nil
+		i.doneHandler = nil
+		i.scaleHandler = nil
+		i.nameHandler = nil
+		i.descriptionHandler = nil
+		return
+	}
+	i.geometryHandler = l.Geometry
+	i.modeHandler = l.Mode
+	i.doneHandler = l.Done
+	i.scaleHandler = l.Scale
+	i.nameHandler = l.Name
+	i.descriptionHandler = l.Description
+}
+
 func (i *Output) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -6329,7 +9717,7 @@ func (i *Output) Dispatch(opcode uint32, fd int, data []byte) {
 		l += 4
 		e.PhysicalHeight = int32(Uint32(data[l : l+4]))
 		l += 4
-		e.Subpixel = int32(Uint32(data[l : l+4]))
+		e.Subpixel = OutputSubpixel(Uint32(data[l : l+4]))
 		l += 4
 		makeLen := PaddedLen(int(Uint32(data[l : l+4])))
 		l += 4
@@ -6339,7 +9727,7 @@ func (i *Output) Dispatch(opcode uint32, fd int, data []byte) {
 		l += 4
 		e.Model = String(data[l : l+modelLen])
 		l += modelLen
-		e.Transform = int32(Uint32(data[l : l+4]))
+		e.Transform = OutputTransform(Uint32(data[l : l+4]))
 		l += 4
 
 		i.geometryHandler(e)
@@ -6349,7 +9737,7 @@ func (i *Output) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e OutputModeEvent
 		l := 0
-		e.Flags = Uint32(data[l : l+4])
+		e.Flags = OutputMode(Uint32(data[l : l+4]))
 		l += 4
 		e.Width = int32(Uint32(data[l : l+4]))
 		l += 4
@@ -6425,6 +9813,42 @@ func NewRegion(ctx *Context) *Region {
 	return wlRegion
 }
 
+// RegionInterface describes wl_region at runtime.
+var RegionInterface = &Interface{
+	Name:    "wl_region",
+	Version: 1,
+	New:     func() Proxy { return &Region{} },
+	Requests: []Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "add",
+			Args: []Arg{
+				{Name: "x", Type: "int"},
+				{Name: "y", Type: "int"},
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+			},
+		},
+		{
+			Name: "subtract",
+			Args: []Arg{
+				{Name: "x", Type: "int"},
+				{Name: "y", Type: "int"},
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wl_region.
+func (i *Region) Interface() *Interface {
+	return RegionInterface
+}
+
 // Destroy : destroy region
 //
 // Destroy the region.  This will invalidate the object ID.
@@ -6552,6 +9976,40 @@ func NewSubcompositor(ctx *Context) *Subcompositor {
 	return wlSubcompositor
 }
 
+// SubcompositorInterface describes wl_subcompositor at runtime.
+var SubcompositorInterface = &Interface{
+	Name:    "wl_subcompositor",
+	Version: 1,
+	New:     func() Proxy { return &Subcompositor{} },
+	Requests: []Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "get_subsurface",
+			Args: []Arg{
+				{Name: "id", Type: "new_id", Interface: "wl_subsurface"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+				{Name: "parent", Type: "object", Interface: "wl_surface"},
+			},
+		},
+	},
+	Enums: []Enum{
+		{
+			Name: "error",
+			Entries: []EnumEntry{
+				{Name: "bad_surface", Value: 0},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wl_subcompositor.
+func (i *Subcompositor) Interface() *Interface {
+	return SubcompositorInterface
+}
+
 // Destroy : unbind from the subcompositor interface
 //
 // Informs the server that the client will not be using this
@@ -6593,6 +10051,7 @@ func (i *Subcompositor) Destroy() error {
 //	parent: the parent surface
 func (i *Subcompositor) GetSubsurface(surface, parent *Surface) (*Subsurface, error) {
 	id := NewSubsurface(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -6753,6 +10212,57 @@ func NewSubsurface(ctx *Context) *Subsurface {
 	return wlSubsurface
 }
 
+// SubsurfaceInterface describes wl_subsurface at runtime.
+var SubsurfaceInterface = &Interface{
+	Name:    "wl_subsurface",
+	Version: 1,
+	New:     func() Proxy { return &Subsurface{} },
+	Requests: []Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "set_position",
+			Args: []Arg{
+				{Name: "x", Type: "int"},
+				{Name: "y", Type: "int"},
+			},
+		},
+		{
+			Name: "place_above",
+			Args: []Arg{
+				{Name: "sibling", Type: "object", Interface: "wl_surface"},
+			},
+		},
+		{
+			Name: "place_below",
+			Args: []Arg{
+				{Name: "sibling", Type: "object", Interface: "wl_surface"},
+			},
+		},
+		{
+			Name: "set_sync",
+		},
+		{
+			Name: "set_desync",
+		},
+	},
+	Enums: []Enum{
+		{
+			Name: "error",
+			Entries: []EnumEntry{
+				{Name: "bad_surface", Value: 0},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wl_subsurface.
+func (i *Subsurface) Interface() *Interface {
+	return SubsurfaceInterface
+}
+
 // Destroy : remove sub-surface interface
 //
 // The sub-surface interface is removed from the wl_surface object
@@ -6958,3 +10468,114 @@ func (e SubsurfaceError) Value() string {
 func (e SubsurfaceError) String() string {
 	return e.Name() + "=" + e.Value()
 }
+
+// Fixes : wayland protocol fixes
+//
+// This global fixes problems with other core-protocol interfaces that
+// cannot be fixed in these interfaces themselves.
+type Fixes struct {
+	BaseProxy
+}
+
+// NewFixes : wayland protocol fixes
+//
+// This global fixes problems with other core-protocol interfaces that
+// cannot be fixed in these interfaces themselves.
+func NewFixes(ctx *Context) *Fixes {
+	wlFixes := &Fixes{}
+	ctx.Register(wlFixes)
+	return wlFixes
+}
+
+// FixesInterface describes wl_fixes at runtime.
+var FixesInterface = &Interface{
+	Name:    "wl_fixes",
+	Version: 1,
+	New:     func() Proxy { return &Fixes{} },
+	Requests: []Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "destroy_registry",
+			Args: []Arg{
+				{Name: "registry", Type: "object", Interface: "wl_registry"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wl_fixes.
+func (i *Fixes) Interface() *Interface {
+	return FixesInterface
+}
+
+// Destroy : destroys this object
+func (i *Fixes) Destroy() error {
+	defer i.Context().Unregister(i)
+	const opcode = 0
+	const _reqBufLen = 8
+	var _reqBuf [_reqBufLen]byte
+	l := 0
+	PutUint32(_reqBuf[l:4], i.ID())
+	l += 4
+	PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
+	l += 4
+	err := i.Context().WriteMsg(_reqBuf[:], nil)
+	return err
+}
+
+// DestroyRegistry : destroy a wl_registry
+//
+// This request destroys a wl_registry object.
+//
+// The client should no longer use the wl_registry after making this
+// request.
+//
+// The compositor will emit a wl_display.delete_id event with the object ID
+// of the registry and will no longer emit any events on the registry. The
+// client should re-use the object ID once it receives the
+// wl_display.delete_id event.
+//
+//	registry: the registry to destroy
+func (i *Fixes) DestroyRegistry(registry *Registry) error {
+	const opcode = 1
+	const _reqBufLen = 8 + 4
+	var _reqBuf [_reqBufLen]byte
+	l := 0
+	PutUint32(_reqBuf[l:4], i.ID())
+	l += 4
+	PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
+	l += 4
+	PutUint32(_reqBuf[l:l+4], registry.ID())
+	l += 4
+	err := i.Context().WriteMsg(_reqBuf[:], nil)
+	return err
+}
+
+func init() {
+	RegisterInterface(DisplayInterface)
+	RegisterInterface(RegistryInterface)
+	RegisterInterface(CallbackInterface)
+	RegisterInterface(CompositorInterface)
+	RegisterInterface(ShmPoolInterface)
+	RegisterInterface(ShmInterface)
+	RegisterInterface(BufferInterface)
+	RegisterInterface(DataOfferInterface)
+	RegisterInterface(DataSourceInterface)
+	RegisterInterface(DataDeviceInterface)
+	RegisterInterface(DataDeviceManagerInterface)
+	RegisterInterface(ShellInterface)
+	RegisterInterface(ShellSurfaceInterface)
+	RegisterInterface(SurfaceInterface)
+	RegisterInterface(SeatInterface)
+	RegisterInterface(PointerInterface)
+	RegisterInterface(KeyboardInterface)
+	RegisterInterface(TouchInterface)
+	RegisterInterface(OutputInterface)
+	RegisterInterface(RegionInterface)
+	RegisterInterface(SubcompositorInterface)
+	RegisterInterface(SubsurfaceInterface)
+	RegisterInterface(FixesInterface)
+}
@@ -0,0 +1,2905 @@
+// Generated by go-wayland-scanner
+// https://github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner
+// XML file : https://raw.githubusercontent.com/wayland-project/wayland/1.24.0/protocol/wayland.xml
+//
+// wayland Protocol Copyright:
+//
+// Copyright © 2008-2011 Kristian Høgsberg
+// Copyright © 2010-2011 Intel Corporation
+// Copyright © 2012-2013 Collabora, Ltd.
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice (including the
+// next paragraph) shall be included in all copies or substantial
+// portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT.  IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package client
+
+// DisplayAPI is the interface of the methods of Display, to
+// replace it with a FakeDisplay in tests. Display.API returns the
+// DisplayAPI of a proxy.
+type DisplayAPI interface {
+	Sync() (CallbackAPI, error)
+	GetRegistry() (RegistryAPI, error)
+	Destroy() error
+	SetErrorHandler(f DisplayErrorHandlerFunc)
+	SetDeleteIdHandler(f DisplayDeleteIdHandlerFunc)
+	SetListener(l DisplayListener)
+}
+
+// API returns the Display as a DisplayAPI, whose requests
+// take and return API interfaces.
+func (i *Display) API() DisplayAPI {
+	return wlDisplayAPI{i}
+}
+
+// wlDisplayAPI is the DisplayAPI of a Display.
+type wlDisplayAPI struct {
+	*Display
+}
+
+var _ DisplayAPI = wlDisplayAPI{}
+
+// Unwrap returns the proxy, for APIProxy.
+func (a wlDisplayAPI) Unwrap() Proxy {
+	return a.Display
+}
+
+func (a wlDisplayAPI) Sync() (CallbackAPI, error) {
+	callback, err := a.Display.Sync()
+	if callback == nil {
+		return nil, err
+	}
+	return callback.API(), err
+}
+
+func (a wlDisplayAPI) GetRegistry() (RegistryAPI, error) {
+	registry, err := a.Display.GetRegistry()
+	if registry == nil {
+		return nil, err
+	}
+	return registry.API(), err
+}
+
+// FakeDisplay is a DisplayAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// Proxy, to be bound with the bind request of a fake registry.
+type FakeDisplay struct {
+	BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+
+	errorHandler    DisplayErrorHandlerFunc
+	deleteIdHandler DisplayDeleteIdHandlerFunc
+}
+
+var _ DisplayAPI = (*FakeDisplay)(nil)
+
+// Sync records the sync request.
+func (i *FakeDisplay) Sync() (CallbackAPI, error) {
+	callback := &FakeCallback{}
+	i.Calls = append(i.Calls, FakeCall{Request: "sync", Args: []any{callback}})
+	return callback, i.Err
+}
+
+// GetRegistry records the get_registry request.
+func (i *FakeDisplay) GetRegistry() (RegistryAPI, error) {
+	registry := &FakeRegistry{}
+	i.Calls = append(i.Calls, FakeCall{Request: "get_registry", Args: []any{registry}})
+	return registry, i.Err
+}
+
+// Destroy marks the fake destroyed.
+func (i *FakeDisplay) Destroy() error {
+	i.Destroyed = true
+	return i.Err
+}
+
+func (i *FakeDisplay) SetErrorHandler(f DisplayErrorHandlerFunc) {
+	i.errorHandler = f
+}
+
+// EmitError calls the handler of error as if the event was received.
+func (i *FakeDisplay) EmitError(e DisplayErrorEvent) {
+	if i.errorHandler != nil {
+		i.errorHandler(e)
+	}
+}
+
+func (i *FakeDisplay) SetDeleteIdHandler(f DisplayDeleteIdHandlerFunc) {
+	i.deleteIdHandler = f
+}
+
+// EmitDeleteId calls the handler of delete_id as if the event was received.
+func (i *FakeDisplay) EmitDeleteId(e DisplayDeleteIdEvent) {
+	if i.deleteIdHandler != nil {
+		i.deleteIdHandler(e)
+	}
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *FakeDisplay) SetListener(l DisplayListener) {
+	if l == nil {
+		i.errorHandler = nil
+		i.deleteIdHandler = nil
+		return
+	}
+	i.errorHandler = l.Error
+	i.deleteIdHandler = l.DeleteId
+}
+
+// RegistryAPI is the interface of the methods of Registry, to
+// replace it with a FakeRegistry in tests. Registry.API returns the
+// RegistryAPI of a proxy.
+type RegistryAPI interface {
+	Bind(name uint32, iface string, version uint32, id Proxy) error
+	Destroy() error
+	SetGlobalHandler(f RegistryGlobalHandlerFunc)
+	SetGlobalRemoveHandler(f RegistryGlobalRemoveHandlerFunc)
+	SetListener(l RegistryListener)
+}
+
+// API returns the Registry as a RegistryAPI, whose requests
+// take and return API interfaces.
+func (i *Registry) API() RegistryAPI {
+	return wlRegistryAPI{i}
+}
+
+// wlRegistryAPI is the RegistryAPI of a Registry.
+type wlRegistryAPI struct {
+	*Registry
+}
+
+var _ RegistryAPI = wlRegistryAPI{}
+
+// Unwrap returns the proxy, for APIProxy.
+func (a wlRegistryAPI) Unwrap() Proxy {
+	return a.Registry
+}
+
+// FakeRegistry is a RegistryAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// Proxy, to be bound with the bind request of a fake registry.
+type FakeRegistry struct {
+	BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+
+	globalHandler       RegistryGlobalHandlerFunc
+	globalRemoveHandler RegistryGlobalRemoveHandlerFunc
+}
+
+var _ RegistryAPI = (*FakeRegistry)(nil)
+
+// Bind records the bind request.
+func (i *FakeRegistry) Bind(name uint32, iface string, version uint32, id Proxy) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "bind", Args: []any{name, iface, version, id}})
+	return i.Err
+}
+
+// Destroy marks the fake destroyed.
+func (i *FakeRegistry) Destroy() error {
+	i.Destroyed = true
+	return i.Err
+}
+
+func (i *FakeRegistry) SetGlobalHandler(f RegistryGlobalHandlerFunc) {
+	i.globalHandler = f
+}
+
+// EmitGlobal calls the handler of global as if the event was received.
+func (i *FakeRegistry) EmitGlobal(e RegistryGlobalEvent) {
+	if i.globalHandler != nil {
+		i.globalHandler(e)
+	}
+}
+
+func (i *FakeRegistry) SetGlobalRemoveHandler(f RegistryGlobalRemoveHandlerFunc) {
+	i.globalRemoveHandler = f
+}
+
+// EmitGlobalRemove calls the handler of global_remove as if the event was received.
+func (i *FakeRegistry) EmitGlobalRemove(e RegistryGlobalRemoveEvent) {
+	if i.globalRemoveHandler != nil {
+		i.globalRemoveHandler(e)
+	}
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *FakeRegistry) SetListener(l RegistryListener) {
+	if l == nil {
+		i.globalHandler = nil
+		i.globalRemoveHandler = nil
+		return
+	}
+	i.globalHandler = l.Global
+	i.globalRemoveHandler = l.GlobalRemove
+}
+
+// CallbackAPI is the interface of the methods of Callback, to
+// replace it with a FakeCallback in tests. Callback.API returns the
+// CallbackAPI of a proxy.
+type CallbackAPI interface {
+	Destroy() error
+	SetDoneHandler(f CallbackDoneHandlerFunc)
+	SetListener(l CallbackListener)
+}
+
+// API returns the Callback as a CallbackAPI, whose requests
+// take and return API interfaces.
+func (i *Callback) API() CallbackAPI {
+	return wlCallbackAPI{i}
+}
+
+// wlCallbackAPI is the CallbackAPI of a Callback.
+type wlCallbackAPI struct {
+	*Callback
+}
+
+var _ CallbackAPI = wlCallbackAPI{}
+
+// Unwrap returns the proxy, for APIProxy.
+func (a wlCallbackAPI) Unwrap() Proxy {
+	return a.Callback
+}
+
+// FakeCallback is a CallbackAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// Proxy, to be bound with the bind request of a fake registry.
+type FakeCallback struct {
+	BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+
+	doneHandler CallbackDoneHandlerFunc
+}
+
+var _ CallbackAPI = (*FakeCallback)(nil)
+
+// Destroy marks the fake destroyed.
+func (i *FakeCallback) Destroy() error {
+	i.Destroyed = true
+	return i.Err
+}
+
+func (i *FakeCallback) SetDoneHandler(f CallbackDoneHandlerFunc) {
+	i.doneHandler = f
+}
+
+// EmitDone calls the handler of done as if the event was received.
+func (i *FakeCallback) EmitDone(e CallbackDoneEvent) {
+	i.Destroyed = true
+	if i.doneHandler != nil {
+		i.doneHandler(e)
+	}
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *FakeCallback) SetListener(l CallbackListener) {
+	if l == nil {
+		i.doneHandler = nil
+		return
+	}
+	i.doneHandler = l.Done
+}
+
+// CompositorAPI is the interface of the methods of Compositor, to
+// replace it with a FakeCompositor in tests. Compositor.API returns the
+// CompositorAPI of a proxy.
+type CompositorAPI interface {
+	CreateSurface() (SurfaceAPI, error)
+	CreateRegion() (RegionAPI, error)
+	Destroy() error
+}
+
+// API returns the Compositor as a CompositorAPI, whose requests
+// take and return API interfaces.
+func (i *Compositor) API() CompositorAPI {
+	return wlCompositorAPI{i}
+}
+
+// wlCompositorAPI is the CompositorAPI of a Compositor.
+type wlCompositorAPI struct {
+	*Compositor
+}
+
+var _ CompositorAPI = wlCompositorAPI{}
+
+// Unwrap returns the proxy, for APIProxy.
+func (a wlCompositorAPI) Unwrap() Proxy {
+	return a.Compositor
+}
+
+func (a wlCompositorAPI) CreateSurface() (SurfaceAPI, error) {
+	id, err := a.Compositor.CreateSurface()
+	if id == nil {
+		return nil, err
+	}
+	return id.API(), err
+}
+
+func (a wlCompositorAPI) CreateRegion() (RegionAPI, error) {
+	id, err := a.Compositor.CreateRegion()
+	if id == nil {
+		return nil, err
+	}
+	return id.API(), err
+}
+
+// FakeCompositor is a CompositorAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// Proxy, to be bound with the bind request of a fake registry.
+type FakeCompositor struct {
+	BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+}
+
+var _ CompositorAPI = (*FakeCompositor)(nil)
+
+// CreateSurface records the create_surface request.
+func (i *FakeCompositor) CreateSurface() (SurfaceAPI, error) {
+	id := &FakeSurface{}
+	i.Calls = append(i.Calls, FakeCall{Request: "create_surface", Args: []any{id}})
+	return id, i.Err
+}
+
+// CreateRegion records the create_region request.
+func (i *FakeCompositor) CreateRegion() (RegionAPI, error) {
+	id := &FakeRegion{}
+	i.Calls = append(i.Calls, FakeCall{Request: "create_region", Args: []any{id}})
+	return id, i.Err
+}
+
+// Destroy marks the fake destroyed.
+func (i *FakeCompositor) Destroy() error {
+	i.Destroyed = true
+	return i.Err
+}
+
+// ShmPoolAPI is the interface of the methods of ShmPool, to
+// replace it with a FakeShmPool in tests. ShmPool.API returns the
+// ShmPoolAPI of a proxy.
+type ShmPoolAPI interface {
+	CreateBuffer(offset, width, height, stride int32, format ShmFormat) (BufferAPI, error)
+	Destroy() error
+	Resize(size int32) error
+}
+
+// API returns the ShmPool as a ShmPoolAPI, whose requests
+// take and return API interfaces.
+func (i *ShmPool) API() ShmPoolAPI {
+	return wlShmPoolAPI{i}
+}
+
+// wlShmPoolAPI is the ShmPoolAPI of a ShmPool.
+type wlShmPoolAPI struct {
+	*ShmPool
+}
+
+var _ ShmPoolAPI = wlShmPoolAPI{}
+
+// Unwrap returns the proxy, for APIProxy.
+func (a wlShmPoolAPI) Unwrap() Proxy {
+	return a.ShmPool
+}
+
+func (a wlShmPoolAPI) CreateBuffer(offset, width, height, stride int32, format ShmFormat) (BufferAPI, error) {
+	id, err := a.ShmPool.CreateBuffer(offset, width, height, stride, format)
+	if id == nil {
+		return nil, err
+	}
+	return id.API(), err
+}
+
+// FakeShmPool is a ShmPoolAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// Proxy, to be bound with the bind request of a fake registry.
+type FakeShmPool struct {
+	BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+}
+
+var _ ShmPoolAPI = (*FakeShmPool)(nil)
+
+// CreateBuffer records the create_buffer request.
+func (i *FakeShmPool) CreateBuffer(offset, width, height, stride int32, format ShmFormat) (BufferAPI, error) {
+	id := &FakeBuffer{}
+	i.Calls = append(i.Calls, FakeCall{Request: "create_buffer", Args: []any{id, offset, width, height, stride, format}})
+	return id, i.Err
+}
+
+// Destroy records the destroy request.
+func (i *FakeShmPool) Destroy() error {
+	i.Calls = append(i.Calls, FakeCall{Request: "destroy"})
+	i.Destroyed = true
+	return i.Err
+}
+
+// Resize records the resize request.
+func (i *FakeShmPool) Resize(size int32) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "resize", Args: []any{size}})
+	return i.Err
+}
+
+// ShmAPI is the interface of the methods of Shm, to
+// replace it with a FakeShm in tests. Shm.API returns the
+// ShmAPI of a proxy.
+type ShmAPI interface {
+	CreatePool(fd int, size int32) (ShmPoolAPI, error)
+	Release() error
+	Destroy() error
+	SetFormatHandler(f ShmFormatHandlerFunc)
+	SetListener(l ShmListener)
+}
+
+// API returns the Shm as a ShmAPI, whose requests
+// take and return API interfaces.
+func (i *Shm) API() ShmAPI {
+	return wlShmAPI{i}
+}
+
+// wlShmAPI is the ShmAPI of a Shm.
+type wlShmAPI struct {
+	*Shm
+}
+
+var _ ShmAPI = wlShmAPI{}
+
+// Unwrap returns the proxy, for APIProxy.
+func (a wlShmAPI) Unwrap() Proxy {
+	return a.Shm
+}
+
+func (a wlShmAPI) CreatePool(fd int, size int32) (ShmPoolAPI, error) {
+	id, err := a.Shm.CreatePool(fd, size)
+	if id == nil {
+		return nil, err
+	}
+	return id.API(), err
+}
+
+// FakeShm is a ShmAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// Proxy, to be bound with the bind request of a fake registry.
+type FakeShm struct {
+	BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+
+	formatHandler ShmFormatHandlerFunc
+}
+
+var _ ShmAPI = (*FakeShm)(nil)
+
+// CreatePool records the create_pool request.
+func (i *FakeShm) CreatePool(fd int, size int32) (ShmPoolAPI, error) {
+	id := &FakeShmPool{}
+	i.Calls = append(i.Calls, FakeCall{Request: "create_pool", Args: []any{id, fd, size}})
+	return id, i.Err
+}
+
+// Release records the release request.
+func (i *FakeShm) Release() error {
+	i.Calls = append(i.Calls, FakeCall{Request: "release"})
+	i.Destroyed = true
+	return i.Err
+}
+
+// Destroy marks the fake destroyed.
+func (i *FakeShm) Destroy() error {
+	i.Destroyed = true
+	return i.Err
+}
+
+func (i *FakeShm) SetFormatHandler(f ShmFormatHandlerFunc) {
+	i.formatHandler = f
+}
+
+// EmitFormat calls the handler of format as if the event was received.
+func (i *FakeShm) EmitFormat(e ShmFormatEvent) {
+	if i.formatHandler != nil {
+		i.formatHandler(e)
+	}
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *FakeShm) SetListener(l ShmListener) {
+	if l == nil {
+		i.formatHandler = nil
+		return
+	}
+	i.formatHandler = l.Format
+}
+
+// BufferAPI is the interface of the methods of Buffer, to
+// replace it with a FakeBuffer in tests. Buffer.API returns the
+// BufferAPI of a proxy.
+type BufferAPI interface {
+	Destroy() error
+	SetReleaseHandler(f BufferReleaseHandlerFunc)
+	SetListener(l BufferListener)
+}
+
+// API returns the Buffer as a BufferAPI, whose requests
+// take and return API interfaces.
+func (i *Buffer) API() BufferAPI {
+	return wlBufferAPI{i}
+}
+
+// wlBufferAPI is the BufferAPI of a Buffer.
+type wlBufferAPI struct {
+	*Buffer
+}
+
+var _ BufferAPI = wlBufferAPI{}
+
+// Unwrap returns the proxy, for APIProxy.
+func (a wlBufferAPI) Unwrap() Proxy {
+	return a.Buffer
+}
+
+// FakeBuffer is a BufferAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// Proxy, to be bound with the bind request of a fake registry.
+type FakeBuffer struct {
+	BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+
+	releaseHandler BufferReleaseHandlerFunc
+}
+
+var _ BufferAPI = (*FakeBuffer)(nil)
+
+// Destroy records the destroy request.
+func (i *FakeBuffer) Destroy() error {
+	i.Calls = append(i.Calls, FakeCall{Request: "destroy"})
+	i.Destroyed = true
+	return i.Err
+}
+
+func (i *FakeBuffer) SetReleaseHandler(f BufferReleaseHandlerFunc) {
+	i.releaseHandler = f
+}
+
+// EmitRelease calls the handler of release as if the event was received.
+func (i *FakeBuffer) EmitRelease(e BufferReleaseEvent) {
+	if i.releaseHandler != nil {
+		i.releaseHandler(e)
+	}
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *FakeBuffer) SetListener(l BufferListener) {
+	if l == nil {
+		i.releaseHandler = nil
+		return
+	}
+	i.releaseHandler = l.Release
+}
+
+// DataOfferAPI is the interface of the methods of DataOffer, to
+// replace it with a FakeDataOffer in tests. DataOffer.API returns the
+// DataOfferAPI of a proxy.
+type DataOfferAPI interface {
+	Accept(serial uint32, mimeType *string) error
+	Receive(mimeType string, fd int) error
+	Destroy() error
+	Finish() error
+	SetActions(dndActions, preferredAction DataDeviceManagerDndAction) error
+	SetOfferHandler(f DataOfferOfferHandlerFunc)
+	SetSourceActionsHandler(f DataOfferSourceActionsHandlerFunc)
+	SetActionHandler(f DataOfferActionHandlerFunc)
+	SetListener(l DataOfferListener)
+}
+
+// API returns the DataOffer as a DataOfferAPI, whose requests
+// take and return API interfaces.
+func (i *DataOffer) API() DataOfferAPI {
+	return wlDataOfferAPI{i}
+}
+
+// wlDataOfferAPI is the DataOfferAPI of a DataOffer.
+type wlDataOfferAPI struct {
+	*DataOffer
+}
+
+var _ DataOfferAPI = wlDataOfferAPI{}
+
+// Unwrap returns the proxy, for APIProxy.
+func (a wlDataOfferAPI) Unwrap() Proxy {
+	return a.DataOffer
+}
+
+// FakeDataOffer is a DataOfferAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// Proxy, to be bound with the bind request of a fake registry.
+type FakeDataOffer struct {
+	BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+
+	offerHandler         DataOfferOfferHandlerFunc
+	sourceActionsHandler DataOfferSourceActionsHandlerFunc
+	actionHandler        DataOfferActionHandlerFunc
+}
+
+var _ DataOfferAPI = (*FakeDataOffer)(nil)
+
+// Accept records the accept request.
+func (i *FakeDataOffer) Accept(serial uint32, mimeType *string) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "accept", Args: []any{serial, mimeType}})
+	return i.Err
+}
+
+// Receive records the receive request.
+func (i *FakeDataOffer) Receive(mimeType string, fd int) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "receive", Args: []any{mimeType, fd}})
+	return i.Err
+}
+
+// Destroy records the destroy request.
+func (i *FakeDataOffer) Destroy() error {
+	i.Calls = append(i.Calls, FakeCall{Request: "destroy"})
+	i.Destroyed = true
+	return i.Err
+}
+
+// Finish records the finish request.
+func (i *FakeDataOffer) Finish() error {
+	i.Calls = append(i.Calls, FakeCall{Request: "finish"})
+	return i.Err
+}
+
+// SetActions records the set_actions request.
+func (i *FakeDataOffer) SetActions(dndActions, preferredAction DataDeviceManagerDndAction) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "set_actions", Args: []any{dndActions, preferredAction}})
+	return i.Err
+}
+
+func (i *FakeDataOffer) SetOfferHandler(f DataOfferOfferHandlerFunc) {
+	i.offerHandler = f
+}
+
+// EmitOffer calls the handler of offer as if the event was received.
+func (i *FakeDataOffer) EmitOffer(e DataOfferOfferEvent) {
+	if i.offerHandler != nil {
+		i.offerHandler(e)
+	}
+}
+
+func (i *FakeDataOffer) SetSourceActionsHandler(f DataOfferSourceActionsHandlerFunc) {
+	i.sourceActionsHandler = f
+}
+
+// EmitSourceActions calls the handler of source_actions as if the event was received.
+func (i *FakeDataOffer) EmitSourceActions(e DataOfferSourceActionsEvent) {
+	if i.sourceActionsHandler != nil {
+		i.sourceActionsHandler(e)
+	}
+}
+
+func (i *FakeDataOffer) SetActionHandler(f DataOfferActionHandlerFunc) {
+	i.actionHandler = f
+}
+
+// EmitAction calls the handler of action as if the event was received.
+func (i *FakeDataOffer) EmitAction(e DataOfferActionEvent) {
+	if i.actionHandler != nil {
+		i.actionHandler(e)
+	}
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *FakeDataOffer) SetListener(l DataOfferListener) {
+	if l == nil {
+		i.offerHandler = nil
+		i.sourceActionsHandler = nil
+		i.actionHandler = nil
+		return
+	}
+	i.offerHandler = l.Offer
+	i.sourceActionsHandler = l.SourceActions
+	i.actionHandler = l.Action
+}
+
+// DataSourceAPI is the interface of the methods of DataSource, to
+// replace it with a FakeDataSource in tests. DataSource.API returns the
+// DataSourceAPI of a proxy.
+type DataSourceAPI interface {
+	Offer(mimeType string) error
+	Destroy() error
+	SetActions(dndActions DataDeviceManagerDndAction) error
+	SetTargetHandler(f DataSourceTargetHandlerFunc)
+	SetSendHandler(f DataSourceSendHandlerFunc)
+	SetCancelledHandler(f DataSourceCancelledHandlerFunc)
+	SetDndDropPerformedHandler(f DataSourceDndDropPerformedHandlerFunc)
+	SetDndFinishedHandler(f DataSourceDndFinishedHandlerFunc)
+	SetActionHandler(f DataSourceActionHandlerFunc)
+	SetListener(l DataSourceListener)
+}
+
+// API returns the DataSource as a DataSourceAPI, whose requests
+// take and return API interfaces.
+func (i *DataSource) API() DataSourceAPI {
+	return wlDataSourceAPI{i}
+}
+
+// wlDataSourceAPI is the DataSourceAPI of a DataSource.
+type wlDataSourceAPI struct {
+	*DataSource
+}
+
+var _ DataSourceAPI = wlDataSourceAPI{}
+
+// Unwrap returns the proxy, for APIProxy.
+func (a wlDataSourceAPI) Unwrap() Proxy {
+	return a.DataSource
+}
+
+// FakeDataSource is a DataSourceAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// Proxy, to be bound with the bind request of a fake registry.
+type FakeDataSource struct {
+	BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+
+	targetHandler           DataSourceTargetHandlerFunc
+	sendHandler             DataSourceSendHandlerFunc
+	cancelledHandler        DataSourceCancelledHandlerFunc
+	dndDropPerformedHandler DataSourceDndDropPerformedHandlerFunc
+	dndFinishedHandler      DataSourceDndFinishedHandlerFunc
+	actionHandler           DataSourceActionHandlerFunc
+}
+
+var _ DataSourceAPI = (*FakeDataSource)(nil)
+
+// Offer records the offer request.
+func (i *FakeDataSource) Offer(mimeType string) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "offer", Args: []any{mimeType}})
+	return i.Err
+}
+
+// Destroy records the destroy request.
+func (i *FakeDataSource) Destroy() error {
+	i.Calls = append(i.Calls, FakeCall{Request: "destroy"})
+	i.Destroyed = true
+	return i.Err
+}
+
+// SetActions records the set_actions request.
+func (i *FakeDataSource) SetActions(dndActions DataDeviceManagerDndAction) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "set_actions", Args: []any{dndActions}})
+	return i.Err
+}
+
+func (i *FakeDataSource) SetTargetHandler(f DataSourceTargetHandlerFunc) {
+	i.targetHandler = f
+}
+
+// EmitTarget calls the handler of target as if the event was received.
+func (i *FakeDataSource) EmitTarget(e DataSourceTargetEvent) {
+	if i.targetHandler != nil {
+		i.targetHandler(e)
+	}
+}
+
+func (i *FakeDataSource) SetSendHandler(f DataSourceSendHandlerFunc) {
+	i.sendHandler = f
+}
+
+// EmitSend calls the handler of send as if the event was received.
+func (i *FakeDataSource) EmitSend(e DataSourceSendEvent) {
+	if i.sendHandler != nil {
+		i.sendHandler(e)
+	}
+}
+
+func (i *FakeDataSource) SetCancelledHandler(f DataSourceCancelledHandlerFunc) {
+	i.cancelledHandler = f
+}
+
+// EmitCancelled calls the handler of cancelled as if the event was received.
+func (i *FakeDataSource) EmitCancelled(e DataSourceCancelledEvent) {
+	if i.cancelledHandler != nil {
+		i.cancelledHandler(e)
+	}
+}
+
+func (i *FakeDataSource) SetDndDropPerformedHandler(f DataSourceDndDropPerformedHandlerFunc) {
+	i.dndDropPerformedHandler = f
+}
+
+// EmitDndDropPerformed calls the handler of dnd_drop_performed as if the event was received.
+func (i *FakeDataSource) EmitDndDropPerformed(e DataSourceDndDropPerformedEvent) {
+	if i.dndDropPerformedHandler != nil {
+		i.dndDropPerformedHandler(e)
+	}
+}
+
+func (i *FakeDataSource) SetDndFinishedHandler(f DataSourceDndFinishedHandlerFunc) {
+	i.dndFinishedHandler = f
+}
+
+// EmitDndFinished calls the handler of dnd_finished as if the event was received.
+func (i *FakeDataSource) EmitDndFinished(e DataSourceDndFinishedEvent) {
+	if i.dndFinishedHandler != nil {
+		i.dndFinishedHandler(e)
+	}
+}
+
+func (i *FakeDataSource) SetActionHandler(f DataSourceActionHandlerFunc) {
+	i.actionHandler = f
+}
+
+// EmitAction calls the handler of action as if the event was received.
+func (i *FakeDataSource) EmitAction(e DataSourceActionEvent) {
+	if i.actionHandler != nil {
+		i.actionHandler(e)
+	}
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *FakeDataSource) SetListener(l DataSourceListener) {
+	if l == nil {
+		i.targetHandler = nil
+		i.sendHandler = nil
+		i.cancelledHandler = nil
+		i.dndDropPerformedHandler = nil
+		i.dndFinishedHandler = nil
+		i.actionHandler = nil
+		return
+	}
+	i.targetHandler = l.Target
+	i.sendHandler = l.Send
+	i.cancelledHandler = l.Cancelled
+	i.dndDropPerformedHandler = l.DndDropPerformed
+	i.dndFinishedHandler = l.DndFinished
+	i.actionHandler = l.Action
+}
+
+// DataDeviceAPI is the interface of the methods of DataDevice, to
+// replace it with a FakeDataDevice in tests. DataDevice.API returns the
+// DataDeviceAPI of a proxy.
+type DataDeviceAPI interface {
+	StartDrag(source DataSourceAPI, origin, icon SurfaceAPI, serial uint32) error
+	SetSelection(source DataSourceAPI, serial uint32) error
+	Release() error
+	Destroy() error
+	SetDataOfferHandler(f DataDeviceDataOfferHandlerFunc)
+	SetEnterHandler(f DataDeviceEnterHandlerFunc)
+	SetLeaveHandler(f DataDeviceLeaveHandlerFunc)
+	SetMotionHandler(f DataDeviceMotionHandlerFunc)
+	SetDropHandler(f DataDeviceDropHandlerFunc)
+	SetSelectionHandler(f DataDeviceSelectionHandlerFunc)
+	SetListener(l DataDeviceListener)
+}
+
+// API returns the DataDevice as a DataDeviceAPI, whose requests
+// take and return API interfaces.
+func (i *DataDevice) API() DataDeviceAPI {
+	return wlDataDeviceAPI{i}
+}
+
+// wlDataDeviceAPI is the DataDeviceAPI of a DataDevice.
+type wlDataDeviceAPI struct {
+	*DataDevice
+}
+
+var _ DataDeviceAPI = wlDataDeviceAPI{}
+
+// Unwrap returns the proxy, for APIProxy.
+func (a wlDataDeviceAPI) Unwrap() Proxy {
+	return a.DataDevice
+}
+
+func (a wlDataDeviceAPI) StartDrag(source DataSourceAPI, origin, icon SurfaceAPI, serial uint32) error {
+	sourceProxy, err := APIProxy[*DataSource](source)
+	if err != nil {
+		return err
+	}
+	originProxy, err := APIProxy[*Surface](origin)
+	if err != nil {
+		return err
+	}
+	iconProxy, err := APIProxy[*Surface](icon)
+	if err != nil {
+		return err
+	}
+	return a.DataDevice.StartDrag(sourceProxy, originProxy, iconProxy, serial)
+}
+
+func (a wlDataDeviceAPI) SetSelection(source DataSourceAPI, serial uint32) error {
+	sourceProxy, err := APIProxy[*DataSource](source)
+	if err != nil {
+		return err
+	}
+	return a.DataDevice.SetSelection(sourceProxy, serial)
+}
+
+// FakeDataDevice is a DataDeviceAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// Proxy, to be bound with the bind request of a fake registry.
+type FakeDataDevice struct {
+	BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+
+	dataOfferHandler DataDeviceDataOfferHandlerFunc
+	enterHandler     DataDeviceEnterHandlerFunc
+	leaveHandler     DataDeviceLeaveHandlerFunc
+	motionHandler    DataDeviceMotionHandlerFunc
+	dropHandler      DataDeviceDropHandlerFunc
+	selectionHandler DataDeviceSelectionHandlerFunc
+}
+
+var _ DataDeviceAPI = (*FakeDataDevice)(nil)
+
+// StartDrag records the start_drag request.
+func (i *FakeDataDevice) StartDrag(source DataSourceAPI, origin, icon SurfaceAPI, serial uint32) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "start_drag", Args: []any{source, origin, icon, serial}})
+	return i.Err
+}
+
+// SetSelection records the set_selection request.
+func (i *FakeDataDevice) SetSelection(source DataSourceAPI, serial uint32) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "set_selection", Args: []any{source, serial}})
+	return i.Err
+}
+
+// Release records the release request.
+func (i *FakeDataDevice) Release() error {
+	i.Calls = append(i.Calls, FakeCall{Request: "release"})
+	i.Destroyed = true
+	return i.Err
+}
+
+// Destroy marks the fake destroyed.
+func (i *FakeDataDevice) Destroy() error {
+	i.Destroyed = true
+	return i.Err
+}
+
+func (i *FakeDataDevice) SetDataOfferHandler(f DataDeviceDataOfferHandlerFunc) {
+	i.dataOfferHandler = f
+}
+
+// EmitDataOffer calls the handler of data_offer as if the event was received.
+func (i *FakeDataDevice) EmitDataOffer(e DataDeviceDataOfferEvent) {
+	if i.dataOfferHandler != nil {
+		i.dataOfferHandler(e)
+	}
+}
+
+func (i *FakeDataDevice) SetEnterHandler(f DataDeviceEnterHandlerFunc) {
+	i.enterHandler = f
+}
+
+// EmitEnter calls the handler of enter as if the event was received.
+func (i *FakeDataDevice) EmitEnter(e DataDeviceEnterEvent) {
+	if i.enterHandler != nil {
+		i.enterHandler(e)
+	}
+}
+
+func (i *FakeDataDevice) SetLeaveHandler(f DataDeviceLeaveHandlerFunc) {
+	i.leaveHandler = f
+}
+
+// EmitLeave calls the handler of leave as if the event was received.
+func (i *FakeDataDevice) EmitLeave(e DataDeviceLeaveEvent) {
+	if i.leaveHandler != nil {
+		i.leaveHandler(e)
+	}
+}
+
+func (i *FakeDataDevice) SetMotionHandler(f DataDeviceMotionHandlerFunc) {
+	i.motionHandler = f
+}
+
+// EmitMotion calls the handler of motion as if the event was received.
+func (i *FakeDataDevice) EmitMotion(e DataDeviceMotionEvent) {
+	if i.motionHandler != nil {
+		i.motionHandler(e)
+	}
+}
+
+func (i *FakeDataDevice) SetDropHandler(f DataDeviceDropHandlerFunc) {
+	i.dropHandler = f
+}
+
+// EmitDrop calls the handler of drop as if the event was received.
+func (i *FakeDataDevice) EmitDrop(e DataDeviceDropEvent) {
+	if i.dropHandler != nil {
+		i.dropHandler(e)
+	}
+}
+
+func (i *FakeDataDevice) SetSelectionHandler(f DataDeviceSelectionHandlerFunc) {
+	i.selectionHandler = f
+}
+
+// EmitSelection calls the handler of selection as if the event was received.
+func (i *FakeDataDevice) EmitSelection(e DataDeviceSelectionEvent) {
+	if i.selectionHandler != nil {
+		i.selectionHandler(e)
+	}
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *FakeDataDevice) SetListener(l DataDeviceListener) {
+	if l == nil {
+		i.dataOfferHandler = nil
+		i.enterHandler = nil
+		i.leaveHandler = nil
+		i.motionHandler = nil
+		i.dropHandler = nil
+		i.selectionHandler = nil
+		return
+	}
+	i.dataOfferHandler = l.DataOffer
+	i.enterHandler = l.Enter
+	i.leaveHandler = l.Leave
+	i.motionHandler = l.Motion
+	i.dropHandler = l.Drop
+	i.selectionHandler = l.Selection
+}
+
+// DataDeviceManagerAPI is the interface of the methods of DataDeviceManager, to
+// replace it with a FakeDataDeviceManager in tests. DataDeviceManager.API returns the
+// DataDeviceManagerAPI of a proxy.
+type DataDeviceManagerAPI interface {
+	CreateDataSource() (DataSourceAPI, error)
+	GetDataDevice(seat SeatAPI) (DataDeviceAPI, error)
+	Destroy() error
+}
+
+// API returns the DataDeviceManager as a DataDeviceManagerAPI, whose requests
+// take and return API interfaces.
+func (i *DataDeviceManager) API() DataDeviceManagerAPI {
+	return wlDataDeviceManagerAPI{i}
+}
+
+// wlDataDeviceManagerAPI is the DataDeviceManagerAPI of a DataDeviceManager.
+type wlDataDeviceManagerAPI struct {
+	*DataDeviceManager
+}
+
+var _ DataDeviceManagerAPI = wlDataDeviceManagerAPI{}
+
+// Unwrap returns the proxy, for APIProxy.
+func (a wlDataDeviceManagerAPI) Unwrap() Proxy {
+	return a.DataDeviceManager
+}
+
+func (a wlDataDeviceManagerAPI) CreateDataSource() (DataSourceAPI, error) {
+	id, err := a.DataDeviceManager.CreateDataSource()
+	if id == nil {
+		return nil, err
+	}
+	return id.API(), err
+}
+
+func (a wlDataDeviceManagerAPI) GetDataDevice(seat SeatAPI) (DataDeviceAPI, error) {
+	seatProxy, err := APIProxy[*Seat](seat)
+	if err != nil {
+		return nil, err
+	}
+	id, err := a.DataDeviceManager.GetDataDevice(seatProxy)
+	if id == nil {
+		return nil, err
+	}
+	return id.API(), err
+}
+
+// FakeDataDeviceManager is a DataDeviceManagerAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// Proxy, to be bound with the bind request of a fake registry.
+type FakeDataDeviceManager struct {
+	BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+}
+
+var _ DataDeviceManagerAPI = (*FakeDataDeviceManager)(nil)
+
+// CreateDataSource records the create_data_source request.
+func (i *FakeDataDeviceManager) CreateDataSource() (DataSourceAPI, error) {
+	id := &FakeDataSource{}
+	i.Calls = append(i.Calls, FakeCall{Request: "create_data_source", Args: []any{id}})
+	return id, i.Err
+}
+
+// GetDataDevice records the get_data_device request.
+func (i *FakeDataDeviceManager) GetDataDevice(seat SeatAPI) (DataDeviceAPI, error) {
+	id := &FakeDataDevice{}
+	i.Calls = append(i.Calls, FakeCall{Request: "get_data_device", Args: []any{id, seat}})
+	return id, i.Err
+}
+
+// Destroy marks the fake destroyed.
+func (i *FakeDataDeviceManager) Destroy() error {
+	i.Destroyed = true
+	return i.Err
+}
+
+// ShellAPI is the interface of the methods of Shell, to
+// replace it with a FakeShell in tests. Shell.API returns the
+// ShellAPI of a proxy.
+type ShellAPI interface {
+	GetShellSurface(surface SurfaceAPI) (ShellSurfaceAPI, error)
+	Destroy() error
+}
+
+// API returns the Shell as a ShellAPI, whose requests
+// take and return API interfaces.
+func (i *Shell) API() ShellAPI {
+	return wlShellAPI{i}
+}
+
+// wlShellAPI is the ShellAPI of a Shell.
+type wlShellAPI struct {
+	*Shell
+}
+
+var _ ShellAPI = wlShellAPI{}
+
+// Unwrap returns the proxy, for APIProxy.
+func (a wlShellAPI) Unwrap() Proxy {
+	return a.Shell
+}
+
+func (a wlShellAPI) GetShellSurface(surface SurfaceAPI) (ShellSurfaceAPI, error) {
+	surfaceProxy, err := APIProxy[*Surface](surface)
+	if err != nil {
+		return nil, err
+	}
+	id, err := a.Shell.GetShellSurface(surfaceProxy)
+	if id == nil {
+		return nil, err
+	}
+	return id.API(), err
+}
+
+// FakeShell is a ShellAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// Proxy, to be bound with the bind request of a fake registry.
+type FakeShell struct {
+	BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+}
+
+var _ ShellAPI = (*FakeShell)(nil)
+
+// GetShellSurface records the get_shell_surface request.
+func (i *FakeShell) GetShellSurface(surface SurfaceAPI) (ShellSurfaceAPI, error) {
+	id := &FakeShellSurface{}
+	i.Calls = append(i.Calls, FakeCall{Request: "get_shell_surface", Args: []any{id, surface}})
+	return id, i.Err
+}
+
+// Destroy marks the fake destroyed.
+func (i *FakeShell) Destroy() error {
+	i.Destroyed = true
+	return i.Err
+}
+
+// ShellSurfaceAPI is the interface of the methods of ShellSurface, to
+// replace it with a FakeShellSurface in tests. ShellSurface.API returns the
+// ShellSurfaceAPI of a proxy.
+type ShellSurfaceAPI interface {
+	Pong(serial uint32) error
+	Move(seat SeatAPI, serial uint32) error
+	Resize(seat SeatAPI, serial uint32, edges ShellSurfaceResize) error
+	SetToplevel() error
+	SetTransient(parent SurfaceAPI, x, y int32, flags ShellSurfaceTransient) error
+	SetFullscreen(method ShellSurfaceFullscreenMethod, framerate uint32, output OutputAPI) error
+	SetPopup(seat SeatAPI, serial uint32, parent SurfaceAPI, x, y int32, flags ShellSurfaceTransient) error
+	SetMaximized(output OutputAPI) error
+	SetTitle(title string) error
+	SetClass(class string) error
+	Destroy() error
+	SetPingHandler(f ShellSurfacePingHandlerFunc)
+	SetConfigureHandler(f ShellSurfaceConfigureHandlerFunc)
+	SetPopupDoneHandler(f ShellSurfacePopupDoneHandlerFunc)
+	SetListener(l ShellSurfaceListener)
+}
+
+// API returns the ShellSurface as a ShellSurfaceAPI, whose requests
+// take and return API interfaces.
+func (i *ShellSurface) API() ShellSurfaceAPI {
+	return wlShellSurfaceAPI{i}
+}
+
+// wlShellSurfaceAPI is the ShellSurfaceAPI of a ShellSurface.
+type wlShellSurfaceAPI struct {
+	*ShellSurface
+}
+
+var _ ShellSurfaceAPI = wlShellSurfaceAPI{}
+
+// Unwrap returns the proxy, for APIProxy.
+func (a wlShellSurfaceAPI) Unwrap() Proxy {
+	return a.ShellSurface
+}
+
+func (a wlShellSurfaceAPI) Move(seat SeatAPI, serial uint32) error {
+	seatProxy, err := APIProxy[*Seat](seat)
+	if err != nil {
+		return err
+	}
+	return a.ShellSurface.Move(seatProxy, serial)
+}
+
+func (a wlShellSurfaceAPI) Resize(seat SeatAPI, serial uint32, edges ShellSurfaceResize) error {
+	seatProxy, err := APIProxy[*Seat](seat)
+	if err != nil {
+		return err
+	}
+	return a.ShellSurface.Resize(seatProxy, serial, edges)
+}
+
+func (a wlShellSurfaceAPI) SetTransient(parent SurfaceAPI, x, y int32, flags ShellSurfaceTransient) error {
+	parentProxy, err := APIProxy[*Surface](parent)
+	if err != nil {
+		return err
+	}
+	return a.ShellSurface.SetTransient(parentProxy, x, y, flags)
+}
+
+func (a wlShellSurfaceAPI) SetFullscreen(method ShellSurfaceFullscreenMethod, framerate uint32, output OutputAPI) error {
+	outputProxy, err := APIProxy[*Output](output)
+	if err != nil {
+		return err
+	}
+	return a.ShellSurface.SetFullscreen(method, framerate, outputProxy)
+}
+
+func (a wlShellSurfaceAPI) SetPopup(seat SeatAPI, serial uint32, parent SurfaceAPI, x, y int32, flags ShellSurfaceTransient) error {
+	seatProxy, err := APIProxy[*Seat](seat)
+	if err != nil {
+		return err
+	}
+	parentProxy, err := APIProxy[*Surface](parent)
+	if err != nil {
+		return err
+	}
+	return a.ShellSurface.SetPopup(seatProxy, serial, parentProxy, x, y, flags)
+}
+
+func (a wlShellSurfaceAPI) SetMaximized(output OutputAPI) error {
+	outputProxy, err := APIProxy[*Output](output)
+	if err != nil {
+		return err
+	}
+	return a.ShellSurface.SetMaximized(outputProxy)
+}
+
+// FakeShellSurface is a ShellSurfaceAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// Proxy, to be bound with the bind request of a fake registry.
+type FakeShellSurface struct {
+	BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+
+	pingHandler      ShellSurfacePingHandlerFunc
+	configureHandler ShellSurfaceConfigureHandlerFunc
+	popupDoneHandler ShellSurfacePopupDoneHandlerFunc
+}
+
+var _ ShellSurfaceAPI = (*FakeShellSurface)(nil)
+
+// Pong records the pong request.
+func (i *FakeShellSurface) Pong(serial uint32) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "pong", Args: []any{serial}})
+	return i.Err
+}
+
+// Move records the move request.
+func (i *FakeShellSurface) Move(seat SeatAPI, serial uint32) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "move", Args: []any{seat, serial}})
+	return i.Err
+}
+
+// Resize records the resize request.
+func (i *FakeShellSurface) Resize(seat SeatAPI, serial uint32, edges ShellSurfaceResize) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "resize", Args: []any{seat, serial, edges}})
+	return i.Err
+}
+
+// SetToplevel records the set_toplevel request.
+func (i *FakeShellSurface) SetToplevel() error {
+	i.Calls = append(i.Calls, FakeCall{Request: "set_toplevel"})
+	return i.Err
+}
+
+// SetTransient records the set_transient request.
+func (i *FakeShellSurface) SetTransient(parent SurfaceAPI, x, y int32, flags ShellSurfaceTransient) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "set_transient", Args: []any{parent, x, y, flags}})
+	return i.Err
+}
+
+// SetFullscreen records the set_fullscreen request.
+func (i *FakeShellSurface) SetFullscreen(method ShellSurfaceFullscreenMethod, framerate uint32, output OutputAPI) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "set_fullscreen", Args: []any{method, framerate, output}})
+	return i.Err
+}
+
+// SetPopup records the set_popup request.
+func (i *FakeShellSurface) SetPopup(seat SeatAPI, serial uint32, parent SurfaceAPI, x, y int32, flags ShellSurfaceTransient) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "set_popup", Args: []any{seat, serial, parent, x, y, flags}})
+	return i.Err
+}
+
+// SetMaximized records the set_maximized request.
+func (i *FakeShellSurface) SetMaximized(output OutputAPI) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "set_maximized", Args: []any{output}})
+	return i.Err
+}
+
+// SetTitle records the set_title request.
+func (i *FakeShellSurface) SetTitle(title string) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "set_title", Args: []any{title}})
+	return i.Err
+}
+
+// SetClass records the set_class request.
+func (i *FakeShellSurface) SetClass(class string) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "set_class", Args: []any{class}})
+	return i.Err
+}
+
+// Destroy marks the fake destroyed.
+func (i *FakeShellSurface) Destroy() error {
+	i.Destroyed = true
+	return i.Err
+}
+
+func (i *FakeShellSurface) SetPingHandler(f ShellSurfacePingHandlerFunc) {
+	i.pingHandler = f
+}
+
+// EmitPing calls the handler of ping as if the event was received.
+func (i *FakeShellSurface) EmitPing(e ShellSurfacePingEvent) {
+	if i.pingHandler != nil {
+		i.pingHandler(e)
+	}
+}
+
+func (i *FakeShellSurface) SetConfigureHandler(f ShellSurfaceConfigureHandlerFunc) {
+	i.configureHandler = f
+}
+
+// EmitConfigure calls the handler of configure as if the event was received.
+func (i *FakeShellSurface) EmitConfigure(e ShellSurfaceConfigureEvent) {
+	if i.configureHandler != nil {
+		i.configureHandler(e)
+	}
+}
+
+func (i *FakeShellSurface) SetPopupDoneHandler(f ShellSurfacePopupDoneHandlerFunc) {
+	i.popupDoneHandler = f
+}
+
+// EmitPopupDone calls the handler of popup_done as if the event was received.
+func (i *FakeShellSurface) EmitPopupDone(e ShellSurfacePopupDoneEvent) {
+	if i.popupDoneHandler != nil {
+		i.popupDoneHandler(e)
+	}
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *FakeShellSurface) SetListener(l ShellSurfaceListener) {
+	if l == nil {
+		i.pingHandler = nil
+		i.configureHandler = nil
+		i.popupDoneHandler = nil
+		return
+	}
+	i.pingHandler = l.Ping
+	i.configureHandler = l.Configure
+	i.popupDoneHandler = l.PopupDone
+}
+
+// SurfaceAPI is the interface of the methods of Surface, to
+// replace it with a FakeSurface in tests. Surface.API returns the
+// SurfaceAPI of a proxy.
+type SurfaceAPI interface {
+	Destroy() error
+	Attach(buffer BufferAPI, x, y int32) error
+	Damage(x, y, width, height int32) error
+	Frame() (CallbackAPI, error)
+	SetOpaqueRegion(region RegionAPI) error
+	SetInputRegion(region RegionAPI) error
+	Commit() error
+	SetBufferTransform(transform OutputTransform) error
+	SetBufferScale(scale int32) error
+	DamageBuffer(x, y, width, height int32) error
+	Offset(x, y int32) error
+	SetEnterHandler(f SurfaceEnterHandlerFunc)
+	SetLeaveHandler(f SurfaceLeaveHandlerFunc)
+	SetPreferredBufferScaleHandler(f SurfacePreferredBufferScaleHandlerFunc)
+	SetPreferredBufferTransformHandler(f SurfacePreferredBufferTransformHandlerFunc)
+	SetListener(l SurfaceListener)
+}
+
+// API returns the Surface as a SurfaceAPI, whose requests
+// take and return API interfaces.
+func (i *Surface) API() SurfaceAPI {
+	return wlSurfaceAPI{i}
+}
+
+// wlSurfaceAPI is the SurfaceAPI of a Surface.
+type wlSurfaceAPI struct {
+	*Surface
+}
+
+var _ SurfaceAPI = wlSurfaceAPI{}
+
+// Unwrap returns the proxy, for APIProxy.
+func (a wlSurfaceAPI) Unwrap() Proxy {
+	return a.Surface
+}
+
+func (a wlSurfaceAPI) Attach(buffer BufferAPI, x, y int32) error {
+	bufferProxy, err := APIProxy[*Buffer](buffer)
+	if err != nil {
+		return err
+	}
+	return a.Surface.Attach(bufferProxy, x, y)
+}
+
+func (a wlSurfaceAPI) Frame() (CallbackAPI, error) {
+	callback, err := a.Surface.Frame()
+	if callback == nil {
+		return nil, err
+	}
+	return callback.API(), err
+}
+
+func (a wlSurfaceAPI) SetOpaqueRegion(region RegionAPI) error {
+	regionProxy, err := APIProxy[*Region](region)
+	if err != nil {
+		return err
+	}
+	return a.Surface.SetOpaqueRegion(regionProxy)
+}
+
+func (a wlSurfaceAPI) SetInputRegion(region RegionAPI) error {
+	regionProxy, err := APIProxy[*Region](region)
+	if err != nil {
+		return err
+	}
+	return a.Surface.SetInputRegion(regionProxy)
+}
+
+// FakeSurface is a SurfaceAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// Proxy, to be bound with the bind request of a fake registry.
+type FakeSurface struct {
+	BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+
+	enterHandler                    SurfaceEnterHandlerFunc
+	leaveHandler                    SurfaceLeaveHandlerFunc
+	preferredBufferScaleHandler     SurfacePreferredBufferScaleHandlerFunc
+	preferredBufferTransformHandler SurfacePreferredBufferTransformHandlerFunc
+}
+
+var _ SurfaceAPI = (*FakeSurface)(nil)
+
+// Destroy records the destroy request.
+func (i *FakeSurface) Destroy() error {
+	i.Calls = append(i.Calls, FakeCall{Request: "destroy"})
+	i.Destroyed = true
+	return i.Err
+}
+
+// Attach records the attach request.
+func (i *FakeSurface) Attach(buffer BufferAPI, x, y int32) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "attach", Args: []any{buffer, x, y}})
+	return i.Err
+}
+
+// Damage records the damage request.
+func (i *FakeSurface) Damage(x, y, width, height int32) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "damage", Args: []any{x, y, width, height}})
+	return i.Err
+}
+
+// Frame records the frame request.
+func (i *FakeSurface) Frame() (CallbackAPI, error) {
+	callback := &FakeCallback{}
+	i.Calls = append(i.Calls, FakeCall{Request: "frame", Args: []any{callback}})
+	return callback, i.Err
+}
+
+// SetOpaqueRegion records the set_opaque_region request.
+func (i *FakeSurface) SetOpaqueRegion(region RegionAPI) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "set_opaque_region", Args: []any{region}})
+	return i.Err
+}
+
+// SetInputRegion records the set_input_region request.
+func (i *FakeSurface) SetInputRegion(region RegionAPI) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "set_input_region", Args: []any{region}})
+	return i.Err
+}
+
+// Commit records the commit request.
+func (i *FakeSurface) Commit() error {
+	i.Calls = append(i.Calls, FakeCall{Request: "commit"})
+	return i.Err
+}
+
+// SetBufferTransform records the set_buffer_transform request.
+func (i *FakeSurface) SetBufferTransform(transform OutputTransform) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "set_buffer_transform", Args: []any{transform}})
+	return i.Err
+}
+
+// SetBufferScale records the set_buffer_scale request.
+func (i *FakeSurface) SetBufferScale(scale int32) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "set_buffer_scale", Args: []any{scale}})
+	return i.Err
+}
+
+// DamageBuffer records the damage_buffer request.
+func (i *FakeSurface) DamageBuffer(x, y, width, height int32) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "damage_buffer", Args: []any{x, y, width, height}})
+	return i.Err
+}
+
+// Offset records the offset request.
+func (i *FakeSurface) Offset(x, y int32) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "offset", Args: []any{x, y}})
+	return i.Err
+}
+
+func (i *FakeSurface) SetEnterHandler(f SurfaceEnterHandlerFunc) {
+	i.enterHandler = f
+}
+
+// EmitEnter calls the handler of enter as if the event was received.
+func (i *FakeSurface) EmitEnter(e SurfaceEnterEvent) {
+	if i.enterHandler != nil {
+		i.enterHandler(e)
+	}
+}
+
+func (i *FakeSurface) SetLeaveHandler(f SurfaceLeaveHandlerFunc) {
+	i.leaveHandler = f
+}
+
+// EmitLeave calls the handler of leave as if the event was received.
+func (i *FakeSurface) EmitLeave(e SurfaceLeaveEvent) {
+	if i.leaveHandler != nil {
+		i.leaveHandler(e)
+	}
+}
+
+func (i *FakeSurface) SetPreferredBufferScaleHandler(f SurfacePreferredBufferScaleHandlerFunc) {
+	i.preferredBufferScaleHandler = f
+}
+
+// EmitPreferredBufferScale calls the handler of preferred_buffer_scale as if the event was received.
+func (i *FakeSurface) EmitPreferredBufferScale(e SurfacePreferredBufferScaleEvent) {
+	if i.preferredBufferScaleHandler != nil {
+		i.preferredBufferScaleHandler(e)
+	}
+}
+
+func (i *FakeSurface) SetPreferredBufferTransformHandler(f SurfacePreferredBufferTransformHandlerFunc) {
+	i.preferredBufferTransformHandler = f
+}
+
+// EmitPreferredBufferTransform calls the handler of preferred_buffer_transform as if the event was received.
+func (i *FakeSurface) EmitPreferredBufferTransform(e SurfacePreferredBufferTransformEvent) {
+	if i.preferredBufferTransformHandler != nil {
+		i.preferredBufferTransformHandler(e)
+	}
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *FakeSurface) SetListener(l SurfaceListener) {
+	if l == nil {
+		i.enterHandler = nil
+		i.leaveHandler = nil
+		i.preferredBufferScaleHandler = nil
+		i.preferredBufferTransformHandler = nil
+		return
+	}
+	i.enterHandler = l.Enter
+	i.leaveHandler = l.Leave
+	i.preferredBufferScaleHandler = l.PreferredBufferScale
+	i.preferredBufferTransformHandler = l.PreferredBufferTransform
+}
+
+// SeatAPI is the interface of the methods of Seat, to
+// replace it with a FakeSeat in tests. Seat.API returns the
+// SeatAPI of a proxy.
+type SeatAPI interface {
+	GetPointer() (PointerAPI, error)
+	GetKeyboard() (KeyboardAPI, error)
+	GetTouch() (TouchAPI, error)
+	Release() error
+	Destroy() error
+	SetCapabilitiesHandler(f SeatCapabilitiesHandlerFunc)
+	SetNameHandler(f SeatNameHandlerFunc)
+	SetListener(l SeatListener)
+}
+
+// API returns the Seat as a SeatAPI, whose requests
+// take and return API interfaces.
+func (i *Seat) API() SeatAPI {
+	return wlSeatAPI{i}
+}
+
+// wlSeatAPI is the SeatAPI of a Seat.
+type wlSeatAPI struct {
+	*Seat
+}
+
+var _ SeatAPI = wlSeatAPI{}
+
+// Unwrap returns the proxy, for APIProxy.
+func (a wlSeatAPI) Unwrap() Proxy {
+	return a.Seat
+}
+
+func (a wlSeatAPI) GetPointer() (PointerAPI, error) {
+	id, err := a.Seat.GetPointer()
+	if id == nil {
+		return nil, err
+	}
+	return id.API(), err
+}
+
+func (a wlSeatAPI) GetKeyboard() (KeyboardAPI, error) {
+	id, err := a.Seat.GetKeyboard()
+	if id == nil {
+		return nil, err
+	}
+	return id.API(), err
+}
+
+func (a wlSeatAPI) GetTouch() (TouchAPI, error) {
+	id, err := a.Seat.GetTouch()
+	if id == nil {
+		return nil, err
+	}
+	return id.API(), err
+}
+
+// FakeSeat is a SeatAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// Proxy, to be bound with the bind request of a fake registry.
+type FakeSeat struct {
+	BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+
+	capabilitiesHandler SeatCapabilitiesHandlerFunc
+	nameHandler         SeatNameHandlerFunc
+}
+
+var _ SeatAPI = (*FakeSeat)(nil)
+
+// GetPointer records the get_pointer request.
+func (i *FakeSeat) GetPointer() (PointerAPI, error) {
+	id := &FakePointer{}
+	i.Calls = append(i.Calls, FakeCall{Request: "get_pointer", Args: []any{id}})
+	return id, i.Err
+}
+
+// GetKeyboard records the get_keyboard request.
+func (i *FakeSeat) GetKeyboard() (KeyboardAPI, error) {
+	id := &FakeKeyboard{}
+	i.Calls = append(i.Calls, FakeCall{Request: "get_keyboard", Args: []any{id}})
+	return id, i.Err
+}
+
+// GetTouch records the get_touch request.
+func (i *FakeSeat) GetTouch() (TouchAPI, error) {
+	id := &FakeTouch{}
+	i.Calls = append(i.Calls, FakeCall{Request: "get_touch", Args: []any{id}})
+	return id, i.Err
+}
+
+// Release records the release request.
+func (i *FakeSeat) Release() error {
+	i.Calls = append(i.Calls, FakeCall{Request: "release"})
+	i.Destroyed = true
+	return i.Err
+}
+
+// Destroy marks the fake destroyed.
+func (i *FakeSeat) Destroy() error {
+	i.Destroyed = true
+	return i.Err
+}
+
+func (i *FakeSeat) SetCapabilitiesHandler(f SeatCapabilitiesHandlerFunc) {
+	i.capabilitiesHandler = f
+}
+
+// EmitCapabilities calls the handler of capabilities as if the event was received.
+func (i *FakeSeat) EmitCapabilities(e SeatCapabilitiesEvent) {
+	if i.capabilitiesHandler != nil {
+		i.capabilitiesHandler(e)
+	}
+}
+
+func (i *FakeSeat) SetNameHandler(f SeatNameHandlerFunc) {
+	i.nameHandler = f
+}
+
+// EmitName calls the handler of name as if the event was received.
+func (i *FakeSeat) EmitName(e SeatNameEvent) {
+	if i.nameHandler != nil {
+		i.nameHandler(e)
+	}
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *FakeSeat) SetListener(l SeatListener) {
+	if l == nil {
+		i.capabilitiesHandler = nil
+		i.nameHandler = nil
+		return
+	}
+	i.capabilitiesHandler = l.Capabilities
+	i.nameHandler = l.Name
+}
+
+// PointerAPI is the interface of the methods of Pointer, to
+// replace it with a FakePointer in tests. Pointer.API returns the
+// PointerAPI of a proxy.
+type PointerAPI interface {
+	SetCursor(serial uint32, surface SurfaceAPI, hotspotX, hotspotY int32) error
+	Release() error
+	Destroy() error
+	SetEnterHandler(f PointerEnterHandlerFunc)
+	SetLeaveHandler(f PointerLeaveHandlerFunc)
+	SetMotionHandler(f PointerMotionHandlerFunc)
+	SetButtonHandler(f PointerButtonHandlerFunc)
+	SetAxisHandler(f PointerAxisHandlerFunc)
+	SetFrameHandler(f PointerFrameHandlerFunc)
+	SetAxisSourceHandler(f PointerAxisSourceHandlerFunc)
+	SetAxisStopHandler(f PointerAxisStopHandlerFunc)
+	SetAxisDiscreteHandler(f PointerAxisDiscreteHandlerFunc)
+	SetAxisValue120Handler(f PointerAxisValue120HandlerFunc)
+	SetAxisRelativeDirectionHandler(f PointerAxisRelativeDirectionHandlerFunc)
+	SetListener(l PointerListener)
+}
+
+// API returns the Pointer as a PointerAPI, whose requests
+// take and return API interfaces.
+func (i *Pointer) API() PointerAPI {
+	return wlPointerAPI{i}
+}
+
+// wlPointerAPI is the PointerAPI of a Pointer.
+type wlPointerAPI struct {
+	*Pointer
+}
+
+var _ PointerAPI = wlPointerAPI{}
+
+// Unwrap returns the proxy, for APIProxy.
+func (a wlPointerAPI) Unwrap() Proxy {
+	return a.Pointer
+}
+
+func (a wlPointerAPI) SetCursor(serial uint32, surface SurfaceAPI, hotspotX, hotspotY int32) error {
+	surfaceProxy, err := APIProxy[*Surface](surface)
+	if err != nil {
+		return err
+	}
+	return a.Pointer.SetCursor(serial, surfaceProxy, hotspotX, hotspotY)
+}
+
+// FakePointer is a PointerAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// Proxy, to be bound with the bind request of a fake registry.
+type FakePointer struct {
+	BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+
+	enterHandler                 PointerEnterHandlerFunc
+	leaveHandler                 PointerLeaveHandlerFunc
+	motionHandler                PointerMotionHandlerFunc
+	buttonHandler                PointerButtonHandlerFunc
+	axisHandler                  PointerAxisHandlerFunc
+	frameHandler                 PointerFrameHandlerFunc
+	axisSourceHandler            PointerAxisSourceHandlerFunc
+	axisStopHandler              PointerAxisStopHandlerFunc
+	axisDiscreteHandler          PointerAxisDiscreteHandlerFunc
+	axisValue120Handler          PointerAxisValue120HandlerFunc
+	axisRelativeDirectionHandler PointerAxisRelativeDirectionHandlerFunc
+}
+
+var _ PointerAPI = (*FakePointer)(nil)
+
+// SetCursor records the set_cursor request.
+func (i *FakePointer) SetCursor(serial uint32, surface SurfaceAPI, hotspotX, hotspotY int32) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "set_cursor", Args: []any{serial, surface, hotspotX, hotspotY}})
+	return i.Err
+}
+
+// Release records the release request.
+func (i *FakePointer) Release() error {
+	i.Calls = append(i.Calls, FakeCall{Request: "release"})
+	i.Destroyed = true
+	return i.Err
+}
+
+// Destroy marks the fake destroyed.
+func (i *FakePointer) Destroy() error {
+	i.Destroyed = true
+	return i.Err
+}
+
+func (i *FakePointer) SetEnterHandler(f PointerEnterHandlerFunc) {
+	i.enterHandler = f
+}
+
+// EmitEnter calls the handler of enter as if the event was received.
+func (i *FakePointer) EmitEnter(e PointerEnterEvent) {
+	if i.enterHandler != nil {
+		i.enterHandler(e)
+	}
+}
+
+func (i *FakePointer) SetLeaveHandler(f PointerLeaveHandlerFunc) {
+	i.leaveHandler = f
+}
+
+// EmitLeave calls the handler of leave as if the event was received.
+func (i *FakePointer) EmitLeave(e PointerLeaveEvent) {
+	if i.leaveHandler != nil {
+		i.leaveHandler(e)
+	}
+}
+
+func (i *FakePointer) SetMotionHandler(f PointerMotionHandlerFunc) {
+	i.motionHandler = f
+}
+
+// EmitMotion calls the handler of motion as if the event was received.
+func (i *FakePointer) EmitMotion(e PointerMotionEvent) {
+	if i.motionHandler != nil {
+		i.motionHandler(e)
+	}
+}
+
+func (i *FakePointer) SetButtonHandler(f PointerButtonHandlerFunc) {
+	i.buttonHandler = f
+}
+
+// EmitButton calls the handler of button as if the event was received.
+func (i *FakePointer) EmitButton(e PointerButtonEvent) {
+	if i.buttonHandler != nil {
+		i.buttonHandler(e)
+	}
+}
+
+func (i *FakePointer) SetAxisHandler(f PointerAxisHandlerFunc) {
+	i.axisHandler = f
+}
+
+// EmitAxis calls the handler of axis as if the event was received.
+func (i *FakePointer) EmitAxis(e PointerAxisEvent) {
+	if i.axisHandler != nil {
+		i.axisHandler(e)
+	}
+}
+
+func (i *FakePointer) SetFrameHandler(f PointerFrameHandlerFunc) {
+	i.frameHandler = f
+}
+
+// EmitFrame calls the handler of frame as if the event was received.
+func (i *FakePointer) EmitFrame(e PointerFrameEvent) {
+	if i.frameHandler != nil {
+		i.frameHandler(e)
+	}
+}
+
+func (i *FakePointer) SetAxisSourceHandler(f PointerAxisSourceHandlerFunc) {
+	i.axisSourceHandler = f
+}
+
+// EmitAxisSource calls the handler of axis_source as if the event was received.
+func (i *FakePointer) EmitAxisSource(e PointerAxisSourceEvent) {
+	if i.axisSourceHandler != nil {
+		i.axisSourceHandler(e)
+	}
+}
+
+func (i *FakePointer) SetAxisStopHandler(f PointerAxisStopHandlerFunc) {
+	i.axisStopHandler = f
+}
+
+// EmitAxisStop calls the handler of axis_stop as if the event was received.
+func (i *FakePointer) EmitAxisStop(e PointerAxisStopEvent) {
+	if i.axisStopHandler != nil {
+		i.axisStopHandler(e)
+	}
+}
+
+func (i *FakePointer) SetAxisDiscreteHandler(f PointerAxisDiscreteHandlerFunc) {
+	i.axisDiscreteHandler = f
+}
+
+// EmitAxisDiscrete calls the handler of axis_discrete as if the event was received.
+func (i *FakePointer) EmitAxisDiscrete(e PointerAxisDiscreteEvent) {
+	if i.axisDiscreteHandler != nil {
+		i.axisDiscreteHandler(e)
+	}
+}
+
+func (i *FakePointer) SetAxisValue120Handler(f PointerAxisValue120HandlerFunc) {
+	i.axisValue120Handler = f
+}
+
+// EmitAxisValue120 calls the handler of axis_value120 as if the event was received.
+func (i *FakePointer) EmitAxisValue120(e PointerAxisValue120Event) {
+	if i.axisValue120Handler != nil {
+		i.axisValue120Handler(e)
+	}
+}
+
+func (i *FakePointer) SetAxisRelativeDirectionHandler(f PointerAxisRelativeDirectionHandlerFunc) {
+	i.axisRelativeDirectionHandler = f
+}
+
+// EmitAxisRelativeDirection calls the handler of axis_relative_direction as if the event was received.
+func (i *FakePointer) EmitAxisRelativeDirection(e PointerAxisRelativeDirectionEvent) {
+	if i.axisRelativeDirectionHandler != nil {
+		i.axisRelativeDirectionHandler(e)
+	}
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *FakePointer) SetListener(l PointerListener) {
+	if l == nil {
+		i.enterHandler = nil
+		i.leaveHandler = nil
+		i.motionHandler = nil
+		i.buttonHandler = nil
+		i.axisHandler = nil
+		i.frameHandler = nil
+		i.axisSourceHandler = nil
+		i.axisStopHandler = nil
+		i.axisDiscreteHandler = nil
+		i.axisValue120Handler = nil
+		i.axisRelativeDirectionHandler = nil
+		return
+	}
+	i.enterHandler = l.Enter
+	i.leaveHandler = l.Leave
+	i.motionHandler = l.Motion
+	i.buttonHandler = l.Button
+	i.axisHandler = l.Axis
+	i.frameHandler = l.Frame
+	i.axisSourceHandler = l.AxisSource
+	i.axisStopHandler = l.AxisStop
+	i.axisDiscreteHandler = l.AxisDiscrete
+	i.axisValue120Handler = l.AxisValue120
+	i.axisRelativeDirectionHandler = l.AxisRelativeDirection
+}
+
+// KeyboardAPI is the interface of the methods of Keyboard, to
+// replace it with a FakeKeyboard in tests. Keyboard.API returns the
+// KeyboardAPI of a proxy.
+type KeyboardAPI interface {
+	Release() error
+	Destroy() error
+	SetKeymapHandler(f KeyboardKeymapHandlerFunc)
+	SetEnterHandler(f KeyboardEnterHandlerFunc)
+	SetLeaveHandler(f KeyboardLeaveHandlerFunc)
+	SetKeyHandler(f KeyboardKeyHandlerFunc)
+	SetModifiersHandler(f KeyboardModifiersHandlerFunc)
+	SetRepeatInfoHandler(f KeyboardRepeatInfoHandlerFunc)
+	SetListener(l KeyboardListener)
+}
+
+// API returns the Keyboard as a KeyboardAPI, whose requests
+// take and return API interfaces.
+func (i *Keyboard) API() KeyboardAPI {
+	return wlKeyboardAPI{i}
+}
+
+// wlKeyboardAPI is the KeyboardAPI of a Keyboard.
+type wlKeyboardAPI struct {
+	*Keyboard
+}
+
+var _ KeyboardAPI = wlKeyboardAPI{}
+
+// Unwrap returns the proxy, for APIProxy.
+func (a wlKeyboardAPI) Unwrap() Proxy {
+	return a.Keyboard
+}
+
+// FakeKeyboard is a KeyboardAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// Proxy, to be bound with the bind request of a fake registry.
+type FakeKeyboard struct {
+	BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+
+	keymapHandler     KeyboardKeymapHandlerFunc
+	enterHandler      KeyboardEnterHandlerFunc
+	leaveHandler      KeyboardLeaveHandlerFunc
+	keyHandler        KeyboardKeyHandlerFunc
+	modifiersHandler  KeyboardModifiersHandlerFunc
+	repeatInfoHandler KeyboardRepeatInfoHandlerFunc
+}
+
+var _ KeyboardAPI = (*FakeKeyboard)(nil)
+
+// Release records the release request.
+func (i *FakeKeyboard) Release() error {
+	i.Calls = append(i.Calls, FakeCall{Request: "release"})
+	i.Destroyed = true
+	return i.Err
+}
+
+// Destroy marks the fake destroyed.
+func (i *FakeKeyboard) Destroy() error {
+	i.Destroyed = true
+	return i.Err
+}
+
+func (i *FakeKeyboard) SetKeymapHandler(f KeyboardKeymapHandlerFunc) {
+	i.keymapHandler = f
+}
+
+// EmitKeymap calls the handler of keymap as if the event was received.
+func (i *FakeKeyboard) EmitKeymap(e KeyboardKeymapEvent) {
+	if i.keymapHandler != nil {
+		i.keymapHandler(e)
+	}
+}
+
+func (i *FakeKeyboard) SetEnterHandler(f KeyboardEnterHandlerFunc) {
+	i.enterHandler = f
+}
+
+// EmitEnter calls the handler of enter as if the event was received.
+func (i *FakeKeyboard) EmitEnter(e KeyboardEnterEvent) {
+	if i.enterHandler != nil {
+		i.enterHandler(e)
+	}
+}
+
+func (i *FakeKeyboard) SetLeaveHandler(f KeyboardLeaveHandlerFunc) {
+	i.leaveHandler = f
+}
+
+// EmitLeave calls the handler of leave as if the event was received.
+func (i *FakeKeyboard) EmitLeave(e KeyboardLeaveEvent) {
+	if i.leaveHandler != nil {
+		i.leaveHandler(e)
+	}
+}
+
+func (i *FakeKeyboard) SetKeyHandler(f KeyboardKeyHandlerFunc) {
+	i.keyHandler = f
+}
+
+// EmitKey calls the handler of key as if the event was received.
+func (i *FakeKeyboard) EmitKey(e KeyboardKeyEvent) {
+	if i.keyHandler != nil {
+		i.keyHandler(e)
+	}
+}
+
+func (i *FakeKeyboard) SetModifiersHandler(f KeyboardModifiersHandlerFunc) {
+	i.modifiersHandler = f
+}
+
+// EmitModifiers calls the handler of modifiers as if the event was received.
+func (i *FakeKeyboard) EmitModifiers(e KeyboardModifiersEvent) {
+	if i.modifiersHandler != nil {
+		i.modifiersHandler(e)
+	}
+}
+
+func (i *FakeKeyboard) SetRepeatInfoHandler(f KeyboardRepeatInfoHandlerFunc) {
+	i.repeatInfoHandler = f
+}
+
+// EmitRepeatInfo calls the handler of repeat_info as if the event was received.
+func (i *FakeKeyboard) EmitRepeatInfo(e KeyboardRepeatInfoEvent) {
+	if i.repeatInfoHandler != nil {
+		i.repeatInfoHandler(e)
+	}
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *FakeKeyboard) SetListener(l KeyboardListener) {
+	if l == nil {
+		i.keymapHandler = nil
+		i.enterHandler = nil
+		i.leaveHandler = nil
+		i.keyHandler = nil
+		i.modifiersHandler = nil
+		i.repeatInfoHandler = nil
+		return
+	}
+	i.keymapHandler = l.Keymap
+	i.enterHandler = l.Enter
+	i.leaveHandler = l.Leave
+	i.keyHandler = l.Key
+	i.modifiersHandler = l.Modifiers
+	i.repeatInfoHandler = l.RepeatInfo
+}
+
+// TouchAPI is the interface of the methods of Touch, to
+// replace it with a FakeTouch in tests. Touch.API returns the
+// TouchAPI of a proxy.
+type TouchAPI interface {
+	Release() error
+	Destroy() error
+	SetDownHandler(f TouchDownHandlerFunc)
+	SetUpHandler(f TouchUpHandlerFunc)
+	SetMotionHandler(f TouchMotionHandlerFunc)
+	SetFrameHandler(f TouchFrameHandlerFunc)
+	SetCancelHandler(f TouchCancelHandlerFunc)
+	SetShapeHandler(f TouchShapeHandlerFunc)
+	SetOrientationHandler(f TouchOrientationHandlerFunc)
+	SetListener(l TouchListener)
+}
+
+// API returns the Touch as a TouchAPI, whose requests
+// take and return API interfaces.
+func (i *Touch) API() TouchAPI {
+	return wlTouchAPI{i}
+}
+
+// wlTouchAPI is the TouchAPI of a Touch.
+type wlTouchAPI struct {
+	*Touch
+}
+
+var _ TouchAPI = wlTouchAPI{}
+
+// Unwrap returns the proxy, for APIProxy.
+func (a wlTouchAPI) Unwrap() Proxy {
+	return a.Touch
+}
+
+// FakeTouch is a TouchAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// Proxy, to be bound with the bind request of a fake registry.
+type FakeTouch struct {
+	BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+
+	downHandler        TouchDownHandlerFunc
+	upHandler          TouchUpHandlerFunc
+	motionHandler      TouchMotionHandlerFunc
+	frameHandler       TouchFrameHandlerFunc
+	cancelHandler      TouchCancelHandlerFunc
+	shapeHandler       TouchShapeHandlerFunc
+	orientationHandler TouchOrientationHandlerFunc
+}
+
+var _ TouchAPI = (*FakeTouch)(nil)
+
+// Release records the release request.
+func (i *FakeTouch) Release() error {
+	i.Calls = append(i.Calls, FakeCall{Request: "release"})
+	i.Destroyed = true
+	return i.Err
+}
+
+// Destroy marks the fake destroyed.
+func (i *FakeTouch) Destroy() error {
+	i.Destroyed = true
+	return i.Err
+}
+
+func (i *FakeTouch) SetDownHandler(f TouchDownHandlerFunc) {
+	i.downHandler = f
+}
+
+// EmitDown calls the handler of down as if the event was received.
+func (i *FakeTouch) EmitDown(e TouchDownEvent) {
+	if i.downHandler != nil {
+		i.downHandler(e)
+	}
+}
+
+func (i *FakeTouch) SetUpHandler(f TouchUpHandlerFunc) {
+	i.upHandler = f
+}
+
+// EmitUp calls the handler of up as if the event was received.
+func (i *FakeTouch) EmitUp(e TouchUpEvent) {
+	if i.upHandler != nil {
+		i.upHandler(e)
+	}
+}
+
+func (i *FakeTouch) SetMotionHandler(f TouchMotionHandlerFunc) {
+	i.motionHandler = f
+}
+
+// EmitMotion calls the handler of motion as if the event was received.
+func (i *FakeTouch) EmitMotion(e TouchMotionEvent) {
+	if i.motionHandler != nil {
+		i.motionHandler(e)
+	}
+}
+
+func (i *FakeTouch) SetFrameHandler(f TouchFrameHandlerFunc) {
+	i.frameHandler = f
+}
+
+// EmitFrame calls the handler of frame as if the event was received.
+func (i *FakeTouch) EmitFrame(e TouchFrameEvent) {
+	if i.frameHandler != nil {
+		i.frameHandler(e)
+	}
+}
+
+func (i *FakeTouch) SetCancelHandler(f TouchCancelHandlerFunc) {
+	i.cancelHandler = f
+}
+
+// EmitCancel calls the handler of cancel as if the event was received.
+func (i *FakeTouch) EmitCancel(e TouchCancelEvent) {
+	if i.cancelHandler != nil {
+		i.cancelHandler(e)
+	}
+}
+
+func (i *FakeTouch) SetShapeHandler(f TouchShapeHandlerFunc) {
+	i.shapeHandler = f
+}
+
+// EmitShape calls the handler of shape as if the event was received.
+func (i *FakeTouch) EmitShape(e TouchShapeEvent) {
+	if i.shapeHandler != nil {
+		i.shapeHandler(e)
+	}
+}
+
+func (i *FakeTouch) SetOrientationHandler(f TouchOrientationHandlerFunc) {
+	i.orientationHandler = f
+}
+
+// EmitOrientation calls the handler of orientation as if the event was received.
+func (i *FakeTouch) EmitOrientation(e TouchOrientationEvent) {
+	if i.orientationHandler != nil {
+		i.orientationHandler(e)
+	}
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *FakeTouch) SetListener(l TouchListener) {
+	if l == nil {
+		i.downHandler = nil
+		i.upHandler = nil
+		i.motionHandler = nil
+		i.frameHandler = nil
+		i.cancelHandler = nil
+		i.shapeHandler = nil
+		i.orientationHandler = nil
+		return
+	}
+	i.downHandler = l.Down
+	i.upHandler = l.Up
+	i.motionHandler = l.Motion
+	i.frameHandler = l.Frame
+	i.cancelHandler = l.Cancel
+	i.shapeHandler = l.Shape
+	i.orientationHandler = l.Orientation
+}
+
+// OutputAPI is the interface of the methods of Output, to
+// replace it with a FakeOutput in tests. Output.API returns the
+// OutputAPI of a proxy.
+type OutputAPI interface {
+	Release() error
+	Destroy() error
+	SetGeometryHandler(f OutputGeometryHandlerFunc)
+	SetModeHandler(f OutputModeHandlerFunc)
+	SetDoneHandler(f OutputDoneHandlerFunc)
+	SetScaleHandler(f OutputScaleHandlerFunc)
+	SetNameHandler(f OutputNameHandlerFunc)
+	SetDescriptionHandler(f OutputDescriptionHandlerFunc)
+	SetListener(l OutputListener)
+}
+
+// API returns the Output as a OutputAPI, whose requests
+// take and return API interfaces.
+func (i *Output) API() OutputAPI {
+	return wlOutputAPI{i}
+}
+
+// wlOutputAPI is the OutputAPI of a Output.
+type wlOutputAPI struct {
+	*Output
+}
+
+var _ OutputAPI = wlOutputAPI{}
+
+// Unwrap returns the proxy, for APIProxy.
+func (a wlOutputAPI) Unwrap() Proxy {
+	return a.Output
+}
+
+// FakeOutput is a OutputAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// Proxy, to be bound with the bind request of a fake registry.
+type FakeOutput struct {
+	BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+
+	geometryHandler    OutputGeometryHandlerFunc
+	modeHandler        OutputModeHandlerFunc
+	doneHandler        OutputDoneHandlerFunc
+	scaleHandler       OutputScaleHandlerFunc
+	nameHandler        OutputNameHandlerFunc
+	descriptionHandler OutputDescriptionHandlerFunc
+}
+
+var _ OutputAPI = (*FakeOutput)(nil)
+
+// Release records the release request.
+func (i *FakeOutput) Release() error {
+	i.Calls = append(i.Calls, FakeCall{Request: "release"})
+	i.Destroyed = true
+	return i.Err
+}
+
+// Destroy marks the fake destroyed.
+func (i *FakeOutput) Destroy() error {
+	i.Destroyed = true
+	return i.Err
+}
+
+func (i *FakeOutput) SetGeometryHandler(f OutputGeometryHandlerFunc) {
+	i.geometryHandler = f
+}
+
+// EmitGeometry calls the handler of geometry as if the event was received.
+func (i *FakeOutput) EmitGeometry(e OutputGeometryEvent) {
+	if i.geometryHandler != nil {
+		i.geometryHandler(e)
+	}
+}
+
+func (i *FakeOutput) SetModeHandler(f OutputModeHandlerFunc) {
+	i.modeHandler = f
+}
+
+// EmitMode calls the handler of mode as if the event was received.
+func (i *FakeOutput) EmitMode(e OutputModeEvent) {
+	if i.modeHandler != nil {
+		i.modeHandler(e)
+	}
+}
+
+func (i *FakeOutput) SetDoneHandler(f OutputDoneHandlerFunc) {
+	i.doneHandler = f
+}
+
+// EmitDone calls the handler of done as if the event was received.
+func (i *FakeOutput) EmitDone(e OutputDoneEvent) {
+	if i.doneHandler != nil {
+		i.doneHandler(e)
+	}
+}
+
+func (i *FakeOutput) SetScaleHandler(f OutputScaleHandlerFunc) {
+	i.scaleHandler = f
+}
+
+// EmitScale calls the handler of scale as if the event was received.
+func (i *FakeOutput) EmitScale(e OutputScaleEvent) {
+	if i.scaleHandler != nil {
+		i.scaleHandler(e)
+	}
+}
+
+func (i *FakeOutput) SetNameHandler(f OutputNameHandlerFunc) {
+	i.nameHandler = f
+}
+
+// EmitName calls the handler of name as if the event was received.
+func (i *FakeOutput) EmitName(e OutputNameEvent) {
+	if i.nameHandler != nil {
+		i.nameHandler(e)
+	}
+}
+
+func (i *FakeOutput) SetDescriptionHandler(f OutputDescriptionHandlerFunc) {
+	i.descriptionHandler = f
+}
+
+// EmitDescription calls the handler of description as if the event was received.
+func (i *FakeOutput) EmitDescription(e OutputDescriptionEvent) {
+	if i.descriptionHandler != nil {
+		i.descriptionHandler(e)
+	}
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *FakeOutput) SetListener(l OutputListener) {
+	if l == nil {
+		i.geometryHandler = nil
+		i.modeHandler = nil
+		i.doneHandler = nil
+		i.scaleHandler = nil
+		i.nameHandler = nil
+		i.descriptionHandler = nil
+		return
+	}
+	i.geometryHandler = l.Geometry
+	i.modeHandler = l.Mode
+	i.doneHandler = l.Done
+	i.scaleHandler = l.Scale
+	i.nameHandler = l.Name
+	i.descriptionHandler = l.Description
+}
+
+// RegionAPI is the interface of the methods of Region, to
+// replace it with a FakeRegion in tests. Region.API returns the
+// RegionAPI of a proxy.
+type RegionAPI interface {
+	Destroy() error
+	Add(x, y, width, height int32) error
+	Subtract(x, y, width, height int32) error
+}
+
+// API returns the Region as a RegionAPI, whose requests
+// take and return API interfaces.
+func (i *Region) API() RegionAPI {
+	return wlRegionAPI{i}
+}
+
+// wlRegionAPI is the RegionAPI of a Region.
+type wlRegionAPI struct {
+	*Region
+}
+
+var _ RegionAPI = wlRegionAPI{}
+
+// Unwrap returns the proxy, for APIProxy.
+func (a wlRegionAPI) Unwrap() Proxy {
+	return a.Region
+}
+
+// FakeRegion is a RegionAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// Proxy, to be bound with the bind request of a fake registry.
+type FakeRegion struct {
+	BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+}
+
+var _ RegionAPI = (*FakeRegion)(nil)
+
+// Destroy records the destroy request.
+func (i *FakeRegion) Destroy() error {
+	i.Calls = append(i.Calls, FakeCall{Request: "destroy"})
+	i.Destroyed = true
+	return i.Err
+}
+
+// Add records the add request.
+func (i *FakeRegion) Add(x, y, width, height int32) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "add", Args: []any{x, y, width, height}})
+	return i.Err
+}
+
+// Subtract records the subtract request.
+func (i *FakeRegion) Subtract(x, y, width, height int32) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "subtract", Args: []any{x, y, width, height}})
+	return i.Err
+}
+
+// SubcompositorAPI is the interface of the methods of Subcompositor, to
+// replace it with a FakeSubcompositor in tests. Subcompositor.API returns the
+// SubcompositorAPI of a proxy.
+type SubcompositorAPI interface {
+	Destroy() error
+	GetSubsurface(surface, parent SurfaceAPI) (SubsurfaceAPI, error)
+}
+
+// API returns the Subcompositor as a SubcompositorAPI, whose requests
+// take and return API interfaces.
+func (i *Subcompositor) API() SubcompositorAPI {
+	return wlSubcompositorAPI{i}
+}
+
+// wlSubcompositorAPI is the SubcompositorAPI of a Subcompositor.
+type wlSubcompositorAPI struct {
+	*Subcompositor
+}
+
+var _ SubcompositorAPI = wlSubcompositorAPI{}
+
+// Unwrap returns the proxy, for APIProxy.
+func (a wlSubcompositorAPI) Unwrap() Proxy {
+	return a.Subcompositor
+}
+
+func (a wlSubcompositorAPI) GetSubsurface(surface, parent SurfaceAPI) (SubsurfaceAPI, error) {
+	surfaceProxy, err := APIProxy[*Surface](surface)
+	if err != nil {
+		return nil, err
+	}
+	parentProxy, err := APIProxy[*Surface](parent)
+	if err != nil {
+		return nil, err
+	}
+	id, err := a.Subcompositor.GetSubsurface(surfaceProxy, parentProxy)
+	if id == nil {
+		return nil, err
+	}
+	return id.API(), err
+}
+
+// FakeSubcompositor is a SubcompositorAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// Proxy, to be bound with the bind request of a fake registry.
+type FakeSubcompositor struct {
+	BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+}
+
+var _ SubcompositorAPI = (*FakeSubcompositor)(nil)
+
+// Destroy records the destroy request.
+func (i *FakeSubcompositor) Destroy() error {
+	i.Calls = append(i.Calls, FakeCall{Request: "destroy"})
+	i.Destroyed = true
+	return i.Err
+}
+
+// GetSubsurface records the get_subsurface request.
+func (i *FakeSubcompositor) GetSubsurface(surface, parent SurfaceAPI) (SubsurfaceAPI, error) {
+	id := &FakeSubsurface{}
+	i.Calls = append(i.Calls, FakeCall{Request: "get_subsurface", Args: []any{id, surface, parent}})
+	return id, i.Err
+}
+
+// SubsurfaceAPI is the interface of the methods of Subsurface, to
+// replace it with a FakeSubsurface in tests. Subsurface.API returns the
+// SubsurfaceAPI of a proxy.
+type SubsurfaceAPI interface {
+	Destroy() error
+	SetPosition(x, y int32) error
+	PlaceAbove(sibling SurfaceAPI) error
+	PlaceBelow(sibling SurfaceAPI) error
+	SetSync() error
+	SetDesync() error
+}
+
+// API returns the Subsurface as a SubsurfaceAPI, whose requests
+// take and return API interfaces.
+func (i *Subsurface) API() SubsurfaceAPI {
+	return wlSubsurfaceAPI{i}
+}
+
+// wlSubsurfaceAPI is the SubsurfaceAPI of a Subsurface.
+type wlSubsurfaceAPI struct {
+	*Subsurface
+}
+
+var _ SubsurfaceAPI = wlSubsurfaceAPI{}
+
+// Unwrap returns the proxy, for APIProxy.
+func (a wlSubsurfaceAPI) Unwrap() Proxy {
+	return a.Subsurface
+}
+
+func (a wlSubsurfaceAPI) PlaceAbove(sibling SurfaceAPI) error {
+	siblingProxy, err := APIProxy[*Surface](sibling)
+	if err != nil {
+		return err
+	}
+	return a.Subsurface.PlaceAbove(siblingProxy)
+}
+
+func (a wlSubsurfaceAPI) PlaceBelow(sibling SurfaceAPI) error {
+	siblingProxy, err := APIProxy[*Surface](sibling)
+	if err != nil {
+		return err
+	}
+	return a.Subsurface.PlaceBelow(siblingProxy)
+}
+
+// FakeSubsurface is a SubsurfaceAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// Proxy, to be bound with the bind request of a fake registry.
+type FakeSubsurface struct {
+	BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+}
+
+var _ SubsurfaceAPI = (*FakeSubsurface)(nil)
+
+// Destroy records the destroy request.
+func (i *FakeSubsurface) Destroy() error {
+	i.Calls = append(i.Calls, FakeCall{Request: "destroy"})
+	i.Destroyed = true
+	return i.Err
+}
+
+// SetPosition records the set_position request.
+func (i *FakeSubsurface) SetPosition(x, y int32) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "set_position", Args: []any{x, y}})
+	return i.Err
+}
+
+// PlaceAbove records the place_above request.
+func (i *FakeSubsurface) PlaceAbove(sibling SurfaceAPI) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "place_above", Args: []any{sibling}})
+	return i.Err
+}
+
+// PlaceBelow records the place_below request.
+func (i *FakeSubsurface) PlaceBelow(sibling SurfaceAPI) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "place_below", Args: []any{sibling}})
+	return i.Err
+}
+
+// SetSync records the set_sync request.
+func (i *FakeSubsurface) SetSync() error {
+	i.Calls = append(i.Calls, FakeCall{Request: "set_sync"})
+	return i.Err
+}
+
+// SetDesync records the set_desync request.
+func (i *FakeSubsurface) SetDesync() error {
+	i.Calls = append(i.Calls, FakeCall{Request: "set_desync"})
+	return i.Err
+}
+
+// FixesAPI is the interface of the methods of Fixes, to
+// replace it with a FakeFixes in tests. Fixes.API returns the
+// FixesAPI of a proxy.
+type FixesAPI interface {
+	Destroy() error
+	DestroyRegistry(registry RegistryAPI) error
+}
+
+// API returns the Fixes as a FixesAPI, whose requests
+// take and return API interfaces.
+func (i *Fixes) API() FixesAPI {
+	return wlFixesAPI{i}
+}
+
+// wlFixesAPI is the FixesAPI of a Fixes.
+type wlFixesAPI struct {
+	*Fixes
+}
+
+var _ FixesAPI = wlFixesAPI{}
+
+// Unwrap returns the proxy, for APIProxy.
+func (a wlFixesAPI) Unwrap() Proxy {
+	return a.Fixes
+}
+
+func (a wlFixesAPI) DestroyRegistry(registry RegistryAPI) error {
+	registryProxy, err := APIProxy[*Registry](registry)
+	if err != nil {
+		return err
+	}
+	return a.Fixes.DestroyRegistry(registryProxy)
+}
+
+// FakeFixes is a FixesAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// Proxy, to be bound with the bind request of a fake registry.
+type FakeFixes struct {
+	BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+}
+
+var _ FixesAPI = (*FakeFixes)(nil)
+
+// Destroy records the destroy request.
+func (i *FakeFixes) Destroy() error {
+	i.Calls = append(i.Calls, FakeCall{Request: "destroy"})
+	i.Destroyed = true
+	return i.Err
+}
+
+// DestroyRegistry records the destroy_registry request.
+func (i *FakeFixes) DestroyRegistry(registry RegistryAPI) error {
+	i.Calls = append(i.Calls, FakeCall{Request: "destroy_registry", Args: []any{registry}})
+	return i.Err
+}
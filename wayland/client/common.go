@@ -1,5 +1,7 @@
 package client
 
+import "fmt"
+
 type Dispatcher interface {
 	Dispatch(opcode uint32, fd int, data []byte)
 }
@@ -9,11 +11,14 @@ type Proxy interface {
 	SetContext(ctx *Context)
 	ID() uint32
 	SetID(id uint32)
+	Version() uint32
+	SetVersion(version uint32)
 }
 
 type BaseProxy struct {
-	ctx *Context
-	id  uint32
+	ctx     *Context
+	id      uint32
+	version uint32
 }
 
 func (p *BaseProxy) ID() uint32 {
@@ -31,3 +36,27 @@ func (p *BaseProxy) Context() *Context {
 func (p *BaseProxy) SetContext(ctx *Context) {
 	p.ctx = ctx
 }
+
+// Version returns the version of the object: the one it was bound with
+// for globals, the one of the object which created it otherwise. It is 0
+// when unknown, then requests aren't checked against it.
+func (p *BaseProxy) Version() uint32 {
+	return p.version
+}
+
+func (p *BaseProxy) SetVersion(version uint32) {
+	p.version = version
+}
+
+// VersionError is returned by requests made on an object whose version
+// is older than the one the request was added in.
+type VersionError struct {
+	Interface string
+	Request   string
+	Since     uint32
+	Version   uint32
+}
+
+func (e *VersionError) Error() string {
+	return fmt.Sprintf("%s.%s needs version %d, object has version %d", e.Interface, e.Request, e.Since, e.Version)
+}
@@ -20,6 +20,14 @@ func (ctx *Context) Register(p Proxy) {
 	ctx.objects[ctx.currentID] = p
 }
 
+// RegisterWithID registers an object created by the server, which
+// allocates the id itself.
+func (ctx *Context) RegisterWithID(p Proxy, id uint32) {
+	p.SetID(id)
+	p.SetContext(ctx)
+	ctx.objects[id] = p
+}
+
 func (ctx *Context) Unregister(p Proxy) {
 	delete(ctx.objects, p.ID())
 }
@@ -67,15 +75,21 @@ func Connect(addr string) (*Display, error) {
 		addr = runtimeDir + "/" + addr
 	}
 
-	ctx := &Context{
-		objects: map[uint32]Proxy{},
-	}
-
 	conn, err := net.DialUnix("unix", nil, &net.UnixAddr{Name: addr, Net: "unix"})
 	if err != nil {
 		return nil, err
 	}
-	ctx.conn = conn
 
-	return NewDisplay(ctx), nil
+	return ConnectConn(conn), nil
+}
+
+// ConnectConn uses an already established connection to the wayland
+// server, e.g. one end of a socketpair or an inherited WAYLAND_SOCKET.
+func ConnectConn(conn *net.UnixConn) *Display {
+	ctx := &Context{
+		conn:    conn,
+		objects: map[uint32]Proxy{},
+	}
+
+	return NewDisplay(ctx)
 }
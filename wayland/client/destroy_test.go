@@ -0,0 +1,54 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+	"github.com/rajveermalviya/go-wayland/wayland/waylandtest"
+)
+
+func TestDestroyRelease(t *testing.T) {
+	// version is the version of the proxy, 0 when it is unknown
+	for _, tt := range []struct {
+		global, version uint32
+		release         bool
+	}{
+		{1, 1, false},
+		{2, 2, true},
+		{2, 0, true},
+	} {
+		version := tt.version
+		s := waylandtest.NewServer(t)
+		s.AddGlobal("wl_shm", tt.global)
+
+		registry, err := s.Display().GetRegistry()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var shm *client.Shm
+		registry.SetGlobalHandler(func(e client.RegistryGlobalEvent) {
+			shm, _ = client.Bind[client.Shm](registry, e.Name, e.Version)
+		})
+		s.Roundtrip()
+		s.Expect("wl_display", "get_registry")
+		s.Expect("wl_registry", "bind")
+		shm.SetVersion(version)
+
+		if err := shm.Destroy(); err != nil {
+			t.Fatal(err)
+		}
+		if shm.Context().GetProxy(shm.ID()) != nil {
+			t.Errorf("version %d: destroyed wl_shm is still registered", version)
+		}
+		// A request after Destroy shows whether release was sent
+		if _, err := s.Display().GetRegistry(); err != nil {
+			t.Fatal(err)
+		}
+		s.Roundtrip()
+		if tt.release {
+			s.Expect("wl_shm", "release")
+		}
+		s.Expect("wl_display", "get_registry")
+		s.Close()
+	}
+}
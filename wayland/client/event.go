@@ -113,8 +113,20 @@ func String(src []byte) string {
 	return *(*string)(unsafe.Pointer(&src))
 }
 
-func Fixed(src []byte) float64 {
-	_ = src[3]
-	fx := *(*int32)(unsafe.Pointer(&src[0]))
-	return fixedToFloat64(fx)
+// FixedFloat64 decodes a fixed argument as a float64. It was Fixed before
+// client.Fixed took the name.
+func FixedFloat64(src []byte) float64 {
+	return Fixed(Uint32(src)).Float64()
+}
+
+// Array copies the elements of an array argument, trailing bytes which
+// don't make up a whole element are ignored.
+func Array[T ArrayElement](src []byte) []T {
+	var zero T
+	size := int(unsafe.Sizeof(zero))
+	dst := make([]T, len(src)/size)
+	if len(dst) > 0 {
+		copy(unsafe.Slice((*byte)(unsafe.Pointer(&dst[0])), len(dst)*size), src)
+	}
+	return dst
 }
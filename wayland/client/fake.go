@@ -0,0 +1,32 @@
+package client
+
+import "fmt"
+
+// FakeCall is a request recorded by the fakes go-wayland-scanner
+// generates with -api, such as FakeSurface.
+type FakeCall struct {
+	// Request is the name of the request in the protocol XML.
+	Request string
+	// Args are the arguments of the request, in order. A new_id without
+	// interface is recorded as its interface name, version and object.
+	Args []any
+}
+
+// APIProxy returns the proxy of an API interface value, such as the
+// SurfaceAPI returned by Surface.API. It returns the zero P for nil and an
+// error for fakes, which can't be sent to the compositor.
+func APIProxy[P Proxy](v any) (P, error) {
+	var zero P
+	if v == nil {
+		return zero, nil
+	}
+	if p, ok := v.(P); ok {
+		return p, nil
+	}
+	if u, ok := v.(interface{ Unwrap() Proxy }); ok {
+		if p, ok := u.Unwrap().(P); ok {
+			return p, nil
+		}
+	}
+	return zero, fmt.Errorf("client: %T is not a %T", v, zero)
+}
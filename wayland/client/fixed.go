@@ -0,0 +1,59 @@
+package client
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Fixed is a wl_fixed_t, a signed 24.8 fixed-point number: the number
+// multiplied by 256.
+type Fixed int32
+
+// MinFixed and MaxFixed are the smallest and largest Fixed.
+const (
+	MinFixed Fixed = math.MinInt32
+	MaxFixed Fixed = math.MaxInt32
+)
+
+// FixedFromInt returns i as a Fixed, or an error when it is out of the
+// range of the integer part, [-2^23, 2^23).
+func FixedFromInt(i int) (Fixed, error) {
+	if i < int(MinFixed>>8) || i > int(MaxFixed>>8) {
+		return 0, fmt.Errorf("client.FixedFromInt: %d is out of the range of fixed", i)
+	}
+	return Fixed(i << 8), nil
+}
+
+// FixedFromFloat64 returns the Fixed closest to f, rounding halfway cases
+// to even like libwayland. It returns an error when f is NaN or its
+// rounded value is out of the range of Fixed.
+func FixedFromFloat64(f float64) (Fixed, error) {
+	v := math.RoundToEven(f * 256)
+	if math.IsNaN(v) || v < float64(MinFixed) || v > float64(MaxFixed) {
+		return 0, fmt.Errorf("client.FixedFromFloat64: %v is out of the range of fixed", f)
+	}
+	return Fixed(v), nil
+}
+
+// Float64 returns f as a float64, which represents every Fixed exactly.
+func (f Fixed) Float64() float64 {
+	return float64(f) / 256
+}
+
+// Int returns the integer part of f, rounded down so that f equals
+// Int()*256 + Frac(). It differs from wl_fixed_to_int, which rounds toward
+// zero, for negative numbers with a fractional part.
+func (f Fixed) Int() int {
+	return int(f >> 8)
+}
+
+// Frac returns the fractional part of f in 1/256 units, in [0, 256).
+func (f Fixed) Frac() int {
+	return int(f & 0xff)
+}
+
+// String formats f as its shortest exact decimal representation.
+func (f Fixed) String() string {
+	return strconv.FormatFloat(f.Float64(), 'g', -1, 64)
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFixed(t *testing.T) {
+	tests := []struct {
+		f     Fixed
+		float float64
+		i     int
+		frac  int
+		str   string
+	}{
+		{0, 0, 0, 0, "0"},
+		{384, 1.5, 1, 128, "1.5"},
+		{1, 0.00390625, 0, 1, "0.00390625"},
+		{-832, -3.25, -4, 192, "-3.25"},
+		{-1, -0.00390625, -1, 255, "-0.00390625"},
+		{MaxFixed, 8388607.99609375, 8388607, 255, "8.38860799609375e+06"},
+		{MinFixed, -8388608, -8388608, 0, "-8.388608e+06"},
+	}
+	for _, tt := range tests {
+		if got := tt.f.Float64(); got != tt.float {
+			t.Errorf("Fixed(%d).Float64() = %v, want %v", int32(tt.f), got, tt.float)
+		}
+		if got := tt.f.Int(); got != tt.i {
+			t.Errorf("Fixed(%d).Int() = %d, want %d", int32(tt.f), got, tt.i)
+		}
+		if got := tt.f.Frac(); got != tt.frac {
+			t.Errorf("Fixed(%d).Frac() = %d, want %d", int32(tt.f), got, tt.frac)
+		}
+		if got := tt.f.String(); got != tt.str {
+			t.Errorf("Fixed(%d).String() = %s, want %s", int32(tt.f), got, tt.str)
+		}
+		if got, err := FixedFromFloat64(tt.float); err != nil || got != tt.f {
+			t.Errorf("FixedFromFloat64(%v) = %d, %v, want %d", tt.float, int32(got), err, int32(tt.f))
+		}
+	}
+}
+
+func TestFixedFromFloat64(t *testing.T) {
+	tests := []struct {
+		float float64
+		want  Fixed
+	}{
+		// Rounded to the nearest 1/256, halfway cases to even
+		{0.001, 0},
+		{0.002, 1},
+		{1.0 / 512, 0},
+		{3.0 / 512, 2},
+		{-0.002, -1},
+		{-8388608.001, MinFixed},
+	}
+	for _, tt := range tests {
+		if got, err := FixedFromFloat64(tt.float); err != nil || got != tt.want {
+			t.Errorf("FixedFromFloat64(%v) = %d, %v, want %d", tt.float, int32(got), err, int32(tt.want))
+		}
+		// PutFixed rounds the same way
+		var b [4]byte
+		PutFixed(b[:], tt.float)
+		if got := Fixed(Uint32(b[:])); got != tt.want {
+			t.Errorf("PutFixed(%v) wrote %d, want %d", tt.float, int32(got), int32(tt.want))
+		}
+	}
+
+	// Out of range, PutFixed clamps instead of wrapping around
+	outOfRange := []struct {
+		float float64
+		put   Fixed
+	}{
+		{8388608, MaxFixed},
+		{-8388608.002, MinFixed},
+		{math.Inf(1), MaxFixed},
+		{math.Inf(-1), MinFixed},
+		{math.NaN(), 0},
+		{1e300, MaxFixed},
+		{-1e10, MinFixed},
+	}
+	for _, tt := range outOfRange {
+		if got, err := FixedFromFloat64(tt.float); err == nil {
+			t.Errorf("FixedFromFloat64(%v) = %d, want an error", tt.float, int32(got))
+		}
+		var b [4]byte
+		PutFixed(b[:], tt.float)
+		if got := Fixed(Uint32(b[:])); got != tt.put {
+			t.Errorf("PutFixed(%v) wrote %d, want %d", tt.float, int32(got), int32(tt.put))
+		}
+	}
+}
+
+func TestFixedFromInt(t *testing.T) {
+	for _, i := range []int{0, 1, -1, 8388607, -8388608} {
+		f, err := FixedFromInt(i)
+		if err != nil || f.Int() != i || f.Frac() != 0 {
+			t.Errorf("FixedFromInt(%d) = %d, %v", i, int32(f), err)
+		}
+	}
+	for _, i := range []int{8388608, -8388609, math.MaxInt32} {
+		if f, err := FixedFromInt(i); err == nil {
+			t.Errorf("FixedFromInt(%d) = %d, want an error", i, int32(f))
+		}
+	}
+}
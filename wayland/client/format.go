@@ -0,0 +1,47 @@
+package client
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// The generated String and LogValue methods of events format their
+// arguments with these helpers.
+
+// ObjectString formats an object as interface@id, "nil" when it is null.
+func ObjectString(p Proxy) string {
+	if p == nil || reflect.ValueOf(p).IsNil() {
+		return "nil"
+	}
+	name := "unknown"
+	if iface := InterfaceOf(p); iface != nil {
+		name = iface.Name
+	}
+	return name + "@" + strconv.FormatUint(uint64(p.ID()), 10)
+}
+
+// EnumString formats an enum value as its name, or as a number when it
+// has none.
+func EnumString(name string, value uint32) string {
+	if name == "" {
+		return strconv.FormatUint(uint64(value), 10)
+	}
+	return name
+}
+
+// NullableString quotes a nullable string, "nil" when it is null.
+func NullableString(s *string) string {
+	if s == nil {
+		return "nil"
+	}
+	return strconv.Quote(*s)
+}
+
+// NullableValue returns the value of a nullable string, nil when it is
+// null.
+func NullableValue(s *string) any {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
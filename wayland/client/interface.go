@@ -0,0 +1,244 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/sys/unix"
+)
+
+// Interface describes a wayland interface at runtime, the same
+// information libwayland keeps in a wl_interface plus the enums.
+//
+// go-wayland-scanner generates one for every interface and registers it
+// with RegisterInterface, so they can be found by name with
+// LookupInterface.
+type Interface struct {
+	Name    string
+	Version uint32
+	// Requests and Events are indexed by opcode.
+	Requests []Message
+	Events   []Message
+	Enums    []Enum
+	// New returns a new proxy of the interface, not registered with a
+	// context yet. It is nil for interfaces without generated code.
+	New func() Proxy
+}
+
+// Message describes a request or an event.
+type Message struct {
+	Name string
+	// Since is the interface version the message was added in, 0 when it
+	// is part of the first version.
+	Since uint32
+	// DeprecatedSince is the interface version the message is deprecated
+	// in, 0 when it isn't.
+	DeprecatedSince uint32
+	Destructor      bool
+	Args            []Arg
+}
+
+// Arg describes an argument of a message.
+type Arg struct {
+	Name string
+	// Type is the argument type of the protocol XML: "int", "uint",
+	// "fixed", "string", "object", "new_id", "array" or "fd".
+	Type string
+	// Interface of object and new_id arguments, empty when the argument
+	// can be of any interface.
+	Interface string
+	// Enum is the qualified name ("interface.enum") of the enum of int
+	// and uint arguments, if any.
+	Enum     string
+	Nullable bool
+}
+
+// Enum describes an enum of an interface.
+type Enum struct {
+	Name     string
+	Since    uint32
+	Bitfield bool
+	Entries  []EnumEntry
+}
+
+// EnumEntry is a named value of an enum.
+type EnumEntry struct {
+	Name            string
+	Value           uint32
+	Since           uint32
+	DeprecatedSince uint32
+}
+
+var signatureChars = map[string]byte{
+	"int":    'i',
+	"uint":   'u',
+	"fixed":  'f',
+	"string": 's',
+	"object": 'o',
+	"new_id": 'n',
+	"array":  'a',
+	"fd":     'h',
+}
+
+// Signature returns the signature of the message in libwayland's
+// notation: the version it was added in when above 1, then one character
+// per argument, prefixed with '?' when the argument is nullable. A new_id
+// without interface is "sun", the interface name and version being sent
+// before the id.
+func (m *Message) Signature() string {
+	var b strings.Builder
+	if m.Since > 1 {
+		fmt.Fprint(&b, m.Since)
+	}
+	for _, arg := range m.Args {
+		if arg.Nullable {
+			b.WriteByte('?')
+		}
+		if arg.Type == "new_id" && arg.Interface == "" {
+			b.WriteString("su")
+		}
+		b.WriteByte(signatureChars[arg.Type])
+	}
+	return b.String()
+}
+
+// Request returns the request with the given opcode, or nil.
+func (i *Interface) Request(opcode uint32) *Message {
+	if opcode >= uint32(len(i.Requests)) {
+		return nil
+	}
+	return &i.Requests[opcode]
+}
+
+// Event returns the event with the given opcode, or nil.
+func (i *Interface) Event(opcode uint32) *Message {
+	if opcode >= uint32(len(i.Events)) {
+		return nil
+	}
+	return &i.Events[opcode]
+}
+
+// Enum returns the enum with the given name, or nil.
+func (i *Interface) Enum(name string) *Enum {
+	for j := range i.Enums {
+		if i.Enums[j].Name == name {
+			return &i.Enums[j]
+		}
+	}
+	return nil
+}
+
+// EntryName returns the name of the entry with the given value, or ""
+// when there is none.
+func (e *Enum) EntryName(value uint32) string {
+	for _, entry := range e.Entries {
+		if entry.Value == value {
+			return entry.Name
+		}
+	}
+	return ""
+}
+
+var interfaces = struct {
+	sync.RWMutex
+	m map[string]*Interface
+}{m: map[string]*Interface{}}
+
+// RegisterInterface adds the description of an interface to the ones
+// returned by LookupInterface. It panics if an interface with the same
+// name is already registered.
+func RegisterInterface(iface *Interface) {
+	interfaces.Lock()
+	defer interfaces.Unlock()
+
+	if _, ok := interfaces.m[iface.Name]; ok {
+		panic("wayland: interface " + iface.Name + " registered twice")
+	}
+	interfaces.m[iface.Name] = iface
+}
+
+// LookupInterface returns the description of the interface with the
+// given name, or nil if no package registered it.
+func LookupInterface(name string) *Interface {
+	interfaces.RLock()
+	defer interfaces.RUnlock()
+
+	return interfaces.m[name]
+}
+
+// Interfaces returns every registered interface, sorted by name.
+func Interfaces() []*Interface {
+	interfaces.RLock()
+	defer interfaces.RUnlock()
+
+	ifaces := make([]*Interface, 0, len(interfaces.m))
+	for _, iface := range interfaces.m {
+		ifaces = append(ifaces, iface)
+	}
+	sort.Slice(ifaces, func(i, j int) bool { return ifaces[i].Name < ifaces[j].Name })
+	return ifaces
+}
+
+// NewProxy returns a new proxy of the registered interface with the
+// given name, not registered with a context yet, or nil when no package
+// registered the interface or it has no generated code.
+func NewProxy(name string) Proxy {
+	iface := LookupInterface(name)
+	if iface == nil || iface.New == nil {
+		return nil
+	}
+	return iface.New()
+}
+
+// UnknownProxy stands for an object created by an event whose interface
+// has no generated code, NewProxy returned nil. It reserves the id of the
+// object and drops its events.
+type UnknownProxy struct {
+	BaseProxy
+	// Name is the name of the interface of the object
+	Name string
+}
+
+// Dispatch drops the event, closing its file descriptor if any.
+func (p *UnknownProxy) Dispatch(opcode uint32, fd int, data []byte) {
+	if fd != -1 {
+		unix.Close(fd)
+	}
+}
+
+// Destroy forgets the object, no request of its interface is known.
+func (p *UnknownProxy) Destroy() error {
+	p.Context().Unregister(p)
+	return nil
+}
+
+// InterfaceOf returns the description of the interface of a proxy, nil
+// when its type doesn't provide one. Generated proxies do.
+func InterfaceOf(p Proxy) *Interface {
+	if p, ok := p.(interface{ Interface() *Interface }); ok {
+		return p.Interface()
+	}
+	return nil
+}
+
+// Error formats a protocol error, naming the error code after the error
+// enum of the interface of the object when it is known.
+func (e DisplayErrorEvent) Error() string {
+	if e.ObjectId == nil {
+		return fmt.Sprintf("protocol error %d: %s", e.Code, e.Message)
+	}
+
+	object := fmt.Sprintf("object %d", e.ObjectId.ID())
+	code := fmt.Sprint(e.Code)
+	if iface := InterfaceOf(e.ObjectId); iface != nil {
+		object = fmt.Sprintf("%s#%d", iface.Name, e.ObjectId.ID())
+		if enum := iface.Enum("error"); enum != nil {
+			if name := enum.EntryName(e.Code); name != "" {
+				code += " (" + name + ")"
+			}
+		}
+	}
+	return fmt.Sprintf("%s: protocol error %s: %s", object, code, e.Message)
+}
@@ -0,0 +1,25 @@
+package client
+
+// Bind binds the global with the given numeric name to a new proxy of
+// type *T, such as Bind[Compositor](registry, e.Name, e.Version) in a
+// registry global handler. The interface name is the one of T and the
+// version is lowered to the version of T when the global is newer, as the
+// generated code can't handle what it doesn't know.
+func Bind[T any, P interface {
+	*T
+	Proxy
+	Interface() *Interface
+}](r *Registry, name uint32, version uint32) (P, error) {
+	p := P(new(T))
+	iface := p.Interface()
+	if version > iface.Version {
+		version = iface.Version
+	}
+
+	r.Context().Register(p)
+	if err := r.Bind(name, iface.Name, version, p); err != nil {
+		r.Context().Unregister(p)
+		return nil, err
+	}
+	return p, nil
+}
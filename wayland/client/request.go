@@ -22,14 +22,27 @@ func PutUint32(dst []byte, v uint32) {
 	*(*uint32)(unsafe.Pointer(&dst[0])) = v
 }
 
+// PutFixed writes f as a fixed argument, rounded like FixedFromFloat64.
+// Values out of the range of Fixed are clamped to MinFixed or MaxFixed,
+// and NaN is written as 0.
 func PutFixed(dst []byte, f float64) {
-	fx := fixedFromfloat64(f)
-	_ = dst[3]
-	*(*int32)(unsafe.Pointer(&dst[0])) = fx
+	fx, err := FixedFromFloat64(f)
+	if err != nil {
+		switch {
+		case f > 0:
+			fx = MaxFixed
+		case f < 0:
+			fx = MinFixed
+		}
+	}
+	PutUint32(dst, uint32(fx))
 }
 
-func PutString(dst []byte, v string, l int) {
-	PutUint32(dst[:4], uint32(l))
+// PutString writes a string argument, dst must hold its padded length.
+// The length on the wire counts the terminating NUL but not the padding,
+// like libwayland.
+func PutString(dst []byte, v string) {
+	PutUint32(dst[:4], uint32(len(v)+1))
 	copy(dst[4:], []byte(v))
 }
 
@@ -37,3 +50,13 @@ func PutArray(dst []byte, a []byte) {
 	PutUint32(dst[:4], uint32(len(a)))
 	copy(dst[4:], a)
 }
+
+// ArrayBytes returns the bytes of a typed array argument, without
+// copying them.
+func ArrayBytes[T ArrayElement](a []T) []byte {
+	if len(a) == 0 {
+		return nil
+	}
+	var zero T
+	return unsafe.Slice((*byte)(unsafe.Pointer(&a[0])), len(a)*int(unsafe.Sizeof(zero)))
+}
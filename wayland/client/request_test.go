@@ -0,0 +1,29 @@
+package client
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPutString(t *testing.T) {
+	tests := []struct {
+		v    string
+		want []byte
+	}{
+		{"", []byte{1, 0, 0, 0, 0, 0, 0, 0}},
+		{"abc", []byte{4, 0, 0, 0, 'a', 'b', 'c', 0}},
+		{"abcd", []byte{5, 0, 0, 0, 'a', 'b', 'c', 'd', 0, 0, 0, 0}},
+		{"wl_shm", []byte{7, 0, 0, 0, 'w', 'l', '_', 's', 'h', 'm', 0, 0}},
+	}
+	for _, tt := range tests {
+		l := PaddedLen(len(tt.v) + 1)
+		dst := make([]byte, 4+l)
+		PutString(dst, tt.v)
+		if !bytes.Equal(dst, tt.want) {
+			t.Errorf("PutString(%q) wrote %v, want %v", tt.v, dst, tt.want)
+		}
+		if got := String(dst[4:]); got != tt.v {
+			t.Errorf("String read back %q, want %q", got, tt.v)
+		}
+	}
+}
@@ -3,12 +3,10 @@ package cursor
 
 import (
 	"errors"
-	"os"
 
 	"github.com/rajveermalviya/go-wayland/wayland/client"
 	"github.com/rajveermalviya/go-wayland/wayland/cursor/xcursor"
-	"github.com/rajveermalviya/go-wayland/wayland/internal/tempfile"
-	"golang.org/x/sys/unix"
+	"github.com/rajveermalviya/go-wayland/wayland/shm"
 )
 
 // interesting cursor icons.
@@ -28,95 +26,6 @@ const (
 	Watch             = "watch"
 )
 
-type shmPool struct {
-	Data []byte
-
-	pool *client.ShmPool
-	f    *os.File
-	size uint32
-	used uint32
-}
-
-func createShmPool(shm *client.Shm, size int) (*shmPool, error) {
-	f, err := tempfile.Create(int64(size))
-	if err != nil {
-		return nil, err
-	}
-
-	data, err := unix.Mmap(int(f.Fd()), 0, size, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_SHARED)
-	if err != nil {
-		return nil, err
-	}
-
-	pool, err := shm.CreatePool(int(f.Fd()), int32(size))
-	if err != nil {
-		return nil, err
-	}
-
-	return &shmPool{
-		pool: pool,
-		f:    f,
-		Data: data,
-		size: uint32(size),
-		used: 0,
-	}, nil
-}
-
-func (pool *shmPool) Resize(size int) error {
-	if err := pool.f.Truncate(int64(size)); err != nil {
-		return err
-	}
-
-	if err := pool.pool.Resize(int32(size)); err != nil {
-		return err
-	}
-
-	if err := unix.Munmap(pool.Data); err != nil {
-		return err
-	}
-	pool.Data = nil
-
-	data, err := unix.Mmap(int(pool.f.Fd()), 0, size, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_SHARED)
-	if err != nil {
-		return err
-	}
-	pool.Data = data
-
-	pool.size = uint32(size)
-
-	return nil
-}
-
-func (pool *shmPool) Allocate(size int) (int, error) {
-	if pool.used+uint32(size) > pool.size {
-		if err := pool.Resize(2*int(pool.size) + size); err != nil {
-			return 0, err
-		}
-	}
-
-	offset := pool.used
-	pool.used += uint32(size)
-
-	return int(offset), nil
-}
-
-func (pool *shmPool) Destroy() error {
-	if err := unix.Munmap(pool.Data); err != nil {
-		return err
-	}
-	pool.Data = nil
-
-	if err := pool.pool.Destroy(); err != nil {
-		return err
-	}
-
-	if err := pool.f.Close(); err != nil {
-		return err
-	}
-
-	return nil
-}
-
 // Image is a still image part of a cursor
 //
 // Use Image.GetBuffer() to get the corresponding WlBuffer
@@ -137,35 +46,15 @@ type Image struct {
 	// Animation Delay to next frame (ms)
 	Delay uint32
 
-	theme  *Theme
-	buffer *client.Buffer
-	offset int // data offset of this image in the shm pool
+	buffer *shm.Buffer // pixels of this image in the shm pool
 }
 
 func (image *Image) GetBuffer() (*client.Buffer, error) {
-	theme := image.theme
-
-	if image.buffer == nil {
-		buffer, err := theme.pool.pool.CreateBuffer(
-			int32(image.offset), int32(image.Width), int32(image.Height),
-			int32(image.Width)*4, uint32(client.ShmFormatArgb8888),
-		)
-		if err != nil {
-			return nil, err
-		}
-		image.buffer = buffer
-	}
-
-	return image.buffer, nil
+	return image.buffer.WlBuffer()
 }
 
 func (image *Image) Destroy() error {
-	if image.buffer != nil {
-		if err := image.buffer.Destroy(); err != nil {
-			return err
-		}
-	}
-	return nil
+	return image.buffer.Destroy()
 }
 
 // Cursor as returned by Theme.GetCursor()
@@ -185,24 +74,25 @@ func createCursorFromXcursorImages(name string, xcimages []xcursor.Image, theme
 	totalDelay := uint32(0)
 
 	for i, image := range xcimages {
-		size := image.Width * image.Height * 4
-		offset, err := theme.pool.Allocate(int(size))
+		buffer, err := theme.pool.Alloc(
+			int32(image.Width), int32(image.Height),
+			int32(image.Width)*4, client.ShmFormatArgb8888,
+		)
 		if err != nil {
 			return nil, err
 		}
 
 		// Copy pixels to shm pool
-		copy(theme.pool.Data[offset:], image.Pixels)
+		copy(buffer.Data(), image.Pixels)
 		totalDelay += image.Delay
 
 		images[i] = Image{
-			theme:    theme,
 			Width:    image.Width,
 			Height:   image.Height,
 			HotspotX: image.HotspotX,
 			HotspotY: image.HotspotY,
 			Delay:    image.Delay,
-			offset:   offset,
+			buffer:   buffer,
 		}
 	}
 
@@ -284,7 +174,7 @@ func (cursor *Cursor) Destroy() error {
 
 type Theme struct {
 	cursors map[string]*Cursor
-	pool    *shmPool
+	pool    *shm.Pool
 	size    int
 }
 
@@ -309,12 +199,12 @@ func (theme *Theme) loadCallback(name string, images []xcursor.Image) {
 //
 // Returns an object representing the theme that should be destroyed with
 // Theme.Destroy().
-func LoadTheme(name string, size int, shm *client.Shm) (*Theme, error) {
+func LoadTheme(name string, size int, wlShm *client.Shm) (*Theme, error) {
 	if name == "" {
 		name = "default"
 	}
 
-	pool, err := createShmPool(shm, size*size*4)
+	pool, err := shm.NewPool(wlShm, size*size*4)
 	if err != nil {
 		return nil, err
 	}
@@ -336,6 +226,12 @@ func LoadTheme(name string, size int, shm *client.Shm) (*Theme, error) {
 		return nil, errors.New("unable to find cursors in specified theme")
 	}
 
+	// All the images are loaded, the pool won't grow anymore
+	if err := pool.Seal(); err != nil {
+		_ = theme.Destroy()
+		return nil, err
+	}
+
 	return theme, nil
 }
 
@@ -0,0 +1,540 @@
+// Package dynamic speaks wayland protocols loaded from XML at runtime,
+// without generating code ahead of time with go-wayland-scanner.
+//
+// Proxies created by this package live in the same client.Context as the
+// generated ones, so both can be mixed on one connection: a dynamic
+// proxy can be bound from a generated client.Registry and passed as an
+// object argument to generated requests, and the other way round.
+//
+//	protocols, err := dynamic.Load("/usr/share/wayland/wayland.xml")
+//	...
+//	display, err := client.Connect("")
+//	...
+//	registry, err := dynamic.Display(display, protocols).Request("get_registry")
+//	...
+//	registry.SetEventHandler("global", func(e dynamic.Event) {
+//		fmt.Println(e.Args["interface"], e.Args["version"])
+//	})
+//
+// Requests take their arguments in the order of the XML, leaving out the
+// new_id, which the request creates and returns. Integer arguments accept
+// any integer type (including generated enums), fixed accepts float64 or
+// client.Fixed, strings accept string or nil when nullable, arrays accept
+// []byte, fds accept int or *os.File and objects accept any client.Proxy
+// or nil when nullable. A new_id without interface, as in wl_registry.bind, is given
+// as the interface name followed by the version.
+//
+// Events are decoded into maps keyed by argument name, holding int32,
+// uint32, float64, string (nil for a null string), []byte, int for fds
+// and client.Proxy (nil for a null object) for objects. Objects created
+// by an event are new *Proxy values.
+package dynamic
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+	"github.com/rajveermalviya/go-wayland/wayland/protocol"
+	"github.com/rajveermalviya/go-wayland/wayland/wire"
+	"golang.org/x/sys/unix"
+)
+
+// Protocols is a set of interfaces, indexed by name.
+type Protocols struct {
+	interfaces map[string]*protocol.Interface
+}
+
+// NewProtocols returns a set holding the interfaces of the given
+// protocols.
+func NewProtocols(protocols ...*protocol.Protocol) *Protocols {
+	p := &Protocols{interfaces: map[string]*protocol.Interface{}}
+	for _, proto := range protocols {
+		p.Add(proto)
+	}
+	return p
+}
+
+// Load parses protocol XML files, directories are searched for XML files
+// recursively.
+func Load(paths ...string) (*Protocols, error) {
+	p := NewProtocols()
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || !strings.HasSuffix(path, ".xml") {
+				return nil
+			}
+			proto, err := protocol.Load(path)
+			if err != nil {
+				return fmt.Errorf("dynamic.Load: unable to load %s: %w", path, err)
+			}
+			p.Add(proto)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// Add adds the interfaces of a protocol, replacing interfaces with the
+// same name.
+func (p *Protocols) Add(proto *protocol.Protocol) {
+	for i := range proto.Interfaces {
+		iface := &proto.Interfaces[i]
+		p.interfaces[iface.Name] = iface
+	}
+}
+
+// Registered returns a set holding the interfaces described by the
+// generated packages linked into the program, as found with
+// client.LookupInterface, so that no XML is needed to speak them.
+func Registered() *Protocols {
+	p := NewProtocols()
+	for _, iface := range client.Interfaces() {
+		p.AddInterface(iface)
+	}
+	return p
+}
+
+// AddInterface adds an interface from its runtime description, replacing
+// the interface with the same name.
+func (p *Protocols) AddInterface(iface *client.Interface) {
+	args := func(args []client.Arg) []protocol.Arg {
+		var out []protocol.Arg
+		for _, arg := range args {
+			out = append(out, protocol.Arg{
+				Name:      arg.Name,
+				Type:      arg.Type,
+				Interface: arg.Interface,
+				Enum:      arg.Enum,
+				AllowNull: arg.Nullable,
+			})
+		}
+		return out
+	}
+	messageType := func(m *client.Message) string {
+		if m.Destructor {
+			return "destructor"
+		}
+		return ""
+	}
+
+	i := &protocol.Interface{Name: iface.Name, Version: int(iface.Version)}
+	for j := range iface.Requests {
+		r := &iface.Requests[j]
+		i.Requests = append(i.Requests, protocol.Request{Name: r.Name, Type: messageType(r), Since: int(r.Since), DeprecatedSince: int(r.DeprecatedSince), Args: args(r.Args)})
+	}
+	for j := range iface.Events {
+		e := &iface.Events[j]
+		i.Events = append(i.Events, protocol.Event{Name: e.Name, Type: messageType(e), Since: int(e.Since), DeprecatedSince: int(e.DeprecatedSince), Args: args(e.Args)})
+	}
+	for _, e := range iface.Enums {
+		enum := protocol.Enum{Name: e.Name, Since: int(e.Since), Bitfield: e.Bitfield}
+		for _, entry := range e.Entries {
+			enum.Entries = append(enum.Entries, protocol.Entry{Name: entry.Name, Value: fmt.Sprint(entry.Value), Since: int(entry.Since), DeprecatedSince: int(entry.DeprecatedSince)})
+		}
+		i.Enums = append(i.Enums, enum)
+	}
+	p.interfaces[i.Name] = i
+}
+
+// Interface returns the interface with the given name, or nil.
+func (p *Protocols) Interface(name string) *protocol.Interface {
+	return p.interfaces[name]
+}
+
+// Proxy is a client side object whose interface is only known at
+// runtime.
+type Proxy struct {
+	client.BaseProxy
+	protocols *Protocols
+	iface     *protocol.Interface
+
+	handler       EventHandlerFunc
+	eventHandlers map[string]EventHandlerFunc
+}
+
+// Event is a decoded event.
+type Event struct {
+	Proxy *Proxy
+	Name  string
+	Args  map[string]any
+}
+
+type EventHandlerFunc func(Event)
+
+// NewProxy creates a proxy implementing the interface with the given
+// name and registers it in ctx, like the generated New functions do.
+// Version is the version the object is bound with, 0 stands for the
+// newest.
+func NewProxy(ctx *client.Context, protocols *Protocols, iface string, version uint32) (*Proxy, error) {
+	i := protocols.Interface(iface)
+	if i == nil {
+		return nil, fmt.Errorf("dynamic.NewProxy: unknown interface %q", iface)
+	}
+	if version == 0 || version > uint32(i.Version) {
+		version = uint32(i.Version)
+	}
+
+	p := &Proxy{protocols: protocols, iface: i}
+	p.SetVersion(version)
+	ctx.Register(p)
+	return p, nil
+}
+
+// Display returns a proxy for requests to the wl_display of a
+// connection. Its events keep being handled by the client.Display.
+func Display(display *client.Display, protocols *Protocols) *Proxy {
+	p := &Proxy{protocols: protocols, iface: protocols.Interface("wl_display")}
+	p.SetVersion(1)
+	p.SetContext(display.Context())
+	p.SetID(display.ID())
+	return p
+}
+
+// Bind binds a global advertised by a generated client.Registry.
+func Bind(registry *client.Registry, protocols *Protocols, name uint32, iface string, version uint32) (*Proxy, error) {
+	p, err := NewProxy(registry.Context(), protocols, iface, version)
+	if err != nil {
+		return nil, err
+	}
+	if err := registry.Bind(name, iface, p.Version(), p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Interface returns the name of the interface of the proxy.
+func (p *Proxy) Interface() string {
+	if p.iface == nil {
+		return ""
+	}
+	return p.iface.Name
+}
+
+func (p *Proxy) String() string {
+	return fmt.Sprintf("%s#%d", p.Interface(), p.ID())
+}
+
+// SetEventHandler sets the handler of the named event. It replaces the
+// handler set with SetHandler for that event.
+func (p *Proxy) SetEventHandler(event string, f EventHandlerFunc) {
+	if p.eventHandlers == nil {
+		p.eventHandlers = map[string]EventHandlerFunc{}
+	}
+	p.eventHandlers[event] = f
+}
+
+// SetHandler sets the handler of all events without their own handler.
+func (p *Proxy) SetHandler(f EventHandlerFunc) {
+	p.handler = f
+}
+
+// Request sends the named request and returns the object it creates,
+// if any. A destructor request unregisters the proxy.
+func (p *Proxy) Request(name string, args ...any) (*Proxy, error) {
+	if p.iface == nil {
+		return nil, fmt.Errorf("dynamic: %s has no interface", p)
+	}
+
+	opcode := -1
+	for i := range p.iface.Requests {
+		if p.iface.Requests[i].Name == name {
+			opcode = i
+			break
+		}
+	}
+	if opcode == -1 {
+		return nil, fmt.Errorf("dynamic: %s has no request %q", p.Interface(), name)
+	}
+	r := &p.iface.Requests[opcode]
+	if r.Since > 1 && uint32(r.Since) > p.Version() {
+		return nil, &client.VersionError{Interface: p.Interface(), Request: name, Since: uint32(r.Since), Version: p.Version()}
+	}
+
+	e := &wire.Encoder{}
+	created, err := p.encode(e, r, args)
+	if err != nil {
+		if created != nil {
+			p.Context().Unregister(created)
+		}
+		return nil, fmt.Errorf("dynamic: %s.%s: %w", p.Interface(), name, err)
+	}
+
+	buf, fds := e.Message(p.ID(), uint16(opcode))
+	var oob []byte
+	if len(fds) > 0 {
+		oob = unix.UnixRights(fds...)
+	}
+	if err := p.Context().WriteMsg(buf, oob); err != nil {
+		if created != nil {
+			p.Context().Unregister(created)
+		}
+		return nil, err
+	}
+
+	if r.Type == "destructor" {
+		p.Context().Unregister(p)
+	}
+	return created, nil
+}
+
+// encode writes the arguments of a request and creates the object of its
+// new_id. The object is returned along with an error so it can be
+// unregistered.
+func (p *Proxy) encode(e *wire.Encoder, r *protocol.Request, args []any) (created *Proxy, err error) {
+	for _, arg := range r.Args {
+		if arg.Type == "new_id" {
+			created, args, err = p.newID(arg, args)
+			if err != nil {
+				return created, err
+			}
+			if arg.Interface == "" {
+				e.String(created.Interface())
+				e.Uint32(created.Version())
+			}
+			e.Uint32(created.ID())
+			continue
+		}
+
+		if len(args) == 0 {
+			return created, fmt.Errorf("missing argument %s", arg.Name)
+		}
+		if err := encodeArg(e, arg, args[0]); err != nil {
+			return created, fmt.Errorf("argument %s: %w", arg.Name, err)
+		}
+		args = args[1:]
+	}
+	if len(args) > 0 {
+		return created, errors.New("too many arguments")
+	}
+	return created, nil
+}
+
+// newID creates the object of a new_id argument, consuming the
+// interface name and version from args when the XML doesn't specify the
+// interface.
+func (p *Proxy) newID(arg protocol.Arg, args []any) (*Proxy, []any, error) {
+	if arg.Interface != "" {
+		created, err := NewProxy(p.Context(), p.protocols, arg.Interface, p.Version())
+		return created, args, err
+	}
+
+	if len(args) < 2 {
+		return nil, args, fmt.Errorf("argument %s: want interface name and version", arg.Name)
+	}
+	iface, ok := args[0].(string)
+	if !ok {
+		return nil, args, fmt.Errorf("argument %s: interface name is %T, want string", arg.Name, args[0])
+	}
+	version, ok := toUint32(args[1])
+	if !ok {
+		return nil, args, fmt.Errorf("argument %s: version is %T, want integer", arg.Name, args[1])
+	}
+	created, err := NewProxy(p.Context(), p.protocols, iface, version)
+	return created, args[2:], err
+}
+
+// Destroy sends the destructor request if the interface has one without
+// arguments, then unregisters the proxy.
+func (p *Proxy) Destroy() error {
+	if p.iface != nil {
+		for _, r := range p.iface.Requests {
+			if r.Type == "destructor" && len(r.Args) == 0 {
+				_, err := p.Request(r.Name)
+				return err
+			}
+		}
+	}
+	p.Context().Unregister(p)
+	return nil
+}
+
+// Dispatch decodes an event and calls its handler. Like the generated
+// dispatchers, the proxy is unregistered after a destructor event and a
+// received fd is closed unless a handler gets it.
+func (p *Proxy) Dispatch(opcode uint32, fd int, data []byte) {
+	passed := false
+	defer func() {
+		if fd != -1 && !passed {
+			unix.Close(fd)
+		}
+	}()
+
+	if p.iface == nil || int(opcode) >= len(p.iface.Events) {
+		return
+	}
+	ev := &p.iface.Events[opcode]
+	if ev.Type == "destructor" {
+		defer p.Context().Unregister(p)
+	}
+
+	// objects created by the event are registered even without handler
+	e := Event{Proxy: p, Name: ev.Name, Args: make(map[string]any, len(ev.Args))}
+	d := wire.NewDecoder(data, nil)
+	hasFd := false
+	for _, arg := range ev.Args {
+		var v any
+		switch arg.Type {
+		case "fd":
+			v, hasFd = fd, true
+		case "object":
+			if id := d.Uint32(); id != 0 {
+				v = p.Context().GetProxy(id)
+			}
+		case "new_id":
+			iface, version := arg.Interface, p.Version()
+			if iface == "" {
+				iface, _ = d.String()
+				version = d.Uint32()
+			}
+			id := d.Uint32()
+			if d.Err != nil {
+				break
+			}
+			// server allocated objects keep the id chosen by the server
+			created := &Proxy{protocols: p.protocols, iface: p.protocols.Interface(iface)}
+			created.SetVersion(version)
+			p.Context().RegisterWithID(created, id)
+			v = created
+		default:
+			v = decodeArg(d, arg.Type)
+		}
+		if d.Err != nil {
+			return
+		}
+		e.Args[arg.Name] = v
+	}
+
+	f := p.eventHandlers[ev.Name]
+	if f == nil {
+		f = p.handler
+	}
+	if f == nil {
+		return
+	}
+	passed = hasFd
+	f(e)
+}
+
+func encodeArg(e *wire.Encoder, arg protocol.Arg, v any) error {
+	switch arg.Type {
+	case "int", "uint":
+		u, ok := toUint32(v)
+		if !ok {
+			return fmt.Errorf("got %T, want integer", v)
+		}
+		e.Uint32(u)
+
+	case "fixed":
+		switch f := v.(type) {
+		case float64:
+			fx, err := client.FixedFromFloat64(f)
+			if err != nil {
+				return err
+			}
+			e.Fixed(fx)
+		case client.Fixed:
+			e.Fixed(f)
+		default:
+			return fmt.Errorf("got %T, want float64 or client.Fixed", v)
+		}
+
+	case "string":
+		switch v := v.(type) {
+		case nil:
+			if !arg.AllowNull {
+				return errors.New("string is not nullable")
+			}
+			e.Uint32(0)
+		case string:
+			e.String(v)
+		default:
+			return fmt.Errorf("got %T, want string", v)
+		}
+
+	case "array":
+		b, ok := v.([]byte)
+		if !ok {
+			return fmt.Errorf("got %T, want []byte", v)
+		}
+		e.Array(b)
+
+	case "fd":
+		switch v := v.(type) {
+		case int:
+			e.Fd(v)
+		case *os.File:
+			e.Fd(int(v.Fd()))
+		default:
+			return fmt.Errorf("got %T, want int or *os.File", v)
+		}
+
+	case "object":
+		if v == nil || reflect.ValueOf(v).Kind() == reflect.Pointer && reflect.ValueOf(v).IsNil() {
+			if !arg.AllowNull {
+				return errors.New("object is not nullable")
+			}
+			e.Uint32(0)
+			return nil
+		}
+		p, ok := v.(client.Proxy)
+		if !ok {
+			return fmt.Errorf("got %T, want client.Proxy", v)
+		}
+		if dp, ok := p.(*Proxy); ok && arg.Interface != "" && dp.Interface() != arg.Interface {
+			return fmt.Errorf("got %s, want %s", dp.Interface(), arg.Interface)
+		}
+		e.Uint32(p.ID())
+
+	default:
+		return fmt.Errorf("unknown argument type %q", arg.Type)
+	}
+	return nil
+}
+
+func decodeArg(d *wire.Decoder, typ string) any {
+	switch typ {
+	case "int":
+		return d.Int32()
+	case "uint":
+		return d.Uint32()
+	case "fixed":
+		f := d.Fixed()
+		if d.Err != nil {
+			return nil
+		}
+		return f.Float64()
+	case "string":
+		if s, ok := d.String(); ok {
+			return s
+		}
+		return nil
+	case "array":
+		// the message buffer is reused by the context
+		return append([]byte(nil), d.Array()...)
+	}
+	d.Err = fmt.Errorf("unknown argument type %q", typ)
+	return nil
+}
+
+func toUint32(v any) (uint32, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return uint32(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return uint32(rv.Uint()), true
+	}
+	return 0, false
+}
@@ -0,0 +1,375 @@
+package dynamic_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rajveermalviya/go-wayland/wayland/dynamic"
+	"github.com/rajveermalviya/go-wayland/wayland/protocol"
+	"github.com/rajveermalviya/go-wayland/wayland/waylandtest"
+	"golang.org/x/sys/unix"
+)
+
+// testXML is the part of wayland.xml used by the tests, messages are
+// truncated after the last one used but keep their opcodes.
+const testXML = `<protocol name="wayland">
+  <interface name="wl_display" version="1">
+    <request name="sync">
+      <arg name="callback" type="new_id" interface="wl_callback"/>
+    </request>
+    <request name="get_registry">
+      <arg name="registry" type="new_id" interface="wl_registry"/>
+    </request>
+  </interface>
+  <interface name="wl_registry" version="1">
+    <request name="bind">
+      <arg name="name" type="uint"/>
+      <arg name="id" type="new_id"/>
+    </request>
+    <event name="global">
+      <arg name="name" type="uint"/>
+      <arg name="interface" type="string"/>
+      <arg name="version" type="uint"/>
+    </event>
+  </interface>
+  <interface name="wl_compositor" version="6">
+    <request name="create_surface">
+      <arg name="id" type="new_id" interface="wl_surface"/>
+    </request>
+  </interface>
+  <interface name="wl_surface" version="6">
+    <request name="destroy" type="destructor"/>
+    <request name="attach">
+      <arg name="buffer" type="object" interface="wl_buffer" allow-null="true"/>
+      <arg name="x" type="int"/>
+      <arg name="y" type="int"/>
+    </request>
+    <request name="damage">
+      <arg name="x" type="int"/>
+      <arg name="y" type="int"/>
+      <arg name="width" type="int"/>
+      <arg name="height" type="int"/>
+    </request>
+    <request name="frame">
+      <arg name="callback" type="new_id" interface="wl_callback"/>
+    </request>
+    <request name="set_opaque_region">
+      <arg name="region" type="object" interface="wl_region" allow-null="true"/>
+    </request>
+    <request name="set_input_region">
+      <arg name="region" type="object" interface="wl_region" allow-null="true"/>
+    </request>
+    <request name="commit"/>
+    <request name="set_buffer_transform" since="2">
+      <arg name="transform" type="int"/>
+    </request>
+    <request name="set_buffer_scale" since="3">
+      <arg name="scale" type="int"/>
+    </request>
+    <event name="enter">
+      <arg name="output" type="object" interface="wl_output"/>
+    </event>
+  </interface>
+  <interface name="wl_callback" version="1">
+    <event name="done" type="destructor">
+      <arg name="callback_data" type="uint"/>
+    </event>
+  </interface>
+  <interface name="wl_seat" version="10">
+    <request name="get_pointer">
+      <arg name="id" type="new_id" interface="wl_pointer"/>
+    </request>
+    <request name="get_keyboard">
+      <arg name="id" type="new_id" interface="wl_keyboard"/>
+    </request>
+  </interface>
+  <interface name="wl_keyboard" version="10">
+    <event name="keymap">
+      <arg name="format" type="uint"/>
+      <arg name="fd" type="fd"/>
+      <arg name="size" type="uint"/>
+    </event>
+  </interface>
+  <interface name="wl_output" version="4"/>
+  <interface name="wl_data_device_manager" version="3">
+    <request name="create_data_source">
+      <arg name="id" type="new_id" interface="wl_data_source"/>
+    </request>
+    <request name="get_data_device">
+      <arg name="id" type="new_id" interface="wl_data_device"/>
+      <arg name="seat" type="object" interface="wl_seat"/>
+    </request>
+  </interface>
+  <interface name="wl_data_device" version="3">
+    <event name="data_offer">
+      <arg name="id" type="new_id" interface="wl_data_offer"/>
+    </event>
+  </interface>
+  <interface name="wl_data_offer" version="3">
+    <event name="offer">
+      <arg name="mime_type" type="string"/>
+    </event>
+  </interface>
+</protocol>`
+
+func setup(t *testing.T) (*waylandtest.Server, map[string]*dynamic.Proxy) {
+	t.Helper()
+
+	p, err := protocol.Decode(strings.NewReader(testXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	protocols := dynamic.NewProtocols(p)
+
+	s := waylandtest.NewServer(t)
+	s.AddGlobal("wl_compositor", 4)
+	s.AddGlobal("wl_seat", 7)
+	s.AddGlobal("wl_output", 4)
+	s.AddGlobal("wl_data_device_manager", 3)
+
+	registry, err := dynamic.Display(s.Display(), protocols).Request("get_registry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	globals := map[string]*dynamic.Proxy{}
+	registry.SetEventHandler("global", func(e dynamic.Event) {
+		iface := e.Args["interface"].(string)
+		g, err := registry.Request("bind", e.Args["name"], iface, e.Args["version"])
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		globals[iface] = g
+	})
+	s.Roundtrip()
+
+	s.Expect("wl_display", "get_registry", registry)
+	s.Expect("wl_registry", "bind", uint32(1), "wl_compositor", uint32(4), globals["wl_compositor"])
+	s.Expect("wl_registry", "bind", uint32(2), "wl_seat", uint32(7), globals["wl_seat"])
+	s.Expect("wl_registry", "bind", uint32(3), "wl_output", uint32(4), globals["wl_output"])
+	s.Expect("wl_registry", "bind", uint32(4), "wl_data_device_manager", uint32(3), globals["wl_data_device_manager"])
+	return s, globals
+}
+
+func TestRequests(t *testing.T) {
+	s, globals := setup(t)
+
+	surface, err := globals["wl_compositor"].Request("create_surface")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if surface.Interface() != "wl_surface" || surface.Version() != 4 {
+		t.Errorf("got %v version %d, want wl_surface version 4", surface, surface.Version())
+	}
+	if _, err := surface.Request("attach", nil, 0, int32(0)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := surface.Request("set_buffer_scale", 2); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := surface.Request("commit"); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Roundtrip()
+	s.Expect("wl_compositor", "create_surface", surface)
+	s.Expect("wl_surface", "attach", nil, 0, 0)
+	s.Expect("wl_surface", "set_buffer_scale", 2)
+	s.Expect("wl_surface", "commit")
+
+	var entered any
+	surface.SetHandler(func(e dynamic.Event) {
+		if e.Name == "enter" {
+			entered = e.Args["output"]
+		}
+	})
+	s.Send(surface, "enter", globals["wl_output"])
+	s.Roundtrip()
+	if entered != globals["wl_output"] {
+		t.Errorf("got enter %v, want %v", entered, globals["wl_output"])
+	}
+
+	if err := surface.Destroy(); err != nil {
+		t.Fatal(err)
+	}
+	s.Expect("wl_surface", "destroy")
+	if p := surface.Context().GetProxy(surface.ID()); p != nil {
+		t.Errorf("destroyed %v still registered", p)
+	}
+}
+
+func TestRequestErrors(t *testing.T) {
+	_, globals := setup(t)
+	compositor := globals["wl_compositor"]
+	ctx := compositor.Context()
+
+	surface, err := compositor.Request("create_surface")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name string
+		args []any
+		err  string
+	}{
+		{"unknown", nil, `no request "unknown"`},
+		{"commit", []any{1}, "too many arguments"},
+		{"damage", []any{0, 0, 0}, "missing argument height"},
+		{"damage", []any{0, 0, 0, "10"}, "argument height: got string, want integer"},
+		{"set_opaque_region", []any{surface}, "got wl_surface, want wl_region"},
+		{"frame", []any{1}, "too many arguments"},
+	} {
+		_, err := surface.Request(tt.name, tt.args...)
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s%v: got error %v, want %q", tt.name, tt.args, err, tt.err)
+		}
+	}
+
+	// the callback of the failed frame request must not stay registered
+	if p := ctx.GetProxy(surface.ID() + 1); p != nil {
+		t.Errorf("got leaked %v", p)
+	}
+}
+
+func TestServerCreatedObjects(t *testing.T) {
+	s, globals := setup(t)
+
+	device, err := globals["wl_data_device_manager"].Request("get_data_device", globals["wl_seat"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var offer *dynamic.Proxy
+	var mimeTypes []string
+	device.SetEventHandler("data_offer", func(e dynamic.Event) {
+		offer = e.Args["id"].(*dynamic.Proxy)
+		offer.SetEventHandler("offer", func(e dynamic.Event) {
+			mimeTypes = append(mimeTypes, e.Args["mime_type"].(string))
+		})
+	})
+
+	s.Roundtrip()
+	s.Expect("wl_data_device_manager", "get_data_device", device, globals["wl_seat"])
+
+	obj := s.NewObject("wl_data_offer", 3)
+	s.Send(device, "data_offer", obj)
+	s.Send(obj, "offer", "text/plain")
+	s.Roundtrip()
+
+	if offer == nil || offer.ID() != obj.ID || offer.Interface() != "wl_data_offer" {
+		t.Fatalf("got offer %v, want %v", offer, obj)
+	}
+	if len(mimeTypes) != 1 || mimeTypes[0] != "text/plain" {
+		t.Errorf("got mime types %q, want [text/plain]", mimeTypes)
+	}
+}
+
+func TestDestructorEvent(t *testing.T) {
+	s, globals := setup(t)
+
+	surface, err := globals["wl_compositor"].Request("create_surface")
+	if err != nil {
+		t.Fatal(err)
+	}
+	callback, err := surface.Request("frame")
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := false
+	callback.SetEventHandler("done", func(dynamic.Event) {
+		done = true
+	})
+	s.Roundtrip()
+
+	s.Send(callback, "done", 0)
+	s.Roundtrip()
+	if !done {
+		t.Error("done was not dispatched")
+	}
+	if p := callback.Context().GetProxy(callback.ID()); p != nil {
+		t.Errorf("%v is still registered after done", p)
+	}
+}
+
+func TestEventFds(t *testing.T) {
+	s, globals := setup(t)
+
+	keyboard, err := globals["wl_seat"].Request("get_keyboard")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Roundtrip()
+
+	// sendKeymap sends the write end of a pipe, the read end sees EOF
+	// once the client closed its copy
+	sendKeymap := func() (r int) {
+		var p [2]int
+		if err := unix.Pipe2(p[:], unix.O_CLOEXEC|unix.O_NONBLOCK); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { unix.Close(p[0]) })
+		s.Send(keyboard, "keymap", 1, p[1], 0)
+		unix.Close(p[1])
+		s.Roundtrip()
+		return p[0]
+	}
+	closed := func(r int) bool {
+		n, err := unix.Read(r, make([]byte, 1))
+		return n == 0 && err == nil
+	}
+
+	// without handler the fd is closed
+	if r := sendKeymap(); !closed(r) {
+		t.Error("fd without handler was not closed")
+	}
+
+	// the handler owns the fd it gets
+	var fd int
+	keyboard.SetEventHandler("keymap", func(e dynamic.Event) {
+		fd = e.Args["fd"].(int)
+	})
+	r := sendKeymap()
+	if closed(r) {
+		t.Fatal("fd given to the handler was closed")
+	}
+	unix.Close(fd)
+	if !closed(r) {
+		t.Error("fd was not closed by the handler")
+	}
+}
+
+func TestRegistered(t *testing.T) {
+	p, err := protocol.Decode(strings.NewReader(testXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	registered := dynamic.Registered()
+
+	// testXML is a prefix of the messages of wayland.xml, which the
+	// generated client package registers
+	for _, want := range p.Interfaces {
+		got := registered.Interface(want.Name)
+		if got == nil {
+			t.Errorf("%s is not registered", want.Name)
+			continue
+		}
+		if got.Version != want.Version {
+			t.Errorf("%s: got version %d, want %d", want.Name, got.Version, want.Version)
+		}
+		for i, r := range want.Requests {
+			if i >= len(got.Requests) {
+				t.Errorf("%s: missing request %s", want.Name, r.Name)
+				break
+			}
+			g := got.Requests[i]
+			if g.Name != r.Name || g.Type != r.Type || g.Since != r.Since || len(g.Args) != len(r.Args) {
+				t.Errorf("%s: got request %d %s (%q, since %d, %d args), want %s (%q, since %d, %d args)",
+					want.Name, i, g.Name, g.Type, g.Since, len(g.Args), r.Name, r.Type, r.Since, len(r.Args))
+			}
+		}
+	}
+
+	if enum := registered.Interface("wl_output").Enums; len(enum) == 0 || enum[0].Name != "subpixel" {
+		t.Errorf("got wl_output enums %v, want subpixel first", enum)
+	}
+}
@@ -1,3 +1 @@
 package wayland_drm
-
-//go:generate go run github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner -pkg wayland_drm -prefix wl -o wayland_drm.go -i https://raw.githubusercontent.com/mesa3d/mesa/mesa-22.2.0/src/egl/wayland/wayland-drm/wayland-drm.xml
@@ -31,6 +31,9 @@
 package wayland_drm
 
 import (
+	"log/slog"
+	"strconv"
+
 	"github.com/rajveermalviya/go-wayland/wayland/client"
 	"golang.org/x/sys/unix"
 )
@@ -51,6 +54,177 @@ func NewDrm(ctx *client.Context) *Drm {
 	return wlDrm
 }
 
+// DrmInterface describes wl_drm at runtime.
+var DrmInterface = &client.Interface{
+	Name:    "wl_drm",
+	Version: 2,
+	New:     func() client.Proxy { return &Drm{} },
+	Requests: []client.Message{
+		{
+			Name: "authenticate",
+			Args: []client.Arg{
+				{Name: "id", Type: "uint"},
+			},
+		},
+		{
+			Name: "create_buffer",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "wl_buffer"},
+				{Name: "name", Type: "uint"},
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+				{Name: "stride", Type: "uint"},
+				{Name: "format", Type: "uint"},
+			},
+		},
+		{
+			Name: "create_planar_buffer",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "wl_buffer"},
+				{Name: "name", Type: "uint"},
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+				{Name: "format", Type: "uint"},
+				{Name: "offset_0", Type: "int"},
+				{Name: "stride_0", Type: "int"},
+				{Name: "offset_1", Type: "int"},
+				{Name: "stride_1", Type: "int"},
+				{Name: "offset_2", Type: "int"},
+				{Name: "stride_2", Type: "int"},
+			},
+		},
+		{
+			Name:  "create_prime_buffer",
+			Since: 2,
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "wl_buffer"},
+				{Name: "name", Type: "fd"},
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+				{Name: "format", Type: "uint"},
+				{Name: "offset_0", Type: "int"},
+				{Name: "stride_0", Type: "int"},
+				{Name: "offset_1", Type: "int"},
+				{Name: "stride_1", Type: "int"},
+				{Name: "offset_2", Type: "int"},
+				{Name: "stride_2", Type: "int"},
+			},
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "device",
+			Args: []client.Arg{
+				{Name: "name", Type: "string"},
+			},
+		},
+		{
+			Name: "format",
+			Args: []client.Arg{
+				{Name: "format", Type: "uint"},
+			},
+		},
+		{
+			Name: "authenticated",
+		},
+		{
+			Name:  "capabilities",
+			Since: 2,
+			Args: []client.Arg{
+				{Name: "value", Type: "uint"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "authenticate_fail", Value: 0},
+				{Name: "invalid_format", Value: 1},
+				{Name: "invalid_name", Value: 2},
+			},
+		},
+		{
+			Name: "format",
+			Entries: []client.EnumEntry{
+				{Name: "c8", Value: 0x20203843},
+				{Name: "rgb332", Value: 0x38424752},
+				{Name: "bgr233", Value: 0x38524742},
+				{Name: "xrgb4444", Value: 0x32315258},
+				{Name: "xbgr4444", Value: 0x32314258},
+				{Name: "rgbx4444", Value: 0x32315852},
+				{Name: "bgrx4444", Value: 0x32315842},
+				{Name: "argb4444", Value: 0x32315241},
+				{Name: "abgr4444", Value: 0x32314241},
+				{Name: "rgba4444", Value: 0x32314152},
+				{Name: "bgra4444", Value: 0x32314142},
+				{Name: "xrgb1555", Value: 0x35315258},
+				{Name: "xbgr1555", Value: 0x35314258},
+				{Name: "rgbx5551", Value: 0x35315852},
+				{Name: "bgrx5551", Value: 0x35315842},
+				{Name: "argb1555", Value: 0x35315241},
+				{Name: "abgr1555", Value: 0x35314241},
+				{Name: "rgba5551", Value: 0x35314152},
+				{Name: "bgra5551", Value: 0x35314142},
+				{Name: "rgb565", Value: 0x36314752},
+				{Name: "bgr565", Value: 0x36314742},
+				{Name: "rgb888", Value: 0x34324752},
+				{Name: "bgr888", Value: 0x34324742},
+				{Name: "xrgb8888", Value: 0x34325258},
+				{Name: "xbgr8888", Value: 0x34324258},
+				{Name: "rgbx8888", Value: 0x34325852},
+				{Name: "bgrx8888", Value: 0x34325842},
+				{Name: "argb8888", Value: 0x34325241},
+				{Name: "abgr8888", Value: 0x34324241},
+				{Name: "rgba8888", Value: 0x34324152},
+				{Name: "bgra8888", Value: 0x34324142},
+				{Name: "xrgb2101010", Value: 0x30335258},
+				{Name: "xbgr2101010", Value: 0x30334258},
+				{Name: "rgbx1010102", Value: 0x30335852},
+				{Name: "bgrx1010102", Value: 0x30335842},
+				{Name: "argb2101010", Value: 0x30335241},
+				{Name: "abgr2101010", Value: 0x30334241},
+				{Name: "rgba1010102", Value: 0x30334152},
+				{Name: "bgra1010102", Value: 0x30334142},
+				{Name: "yuyv", Value: 0x56595559},
+				{Name: "yvyu", Value: 0x55595659},
+				{Name: "uyvy", Value: 0x59565955},
+				{Name: "vyuy", Value: 0x59555956},
+				{Name: "ayuv", Value: 0x56555941},
+				{Name: "xyuv8888", Value: 0x56555958},
+				{Name: "nv12", Value: 0x3231564e},
+				{Name: "nv21", Value: 0x3132564e},
+				{Name: "nv16", Value: 0x3631564e},
+				{Name: "nv61", Value: 0x3136564e},
+				{Name: "yuv410", Value: 0x39565559},
+				{Name: "yvu410", Value: 0x39555659},
+				{Name: "yuv411", Value: 0x31315559},
+				{Name: "yvu411", Value: 0x31315659},
+				{Name: "yuv420", Value: 0x32315559},
+				{Name: "yvu420", Value: 0x32315659},
+				{Name: "yuv422", Value: 0x36315559},
+				{Name: "yvu422", Value: 0x36315659},
+				{Name: "yuv444", Value: 0x34325559},
+				{Name: "yvu444", Value: 0x34325659},
+				{Name: "abgr16f", Value: 0x48344241},
+				{Name: "xbgr16f", Value: 0x48344258},
+			},
+		},
+		{
+			Name:  "capability",
+			Since: 2,
+			Entries: []client.EnumEntry{
+				{Name: "prime", Value: 1},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wl_drm.
+func (i *Drm) Interface() *client.Interface {
+	return DrmInterface
+}
+
 // Authenticate :
 func (i *Drm) Authenticate(id uint32) error {
 	const opcode = 0
@@ -70,6 +244,7 @@ func (i *Drm) Authenticate(id uint32) error {
 // CreateBuffer :
 func (i *Drm) CreateBuffer(name uint32, width, height int32, stride, format uint32) (*client.Buffer, error) {
 	id := client.NewBuffer(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4 + 4 + 4 + 4 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -97,6 +272,7 @@ func (i *Drm) CreateBuffer(name uint32, width, height int32, stride, format uint
 // CreatePlanarBuffer :
 func (i *Drm) CreatePlanarBuffer(name uint32, width, height int32, format uint32, offset0, stride0, offset1, stride1, offset2, stride2 int32) (*client.Buffer, error) {
 	id := client.NewBuffer(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 2
 	const _reqBufLen = 8 + 4 + 4 + 4 + 4 + 4 + 4 + 4 + 4 + 4 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -133,7 +309,11 @@ func (i *Drm) CreatePlanarBuffer(name uint32, width, height int32, format uint32
 
 // CreatePrimeBuffer :
 func (i *Drm) CreatePrimeBuffer(name int, width, height int32, format uint32, offset0, stride0, offset1, stride1, offset2, stride2 int32) (*client.Buffer, error) {
+	if v := i.Version(); v != 0 && v < 2 {
+		return nil, &client.VersionError{Interface: "wl_drm", Request: "create_prime_buffer", Since: 2, Version: v}
+	}
 	id := client.NewBuffer(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 3
 	const _reqBufLen = 8 + 4 + 4 + 4 + 4 + 4 + 4 + 4 + 4 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -583,6 +763,20 @@ func (i *Drm) SetDeviceHandler(f DrmDeviceHandlerFunc) {
 	i.deviceHandler = f
 }
 
+// String formats the event as wl_drm.device(argument=value, ...).
+func (e DrmDeviceEvent) String() string {
+	return "wl_drm.device(" +
+		"name=" + strconv.Quote(e.Name) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DrmDeviceEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("name", e.Name),
+	)
+}
+
 // DrmFormatEvent :
 type DrmFormatEvent struct {
 	Format uint32
@@ -594,6 +788,20 @@ func (i *Drm) SetFormatHandler(f DrmFormatHandlerFunc) {
 	i.formatHandler = f
 }
 
+// String formats the event as wl_drm.format(argument=value, ...).
+func (e DrmFormatEvent) String() string {
+	return "wl_drm.format(" +
+		"format=" + strconv.FormatUint(uint64(e.Format), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DrmFormatEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("format", uint64(e.Format)),
+	)
+}
+
 // DrmAuthenticatedEvent :
 type DrmAuthenticatedEvent struct{}
 type DrmAuthenticatedHandlerFunc func(DrmAuthenticatedEvent)
@@ -603,6 +811,16 @@ func (i *Drm) SetAuthenticatedHandler(f DrmAuthenticatedHandlerFunc) {
 	i.authenticatedHandler = f
 }
 
+// String formats the event as wl_drm.authenticated(argument=value, ...).
+func (e DrmAuthenticatedEvent) String() string {
+	return "wl_drm.authenticated()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DrmAuthenticatedEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // DrmCapabilitiesEvent :
 type DrmCapabilitiesEvent struct {
 	Value uint32
@@ -614,6 +832,61 @@ func (i *Drm) SetCapabilitiesHandler(f DrmCapabilitiesHandlerFunc) {
 	i.capabilitiesHandler = f
 }
 
+// String formats the event as wl_drm.capabilities(argument=value, ...).
+func (e DrmCapabilitiesEvent) String() string {
+	return "wl_drm.capabilities(" +
+		"value=" + strconv.FormatUint(uint64(e.Value), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DrmCapabilitiesEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("value", uint64(e.Value)),
+	)
+}
+
+// DrmListener receives all the events of Drm, embed
+// DrmNoopListener to implement only some of them.
+type DrmListener interface {
+	Device(e DrmDeviceEvent)
+	Format(e DrmFormatEvent)
+	Authenticated(e DrmAuthenticatedEvent)
+	Capabilities(e DrmCapabilitiesEvent)
+}
+
+// DrmNoopListener is a DrmListener ignoring
+// all the events, it closes the file descriptors they carry.
+type DrmNoopListener struct{}
+
+func (DrmNoopListener) Device(DrmDeviceEvent) {
+}
+
+func (DrmNoopListener) Format(DrmFormatEvent) {
+}
+
+func (DrmNoopListener) Authenticated(DrmAuthenticatedEvent) {
+}
+
+func (DrmNoopListener) Capabilities(DrmCapabilitiesEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *Drm) SetListener(l DrmListener) {
+	if l == nil {
+		i.deviceHandler = nil
+		i.formatHandler = nil
+		i.authenticatedHandler = nil
+		i.capabilitiesHandler = nil
+		return
+	}
+	i.deviceHandler = l.Device
+	i.formatHandler = l.Format
+	i.authenticatedHandler = l.Authenticated
+	i.capabilitiesHandler = l.Capabilities
+}
+
 func (i *Drm) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -657,3 +930,7 @@ func (i *Drm) Dispatch(opcode uint32, fd int, data []byte) {
 		i.capabilitiesHandler(e)
 	}
 }
+
+func init() {
+	client.RegisterInterface(DrmInterface)
+}
@@ -1,5 +1,5 @@
 module github.com/rajveermalviya/go-wayland/wayland
 
-go 1.19
+go 1.21
 
 require golang.org/x/sys v0.0.0-20211117180635-dee7805ff2e1
@@ -1,25 +1,96 @@
+// Package tempfile creates anonymous files to share memory with the
+// compositor.
 package tempfile
 
 import (
 	"errors"
 	"os"
+
+	"golang.org/x/sys/unix"
 )
 
+// Create returns an anonymous file of size bytes. It is a memfd when the
+// kernel supports them, sealed so that it can't shrink under the mappings
+// of the compositor, else an O_TMPFILE file and at last a file created and
+// removed right away, both in XDG_RUNTIME_DIR or the temporary directory.
 func Create(size int64) (*os.File, error) {
-	dir := os.Getenv("XDG_RUNTIME_DIR")
-	if dir == "" {
-		return nil, errors.New("XDG_RUNTIME_DIR is not defined in env")
+	file, err := createMemfd(size)
+	if err == nil {
+		return file, nil
 	}
-	file, err := os.CreateTemp(dir, "wl_shm_go_*")
+	errs := []error{err}
+
+	dirs := []string{os.TempDir()}
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		dirs = []string{dir, os.TempDir()}
+	}
+	for _, create := range []func(string, int64) (*os.File, error){createTmpfile, createNamed} {
+		for _, dir := range dirs {
+			file, err := create(dir, size)
+			if err == nil {
+				return file, nil
+			}
+			errs = append(errs, err)
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
+// Seal forbids growing the file, which can't be resized anymore. It does
+// nothing on files which can't be sealed.
+func Seal(file *os.File) error {
+	_, err := unix.FcntlInt(file.Fd(), unix.F_ADD_SEALS, unix.F_SEAL_GROW|unix.F_SEAL_SEAL)
+	if errors.Is(err, unix.EINVAL) || errors.Is(err, unix.EPERM) {
+		// Not a memfd, or already sealed
+		return nil
+	}
+	if err != nil {
+		return &os.PathError{Op: "seal", Path: file.Name(), Err: err}
+	}
+	return nil
+}
+
+func createMemfd(size int64) (*os.File, error) {
+	fd, err := unix.MemfdCreate("wl_shm_go", unix.MFD_CLOEXEC|unix.MFD_ALLOW_SEALING)
+	if err != nil {
+		return nil, os.NewSyscallError("memfd_create", err)
+	}
+	file := os.NewFile(uintptr(fd), "memfd:wl_shm_go")
+	if err := file.Truncate(size); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if _, err := unix.FcntlInt(file.Fd(), unix.F_ADD_SEALS, unix.F_SEAL_SHRINK); err != nil {
+		file.Close()
+		return nil, &os.PathError{Op: "seal", Path: file.Name(), Err: err}
+	}
+	return file, nil
+}
+
+func createTmpfile(dir string, size int64) (*os.File, error) {
+	fd, err := unix.Open(dir, unix.O_TMPFILE|unix.O_RDWR|unix.O_CLOEXEC, 0o600)
 	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: dir, Err: err}
+	}
+	file := os.NewFile(uintptr(fd), dir)
+	if err := file.Truncate(size); err != nil {
+		file.Close()
 		return nil, err
 	}
-	err = file.Truncate(size)
+	return file, nil
+}
+
+func createNamed(dir string, size int64) (*os.File, error) {
+	file, err := os.CreateTemp(dir, "wl_shm_go_*")
 	if err != nil {
 		return nil, err
 	}
 	err = os.Remove(file.Name())
+	if err == nil {
+		err = file.Truncate(size)
+	}
 	if err != nil {
+		file.Close()
 		return nil, err
 	}
 	return file, nil
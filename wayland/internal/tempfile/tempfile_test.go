@@ -0,0 +1,80 @@
+package tempfile
+
+import (
+	"os"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestCreate(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", "")
+
+	file, err := Create(4096)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() != 4096 {
+		t.Errorf("got size %d, want 4096", info.Size())
+	}
+
+	seals, err := unix.FcntlInt(file.Fd(), unix.F_GET_SEALS, 0)
+	if err != nil {
+		t.Skipf("not a memfd: %v", err)
+	}
+	if seals != unix.F_SEAL_SHRINK {
+		t.Errorf("got seals %#x, want F_SEAL_SHRINK", seals)
+	}
+	if err := file.Truncate(2048); err == nil {
+		t.Error("sealed file shrank")
+	}
+	if err := file.Truncate(8192); err != nil {
+		t.Errorf("unable to grow: %v", err)
+	}
+
+	if err := Seal(file); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Truncate(16384); err == nil {
+		t.Error("sealed file grew")
+	}
+	if err := Seal(file); err != nil {
+		t.Errorf("sealing again: %v", err)
+	}
+}
+
+func TestCreateFallbacks(t *testing.T) {
+	dir := t.TempDir()
+	for name, create := range map[string]func(string, int64) (*os.File, error){
+		"O_TMPFILE": createTmpfile,
+		"named":     createNamed,
+	} {
+		file, err := create(dir, 4096)
+		if err != nil {
+			t.Logf("%s: %v", name, err)
+			continue
+		}
+		info, err := file.Stat()
+		file.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Size() != 4096 {
+			t.Errorf("%s: got size %d, want 4096", name, info.Size())
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("files left in the directory: %v", entries)
+	}
+}
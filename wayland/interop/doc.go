@@ -0,0 +1,10 @@
+// Package interop checks the wire format of the generated code against
+// messages captured from libwayland, byte for byte.
+//
+// testdata/requests.txt and testdata/events.txt were written by
+// testdata/capture.c, which marshals requests with libwayland-client and
+// events with libwayland-server. The tests send the same requests with
+// wayland/client and wayland/stable/xdg-shell and compare the bytes, then
+// feed the captured events to their dispatchers and compare the decoded
+// events. libwayland is only needed to capture the messages again.
+package interop
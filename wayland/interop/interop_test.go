@@ -0,0 +1,515 @@
+package interop
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"net"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+	xdg_shell "github.com/rajveermalviya/go-wayland/wayland/stable/xdg-shell"
+)
+
+// fixture is a message captured from libwayland.
+type fixture struct {
+	name string
+	fds  int
+	data []byte
+}
+
+func readFixtures(t *testing.T, file string) []fixture {
+	t.Helper()
+
+	f, err := os.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var fixtures []fixture
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) != 3 {
+			t.Fatalf("%s: invalid line %q", file, s.Text())
+		}
+		fds, err := strconv.Atoi(fields[1])
+		if err != nil {
+			t.Fatalf("%s: invalid fd count: %v", file, err)
+		}
+		data, err := hex.DecodeString(fields[2])
+		if err != nil {
+			t.Fatalf("%s: invalid data: %v", file, err)
+		}
+		fixtures = append(fixtures, fixture{fields[0], fds, data})
+	}
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return fixtures
+}
+
+// objects are the objects created by the requests, in the order of
+// testdata/capture.c so that they get the same ids.
+type objects struct {
+	t    *testing.T
+	peer int
+
+	display    *client.Display
+	registry   *client.Registry
+	compositor *client.Compositor
+	surface    *client.Surface
+	shm        *client.Shm
+	pool       *client.ShmPool
+	buffer     *client.Buffer
+	callback   *client.Callback
+	wmBase     *xdg_shell.WmBase
+	xdgSurface *xdg_shell.Surface
+	toplevel   *xdg_shell.Toplevel
+	seat       *client.Seat
+	pointer    *client.Pointer
+	keyboard   *client.Keyboard
+	output     *client.Output
+	ddm        *client.DataDeviceManager
+	source     *client.DataSource
+	device     *client.DataDevice
+	offer      *client.DataOffer
+}
+
+// connect connects a display to a socket whose other end is read and
+// written directly by the test.
+func connect(t *testing.T) *objects {
+	t.Helper()
+
+	fds, err := unix.Socketpair(unix.AF_UNIX, unix.SOCK_STREAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := os.NewFile(uintptr(fds[0]), "interop")
+	defer f.Close()
+	conn, err := net.FileConn(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o := &objects{t: t, peer: fds[1], display: client.ConnectConn(conn.(*net.UnixConn))}
+	t.Cleanup(func() {
+		o.display.Context().Close()
+		unix.Close(o.peer)
+	})
+	return o
+}
+
+// read reads the message sent by the client, returning the number of file
+// descriptors sent with it.
+func (o *objects) read() ([]byte, int) {
+	o.t.Helper()
+
+	buf := make([]byte, 4096)
+	oob := make([]byte, unix.CmsgSpace(4*28))
+	n, oobn, _, _, err := unix.Recvmsg(o.peer, buf, oob, 0)
+	if err != nil {
+		o.t.Fatal(err)
+	}
+	msgs, err := unix.ParseSocketControlMessage(oob[:oobn])
+	if err != nil {
+		o.t.Fatal(err)
+	}
+	fds := 0
+	for i := range msgs {
+		rights, err := unix.ParseUnixRights(&msgs[i])
+		if err != nil {
+			o.t.Fatal(err)
+		}
+		for _, fd := range rights {
+			unix.Close(fd)
+		}
+		fds += len(rights)
+	}
+	return buf[:n], fds
+}
+
+// write sends a message to the client with a file descriptor for each fd
+// of the fixture.
+func (o *objects) write(f fixture) {
+	o.t.Helper()
+
+	var fds []int
+	for i := 0; i < f.fds; i++ {
+		r, w, err := os.Pipe()
+		if err != nil {
+			o.t.Fatal(err)
+		}
+		defer r.Close()
+		defer w.Close()
+		fds = append(fds, int(r.Fd()))
+	}
+	var oob []byte
+	if len(fds) > 0 {
+		oob = unix.UnixRights(fds...)
+	}
+	if err := unix.Sendmsg(o.peer, f.data, oob, nil, 0); err != nil {
+		o.t.Fatal(err)
+	}
+}
+
+// bind binds a global to p, which is registered first to get the next id.
+func (o *objects) bind(name uint32, iface string, version uint32, p client.Proxy) error {
+	o.display.Context().Register(p)
+	return o.registry.Bind(name, iface, version, p)
+}
+
+func pipeFd(o *objects) int {
+	r, w, err := os.Pipe()
+	if err != nil {
+		o.t.Fatal(err)
+	}
+	o.t.Cleanup(func() {
+		r.Close()
+		w.Close()
+	})
+	return int(r.Fd())
+}
+
+// requests are sent in the order of testdata/requests.txt.
+var requests = []struct {
+	name string
+	send func(o *objects) error
+}{
+	{"wl_display.get_registry", func(o *objects) (err error) {
+		o.registry, err = o.display.GetRegistry()
+		return err
+	}},
+	{"wl_registry.bind", func(o *objects) error {
+		o.compositor = &client.Compositor{}
+		return o.bind(1, "wl_compositor", 6, o.compositor)
+	}},
+	{"wl_compositor.create_surface", func(o *objects) (err error) {
+		o.surface, err = o.compositor.CreateSurface()
+		return err
+	}},
+	{"wl_registry.bind", func(o *objects) error {
+		o.shm = &client.Shm{}
+		return o.bind(2, "wl_shm", 1, o.shm)
+	}},
+	{"wl_shm.create_pool", func(o *objects) (err error) {
+		o.pool, err = o.shm.CreatePool(pipeFd(o), 4096)
+		return err
+	}},
+	{"wl_shm_pool.create_buffer", func(o *objects) (err error) {
+		o.buffer, err = o.pool.CreateBuffer(0, 16, 8, 64, client.ShmFormatArgb8888)
+		return err
+	}},
+	{"wl_surface.attach", func(o *objects) error {
+		return o.surface.Attach(o.buffer, 0, 0)
+	}},
+	{"wl_surface.attach", func(o *objects) error {
+		return o.surface.Attach(nil, 5, -7)
+	}},
+	{"wl_surface.damage_buffer", func(o *objects) error {
+		return o.surface.DamageBuffer(0, 0, 16, 8)
+	}},
+	{"wl_surface.set_buffer_scale", func(o *objects) error {
+		return o.surface.SetBufferScale(2)
+	}},
+	{"wl_surface.commit", func(o *objects) error {
+		return o.surface.Commit()
+	}},
+	{"wl_display.sync", func(o *objects) (err error) {
+		o.callback, err = o.display.Sync()
+		return err
+	}},
+	{"wl_registry.bind", func(o *objects) error {
+		o.wmBase = &xdg_shell.WmBase{}
+		return o.bind(3, "xdg_wm_base", 5, o.wmBase)
+	}},
+	{"xdg_wm_base.get_xdg_surface", func(o *objects) (err error) {
+		o.xdgSurface, err = o.wmBase.GetXdgSurface(o.surface)
+		return err
+	}},
+	{"xdg_surface.get_toplevel", func(o *objects) (err error) {
+		o.toplevel, err = o.xdgSurface.GetToplevel()
+		return err
+	}},
+	{"xdg_toplevel.set_title", func(o *objects) error {
+		return o.toplevel.SetTitle("go-wayland")
+	}},
+	{"xdg_toplevel.set_app_id", func(o *objects) error {
+		return o.toplevel.SetAppId("")
+	}},
+	{"xdg_toplevel.set_min_size", func(o *objects) error {
+		return o.toplevel.SetMinSize(320, 240)
+	}},
+	{"xdg_toplevel.set_parent", func(o *objects) error {
+		return o.toplevel.SetParent(nil)
+	}},
+	{"xdg_wm_base.pong", func(o *objects) error {
+		return o.wmBase.Pong(77)
+	}},
+	{"wl_registry.bind", func(o *objects) error {
+		o.seat = &client.Seat{}
+		return o.bind(4, "wl_seat", 9, o.seat)
+	}},
+	{"wl_seat.get_pointer", func(o *objects) (err error) {
+		o.pointer, err = o.seat.GetPointer()
+		return err
+	}},
+	{"wl_seat.get_keyboard", func(o *objects) (err error) {
+		o.keyboard, err = o.seat.GetKeyboard()
+		return err
+	}},
+	{"wl_registry.bind", func(o *objects) error {
+		o.output = &client.Output{}
+		return o.bind(5, "wl_output", 4, o.output)
+	}},
+	{"wl_registry.bind", func(o *objects) error {
+		o.ddm = &client.DataDeviceManager{}
+		return o.bind(6, "wl_data_device_manager", 3, o.ddm)
+	}},
+	{"wl_data_device_manager.create_data_source", func(o *objects) (err error) {
+		o.source, err = o.ddm.CreateDataSource()
+		return err
+	}},
+	{"wl_data_source.offer", func(o *objects) error {
+		return o.source.Offer("text/plain;charset=utf-8")
+	}},
+	{"wl_data_source.set_actions", func(o *objects) error {
+		return o.source.SetActions(client.DataDeviceManagerDndActionCopy | client.DataDeviceManagerDndActionMove)
+	}},
+	{"wl_data_device_manager.get_data_device", func(o *objects) (err error) {
+		o.device, err = o.ddm.GetDataDevice(o.seat)
+		return err
+	}},
+	{"wl_data_device.set_selection", func(o *objects) error {
+		return o.device.SetSelection(o.source, 5)
+	}},
+	{"wl_pointer.set_cursor", func(o *objects) error {
+		return o.pointer.SetCursor(9, o.surface, -1, 2)
+	}},
+	{"wl_shm_pool.destroy", func(o *objects) error {
+		return o.pool.Destroy()
+	}},
+}
+
+func TestRequests(t *testing.T) {
+	fixtures := readFixtures(t, "testdata/requests.txt")
+	if len(fixtures) != len(requests) {
+		t.Fatalf("got %d requests, want the %d of testdata/requests.txt", len(requests), len(fixtures))
+	}
+
+	o := connect(t)
+	for i, r := range requests {
+		f := fixtures[i]
+		if r.name != f.name {
+			t.Fatalf("request %d is %s, want %s", i, r.name, f.name)
+		}
+		if err := r.send(o); err != nil {
+			t.Fatalf("%s: %v", r.name, err)
+		}
+		data, fds := o.read()
+		if !bytes.Equal(data, f.data) {
+			t.Errorf("%s: got\n%x\nwant\n%x", r.name, data, f.data)
+		}
+		if fds != f.fds {
+			t.Errorf("%s: got %d fds, want %d", r.name, fds, f.fds)
+		}
+	}
+}
+
+// store returns a handler storing the event in got.
+func store[E any](got *any) func(E) {
+	return func(e E) { *got = e }
+}
+
+// events are received in the order of testdata/events.txt. handle sets
+// the handler of the event, which stores it in got, and returns the
+// event it should get.
+var events = []struct {
+	name   string
+	handle func(o *objects, got *any) any
+}{
+	{"wl_callback.done", func(o *objects, got *any) any {
+		o.callback.SetDoneHandler(store[client.CallbackDoneEvent](got))
+		return client.CallbackDoneEvent{CallbackData: 1234}
+	}},
+	{"wl_display.delete_id", func(o *objects, got *any) any {
+		o.display.SetDeleteIdHandler(store[client.DisplayDeleteIdEvent](got))
+		return client.DisplayDeleteIdEvent{Id: 8}
+	}},
+	{"wl_registry.global", func(o *objects, got *any) any {
+		o.registry.SetGlobalHandler(store[client.RegistryGlobalEvent](got))
+		return client.RegistryGlobalEvent{Name: 1, Interface: "wl_compositor", Version: 6}
+	}},
+	{"wl_registry.global", func(o *objects, got *any) any {
+		o.registry.SetGlobalHandler(store[client.RegistryGlobalEvent](got))
+		return client.RegistryGlobalEvent{Name: 3, Interface: "xdg_wm_base", Version: 5}
+	}},
+	{"wl_registry.global_remove", func(o *objects, got *any) any {
+		o.registry.SetGlobalRemoveHandler(store[client.RegistryGlobalRemoveEvent](got))
+		return client.RegistryGlobalRemoveEvent{Name: 6}
+	}},
+	{"wl_shm.format", func(o *objects, got *any) any {
+		o.shm.SetFormatHandler(store[client.ShmFormatEvent](got))
+		return client.ShmFormatEvent{Format: client.ShmFormatXrgb8888}
+	}},
+	{"wl_buffer.release", func(o *objects, got *any) any {
+		o.buffer.SetReleaseHandler(store[client.BufferReleaseEvent](got))
+		return client.BufferReleaseEvent{}
+	}},
+	{"wl_surface.enter", func(o *objects, got *any) any {
+		o.surface.SetEnterHandler(store[client.SurfaceEnterEvent](got))
+		return client.SurfaceEnterEvent{Output: o.output}
+	}},
+	{"wl_output.geometry", func(o *objects, got *any) any {
+		o.output.SetGeometryHandler(store[client.OutputGeometryEvent](got))
+		return client.OutputGeometryEvent{
+			X: 10, Y: -20, PhysicalWidth: 600, PhysicalHeight: 340,
+			Subpixel: client.OutputSubpixelNone, Make: "Go", Model: "Wayland",
+			Transform: client.OutputTransform270,
+		}
+	}},
+	{"wl_output.done", func(o *objects, got *any) any {
+		o.output.SetDoneHandler(store[client.OutputDoneEvent](got))
+		return client.OutputDoneEvent{}
+	}},
+	{"xdg_wm_base.ping", func(o *objects, got *any) any {
+		o.wmBase.SetPingHandler(store[xdg_shell.WmBasePingEvent](got))
+		return xdg_shell.WmBasePingEvent{Serial: 77}
+	}},
+	{"xdg_surface.configure", func(o *objects, got *any) any {
+		o.xdgSurface.SetConfigureHandler(store[xdg_shell.SurfaceConfigureEvent](got))
+		return xdg_shell.SurfaceConfigureEvent{Serial: 42}
+	}},
+	{"xdg_toplevel.configure", func(o *objects, got *any) any {
+		o.toplevel.SetConfigureHandler(store[xdg_shell.ToplevelConfigureEvent](got))
+		return xdg_shell.ToplevelConfigureEvent{
+			Width: 640, Height: 480,
+			States: []xdg_shell.ToplevelState{xdg_shell.ToplevelStateActivated, xdg_shell.ToplevelStateResizing},
+		}
+	}},
+	{"xdg_toplevel.close", func(o *objects, got *any) any {
+		o.toplevel.SetCloseHandler(store[xdg_shell.ToplevelCloseEvent](got))
+		return xdg_shell.ToplevelCloseEvent{}
+	}},
+	{"wl_seat.capabilities", func(o *objects, got *any) any {
+		o.seat.SetCapabilitiesHandler(store[client.SeatCapabilitiesEvent](got))
+		return client.SeatCapabilitiesEvent{Capabilities: client.SeatCapabilityPointer | client.SeatCapabilityKeyboard}
+	}},
+	{"wl_seat.name", func(o *objects, got *any) any {
+		o.seat.SetNameHandler(store[client.SeatNameEvent](got))
+		return client.SeatNameEvent{Name: "seat0"}
+	}},
+	{"wl_pointer.enter", func(o *objects, got *any) any {
+		o.pointer.SetEnterHandler(store[client.PointerEnterEvent](got))
+		return client.PointerEnterEvent{Serial: 10, Surface: o.surface, SurfaceX: 12.5, SurfaceY: -3.25}
+	}},
+	{"wl_pointer.motion", func(o *objects, got *any) any {
+		o.pointer.SetMotionHandler(store[client.PointerMotionEvent](got))
+		return client.PointerMotionEvent{Time: 1000, SurfaceX: 0.00390625, SurfaceY: 1024.75}
+	}},
+	{"wl_pointer.axis", func(o *objects, got *any) any {
+		o.pointer.SetAxisHandler(store[client.PointerAxisEvent](got))
+		return client.PointerAxisEvent{Time: 1001, Axis: client.PointerAxisVerticalScroll, Value: -10.5}
+	}},
+	{"wl_keyboard.keymap", func(o *objects, got *any) any {
+		o.keyboard.SetKeymapHandler(func(e client.KeyboardKeymapEvent) {
+			// The fd is a new one, only check that it is open
+			if unix.Close(e.Fd) == nil {
+				e.Fd = 0
+			}
+			*got = e
+		})
+		return client.KeyboardKeymapEvent{Format: client.KeyboardKeymapFormatXkbV1, Size: 4096}
+	}},
+	{"wl_keyboard.enter", func(o *objects, got *any) any {
+		o.keyboard.SetEnterHandler(store[client.KeyboardEnterEvent](got))
+		return client.KeyboardEnterEvent{Serial: 11, Surface: o.surface, Keys: []uint32{30, 48}}
+	}},
+	{"wl_keyboard.key", func(o *objects, got *any) any {
+		o.keyboard.SetKeyHandler(store[client.KeyboardKeyEvent](got))
+		return client.KeyboardKeyEvent{Serial: 12, Time: 2000, Key: 30, State: client.KeyboardKeyStatePressed}
+	}},
+	{"wl_keyboard.repeat_info", func(o *objects, got *any) any {
+		o.keyboard.SetRepeatInfoHandler(store[client.KeyboardRepeatInfoEvent](got))
+		return client.KeyboardRepeatInfoEvent{Rate: 25, Delay: 600}
+	}},
+	{"wl_data_device.data_offer", func(o *objects, got *any) any {
+		o.device.SetDataOfferHandler(func(e client.DataDeviceDataOfferEvent) {
+			o.offer = e.Id
+			*got = client.ObjectString(e.Id)
+		})
+		return "wl_data_offer@4278190080"
+	}},
+	{"wl_data_offer.offer", func(o *objects, got *any) any {
+		o.offer.SetOfferHandler(store[client.DataOfferOfferEvent](got))
+		return client.DataOfferOfferEvent{MimeType: "text/plain"}
+	}},
+	{"wl_data_offer.source_actions", func(o *objects, got *any) any {
+		o.offer.SetSourceActionsHandler(store[client.DataOfferSourceActionsEvent](got))
+		return client.DataOfferSourceActionsEvent{SourceActions: client.DataDeviceManagerDndActionCopy | client.DataDeviceManagerDndActionMove}
+	}},
+	{"wl_data_device.selection", func(o *objects, got *any) any {
+		o.device.SetSelectionHandler(store[client.DataDeviceSelectionEvent](got))
+		return client.DataDeviceSelectionEvent{Id: o.offer}
+	}},
+	{"wl_data_device.selection", func(o *objects, got *any) any {
+		o.device.SetSelectionHandler(store[client.DataDeviceSelectionEvent](got))
+		return client.DataDeviceSelectionEvent{}
+	}},
+	{"wl_data_source.target", func(o *objects, got *any) any {
+		o.source.SetTargetHandler(store[client.DataSourceTargetEvent](got))
+		mimeType := "text/plain"
+		return client.DataSourceTargetEvent{MimeType: &mimeType}
+	}},
+	{"wl_data_source.target", func(o *objects, got *any) any {
+		o.source.SetTargetHandler(store[client.DataSourceTargetEvent](got))
+		return client.DataSourceTargetEvent{}
+	}},
+	{"wl_data_source.cancelled", func(o *objects, got *any) any {
+		o.source.SetCancelledHandler(store[client.DataSourceCancelledEvent](got))
+		return client.DataSourceCancelledEvent{}
+	}},
+	{"wl_display.error", func(o *objects, got *any) any {
+		o.display.SetErrorHandler(store[client.DisplayErrorEvent](got))
+		return client.DisplayErrorEvent{ObjectId: o.surface, Code: 2, Message: "invalid surface"}
+	}},
+}
+
+func TestEvents(t *testing.T) {
+	fixtures := readFixtures(t, "testdata/events.txt")
+	if len(fixtures) != len(events) {
+		t.Fatalf("got %d events, want the %d of testdata/events.txt", len(events), len(fixtures))
+	}
+
+	// Create the objects the events are sent to
+	o := connect(t)
+	for _, r := range requests {
+		if err := r.send(o); err != nil {
+			t.Fatalf("%s: %v", r.name, err)
+		}
+		o.read()
+	}
+
+	for i, e := range events {
+		f := fixtures[i]
+		if e.name != f.name {
+			t.Fatalf("event %d is %s, want %s", i, e.name, f.name)
+		}
+		var got any
+		want := e.handle(o, &got)
+		o.write(f)
+		if err := o.display.Context().Dispatch(); err != nil {
+			t.Fatalf("%s: %v", e.name, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %+v, want %+v", e.name, got, want)
+		}
+	}
+}
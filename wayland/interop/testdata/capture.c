@@ -0,0 +1,380 @@
+// capture writes the wire format of messages marshalled by libwayland,
+// for the interop tests. It declares what it needs from libwayland itself,
+// so it builds without the development headers:
+//
+//	cc -o capture capture.c -l:libwayland-client.so.0 -l:libwayland-server.so.0
+//	./capture requests > requests.txt
+//	./capture events > events.txt
+//
+// Each line is the message name, the number of file descriptors sent with
+// it and its bytes in hex. The objects are created in the same order as in
+// interop_test.go, so that both sides use the same ids.
+
+#include <stdarg.h>
+#include <stdint.h>
+#include <stdio.h>
+#include <stdlib.h>
+#include <string.h>
+#include <sys/socket.h>
+#include <unistd.h>
+
+struct wl_interface;
+
+struct wl_message {
+	const char *name;
+	const char *signature;
+	const struct wl_interface **types;
+};
+
+struct wl_interface {
+	const char *name;
+	int version;
+	int method_count;
+	const struct wl_message *methods;
+	int event_count;
+	const struct wl_message *events;
+};
+
+struct wl_array {
+	size_t size;
+	size_t alloc;
+	void *data;
+};
+
+struct wl_proxy;
+struct wl_display;
+struct wl_resource;
+struct wl_client;
+struct wl_server_display;
+
+struct wl_display *wl_display_connect_to_fd(int fd);
+int wl_display_flush(struct wl_display *display);
+struct wl_proxy *wl_proxy_marshal_flags(struct wl_proxy *proxy, uint32_t opcode,
+					const struct wl_interface *interface,
+					uint32_t version, uint32_t flags, ...);
+
+struct wl_server_display *wl_display_create(void);
+struct wl_client *wl_client_create(struct wl_server_display *display, int fd);
+void wl_client_flush(struct wl_client *client);
+struct wl_resource *wl_client_get_object(struct wl_client *client, uint32_t id);
+struct wl_resource *wl_resource_create(struct wl_client *client,
+				       const struct wl_interface *interface,
+				       int version, uint32_t id);
+void wl_resource_post_event(struct wl_resource *resource, uint32_t opcode, ...);
+
+extern const struct wl_interface wl_registry_interface;
+extern const struct wl_interface wl_callback_interface;
+extern const struct wl_interface wl_compositor_interface;
+extern const struct wl_interface wl_shm_interface;
+extern const struct wl_interface wl_shm_pool_interface;
+extern const struct wl_interface wl_buffer_interface;
+extern const struct wl_interface wl_surface_interface;
+extern const struct wl_interface wl_seat_interface;
+extern const struct wl_interface wl_pointer_interface;
+extern const struct wl_interface wl_keyboard_interface;
+extern const struct wl_interface wl_output_interface;
+extern const struct wl_interface wl_data_device_manager_interface;
+extern const struct wl_interface wl_data_source_interface;
+extern const struct wl_interface wl_data_device_interface;
+extern const struct wl_interface wl_data_offer_interface;
+
+// The xdg-shell messages used here, libwayland doesn't ship them. The
+// types are only needed to demarshal, they are left out.
+static const struct wl_interface *no_types[8];
+
+static const struct wl_message xdg_wm_base_requests[] = {
+	{"destroy", "", no_types},
+	{"create_positioner", "n", no_types},
+	{"get_xdg_surface", "no", no_types},
+	{"pong", "u", no_types},
+};
+static const struct wl_message xdg_wm_base_events[] = {
+	{"ping", "u", no_types},
+};
+static const struct wl_interface xdg_wm_base_interface = {
+	"xdg_wm_base", 5, 4, xdg_wm_base_requests, 1, xdg_wm_base_events,
+};
+
+static const struct wl_message xdg_surface_requests[] = {
+	{"destroy", "", no_types},
+	{"get_toplevel", "n", no_types},
+	{"get_popup", "n?oo", no_types},
+	{"set_window_geometry", "iiii", no_types},
+	{"ack_configure", "u", no_types},
+};
+static const struct wl_message xdg_surface_events[] = {
+	{"configure", "u", no_types},
+};
+static const struct wl_interface xdg_surface_interface = {
+	"xdg_surface", 5, 5, xdg_surface_requests, 1, xdg_surface_events,
+};
+
+static const struct wl_message xdg_toplevel_requests[] = {
+	{"destroy", "", no_types},
+	{"set_parent", "?o", no_types},
+	{"set_title", "s", no_types},
+	{"set_app_id", "s", no_types},
+	{"show_window_menu", "ouii", no_types},
+	{"move", "ou", no_types},
+	{"resize", "ouu", no_types},
+	{"set_max_size", "ii", no_types},
+	{"set_min_size", "ii", no_types},
+};
+static const struct wl_message xdg_toplevel_events[] = {
+	{"configure", "iia", no_types},
+	{"close", "", no_types},
+};
+static const struct wl_interface xdg_toplevel_interface = {
+	"xdg_toplevel", 5, 9, xdg_toplevel_requests, 2, xdg_toplevel_events,
+};
+
+static int peer;
+
+// dump reads the message just sent to the other end of the socket and
+// prints it.
+static void dump(const char *name)
+{
+	unsigned char buf[4096];
+	char control[CMSG_SPACE(sizeof(int) * 28)];
+	struct iovec iov = {buf, sizeof buf};
+	struct msghdr msg = {
+		.msg_iov = &iov,
+		.msg_iovlen = 1,
+		.msg_control = control,
+		.msg_controllen = sizeof control,
+	};
+	ssize_t n = recvmsg(peer, &msg, 0);
+	if (n <= 0) {
+		perror(name);
+		exit(1);
+	}
+
+	int fds = 0;
+	for (struct cmsghdr *c = CMSG_FIRSTHDR(&msg); c; c = CMSG_NXTHDR(&msg, c)) {
+		if (c->cmsg_level == SOL_SOCKET && c->cmsg_type == SCM_RIGHTS) {
+			int *fd = (int *)CMSG_DATA(c);
+			int count = (c->cmsg_len - CMSG_LEN(0)) / sizeof(int);
+			for (int i = 0; i < count; i++)
+				close(fd[i]);
+			fds += count;
+		}
+	}
+
+	printf("%s %d ", name, fds);
+	for (ssize_t i = 0; i < n; i++)
+		printf("%02x", buf[i]);
+	printf("\n");
+}
+
+static int32_t fixed(double d)
+{
+	return (int32_t)(d * 256.0);
+}
+
+static struct wl_display *display;
+
+#define REQUEST(name, proxy, opcode, iface, version, ...)                              \
+	({                                                                             \
+		struct wl_proxy *p = wl_proxy_marshal_flags((struct wl_proxy *)(proxy), \
+							    opcode, iface, version, 0,  \
+							    ##__VA_ARGS__);             \
+		wl_display_flush(display);                                             \
+		dump(name);                                                            \
+		p;                                                                     \
+	})
+
+#define BIND(global, iface, version)                                        \
+	REQUEST("wl_registry.bind", registry, 0, iface, version, global,    \
+		(iface)->name, version, NULL)
+
+static void requests(int fd)
+{
+	display = wl_display_connect_to_fd(fd);
+	if (!display) {
+		perror("wl_display_connect_to_fd");
+		exit(1);
+	}
+
+	// 2
+	struct wl_proxy *registry = REQUEST("wl_display.get_registry", display, 1,
+					    &wl_registry_interface, 1, NULL);
+	// 3, 4
+	struct wl_proxy *compositor = BIND(1, &wl_compositor_interface, 6);
+	struct wl_proxy *surface = REQUEST("wl_compositor.create_surface", compositor, 0,
+					   &wl_surface_interface, 6, NULL);
+	// 5, 6, 7
+	struct wl_proxy *shm = BIND(2, &wl_shm_interface, 1);
+	int pipes[2];
+	if (pipe(pipes) != 0) {
+		perror("pipe");
+		exit(1);
+	}
+	struct wl_proxy *pool = REQUEST("wl_shm.create_pool", shm, 0,
+					&wl_shm_pool_interface, 1, NULL, pipes[0], 4096);
+	close(pipes[0]);
+	close(pipes[1]);
+	struct wl_proxy *buffer = REQUEST("wl_shm_pool.create_buffer", pool, 0,
+					  &wl_buffer_interface, 1, NULL, 0, 16, 8, 64, 0);
+	REQUEST("wl_surface.attach", surface, 1, NULL, 6, buffer, 0, 0);
+	REQUEST("wl_surface.attach", surface, 1, NULL, 6, NULL, 5, -7);
+	REQUEST("wl_surface.damage_buffer", surface, 9, NULL, 6, 0, 0, 16, 8);
+	REQUEST("wl_surface.set_buffer_scale", surface, 8, NULL, 6, 2);
+	REQUEST("wl_surface.commit", surface, 6, NULL, 6);
+	// 8
+	REQUEST("wl_display.sync", display, 0, &wl_callback_interface, 1, NULL);
+	// 9, 10, 11
+	struct wl_proxy *wm_base = BIND(3, &xdg_wm_base_interface, 5);
+	struct wl_proxy *xdg_surface = REQUEST("xdg_wm_base.get_xdg_surface", wm_base, 2,
+					       &xdg_surface_interface, 5, NULL, surface);
+	struct wl_proxy *toplevel = REQUEST("xdg_surface.get_toplevel", xdg_surface, 1,
+					    &xdg_toplevel_interface, 5, NULL);
+	REQUEST("xdg_toplevel.set_title", toplevel, 2, NULL, 5, "go-wayland");
+	REQUEST("xdg_toplevel.set_app_id", toplevel, 3, NULL, 5, "");
+	REQUEST("xdg_toplevel.set_min_size", toplevel, 8, NULL, 5, 320, 240);
+	REQUEST("xdg_toplevel.set_parent", toplevel, 1, NULL, 5, NULL);
+	REQUEST("xdg_wm_base.pong", wm_base, 3, NULL, 5, 77);
+	// 12, 13, 14
+	struct wl_proxy *seat = BIND(4, &wl_seat_interface, 9);
+	struct wl_proxy *pointer = REQUEST("wl_seat.get_pointer", seat, 0,
+					   &wl_pointer_interface, 9, NULL);
+	REQUEST("wl_seat.get_keyboard", seat, 1, &wl_keyboard_interface, 9, NULL);
+	// 15
+	BIND(5, &wl_output_interface, 4);
+	// 16, 17, 18
+	struct wl_proxy *ddm = BIND(6, &wl_data_device_manager_interface, 3);
+	struct wl_proxy *source = REQUEST("wl_data_device_manager.create_data_source", ddm, 0,
+					  &wl_data_source_interface, 3, NULL);
+	REQUEST("wl_data_source.offer", source, 0, NULL, 3, "text/plain;charset=utf-8");
+	REQUEST("wl_data_source.set_actions", source, 2, NULL, 3, 3);
+	struct wl_proxy *device = REQUEST("wl_data_device_manager.get_data_device", ddm, 1,
+					  &wl_data_device_interface, 3, NULL, seat);
+	REQUEST("wl_data_device.set_selection", device, 1, NULL, 3, source, 5);
+	REQUEST("wl_pointer.set_cursor", pointer, 0, NULL, 9, 9, surface, -1, 2);
+	REQUEST("wl_shm_pool.destroy", pool, 1, NULL, 1);
+}
+
+#define EVENT(name, resource, opcode, ...)                        \
+	do {                                                      \
+		wl_resource_post_event(resource, opcode, ##__VA_ARGS__); \
+		wl_client_flush(client);                          \
+		dump(name);                                       \
+	} while (0)
+
+static void events(int fd)
+{
+	struct wl_server_display *display = wl_display_create();
+	struct wl_client *client = wl_client_create(display, fd);
+	if (!client) {
+		perror("wl_client_create");
+		exit(1);
+	}
+
+	// The objects created by the requests, the ids of the client have to
+	// be allocated in order
+	struct wl_resource *r[19] = {0};
+	r[1] = wl_client_get_object(client, 1);
+	static const struct {
+		const struct wl_interface *iface;
+		int version;
+	} objects[] = {
+		[2] = {&wl_registry_interface, 1},
+		[3] = {&wl_compositor_interface, 6},
+		[4] = {&wl_surface_interface, 6},
+		[5] = {&wl_shm_interface, 1},
+		[6] = {&wl_shm_pool_interface, 1},
+		[7] = {&wl_buffer_interface, 1},
+		[8] = {&wl_callback_interface, 1},
+		[9] = {&xdg_wm_base_interface, 5},
+		[10] = {&xdg_surface_interface, 5},
+		[11] = {&xdg_toplevel_interface, 5},
+		[12] = {&wl_seat_interface, 9},
+		[13] = {&wl_pointer_interface, 9},
+		[14] = {&wl_keyboard_interface, 9},
+		[15] = {&wl_output_interface, 4},
+		[16] = {&wl_data_device_manager_interface, 3},
+		[17] = {&wl_data_source_interface, 3},
+		[18] = {&wl_data_device_interface, 3},
+	};
+	for (uint32_t id = 2; id <= 18; id++) {
+		r[id] = wl_resource_create(client, objects[id].iface, objects[id].version, id);
+		if (!r[id]) {
+			perror("wl_resource_create");
+			exit(1);
+		}
+	}
+
+	EVENT("wl_callback.done", r[8], 0, 1234);
+	EVENT("wl_display.delete_id", r[1], 1, 8);
+	EVENT("wl_registry.global", r[2], 0, 1, "wl_compositor", 6);
+	EVENT("wl_registry.global", r[2], 0, 3, "xdg_wm_base", 5);
+	EVENT("wl_registry.global_remove", r[2], 1, 6);
+	EVENT("wl_shm.format", r[5], 0, 1);
+	EVENT("wl_buffer.release", r[7], 0);
+	EVENT("wl_surface.enter", r[4], 0, r[15]);
+	EVENT("wl_output.geometry", r[15], 0, 10, -20, 600, 340, 1, "Go", "Wayland", 3);
+	EVENT("wl_output.done", r[15], 2);
+	EVENT("xdg_wm_base.ping", r[9], 0, 77);
+	EVENT("xdg_surface.configure", r[10], 0, 42);
+
+	uint32_t states[] = {4, 3};
+	struct wl_array states_array = {sizeof states, sizeof states, states};
+	EVENT("xdg_toplevel.configure", r[11], 0, 640, 480, &states_array);
+	EVENT("xdg_toplevel.close", r[11], 1);
+
+	EVENT("wl_seat.capabilities", r[12], 0, 3);
+	EVENT("wl_seat.name", r[12], 1, "seat0");
+	EVENT("wl_pointer.enter", r[13], 0, 10, r[4], fixed(12.5), fixed(-3.25));
+	EVENT("wl_pointer.motion", r[13], 2, 1000, fixed(0.00390625), fixed(1024.75));
+	EVENT("wl_pointer.axis", r[13], 4, 1001, 0, fixed(-10.5));
+
+	int pipes[2];
+	if (pipe(pipes) != 0) {
+		perror("pipe");
+		exit(1);
+	}
+	EVENT("wl_keyboard.keymap", r[14], 0, 1, pipes[0], 4096);
+	close(pipes[0]);
+	close(pipes[1]);
+
+	uint32_t keys[] = {30, 48};
+	struct wl_array keys_array = {sizeof keys, sizeof keys, keys};
+	EVENT("wl_keyboard.enter", r[14], 1, 11, r[4], &keys_array);
+	EVENT("wl_keyboard.key", r[14], 3, 12, 2000, 30, 1);
+	EVENT("wl_keyboard.repeat_info", r[14], 5, 25, 600);
+
+	struct wl_resource *offer = wl_resource_create(client, &wl_data_offer_interface, 3, 0);
+	EVENT("wl_data_device.data_offer", r[18], 0, offer);
+	EVENT("wl_data_offer.offer", offer, 0, "text/plain");
+	EVENT("wl_data_offer.source_actions", offer, 1, 3);
+	EVENT("wl_data_device.selection", r[18], 5, offer);
+	EVENT("wl_data_device.selection", r[18], 5, NULL);
+	EVENT("wl_data_source.target", r[17], 0, "text/plain");
+	EVENT("wl_data_source.target", r[17], 0, NULL);
+	EVENT("wl_data_source.cancelled", r[17], 2);
+	EVENT("wl_display.error", r[1], 0, r[4], 2, "invalid surface");
+}
+
+int main(int argc, char **argv)
+{
+	if (argc != 2) {
+		fprintf(stderr, "usage: capture requests|events\n");
+		return 2;
+	}
+
+	int fds[2];
+	if (socketpair(AF_UNIX, SOCK_STREAM | SOCK_CLOEXEC, 0, fds) != 0) {
+		perror("socketpair");
+		return 1;
+	}
+	peer = fds[1];
+
+	if (strcmp(argv[1], "requests") == 0) {
+		requests(fds[0]);
+	} else if (strcmp(argv[1], "events") == 0) {
+		events(fds[0]);
+	} else {
+		fprintf(stderr, "usage: capture requests|events\n");
+		return 2;
+	}
+	return 0;
+}
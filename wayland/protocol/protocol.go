@@ -0,0 +1,104 @@
+// Package protocol is the model of wayland protocol XML files, as parsed
+// by go-wayland-scanner and the tools built around it.
+package protocol
+
+//go:generate go run github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner -manifest manifest.json
+
+import (
+	"encoding/xml"
+	"io"
+	"os"
+)
+
+type Protocol struct {
+	XMLName    xml.Name    `xml:"protocol"`
+	Name       string      `xml:"name,attr"`
+	Copyright  string      `xml:"copyright"`
+	Interfaces []Interface `xml:"interface"`
+}
+
+type Interface struct {
+	XMLName     xml.Name    `xml:"interface"`
+	Name        string      `xml:"name,attr"`
+	Description Description `xml:"description"`
+	Requests    []Request   `xml:"request"`
+	Events      []Event     `xml:"event"`
+	Enums       []Enum      `xml:"enum"`
+	Version     int         `xml:"version,attr"`
+}
+
+type Request struct {
+	XMLName         xml.Name    `xml:"request"`
+	Name            string      `xml:"name,attr"`
+	Type            string      `xml:"type,attr"`
+	Description     Description `xml:"description"`
+	Args            []Arg       `xml:"arg"`
+	Since           int         `xml:"since,attr"`
+	DeprecatedSince int         `xml:"deprecated-since,attr"`
+}
+
+type Event struct {
+	XMLName         xml.Name    `xml:"event"`
+	Name            string      `xml:"name,attr"`
+	Type            string      `xml:"type,attr"`
+	Description     Description `xml:"description"`
+	Args            []Arg       `xml:"arg"`
+	Since           int         `xml:"since,attr"`
+	DeprecatedSince int         `xml:"deprecated-since,attr"`
+}
+
+type Enum struct {
+	XMLName     xml.Name    `xml:"enum"`
+	Name        string      `xml:"name,attr"`
+	Description Description `xml:"description"`
+	Entries     []Entry     `xml:"entry"`
+	Since       int         `xml:"since,attr"`
+	Bitfield    bool        `xml:"bitfield,attr"`
+}
+
+type Entry struct {
+	XMLName         xml.Name    `xml:"entry"`
+	Name            string      `xml:"name,attr"`
+	Value           string      `xml:"value,attr"`
+	Summary         string      `xml:"summary,attr"`
+	Description     Description `xml:"description"`
+	Since           int         `xml:"since,attr"`
+	DeprecatedSince int         `xml:"deprecated-since,attr"`
+}
+
+type Arg struct {
+	XMLName     xml.Name    `xml:"arg"`
+	Name        string      `xml:"name,attr"`
+	Type        string      `xml:"type,attr"`
+	Summary     string      `xml:"summary,attr"`
+	Interface   string      `xml:"interface,attr"`
+	Enum        string      `xml:"enum,attr"`
+	Description Description `xml:"description"`
+	AllowNull   bool        `xml:"allow-null,attr"`
+}
+
+type Description struct {
+	XMLName xml.Name `xml:"description"`
+	Text    string   `xml:",chardata"`
+	Summary string   `xml:"summary,attr"`
+}
+
+// Decode parses a protocol XML document.
+func Decode(r io.Reader) (*Protocol, error) {
+	p := &Protocol{}
+	if err := xml.NewDecoder(r).Decode(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Load parses the protocol XML file at path.
+func Load(path string) (*Protocol, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Decode(f)
+}
@@ -0,0 +1,89 @@
+package shm
+
+import "github.com/rajveermalviya/go-wayland/wayland/client"
+
+// Buffer is a buffer allocated in a Pool, with its wl_buffer.
+type Buffer struct {
+	Width  int32
+	Height int32
+	Stride int32
+	Format client.ShmFormat
+
+	pool   *Pool
+	span   span
+	buffer *client.Buffer
+	// busy is set from Attach to the release of the buffer
+	busy bool
+	// destroyed is set by Destroy while the buffer is busy, it is
+	// destroyed on release
+	destroyed bool
+}
+
+// Data returns the pixels of the buffer, Stride bytes per row. It is only
+// valid until the pool grows, call it again after allocating.
+func (b *Buffer) Data() []byte {
+	return b.pool.data[b.span.offset : b.span.offset+b.span.size]
+}
+
+// WlBuffer returns the wl_buffer of the buffer, created on the first call.
+// Its release handler is set by the Buffer.
+func (b *Buffer) WlBuffer() (*client.Buffer, error) {
+	if b.buffer != nil {
+		return b.buffer, nil
+	}
+
+	buffer, err := b.pool.pool.CreateBuffer(int32(b.span.offset), b.Width, b.Height, b.Stride, b.Format)
+	if err != nil {
+		return nil, err
+	}
+	buffer.SetReleaseHandler(func(client.BufferReleaseEvent) {
+		b.busy = false
+		if b.destroyed {
+			_ = b.destroy()
+		}
+	})
+	b.buffer = buffer
+	return buffer, nil
+}
+
+// Attach attaches the buffer to a surface, it is busy until the
+// compositor releases it.
+func (b *Buffer) Attach(surface *client.Surface, x, y int32) error {
+	buffer, err := b.WlBuffer()
+	if err != nil {
+		return err
+	}
+	if err := surface.Attach(buffer, x, y); err != nil {
+		return err
+	}
+	b.busy = true
+	return nil
+}
+
+// Busy reports whether the buffer was attached and not released yet by
+// the compositor, which may still read it.
+func (b *Buffer) Busy() bool {
+	return b.busy
+}
+
+// Destroy destroys the wl_buffer and frees the memory of the buffer. A busy
+// buffer is destroyed once the compositor releases it.
+func (b *Buffer) Destroy() error {
+	if b.busy {
+		b.destroyed = true
+		return nil
+	}
+	return b.destroy()
+}
+
+func (b *Buffer) destroy() error {
+	var err error
+	if b.buffer != nil {
+		err = b.buffer.Destroy()
+		b.buffer = nil
+	}
+	if b.pool.data != nil {
+		b.pool.release(b.span)
+	}
+	return err
+}
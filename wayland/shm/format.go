@@ -0,0 +1,47 @@
+package shm
+
+import "github.com/rajveermalviya/go-wayland/wayland/client"
+
+// bytesPerPixel returns the size of a pixel of the packed RGB formats. The
+// other formats, YUV and planar, return 1, the least size of the pixels of
+// their first plane.
+func bytesPerPixel(format client.ShmFormat) int32 {
+	switch format {
+	case client.ShmFormatC8, client.ShmFormatR8,
+		client.ShmFormatRgb332, client.ShmFormatBgr233:
+		return 1
+
+	case client.ShmFormatXrgb4444, client.ShmFormatXbgr4444,
+		client.ShmFormatRgbx4444, client.ShmFormatBgrx4444,
+		client.ShmFormatArgb4444, client.ShmFormatAbgr4444,
+		client.ShmFormatRgba4444, client.ShmFormatBgra4444,
+		client.ShmFormatXrgb1555, client.ShmFormatXbgr1555,
+		client.ShmFormatRgbx5551, client.ShmFormatBgrx5551,
+		client.ShmFormatArgb1555, client.ShmFormatAbgr1555,
+		client.ShmFormatRgba5551, client.ShmFormatBgra5551,
+		client.ShmFormatRgb565, client.ShmFormatBgr565,
+		client.ShmFormatR16, client.ShmFormatRg88, client.ShmFormatGr88:
+		return 2
+
+	case client.ShmFormatRgb888, client.ShmFormatBgr888:
+		return 3
+
+	case client.ShmFormatArgb8888, client.ShmFormatXrgb8888,
+		client.ShmFormatXbgr8888, client.ShmFormatRgbx8888,
+		client.ShmFormatBgrx8888, client.ShmFormatAbgr8888,
+		client.ShmFormatRgba8888, client.ShmFormatBgra8888,
+		client.ShmFormatXrgb2101010, client.ShmFormatXbgr2101010,
+		client.ShmFormatRgbx1010102, client.ShmFormatBgrx1010102,
+		client.ShmFormatArgb2101010, client.ShmFormatAbgr2101010,
+		client.ShmFormatRgba1010102, client.ShmFormatBgra1010102,
+		client.ShmFormatRg1616, client.ShmFormatGr1616:
+		return 4
+
+	case client.ShmFormatXrgb16161616F, client.ShmFormatXbgr16161616F,
+		client.ShmFormatArgb16161616F, client.ShmFormatAbgr16161616F,
+		client.ShmFormatXrgb16161616, client.ShmFormatXbgr16161616,
+		client.ShmFormatArgb16161616, client.ShmFormatAbgr16161616:
+		return 8
+	}
+	return 1
+}
@@ -0,0 +1,209 @@
+// Package shm allocates wl_shm buffers in memory shared with the
+// compositor.
+//
+// A Pool is a wl_shm_pool which grows as buffers are allocated in it.
+// Buffers track whether the compositor still reads them, between their
+// attachment to a surface and their wl_buffer.release event, and Slots
+// cycle through a few buffers of the size of a window for double or triple
+// buffering:
+//
+//	pool, err := shm.NewPool(shm, width*height*4*2)
+//	slots := shm.NewSlots(pool, 2)
+//
+//	// on every frame
+//	buf, err := slots.Next(width, height, width*4, client.ShmFormatArgb8888)
+//	if errors.Is(err, shm.ErrBusy) {
+//		// wait for a wl_surface.frame callback
+//	}
+//	draw(buf.Data())
+//	buf.Attach(surface, 0, 0)
+//	surface.Commit()
+package shm
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+	"github.com/rajveermalviya/go-wayland/wayland/internal/tempfile"
+	"golang.org/x/sys/unix"
+)
+
+// span is a range of bytes of a pool.
+type span struct {
+	offset int
+	size   int
+}
+
+// Pool is a wl_shm_pool and its memory, mapped in the client.
+type Pool struct {
+	pool *client.ShmPool
+	f    *os.File
+	data []byte
+	// free are the unallocated spans, sorted by offset and merged
+	free []span
+}
+
+// NewPool creates a pool of size bytes, which grows when allocations
+// don't fit.
+func NewPool(shm *client.Shm, size int) (*Pool, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("shm.NewPool: invalid size %d", size)
+	}
+
+	f, err := tempfile.Create(int64(size))
+	if err != nil {
+		return nil, err
+	}
+	data, err := unix.Mmap(int(f.Fd()), 0, size, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_SHARED)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	pool, err := shm.CreatePool(int(f.Fd()), int32(size))
+	if err != nil {
+		_ = unix.Munmap(data)
+		f.Close()
+		return nil, err
+	}
+
+	return &Pool{
+		pool: pool,
+		f:    f,
+		data: data,
+		free: []span{{0, size}},
+	}, nil
+}
+
+// Data returns the memory of the pool. It is mapped again when the pool
+// grows, slices of it are only valid until then.
+func (p *Pool) Data() []byte {
+	return p.data
+}
+
+// Size returns the size of the pool in bytes.
+func (p *Pool) Size() int {
+	return len(p.data)
+}
+
+// Resize grows the pool to size bytes, a wl_shm_pool can't shrink.
+func (p *Pool) Resize(size int) error {
+	old := len(p.data)
+	if size < old {
+		return fmt.Errorf("shm.Pool.Resize: %d is below the size of the pool %d", size, old)
+	}
+	if size == old {
+		return nil
+	}
+
+	if err := p.f.Truncate(int64(size)); err != nil {
+		return err
+	}
+	data, err := unix.Mmap(int(p.f.Fd()), 0, size, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_SHARED)
+	if err != nil {
+		return err
+	}
+	if err := p.pool.Resize(int32(size)); err != nil {
+		_ = unix.Munmap(data)
+		return err
+	}
+	if err := unix.Munmap(p.data); err != nil {
+		return err
+	}
+	p.data = data
+
+	p.release(span{old, size - old})
+	return nil
+}
+
+// allocate returns the offset of size free bytes, growing the pool to at
+// least twice its size when they don't fit.
+func (p *Pool) allocate(size int) (int, error) {
+	for i, s := range p.free {
+		if s.size < size {
+			continue
+		}
+		if s.size == size {
+			p.free = append(p.free[:i], p.free[i+1:]...)
+		} else {
+			p.free[i] = span{s.offset + size, s.size - size}
+		}
+		return s.offset, nil
+	}
+
+	// The free span at the end, if any, is extended by the growth
+	grow := size
+	if n := len(p.free); n > 0 {
+		if last := p.free[n-1]; last.offset+last.size == len(p.data) {
+			grow -= last.size
+		}
+	}
+	if err := p.Resize(max(2*len(p.data), len(p.data)+grow)); err != nil {
+		return 0, err
+	}
+	return p.allocate(size)
+}
+
+// release returns a span to the free list, merging it with its
+// neighbours.
+func (p *Pool) release(s span) {
+	i := sort.Search(len(p.free), func(i int) bool { return p.free[i].offset > s.offset })
+	p.free = append(p.free, span{})
+	copy(p.free[i+1:], p.free[i:])
+	p.free[i] = s
+
+	if i+1 < len(p.free) && s.offset+s.size == p.free[i+1].offset {
+		p.free[i].size += p.free[i+1].size
+		p.free = append(p.free[:i+1], p.free[i+2:]...)
+	}
+	if i > 0 && p.free[i-1].offset+p.free[i-1].size == s.offset {
+		p.free[i-1].size += p.free[i].size
+		p.free = append(p.free[:i], p.free[i+1:]...)
+	}
+}
+
+// Seal fixes the size of the pool, when its memory can be sealed, so that
+// the compositor can rely on it. The pool can't grow anymore, allocations
+// which don't fit fail.
+func (p *Pool) Seal() error {
+	return tempfile.Seal(p.f)
+}
+
+// Alloc allocates the memory of a buffer in the pool, its wl_buffer is
+// created by Buffer.WlBuffer or Buffer.Attach. The stride must hold width
+// pixels of the format.
+func (p *Pool) Alloc(width, height, stride int32, format client.ShmFormat) (*Buffer, error) {
+	if width <= 0 || height <= 0 || int64(stride) < int64(width)*int64(bytesPerPixel(format)) {
+		return nil, fmt.Errorf("shm.Pool.Alloc: invalid size %dx%d with stride %d", width, height, stride)
+	}
+
+	size := int(stride) * int(height)
+	offset, err := p.allocate(size)
+	if err != nil {
+		return nil, err
+	}
+	return &Buffer{
+		Width:  width,
+		Height: height,
+		Stride: stride,
+		Format: format,
+		pool:   p,
+		span:   span{offset, size},
+	}, nil
+}
+
+// Destroy destroys the wl_shm_pool and unmaps its memory. The wl_buffers
+// of the pool stay valid for the compositor, the memory of its Buffers
+// can't be used anymore.
+func (p *Pool) Destroy() error {
+	err := p.pool.Destroy()
+	if err2 := unix.Munmap(p.data); err == nil {
+		err = err2
+	}
+	p.data = nil
+	if err2 := p.f.Close(); err == nil {
+		err = err2
+	}
+	return err
+}
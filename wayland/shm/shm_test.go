@@ -0,0 +1,209 @@
+package shm_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+	"github.com/rajveermalviya/go-wayland/wayland/shm"
+	"github.com/rajveermalviya/go-wayland/wayland/waylandtest"
+	"golang.org/x/sys/unix"
+)
+
+// setup binds wl_shm and creates a wl_surface.
+func setup(t *testing.T) (*waylandtest.Server, *client.Shm, *client.Surface) {
+	s := waylandtest.NewServer(t)
+	s.AddGlobal("wl_compositor", 4)
+	s.AddGlobal("wl_shm", 1)
+
+	registry, err := s.Display().GetRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		compositor *client.Compositor
+		wlShm      *client.Shm
+	)
+	registry.SetGlobalHandler(func(e client.RegistryGlobalEvent) {
+		switch e.Interface {
+		case "wl_compositor":
+			compositor, _ = client.Bind[client.Compositor](registry, e.Name, e.Version)
+		case "wl_shm":
+			wlShm, _ = client.Bind[client.Shm](registry, e.Name, e.Version)
+		}
+	})
+	s.Roundtrip()
+	if compositor == nil || wlShm == nil {
+		t.Fatal("globals were not bound")
+	}
+	surface, err := compositor.CreateSurface()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Expect("wl_display", "get_registry")
+	s.Expect("wl_registry", "bind")
+	s.Expect("wl_registry", "bind")
+	s.Expect("wl_compositor", "create_surface", surface)
+	return s, wlShm, surface
+}
+
+func newPool(t *testing.T, s *waylandtest.Server, wlShm *client.Shm, size int) *shm.Pool {
+	pool, err := shm.NewPool(wlShm, size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Roundtrip()
+	r := s.Expect("wl_shm", "create_pool", waylandtest.Any, waylandtest.Any, size)
+	unix.Close(r.Args[1].(int))
+	return pool
+}
+
+func TestPool(t *testing.T) {
+	s, wlShm, _ := setup(t)
+	pool := newPool(t, s, wlShm, 64)
+
+	a, err := pool.Alloc(4, 2, 16, client.ShmFormatArgb8888)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := pool.Alloc(4, 2, 16, client.ShmFormatArgb8888)
+	if err != nil {
+		t.Fatal(err)
+	}
+	copy(b.Data(), "pixels")
+
+	// A third buffer doesn't fit, the pool doubles
+	c, err := pool.Alloc(4, 2, 16, client.ShmFormatArgb8888)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pool.Size() != 128 {
+		t.Errorf("got size %d after growing, want 128", pool.Size())
+	}
+	if got := string(b.Data()[:6]); got != "pixels" {
+		t.Errorf("got %q after growing, want the pixels written before", got)
+	}
+
+	// The memory of a destroyed buffer is reused
+	if err := a.Destroy(); err != nil {
+		t.Fatal(err)
+	}
+	d, err := pool.Alloc(2, 4, 8, client.ShmFormatXrgb8888)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.WlBuffer(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.WlBuffer(); err != nil {
+		t.Fatal(err)
+	}
+	s.Roundtrip()
+	s.Expect("wl_shm_pool", "resize", 128)
+	s.Expect("wl_shm_pool", "create_buffer", waylandtest.Any, 64, 4, 2, 16, client.ShmFormatArgb8888)
+	s.Expect("wl_shm_pool", "create_buffer", waylandtest.Any, 0, 2, 4, 8, client.ShmFormatXrgb8888)
+
+	if err := pool.Destroy(); err != nil {
+		t.Fatal(err)
+	}
+	s.Roundtrip()
+	s.Expect("wl_shm_pool", "destroy")
+}
+
+func TestAllocStride(t *testing.T) {
+	s, wlShm, _ := setup(t)
+	pool := newPool(t, s, wlShm, 64)
+
+	for _, tt := range []struct {
+		width, stride int32
+		format        client.ShmFormat
+		ok            bool
+	}{
+		{4, 16, client.ShmFormatArgb8888, true},
+		{4, 20, client.ShmFormatArgb8888, true},
+		{4, 15, client.ShmFormatArgb8888, false},
+		{4, 4, client.ShmFormatXrgb8888, false},
+		{4, 8, client.ShmFormatRgb565, true},
+		{4, 11, client.ShmFormatBgr888, false},
+		{4, 4, client.ShmFormatC8, true},
+	} {
+		buf, err := pool.Alloc(tt.width, 1, tt.stride, tt.format)
+		if (err == nil) != tt.ok {
+			t.Errorf("Alloc(%d, 1, %d, %v): got error %v, want ok %v", tt.width, tt.stride, tt.format, err, tt.ok)
+		}
+		if buf != nil {
+			buf.Destroy()
+		}
+	}
+}
+
+func TestSlots(t *testing.T) {
+	s, wlShm, surface := setup(t)
+	pool := newPool(t, s, wlShm, 2*4*4*4)
+	slots := shm.NewSlots(pool, 2)
+
+	next := func(width int32) *shm.Buffer {
+		t.Helper()
+		buf, err := slots.Next(width, 4, width*4, client.ShmFormatArgb8888)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := buf.Attach(surface, 0, 0); err != nil {
+			t.Fatal(err)
+		}
+		return buf
+	}
+
+	a := next(4)
+	b := next(4)
+	if a == b {
+		t.Fatal("got the attached buffer again")
+	}
+	if _, err := slots.Next(4, 4, 16, client.ShmFormatArgb8888); !errors.Is(err, shm.ErrBusy) {
+		t.Fatalf("got error %v with both buffers attached, want ErrBusy", err)
+	}
+	s.Roundtrip()
+	wlA := s.Expect("wl_shm_pool", "create_buffer", waylandtest.Any, 0, 4, 4, 16, client.ShmFormatArgb8888).Args[0]
+	s.Expect("wl_surface", "attach", wlA, 0, 0)
+	wlB := s.Expect("wl_shm_pool", "create_buffer", waylandtest.Any, 64, 4, 4, 16, client.ShmFormatArgb8888).Args[0]
+	s.Expect("wl_surface", "attach", wlB, 0, 0)
+
+	// A released buffer is reused
+	s.Send(wlA, "release")
+	s.Roundtrip()
+	if a.Busy() {
+		t.Fatal("released buffer is busy")
+	}
+	if got := next(4); got != a {
+		t.Fatal("released buffer wasn't reused")
+	}
+	s.Roundtrip()
+	s.Expect("wl_surface", "attach", wlA, 0, 0)
+
+	// On resize, the released buffers are destroyed right away and the
+	// busy ones on release
+	s.Send(wlB, "release")
+	s.Roundtrip()
+	c := next(2)
+	s.Roundtrip()
+	if r := s.Expect("wl_buffer", "destroy"); r.Object != wlB {
+		t.Errorf("got %v, want the destruction of %v", r, wlB)
+	}
+	wlC := s.Expect("wl_shm_pool", "create_buffer", waylandtest.Any, 64, 2, 4, 8, client.ShmFormatArgb8888).Args[0]
+	s.Expect("wl_surface", "attach", wlC, 0, 0)
+	if !a.Busy() || !c.Busy() {
+		t.Fatal("attached buffers aren't busy")
+	}
+	s.Send(wlA, "release")
+	s.Roundtrip()
+	if r := s.Expect("wl_buffer", "destroy"); r.Object != wlA {
+		t.Errorf("got %v, want the destruction of %v", r, wlA)
+	}
+
+	if err := slots.Destroy(); err != nil {
+		t.Fatal(err)
+	}
+	if err := pool.Destroy(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,74 @@
+package shm
+
+import (
+	"errors"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+)
+
+// ErrBusy is returned by Slots.Next when the compositor still reads all
+// the buffers.
+var ErrBusy = errors.New("shm: all the buffers are busy")
+
+// Slots is a set of buffers of the same size which are drawn in turn,
+// two for double buffering, three for triple buffering.
+type Slots struct {
+	pool    *Pool
+	count   int
+	buffers []*Buffer
+}
+
+// NewSlots returns slots of up to count buffers allocated in pool.
+func NewSlots(pool *Pool, count int) *Slots {
+	return &Slots{pool: pool, count: count}
+}
+
+// Next returns a buffer which isn't busy, reusing a released one or
+// allocating a new one. When the size or format changes, the buffers of
+// the previous one are destroyed, once released for the busy ones. It
+// returns ErrBusy when all the slots are busy.
+func (s *Slots) Next(width, height, stride int32, format client.ShmFormat) (*Buffer, error) {
+	var err error
+	buffers := s.buffers[:0]
+	for _, b := range s.buffers {
+		if b.Width != width || b.Height != height || b.Stride != stride || b.Format != format {
+			if err2 := b.Destroy(); err == nil {
+				err = err2
+			}
+			continue
+		}
+		buffers = append(buffers, b)
+	}
+	s.buffers = buffers
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range s.buffers {
+		if !b.Busy() {
+			return b, nil
+		}
+	}
+	if len(s.buffers) == s.count {
+		return nil, ErrBusy
+	}
+
+	b, err := s.pool.Alloc(width, height, stride, format)
+	if err != nil {
+		return nil, err
+	}
+	s.buffers = append(s.buffers, b)
+	return b, nil
+}
+
+// Destroy destroys the buffers, once released for the busy ones.
+func (s *Slots) Destroy() error {
+	var err error
+	for _, b := range s.buffers {
+		if err2 := b.Destroy(); err == nil {
+			err = err2
+		}
+	}
+	s.buffers = nil
+	return err
+}
@@ -1,3 +1 @@
 package presentation_time
-
-//go:generate go run github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner -pkg presentation_time -prefix wp -o presentation_time.go -i https://raw.githubusercontent.com/wayland-project/wayland-protocols/1.31/stable/presentation-time/presentation-time.xml
@@ -27,7 +27,13 @@
 
 package presentation_time
 
-import "github.com/rajveermalviya/go-wayland/wayland/client"
+import (
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+)
 
 // Presentation : timed presentation related wl_surface requests
 //
@@ -78,6 +84,48 @@ func NewPresentation(ctx *client.Context) *Presentation {
 	return wpPresentation
 }
 
+// PresentationInterface describes wp_presentation at runtime.
+var PresentationInterface = &client.Interface{
+	Name:    "wp_presentation",
+	Version: 1,
+	New:     func() client.Proxy { return &Presentation{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "feedback",
+			Args: []client.Arg{
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+				{Name: "callback", Type: "new_id", Interface: "wp_presentation_feedback"},
+			},
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "clock_id",
+			Args: []client.Arg{
+				{Name: "clk_id", Type: "uint"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "invalid_timestamp", Value: 0},
+				{Name: "invalid_flag", Value: 1},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wp_presentation.
+func (i *Presentation) Interface() *client.Interface {
+	return PresentationInterface
+}
+
 // Destroy : unbind from the presentation interface
 //
 // Informs the server that the client will no longer be using
@@ -111,6 +159,7 @@ func (i *Presentation) Destroy() error {
 //	surface: target surface
 func (i *Presentation) Feedback(surface *client.Surface) (*PresentationFeedback, error) {
 	callback := NewPresentationFeedback(i.Context())
+	callback.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -207,6 +256,43 @@ func (i *Presentation) SetClockIdHandler(f PresentationClockIdHandlerFunc) {
 	i.clockIdHandler = f
 }
 
+// String formats the event as wp_presentation.clock_id(argument=value, ...).
+func (e PresentationClockIdEvent) String() string {
+	return "wp_presentation.clock_id(" +
+		"clk_id=" + strconv.FormatUint(uint64(e.ClkId), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PresentationClockIdEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("clk_id", uint64(e.ClkId)),
+	)
+}
+
+// PresentationListener receives all the events of Presentation, embed
+// PresentationNoopListener to implement only some of them.
+type PresentationListener interface {
+	ClockId(e PresentationClockIdEvent)
+}
+
+// PresentationNoopListener is a PresentationListener ignoring
+// all the events, it closes the file descriptors they carry.
+type PresentationNoopListener struct{}
+
+func (PresentationNoopListener) ClockId(PresentationClockIdEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *Presentation) SetListener(l PresentationListener) {
+	if l == nil {
+		i.clockIdHandler = nil
+		return
+	}
+	i.clockIdHandler = l.ClockId
+}
+
 func (i *Presentation) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -261,6 +347,55 @@ func NewPresentationFeedback(ctx *client.Context) *PresentationFeedback {
 	return wpPresentationFeedback
 }
 
+// PresentationFeedbackInterface describes wp_presentation_feedback at runtime.
+var PresentationFeedbackInterface = &client.Interface{
+	Name:    "wp_presentation_feedback",
+	Version: 1,
+	New:     func() client.Proxy { return &PresentationFeedback{} },
+	Events: []client.Message{
+		{
+			Name: "sync_output",
+			Args: []client.Arg{
+				{Name: "output", Type: "object", Interface: "wl_output"},
+			},
+		},
+		{
+			Name:       "presented",
+			Destructor: true,
+			Args: []client.Arg{
+				{Name: "tv_sec_hi", Type: "uint"},
+				{Name: "tv_sec_lo", Type: "uint"},
+				{Name: "tv_nsec", Type: "uint"},
+				{Name: "refresh", Type: "uint"},
+				{Name: "seq_hi", Type: "uint"},
+				{Name: "seq_lo", Type: "uint"},
+				{Name: "flags", Type: "uint", Enum: "wp_presentation_feedback.kind"},
+			},
+		},
+		{
+			Name:       "discarded",
+			Destructor: true,
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name:     "kind",
+			Bitfield: true,
+			Entries: []client.EnumEntry{
+				{Name: "vsync", Value: 0x1},
+				{Name: "hw_clock", Value: 0x2},
+				{Name: "hw_completion", Value: 0x4},
+				{Name: "zero_copy", Value: 0x8},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wp_presentation_feedback.
+func (i *PresentationFeedback) Interface() *client.Interface {
+	return PresentationFeedbackInterface
+}
+
 func (i *PresentationFeedback) Destroy() error {
 	i.Context().Unregister(i)
 	return nil
@@ -311,8 +446,29 @@ func (e PresentationFeedbackKind) Value() string {
 	}
 }
 
+func (e PresentationFeedbackKind) Has(f PresentationFeedbackKind) bool {
+	return e&f == f
+}
+
+func (e PresentationFeedbackKind) Set(f PresentationFeedbackKind) PresentationFeedbackKind {
+	return e | f
+}
+
 func (e PresentationFeedbackKind) String() string {
-	return e.Name() + "=" + e.Value()
+	if e == 0 {
+		return "0"
+	}
+	var names []string
+	for _, f := range []PresentationFeedbackKind{PresentationFeedbackKindVsync, PresentationFeedbackKindHwClock, PresentationFeedbackKindHwCompletion, PresentationFeedbackKindZeroCopy} {
+		if e.Has(f) {
+			names = append(names, f.Name())
+			e &^= f
+		}
+	}
+	if e != 0 {
+		names = append(names, "0x"+strconv.FormatUint(uint64(e), 16))
+	}
+	return strings.Join(names, "|")
 }
 
 // PresentationFeedbackSyncOutputEvent : presentation synchronized to this output
@@ -335,6 +491,20 @@ func (i *PresentationFeedback) SetSyncOutputHandler(f PresentationFeedbackSyncOu
 	i.syncOutputHandler = f
 }
 
+// String formats the event as wp_presentation_feedback.sync_output(argument=value, ...).
+func (e PresentationFeedbackSyncOutputEvent) String() string {
+	return "wp_presentation_feedback.sync_output(" +
+		"output=" + client.ObjectString(e.Output) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PresentationFeedbackSyncOutputEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("output", client.ObjectString(e.Output)),
+	)
+}
+
 // PresentationFeedbackPresentedEvent : the content update was displayed
 //
 // The associated content update was displayed to the user at the
@@ -385,7 +555,7 @@ type PresentationFeedbackPresentedEvent struct {
 	Refresh uint32
 	SeqHi   uint32
 	SeqLo   uint32
-	Flags   uint32
+	Flags   PresentationFeedbackKind
 }
 type PresentationFeedbackPresentedHandlerFunc func(PresentationFeedbackPresentedEvent)
 
@@ -394,6 +564,32 @@ func (i *PresentationFeedback) SetPresentedHandler(f PresentationFeedbackPresent
 	i.presentedHandler = f
 }
 
+// String formats the event as wp_presentation_feedback.presented(argument=value, ...).
+func (e PresentationFeedbackPresentedEvent) String() string {
+	return "wp_presentation_feedback.presented(" +
+		"tv_sec_hi=" + strconv.FormatUint(uint64(e.TvSecHi), 10) +
+		", tv_sec_lo=" + strconv.FormatUint(uint64(e.TvSecLo), 10) +
+		", tv_nsec=" + strconv.FormatUint(uint64(e.TvNsec), 10) +
+		", refresh=" + strconv.FormatUint(uint64(e.Refresh), 10) +
+		", seq_hi=" + strconv.FormatUint(uint64(e.SeqHi), 10) +
+		", seq_lo=" + strconv.FormatUint(uint64(e.SeqLo), 10) +
+		", flags=" + e.Flags.String() +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PresentationFeedbackPresentedEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("tv_sec_hi", uint64(e.TvSecHi)),
+		slog.Uint64("tv_sec_lo", uint64(e.TvSecLo)),
+		slog.Uint64("tv_nsec", uint64(e.TvNsec)),
+		slog.Uint64("refresh", uint64(e.Refresh)),
+		slog.Uint64("seq_hi", uint64(e.SeqHi)),
+		slog.Uint64("seq_lo", uint64(e.SeqLo)),
+		slog.String("flags", e.Flags.String()),
+	)
+}
+
 // PresentationFeedbackDiscardedEvent : the content update was not displayed
 //
 // The content update was never displayed to the user.
@@ -405,6 +601,51 @@ func (i *PresentationFeedback) SetDiscardedHandler(f PresentationFeedbackDiscard
 	i.discardedHandler = f
 }
 
+// String formats the event as wp_presentation_feedback.discarded(argument=value, ...).
+func (e PresentationFeedbackDiscardedEvent) String() string {
+	return "wp_presentation_feedback.discarded()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PresentationFeedbackDiscardedEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
+// PresentationFeedbackListener receives all the events of PresentationFeedback, embed
+// PresentationFeedbackNoopListener to implement only some of them.
+type PresentationFeedbackListener interface {
+	SyncOutput(e PresentationFeedbackSyncOutputEvent)
+	Presented(e PresentationFeedbackPresentedEvent)
+	Discarded(e PresentationFeedbackDiscardedEvent)
+}
+
+// PresentationFeedbackNoopListener is a PresentationFeedbackListener ignoring
+// all the events, it closes the file descriptors they carry.
+type PresentationFeedbackNoopListener struct{}
+
+func (PresentationFeedbackNoopListener) SyncOutput(PresentationFeedbackSyncOutputEvent) {
+}
+
+func (PresentationFeedbackNoopListener) Presented(PresentationFeedbackPresentedEvent) {
+}
+
+func (PresentationFeedbackNoopListener) Discarded(PresentationFeedbackDiscardedEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *PresentationFeedback) SetListener(l PresentationFeedbackListener) {
+	if l == nil {
+		i.syncOutputHandler = nil
+		i.presentedHandler = nil
+		i.discardedHandler = nil
+		return
+	}
+	i.syncOutputHandler = l.SyncOutput
+	i.presentedHandler = l.Presented
+	i.discardedHandler = l.Discarded
+}
+
 func (i *PresentationFeedback) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -413,11 +654,12 @@ func (i *PresentationFeedback) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e PresentationFeedbackSyncOutputEvent
 		l := 0
-		e.Output = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*client.Output)
+		e.Output, _ = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*client.Output)
 		l += 4
 
 		i.syncOutputHandler(e)
 	case 1:
+		defer i.Context().Unregister(i)
 		if i.presentedHandler == nil {
 			return
 		}
@@ -435,11 +677,12 @@ func (i *PresentationFeedback) Dispatch(opcode uint32, fd int, data []byte) {
 		l += 4
 		e.SeqLo = client.Uint32(data[l : l+4])
 		l += 4
-		e.Flags = client.Uint32(data[l : l+4])
+		e.Flags = PresentationFeedbackKind(client.Uint32(data[l : l+4]))
 		l += 4
 
 		i.presentedHandler(e)
 	case 2:
+		defer i.Context().Unregister(i)
 		if i.discardedHandler == nil {
 			return
 		}
@@ -448,3 +691,8 @@ func (i *PresentationFeedback) Dispatch(opcode uint32, fd int, data []byte) {
 		i.discardedHandler(e)
 	}
 }
+
+func init() {
+	client.RegisterInterface(PresentationInterface)
+	client.RegisterInterface(PresentationFeedbackInterface)
+}
@@ -1,3 +1 @@
 package viewporter
-
-//go:generate go run github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner -pkg viewporter -prefix wp -o viewporter.go -i https://raw.githubusercontent.com/wayland-project/wayland-protocols/1.31/stable/viewporter/viewporter.xml
@@ -55,6 +55,39 @@ func NewViewporter(ctx *client.Context) *Viewporter {
 	return wpViewporter
 }
 
+// ViewporterInterface describes wp_viewporter at runtime.
+var ViewporterInterface = &client.Interface{
+	Name:    "wp_viewporter",
+	Version: 1,
+	New:     func() client.Proxy { return &Viewporter{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "get_viewport",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "wp_viewport"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "viewport_exists", Value: 0},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wp_viewporter.
+func (i *Viewporter) Interface() *client.Interface {
+	return ViewporterInterface
+}
+
 // Destroy : unbind from the cropping and scaling interface
 //
 // Informs the server that the client will not be using this
@@ -84,6 +117,7 @@ func (i *Viewporter) Destroy() error {
 //	surface: the surface
 func (i *Viewporter) GetViewport(surface *client.Surface) (*Viewport, error) {
 	id := NewViewport(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -254,6 +288,51 @@ func NewViewport(ctx *client.Context) *Viewport {
 	return wpViewport
 }
 
+// ViewportInterface describes wp_viewport at runtime.
+var ViewportInterface = &client.Interface{
+	Name:    "wp_viewport",
+	Version: 1,
+	New:     func() client.Proxy { return &Viewport{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "set_source",
+			Args: []client.Arg{
+				{Name: "x", Type: "fixed"},
+				{Name: "y", Type: "fixed"},
+				{Name: "width", Type: "fixed"},
+				{Name: "height", Type: "fixed"},
+			},
+		},
+		{
+			Name: "set_destination",
+			Args: []client.Arg{
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "bad_value", Value: 0},
+				{Name: "bad_size", Value: 1},
+				{Name: "out_of_buffer", Value: 2},
+				{Name: "no_surface", Value: 3},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wp_viewport.
+func (i *Viewport) Interface() *client.Interface {
+	return ViewportInterface
+}
+
 // Destroy : remove scaling and cropping from the surface
 //
 // The associated wl_surface's crop and scale state is removed.
@@ -391,3 +470,8 @@ func (e ViewportError) Value() string {
 func (e ViewportError) String() string {
 	return e.Name() + "=" + e.Value()
 }
+
+func init() {
+	client.RegisterInterface(ViewporterInterface)
+	client.RegisterInterface(ViewportInterface)
+}
@@ -1,3 +1 @@
 package xdg_shell
-
-//go:generate go run github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner -pkg xdg_shell -prefix xdg  -o xdg_shell.go -i https://raw.githubusercontent.com/wayland-project/wayland-protocols/1.31/stable/xdg-shell/xdg-shell.xml
@@ -32,7 +32,14 @@
 
 package xdg_shell
 
-import "github.com/rajveermalviya/go-wayland/wayland/client"
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+)
 
 // WmBase : create desktop-style surfaces
 //
@@ -59,6 +66,65 @@ func NewWmBase(ctx *client.Context) *WmBase {
 	return xdgWmBase
 }
 
+// WmBaseInterface describes xdg_wm_base at runtime.
+var WmBaseInterface = &client.Interface{
+	Name:    "xdg_wm_base",
+	Version: 5,
+	New:     func() client.Proxy { return &WmBase{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "create_positioner",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "xdg_positioner"},
+			},
+		},
+		{
+			Name: "get_xdg_surface",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "xdg_surface"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+			},
+		},
+		{
+			Name: "pong",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+			},
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "ping",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "role", Value: 0},
+				{Name: "defunct_surfaces", Value: 1},
+				{Name: "not_the_topmost_popup", Value: 2},
+				{Name: "invalid_popup_parent", Value: 3},
+				{Name: "invalid_surface_state", Value: 4},
+				{Name: "invalid_positioner", Value: 5},
+				{Name: "unresponsive", Value: 6},
+			},
+		},
+	},
+}
+
+// Interface returns the description of xdg_wm_base.
+func (i *WmBase) Interface() *client.Interface {
+	return WmBaseInterface
+}
+
 // Destroy : destroy xdg_wm_base
 //
 // Destroy this xdg_wm_base object.
@@ -87,6 +153,7 @@ func (i *WmBase) Destroy() error {
 // and xdg_surface.get_popup for details.
 func (i *WmBase) CreatePositioner() (*Positioner, error) {
 	id := NewPositioner(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -118,6 +185,7 @@ func (i *WmBase) CreatePositioner() (*Positioner, error) {
 // xdg_surface is and how it is used.
 func (i *WmBase) GetXdgSurface(surface *client.Surface) (*Surface, error) {
 	id := NewSurface(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 2
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -247,6 +315,43 @@ func (i *WmBase) SetPingHandler(f WmBasePingHandlerFunc) {
 	i.pingHandler = f
 }
 
+// String formats the event as xdg_wm_base.ping(argument=value, ...).
+func (e WmBasePingEvent) String() string {
+	return "xdg_wm_base.ping(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e WmBasePingEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+	)
+}
+
+// WmBaseListener receives all the events of WmBase, embed
+// WmBaseNoopListener to implement only some of them.
+type WmBaseListener interface {
+	Ping(e WmBasePingEvent)
+}
+
+// WmBaseNoopListener is a WmBaseListener ignoring
+// all the events, it closes the file descriptors they carry.
+type WmBaseNoopListener struct{}
+
+func (WmBaseNoopListener) Ping(WmBasePingEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *WmBase) SetListener(l WmBaseListener) {
+	if l == nil {
+		i.pingHandler = nil
+		return
+	}
+	i.pingHandler = l.Ping
+}
+
 func (i *WmBase) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -314,6 +419,133 @@ func NewPositioner(ctx *client.Context) *Positioner {
 	return xdgPositioner
 }
 
+// PositionerInterface describes xdg_positioner at runtime.
+var PositionerInterface = &client.Interface{
+	Name:    "xdg_positioner",
+	Version: 5,
+	New:     func() client.Proxy { return &Positioner{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "set_size",
+			Args: []client.Arg{
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+			},
+		},
+		{
+			Name: "set_anchor_rect",
+			Args: []client.Arg{
+				{Name: "x", Type: "int"},
+				{Name: "y", Type: "int"},
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+			},
+		},
+		{
+			Name: "set_anchor",
+			Args: []client.Arg{
+				{Name: "anchor", Type: "uint", Enum: "xdg_positioner.anchor"},
+			},
+		},
+		{
+			Name: "set_gravity",
+			Args: []client.Arg{
+				{Name: "gravity", Type: "uint", Enum: "xdg_positioner.gravity"},
+			},
+		},
+		{
+			Name: "set_constraint_adjustment",
+			Args: []client.Arg{
+				{Name: "constraint_adjustment", Type: "uint", Enum: "xdg_positioner.constraint_adjustment"},
+			},
+		},
+		{
+			Name: "set_offset",
+			Args: []client.Arg{
+				{Name: "x", Type: "int"},
+				{Name: "y", Type: "int"},
+			},
+		},
+		{
+			Name:  "set_reactive",
+			Since: 3,
+		},
+		{
+			Name:  "set_parent_size",
+			Since: 3,
+			Args: []client.Arg{
+				{Name: "parent_width", Type: "int"},
+				{Name: "parent_height", Type: "int"},
+			},
+		},
+		{
+			Name:  "set_parent_configure",
+			Since: 3,
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "invalid_input", Value: 0},
+			},
+		},
+		{
+			Name: "anchor",
+			Entries: []client.EnumEntry{
+				{Name: "none", Value: 0},
+				{Name: "top", Value: 1},
+				{Name: "bottom", Value: 2},
+				{Name: "left", Value: 3},
+				{Name: "right", Value: 4},
+				{Name: "top_left", Value: 5},
+				{Name: "bottom_left", Value: 6},
+				{Name: "top_right", Value: 7},
+				{Name: "bottom_right", Value: 8},
+			},
+		},
+		{
+			Name: "gravity",
+			Entries: []client.EnumEntry{
+				{Name: "none", Value: 0},
+				{Name: "top", Value: 1},
+				{Name: "bottom", Value: 2},
+				{Name: "left", Value: 3},
+				{Name: "right", Value: 4},
+				{Name: "top_left", Value: 5},
+				{Name: "bottom_left", Value: 6},
+				{Name: "top_right", Value: 7},
+				{Name: "bottom_right", Value: 8},
+			},
+		},
+		{
+			Name:     "constraint_adjustment",
+			Bitfield: true,
+			Entries: []client.EnumEntry{
+				{Name: "none", Value: 0},
+				{Name: "slide_x", Value: 1},
+				{Name: "slide_y", Value: 2},
+				{Name: "flip_x", Value: 4},
+				{Name: "flip_y", Value: 8},
+				{Name: "resize_x", Value: 16},
+				{Name: "resize_y", Value: 32},
+			},
+		},
+	},
+}
+
+// Interface returns the description of xdg_positioner.
+func (i *Positioner) Interface() *client.Interface {
+	return PositionerInterface
+}
+
 // Destroy : destroy the xdg_positioner object
 //
 // Notify the compositor that the xdg_positioner will no longer be used.
@@ -406,7 +638,7 @@ func (i *Positioner) SetAnchorRect(x, y, width, height int32) error {
 // edge, or in the center of the anchor rectangle if no edge is specified.
 //
 //	anchor: anchor
-func (i *Positioner) SetAnchor(anchor uint32) error {
+func (i *Positioner) SetAnchor(anchor PositionerAnchor) error {
 	const opcode = 3
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -432,7 +664,7 @@ func (i *Positioner) SetAnchor(anchor uint32) error {
 // invalid_input error is raised.
 //
 //	gravity: gravity direction
-func (i *Positioner) SetGravity(gravity uint32) error {
+func (i *Positioner) SetGravity(gravity PositionerGravity) error {
 	const opcode = 4
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -464,7 +696,7 @@ func (i *Positioner) SetGravity(gravity uint32) error {
 // The default adjustment is none.
 //
 //	constraintAdjustment: bit mask of constraint adjustments
-func (i *Positioner) SetConstraintAdjustment(constraintAdjustment uint32) error {
+func (i *Positioner) SetConstraintAdjustment(constraintAdjustment PositionerConstraintAdjustment) error {
 	const opcode = 5
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -521,6 +753,9 @@ func (i *Positioner) SetOffset(x, y int32) error {
 // xdg_popup.configure event is sent with updated geometry, followed by an
 // xdg_surface.configure event.
 func (i *Positioner) SetReactive() error {
+	if v := i.Version(); v != 0 && v < 3 {
+		return &client.VersionError{Interface: "xdg_positioner", Request: "set_reactive", Since: 3, Version: v}
+	}
 	const opcode = 7
 	const _reqBufLen = 8
 	var _reqBuf [_reqBufLen]byte
@@ -546,6 +781,9 @@ func (i *Positioner) SetReactive() error {
 //	parentWidth: future window geometry width of parent
 //	parentHeight: future window geometry height of parent
 func (i *Positioner) SetParentSize(parentWidth, parentHeight int32) error {
+	if v := i.Version(); v != 0 && v < 3 {
+		return &client.VersionError{Interface: "xdg_positioner", Request: "set_parent_size", Since: 3, Version: v}
+	}
 	const opcode = 8
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -571,6 +809,9 @@ func (i *Positioner) SetParentSize(parentWidth, parentHeight int32) error {
 //
 //	serial: serial of parent configure event
 func (i *Positioner) SetParentConfigure(serial uint32) error {
+	if v := i.Version(); v != 0 && v < 3 {
+		return &client.VersionError{Interface: "xdg_positioner", Request: "set_parent_configure", Since: 3, Version: v}
+	}
 	const opcode = 9
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -820,8 +1061,29 @@ func (e PositionerConstraintAdjustment) Value() string {
 	}
 }
 
+func (e PositionerConstraintAdjustment) Has(f PositionerConstraintAdjustment) bool {
+	return e&f == f
+}
+
+func (e PositionerConstraintAdjustment) Set(f PositionerConstraintAdjustment) PositionerConstraintAdjustment {
+	return e | f
+}
+
 func (e PositionerConstraintAdjustment) String() string {
-	return e.Name() + "=" + e.Value()
+	if e == 0 {
+		return "none"
+	}
+	var names []string
+	for _, f := range []PositionerConstraintAdjustment{PositionerConstraintAdjustmentSlideX, PositionerConstraintAdjustmentSlideY, PositionerConstraintAdjustmentFlipX, PositionerConstraintAdjustmentFlipY, PositionerConstraintAdjustmentResizeX, PositionerConstraintAdjustmentResizeY} {
+		if e.Has(f) {
+			names = append(names, f.Name())
+			e &^= f
+		}
+	}
+	if e != 0 {
+		names = append(names, "0x"+strconv.FormatUint(uint64(e), 16))
+	}
+	return strings.Join(names, "|")
 }
 
 // Surface : desktop user interface surface base interface
@@ -929,6 +1191,74 @@ func NewSurface(ctx *client.Context) *Surface {
 	return xdgSurface
 }
 
+// SurfaceInterface describes xdg_surface at runtime.
+var SurfaceInterface = &client.Interface{
+	Name:    "xdg_surface",
+	Version: 5,
+	New:     func() client.Proxy { return &Surface{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "get_toplevel",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "xdg_toplevel"},
+			},
+		},
+		{
+			Name: "get_popup",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "xdg_popup"},
+				{Name: "parent", Type: "object", Interface: "xdg_surface", Nullable: true},
+				{Name: "positioner", Type: "object", Interface: "xdg_positioner"},
+			},
+		},
+		{
+			Name: "set_window_geometry",
+			Args: []client.Arg{
+				{Name: "x", Type: "int"},
+				{Name: "y", Type: "int"},
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+			},
+		},
+		{
+			Name: "ack_configure",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+			},
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "configure",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "not_constructed", Value: 1},
+				{Name: "already_constructed", Value: 2},
+				{Name: "unconfigured_buffer", Value: 3},
+				{Name: "invalid_serial", Value: 4},
+				{Name: "invalid_size", Value: 5},
+				{Name: "defunct_role_object", Value: 6},
+			},
+		},
+	},
+}
+
+// Interface returns the description of xdg_surface.
+func (i *Surface) Interface() *client.Interface {
+	return SurfaceInterface
+}
+
 // Destroy : destroy the xdg_surface
 //
 // Destroy the xdg_surface object. An xdg_surface must only be destroyed
@@ -957,6 +1287,7 @@ func (i *Surface) Destroy() error {
 // xdg_toplevel is and how it is used.
 func (i *Surface) GetToplevel() (*Toplevel, error) {
 	id := NewToplevel(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -983,6 +1314,7 @@ func (i *Surface) GetToplevel() (*Toplevel, error) {
 // xdg_popup is and how it is used.
 func (i *Surface) GetPopup(parent *Surface, positioner *Positioner) (*Popup, error) {
 	id := NewPopup(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 2
 	const _reqBufLen = 8 + 4 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -1197,6 +1529,43 @@ func (i *Surface) SetConfigureHandler(f SurfaceConfigureHandlerFunc) {
 	i.configureHandler = f
 }
 
+// String formats the event as xdg_surface.configure(argument=value, ...).
+func (e SurfaceConfigureEvent) String() string {
+	return "xdg_surface.configure(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e SurfaceConfigureEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+	)
+}
+
+// SurfaceListener receives all the events of Surface, embed
+// SurfaceNoopListener to implement only some of them.
+type SurfaceListener interface {
+	Configure(e SurfaceConfigureEvent)
+}
+
+// SurfaceNoopListener is a SurfaceListener ignoring
+// all the events, it closes the file descriptors they carry.
+type SurfaceNoopListener struct{}
+
+func (SurfaceNoopListener) Configure(SurfaceConfigureEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *Surface) SetListener(l SurfaceListener) {
+	if l == nil {
+		i.configureHandler = nil
+		return
+	}
+	i.configureHandler = l.Configure
+}
+
 func (i *Surface) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -1264,6 +1633,173 @@ func NewToplevel(ctx *client.Context) *Toplevel {
 	return xdgToplevel
 }
 
+// ToplevelInterface describes xdg_toplevel at runtime.
+var ToplevelInterface = &client.Interface{
+	Name:    "xdg_toplevel",
+	Version: 5,
+	New:     func() client.Proxy { return &Toplevel{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "set_parent",
+			Args: []client.Arg{
+				{Name: "parent", Type: "object", Interface: "xdg_toplevel", Nullable: true},
+			},
+		},
+		{
+			Name: "set_title",
+			Args: []client.Arg{
+				{Name: "title", Type: "string"},
+			},
+		},
+		{
+			Name: "set_app_id",
+			Args: []client.Arg{
+				{Name: "app_id", Type: "string"},
+			},
+		},
+		{
+			Name: "show_window_menu",
+			Args: []client.Arg{
+				{Name: "seat", Type: "object", Interface: "wl_seat"},
+				{Name: "serial", Type: "uint"},
+				{Name: "x", Type: "int"},
+				{Name: "y", Type: "int"},
+			},
+		},
+		{
+			Name: "move",
+			Args: []client.Arg{
+				{Name: "seat", Type: "object", Interface: "wl_seat"},
+				{Name: "serial", Type: "uint"},
+			},
+		},
+		{
+			Name: "resize",
+			Args: []client.Arg{
+				{Name: "seat", Type: "object", Interface: "wl_seat"},
+				{Name: "serial", Type: "uint"},
+				{Name: "edges", Type: "uint", Enum: "xdg_toplevel.resize_edge"},
+			},
+		},
+		{
+			Name: "set_max_size",
+			Args: []client.Arg{
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+			},
+		},
+		{
+			Name: "set_min_size",
+			Args: []client.Arg{
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+			},
+		},
+		{
+			Name: "set_maximized",
+		},
+		{
+			Name: "unset_maximized",
+		},
+		{
+			Name: "set_fullscreen",
+			Args: []client.Arg{
+				{Name: "output", Type: "object", Interface: "wl_output", Nullable: true},
+			},
+		},
+		{
+			Name: "unset_fullscreen",
+		},
+		{
+			Name: "set_minimized",
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "configure",
+			Args: []client.Arg{
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+				{Name: "states", Type: "array"},
+			},
+		},
+		{
+			Name: "close",
+		},
+		{
+			Name:  "configure_bounds",
+			Since: 4,
+			Args: []client.Arg{
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+			},
+		},
+		{
+			Name:  "wm_capabilities",
+			Since: 5,
+			Args: []client.Arg{
+				{Name: "capabilities", Type: "array"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "invalid_resize_edge", Value: 0},
+				{Name: "invalid_parent", Value: 1},
+				{Name: "invalid_size", Value: 2},
+			},
+		},
+		{
+			Name: "resize_edge",
+			Entries: []client.EnumEntry{
+				{Name: "none", Value: 0},
+				{Name: "top", Value: 1},
+				{Name: "bottom", Value: 2},
+				{Name: "left", Value: 4},
+				{Name: "top_left", Value: 5},
+				{Name: "bottom_left", Value: 6},
+				{Name: "right", Value: 8},
+				{Name: "top_right", Value: 9},
+				{Name: "bottom_right", Value: 10},
+			},
+		},
+		{
+			Name: "state",
+			Entries: []client.EnumEntry{
+				{Name: "maximized", Value: 1},
+				{Name: "fullscreen", Value: 2},
+				{Name: "resizing", Value: 3},
+				{Name: "activated", Value: 4},
+				{Name: "tiled_left", Value: 5, Since: 2},
+				{Name: "tiled_right", Value: 6, Since: 2},
+				{Name: "tiled_top", Value: 7, Since: 2},
+				{Name: "tiled_bottom", Value: 8, Since: 2},
+			},
+		},
+		{
+			Name:  "wm_capabilities",
+			Since: 5,
+			Entries: []client.EnumEntry{
+				{Name: "window_menu", Value: 1},
+				{Name: "maximize", Value: 2},
+				{Name: "fullscreen", Value: 3},
+				{Name: "minimize", Value: 4},
+			},
+		},
+	},
+}
+
+// Interface returns the description of xdg_toplevel.
+func (i *Toplevel) Interface() *client.Interface {
+	return ToplevelInterface
+}
+
 // Destroy : destroy the xdg_toplevel
 //
 // This request destroys the role surface and unmaps the surface;
@@ -1343,7 +1879,7 @@ func (i *Toplevel) SetTitle(title string) error {
 	l += 4
 	client.PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
 	l += 4
-	client.PutString(_reqBuf[l:l+(4+titleLen)], title, titleLen)
+	client.PutString(_reqBuf[l:l+(4+titleLen)], title)
 	l += (4 + titleLen)
 	err := i.Context().WriteMsg(_reqBuf, nil)
 	return err
@@ -1384,7 +1920,7 @@ func (i *Toplevel) SetAppId(appId string) error {
 	l += 4
 	client.PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
 	l += 4
-	client.PutString(_reqBuf[l:l+(4+appIdLen)], appId, appIdLen)
+	client.PutString(_reqBuf[l:l+(4+appIdLen)], appId)
 	l += (4 + appIdLen)
 	err := i.Context().WriteMsg(_reqBuf, nil)
 	return err
@@ -1505,7 +2041,7 @@ func (i *Toplevel) Move(seat *client.Seat, serial uint32) error {
 //	seat: the wl_seat of the user event
 //	serial: the serial of the user event
 //	edges: which edge or corner is being dragged
-func (i *Toplevel) Resize(seat *client.Seat, serial, edges uint32) error {
+func (i *Toplevel) Resize(seat *client.Seat, serial uint32, edges ToplevelResizeEdge) error {
 	const opcode = 6
 	const _reqBufLen = 8 + 4 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -2062,7 +2598,7 @@ func (e ToplevelWmCapabilities) String() string {
 type ToplevelConfigureEvent struct {
 	Width  int32
 	Height int32
-	States []byte
+	States []ToplevelState
 }
 type ToplevelConfigureHandlerFunc func(ToplevelConfigureEvent)
 
@@ -2071,6 +2607,24 @@ func (i *Toplevel) SetConfigureHandler(f ToplevelConfigureHandlerFunc) {
 	i.configureHandler = f
 }
 
+// String formats the event as xdg_toplevel.configure(argument=value, ...).
+func (e ToplevelConfigureEvent) String() string {
+	return "xdg_toplevel.configure(" +
+		"width=" + strconv.FormatInt(int64(e.Width), 10) +
+		", height=" + strconv.FormatInt(int64(e.Height), 10) +
+		", states=" + fmt.Sprint(e.States) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e ToplevelConfigureEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int64("width", int64(e.Width)),
+		slog.Int64("height", int64(e.Height)),
+		slog.Any("states", e.States),
+	)
+}
+
 // ToplevelCloseEvent : surface wants to be closed
 //
 // The close event is sent by the compositor when the user
@@ -2089,6 +2643,16 @@ func (i *Toplevel) SetCloseHandler(f ToplevelCloseHandlerFunc) {
 	i.closeHandler = f
 }
 
+// String formats the event as xdg_toplevel.close(argument=value, ...).
+func (e ToplevelCloseEvent) String() string {
+	return "xdg_toplevel.close()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e ToplevelCloseEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // ToplevelConfigureBoundsEvent : recommended window geometry bounds
 //
 // The configure_bounds event may be sent prior to a xdg_toplevel.configure
@@ -2117,6 +2681,22 @@ func (i *Toplevel) SetConfigureBoundsHandler(f ToplevelConfigureBoundsHandlerFun
 	i.configureBoundsHandler = f
 }
 
+// String formats the event as xdg_toplevel.configure_bounds(argument=value, ...).
+func (e ToplevelConfigureBoundsEvent) String() string {
+	return "xdg_toplevel.configure_bounds(" +
+		"width=" + strconv.FormatInt(int64(e.Width), 10) +
+		", height=" + strconv.FormatInt(int64(e.Height), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e ToplevelConfigureBoundsEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int64("width", int64(e.Width)),
+		slog.Int64("height", int64(e.Height)),
+	)
+}
+
 // ToplevelWmCapabilitiesEvent : compositor capabilities
 //
 // This event advertises the capabilities supported by the compositor. If
@@ -2140,7 +2720,7 @@ func (i *Toplevel) SetConfigureBoundsHandler(f ToplevelConfigureBoundsHandlerFun
 // The capabilities are sent as an array of 32-bit unsigned integers in
 // native endianness.
 type ToplevelWmCapabilitiesEvent struct {
-	Capabilities []byte
+	Capabilities []ToplevelWmCapabilities
 }
 type ToplevelWmCapabilitiesHandlerFunc func(ToplevelWmCapabilitiesEvent)
 
@@ -2149,6 +2729,61 @@ func (i *Toplevel) SetWmCapabilitiesHandler(f ToplevelWmCapabilitiesHandlerFunc)
 	i.wmCapabilitiesHandler = f
 }
 
+// String formats the event as xdg_toplevel.wm_capabilities(argument=value, ...).
+func (e ToplevelWmCapabilitiesEvent) String() string {
+	return "xdg_toplevel.wm_capabilities(" +
+		"capabilities=" + fmt.Sprint(e.Capabilities) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e ToplevelWmCapabilitiesEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Any("capabilities", e.Capabilities),
+	)
+}
+
+// ToplevelListener receives all the events of Toplevel, embed
+// ToplevelNoopListener to implement only some of them.
+type ToplevelListener interface {
+	Configure(e ToplevelConfigureEvent)
+	Close(e ToplevelCloseEvent)
+	ConfigureBounds(e ToplevelConfigureBoundsEvent)
+	WmCapabilities(e ToplevelWmCapabilitiesEvent)
+}
+
+// ToplevelNoopListener is a ToplevelListener ignoring
+// all the events, it closes the file descriptors they carry.
+type ToplevelNoopListener struct{}
+
+func (ToplevelNoopListener) Configure(ToplevelConfigureEvent) {
+}
+
+func (ToplevelNoopListener) Close(ToplevelCloseEvent) {
+}
+
+func (ToplevelNoopListener) ConfigureBounds(ToplevelConfigureBoundsEvent) {
+}
+
+func (ToplevelNoopListener) WmCapabilities(ToplevelWmCapabilitiesEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *Toplevel) SetListener(l ToplevelListener) {
+	if l == nil {
+		i.configureHandler = nil
+		i.closeHandler = nil
+		i.configureBoundsHandler = nil
+		i.wmCapabilitiesHandler = nil
+		return
+	}
+	i.configureHandler = l.Configure
+	i.closeHandler = l.Close
+	i.configureBoundsHandler = l.ConfigureBounds
+	i.wmCapabilitiesHandler = l.WmCapabilities
+}
+
 func (i *Toplevel) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -2163,9 +2798,8 @@ func (i *Toplevel) Dispatch(opcode uint32, fd int, data []byte) {
 		l += 4
 		statesLen := int(client.Uint32(data[l : l+4]))
 		l += 4
-		e.States = make([]byte, statesLen)
-		copy(e.States, data[l:l+statesLen])
-		l += statesLen
+		e.States = client.Array[ToplevelState](data[l : l+statesLen])
+		l += client.PaddedLen(statesLen)
 
 		i.configureHandler(e)
 	case 1:
@@ -2195,9 +2829,8 @@ func (i *Toplevel) Dispatch(opcode uint32, fd int, data []byte) {
 		l := 0
 		capabilitiesLen := int(client.Uint32(data[l : l+4]))
 		l += 4
-		e.Capabilities = make([]byte, capabilitiesLen)
-		copy(e.Capabilities, data[l:l+capabilitiesLen])
-		l += capabilitiesLen
+		e.Capabilities = client.Array[ToplevelWmCapabilities](data[l : l+capabilitiesLen])
+		l += client.PaddedLen(capabilitiesLen)
 
 		i.wmCapabilitiesHandler(e)
 	}
@@ -2268,6 +2901,68 @@ func NewPopup(ctx *client.Context) *Popup {
 	return xdgPopup
 }
 
+// PopupInterface describes xdg_popup at runtime.
+var PopupInterface = &client.Interface{
+	Name:    "xdg_popup",
+	Version: 5,
+	New:     func() client.Proxy { return &Popup{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "grab",
+			Args: []client.Arg{
+				{Name: "seat", Type: "object", Interface: "wl_seat"},
+				{Name: "serial", Type: "uint"},
+			},
+		},
+		{
+			Name:  "reposition",
+			Since: 3,
+			Args: []client.Arg{
+				{Name: "positioner", Type: "object", Interface: "xdg_positioner"},
+				{Name: "token", Type: "uint"},
+			},
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "configure",
+			Args: []client.Arg{
+				{Name: "x", Type: "int"},
+				{Name: "y", Type: "int"},
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+			},
+		},
+		{
+			Name: "popup_done",
+		},
+		{
+			Name:  "repositioned",
+			Since: 3,
+			Args: []client.Arg{
+				{Name: "token", Type: "uint"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "invalid_grab", Value: 0},
+			},
+		},
+	},
+}
+
+// Interface returns the description of xdg_popup.
+func (i *Popup) Interface() *client.Interface {
+	return PopupInterface
+}
+
 // Destroy : remove xdg_popup interface
 //
 // This destroys the popup. Explicitly destroying the xdg_popup
@@ -2376,6 +3071,9 @@ func (i *Popup) Grab(seat *client.Seat, serial uint32) error {
 //
 //	token: reposition request token
 func (i *Popup) Reposition(positioner *Positioner, token uint32) error {
+	if v := i.Version(); v != 0 && v < 3 {
+		return &client.VersionError{Interface: "xdg_popup", Request: "reposition", Since: 3, Version: v}
+	}
 	const opcode = 2
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -2449,6 +3147,26 @@ func (i *Popup) SetConfigureHandler(f PopupConfigureHandlerFunc) {
 	i.configureHandler = f
 }
 
+// String formats the event as xdg_popup.configure(argument=value, ...).
+func (e PopupConfigureEvent) String() string {
+	return "xdg_popup.configure(" +
+		"x=" + strconv.FormatInt(int64(e.X), 10) +
+		", y=" + strconv.FormatInt(int64(e.Y), 10) +
+		", width=" + strconv.FormatInt(int64(e.Width), 10) +
+		", height=" + strconv.FormatInt(int64(e.Height), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PopupConfigureEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int64("x", int64(e.X)),
+		slog.Int64("y", int64(e.Y)),
+		slog.Int64("width", int64(e.Width)),
+		slog.Int64("height", int64(e.Height)),
+	)
+}
+
 // PopupPopupDoneEvent : popup interaction is done
 //
 // The popup_done event is sent out when a popup is dismissed by the
@@ -2462,6 +3180,16 @@ func (i *Popup) SetPopupDoneHandler(f PopupPopupDoneHandlerFunc) {
 	i.popupDoneHandler = f
 }
 
+// String formats the event as xdg_popup.popup_done(argument=value, ...).
+func (e PopupPopupDoneEvent) String() string {
+	return "xdg_popup.popup_done()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PopupPopupDoneEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // PopupRepositionedEvent : signal the completion of a repositioned request
 //
 // The repositioned event is sent as part of a popup configuration
@@ -2489,6 +3217,55 @@ func (i *Popup) SetRepositionedHandler(f PopupRepositionedHandlerFunc) {
 	i.repositionedHandler = f
 }
 
+// String formats the event as xdg_popup.repositioned(argument=value, ...).
+func (e PopupRepositionedEvent) String() string {
+	return "xdg_popup.repositioned(" +
+		"token=" + strconv.FormatUint(uint64(e.Token), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PopupRepositionedEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("token", uint64(e.Token)),
+	)
+}
+
+// PopupListener receives all the events of Popup, embed
+// PopupNoopListener to implement only some of them.
+type PopupListener interface {
+	Configure(e PopupConfigureEvent)
+	PopupDone(e PopupPopupDoneEvent)
+	Repositioned(e PopupRepositionedEvent)
+}
+
+// PopupNoopListener is a PopupListener ignoring
+// all the events, it closes the file descriptors they carry.
+type PopupNoopListener struct{}
+
+func (PopupNoopListener) Configure(PopupConfigureEvent) {
+}
+
+func (PopupNoopListener) PopupDone(PopupPopupDoneEvent) {
+}
+
+func (PopupNoopListener) Repositioned(PopupRepositionedEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *Popup) SetListener(l PopupListener) {
+	if l == nil {
+		i.configureHandler = nil
+		i.popupDoneHandler = nil
+		i.repositionedHandler = nil
+		return
+	}
+	i.configureHandler = l.Configure
+	i.popupDoneHandler = l.PopupDone
+	i.repositionedHandler = l.Repositioned
+}
+
 func (i *Popup) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -2526,3 +3303,11 @@ func (i *Popup) Dispatch(opcode uint32, fd int, data []byte) {
 		i.repositionedHandler(e)
 	}
 }
+
+func init() {
+	client.RegisterInterface(WmBaseInterface)
+	client.RegisterInterface(PositionerInterface)
+	client.RegisterInterface(SurfaceInterface)
+	client.RegisterInterface(ToplevelInterface)
+	client.RegisterInterface(PopupInterface)
+}
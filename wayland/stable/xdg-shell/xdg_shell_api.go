@@ -0,0 +1,776 @@
+// Generated by go-wayland-scanner
+// https://github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner
+// XML file : https://raw.githubusercontent.com/wayland-project/wayland-protocols/1.31/stable/xdg-shell/xdg-shell.xml
+//
+// xdg_shell Protocol Copyright:
+//
+// Copyright © 2008-2013 Kristian Høgsberg
+// Copyright © 2013      Rafael Antognolli
+// Copyright © 2013      Jasper St. Pierre
+// Copyright © 2010-2013 Intel Corporation
+// Copyright © 2015-2017 Samsung Electronics Co., Ltd
+// Copyright © 2015-2017 Red Hat Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice (including the next
+// paragraph) shall be included in all copies or substantial portions of the
+// Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package xdg_shell
+
+import "github.com/rajveermalviya/go-wayland/wayland/client"
+
+// WmBaseAPI is the interface of the methods of WmBase, to
+// replace it with a FakeWmBase in tests. WmBase.API returns the
+// WmBaseAPI of a proxy.
+type WmBaseAPI interface {
+	Destroy() error
+	CreatePositioner() (PositionerAPI, error)
+	GetXdgSurface(surface client.SurfaceAPI) (SurfaceAPI, error)
+	Pong(serial uint32) error
+	SetPingHandler(f WmBasePingHandlerFunc)
+	SetListener(l WmBaseListener)
+}
+
+// API returns the WmBase as a WmBaseAPI, whose requests
+// take and return API interfaces.
+func (i *WmBase) API() WmBaseAPI {
+	return xdgWmBaseAPI{i}
+}
+
+// xdgWmBaseAPI is the WmBaseAPI of a WmBase.
+type xdgWmBaseAPI struct {
+	*WmBase
+}
+
+var _ WmBaseAPI = xdgWmBaseAPI{}
+
+// Unwrap returns the proxy, for client.APIProxy.
+func (a xdgWmBaseAPI) Unwrap() client.Proxy {
+	return a.WmBase
+}
+
+func (a xdgWmBaseAPI) CreatePositioner() (PositionerAPI, error) {
+	id, err := a.WmBase.CreatePositioner()
+	if id == nil {
+		return nil, err
+	}
+	return id.API(), err
+}
+
+func (a xdgWmBaseAPI) GetXdgSurface(surface client.SurfaceAPI) (SurfaceAPI, error) {
+	surfaceProxy, err := client.APIProxy[*client.Surface](surface)
+	if err != nil {
+		return nil, err
+	}
+	id, err := a.WmBase.GetXdgSurface(surfaceProxy)
+	if id == nil {
+		return nil, err
+	}
+	return id.API(), err
+}
+
+// FakeWmBase is a WmBaseAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// client.Proxy, to be bound with the bind request of a fake registry.
+type FakeWmBase struct {
+	client.BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []client.FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+
+	pingHandler WmBasePingHandlerFunc
+}
+
+var _ WmBaseAPI = (*FakeWmBase)(nil)
+
+// Destroy records the destroy request.
+func (i *FakeWmBase) Destroy() error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "destroy"})
+	i.Destroyed = true
+	return i.Err
+}
+
+// CreatePositioner records the create_positioner request.
+func (i *FakeWmBase) CreatePositioner() (PositionerAPI, error) {
+	id := &FakePositioner{}
+	i.Calls = append(i.Calls, client.FakeCall{Request: "create_positioner", Args: []any{id}})
+	return id, i.Err
+}
+
+// GetXdgSurface records the get_xdg_surface request.
+func (i *FakeWmBase) GetXdgSurface(surface client.SurfaceAPI) (SurfaceAPI, error) {
+	id := &FakeSurface{}
+	i.Calls = append(i.Calls, client.FakeCall{Request: "get_xdg_surface", Args: []any{id, surface}})
+	return id, i.Err
+}
+
+// Pong records the pong request.
+func (i *FakeWmBase) Pong(serial uint32) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "pong", Args: []any{serial}})
+	return i.Err
+}
+
+func (i *FakeWmBase) SetPingHandler(f WmBasePingHandlerFunc) {
+	i.pingHandler = f
+}
+
+// EmitPing calls the handler of ping as if the event was received.
+func (i *FakeWmBase) EmitPing(e WmBasePingEvent) {
+	if i.pingHandler != nil {
+		i.pingHandler(e)
+	}
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *FakeWmBase) SetListener(l WmBaseListener) {
+	if l == nil {
+		i.pingHandler = nil
+		return
+	}
+	i.pingHandler = l.Ping
+}
+
+// PositionerAPI is the interface of the methods of Positioner, to
+// replace it with a FakePositioner in tests. Positioner.API returns the
+// PositionerAPI of a proxy.
+type PositionerAPI interface {
+	Destroy() error
+	SetSize(width, height int32) error
+	SetAnchorRect(x, y, width, height int32) error
+	SetAnchor(anchor PositionerAnchor) error
+	SetGravity(gravity PositionerGravity) error
+	SetConstraintAdjustment(constraintAdjustment PositionerConstraintAdjustment) error
+	SetOffset(x, y int32) error
+	SetReactive() error
+	SetParentSize(parentWidth, parentHeight int32) error
+	SetParentConfigure(serial uint32) error
+}
+
+// API returns the Positioner as a PositionerAPI, whose requests
+// take and return API interfaces.
+func (i *Positioner) API() PositionerAPI {
+	return xdgPositionerAPI{i}
+}
+
+// xdgPositionerAPI is the PositionerAPI of a Positioner.
+type xdgPositionerAPI struct {
+	*Positioner
+}
+
+var _ PositionerAPI = xdgPositionerAPI{}
+
+// Unwrap returns the proxy, for client.APIProxy.
+func (a xdgPositionerAPI) Unwrap() client.Proxy {
+	return a.Positioner
+}
+
+// FakePositioner is a PositionerAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// client.Proxy, to be bound with the bind request of a fake registry.
+type FakePositioner struct {
+	client.BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []client.FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+}
+
+var _ PositionerAPI = (*FakePositioner)(nil)
+
+// Destroy records the destroy request.
+func (i *FakePositioner) Destroy() error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "destroy"})
+	i.Destroyed = true
+	return i.Err
+}
+
+// SetSize records the set_size request.
+func (i *FakePositioner) SetSize(width, height int32) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "set_size", Args: []any{width, height}})
+	return i.Err
+}
+
+// SetAnchorRect records the set_anchor_rect request.
+func (i *FakePositioner) SetAnchorRect(x, y, width, height int32) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "set_anchor_rect", Args: []any{x, y, width, height}})
+	return i.Err
+}
+
+// SetAnchor records the set_anchor request.
+func (i *FakePositioner) SetAnchor(anchor PositionerAnchor) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "set_anchor", Args: []any{anchor}})
+	return i.Err
+}
+
+// SetGravity records the set_gravity request.
+func (i *FakePositioner) SetGravity(gravity PositionerGravity) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "set_gravity", Args: []any{gravity}})
+	return i.Err
+}
+
+// SetConstraintAdjustment records the set_constraint_adjustment request.
+func (i *FakePositioner) SetConstraintAdjustment(constraintAdjustment PositionerConstraintAdjustment) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "set_constraint_adjustment", Args: []any{constraintAdjustment}})
+	return i.Err
+}
+
+// SetOffset records the set_offset request.
+func (i *FakePositioner) SetOffset(x, y int32) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "set_offset", Args: []any{x, y}})
+	return i.Err
+}
+
+// SetReactive records the set_reactive request.
+func (i *FakePositioner) SetReactive() error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "set_reactive"})
+	return i.Err
+}
+
+// SetParentSize records the set_parent_size request.
+func (i *FakePositioner) SetParentSize(parentWidth, parentHeight int32) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "set_parent_size", Args: []any{parentWidth, parentHeight}})
+	return i.Err
+}
+
+// SetParentConfigure records the set_parent_configure request.
+func (i *FakePositioner) SetParentConfigure(serial uint32) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "set_parent_configure", Args: []any{serial}})
+	return i.Err
+}
+
+// SurfaceAPI is the interface of the methods of Surface, to
+// replace it with a FakeSurface in tests. Surface.API returns the
+// SurfaceAPI of a proxy.
+type SurfaceAPI interface {
+	Destroy() error
+	GetToplevel() (ToplevelAPI, error)
+	GetPopup(parent SurfaceAPI, positioner PositionerAPI) (PopupAPI, error)
+	SetWindowGeometry(x, y, width, height int32) error
+	AckConfigure(serial uint32) error
+	SetConfigureHandler(f SurfaceConfigureHandlerFunc)
+	SetListener(l SurfaceListener)
+}
+
+// API returns the Surface as a SurfaceAPI, whose requests
+// take and return API interfaces.
+func (i *Surface) API() SurfaceAPI {
+	return xdgSurfaceAPI{i}
+}
+
+// xdgSurfaceAPI is the SurfaceAPI of a Surface.
+type xdgSurfaceAPI struct {
+	*Surface
+}
+
+var _ SurfaceAPI = xdgSurfaceAPI{}
+
+// Unwrap returns the proxy, for client.APIProxy.
+func (a xdgSurfaceAPI) Unwrap() client.Proxy {
+	return a.Surface
+}
+
+func (a xdgSurfaceAPI) GetToplevel() (ToplevelAPI, error) {
+	id, err := a.Surface.GetToplevel()
+	if id == nil {
+		return nil, err
+	}
+	return id.API(), err
+}
+
+func (a xdgSurfaceAPI) GetPopup(parent SurfaceAPI, positioner PositionerAPI) (PopupAPI, error) {
+	parentProxy, err := client.APIProxy[*Surface](parent)
+	if err != nil {
+		return nil, err
+	}
+	positionerProxy, err := client.APIProxy[*Positioner](positioner)
+	if err != nil {
+		return nil, err
+	}
+	id, err := a.Surface.GetPopup(parentProxy, positionerProxy)
+	if id == nil {
+		return nil, err
+	}
+	return id.API(), err
+}
+
+// FakeSurface is a SurfaceAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// client.Proxy, to be bound with the bind request of a fake registry.
+type FakeSurface struct {
+	client.BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []client.FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+
+	configureHandler SurfaceConfigureHandlerFunc
+}
+
+var _ SurfaceAPI = (*FakeSurface)(nil)
+
+// Destroy records the destroy request.
+func (i *FakeSurface) Destroy() error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "destroy"})
+	i.Destroyed = true
+	return i.Err
+}
+
+// GetToplevel records the get_toplevel request.
+func (i *FakeSurface) GetToplevel() (ToplevelAPI, error) {
+	id := &FakeToplevel{}
+	i.Calls = append(i.Calls, client.FakeCall{Request: "get_toplevel", Args: []any{id}})
+	return id, i.Err
+}
+
+// GetPopup records the get_popup request.
+func (i *FakeSurface) GetPopup(parent SurfaceAPI, positioner PositionerAPI) (PopupAPI, error) {
+	id := &FakePopup{}
+	i.Calls = append(i.Calls, client.FakeCall{Request: "get_popup", Args: []any{id, parent, positioner}})
+	return id, i.Err
+}
+
+// SetWindowGeometry records the set_window_geometry request.
+func (i *FakeSurface) SetWindowGeometry(x, y, width, height int32) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "set_window_geometry", Args: []any{x, y, width, height}})
+	return i.Err
+}
+
+// AckConfigure records the ack_configure request.
+func (i *FakeSurface) AckConfigure(serial uint32) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "ack_configure", Args: []any{serial}})
+	return i.Err
+}
+
+func (i *FakeSurface) SetConfigureHandler(f SurfaceConfigureHandlerFunc) {
+	i.configureHandler = f
+}
+
+// EmitConfigure calls the handler of configure as if the event was received.
+func (i *FakeSurface) EmitConfigure(e SurfaceConfigureEvent) {
+	if i.configureHandler != nil {
+		i.configureHandler(e)
+	}
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *FakeSurface) SetListener(l SurfaceListener) {
+	if l == nil {
+		i.configureHandler = nil
+		return
+	}
+	i.configureHandler = l.Configure
+}
+
+// ToplevelAPI is the interface of the methods of Toplevel, to
+// replace it with a FakeToplevel in tests. Toplevel.API returns the
+// ToplevelAPI of a proxy.
+type ToplevelAPI interface {
+	Destroy() error
+	SetParent(parent ToplevelAPI) error
+	SetTitle(title string) error
+	SetAppId(appId string) error
+	ShowWindowMenu(seat client.SeatAPI, serial uint32, x, y int32) error
+	Move(seat client.SeatAPI, serial uint32) error
+	Resize(seat client.SeatAPI, serial uint32, edges ToplevelResizeEdge) error
+	SetMaxSize(width, height int32) error
+	SetMinSize(width, height int32) error
+	SetMaximized() error
+	UnsetMaximized() error
+	SetFullscreen(output client.OutputAPI) error
+	UnsetFullscreen() error
+	SetMinimized() error
+	SetConfigureHandler(f ToplevelConfigureHandlerFunc)
+	SetCloseHandler(f ToplevelCloseHandlerFunc)
+	SetConfigureBoundsHandler(f ToplevelConfigureBoundsHandlerFunc)
+	SetWmCapabilitiesHandler(f ToplevelWmCapabilitiesHandlerFunc)
+	SetListener(l ToplevelListener)
+}
+
+// API returns the Toplevel as a ToplevelAPI, whose requests
+// take and return API interfaces.
+func (i *Toplevel) API() ToplevelAPI {
+	return xdgToplevelAPI{i}
+}
+
+// xdgToplevelAPI is the ToplevelAPI of a Toplevel.
+type xdgToplevelAPI struct {
+	*Toplevel
+}
+
+var _ ToplevelAPI = xdgToplevelAPI{}
+
+// Unwrap returns the proxy, for client.APIProxy.
+func (a xdgToplevelAPI) Unwrap() client.Proxy {
+	return a.Toplevel
+}
+
+func (a xdgToplevelAPI) SetParent(parent ToplevelAPI) error {
+	parentProxy, err := client.APIProxy[*Toplevel](parent)
+	if err != nil {
+		return err
+	}
+	return a.Toplevel.SetParent(parentProxy)
+}
+
+func (a xdgToplevelAPI) ShowWindowMenu(seat client.SeatAPI, serial uint32, x, y int32) error {
+	seatProxy, err := client.APIProxy[*client.Seat](seat)
+	if err != nil {
+		return err
+	}
+	return a.Toplevel.ShowWindowMenu(seatProxy, serial, x, y)
+}
+
+func (a xdgToplevelAPI) Move(seat client.SeatAPI, serial uint32) error {
+	seatProxy, err := client.APIProxy[*client.Seat](seat)
+	if err != nil {
+		return err
+	}
+	return a.Toplevel.Move(seatProxy, serial)
+}
+
+func (a xdgToplevelAPI) Resize(seat client.SeatAPI, serial uint32, edges ToplevelResizeEdge) error {
+	seatProxy, err := client.APIProxy[*client.Seat](seat)
+	if err != nil {
+		return err
+	}
+	return a.Toplevel.Resize(seatProxy, serial, edges)
+}
+
+func (a xdgToplevelAPI) SetFullscreen(output client.OutputAPI) error {
+	outputProxy, err := client.APIProxy[*client.Output](output)
+	if err != nil {
+		return err
+	}
+	return a.Toplevel.SetFullscreen(outputProxy)
+}
+
+// FakeToplevel is a ToplevelAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// client.Proxy, to be bound with the bind request of a fake registry.
+type FakeToplevel struct {
+	client.BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []client.FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+
+	configureHandler       ToplevelConfigureHandlerFunc
+	closeHandler           ToplevelCloseHandlerFunc
+	configureBoundsHandler ToplevelConfigureBoundsHandlerFunc
+	wmCapabilitiesHandler  ToplevelWmCapabilitiesHandlerFunc
+}
+
+var _ ToplevelAPI = (*FakeToplevel)(nil)
+
+// Destroy records the destroy request.
+func (i *FakeToplevel) Destroy() error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "destroy"})
+	i.Destroyed = true
+	return i.Err
+}
+
+// SetParent records the set_parent request.
+func (i *FakeToplevel) SetParent(parent ToplevelAPI) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "set_parent", Args: []any{parent}})
+	return i.Err
+}
+
+// SetTitle records the set_title request.
+func (i *FakeToplevel) SetTitle(title string) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "set_title", Args: []any{title}})
+	return i.Err
+}
+
+// SetAppId records the set_app_id request.
+func (i *FakeToplevel) SetAppId(appId string) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "set_app_id", Args: []any{appId}})
+	return i.Err
+}
+
+// ShowWindowMenu records the show_window_menu request.
+func (i *FakeToplevel) ShowWindowMenu(seat client.SeatAPI, serial uint32, x, y int32) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "show_window_menu", Args: []any{seat, serial, x, y}})
+	return i.Err
+}
+
+// Move records the move request.
+func (i *FakeToplevel) Move(seat client.SeatAPI, serial uint32) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "move", Args: []any{seat, serial}})
+	return i.Err
+}
+
+// Resize records the resize request.
+func (i *FakeToplevel) Resize(seat client.SeatAPI, serial uint32, edges ToplevelResizeEdge) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "resize", Args: []any{seat, serial, edges}})
+	return i.Err
+}
+
+// SetMaxSize records the set_max_size request.
+func (i *FakeToplevel) SetMaxSize(width, height int32) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "set_max_size", Args: []any{width, height}})
+	return i.Err
+}
+
+// SetMinSize records the set_min_size request.
+func (i *FakeToplevel) SetMinSize(width, height int32) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "set_min_size", Args: []any{width, height}})
+	return i.Err
+}
+
+// SetMaximized records the set_maximized request.
+func (i *FakeToplevel) SetMaximized() error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "set_maximized"})
+	return i.Err
+}
+
+// UnsetMaximized records the unset_maximized request.
+func (i *FakeToplevel) UnsetMaximized() error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "unset_maximized"})
+	return i.Err
+}
+
+// SetFullscreen records the set_fullscreen request.
+func (i *FakeToplevel) SetFullscreen(output client.OutputAPI) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "set_fullscreen", Args: []any{output}})
+	return i.Err
+}
+
+// UnsetFullscreen records the unset_fullscreen request.
+func (i *FakeToplevel) UnsetFullscreen() error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "unset_fullscreen"})
+	return i.Err
+}
+
+// SetMinimized records the set_minimized request.
+func (i *FakeToplevel) SetMinimized() error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "set_minimized"})
+	return i.Err
+}
+
+func (i *FakeToplevel) SetConfigureHandler(f ToplevelConfigureHandlerFunc) {
+	i.configureHandler = f
+}
+
+// EmitConfigure calls the handler of configure as if the event was received.
+func (i *FakeToplevel) EmitConfigure(e ToplevelConfigureEvent) {
+	if i.configureHandler != nil {
+		i.configureHandler(e)
+	}
+}
+
+func (i *FakeToplevel) SetCloseHandler(f ToplevelCloseHandlerFunc) {
+	i.closeHandler = f
+}
+
+// EmitClose calls the handler of close as if the event was received.
+func (i *FakeToplevel) EmitClose(e ToplevelCloseEvent) {
+	if i.closeHandler != nil {
+		i.closeHandler(e)
+	}
+}
+
+func (i *FakeToplevel) SetConfigureBoundsHandler(f ToplevelConfigureBoundsHandlerFunc) {
+	i.configureBoundsHandler = f
+}
+
+// EmitConfigureBounds calls the handler of configure_bounds as if the event was received.
+func (i *FakeToplevel) EmitConfigureBounds(e ToplevelConfigureBoundsEvent) {
+	if i.configureBoundsHandler != nil {
+		i.configureBoundsHandler(e)
+	}
+}
+
+func (i *FakeToplevel) SetWmCapabilitiesHandler(f ToplevelWmCapabilitiesHandlerFunc) {
+	i.wmCapabilitiesHandler = f
+}
+
+// EmitWmCapabilities calls the handler of wm_capabilities as if the event was received.
+func (i *FakeToplevel) EmitWmCapabilities(e ToplevelWmCapabilitiesEvent) {
+	if i.wmCapabilitiesHandler != nil {
+		i.wmCapabilitiesHandler(e)
+	}
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *FakeToplevel) SetListener(l ToplevelListener) {
+	if l == nil {
+		i.configureHandler = nil
+		i.closeHandler = nil
+		i.configureBoundsHandler = nil
+		i.wmCapabilitiesHandler = nil
+		return
+	}
+	i.configureHandler = l.Configure
+	i.closeHandler = l.Close
+	i.configureBoundsHandler = l.ConfigureBounds
+	i.wmCapabilitiesHandler = l.WmCapabilities
+}
+
+// PopupAPI is the interface of the methods of Popup, to
+// replace it with a FakePopup in tests. Popup.API returns the
+// PopupAPI of a proxy.
+type PopupAPI interface {
+	Destroy() error
+	Grab(seat client.SeatAPI, serial uint32) error
+	Reposition(positioner PositionerAPI, token uint32) error
+	SetConfigureHandler(f PopupConfigureHandlerFunc)
+	SetPopupDoneHandler(f PopupPopupDoneHandlerFunc)
+	SetRepositionedHandler(f PopupRepositionedHandlerFunc)
+	SetListener(l PopupListener)
+}
+
+// API returns the Popup as a PopupAPI, whose requests
+// take and return API interfaces.
+func (i *Popup) API() PopupAPI {
+	return xdgPopupAPI{i}
+}
+
+// xdgPopupAPI is the PopupAPI of a Popup.
+type xdgPopupAPI struct {
+	*Popup
+}
+
+var _ PopupAPI = xdgPopupAPI{}
+
+// Unwrap returns the proxy, for client.APIProxy.
+func (a xdgPopupAPI) Unwrap() client.Proxy {
+	return a.Popup
+}
+
+func (a xdgPopupAPI) Grab(seat client.SeatAPI, serial uint32) error {
+	seatProxy, err := client.APIProxy[*client.Seat](seat)
+	if err != nil {
+		return err
+	}
+	return a.Popup.Grab(seatProxy, serial)
+}
+
+func (a xdgPopupAPI) Reposition(positioner PositionerAPI, token uint32) error {
+	positionerProxy, err := client.APIProxy[*Positioner](positioner)
+	if err != nil {
+		return err
+	}
+	return a.Popup.Reposition(positionerProxy, token)
+}
+
+// FakePopup is a PopupAPI which records the requests made on it
+// instead of sending them, and emits events on demand. It is also a
+// client.Proxy, to be bound with the bind request of a fake registry.
+type FakePopup struct {
+	client.BaseProxy
+	// Calls are the requests made, in order. The objects created by a
+	// request are new fakes, recorded in its arguments.
+	Calls []client.FakeCall
+	// Err is returned by every request.
+	Err error
+	// Destroyed is set by Destroy, destructor requests and destructor
+	// events.
+	Destroyed bool
+
+	configureHandler    PopupConfigureHandlerFunc
+	popupDoneHandler    PopupPopupDoneHandlerFunc
+	repositionedHandler PopupRepositionedHandlerFunc
+}
+
+var _ PopupAPI = (*FakePopup)(nil)
+
+// Destroy records the destroy request.
+func (i *FakePopup) Destroy() error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "destroy"})
+	i.Destroyed = true
+	return i.Err
+}
+
+// Grab records the grab request.
+func (i *FakePopup) Grab(seat client.SeatAPI, serial uint32) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "grab", Args: []any{seat, serial}})
+	return i.Err
+}
+
+// Reposition records the reposition request.
+func (i *FakePopup) Reposition(positioner PositionerAPI, token uint32) error {
+	i.Calls = append(i.Calls, client.FakeCall{Request: "reposition", Args: []any{positioner, token}})
+	return i.Err
+}
+
+func (i *FakePopup) SetConfigureHandler(f PopupConfigureHandlerFunc) {
+	i.configureHandler = f
+}
+
+// EmitConfigure calls the handler of configure as if the event was received.
+func (i *FakePopup) EmitConfigure(e PopupConfigureEvent) {
+	if i.configureHandler != nil {
+		i.configureHandler(e)
+	}
+}
+
+func (i *FakePopup) SetPopupDoneHandler(f PopupPopupDoneHandlerFunc) {
+	i.popupDoneHandler = f
+}
+
+// EmitPopupDone calls the handler of popup_done as if the event was received.
+func (i *FakePopup) EmitPopupDone(e PopupPopupDoneEvent) {
+	if i.popupDoneHandler != nil {
+		i.popupDoneHandler(e)
+	}
+}
+
+func (i *FakePopup) SetRepositionedHandler(f PopupRepositionedHandlerFunc) {
+	i.repositionedHandler = f
+}
+
+// EmitRepositioned calls the handler of repositioned as if the event was received.
+func (i *FakePopup) EmitRepositioned(e PopupRepositionedEvent) {
+	if i.repositionedHandler != nil {
+		i.repositionedHandler(e)
+	}
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *FakePopup) SetListener(l PopupListener) {
+	if l == nil {
+		i.configureHandler = nil
+		i.popupDoneHandler = nil
+		i.repositionedHandler = nil
+		return
+	}
+	i.configureHandler = l.Configure
+	i.popupDoneHandler = l.PopupDone
+	i.repositionedHandler = l.Repositioned
+}
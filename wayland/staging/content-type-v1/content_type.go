@@ -58,6 +58,39 @@ func NewContentTypeManager(ctx *client.Context) *ContentTypeManager {
 	return wpContentTypeManagerV1
 }
 
+// ContentTypeManagerInterface describes wp_content_type_manager_v1 at runtime.
+var ContentTypeManagerInterface = &client.Interface{
+	Name:    "wp_content_type_manager_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &ContentTypeManager{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "get_surface_content_type",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "wp_content_type_v1"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "already_constructed", Value: 0},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wp_content_type_manager_v1.
+func (i *ContentTypeManager) Interface() *client.Interface {
+	return ContentTypeManagerInterface
+}
+
 // Destroy : destroy the content type manager object
 //
 // Destroy the content type manager. This doesn't destroy objects created
@@ -84,6 +117,7 @@ func (i *ContentTypeManager) Destroy() error {
 // attached is a client error: already_constructed.
 func (i *ContentTypeManager) GetSurfaceContentType(surface *client.Surface) (*ContentType, error) {
 	id := NewContentType(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -158,6 +192,41 @@ func NewContentType(ctx *client.Context) *ContentType {
 	return wpContentTypeV1
 }
 
+// ContentTypeInterface describes wp_content_type_v1 at runtime.
+var ContentTypeInterface = &client.Interface{
+	Name:    "wp_content_type_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &ContentType{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "set_content_type",
+			Args: []client.Arg{
+				{Name: "content_type", Type: "uint", Enum: "wp_content_type_v1.type"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "type",
+			Entries: []client.EnumEntry{
+				{Name: "none", Value: 0},
+				{Name: "photo", Value: 1},
+				{Name: "video", Value: 2},
+				{Name: "game", Value: 3},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wp_content_type_v1.
+func (i *ContentType) Interface() *client.Interface {
+	return ContentTypeInterface
+}
+
 // Destroy : destroy the content type object
 //
 // Switch back to not specifying the content type of this surface. This is
@@ -189,7 +258,7 @@ func (i *ContentType) Destroy() error {
 // details.
 //
 //	contentType: the content type
-func (i *ContentType) SetContentType(contentType uint32) error {
+func (i *ContentType) SetContentType(contentType ContentTypeType) error {
 	const opcode = 1
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -249,3 +318,8 @@ func (e ContentTypeType) Value() string {
 func (e ContentTypeType) String() string {
 	return e.Name() + "=" + e.Value()
 }
+
+func init() {
+	client.RegisterInterface(ContentTypeManagerInterface)
+	client.RegisterInterface(ContentTypeInterface)
+}
@@ -1,3 +1 @@
 package content_type
-
-//go:generate go run github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner -pkg content_type -prefix wp -suffix v1 -o content_type.go -i https://raw.githubusercontent.com/wayland-project/wayland-protocols/1.31/staging/content-type/content-type-v1.xml
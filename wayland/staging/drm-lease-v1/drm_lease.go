@@ -30,6 +30,9 @@
 package drm_lease
 
 import (
+	"log/slog"
+	"strconv"
+
 	"github.com/rajveermalviya/go-wayland/wayland/client"
 	"golang.org/x/sys/unix"
 )
@@ -112,6 +115,49 @@ func NewDrmLeaseDevice(ctx *client.Context) *DrmLeaseDevice {
 	return wpDrmLeaseDeviceV1
 }
 
+// DrmLeaseDeviceInterface describes wp_drm_lease_device_v1 at runtime.
+var DrmLeaseDeviceInterface = &client.Interface{
+	Name:    "wp_drm_lease_device_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &DrmLeaseDevice{} },
+	Requests: []client.Message{
+		{
+			Name: "create_lease_request",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "wp_drm_lease_request_v1"},
+			},
+		},
+		{
+			Name: "release",
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "drm_fd",
+			Args: []client.Arg{
+				{Name: "fd", Type: "fd"},
+			},
+		},
+		{
+			Name: "connector",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "wp_drm_lease_connector_v1"},
+			},
+		},
+		{
+			Name: "done",
+		},
+		{
+			Name: "released",
+		},
+	},
+}
+
+// Interface returns the description of wp_drm_lease_device_v1.
+func (i *DrmLeaseDevice) Interface() *client.Interface {
+	return DrmLeaseDeviceInterface
+}
+
 // CreateLeaseRequest : create a lease request object
 //
 // Creates a lease request object.
@@ -119,6 +165,7 @@ func NewDrmLeaseDevice(ctx *client.Context) *DrmLeaseDevice {
 // See the documentation for wp_drm_lease_request_v1 for details.
 func (i *DrmLeaseDevice) CreateLeaseRequest() (*DrmLeaseRequest, error) {
 	id := NewDrmLeaseRequest(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 0
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -179,6 +226,20 @@ func (i *DrmLeaseDevice) SetDrmFdHandler(f DrmLeaseDeviceDrmFdHandlerFunc) {
 	i.drmFdHandler = f
 }
 
+// String formats the event as wp_drm_lease_device_v1.drm_fd(argument=value, ...).
+func (e DrmLeaseDeviceDrmFdEvent) String() string {
+	return "wp_drm_lease_device_v1.drm_fd(" +
+		"fd=" + strconv.Itoa(e.Fd) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DrmLeaseDeviceDrmFdEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int("fd", e.Fd),
+	)
+}
+
 // DrmLeaseDeviceConnectorEvent : advertise connectors available for leases
 //
 // The compositor will use this event to advertise connectors available for
@@ -201,6 +262,20 @@ func (i *DrmLeaseDevice) SetConnectorHandler(f DrmLeaseDeviceConnectorHandlerFun
 	i.connectorHandler = f
 }
 
+// String formats the event as wp_drm_lease_device_v1.connector(argument=value, ...).
+func (e DrmLeaseDeviceConnectorEvent) String() string {
+	return "wp_drm_lease_device_v1.connector(" +
+		"id=" + client.ObjectString(e.Id) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DrmLeaseDeviceConnectorEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", client.ObjectString(e.Id)),
+	)
+}
+
 // DrmLeaseDeviceDoneEvent : signals grouping of connectors
 //
 // The compositor will send this event to indicate that it has sent all
@@ -217,6 +292,16 @@ func (i *DrmLeaseDevice) SetDoneHandler(f DrmLeaseDeviceDoneHandlerFunc) {
 	i.doneHandler = f
 }
 
+// String formats the event as wp_drm_lease_device_v1.done(argument=value, ...).
+func (e DrmLeaseDeviceDoneEvent) String() string {
+	return "wp_drm_lease_device_v1.done()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DrmLeaseDeviceDoneEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // DrmLeaseDeviceReleasedEvent : the compositor has finished using the device
 //
 // This event is sent in response to the release request and indicates
@@ -232,6 +317,58 @@ func (i *DrmLeaseDevice) SetReleasedHandler(f DrmLeaseDeviceReleasedHandlerFunc)
 	i.releasedHandler = f
 }
 
+// String formats the event as wp_drm_lease_device_v1.released(argument=value, ...).
+func (e DrmLeaseDeviceReleasedEvent) String() string {
+	return "wp_drm_lease_device_v1.released()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DrmLeaseDeviceReleasedEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
+// DrmLeaseDeviceListener receives all the events of DrmLeaseDevice, embed
+// DrmLeaseDeviceNoopListener to implement only some of them.
+type DrmLeaseDeviceListener interface {
+	DrmFd(e DrmLeaseDeviceDrmFdEvent)
+	Connector(e DrmLeaseDeviceConnectorEvent)
+	Done(e DrmLeaseDeviceDoneEvent)
+	Released(e DrmLeaseDeviceReleasedEvent)
+}
+
+// DrmLeaseDeviceNoopListener is a DrmLeaseDeviceListener ignoring
+// all the events, it closes the file descriptors they carry.
+type DrmLeaseDeviceNoopListener struct{}
+
+func (DrmLeaseDeviceNoopListener) DrmFd(e DrmLeaseDeviceDrmFdEvent) {
+	unix.Close(e.Fd)
+}
+
+func (DrmLeaseDeviceNoopListener) Connector(DrmLeaseDeviceConnectorEvent) {
+}
+
+func (DrmLeaseDeviceNoopListener) Done(DrmLeaseDeviceDoneEvent) {
+}
+
+func (DrmLeaseDeviceNoopListener) Released(DrmLeaseDeviceReleasedEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *DrmLeaseDevice) SetListener(l DrmLeaseDeviceListener) {
+	if l == nil {
+		i.drmFdHandler = nil
+		i.connectorHandler = nil
+		i.doneHandler = nil
+		i.releasedHandler = nil
+		return
+	}
+	i.drmFdHandler = l.DrmFd
+	i.connectorHandler = l.Connector
+	i.doneHandler = l.Done
+	i.releasedHandler = l.Released
+}
+
 func (i *DrmLeaseDevice) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -246,15 +383,16 @@ func (i *DrmLeaseDevice) Dispatch(opcode uint32, fd int, data []byte) {
 
 		i.drmFdHandler(e)
 	case 1:
-		if i.connectorHandler == nil {
-			return
-		}
 		var e DrmLeaseDeviceConnectorEvent
 		l := 0
-		e.Id = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*DrmLeaseConnector)
+		e.Id = &DrmLeaseConnector{}
+		i.Context().RegisterWithID(e.Id, client.Uint32(data[l:l+4]))
+		e.Id.SetVersion(i.Version())
 		l += 4
 
-		i.connectorHandler(e)
+		if i.connectorHandler != nil {
+			i.connectorHandler(e)
+		}
 	case 2:
 		if i.doneHandler == nil {
 			return
@@ -305,6 +443,50 @@ func NewDrmLeaseConnector(ctx *client.Context) *DrmLeaseConnector {
 	return wpDrmLeaseConnectorV1
 }
 
+// DrmLeaseConnectorInterface describes wp_drm_lease_connector_v1 at runtime.
+var DrmLeaseConnectorInterface = &client.Interface{
+	Name:    "wp_drm_lease_connector_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &DrmLeaseConnector{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "name",
+			Args: []client.Arg{
+				{Name: "name", Type: "string"},
+			},
+		},
+		{
+			Name: "description",
+			Args: []client.Arg{
+				{Name: "description", Type: "string"},
+			},
+		},
+		{
+			Name: "connector_id",
+			Args: []client.Arg{
+				{Name: "connector_id", Type: "uint"},
+			},
+		},
+		{
+			Name: "done",
+		},
+		{
+			Name: "withdrawn",
+		},
+	},
+}
+
+// Interface returns the description of wp_drm_lease_connector_v1.
+func (i *DrmLeaseConnector) Interface() *client.Interface {
+	return DrmLeaseConnectorInterface
+}
+
 // Destroy : destroy connector
 //
 // The client may send this request to indicate that it will not use this
@@ -342,6 +524,20 @@ func (i *DrmLeaseConnector) SetNameHandler(f DrmLeaseConnectorNameHandlerFunc) {
 	i.nameHandler = f
 }
 
+// String formats the event as wp_drm_lease_connector_v1.name(argument=value, ...).
+func (e DrmLeaseConnectorNameEvent) String() string {
+	return "wp_drm_lease_connector_v1.name(" +
+		"name=" + strconv.Quote(e.Name) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DrmLeaseConnectorNameEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("name", e.Name),
+	)
+}
+
 // DrmLeaseConnectorDescriptionEvent : description
 //
 // The compositor sends this event once the connector is created to provide
@@ -358,6 +554,20 @@ func (i *DrmLeaseConnector) SetDescriptionHandler(f DrmLeaseConnectorDescription
 	i.descriptionHandler = f
 }
 
+// String formats the event as wp_drm_lease_connector_v1.description(argument=value, ...).
+func (e DrmLeaseConnectorDescriptionEvent) String() string {
+	return "wp_drm_lease_connector_v1.description(" +
+		"description=" + strconv.Quote(e.Description) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DrmLeaseConnectorDescriptionEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("description", e.Description),
+	)
+}
+
 // DrmLeaseConnectorConnectorIdEvent : connector_id
 //
 // The compositor sends this event once the connector is created to
@@ -374,6 +584,20 @@ func (i *DrmLeaseConnector) SetConnectorIdHandler(f DrmLeaseConnectorConnectorId
 	i.connectorIdHandler = f
 }
 
+// String formats the event as wp_drm_lease_connector_v1.connector_id(argument=value, ...).
+func (e DrmLeaseConnectorConnectorIdEvent) String() string {
+	return "wp_drm_lease_connector_v1.connector_id(" +
+		"connector_id=" + strconv.FormatUint(uint64(e.ConnectorId), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DrmLeaseConnectorConnectorIdEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("connector_id", uint64(e.ConnectorId)),
+	)
+}
+
 // DrmLeaseConnectorDoneEvent : all properties have been sent
 //
 // This event is sent after all properties of a connector have been sent.
@@ -387,6 +611,16 @@ func (i *DrmLeaseConnector) SetDoneHandler(f DrmLeaseConnectorDoneHandlerFunc) {
 	i.doneHandler = f
 }
 
+// String formats the event as wp_drm_lease_connector_v1.done(argument=value, ...).
+func (e DrmLeaseConnectorDoneEvent) String() string {
+	return "wp_drm_lease_connector_v1.done()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DrmLeaseConnectorDoneEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // DrmLeaseConnectorWithdrawnEvent : lease offer withdrawn
 //
 // Sent to indicate that the compositor will no longer honor requests for
@@ -404,6 +638,63 @@ func (i *DrmLeaseConnector) SetWithdrawnHandler(f DrmLeaseConnectorWithdrawnHand
 	i.withdrawnHandler = f
 }
 
+// String formats the event as wp_drm_lease_connector_v1.withdrawn(argument=value, ...).
+func (e DrmLeaseConnectorWithdrawnEvent) String() string {
+	return "wp_drm_lease_connector_v1.withdrawn()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DrmLeaseConnectorWithdrawnEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
+// DrmLeaseConnectorListener receives all the events of DrmLeaseConnector, embed
+// DrmLeaseConnectorNoopListener to implement only some of them.
+type DrmLeaseConnectorListener interface {
+	Name(e DrmLeaseConnectorNameEvent)
+	Description(e DrmLeaseConnectorDescriptionEvent)
+	ConnectorId(e DrmLeaseConnectorConnectorIdEvent)
+	Done(e DrmLeaseConnectorDoneEvent)
+	Withdrawn(e DrmLeaseConnectorWithdrawnEvent)
+}
+
+// DrmLeaseConnectorNoopListener is a DrmLeaseConnectorListener ignoring
+// all the events, it closes the file descriptors they carry.
+type DrmLeaseConnectorNoopListener struct{}
+
+func (DrmLeaseConnectorNoopListener) Name(DrmLeaseConnectorNameEvent) {
+}
+
+func (DrmLeaseConnectorNoopListener) Description(DrmLeaseConnectorDescriptionEvent) {
+}
+
+func (DrmLeaseConnectorNoopListener) ConnectorId(DrmLeaseConnectorConnectorIdEvent) {
+}
+
+func (DrmLeaseConnectorNoopListener) Done(DrmLeaseConnectorDoneEvent) {
+}
+
+func (DrmLeaseConnectorNoopListener) Withdrawn(DrmLeaseConnectorWithdrawnEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *DrmLeaseConnector) SetListener(l DrmLeaseConnectorListener) {
+	if l == nil {
+		i.nameHandler = nil
+		i.descriptionHandler = nil
+		i.connectorIdHandler = nil
+		i.doneHandler = nil
+		i.withdrawnHandler = nil
+		return
+	}
+	i.nameHandler = l.Name
+	i.descriptionHandler = l.Description
+	i.connectorIdHandler = l.ConnectorId
+	i.doneHandler = l.Done
+	i.withdrawnHandler = l.Withdrawn
+}
+
 func (i *DrmLeaseConnector) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -479,6 +770,43 @@ func NewDrmLeaseRequest(ctx *client.Context) *DrmLeaseRequest {
 	return wpDrmLeaseRequestV1
 }
 
+// DrmLeaseRequestInterface describes wp_drm_lease_request_v1 at runtime.
+var DrmLeaseRequestInterface = &client.Interface{
+	Name:    "wp_drm_lease_request_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &DrmLeaseRequest{} },
+	Requests: []client.Message{
+		{
+			Name: "request_connector",
+			Args: []client.Arg{
+				{Name: "connector", Type: "object", Interface: "wp_drm_lease_connector_v1"},
+			},
+		},
+		{
+			Name:       "submit",
+			Destructor: true,
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "wp_drm_lease_v1"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "wrong_device", Value: 0},
+				{Name: "duplicate_connector", Value: 1},
+				{Name: "empty_lease", Value: 2},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wp_drm_lease_request_v1.
+func (i *DrmLeaseRequest) Interface() *client.Interface {
+	return DrmLeaseRequestInterface
+}
+
 // RequestConnector : request a connector for this lease
 //
 // Indicates that the client would like to lease the given connector.
@@ -518,6 +846,7 @@ func (i *DrmLeaseRequest) RequestConnector(connector *DrmLeaseConnector) error {
 func (i *DrmLeaseRequest) Submit() (*DrmLease, error) {
 	defer i.Context().Unregister(i)
 	id := NewDrmLease(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -532,6 +861,11 @@ func (i *DrmLeaseRequest) Submit() (*DrmLease, error) {
 	return id, err
 }
 
+func (i *DrmLeaseRequest) Destroy() error {
+	i.Context().Unregister(i)
+	return nil
+}
+
 type DrmLeaseRequestError uint32
 
 // DrmLeaseRequestError :
@@ -606,6 +940,35 @@ func NewDrmLease(ctx *client.Context) *DrmLease {
 	return wpDrmLeaseV1
 }
 
+// DrmLeaseInterface describes wp_drm_lease_v1 at runtime.
+var DrmLeaseInterface = &client.Interface{
+	Name:    "wp_drm_lease_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &DrmLease{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "lease_fd",
+			Args: []client.Arg{
+				{Name: "leased_fd", Type: "fd"},
+			},
+		},
+		{
+			Name: "finished",
+		},
+	},
+}
+
+// Interface returns the description of wp_drm_lease_v1.
+func (i *DrmLease) Interface() *client.Interface {
+	return DrmLeaseInterface
+}
+
 // Destroy : destroys the lease object
 //
 // The client should send this to indicate that it no longer wishes to use
@@ -647,6 +1010,20 @@ func (i *DrmLease) SetLeaseFdHandler(f DrmLeaseLeaseFdHandlerFunc) {
 	i.leaseFdHandler = f
 }
 
+// String formats the event as wp_drm_lease_v1.lease_fd(argument=value, ...).
+func (e DrmLeaseLeaseFdEvent) String() string {
+	return "wp_drm_lease_v1.lease_fd(" +
+		"leased_fd=" + strconv.Itoa(e.LeasedFd) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DrmLeaseLeaseFdEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int("leased_fd", e.LeasedFd),
+	)
+}
+
 // DrmLeaseFinishedEvent : sent when the lease has been revoked
 //
 // The compositor uses this event to either reject a lease request, or if
@@ -665,6 +1042,46 @@ func (i *DrmLease) SetFinishedHandler(f DrmLeaseFinishedHandlerFunc) {
 	i.finishedHandler = f
 }
 
+// String formats the event as wp_drm_lease_v1.finished(argument=value, ...).
+func (e DrmLeaseFinishedEvent) String() string {
+	return "wp_drm_lease_v1.finished()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e DrmLeaseFinishedEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
+// DrmLeaseListener receives all the events of DrmLease, embed
+// DrmLeaseNoopListener to implement only some of them.
+type DrmLeaseListener interface {
+	LeaseFd(e DrmLeaseLeaseFdEvent)
+	Finished(e DrmLeaseFinishedEvent)
+}
+
+// DrmLeaseNoopListener is a DrmLeaseListener ignoring
+// all the events, it closes the file descriptors they carry.
+type DrmLeaseNoopListener struct{}
+
+func (DrmLeaseNoopListener) LeaseFd(e DrmLeaseLeaseFdEvent) {
+	unix.Close(e.LeasedFd)
+}
+
+func (DrmLeaseNoopListener) Finished(DrmLeaseFinishedEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *DrmLease) SetListener(l DrmLeaseListener) {
+	if l == nil {
+		i.leaseFdHandler = nil
+		i.finishedHandler = nil
+		return
+	}
+	i.leaseFdHandler = l.LeaseFd
+	i.finishedHandler = l.Finished
+}
+
 func (i *DrmLease) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -687,3 +1104,10 @@ func (i *DrmLease) Dispatch(opcode uint32, fd int, data []byte) {
 		i.finishedHandler(e)
 	}
 }
+
+func init() {
+	client.RegisterInterface(DrmLeaseDeviceInterface)
+	client.RegisterInterface(DrmLeaseConnectorInterface)
+	client.RegisterInterface(DrmLeaseRequestInterface)
+	client.RegisterInterface(DrmLeaseInterface)
+}
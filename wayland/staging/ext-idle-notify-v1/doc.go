@@ -1,3 +1 @@
 package ext_idle_notify
-
-//go:generate go run github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner -pkg ext_idle_notify -prefix ext -suffix v1 -o ext_idle_notify.go -i https://raw.githubusercontent.com/wayland-project/wayland-protocols/1.31/staging/ext-idle-notify/ext-idle-notify-v1.xml
@@ -28,7 +28,11 @@
 
 package ext_idle_notify
 
-import "github.com/rajveermalviya/go-wayland/wayland/client"
+import (
+	"log/slog"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+)
 
 // IdleNotifier : idle notification manager
 //
@@ -52,6 +56,32 @@ func NewIdleNotifier(ctx *client.Context) *IdleNotifier {
 	return extIdleNotifierV1
 }
 
+// IdleNotifierInterface describes ext_idle_notifier_v1 at runtime.
+var IdleNotifierInterface = &client.Interface{
+	Name:    "ext_idle_notifier_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &IdleNotifier{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "get_idle_notification",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "ext_idle_notification_v1"},
+				{Name: "timeout", Type: "uint"},
+				{Name: "seat", Type: "object", Interface: "wl_seat"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of ext_idle_notifier_v1.
+func (i *IdleNotifier) Interface() *client.Interface {
+	return IdleNotifierInterface
+}
+
 // Destroy : destroy the manager
 //
 // Destroy the manager object. All objects created via this interface
@@ -84,6 +114,7 @@ func (i *IdleNotifier) Destroy() error {
 //	timeout: minimum idle timeout in msec
 func (i *IdleNotifier) GetIdleNotification(timeout uint32, seat *client.Seat) (*IdleNotification, error) {
 	id := NewIdleNotification(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -146,6 +177,32 @@ func NewIdleNotification(ctx *client.Context) *IdleNotification {
 	return extIdleNotificationV1
 }
 
+// IdleNotificationInterface describes ext_idle_notification_v1 at runtime.
+var IdleNotificationInterface = &client.Interface{
+	Name:    "ext_idle_notification_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &IdleNotification{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "idled",
+		},
+		{
+			Name: "resumed",
+		},
+	},
+}
+
+// Interface returns the description of ext_idle_notification_v1.
+func (i *IdleNotification) Interface() *client.Interface {
+	return IdleNotificationInterface
+}
+
 // Destroy : destroy the notification object
 //
 // Destroy the notification object.
@@ -177,6 +234,16 @@ func (i *IdleNotification) SetIdledHandler(f IdleNotificationIdledHandlerFunc) {
 	i.idledHandler = f
 }
 
+// String formats the event as ext_idle_notification_v1.idled(argument=value, ...).
+func (e IdleNotificationIdledEvent) String() string {
+	return "ext_idle_notification_v1.idled()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e IdleNotificationIdledEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // IdleNotificationResumedEvent : notification object is no longer idle
 //
 // This event is sent when the notification object stops being idle.
@@ -192,6 +259,45 @@ func (i *IdleNotification) SetResumedHandler(f IdleNotificationResumedHandlerFun
 	i.resumedHandler = f
 }
 
+// String formats the event as ext_idle_notification_v1.resumed(argument=value, ...).
+func (e IdleNotificationResumedEvent) String() string {
+	return "ext_idle_notification_v1.resumed()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e IdleNotificationResumedEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
+// IdleNotificationListener receives all the events of IdleNotification, embed
+// IdleNotificationNoopListener to implement only some of them.
+type IdleNotificationListener interface {
+	Idled(e IdleNotificationIdledEvent)
+	Resumed(e IdleNotificationResumedEvent)
+}
+
+// IdleNotificationNoopListener is a IdleNotificationListener ignoring
+// all the events, it closes the file descriptors they carry.
+type IdleNotificationNoopListener struct{}
+
+func (IdleNotificationNoopListener) Idled(IdleNotificationIdledEvent) {
+}
+
+func (IdleNotificationNoopListener) Resumed(IdleNotificationResumedEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *IdleNotification) SetListener(l IdleNotificationListener) {
+	if l == nil {
+		i.idledHandler = nil
+		i.resumedHandler = nil
+		return
+	}
+	i.idledHandler = l.Idled
+	i.resumedHandler = l.Resumed
+}
+
 func (i *IdleNotification) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -210,3 +316,8 @@ func (i *IdleNotification) Dispatch(opcode uint32, fd int, data []byte) {
 		i.resumedHandler(e)
 	}
 }
+
+func init() {
+	client.RegisterInterface(IdleNotifierInterface)
+	client.RegisterInterface(IdleNotificationInterface)
+}
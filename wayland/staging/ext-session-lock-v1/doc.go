@@ -1,3 +1 @@
 package ext_session_lock
-
-//go:generate go run github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner -pkg ext_session_lock -suffix v1 -o ext_session_lock.go -i https://raw.githubusercontent.com/wayland-project/wayland-protocols/1.31/staging/ext-session-lock/ext-session-lock-v1.xml
@@ -20,7 +20,12 @@
 
 package ext_session_lock
 
-import "github.com/rajveermalviya/go-wayland/wayland/client"
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+)
 
 // ExtSessionLockManager : used to lock the session
 //
@@ -38,6 +43,30 @@ func NewExtSessionLockManager(ctx *client.Context) *ExtSessionLockManager {
 	return extSessionLockManagerV1
 }
 
+// ExtSessionLockManagerInterface describes ext_session_lock_manager_v1 at runtime.
+var ExtSessionLockManagerInterface = &client.Interface{
+	Name:    "ext_session_lock_manager_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &ExtSessionLockManager{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "lock",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "ext_session_lock_v1"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of ext_session_lock_manager_v1.
+func (i *ExtSessionLockManager) Interface() *client.Interface {
+	return ExtSessionLockManagerInterface
+}
+
 // Destroy : destroy the session lock manager object
 //
 // This informs the compositor that the session lock manager object will
@@ -65,6 +94,7 @@ func (i *ExtSessionLockManager) Destroy() error {
 // response to this request.
 func (i *ExtSessionLockManager) Lock() (*ExtSessionLock, error) {
 	id := NewExtSessionLock(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -143,6 +173,56 @@ func NewExtSessionLock(ctx *client.Context) *ExtSessionLock {
 	return extSessionLockV1
 }
 
+// ExtSessionLockInterface describes ext_session_lock_v1 at runtime.
+var ExtSessionLockInterface = &client.Interface{
+	Name:    "ext_session_lock_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &ExtSessionLock{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "get_lock_surface",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "ext_session_lock_surface_v1"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+				{Name: "output", Type: "object", Interface: "wl_output"},
+			},
+		},
+		{
+			Name:       "unlock_and_destroy",
+			Destructor: true,
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "locked",
+		},
+		{
+			Name: "finished",
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "invalid_destroy", Value: 0},
+				{Name: "invalid_unlock", Value: 1},
+				{Name: "role", Value: 2},
+				{Name: "duplicate_output", Value: 3},
+				{Name: "already_constructed", Value: 4},
+			},
+		},
+	},
+}
+
+// Interface returns the description of ext_session_lock_v1.
+func (i *ExtSessionLock) Interface() *client.Interface {
+	return ExtSessionLockInterface
+}
+
 // Destroy : destroy the session lock
 //
 // This informs the compositor that the lock object will no longer be
@@ -183,6 +263,7 @@ func (i *ExtSessionLock) Destroy() error {
 // is a duplicate_output protocol error.
 func (i *ExtSessionLock) GetLockSurface(surface *client.Surface, output *client.Output) (*ExtSessionLockSurface, error) {
 	id := NewExtSessionLockSurface(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -313,6 +394,16 @@ func (i *ExtSessionLock) SetLockedHandler(f ExtSessionLockLockedHandlerFunc) {
 	i.lockedHandler = f
 }
 
+// String formats the event as ext_session_lock_v1.locked(argument=value, ...).
+func (e ExtSessionLockLockedEvent) String() string {
+	return "ext_session_lock_v1.locked()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e ExtSessionLockLockedEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // ExtSessionLockFinishedEvent : the session lock object should be destroyed
 //
 // The compositor has decided that the session lock should be
@@ -341,6 +432,45 @@ func (i *ExtSessionLock) SetFinishedHandler(f ExtSessionLockFinishedHandlerFunc)
 	i.finishedHandler = f
 }
 
+// String formats the event as ext_session_lock_v1.finished(argument=value, ...).
+func (e ExtSessionLockFinishedEvent) String() string {
+	return "ext_session_lock_v1.finished()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e ExtSessionLockFinishedEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
+// ExtSessionLockListener receives all the events of ExtSessionLock, embed
+// ExtSessionLockNoopListener to implement only some of them.
+type ExtSessionLockListener interface {
+	Locked(e ExtSessionLockLockedEvent)
+	Finished(e ExtSessionLockFinishedEvent)
+}
+
+// ExtSessionLockNoopListener is a ExtSessionLockListener ignoring
+// all the events, it closes the file descriptors they carry.
+type ExtSessionLockNoopListener struct{}
+
+func (ExtSessionLockNoopListener) Locked(ExtSessionLockLockedEvent) {
+}
+
+func (ExtSessionLockNoopListener) Finished(ExtSessionLockFinishedEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *ExtSessionLock) SetListener(l ExtSessionLockListener) {
+	if l == nil {
+		i.lockedHandler = nil
+		i.finishedHandler = nil
+		return
+	}
+	i.lockedHandler = l.Locked
+	i.finishedHandler = l.Finished
+}
+
 func (i *ExtSessionLock) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -405,6 +535,51 @@ func NewExtSessionLockSurface(ctx *client.Context) *ExtSessionLockSurface {
 	return extSessionLockSurfaceV1
 }
 
+// ExtSessionLockSurfaceInterface describes ext_session_lock_surface_v1 at runtime.
+var ExtSessionLockSurfaceInterface = &client.Interface{
+	Name:    "ext_session_lock_surface_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &ExtSessionLockSurface{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "ack_configure",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+			},
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "configure",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "width", Type: "uint"},
+				{Name: "height", Type: "uint"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "commit_before_first_ack", Value: 0},
+				{Name: "null_buffer", Value: 1},
+				{Name: "dimensions_mismatch", Value: 2},
+				{Name: "invalid_serial", Value: 3},
+			},
+		},
+	},
+}
+
+// Interface returns the description of ext_session_lock_surface_v1.
+func (i *ExtSessionLockSurface) Interface() *client.Interface {
+	return ExtSessionLockSurfaceInterface
+}
+
 // Destroy : destroy the lock surface object
 //
 // This informs the compositor that the lock surface object will no
@@ -541,6 +716,47 @@ func (i *ExtSessionLockSurface) SetConfigureHandler(f ExtSessionLockSurfaceConfi
 	i.configureHandler = f
 }
 
+// String formats the event as ext_session_lock_surface_v1.configure(argument=value, ...).
+func (e ExtSessionLockSurfaceConfigureEvent) String() string {
+	return "ext_session_lock_surface_v1.configure(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", width=" + strconv.FormatUint(uint64(e.Width), 10) +
+		", height=" + strconv.FormatUint(uint64(e.Height), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e ExtSessionLockSurfaceConfigureEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.Uint64("width", uint64(e.Width)),
+		slog.Uint64("height", uint64(e.Height)),
+	)
+}
+
+// ExtSessionLockSurfaceListener receives all the events of ExtSessionLockSurface, embed
+// ExtSessionLockSurfaceNoopListener to implement only some of them.
+type ExtSessionLockSurfaceListener interface {
+	Configure(e ExtSessionLockSurfaceConfigureEvent)
+}
+
+// ExtSessionLockSurfaceNoopListener is a ExtSessionLockSurfaceListener ignoring
+// all the events, it closes the file descriptors they carry.
+type ExtSessionLockSurfaceNoopListener struct{}
+
+func (ExtSessionLockSurfaceNoopListener) Configure(ExtSessionLockSurfaceConfigureEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *ExtSessionLockSurface) SetListener(l ExtSessionLockSurfaceListener) {
+	if l == nil {
+		i.configureHandler = nil
+		return
+	}
+	i.configureHandler = l.Configure
+}
+
 func (i *ExtSessionLockSurface) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -559,3 +775,9 @@ func (i *ExtSessionLockSurface) Dispatch(opcode uint32, fd int, data []byte) {
 		i.configureHandler(e)
 	}
 }
+
+func init() {
+	client.RegisterInterface(ExtSessionLockManagerInterface)
+	client.RegisterInterface(ExtSessionLockInterface)
+	client.RegisterInterface(ExtSessionLockSurfaceInterface)
+}
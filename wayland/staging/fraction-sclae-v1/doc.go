@@ -1,3 +1 @@
 package fractional_scale
-
-//go:generate go run github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner -pkg fractional_scale -prefix wp -suffix v1 -o fractional_scale.go -i https://raw.githubusercontent.com/wayland-project/wayland-protocols/1.31/staging/fractional-scale/fractional-scale-v1.xml
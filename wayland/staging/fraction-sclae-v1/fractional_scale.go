@@ -27,7 +27,12 @@
 
 package fractional_scale
 
-import "github.com/rajveermalviya/go-wayland/wayland/client"
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+)
 
 // FractionalScaleManager : fractional surface scale information
 //
@@ -45,6 +50,39 @@ func NewFractionalScaleManager(ctx *client.Context) *FractionalScaleManager {
 	return wpFractionalScaleManagerV1
 }
 
+// FractionalScaleManagerInterface describes wp_fractional_scale_manager_v1 at runtime.
+var FractionalScaleManagerInterface = &client.Interface{
+	Name:    "wp_fractional_scale_manager_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &FractionalScaleManager{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "get_fractional_scale",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "wp_fractional_scale_v1"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "fractional_scale_exists", Value: 0},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wp_fractional_scale_manager_v1.
+func (i *FractionalScaleManager) Interface() *client.Interface {
+	return FractionalScaleManagerInterface
+}
+
 // Destroy : unbind the fractional surface scale interface
 //
 // Informs the server that the client will not be using this protocol
@@ -74,6 +112,7 @@ func (i *FractionalScaleManager) Destroy() error {
 //	surface: the surface
 func (i *FractionalScaleManager) GetFractionalScale(surface *client.Surface) (*FractionalScale, error) {
 	id := NewFractionalScale(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -139,6 +178,32 @@ func NewFractionalScale(ctx *client.Context) *FractionalScale {
 	return wpFractionalScaleV1
 }
 
+// FractionalScaleInterface describes wp_fractional_scale_v1 at runtime.
+var FractionalScaleInterface = &client.Interface{
+	Name:    "wp_fractional_scale_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &FractionalScale{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "preferred_scale",
+			Args: []client.Arg{
+				{Name: "scale", Type: "uint"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wp_fractional_scale_v1.
+func (i *FractionalScale) Interface() *client.Interface {
+	return FractionalScaleInterface
+}
+
 // Destroy : remove surface scale information for surface
 //
 // Destroy the fractional scale object. When this object is destroyed,
@@ -173,6 +238,43 @@ func (i *FractionalScale) SetPreferredScaleHandler(f FractionalScalePreferredSca
 	i.preferredScaleHandler = f
 }
 
+// String formats the event as wp_fractional_scale_v1.preferred_scale(argument=value, ...).
+func (e FractionalScalePreferredScaleEvent) String() string {
+	return "wp_fractional_scale_v1.preferred_scale(" +
+		"scale=" + strconv.FormatUint(uint64(e.Scale), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e FractionalScalePreferredScaleEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("scale", uint64(e.Scale)),
+	)
+}
+
+// FractionalScaleListener receives all the events of FractionalScale, embed
+// FractionalScaleNoopListener to implement only some of them.
+type FractionalScaleListener interface {
+	PreferredScale(e FractionalScalePreferredScaleEvent)
+}
+
+// FractionalScaleNoopListener is a FractionalScaleListener ignoring
+// all the events, it closes the file descriptors they carry.
+type FractionalScaleNoopListener struct{}
+
+func (FractionalScaleNoopListener) PreferredScale(FractionalScalePreferredScaleEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *FractionalScale) SetListener(l FractionalScaleListener) {
+	if l == nil {
+		i.preferredScaleHandler = nil
+		return
+	}
+	i.preferredScaleHandler = l.PreferredScale
+}
+
 func (i *FractionalScale) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -187,3 +289,8 @@ func (i *FractionalScale) Dispatch(opcode uint32, fd int, data []byte) {
 		i.preferredScaleHandler(e)
 	}
 }
+
+func init() {
+	client.RegisterInterface(FractionalScaleManagerInterface)
+	client.RegisterInterface(FractionalScaleInterface)
+}
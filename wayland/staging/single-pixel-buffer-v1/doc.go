@@ -1,3 +1 @@
 package single_pixel_buffer
-
-//go:generate go run github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner -pkg single_pixel_buffer -suffix v1 -o single_pixel_buffer.go -i https://raw.githubusercontent.com/wayland-project/wayland-protocols/1.31/staging/single-pixel-buffer/single-pixel-buffer-v1.xml
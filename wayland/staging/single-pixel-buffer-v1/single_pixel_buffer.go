@@ -47,6 +47,34 @@ func NewWpSinglePixelBufferManager(ctx *client.Context) *WpSinglePixelBufferMana
 	return wpSinglePixelBufferManagerV1
 }
 
+// WpSinglePixelBufferManagerInterface describes wp_single_pixel_buffer_manager_v1 at runtime.
+var WpSinglePixelBufferManagerInterface = &client.Interface{
+	Name:    "wp_single_pixel_buffer_manager_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &WpSinglePixelBufferManager{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "create_u32_rgba_buffer",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "wl_buffer"},
+				{Name: "r", Type: "uint"},
+				{Name: "g", Type: "uint"},
+				{Name: "b", Type: "uint"},
+				{Name: "a", Type: "uint"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wp_single_pixel_buffer_manager_v1.
+func (i *WpSinglePixelBufferManager) Interface() *client.Interface {
+	return WpSinglePixelBufferManagerInterface
+}
+
 // Destroy : destroy the manager
 //
 // Destroy the wp_single_pixel_buffer_manager_v1 object.
@@ -81,6 +109,7 @@ func (i *WpSinglePixelBufferManager) Destroy() error {
 //	a: value of the buffer's alpha channel
 func (i *WpSinglePixelBufferManager) CreateU32RgbaBuffer(r, g, b, a uint32) (*client.Buffer, error) {
 	id := client.NewBuffer(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4 + 4 + 4 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -102,3 +131,7 @@ func (i *WpSinglePixelBufferManager) CreateU32RgbaBuffer(r, g, b, a uint32) (*cl
 	err := i.Context().WriteMsg(_reqBuf[:], nil)
 	return id, err
 }
+
+func init() {
+	client.RegisterInterface(WpSinglePixelBufferManagerInterface)
+}
@@ -1,3 +1 @@
 package tearing_control
-
-//go:generate go run github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner -pkg tearing_control -prefix wp -suffix v1 -o tearing_control.go -i https://raw.githubusercontent.com/wayland-project/wayland-protocols/1.31/staging/tearing-control/tearing-control-v1.xml
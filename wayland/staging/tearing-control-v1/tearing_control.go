@@ -73,6 +73,39 @@ func NewTearingControlManager(ctx *client.Context) *TearingControlManager {
 	return wpTearingControlManagerV1
 }
 
+// TearingControlManagerInterface describes wp_tearing_control_manager_v1 at runtime.
+var TearingControlManagerInterface = &client.Interface{
+	Name:    "wp_tearing_control_manager_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &TearingControlManager{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "get_tearing_control",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "wp_tearing_control_v1"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "tearing_control_exists", Value: 0},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wp_tearing_control_manager_v1.
+func (i *TearingControlManager) Interface() *client.Interface {
+	return TearingControlManagerInterface
+}
+
 // Destroy : destroy tearing control factory object
 //
 // Destroy this tearing control factory object. Other objects, including
@@ -101,6 +134,7 @@ func (i *TearingControlManager) Destroy() error {
 // associated, the tearing_control_exists protocol error is raised.
 func (i *TearingControlManager) GetTearingControl(surface *client.Surface) (*TearingControl, error) {
 	id := NewTearingControl(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -171,6 +205,39 @@ func NewTearingControl(ctx *client.Context) *TearingControl {
 	return wpTearingControlV1
 }
 
+// TearingControlInterface describes wp_tearing_control_v1 at runtime.
+var TearingControlInterface = &client.Interface{
+	Name:    "wp_tearing_control_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &TearingControl{} },
+	Requests: []client.Message{
+		{
+			Name: "set_presentation_hint",
+			Args: []client.Arg{
+				{Name: "hint", Type: "uint", Enum: "wp_tearing_control_v1.presentation_hint"},
+			},
+		},
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "presentation_hint",
+			Entries: []client.EnumEntry{
+				{Name: "vsync", Value: 0},
+				{Name: "async", Value: 1},
+			},
+		},
+	},
+}
+
+// Interface returns the description of wp_tearing_control_v1.
+func (i *TearingControl) Interface() *client.Interface {
+	return TearingControlInterface
+}
+
 // SetPresentationHint : set presentation hint
 //
 // Set the presentation hint for the associated wl_surface. This state is
@@ -179,7 +246,7 @@ func NewTearingControl(ctx *client.Context) *TearingControl {
 // The compositor is free to dynamically respect or ignore this hint based
 // on various conditions like hardware capabilities, surface state and
 // user preferences.
-func (i *TearingControl) SetPresentationHint(hint uint32) error {
+func (i *TearingControl) SetPresentationHint(hint TearingControlPresentationHint) error {
 	const opcode = 0
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -248,3 +315,8 @@ func (e TearingControlPresentationHint) Value() string {
 func (e TearingControlPresentationHint) String() string {
 	return e.Name() + "=" + e.Value()
 }
+
+func init() {
+	client.RegisterInterface(TearingControlManagerInterface)
+	client.RegisterInterface(TearingControlInterface)
+}
@@ -1,3 +1 @@
 package xdg_activation
-
-//go:generate go run github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner -pkg xdg_activation -prefix xdg -suffix v1 -o xdg_activation.go -i https://raw.githubusercontent.com/wayland-project/wayland-protocols/1.31/staging/xdg-activation/xdg-activation-v1.xml
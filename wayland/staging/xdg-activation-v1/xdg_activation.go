@@ -28,7 +28,12 @@
 
 package xdg_activation
 
-import "github.com/rajveermalviya/go-wayland/wayland/client"
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+)
 
 // Activation : interface for activating surfaces
 //
@@ -50,6 +55,37 @@ func NewActivation(ctx *client.Context) *Activation {
 	return xdgActivationV1
 }
 
+// ActivationInterface describes xdg_activation_v1 at runtime.
+var ActivationInterface = &client.Interface{
+	Name:    "xdg_activation_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &Activation{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "get_activation_token",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "xdg_activation_token_v1"},
+			},
+		},
+		{
+			Name: "activate",
+			Args: []client.Arg{
+				{Name: "token", Type: "string"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of xdg_activation_v1.
+func (i *Activation) Interface() *client.Interface {
+	return ActivationInterface
+}
+
 // Destroy : destroy the xdg_activation object
 //
 // Notify the compositor that the xdg_activation object will no longer be
@@ -78,6 +114,7 @@ func (i *Activation) Destroy() error {
 // token should be offered to the clients to be activated.
 func (i *Activation) GetActivationToken() (*ActivationToken, error) {
 	id := NewActivationToken(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -117,7 +154,7 @@ func (i *Activation) Activate(token string, surface *client.Surface) error {
 	l += 4
 	client.PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
 	l += 4
-	client.PutString(_reqBuf[l:l+(4+tokenLen)], token, tokenLen)
+	client.PutString(_reqBuf[l:l+(4+tokenLen)], token)
 	l += (4 + tokenLen)
 	client.PutUint32(_reqBuf[l:l+4], surface.ID())
 	l += 4
@@ -156,6 +193,62 @@ func NewActivationToken(ctx *client.Context) *ActivationToken {
 	return xdgActivationTokenV1
 }
 
+// ActivationTokenInterface describes xdg_activation_token_v1 at runtime.
+var ActivationTokenInterface = &client.Interface{
+	Name:    "xdg_activation_token_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &ActivationToken{} },
+	Requests: []client.Message{
+		{
+			Name: "set_serial",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "seat", Type: "object", Interface: "wl_seat"},
+			},
+		},
+		{
+			Name: "set_app_id",
+			Args: []client.Arg{
+				{Name: "app_id", Type: "string"},
+			},
+		},
+		{
+			Name: "set_surface",
+			Args: []client.Arg{
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+			},
+		},
+		{
+			Name: "commit",
+		},
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "done",
+			Args: []client.Arg{
+				{Name: "token", Type: "string"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "already_used", Value: 0},
+			},
+		},
+	},
+}
+
+// Interface returns the description of xdg_activation_token_v1.
+func (i *ActivationToken) Interface() *client.Interface {
+	return ActivationTokenInterface
+}
+
 // SetSerial : specifies the seat and serial of the activating event
 //
 // Provides information about the seat and serial event that requested the
@@ -208,7 +301,7 @@ func (i *ActivationToken) SetAppId(appId string) error {
 	l += 4
 	client.PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
 	l += 4
-	client.PutString(_reqBuf[l:l+(4+appIdLen)], appId, appIdLen)
+	client.PutString(_reqBuf[l:l+(4+appIdLen)], appId)
 	l += (4 + appIdLen)
 	err := i.Context().WriteMsg(_reqBuf, nil)
 	return err
@@ -319,6 +412,43 @@ func (i *ActivationToken) SetDoneHandler(f ActivationTokenDoneHandlerFunc) {
 	i.doneHandler = f
 }
 
+// String formats the event as xdg_activation_token_v1.done(argument=value, ...).
+func (e ActivationTokenDoneEvent) String() string {
+	return "xdg_activation_token_v1.done(" +
+		"token=" + strconv.Quote(e.Token) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e ActivationTokenDoneEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("token", e.Token),
+	)
+}
+
+// ActivationTokenListener receives all the events of ActivationToken, embed
+// ActivationTokenNoopListener to implement only some of them.
+type ActivationTokenListener interface {
+	Done(e ActivationTokenDoneEvent)
+}
+
+// ActivationTokenNoopListener is a ActivationTokenListener ignoring
+// all the events, it closes the file descriptors they carry.
+type ActivationTokenNoopListener struct{}
+
+func (ActivationTokenNoopListener) Done(ActivationTokenDoneEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *ActivationToken) SetListener(l ActivationTokenListener) {
+	if l == nil {
+		i.doneHandler = nil
+		return
+	}
+	i.doneHandler = l.Done
+}
+
 func (i *ActivationToken) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -335,3 +465,8 @@ func (i *ActivationToken) Dispatch(opcode uint32, fd int, data []byte) {
 		i.doneHandler(e)
 	}
 }
+
+func init() {
+	client.RegisterInterface(ActivationInterface)
+	client.RegisterInterface(ActivationTokenInterface)
+}
@@ -1,3 +1 @@
 package xwayland_shell
-
-//go:generate go run github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner -pkg xwayland_shell -suffix v1 -o xwayland_shell.go -i https://raw.githubusercontent.com/wayland-project/wayland-protocols/1.31/staging/xwayland-shell/xwayland-shell-v1.xml
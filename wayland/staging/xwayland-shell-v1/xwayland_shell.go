@@ -73,6 +73,39 @@ func NewXwaylandShell(ctx *client.Context) *XwaylandShell {
 	return xwaylandShellV1
 }
 
+// XwaylandShellInterface describes xwayland_shell_v1 at runtime.
+var XwaylandShellInterface = &client.Interface{
+	Name:    "xwayland_shell_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &XwaylandShell{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "get_xwayland_surface",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "xwayland_surface_v1"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "role", Value: 0},
+			},
+		},
+	},
+}
+
+// Interface returns the description of xwayland_shell_v1.
+func (i *XwaylandShell) Interface() *client.Interface {
+	return XwaylandShellInterface
+}
+
 // Destroy : destroy the Xwayland shell object
 //
 // Destroy the xwayland_shell_v1 object.
@@ -105,6 +138,7 @@ func (i *XwaylandShell) Destroy() error {
 // about what an xwayland_surface_v1 is and how it is used.
 func (i *XwaylandShell) GetXwaylandSurface(surface *client.Surface) (*XwaylandSurface, error) {
 	id := NewXwaylandSurface(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -181,6 +215,40 @@ func NewXwaylandSurface(ctx *client.Context) *XwaylandSurface {
 	return xwaylandSurfaceV1
 }
 
+// XwaylandSurfaceInterface describes xwayland_surface_v1 at runtime.
+var XwaylandSurfaceInterface = &client.Interface{
+	Name:    "xwayland_surface_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &XwaylandSurface{} },
+	Requests: []client.Message{
+		{
+			Name: "set_serial",
+			Args: []client.Arg{
+				{Name: "serial_lo", Type: "uint"},
+				{Name: "serial_hi", Type: "uint"},
+			},
+		},
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "already_associated", Value: 0},
+				{Name: "invalid_serial", Value: 1},
+			},
+		},
+	},
+}
+
+// Interface returns the description of xwayland_surface_v1.
+func (i *XwaylandSurface) Interface() *client.Interface {
+	return XwaylandSurfaceInterface
+}
+
 // SetSerial : associates a Xwayland window to a wl_surface
 //
 // Associates an Xwayland window to a wl_surface.
@@ -279,3 +347,8 @@ func (e XwaylandSurfaceError) Value() string {
 func (e XwaylandSurfaceError) String() string {
 	return e.Name() + "=" + e.Value()
 }
+
+func init() {
+	client.RegisterInterface(XwaylandShellInterface)
+	client.RegisterInterface(XwaylandSurfaceInterface)
+}
@@ -1,3 +1 @@
 package fullscreen_shell
-
-//go:generate go run github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner -pkg fullscreen_shell -prefix zwp -suffix v1 -o fullscreen_shell.go -i https://raw.githubusercontent.com/wayland-project/wayland-protocols/1.31/unstable/fullscreen-shell/fullscreen-shell-unstable-v1.xml
@@ -29,7 +29,11 @@
 
 package fullscreen_shell
 
-import "github.com/rajveermalviya/go-wayland/wayland/client"
+import (
+	"log/slog"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+)
 
 // FullscreenShell : displays a single surface per output
 //
@@ -112,6 +116,75 @@ func NewFullscreenShell(ctx *client.Context) *FullscreenShell {
 	return zwpFullscreenShellV1
 }
 
+// FullscreenShellInterface describes zwp_fullscreen_shell_v1 at runtime.
+var FullscreenShellInterface = &client.Interface{
+	Name:    "zwp_fullscreen_shell_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &FullscreenShell{} },
+	Requests: []client.Message{
+		{
+			Name:       "release",
+			Destructor: true,
+		},
+		{
+			Name: "present_surface",
+			Args: []client.Arg{
+				{Name: "surface", Type: "object", Interface: "wl_surface", Nullable: true},
+				{Name: "method", Type: "uint", Enum: "zwp_fullscreen_shell_v1.present_method"},
+				{Name: "output", Type: "object", Interface: "wl_output", Nullable: true},
+			},
+		},
+		{
+			Name: "present_surface_for_mode",
+			Args: []client.Arg{
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+				{Name: "output", Type: "object", Interface: "wl_output"},
+				{Name: "framerate", Type: "int"},
+				{Name: "feedback", Type: "new_id", Interface: "zwp_fullscreen_shell_mode_feedback_v1"},
+			},
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "capability",
+			Args: []client.Arg{
+				{Name: "capability", Type: "uint", Enum: "zwp_fullscreen_shell_v1.capability"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "capability",
+			Entries: []client.EnumEntry{
+				{Name: "arbitrary_modes", Value: 1},
+				{Name: "cursor_plane", Value: 2},
+			},
+		},
+		{
+			Name: "present_method",
+			Entries: []client.EnumEntry{
+				{Name: "default", Value: 0},
+				{Name: "center", Value: 1},
+				{Name: "zoom", Value: 2},
+				{Name: "zoom_crop", Value: 3},
+				{Name: "stretch", Value: 4},
+			},
+		},
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "invalid_method", Value: 0},
+				{Name: "role", Value: 1},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_fullscreen_shell_v1.
+func (i *FullscreenShell) Interface() *client.Interface {
+	return FullscreenShellInterface
+}
+
 // Release : release the wl_fullscreen_shell interface
 //
 // Release the binding from the wl_fullscreen_shell interface.
@@ -156,7 +229,7 @@ func (i *FullscreenShell) Release() error {
 // This request gives the surface the role of a fullscreen shell surface.
 // If the surface already has another role, it raises a role protocol
 // error.
-func (i *FullscreenShell) PresentSurface(surface *client.Surface, method uint32, output *client.Output) error {
+func (i *FullscreenShell) PresentSurface(surface *client.Surface, method FullscreenShellPresentMethod, output *client.Output) error {
 	const opcode = 1
 	const _reqBufLen = 8 + 4 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -230,6 +303,7 @@ func (i *FullscreenShell) PresentSurface(surface *client.Surface, method uint32,
 // error.
 func (i *FullscreenShell) PresentSurfaceForMode(surface *client.Surface, output *client.Output, framerate int32) (*FullscreenShellModeFeedback, error) {
 	feedback := NewFullscreenShellModeFeedback(i.Context())
+	feedback.SetVersion(i.Version())
 	const opcode = 2
 	const _reqBufLen = 8 + 4 + 4 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -250,6 +324,12 @@ func (i *FullscreenShell) PresentSurfaceForMode(surface *client.Surface, output
 	return feedback, err
 }
 
+// Destroy sends release unless the version of the object is known to
+// predate it, in which case it only forgets the object.
+func (i *FullscreenShell) Destroy() error {
+	return i.Release()
+}
+
 type FullscreenShellCapability uint32
 
 // FullscreenShellCapability : capabilities advertised by the compositor
@@ -415,7 +495,7 @@ func (e FullscreenShellError) String() string {
 // wl_display.sync request immediately after binding to ensure that they
 // receive all the capability events.
 type FullscreenShellCapabilityEvent struct {
-	Capability uint32
+	Capability FullscreenShellCapability
 }
 type FullscreenShellCapabilityHandlerFunc func(FullscreenShellCapabilityEvent)
 
@@ -424,6 +504,43 @@ func (i *FullscreenShell) SetCapabilityHandler(f FullscreenShellCapabilityHandle
 	i.capabilityHandler = f
 }
 
+// String formats the event as zwp_fullscreen_shell_v1.capability(argument=value, ...).
+func (e FullscreenShellCapabilityEvent) String() string {
+	return "zwp_fullscreen_shell_v1.capability(" +
+		"capability=" + client.EnumString(e.Capability.Name(), uint32(e.Capability)) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e FullscreenShellCapabilityEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("capability", client.EnumString(e.Capability.Name(), uint32(e.Capability))),
+	)
+}
+
+// FullscreenShellListener receives all the events of FullscreenShell, embed
+// FullscreenShellNoopListener to implement only some of them.
+type FullscreenShellListener interface {
+	Capability(e FullscreenShellCapabilityEvent)
+}
+
+// FullscreenShellNoopListener is a FullscreenShellListener ignoring
+// all the events, it closes the file descriptors they carry.
+type FullscreenShellNoopListener struct{}
+
+func (FullscreenShellNoopListener) Capability(FullscreenShellCapabilityEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *FullscreenShell) SetListener(l FullscreenShellListener) {
+	if l == nil {
+		i.capabilityHandler = nil
+		return
+	}
+	i.capabilityHandler = l.Capability
+}
+
 func (i *FullscreenShell) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -432,7 +549,7 @@ func (i *FullscreenShell) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e FullscreenShellCapabilityEvent
 		l := 0
-		e.Capability = client.Uint32(data[l : l+4])
+		e.Capability = FullscreenShellCapability(client.Uint32(data[l : l+4]))
 		l += 4
 
 		i.capabilityHandler(e)
@@ -454,6 +571,29 @@ func NewFullscreenShellModeFeedback(ctx *client.Context) *FullscreenShellModeFee
 	return zwpFullscreenShellModeFeedbackV1
 }
 
+// FullscreenShellModeFeedbackInterface describes zwp_fullscreen_shell_mode_feedback_v1 at runtime.
+var FullscreenShellModeFeedbackInterface = &client.Interface{
+	Name:    "zwp_fullscreen_shell_mode_feedback_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &FullscreenShellModeFeedback{} },
+	Events: []client.Message{
+		{
+			Name: "mode_successful",
+		},
+		{
+			Name: "mode_failed",
+		},
+		{
+			Name: "present_cancelled",
+		},
+	},
+}
+
+// Interface returns the description of zwp_fullscreen_shell_mode_feedback_v1.
+func (i *FullscreenShellModeFeedback) Interface() *client.Interface {
+	return FullscreenShellModeFeedbackInterface
+}
+
 func (i *FullscreenShellModeFeedback) Destroy() error {
 	i.Context().Unregister(i)
 	return nil
@@ -475,6 +615,16 @@ func (i *FullscreenShellModeFeedback) SetModeSuccessfulHandler(f FullscreenShell
 	i.modeSuccessfulHandler = f
 }
 
+// String formats the event as zwp_fullscreen_shell_mode_feedback_v1.mode_successful(argument=value, ...).
+func (e FullscreenShellModeFeedbackModeSuccessfulEvent) String() string {
+	return "zwp_fullscreen_shell_mode_feedback_v1.mode_successful()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e FullscreenShellModeFeedbackModeSuccessfulEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // FullscreenShellModeFeedbackModeFailedEvent : mode switch failed
 //
 // This event indicates that the attempted mode switch operation
@@ -491,6 +641,16 @@ func (i *FullscreenShellModeFeedback) SetModeFailedHandler(f FullscreenShellMode
 	i.modeFailedHandler = f
 }
 
+// String formats the event as zwp_fullscreen_shell_mode_feedback_v1.mode_failed(argument=value, ...).
+func (e FullscreenShellModeFeedbackModeFailedEvent) String() string {
+	return "zwp_fullscreen_shell_mode_feedback_v1.mode_failed()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e FullscreenShellModeFeedbackModeFailedEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // FullscreenShellModeFeedbackPresentCancelledEvent : mode switch cancelled
 //
 // This event indicates that the attempted mode switch operation was
@@ -507,6 +667,51 @@ func (i *FullscreenShellModeFeedback) SetPresentCancelledHandler(f FullscreenShe
 	i.presentCancelledHandler = f
 }
 
+// String formats the event as zwp_fullscreen_shell_mode_feedback_v1.present_cancelled(argument=value, ...).
+func (e FullscreenShellModeFeedbackPresentCancelledEvent) String() string {
+	return "zwp_fullscreen_shell_mode_feedback_v1.present_cancelled()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e FullscreenShellModeFeedbackPresentCancelledEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
+// FullscreenShellModeFeedbackListener receives all the events of FullscreenShellModeFeedback, embed
+// FullscreenShellModeFeedbackNoopListener to implement only some of them.
+type FullscreenShellModeFeedbackListener interface {
+	ModeSuccessful(e FullscreenShellModeFeedbackModeSuccessfulEvent)
+	ModeFailed(e FullscreenShellModeFeedbackModeFailedEvent)
+	PresentCancelled(e FullscreenShellModeFeedbackPresentCancelledEvent)
+}
+
+// FullscreenShellModeFeedbackNoopListener is a FullscreenShellModeFeedbackListener ignoring
+// all the events, it closes the file descriptors they carry.
+type FullscreenShellModeFeedbackNoopListener struct{}
+
+func (FullscreenShellModeFeedbackNoopListener) ModeSuccessful(FullscreenShellModeFeedbackModeSuccessfulEvent) {
+}
+
+func (FullscreenShellModeFeedbackNoopListener) ModeFailed(FullscreenShellModeFeedbackModeFailedEvent) {
+}
+
+func (FullscreenShellModeFeedbackNoopListener) PresentCancelled(FullscreenShellModeFeedbackPresentCancelledEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *FullscreenShellModeFeedback) SetListener(l FullscreenShellModeFeedbackListener) {
+	if l == nil {
+		i.modeSuccessfulHandler = nil
+		i.modeFailedHandler = nil
+		i.presentCancelledHandler = nil
+		return
+	}
+	i.modeSuccessfulHandler = l.ModeSuccessful
+	i.modeFailedHandler = l.ModeFailed
+	i.presentCancelledHandler = l.PresentCancelled
+}
+
 func (i *FullscreenShellModeFeedback) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -532,3 +737,8 @@ func (i *FullscreenShellModeFeedback) Dispatch(opcode uint32, fd int, data []byt
 		i.presentCancelledHandler(e)
 	}
 }
+
+func init() {
+	client.RegisterInterface(FullscreenShellInterface)
+	client.RegisterInterface(FullscreenShellModeFeedbackInterface)
+}
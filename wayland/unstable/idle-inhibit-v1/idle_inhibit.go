@@ -67,6 +67,31 @@ func NewIdleInhibitManager(ctx *client.Context) *IdleInhibitManager {
 	return zwpIdleInhibitManagerV1
 }
 
+// IdleInhibitManagerInterface describes zwp_idle_inhibit_manager_v1 at runtime.
+var IdleInhibitManagerInterface = &client.Interface{
+	Name:    "zwp_idle_inhibit_manager_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &IdleInhibitManager{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "create_inhibitor",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zwp_idle_inhibitor_v1"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_idle_inhibit_manager_v1.
+func (i *IdleInhibitManager) Interface() *client.Interface {
+	return IdleInhibitManagerInterface
+}
+
 // Destroy : destroy the idle inhibitor object
 //
 // Destroy the inhibit manager.
@@ -91,6 +116,7 @@ func (i *IdleInhibitManager) Destroy() error {
 //	surface: the surface that inhibits the idle behavior
 func (i *IdleInhibitManager) CreateInhibitor(surface *client.Surface) (*IdleInhibitor, error) {
 	id := NewIdleInhibitor(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -145,6 +171,24 @@ func NewIdleInhibitor(ctx *client.Context) *IdleInhibitor {
 	return zwpIdleInhibitorV1
 }
 
+// IdleInhibitorInterface describes zwp_idle_inhibitor_v1 at runtime.
+var IdleInhibitorInterface = &client.Interface{
+	Name:    "zwp_idle_inhibitor_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &IdleInhibitor{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+}
+
+// Interface returns the description of zwp_idle_inhibitor_v1.
+func (i *IdleInhibitor) Interface() *client.Interface {
+	return IdleInhibitorInterface
+}
+
 // Destroy : destroy the idle inhibitor object
 //
 // Remove the inhibitor effect from the associated wl_surface.
@@ -161,3 +205,8 @@ func (i *IdleInhibitor) Destroy() error {
 	err := i.Context().WriteMsg(_reqBuf[:], nil)
 	return err
 }
+
+func init() {
+	client.RegisterInterface(IdleInhibitManagerInterface)
+	client.RegisterInterface(IdleInhibitorInterface)
+}
@@ -27,7 +27,12 @@
 
 package input_method
 
-import "github.com/rajveermalviya/go-wayland/wayland/client"
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+)
 
 // InputMethodContext : input method context
 //
@@ -95,6 +100,161 @@ func NewInputMethodContext(ctx *client.Context) *InputMethodContext {
 	return zwpInputMethodContextV1
 }
 
+// InputMethodContextInterface describes zwp_input_method_context_v1 at runtime.
+var InputMethodContextInterface = &client.Interface{
+	Name:    "zwp_input_method_context_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &InputMethodContext{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "commit_string",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "text", Type: "string"},
+			},
+		},
+		{
+			Name: "preedit_string",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "text", Type: "string"},
+				{Name: "commit", Type: "string"},
+			},
+		},
+		{
+			Name: "preedit_styling",
+			Args: []client.Arg{
+				{Name: "index", Type: "uint"},
+				{Name: "length", Type: "uint"},
+				{Name: "style", Type: "uint"},
+			},
+		},
+		{
+			Name: "preedit_cursor",
+			Args: []client.Arg{
+				{Name: "index", Type: "int"},
+			},
+		},
+		{
+			Name: "delete_surrounding_text",
+			Args: []client.Arg{
+				{Name: "index", Type: "int"},
+				{Name: "length", Type: "uint"},
+			},
+		},
+		{
+			Name: "cursor_position",
+			Args: []client.Arg{
+				{Name: "index", Type: "int"},
+				{Name: "anchor", Type: "int"},
+			},
+		},
+		{
+			Name: "modifiers_map",
+			Args: []client.Arg{
+				{Name: "map", Type: "array"},
+			},
+		},
+		{
+			Name: "keysym",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "time", Type: "uint"},
+				{Name: "sym", Type: "uint"},
+				{Name: "state", Type: "uint"},
+				{Name: "modifiers", Type: "uint"},
+			},
+		},
+		{
+			Name: "grab_keyboard",
+			Args: []client.Arg{
+				{Name: "keyboard", Type: "new_id", Interface: "wl_keyboard"},
+			},
+		},
+		{
+			Name: "key",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "time", Type: "uint"},
+				{Name: "key", Type: "uint"},
+				{Name: "state", Type: "uint"},
+			},
+		},
+		{
+			Name: "modifiers",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "mods_depressed", Type: "uint"},
+				{Name: "mods_latched", Type: "uint"},
+				{Name: "mods_locked", Type: "uint"},
+				{Name: "group", Type: "uint"},
+			},
+		},
+		{
+			Name: "language",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "language", Type: "string"},
+			},
+		},
+		{
+			Name: "text_direction",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "direction", Type: "uint"},
+			},
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "surrounding_text",
+			Args: []client.Arg{
+				{Name: "text", Type: "string"},
+				{Name: "cursor", Type: "uint"},
+				{Name: "anchor", Type: "uint"},
+			},
+		},
+		{
+			Name: "reset",
+		},
+		{
+			Name: "content_type",
+			Args: []client.Arg{
+				{Name: "hint", Type: "uint"},
+				{Name: "purpose", Type: "uint"},
+			},
+		},
+		{
+			Name: "invoke_action",
+			Args: []client.Arg{
+				{Name: "button", Type: "uint"},
+				{Name: "index", Type: "uint"},
+			},
+		},
+		{
+			Name: "commit_state",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+			},
+		},
+		{
+			Name: "preferred_language",
+			Args: []client.Arg{
+				{Name: "language", Type: "string"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_input_method_context_v1.
+func (i *InputMethodContext) Interface() *client.Interface {
+	return InputMethodContextInterface
+}
+
 // Destroy :
 func (i *InputMethodContext) Destroy() error {
 	defer i.Context().Unregister(i)
@@ -135,7 +295,7 @@ func (i *InputMethodContext) CommitString(serial uint32, text string) error {
 	l += 4
 	client.PutUint32(_reqBuf[l:l+4], uint32(serial))
 	l += 4
-	client.PutString(_reqBuf[l:l+(4+textLen)], text, textLen)
+	client.PutString(_reqBuf[l:l+(4+textLen)], text)
 	l += (4 + textLen)
 	err := i.Context().WriteMsg(_reqBuf, nil)
 	return err
@@ -165,9 +325,9 @@ func (i *InputMethodContext) PreeditString(serial uint32, text, commit string) e
 	l += 4
 	client.PutUint32(_reqBuf[l:l+4], uint32(serial))
 	l += 4
-	client.PutString(_reqBuf[l:l+(4+textLen)], text, textLen)
+	client.PutString(_reqBuf[l:l+(4+textLen)], text)
 	l += (4 + textLen)
-	client.PutString(_reqBuf[l:l+(4+commitLen)], commit, commitLen)
+	client.PutString(_reqBuf[l:l+(4+commitLen)], commit)
 	l += (4 + commitLen)
 	err := i.Context().WriteMsg(_reqBuf, nil)
 	return err
@@ -278,8 +438,8 @@ func (i *InputMethodContext) CursorPosition(index, anchor int32) error {
 // ModifiersMap :
 func (i *InputMethodContext) ModifiersMap(_map []byte) error {
 	const opcode = 7
-	_mapLen := len(_map)
-	_reqBufLen := 8 + _mapLen
+	_mapLen := client.PaddedLen(len(_map))
+	_reqBufLen := 8 + (4 + _mapLen)
 	_reqBuf := make([]byte, _reqBufLen)
 	l := 0
 	client.PutUint32(_reqBuf[l:4], i.ID())
@@ -287,7 +447,7 @@ func (i *InputMethodContext) ModifiersMap(_map []byte) error {
 	client.PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
 	l += 4
 	client.PutArray(_reqBuf[l:l+(4+_mapLen)], _map)
-	l += _mapLen
+	l += (4 + _mapLen)
 	err := i.Context().WriteMsg(_reqBuf, nil)
 	return err
 }
@@ -331,6 +491,7 @@ func (i *InputMethodContext) Keysym(serial, time, sym, state, modifiers uint32)
 // text like it is done for CJK languages.
 func (i *InputMethodContext) GrabKeyboard() (*client.Keyboard, error) {
 	keyboard := client.NewKeyboard(i.Context())
+	keyboard.SetVersion(i.Version())
 	const opcode = 9
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -429,7 +590,7 @@ func (i *InputMethodContext) Language(serial uint32, language string) error {
 	l += 4
 	client.PutUint32(_reqBuf[l:l+4], uint32(serial))
 	l += 4
-	client.PutString(_reqBuf[l:l+(4+languageLen)], language, languageLen)
+	client.PutString(_reqBuf[l:l+(4+languageLen)], language)
 	l += (4 + languageLen)
 	err := i.Context().WriteMsg(_reqBuf, nil)
 	return err
@@ -474,6 +635,24 @@ func (i *InputMethodContext) SetSurroundingTextHandler(f InputMethodContextSurro
 	i.surroundingTextHandler = f
 }
 
+// String formats the event as zwp_input_method_context_v1.surrounding_text(argument=value, ...).
+func (e InputMethodContextSurroundingTextEvent) String() string {
+	return "zwp_input_method_context_v1.surrounding_text(" +
+		"text=" + strconv.Quote(e.Text) +
+		", cursor=" + strconv.FormatUint(uint64(e.Cursor), 10) +
+		", anchor=" + strconv.FormatUint(uint64(e.Anchor), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e InputMethodContextSurroundingTextEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("text", e.Text),
+		slog.Uint64("cursor", uint64(e.Cursor)),
+		slog.Uint64("anchor", uint64(e.Anchor)),
+	)
+}
+
 // InputMethodContextResetEvent :
 type InputMethodContextResetEvent struct{}
 type InputMethodContextResetHandlerFunc func(InputMethodContextResetEvent)
@@ -483,6 +662,16 @@ func (i *InputMethodContext) SetResetHandler(f InputMethodContextResetHandlerFun
 	i.resetHandler = f
 }
 
+// String formats the event as zwp_input_method_context_v1.reset(argument=value, ...).
+func (e InputMethodContextResetEvent) String() string {
+	return "zwp_input_method_context_v1.reset()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e InputMethodContextResetEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // InputMethodContextContentTypeEvent :
 type InputMethodContextContentTypeEvent struct {
 	Hint    uint32
@@ -495,6 +684,22 @@ func (i *InputMethodContext) SetContentTypeHandler(f InputMethodContextContentTy
 	i.contentTypeHandler = f
 }
 
+// String formats the event as zwp_input_method_context_v1.content_type(argument=value, ...).
+func (e InputMethodContextContentTypeEvent) String() string {
+	return "zwp_input_method_context_v1.content_type(" +
+		"hint=" + strconv.FormatUint(uint64(e.Hint), 10) +
+		", purpose=" + strconv.FormatUint(uint64(e.Purpose), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e InputMethodContextContentTypeEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("hint", uint64(e.Hint)),
+		slog.Uint64("purpose", uint64(e.Purpose)),
+	)
+}
+
 // InputMethodContextInvokeActionEvent :
 type InputMethodContextInvokeActionEvent struct {
 	Button uint32
@@ -507,6 +712,22 @@ func (i *InputMethodContext) SetInvokeActionHandler(f InputMethodContextInvokeAc
 	i.invokeActionHandler = f
 }
 
+// String formats the event as zwp_input_method_context_v1.invoke_action(argument=value, ...).
+func (e InputMethodContextInvokeActionEvent) String() string {
+	return "zwp_input_method_context_v1.invoke_action(" +
+		"button=" + strconv.FormatUint(uint64(e.Button), 10) +
+		", index=" + strconv.FormatUint(uint64(e.Index), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e InputMethodContextInvokeActionEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("button", uint64(e.Button)),
+		slog.Uint64("index", uint64(e.Index)),
+	)
+}
+
 // InputMethodContextCommitStateEvent :
 type InputMethodContextCommitStateEvent struct {
 	Serial uint32
@@ -518,6 +739,20 @@ func (i *InputMethodContext) SetCommitStateHandler(f InputMethodContextCommitSta
 	i.commitStateHandler = f
 }
 
+// String formats the event as zwp_input_method_context_v1.commit_state(argument=value, ...).
+func (e InputMethodContextCommitStateEvent) String() string {
+	return "zwp_input_method_context_v1.commit_state(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e InputMethodContextCommitStateEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+	)
+}
+
 // InputMethodContextPreferredLanguageEvent :
 type InputMethodContextPreferredLanguageEvent struct {
 	Language string
@@ -529,6 +764,73 @@ func (i *InputMethodContext) SetPreferredLanguageHandler(f InputMethodContextPre
 	i.preferredLanguageHandler = f
 }
 
+// String formats the event as zwp_input_method_context_v1.preferred_language(argument=value, ...).
+func (e InputMethodContextPreferredLanguageEvent) String() string {
+	return "zwp_input_method_context_v1.preferred_language(" +
+		"language=" + strconv.Quote(e.Language) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e InputMethodContextPreferredLanguageEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("language", e.Language),
+	)
+}
+
+// InputMethodContextListener receives all the events of InputMethodContext, embed
+// InputMethodContextNoopListener to implement only some of them.
+type InputMethodContextListener interface {
+	SurroundingText(e InputMethodContextSurroundingTextEvent)
+	Reset(e InputMethodContextResetEvent)
+	ContentType(e InputMethodContextContentTypeEvent)
+	InvokeAction(e InputMethodContextInvokeActionEvent)
+	CommitState(e InputMethodContextCommitStateEvent)
+	PreferredLanguage(e InputMethodContextPreferredLanguageEvent)
+}
+
+// InputMethodContextNoopListener is a InputMethodContextListener ignoring
+// all the events, it closes the file descriptors they carry.
+type InputMethodContextNoopListener struct{}
+
+func (InputMethodContextNoopListener) SurroundingText(InputMethodContextSurroundingTextEvent) {
+}
+
+func (InputMethodContextNoopListener) Reset(InputMethodContextResetEvent) {
+}
+
+func (InputMethodContextNoopListener) ContentType(InputMethodContextContentTypeEvent) {
+}
+
+func (InputMethodContextNoopListener) InvokeAction(InputMethodContextInvokeActionEvent) {
+}
+
+func (InputMethodContextNoopListener) CommitState(InputMethodContextCommitStateEvent) {
+}
+
+func (InputMethodContextNoopListener) PreferredLanguage(InputMethodContextPreferredLanguageEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *InputMethodContext) SetListener(l InputMethodContextListener) {
+	if l == nil {
+		i.surroundingTextHandler = nil
+		i.resetHandler = nil
+		i.contentTypeHandler = nil
+		i.invokeActionHandler = nil
+		i.commitStateHandler = nil
+		i.preferredLanguageHandler = nil
+		return
+	}
+	i.surroundingTextHandler = l.SurroundingText
+	i.resetHandler = l.Reset
+	i.contentTypeHandler = l.ContentType
+	i.invokeActionHandler = l.InvokeAction
+	i.commitStateHandler = l.CommitState
+	i.preferredLanguageHandler = l.PreferredLanguage
+}
+
 func (i *InputMethodContext) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -627,6 +929,32 @@ func NewInputMethod(ctx *client.Context) *InputMethod {
 	return zwpInputMethodV1
 }
 
+// InputMethodInterface describes zwp_input_method_v1 at runtime.
+var InputMethodInterface = &client.Interface{
+	Name:    "zwp_input_method_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &InputMethod{} },
+	Events: []client.Message{
+		{
+			Name: "activate",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zwp_input_method_context_v1"},
+			},
+		},
+		{
+			Name: "deactivate",
+			Args: []client.Arg{
+				{Name: "context", Type: "object", Interface: "zwp_input_method_context_v1"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_input_method_v1.
+func (i *InputMethod) Interface() *client.Interface {
+	return InputMethodInterface
+}
+
 func (i *InputMethod) Destroy() error {
 	i.Context().Unregister(i)
 	return nil
@@ -646,6 +974,20 @@ func (i *InputMethod) SetActivateHandler(f InputMethodActivateHandlerFunc) {
 	i.activateHandler = f
 }
 
+// String formats the event as zwp_input_method_v1.activate(argument=value, ...).
+func (e InputMethodActivateEvent) String() string {
+	return "zwp_input_method_v1.activate(" +
+		"id=" + client.ObjectString(e.Id) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e InputMethodActivateEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", client.ObjectString(e.Id)),
+	)
+}
+
 // InputMethodDeactivateEvent : deactivate event
 //
 // The text input corresponding to the context argument was deactivated.
@@ -661,25 +1003,69 @@ func (i *InputMethod) SetDeactivateHandler(f InputMethodDeactivateHandlerFunc) {
 	i.deactivateHandler = f
 }
 
+// String formats the event as zwp_input_method_v1.deactivate(argument=value, ...).
+func (e InputMethodDeactivateEvent) String() string {
+	return "zwp_input_method_v1.deactivate(" +
+		"context=" + client.ObjectString(e.Context) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e InputMethodDeactivateEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("context", client.ObjectString(e.Context)),
+	)
+}
+
+// InputMethodListener receives all the events of InputMethod, embed
+// InputMethodNoopListener to implement only some of them.
+type InputMethodListener interface {
+	Activate(e InputMethodActivateEvent)
+	Deactivate(e InputMethodDeactivateEvent)
+}
+
+// InputMethodNoopListener is a InputMethodListener ignoring
+// all the events, it closes the file descriptors they carry.
+type InputMethodNoopListener struct{}
+
+func (InputMethodNoopListener) Activate(InputMethodActivateEvent) {
+}
+
+func (InputMethodNoopListener) Deactivate(InputMethodDeactivateEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *InputMethod) SetListener(l InputMethodListener) {
+	if l == nil {
+		i.activateHandler = nil
+		i.deactivateHandler = nil
+		return
+	}
+	i.activateHandler = l.Activate
+	i.deactivateHandler = l.Deactivate
+}
+
 func (i *InputMethod) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
-		if i.activateHandler == nil {
-			return
-		}
 		var e InputMethodActivateEvent
 		l := 0
-		e.Id = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*InputMethodContext)
+		e.Id = &InputMethodContext{}
+		i.Context().RegisterWithID(e.Id, client.Uint32(data[l:l+4]))
+		e.Id.SetVersion(i.Version())
 		l += 4
 
-		i.activateHandler(e)
+		if i.activateHandler != nil {
+			i.activateHandler(e)
+		}
 	case 1:
 		if i.deactivateHandler == nil {
 			return
 		}
 		var e InputMethodDeactivateEvent
 		l := 0
-		e.Context = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*InputMethodContext)
+		e.Context, _ = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*InputMethodContext)
 		l += 4
 
 		i.deactivateHandler(e)
@@ -702,9 +1088,31 @@ func NewInputPanel(ctx *client.Context) *InputPanel {
 	return zwpInputPanelV1
 }
 
+// InputPanelInterface describes zwp_input_panel_v1 at runtime.
+var InputPanelInterface = &client.Interface{
+	Name:    "zwp_input_panel_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &InputPanel{} },
+	Requests: []client.Message{
+		{
+			Name: "get_input_panel_surface",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zwp_input_panel_surface_v1"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_input_panel_v1.
+func (i *InputPanel) Interface() *client.Interface {
+	return InputPanelInterface
+}
+
 // GetInputPanelSurface :
 func (i *InputPanel) GetInputPanelSurface(surface *client.Surface) (*InputPanelSurface, error) {
 	id := NewInputPanelSurface(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 0
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -738,6 +1146,38 @@ func NewInputPanelSurface(ctx *client.Context) *InputPanelSurface {
 	return zwpInputPanelSurfaceV1
 }
 
+// InputPanelSurfaceInterface describes zwp_input_panel_surface_v1 at runtime.
+var InputPanelSurfaceInterface = &client.Interface{
+	Name:    "zwp_input_panel_surface_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &InputPanelSurface{} },
+	Requests: []client.Message{
+		{
+			Name: "set_toplevel",
+			Args: []client.Arg{
+				{Name: "output", Type: "object", Interface: "wl_output"},
+				{Name: "position", Type: "uint"},
+			},
+		},
+		{
+			Name: "set_overlay_panel",
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "position",
+			Entries: []client.EnumEntry{
+				{Name: "center_bottom", Value: 0},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_input_panel_surface_v1.
+func (i *InputPanelSurface) Interface() *client.Interface {
+	return InputPanelSurfaceInterface
+}
+
 // SetToplevel : set the surface type as a keyboard
 //
 // Set the input_panel_surface type to keyboard.
@@ -812,3 +1252,10 @@ func (e InputPanelSurfacePosition) Value() string {
 func (e InputPanelSurfacePosition) String() string {
 	return e.Name() + "=" + e.Value()
 }
+
+func init() {
+	client.RegisterInterface(InputMethodContextInterface)
+	client.RegisterInterface(InputMethodInterface)
+	client.RegisterInterface(InputPanelInterface)
+	client.RegisterInterface(InputPanelSurfaceInterface)
+}
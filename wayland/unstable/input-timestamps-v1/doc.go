@@ -1,3 +1 @@
 package input_timestamps
-
-//go:generate go run github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner -pkg input_timestamps -prefix zwp -suffix v1 -o input_timestamps.go -i https://raw.githubusercontent.com/wayland-project/wayland-protocols/1.31/unstable/input-timestamps/input-timestamps-unstable-v1.xml
@@ -27,7 +27,12 @@
 
 package input_timestamps
 
-import "github.com/rajveermalviya/go-wayland/wayland/client"
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+)
 
 // InputTimestampsManager : context object for high-resolution input timestamps
 //
@@ -47,6 +52,45 @@ func NewInputTimestampsManager(ctx *client.Context) *InputTimestampsManager {
 	return zwpInputTimestampsManagerV1
 }
 
+// InputTimestampsManagerInterface describes zwp_input_timestamps_manager_v1 at runtime.
+var InputTimestampsManagerInterface = &client.Interface{
+	Name:    "zwp_input_timestamps_manager_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &InputTimestampsManager{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "get_keyboard_timestamps",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zwp_input_timestamps_v1"},
+				{Name: "keyboard", Type: "object", Interface: "wl_keyboard"},
+			},
+		},
+		{
+			Name: "get_pointer_timestamps",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zwp_input_timestamps_v1"},
+				{Name: "pointer", Type: "object", Interface: "wl_pointer"},
+			},
+		},
+		{
+			Name: "get_touch_timestamps",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zwp_input_timestamps_v1"},
+				{Name: "touch", Type: "object", Interface: "wl_touch"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_input_timestamps_manager_v1.
+func (i *InputTimestampsManager) Interface() *client.Interface {
+	return InputTimestampsManagerInterface
+}
+
 // Destroy : destroy the input timestamps manager object
 //
 // Informs the server that the client will no longer be using this
@@ -80,6 +124,7 @@ func (i *InputTimestampsManager) Destroy() error {
 //	keyboard: the wl_keyboard object for which to get timestamp events
 func (i *InputTimestampsManager) GetKeyboardTimestamps(keyboard *client.Keyboard) (*InputTimestamps, error) {
 	id := NewInputTimestamps(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -110,6 +155,7 @@ func (i *InputTimestampsManager) GetKeyboardTimestamps(keyboard *client.Keyboard
 //	pointer: the wl_pointer object for which to get timestamp events
 func (i *InputTimestampsManager) GetPointerTimestamps(pointer *client.Pointer) (*InputTimestamps, error) {
 	id := NewInputTimestamps(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 2
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -140,6 +186,7 @@ func (i *InputTimestampsManager) GetPointerTimestamps(pointer *client.Pointer) (
 //	touch: the wl_touch object for which to get timestamp events
 func (i *InputTimestampsManager) GetTouchTimestamps(touch *client.Touch) (*InputTimestamps, error) {
 	id := NewInputTimestamps(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 3
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -177,6 +224,34 @@ func NewInputTimestamps(ctx *client.Context) *InputTimestamps {
 	return zwpInputTimestampsV1
 }
 
+// InputTimestampsInterface describes zwp_input_timestamps_v1 at runtime.
+var InputTimestampsInterface = &client.Interface{
+	Name:    "zwp_input_timestamps_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &InputTimestamps{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "timestamp",
+			Args: []client.Arg{
+				{Name: "tv_sec_hi", Type: "uint"},
+				{Name: "tv_sec_lo", Type: "uint"},
+				{Name: "tv_nsec", Type: "uint"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_input_timestamps_v1.
+func (i *InputTimestamps) Interface() *client.Interface {
+	return InputTimestampsInterface
+}
+
 // Destroy : destroy the input timestamps object
 //
 // Informs the server that the client will no longer be using this
@@ -224,6 +299,47 @@ func (i *InputTimestamps) SetTimestampHandler(f InputTimestampsTimestampHandlerF
 	i.timestampHandler = f
 }
 
+// String formats the event as zwp_input_timestamps_v1.timestamp(argument=value, ...).
+func (e InputTimestampsTimestampEvent) String() string {
+	return "zwp_input_timestamps_v1.timestamp(" +
+		"tv_sec_hi=" + strconv.FormatUint(uint64(e.TvSecHi), 10) +
+		", tv_sec_lo=" + strconv.FormatUint(uint64(e.TvSecLo), 10) +
+		", tv_nsec=" + strconv.FormatUint(uint64(e.TvNsec), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e InputTimestampsTimestampEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("tv_sec_hi", uint64(e.TvSecHi)),
+		slog.Uint64("tv_sec_lo", uint64(e.TvSecLo)),
+		slog.Uint64("tv_nsec", uint64(e.TvNsec)),
+	)
+}
+
+// InputTimestampsListener receives all the events of InputTimestamps, embed
+// InputTimestampsNoopListener to implement only some of them.
+type InputTimestampsListener interface {
+	Timestamp(e InputTimestampsTimestampEvent)
+}
+
+// InputTimestampsNoopListener is a InputTimestampsListener ignoring
+// all the events, it closes the file descriptors they carry.
+type InputTimestampsNoopListener struct{}
+
+func (InputTimestampsNoopListener) Timestamp(InputTimestampsTimestampEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *InputTimestamps) SetListener(l InputTimestampsListener) {
+	if l == nil {
+		i.timestampHandler = nil
+		return
+	}
+	i.timestampHandler = l.Timestamp
+}
+
 func (i *InputTimestamps) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -242,3 +358,8 @@ func (i *InputTimestamps) Dispatch(opcode uint32, fd int, data []byte) {
 		i.timestampHandler(e)
 	}
 }
+
+func init() {
+	client.RegisterInterface(InputTimestampsManagerInterface)
+	client.RegisterInterface(InputTimestampsInterface)
+}
@@ -27,7 +27,11 @@
 
 package keyboard_shortcuts_inhibit
 
-import "github.com/rajveermalviya/go-wayland/wayland/client"
+import (
+	"log/slog"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+)
 
 // KeyboardShortcutsInhibitManager : context object for keyboard grab_manager
 //
@@ -45,6 +49,40 @@ func NewKeyboardShortcutsInhibitManager(ctx *client.Context) *KeyboardShortcutsI
 	return zwpKeyboardShortcutsInhibitManagerV1
 }
 
+// KeyboardShortcutsInhibitManagerInterface describes zwp_keyboard_shortcuts_inhibit_manager_v1 at runtime.
+var KeyboardShortcutsInhibitManagerInterface = &client.Interface{
+	Name:    "zwp_keyboard_shortcuts_inhibit_manager_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &KeyboardShortcutsInhibitManager{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "inhibit_shortcuts",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zwp_keyboard_shortcuts_inhibitor_v1"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+				{Name: "seat", Type: "object", Interface: "wl_seat"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "already_inhibited", Value: 0},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_keyboard_shortcuts_inhibit_manager_v1.
+func (i *KeyboardShortcutsInhibitManager) Interface() *client.Interface {
+	return KeyboardShortcutsInhibitManagerInterface
+}
+
 // Destroy : destroy the keyboard shortcuts inhibitor object
 //
 // Destroy the keyboard shortcuts inhibitor manager.
@@ -74,6 +112,7 @@ func (i *KeyboardShortcutsInhibitManager) Destroy() error {
 //	seat: the wl_seat for which keyboard shortcuts should be disabled
 func (i *KeyboardShortcutsInhibitManager) InhibitShortcuts(surface *client.Surface, seat *client.Seat) (*KeyboardShortcutsInhibitor, error) {
 	id := NewKeyboardShortcutsInhibitor(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -204,6 +243,32 @@ func NewKeyboardShortcutsInhibitor(ctx *client.Context) *KeyboardShortcutsInhibi
 	return zwpKeyboardShortcutsInhibitorV1
 }
 
+// KeyboardShortcutsInhibitorInterface describes zwp_keyboard_shortcuts_inhibitor_v1 at runtime.
+var KeyboardShortcutsInhibitorInterface = &client.Interface{
+	Name:    "zwp_keyboard_shortcuts_inhibitor_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &KeyboardShortcutsInhibitor{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "active",
+		},
+		{
+			Name: "inactive",
+		},
+	},
+}
+
+// Interface returns the description of zwp_keyboard_shortcuts_inhibitor_v1.
+func (i *KeyboardShortcutsInhibitor) Interface() *client.Interface {
+	return KeyboardShortcutsInhibitorInterface
+}
+
 // Destroy : destroy the keyboard shortcuts inhibitor object
 //
 // Remove the keyboard shortcuts inhibitor from the associated wl_surface.
@@ -242,6 +307,16 @@ func (i *KeyboardShortcutsInhibitor) SetActiveHandler(f KeyboardShortcutsInhibit
 	i.activeHandler = f
 }
 
+// String formats the event as zwp_keyboard_shortcuts_inhibitor_v1.active(argument=value, ...).
+func (e KeyboardShortcutsInhibitorActiveEvent) String() string {
+	return "zwp_keyboard_shortcuts_inhibitor_v1.active()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e KeyboardShortcutsInhibitorActiveEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // KeyboardShortcutsInhibitorInactiveEvent : shortcuts are restored
 //
 // This event indicates that the shortcuts inhibitor is inactive,
@@ -254,6 +329,45 @@ func (i *KeyboardShortcutsInhibitor) SetInactiveHandler(f KeyboardShortcutsInhib
 	i.inactiveHandler = f
 }
 
+// String formats the event as zwp_keyboard_shortcuts_inhibitor_v1.inactive(argument=value, ...).
+func (e KeyboardShortcutsInhibitorInactiveEvent) String() string {
+	return "zwp_keyboard_shortcuts_inhibitor_v1.inactive()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e KeyboardShortcutsInhibitorInactiveEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
+// KeyboardShortcutsInhibitorListener receives all the events of KeyboardShortcutsInhibitor, embed
+// KeyboardShortcutsInhibitorNoopListener to implement only some of them.
+type KeyboardShortcutsInhibitorListener interface {
+	Active(e KeyboardShortcutsInhibitorActiveEvent)
+	Inactive(e KeyboardShortcutsInhibitorInactiveEvent)
+}
+
+// KeyboardShortcutsInhibitorNoopListener is a KeyboardShortcutsInhibitorListener ignoring
+// all the events, it closes the file descriptors they carry.
+type KeyboardShortcutsInhibitorNoopListener struct{}
+
+func (KeyboardShortcutsInhibitorNoopListener) Active(KeyboardShortcutsInhibitorActiveEvent) {
+}
+
+func (KeyboardShortcutsInhibitorNoopListener) Inactive(KeyboardShortcutsInhibitorInactiveEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *KeyboardShortcutsInhibitor) SetListener(l KeyboardShortcutsInhibitorListener) {
+	if l == nil {
+		i.activeHandler = nil
+		i.inactiveHandler = nil
+		return
+	}
+	i.activeHandler = l.Active
+	i.inactiveHandler = l.Inactive
+}
+
 func (i *KeyboardShortcutsInhibitor) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -272,3 +386,8 @@ func (i *KeyboardShortcutsInhibitor) Dispatch(opcode uint32, fd int, data []byte
 		i.inactiveHandler(e)
 	}
 }
+
+func init() {
+	client.RegisterInterface(KeyboardShortcutsInhibitManagerInterface)
+	client.RegisterInterface(KeyboardShortcutsInhibitorInterface)
+}
@@ -28,6 +28,11 @@
 package linux_dmabuf
 
 import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
 	"github.com/rajveermalviya/go-wayland/wayland/client"
 	"golang.org/x/sys/unix"
 )
@@ -184,6 +189,62 @@ func NewLinuxDmabuf(ctx *client.Context) *LinuxDmabuf {
 	return zwpLinuxDmabufV1
 }
 
+// LinuxDmabufInterface describes zwp_linux_dmabuf_v1 at runtime.
+var LinuxDmabufInterface = &client.Interface{
+	Name:    "zwp_linux_dmabuf_v1",
+	Version: 4,
+	New:     func() client.Proxy { return &LinuxDmabuf{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "create_params",
+			Args: []client.Arg{
+				{Name: "params_id", Type: "new_id", Interface: "zwp_linux_buffer_params_v1"},
+			},
+		},
+		{
+			Name:  "get_default_feedback",
+			Since: 4,
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zwp_linux_dmabuf_feedback_v1"},
+			},
+		},
+		{
+			Name:  "get_surface_feedback",
+			Since: 4,
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zwp_linux_dmabuf_feedback_v1"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+			},
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "format",
+			Args: []client.Arg{
+				{Name: "format", Type: "uint"},
+			},
+		},
+		{
+			Name:  "modifier",
+			Since: 3,
+			Args: []client.Arg{
+				{Name: "format", Type: "uint"},
+				{Name: "modifier_hi", Type: "uint"},
+				{Name: "modifier_lo", Type: "uint"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_linux_dmabuf_v1.
+func (i *LinuxDmabuf) Interface() *client.Interface {
+	return LinuxDmabufInterface
+}
+
 // Destroy : unbind the factory
 //
 // Objects created through this interface, especially wl_buffers, will
@@ -210,6 +271,7 @@ func (i *LinuxDmabuf) Destroy() error {
 // received.
 func (i *LinuxDmabuf) CreateParams() (*LinuxBufferParams, error) {
 	paramsId := NewLinuxBufferParams(i.Context())
+	paramsId.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -231,7 +293,11 @@ func (i *LinuxDmabuf) CreateParams() (*LinuxBufferParams, error) {
 // parameters to use if the client doesn't support per-surface feedback
 // (see get_surface_feedback).
 func (i *LinuxDmabuf) GetDefaultFeedback() (*LinuxDmabufFeedback, error) {
+	if v := i.Version(); v != 0 && v < 4 {
+		return nil, &client.VersionError{Interface: "zwp_linux_dmabuf_v1", Request: "get_default_feedback", Since: 4, Version: v}
+	}
 	id := NewLinuxDmabufFeedback(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 2
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -255,7 +321,11 @@ func (i *LinuxDmabuf) GetDefaultFeedback() (*LinuxDmabufFeedback, error) {
 // If the surface is destroyed before the wp_linux_dmabuf_feedback object,
 // the feedback object becomes inert.
 func (i *LinuxDmabuf) GetSurfaceFeedback(surface *client.Surface) (*LinuxDmabufFeedback, error) {
+	if v := i.Version(); v != 0 && v < 4 {
+		return nil, &client.VersionError{Interface: "zwp_linux_dmabuf_v1", Request: "get_surface_feedback", Since: 4, Version: v}
+	}
 	id := NewLinuxDmabufFeedback(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 3
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -295,6 +365,20 @@ func (i *LinuxDmabuf) SetFormatHandler(f LinuxDmabufFormatHandlerFunc) {
 	i.formatHandler = f
 }
 
+// String formats the event as zwp_linux_dmabuf_v1.format(argument=value, ...).
+func (e LinuxDmabufFormatEvent) String() string {
+	return "zwp_linux_dmabuf_v1.format(" +
+		"format=" + strconv.FormatUint(uint64(e.Format), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e LinuxDmabufFormatEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("format", uint64(e.Format)),
+	)
+}
+
 // LinuxDmabufModifierEvent : supported buffer format modifier
 //
 // This event advertises the formats that the server supports, along with
@@ -332,6 +416,53 @@ func (i *LinuxDmabuf) SetModifierHandler(f LinuxDmabufModifierHandlerFunc) {
 	i.modifierHandler = f
 }
 
+// String formats the event as zwp_linux_dmabuf_v1.modifier(argument=value, ...).
+func (e LinuxDmabufModifierEvent) String() string {
+	return "zwp_linux_dmabuf_v1.modifier(" +
+		"format=" + strconv.FormatUint(uint64(e.Format), 10) +
+		", modifier_hi=" + strconv.FormatUint(uint64(e.ModifierHi), 10) +
+		", modifier_lo=" + strconv.FormatUint(uint64(e.ModifierLo), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e LinuxDmabufModifierEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("format", uint64(e.Format)),
+		slog.Uint64("modifier_hi", uint64(e.ModifierHi)),
+		slog.Uint64("modifier_lo", uint64(e.ModifierLo)),
+	)
+}
+
+// LinuxDmabufListener receives all the events of LinuxDmabuf, embed
+// LinuxDmabufNoopListener to implement only some of them.
+type LinuxDmabufListener interface {
+	Format(e LinuxDmabufFormatEvent)
+	Modifier(e LinuxDmabufModifierEvent)
+}
+
+// LinuxDmabufNoopListener is a LinuxDmabufListener ignoring
+// all the events, it closes the file descriptors they carry.
+type LinuxDmabufNoopListener struct{}
+
+func (LinuxDmabufNoopListener) Format(LinuxDmabufFormatEvent) {
+}
+
+func (LinuxDmabufNoopListener) Modifier(LinuxDmabufModifierEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *LinuxDmabuf) SetListener(l LinuxDmabufListener) {
+	if l == nil {
+		i.formatHandler = nil
+		i.modifierHandler = nil
+		return
+	}
+	i.formatHandler = l.Format
+	i.modifierHandler = l.Modifier
+}
+
 func (i *LinuxDmabuf) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -405,6 +536,90 @@ func NewLinuxBufferParams(ctx *client.Context) *LinuxBufferParams {
 	return zwpLinuxBufferParamsV1
 }
 
+// LinuxBufferParamsInterface describes zwp_linux_buffer_params_v1 at runtime.
+var LinuxBufferParamsInterface = &client.Interface{
+	Name:    "zwp_linux_buffer_params_v1",
+	Version: 4,
+	New:     func() client.Proxy { return &LinuxBufferParams{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "add",
+			Args: []client.Arg{
+				{Name: "fd", Type: "fd"},
+				{Name: "plane_idx", Type: "uint"},
+				{Name: "offset", Type: "uint"},
+				{Name: "stride", Type: "uint"},
+				{Name: "modifier_hi", Type: "uint"},
+				{Name: "modifier_lo", Type: "uint"},
+			},
+		},
+		{
+			Name: "create",
+			Args: []client.Arg{
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+				{Name: "format", Type: "uint"},
+				{Name: "flags", Type: "uint", Enum: "zwp_linux_buffer_params_v1.flags"},
+			},
+		},
+		{
+			Name:  "create_immed",
+			Since: 2,
+			Args: []client.Arg{
+				{Name: "buffer_id", Type: "new_id", Interface: "wl_buffer"},
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+				{Name: "format", Type: "uint"},
+				{Name: "flags", Type: "uint", Enum: "zwp_linux_buffer_params_v1.flags"},
+			},
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "created",
+			Args: []client.Arg{
+				{Name: "buffer", Type: "new_id", Interface: "wl_buffer"},
+			},
+		},
+		{
+			Name: "failed",
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "already_used", Value: 0},
+				{Name: "plane_idx", Value: 1},
+				{Name: "plane_set", Value: 2},
+				{Name: "incomplete", Value: 3},
+				{Name: "invalid_format", Value: 4},
+				{Name: "invalid_dimensions", Value: 5},
+				{Name: "out_of_bounds", Value: 6},
+				{Name: "invalid_wl_buffer", Value: 7},
+			},
+		},
+		{
+			Name:     "flags",
+			Bitfield: true,
+			Entries: []client.EnumEntry{
+				{Name: "y_invert", Value: 1},
+				{Name: "interlaced", Value: 2},
+				{Name: "bottom_first", Value: 4},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_linux_buffer_params_v1.
+func (i *LinuxBufferParams) Interface() *client.Interface {
+	return LinuxBufferParamsInterface
+}
+
 // Destroy : delete this object, used or not
 //
 // Cleans up the temporary data sent to the server for dmabuf-based
@@ -538,7 +753,7 @@ func (i *LinuxBufferParams) Add(fd int, planeIdx, offset, stride, modifierHi, mo
 //	height: base plane height in pixels
 //	format: DRM_FORMAT code
 //	flags: see enum flags
-func (i *LinuxBufferParams) Create(width, height int32, format, flags uint32) error {
+func (i *LinuxBufferParams) Create(width, height int32, format uint32, flags LinuxBufferParamsFlags) error {
 	const opcode = 2
 	const _reqBufLen = 8 + 4 + 4 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -589,8 +804,12 @@ func (i *LinuxBufferParams) Create(width, height int32, format, flags uint32) er
 //	height: base plane height in pixels
 //	format: DRM_FORMAT code
 //	flags: see enum flags
-func (i *LinuxBufferParams) CreateImmed(width, height int32, format, flags uint32) (*client.Buffer, error) {
+func (i *LinuxBufferParams) CreateImmed(width, height int32, format uint32, flags LinuxBufferParamsFlags) (*client.Buffer, error) {
+	if v := i.Version(); v != 0 && v < 2 {
+		return nil, &client.VersionError{Interface: "zwp_linux_buffer_params_v1", Request: "create_immed", Since: 2, Version: v}
+	}
 	bufferId := client.NewBuffer(i.Context())
+	bufferId.SetVersion(i.Version())
 	const opcode = 3
 	const _reqBufLen = 8 + 4 + 4 + 4 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -723,8 +942,29 @@ func (e LinuxBufferParamsFlags) Value() string {
 	}
 }
 
+func (e LinuxBufferParamsFlags) Has(f LinuxBufferParamsFlags) bool {
+	return e&f == f
+}
+
+func (e LinuxBufferParamsFlags) Set(f LinuxBufferParamsFlags) LinuxBufferParamsFlags {
+	return e | f
+}
+
 func (e LinuxBufferParamsFlags) String() string {
-	return e.Name() + "=" + e.Value()
+	if e == 0 {
+		return "0"
+	}
+	var names []string
+	for _, f := range []LinuxBufferParamsFlags{LinuxBufferParamsFlagsYInvert, LinuxBufferParamsFlagsInterlaced, LinuxBufferParamsFlagsBottomFirst} {
+		if e.Has(f) {
+			names = append(names, f.Name())
+			e &^= f
+		}
+	}
+	if e != 0 {
+		names = append(names, "0x"+strconv.FormatUint(uint64(e), 16))
+	}
+	return strings.Join(names, "|")
 }
 
 // LinuxBufferParamsCreatedEvent : buffer creation succeeded
@@ -744,6 +984,20 @@ func (i *LinuxBufferParams) SetCreatedHandler(f LinuxBufferParamsCreatedHandlerF
 	i.createdHandler = f
 }
 
+// String formats the event as zwp_linux_buffer_params_v1.created(argument=value, ...).
+func (e LinuxBufferParamsCreatedEvent) String() string {
+	return "zwp_linux_buffer_params_v1.created(" +
+		"buffer=" + client.ObjectString(e.Buffer) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e LinuxBufferParamsCreatedEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("buffer", client.ObjectString(e.Buffer)),
+	)
+}
+
 // LinuxBufferParamsFailedEvent : buffer creation failed
 //
 // This event indicates that the attempted buffer creation has
@@ -760,18 +1014,58 @@ func (i *LinuxBufferParams) SetFailedHandler(f LinuxBufferParamsFailedHandlerFun
 	i.failedHandler = f
 }
 
+// String formats the event as zwp_linux_buffer_params_v1.failed(argument=value, ...).
+func (e LinuxBufferParamsFailedEvent) String() string {
+	return "zwp_linux_buffer_params_v1.failed()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e LinuxBufferParamsFailedEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
+// LinuxBufferParamsListener receives all the events of LinuxBufferParams, embed
+// LinuxBufferParamsNoopListener to implement only some of them.
+type LinuxBufferParamsListener interface {
+	Created(e LinuxBufferParamsCreatedEvent)
+	Failed(e LinuxBufferParamsFailedEvent)
+}
+
+// LinuxBufferParamsNoopListener is a LinuxBufferParamsListener ignoring
+// all the events, it closes the file descriptors they carry.
+type LinuxBufferParamsNoopListener struct{}
+
+func (LinuxBufferParamsNoopListener) Created(LinuxBufferParamsCreatedEvent) {
+}
+
+func (LinuxBufferParamsNoopListener) Failed(LinuxBufferParamsFailedEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *LinuxBufferParams) SetListener(l LinuxBufferParamsListener) {
+	if l == nil {
+		i.createdHandler = nil
+		i.failedHandler = nil
+		return
+	}
+	i.createdHandler = l.Created
+	i.failedHandler = l.Failed
+}
+
 func (i *LinuxBufferParams) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
-		if i.createdHandler == nil {
-			return
-		}
 		var e LinuxBufferParamsCreatedEvent
 		l := 0
-		e.Buffer = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*client.Buffer)
+		e.Buffer = &client.Buffer{}
+		i.Context().RegisterWithID(e.Buffer, client.Uint32(data[l:l+4]))
+		e.Buffer.SetVersion(i.Version())
 		l += 4
 
-		i.createdHandler(e)
+		if i.createdHandler != nil {
+			i.createdHandler(e)
+		}
 	case 1:
 		if i.failedHandler == nil {
 			return
@@ -853,6 +1147,72 @@ func NewLinuxDmabufFeedback(ctx *client.Context) *LinuxDmabufFeedback {
 	return zwpLinuxDmabufFeedbackV1
 }
 
+// LinuxDmabufFeedbackInterface describes zwp_linux_dmabuf_feedback_v1 at runtime.
+var LinuxDmabufFeedbackInterface = &client.Interface{
+	Name:    "zwp_linux_dmabuf_feedback_v1",
+	Version: 4,
+	New:     func() client.Proxy { return &LinuxDmabufFeedback{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "done",
+		},
+		{
+			Name: "format_table",
+			Args: []client.Arg{
+				{Name: "fd", Type: "fd"},
+				{Name: "size", Type: "uint"},
+			},
+		},
+		{
+			Name: "main_device",
+			Args: []client.Arg{
+				{Name: "device", Type: "array"},
+			},
+		},
+		{
+			Name: "tranche_done",
+		},
+		{
+			Name: "tranche_target_device",
+			Args: []client.Arg{
+				{Name: "device", Type: "array"},
+			},
+		},
+		{
+			Name: "tranche_formats",
+			Args: []client.Arg{
+				{Name: "indices", Type: "array"},
+			},
+		},
+		{
+			Name: "tranche_flags",
+			Args: []client.Arg{
+				{Name: "flags", Type: "uint", Enum: "zwp_linux_dmabuf_feedback_v1.tranche_flags"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name:     "tranche_flags",
+			Bitfield: true,
+			Entries: []client.EnumEntry{
+				{Name: "scanout", Value: 1},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_linux_dmabuf_feedback_v1.
+func (i *LinuxDmabufFeedback) Interface() *client.Interface {
+	return LinuxDmabufFeedbackInterface
+}
+
 // Destroy : destroy the feedback object
 //
 // Using this request a client can tell the server that it is not going to
@@ -897,8 +1257,29 @@ func (e LinuxDmabufFeedbackTrancheFlags) Value() string {
 	}
 }
 
+func (e LinuxDmabufFeedbackTrancheFlags) Has(f LinuxDmabufFeedbackTrancheFlags) bool {
+	return e&f == f
+}
+
+func (e LinuxDmabufFeedbackTrancheFlags) Set(f LinuxDmabufFeedbackTrancheFlags) LinuxDmabufFeedbackTrancheFlags {
+	return e | f
+}
+
 func (e LinuxDmabufFeedbackTrancheFlags) String() string {
-	return e.Name() + "=" + e.Value()
+	if e == 0 {
+		return "0"
+	}
+	var names []string
+	for _, f := range []LinuxDmabufFeedbackTrancheFlags{LinuxDmabufFeedbackTrancheFlagsScanout} {
+		if e.Has(f) {
+			names = append(names, f.Name())
+			e &^= f
+		}
+	}
+	if e != 0 {
+		names = append(names, "0x"+strconv.FormatUint(uint64(e), 16))
+	}
+	return strings.Join(names, "|")
 }
 
 // LinuxDmabufFeedbackDoneEvent : all feedback has been sent
@@ -916,6 +1297,16 @@ func (i *LinuxDmabufFeedback) SetDoneHandler(f LinuxDmabufFeedbackDoneHandlerFun
 	i.doneHandler = f
 }
 
+// String formats the event as zwp_linux_dmabuf_feedback_v1.done(argument=value, ...).
+func (e LinuxDmabufFeedbackDoneEvent) String() string {
+	return "zwp_linux_dmabuf_feedback_v1.done()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e LinuxDmabufFeedbackDoneEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // LinuxDmabufFeedbackFormatTableEvent : format and modifier table
 //
 // This event provides a file descriptor which can be memory-mapped to
@@ -943,6 +1334,22 @@ func (i *LinuxDmabufFeedback) SetFormatTableHandler(f LinuxDmabufFeedbackFormatT
 	i.formatTableHandler = f
 }
 
+// String formats the event as zwp_linux_dmabuf_feedback_v1.format_table(argument=value, ...).
+func (e LinuxDmabufFeedbackFormatTableEvent) String() string {
+	return "zwp_linux_dmabuf_feedback_v1.format_table(" +
+		"fd=" + strconv.Itoa(e.Fd) +
+		", size=" + strconv.FormatUint(uint64(e.Size), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e LinuxDmabufFeedbackFormatTableEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int("fd", e.Fd),
+		slog.Uint64("size", uint64(e.Size)),
+	)
+}
+
 // LinuxDmabufFeedbackMainDeviceEvent : preferred main device
 //
 // This event advertises the main device that the server prefers to use
@@ -978,6 +1385,20 @@ func (i *LinuxDmabufFeedback) SetMainDeviceHandler(f LinuxDmabufFeedbackMainDevi
 	i.mainDeviceHandler = f
 }
 
+// String formats the event as zwp_linux_dmabuf_feedback_v1.main_device(argument=value, ...).
+func (e LinuxDmabufFeedbackMainDeviceEvent) String() string {
+	return "zwp_linux_dmabuf_feedback_v1.main_device(" +
+		"device=" + "array[" + strconv.Itoa(len(e.Device)) + "]" +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e LinuxDmabufFeedbackMainDeviceEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Any("device", e.Device),
+	)
+}
+
 // LinuxDmabufFeedbackTrancheDoneEvent : a preference tranche has been sent
 //
 // This event splits tranche_target_device and tranche_formats events in
@@ -992,6 +1413,16 @@ func (i *LinuxDmabufFeedback) SetTrancheDoneHandler(f LinuxDmabufFeedbackTranche
 	i.trancheDoneHandler = f
 }
 
+// String formats the event as zwp_linux_dmabuf_feedback_v1.tranche_done(argument=value, ...).
+func (e LinuxDmabufFeedbackTrancheDoneEvent) String() string {
+	return "zwp_linux_dmabuf_feedback_v1.tranche_done()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e LinuxDmabufFeedbackTrancheDoneEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // LinuxDmabufFeedbackTrancheTargetDeviceEvent : target device
 //
 // This event advertises the target device that the server prefers to use
@@ -1030,6 +1461,20 @@ func (i *LinuxDmabufFeedback) SetTrancheTargetDeviceHandler(f LinuxDmabufFeedbac
 	i.trancheTargetDeviceHandler = f
 }
 
+// String formats the event as zwp_linux_dmabuf_feedback_v1.tranche_target_device(argument=value, ...).
+func (e LinuxDmabufFeedbackTrancheTargetDeviceEvent) String() string {
+	return "zwp_linux_dmabuf_feedback_v1.tranche_target_device(" +
+		"device=" + "array[" + strconv.Itoa(len(e.Device)) + "]" +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e LinuxDmabufFeedbackTrancheTargetDeviceEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Any("device", e.Device),
+	)
+}
+
 // LinuxDmabufFeedbackTrancheFormatsEvent : supported buffer format modifier
 //
 // This event advertises the format + modifier combinations that the
@@ -1057,7 +1502,7 @@ func (i *LinuxDmabufFeedback) SetTrancheTargetDeviceHandler(f LinuxDmabufFeedbac
 // For the definition of the format and modifier codes, see the
 // wp_linux_buffer_params.create request.
 type LinuxDmabufFeedbackTrancheFormatsEvent struct {
-	Indices []byte
+	Indices []uint16
 }
 type LinuxDmabufFeedbackTrancheFormatsHandlerFunc func(LinuxDmabufFeedbackTrancheFormatsEvent)
 
@@ -1066,6 +1511,20 @@ func (i *LinuxDmabufFeedback) SetTrancheFormatsHandler(f LinuxDmabufFeedbackTran
 	i.trancheFormatsHandler = f
 }
 
+// String formats the event as zwp_linux_dmabuf_feedback_v1.tranche_formats(argument=value, ...).
+func (e LinuxDmabufFeedbackTrancheFormatsEvent) String() string {
+	return "zwp_linux_dmabuf_feedback_v1.tranche_formats(" +
+		"indices=" + fmt.Sprint(e.Indices) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e LinuxDmabufFeedbackTrancheFormatsEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Any("indices", e.Indices),
+	)
+}
+
 // LinuxDmabufFeedbackTrancheFlagsEvent : tranche flags
 //
 // This event sets tranche-specific flags.
@@ -1077,7 +1536,7 @@ func (i *LinuxDmabufFeedback) SetTrancheFormatsHandler(f LinuxDmabufFeedbackTran
 //
 // This event is tied to a preference tranche, see the tranche_done event.
 type LinuxDmabufFeedbackTrancheFlagsEvent struct {
-	Flags uint32
+	Flags LinuxDmabufFeedbackTrancheFlags
 }
 type LinuxDmabufFeedbackTrancheFlagsHandlerFunc func(LinuxDmabufFeedbackTrancheFlagsEvent)
 
@@ -1086,6 +1545,80 @@ func (i *LinuxDmabufFeedback) SetTrancheFlagsHandler(f LinuxDmabufFeedbackTranch
 	i.trancheFlagsHandler = f
 }
 
+// String formats the event as zwp_linux_dmabuf_feedback_v1.tranche_flags(argument=value, ...).
+func (e LinuxDmabufFeedbackTrancheFlagsEvent) String() string {
+	return "zwp_linux_dmabuf_feedback_v1.tranche_flags(" +
+		"flags=" + e.Flags.String() +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e LinuxDmabufFeedbackTrancheFlagsEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("flags", e.Flags.String()),
+	)
+}
+
+// LinuxDmabufFeedbackListener receives all the events of LinuxDmabufFeedback, embed
+// LinuxDmabufFeedbackNoopListener to implement only some of them.
+type LinuxDmabufFeedbackListener interface {
+	Done(e LinuxDmabufFeedbackDoneEvent)
+	FormatTable(e LinuxDmabufFeedbackFormatTableEvent)
+	MainDevice(e LinuxDmabufFeedbackMainDeviceEvent)
+	TrancheDone(e LinuxDmabufFeedbackTrancheDoneEvent)
+	TrancheTargetDevice(e LinuxDmabufFeedbackTrancheTargetDeviceEvent)
+	TrancheFormats(e LinuxDmabufFeedbackTrancheFormatsEvent)
+	TrancheFlags(e LinuxDmabufFeedbackTrancheFlagsEvent)
+}
+
+// LinuxDmabufFeedbackNoopListener is a LinuxDmabufFeedbackListener ignoring
+// all the events, it closes the file descriptors they carry.
+type LinuxDmabufFeedbackNoopListener struct{}
+
+func (LinuxDmabufFeedbackNoopListener) Done(LinuxDmabufFeedbackDoneEvent) {
+}
+
+func (LinuxDmabufFeedbackNoopListener) FormatTable(e LinuxDmabufFeedbackFormatTableEvent) {
+	unix.Close(e.Fd)
+}
+
+func (LinuxDmabufFeedbackNoopListener) MainDevice(LinuxDmabufFeedbackMainDeviceEvent) {
+}
+
+func (LinuxDmabufFeedbackNoopListener) TrancheDone(LinuxDmabufFeedbackTrancheDoneEvent) {
+}
+
+func (LinuxDmabufFeedbackNoopListener) TrancheTargetDevice(LinuxDmabufFeedbackTrancheTargetDeviceEvent) {
+}
+
+func (LinuxDmabufFeedbackNoopListener) TrancheFormats(LinuxDmabufFeedbackTrancheFormatsEvent) {
+}
+
+func (LinuxDmabufFeedbackNoopListener) TrancheFlags(LinuxDmabufFeedbackTrancheFlagsEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *LinuxDmabufFeedback) SetListener(l LinuxDmabufFeedbackListener) {
+	if l == nil {
+		i.doneHandler = nil
+		i.formatTableHandler = nil
+		i.mainDeviceHandler = nil
+		i.trancheDoneHandler = nil
+		i.trancheTargetDeviceHandler = nil
+		i.trancheFormatsHandler = nil
+		i.trancheFlagsHandler = nil
+		return
+	}
+	i.doneHandler = l.Done
+	i.formatTableHandler = l.FormatTable
+	i.mainDeviceHandler = l.MainDevice
+	i.trancheDoneHandler = l.TrancheDone
+	i.trancheTargetDeviceHandler = l.TrancheTargetDevice
+	i.trancheFormatsHandler = l.TrancheFormats
+	i.trancheFlagsHandler = l.TrancheFlags
+}
+
 func (i *LinuxDmabufFeedback) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -1119,7 +1652,7 @@ func (i *LinuxDmabufFeedback) Dispatch(opcode uint32, fd int, data []byte) {
 		l += 4
 		e.Device = make([]byte, deviceLen)
 		copy(e.Device, data[l:l+deviceLen])
-		l += deviceLen
+		l += client.PaddedLen(deviceLen)
 
 		i.mainDeviceHandler(e)
 	case 3:
@@ -1139,7 +1672,7 @@ func (i *LinuxDmabufFeedback) Dispatch(opcode uint32, fd int, data []byte) {
 		l += 4
 		e.Device = make([]byte, deviceLen)
 		copy(e.Device, data[l:l+deviceLen])
-		l += deviceLen
+		l += client.PaddedLen(deviceLen)
 
 		i.trancheTargetDeviceHandler(e)
 	case 5:
@@ -1150,9 +1683,8 @@ func (i *LinuxDmabufFeedback) Dispatch(opcode uint32, fd int, data []byte) {
 		l := 0
 		indicesLen := int(client.Uint32(data[l : l+4]))
 		l += 4
-		e.Indices = make([]byte, indicesLen)
-		copy(e.Indices, data[l:l+indicesLen])
-		l += indicesLen
+		e.Indices = client.Array[uint16](data[l : l+indicesLen])
+		l += client.PaddedLen(indicesLen)
 
 		i.trancheFormatsHandler(e)
 	case 6:
@@ -1161,9 +1693,15 @@ func (i *LinuxDmabufFeedback) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e LinuxDmabufFeedbackTrancheFlagsEvent
 		l := 0
-		e.Flags = client.Uint32(data[l : l+4])
+		e.Flags = LinuxDmabufFeedbackTrancheFlags(client.Uint32(data[l : l+4]))
 		l += 4
 
 		i.trancheFlagsHandler(e)
 	}
 }
+
+func init() {
+	client.RegisterInterface(LinuxDmabufInterface)
+	client.RegisterInterface(LinuxBufferParamsInterface)
+	client.RegisterInterface(LinuxDmabufFeedbackInterface)
+}
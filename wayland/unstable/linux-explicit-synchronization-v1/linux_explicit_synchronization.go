@@ -30,6 +30,9 @@
 package linux_explicit_synchronization
 
 import (
+	"log/slog"
+	"strconv"
+
 	"github.com/rajveermalviya/go-wayland/wayland/client"
 	"golang.org/x/sys/unix"
 )
@@ -80,6 +83,39 @@ func NewLinuxExplicitSynchronization(ctx *client.Context) *LinuxExplicitSynchron
 	return zwpLinuxExplicitSynchronizationV1
 }
 
+// LinuxExplicitSynchronizationInterface describes zwp_linux_explicit_synchronization_v1 at runtime.
+var LinuxExplicitSynchronizationInterface = &client.Interface{
+	Name:    "zwp_linux_explicit_synchronization_v1",
+	Version: 2,
+	New:     func() client.Proxy { return &LinuxExplicitSynchronization{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "get_synchronization",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zwp_linux_surface_synchronization_v1"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "synchronization_exists", Value: 0},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_linux_explicit_synchronization_v1.
+func (i *LinuxExplicitSynchronization) Interface() *client.Interface {
+	return LinuxExplicitSynchronizationInterface
+}
+
 // Destroy : destroy explicit synchronization factory object
 //
 // Destroy this explicit synchronization factory object. Other objects,
@@ -116,6 +152,7 @@ func (i *LinuxExplicitSynchronization) Destroy() error {
 //	surface: the surface
 func (i *LinuxExplicitSynchronization) GetSynchronization(surface *client.Surface) (*LinuxSurfaceSynchronization, error) {
 	id := NewLinuxSurfaceSynchronization(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -236,6 +273,49 @@ func NewLinuxSurfaceSynchronization(ctx *client.Context) *LinuxSurfaceSynchroniz
 	return zwpLinuxSurfaceSynchronizationV1
 }
 
+// LinuxSurfaceSynchronizationInterface describes zwp_linux_surface_synchronization_v1 at runtime.
+var LinuxSurfaceSynchronizationInterface = &client.Interface{
+	Name:    "zwp_linux_surface_synchronization_v1",
+	Version: 2,
+	New:     func() client.Proxy { return &LinuxSurfaceSynchronization{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "set_acquire_fence",
+			Args: []client.Arg{
+				{Name: "fd", Type: "fd"},
+			},
+		},
+		{
+			Name: "get_release",
+			Args: []client.Arg{
+				{Name: "release", Type: "new_id", Interface: "zwp_linux_buffer_release_v1"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "invalid_fence", Value: 0},
+				{Name: "duplicate_fence", Value: 1},
+				{Name: "duplicate_release", Value: 2},
+				{Name: "no_surface", Value: 3},
+				{Name: "unsupported_buffer", Value: 4},
+				{Name: "no_buffer", Value: 5},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_linux_surface_synchronization_v1.
+func (i *LinuxSurfaceSynchronization) Interface() *client.Interface {
+	return LinuxSurfaceSynchronizationInterface
+}
+
 // Destroy : destroy synchronization object
 //
 // Destroy this explicit synchronization object.
@@ -322,6 +402,7 @@ func (i *LinuxSurfaceSynchronization) SetAcquireFence(fd int) error {
 // error is raised.
 func (i *LinuxSurfaceSynchronization) GetRelease() (*LinuxBufferRelease, error) {
 	release := NewLinuxBufferRelease(i.Context())
+	release.SetVersion(i.Version())
 	const opcode = 2
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -446,6 +527,31 @@ func NewLinuxBufferRelease(ctx *client.Context) *LinuxBufferRelease {
 	return zwpLinuxBufferReleaseV1
 }
 
+// LinuxBufferReleaseInterface describes zwp_linux_buffer_release_v1 at runtime.
+var LinuxBufferReleaseInterface = &client.Interface{
+	Name:    "zwp_linux_buffer_release_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &LinuxBufferRelease{} },
+	Events: []client.Message{
+		{
+			Name:       "fenced_release",
+			Destructor: true,
+			Args: []client.Arg{
+				{Name: "fence", Type: "fd"},
+			},
+		},
+		{
+			Name:       "immediate_release",
+			Destructor: true,
+		},
+	},
+}
+
+// Interface returns the description of zwp_linux_buffer_release_v1.
+func (i *LinuxBufferRelease) Interface() *client.Interface {
+	return LinuxBufferReleaseInterface
+}
+
 func (i *LinuxBufferRelease) Destroy() error {
 	i.Context().Unregister(i)
 	return nil
@@ -474,6 +580,20 @@ func (i *LinuxBufferRelease) SetFencedReleaseHandler(f LinuxBufferReleaseFencedR
 	i.fencedReleaseHandler = f
 }
 
+// String formats the event as zwp_linux_buffer_release_v1.fenced_release(argument=value, ...).
+func (e LinuxBufferReleaseFencedReleaseEvent) String() string {
+	return "zwp_linux_buffer_release_v1.fenced_release(" +
+		"fence=" + strconv.Itoa(e.Fence) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e LinuxBufferReleaseFencedReleaseEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int("fence", e.Fence),
+	)
+}
+
 // LinuxBufferReleaseImmediateReleaseEvent : release buffer immediately
 //
 // Sent when the compositor has finalised its usage of the associated
@@ -495,9 +615,50 @@ func (i *LinuxBufferRelease) SetImmediateReleaseHandler(f LinuxBufferReleaseImme
 	i.immediateReleaseHandler = f
 }
 
+// String formats the event as zwp_linux_buffer_release_v1.immediate_release(argument=value, ...).
+func (e LinuxBufferReleaseImmediateReleaseEvent) String() string {
+	return "zwp_linux_buffer_release_v1.immediate_release()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e LinuxBufferReleaseImmediateReleaseEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
+// LinuxBufferReleaseListener receives all the events of LinuxBufferRelease, embed
+// LinuxBufferReleaseNoopListener to implement only some of them.
+type LinuxBufferReleaseListener interface {
+	FencedRelease(e LinuxBufferReleaseFencedReleaseEvent)
+	ImmediateRelease(e LinuxBufferReleaseImmediateReleaseEvent)
+}
+
+// LinuxBufferReleaseNoopListener is a LinuxBufferReleaseListener ignoring
+// all the events, it closes the file descriptors they carry.
+type LinuxBufferReleaseNoopListener struct{}
+
+func (LinuxBufferReleaseNoopListener) FencedRelease(e LinuxBufferReleaseFencedReleaseEvent) {
+	unix.Close(e.Fence)
+}
+
+func (LinuxBufferReleaseNoopListener) ImmediateRelease(LinuxBufferReleaseImmediateReleaseEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *LinuxBufferRelease) SetListener(l LinuxBufferReleaseListener) {
+	if l == nil {
+		i.fencedReleaseHandler = nil
+		i.immediateReleaseHandler = nil
+		return
+	}
+	i.fencedReleaseHandler = l.FencedRelease
+	i.immediateReleaseHandler = l.ImmediateRelease
+}
+
 func (i *LinuxBufferRelease) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
+		defer i.Context().Unregister(i)
 		if i.fencedReleaseHandler == nil {
 			if fd != -1 {
 				unix.Close(fd)
@@ -509,6 +670,7 @@ func (i *LinuxBufferRelease) Dispatch(opcode uint32, fd int, data []byte) {
 
 		i.fencedReleaseHandler(e)
 	case 1:
+		defer i.Context().Unregister(i)
 		if i.immediateReleaseHandler == nil {
 			return
 		}
@@ -517,3 +679,9 @@ func (i *LinuxBufferRelease) Dispatch(opcode uint32, fd int, data []byte) {
 		i.immediateReleaseHandler(e)
 	}
 }
+
+func init() {
+	client.RegisterInterface(LinuxExplicitSynchronizationInterface)
+	client.RegisterInterface(LinuxSurfaceSynchronizationInterface)
+	client.RegisterInterface(LinuxBufferReleaseInterface)
+}
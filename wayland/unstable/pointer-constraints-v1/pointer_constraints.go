@@ -28,7 +28,11 @@
 
 package pointer_constraints
 
-import "github.com/rajveermalviya/go-wayland/wayland/client"
+import (
+	"log/slog"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+)
 
 // PointerConstraints : constrain the movement of a pointer
 //
@@ -70,6 +74,59 @@ func NewPointerConstraints(ctx *client.Context) *PointerConstraints {
 	return zwpPointerConstraintsV1
 }
 
+// PointerConstraintsInterface describes zwp_pointer_constraints_v1 at runtime.
+var PointerConstraintsInterface = &client.Interface{
+	Name:    "zwp_pointer_constraints_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &PointerConstraints{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "lock_pointer",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zwp_locked_pointer_v1"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+				{Name: "pointer", Type: "object", Interface: "wl_pointer"},
+				{Name: "region", Type: "object", Interface: "wl_region", Nullable: true},
+				{Name: "lifetime", Type: "uint", Enum: "zwp_pointer_constraints_v1.lifetime"},
+			},
+		},
+		{
+			Name: "confine_pointer",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zwp_confined_pointer_v1"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+				{Name: "pointer", Type: "object", Interface: "wl_pointer"},
+				{Name: "region", Type: "object", Interface: "wl_region", Nullable: true},
+				{Name: "lifetime", Type: "uint", Enum: "zwp_pointer_constraints_v1.lifetime"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "already_constrained", Value: 1},
+			},
+		},
+		{
+			Name: "lifetime",
+			Entries: []client.EnumEntry{
+				{Name: "oneshot", Value: 1},
+				{Name: "persistent", Value: 2},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_pointer_constraints_v1.
+func (i *PointerConstraints) Interface() *client.Interface {
+	return PointerConstraintsInterface
+}
+
 // Destroy : destroy the pointer constraints manager object
 //
 // Used by the client to notify the server that it will no longer use this
@@ -129,8 +186,9 @@ func (i *PointerConstraints) Destroy() error {
 //	pointer: the pointer that should be locked
 //	region: region of surface
 //	lifetime: lock lifetime
-func (i *PointerConstraints) LockPointer(surface *client.Surface, pointer *client.Pointer, region *client.Region, lifetime uint32) (*LockedPointer, error) {
+func (i *PointerConstraints) LockPointer(surface *client.Surface, pointer *client.Pointer, region *client.Region, lifetime PointerConstraintsLifetime) (*LockedPointer, error) {
 	id := NewLockedPointer(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4 + 4 + 4 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -182,8 +240,9 @@ func (i *PointerConstraints) LockPointer(surface *client.Surface, pointer *clien
 //	pointer: the pointer that should be confined
 //	region: region of surface
 //	lifetime: confinement lifetime
-func (i *PointerConstraints) ConfinePointer(surface *client.Surface, pointer *client.Pointer, region *client.Region, lifetime uint32) (*ConfinedPointer, error) {
+func (i *PointerConstraints) ConfinePointer(surface *client.Surface, pointer *client.Pointer, region *client.Region, lifetime PointerConstraintsLifetime) (*ConfinedPointer, error) {
 	id := NewConfinedPointer(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 2
 	const _reqBufLen = 8 + 4 + 4 + 4 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -344,6 +403,45 @@ func NewLockedPointer(ctx *client.Context) *LockedPointer {
 	return zwpLockedPointerV1
 }
 
+// LockedPointerInterface describes zwp_locked_pointer_v1 at runtime.
+var LockedPointerInterface = &client.Interface{
+	Name:    "zwp_locked_pointer_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &LockedPointer{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "set_cursor_position_hint",
+			Args: []client.Arg{
+				{Name: "surface_x", Type: "fixed"},
+				{Name: "surface_y", Type: "fixed"},
+			},
+		},
+		{
+			Name: "set_region",
+			Args: []client.Arg{
+				{Name: "region", Type: "object", Interface: "wl_region", Nullable: true},
+			},
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "locked",
+		},
+		{
+			Name: "unlocked",
+		},
+	},
+}
+
+// Interface returns the description of zwp_locked_pointer_v1.
+func (i *LockedPointer) Interface() *client.Interface {
+	return LockedPointerInterface
+}
+
 // Destroy : destroy the locked pointer object
 //
 // Destroy the locked pointer object. If applicable, the compositor will
@@ -437,6 +535,16 @@ func (i *LockedPointer) SetLockedHandler(f LockedPointerLockedHandlerFunc) {
 	i.lockedHandler = f
 }
 
+// String formats the event as zwp_locked_pointer_v1.locked(argument=value, ...).
+func (e LockedPointerLockedEvent) String() string {
+	return "zwp_locked_pointer_v1.locked()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e LockedPointerLockedEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // LockedPointerUnlockedEvent : lock deactivation event
 //
 // Notification that the pointer lock of the seat's pointer is no longer
@@ -453,6 +561,45 @@ func (i *LockedPointer) SetUnlockedHandler(f LockedPointerUnlockedHandlerFunc) {
 	i.unlockedHandler = f
 }
 
+// String formats the event as zwp_locked_pointer_v1.unlocked(argument=value, ...).
+func (e LockedPointerUnlockedEvent) String() string {
+	return "zwp_locked_pointer_v1.unlocked()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e LockedPointerUnlockedEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
+// LockedPointerListener receives all the events of LockedPointer, embed
+// LockedPointerNoopListener to implement only some of them.
+type LockedPointerListener interface {
+	Locked(e LockedPointerLockedEvent)
+	Unlocked(e LockedPointerUnlockedEvent)
+}
+
+// LockedPointerNoopListener is a LockedPointerListener ignoring
+// all the events, it closes the file descriptors they carry.
+type LockedPointerNoopListener struct{}
+
+func (LockedPointerNoopListener) Locked(LockedPointerLockedEvent) {
+}
+
+func (LockedPointerNoopListener) Unlocked(LockedPointerUnlockedEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *LockedPointer) SetListener(l LockedPointerListener) {
+	if l == nil {
+		i.lockedHandler = nil
+		i.unlockedHandler = nil
+		return
+	}
+	i.lockedHandler = l.Locked
+	i.unlockedHandler = l.Unlocked
+}
+
 func (i *LockedPointer) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -520,6 +667,38 @@ func NewConfinedPointer(ctx *client.Context) *ConfinedPointer {
 	return zwpConfinedPointerV1
 }
 
+// ConfinedPointerInterface describes zwp_confined_pointer_v1 at runtime.
+var ConfinedPointerInterface = &client.Interface{
+	Name:    "zwp_confined_pointer_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &ConfinedPointer{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "set_region",
+			Args: []client.Arg{
+				{Name: "region", Type: "object", Interface: "wl_region", Nullable: true},
+			},
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "confined",
+		},
+		{
+			Name: "unconfined",
+		},
+	},
+}
+
+// Interface returns the description of zwp_confined_pointer_v1.
+func (i *ConfinedPointer) Interface() *client.Interface {
+	return ConfinedPointerInterface
+}
+
 // Destroy : destroy the confined pointer object
 //
 // Destroy the confined pointer object. If applicable, the compositor will
@@ -590,6 +769,16 @@ func (i *ConfinedPointer) SetConfinedHandler(f ConfinedPointerConfinedHandlerFun
 	i.confinedHandler = f
 }
 
+// String formats the event as zwp_confined_pointer_v1.confined(argument=value, ...).
+func (e ConfinedPointerConfinedEvent) String() string {
+	return "zwp_confined_pointer_v1.confined()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e ConfinedPointerConfinedEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // ConfinedPointerUnconfinedEvent : pointer unconfined
 //
 // Notification that the pointer confinement of the seat's pointer is no
@@ -606,6 +795,45 @@ func (i *ConfinedPointer) SetUnconfinedHandler(f ConfinedPointerUnconfinedHandle
 	i.unconfinedHandler = f
 }
 
+// String formats the event as zwp_confined_pointer_v1.unconfined(argument=value, ...).
+func (e ConfinedPointerUnconfinedEvent) String() string {
+	return "zwp_confined_pointer_v1.unconfined()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e ConfinedPointerUnconfinedEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
+// ConfinedPointerListener receives all the events of ConfinedPointer, embed
+// ConfinedPointerNoopListener to implement only some of them.
+type ConfinedPointerListener interface {
+	Confined(e ConfinedPointerConfinedEvent)
+	Unconfined(e ConfinedPointerUnconfinedEvent)
+}
+
+// ConfinedPointerNoopListener is a ConfinedPointerListener ignoring
+// all the events, it closes the file descriptors they carry.
+type ConfinedPointerNoopListener struct{}
+
+func (ConfinedPointerNoopListener) Confined(ConfinedPointerConfinedEvent) {
+}
+
+func (ConfinedPointerNoopListener) Unconfined(ConfinedPointerUnconfinedEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *ConfinedPointer) SetListener(l ConfinedPointerListener) {
+	if l == nil {
+		i.confinedHandler = nil
+		i.unconfinedHandler = nil
+		return
+	}
+	i.confinedHandler = l.Confined
+	i.unconfinedHandler = l.Unconfined
+}
+
 func (i *ConfinedPointer) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -624,3 +852,9 @@ func (i *ConfinedPointer) Dispatch(opcode uint32, fd int, data []byte) {
 		i.unconfinedHandler(e)
 	}
 }
+
+func init() {
+	client.RegisterInterface(PointerConstraintsInterface)
+	client.RegisterInterface(LockedPointerInterface)
+	client.RegisterInterface(ConfinedPointerInterface)
+}
@@ -6,7 +6,12 @@
 
 package pointer_gestures
 
-import "github.com/rajveermalviya/go-wayland/wayland/client"
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+)
 
 // PointerGestures : touchpad gestures
 //
@@ -54,12 +59,54 @@ func NewPointerGestures(ctx *client.Context) *PointerGestures {
 	return zwpPointerGesturesV1
 }
 
+// PointerGesturesInterface describes zwp_pointer_gestures_v1 at runtime.
+var PointerGesturesInterface = &client.Interface{
+	Name:    "zwp_pointer_gestures_v1",
+	Version: 3,
+	New:     func() client.Proxy { return &PointerGestures{} },
+	Requests: []client.Message{
+		{
+			Name: "get_swipe_gesture",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zwp_pointer_gesture_swipe_v1"},
+				{Name: "pointer", Type: "object", Interface: "wl_pointer"},
+			},
+		},
+		{
+			Name: "get_pinch_gesture",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zwp_pointer_gesture_pinch_v1"},
+				{Name: "pointer", Type: "object", Interface: "wl_pointer"},
+			},
+		},
+		{
+			Name:       "release",
+			Since:      2,
+			Destructor: true,
+		},
+		{
+			Name:  "get_hold_gesture",
+			Since: 3,
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zwp_pointer_gesture_hold_v1"},
+				{Name: "pointer", Type: "object", Interface: "wl_pointer"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_pointer_gestures_v1.
+func (i *PointerGestures) Interface() *client.Interface {
+	return PointerGesturesInterface
+}
+
 // GetSwipeGesture : get swipe gesture
 //
 // Create a swipe gesture object. See the
 // wl_pointer_gesture_swipe interface for details.
 func (i *PointerGestures) GetSwipeGesture(pointer *client.Pointer) (*PointerGestureSwipe, error) {
 	id := NewPointerGestureSwipe(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 0
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -82,6 +129,7 @@ func (i *PointerGestures) GetSwipeGesture(pointer *client.Pointer) (*PointerGest
 // wl_pointer_gesture_pinch interface for details.
 func (i *PointerGestures) GetPinchGesture(pointer *client.Pointer) (*PointerGesturePinch, error) {
 	id := NewPointerGesturePinch(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -103,6 +151,9 @@ func (i *PointerGestures) GetPinchGesture(pointer *client.Pointer) (*PointerGest
 // Destroy the pointer gesture object. Swipe, pinch and hold objects
 // created via this gesture object remain valid.
 func (i *PointerGestures) Release() error {
+	if v := i.Version(); v != 0 && v < 2 {
+		return &client.VersionError{Interface: "zwp_pointer_gestures_v1", Request: "release", Since: 2, Version: v}
+	}
 	defer i.Context().Unregister(i)
 	const opcode = 2
 	const _reqBufLen = 8
@@ -121,7 +172,11 @@ func (i *PointerGestures) Release() error {
 // Create a hold gesture object. See the
 // wl_pointer_gesture_hold interface for details.
 func (i *PointerGestures) GetHoldGesture(pointer *client.Pointer) (*PointerGestureHold, error) {
+	if v := i.Version(); v != 0 && v < 3 {
+		return nil, &client.VersionError{Interface: "zwp_pointer_gestures_v1", Request: "get_hold_gesture", Since: 3, Version: v}
+	}
 	id := NewPointerGestureHold(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 3
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -138,6 +193,16 @@ func (i *PointerGestures) GetHoldGesture(pointer *client.Pointer) (*PointerGestu
 	return id, err
 }
 
+// Destroy sends release unless the version of the object is known to
+// predate it, in which case it only forgets the object.
+func (i *PointerGestures) Destroy() error {
+	if v := i.Version(); v != 0 && v < 2 {
+		i.Context().Unregister(i)
+		return nil
+	}
+	return i.Release()
+}
+
 // PointerGestureSwipe : a swipe gesture object
 //
 // A swipe gesture object notifies a client about a multi-finger swipe
@@ -185,6 +250,51 @@ func NewPointerGestureSwipe(ctx *client.Context) *PointerGestureSwipe {
 	return zwpPointerGestureSwipeV1
 }
 
+// PointerGestureSwipeInterface describes zwp_pointer_gesture_swipe_v1 at runtime.
+var PointerGestureSwipeInterface = &client.Interface{
+	Name:    "zwp_pointer_gesture_swipe_v1",
+	Version: 2,
+	New:     func() client.Proxy { return &PointerGestureSwipe{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "begin",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "time", Type: "uint"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+				{Name: "fingers", Type: "uint"},
+			},
+		},
+		{
+			Name: "update",
+			Args: []client.Arg{
+				{Name: "time", Type: "uint"},
+				{Name: "dx", Type: "fixed"},
+				{Name: "dy", Type: "fixed"},
+			},
+		},
+		{
+			Name: "end",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "time", Type: "uint"},
+				{Name: "cancelled", Type: "int"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_pointer_gesture_swipe_v1.
+func (i *PointerGestureSwipe) Interface() *client.Interface {
+	return PointerGestureSwipeInterface
+}
+
 // Destroy : destroy the pointer swipe gesture object
 func (i *PointerGestureSwipe) Destroy() error {
 	defer i.Context().Unregister(i)
@@ -217,6 +327,26 @@ func (i *PointerGestureSwipe) SetBeginHandler(f PointerGestureSwipeBeginHandlerF
 	i.beginHandler = f
 }
 
+// String formats the event as zwp_pointer_gesture_swipe_v1.begin(argument=value, ...).
+func (e PointerGestureSwipeBeginEvent) String() string {
+	return "zwp_pointer_gesture_swipe_v1.begin(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", time=" + strconv.FormatUint(uint64(e.Time), 10) +
+		", surface=" + client.ObjectString(e.Surface) +
+		", fingers=" + strconv.FormatUint(uint64(e.Fingers), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PointerGestureSwipeBeginEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.Uint64("time", uint64(e.Time)),
+		slog.String("surface", client.ObjectString(e.Surface)),
+		slog.Uint64("fingers", uint64(e.Fingers)),
+	)
+}
+
 // PointerGestureSwipeUpdateEvent : multi-finger swipe motion
 //
 // This event is sent when a multi-finger swipe gesture changes the
@@ -236,6 +366,24 @@ func (i *PointerGestureSwipe) SetUpdateHandler(f PointerGestureSwipeUpdateHandle
 	i.updateHandler = f
 }
 
+// String formats the event as zwp_pointer_gesture_swipe_v1.update(argument=value, ...).
+func (e PointerGestureSwipeUpdateEvent) String() string {
+	return "zwp_pointer_gesture_swipe_v1.update(" +
+		"time=" + strconv.FormatUint(uint64(e.Time), 10) +
+		", dx=" + strconv.FormatFloat(e.Dx, 'g', -1, 64) +
+		", dy=" + strconv.FormatFloat(e.Dy, 'g', -1, 64) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PointerGestureSwipeUpdateEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("time", uint64(e.Time)),
+		slog.Float64("dx", e.Dx),
+		slog.Float64("dy", e.Dy),
+	)
+}
+
 // PointerGestureSwipeEndEvent : multi-finger swipe end
 //
 // This event is sent when a multi-finger swipe gesture ceases to
@@ -257,6 +405,59 @@ func (i *PointerGestureSwipe) SetEndHandler(f PointerGestureSwipeEndHandlerFunc)
 	i.endHandler = f
 }
 
+// String formats the event as zwp_pointer_gesture_swipe_v1.end(argument=value, ...).
+func (e PointerGestureSwipeEndEvent) String() string {
+	return "zwp_pointer_gesture_swipe_v1.end(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", time=" + strconv.FormatUint(uint64(e.Time), 10) +
+		", cancelled=" + strconv.FormatInt(int64(e.Cancelled), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PointerGestureSwipeEndEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.Uint64("time", uint64(e.Time)),
+		slog.Int64("cancelled", int64(e.Cancelled)),
+	)
+}
+
+// PointerGestureSwipeListener receives all the events of PointerGestureSwipe, embed
+// PointerGestureSwipeNoopListener to implement only some of them.
+type PointerGestureSwipeListener interface {
+	Begin(e PointerGestureSwipeBeginEvent)
+	Update(e PointerGestureSwipeUpdateEvent)
+	End(e PointerGestureSwipeEndEvent)
+}
+
+// PointerGestureSwipeNoopListener is a PointerGestureSwipeListener ignoring
+// all the events, it closes the file descriptors they carry.
+type PointerGestureSwipeNoopListener struct{}
+
+func (PointerGestureSwipeNoopListener) Begin(PointerGestureSwipeBeginEvent) {
+}
+
+func (PointerGestureSwipeNoopListener) Update(PointerGestureSwipeUpdateEvent) {
+}
+
+func (PointerGestureSwipeNoopListener) End(PointerGestureSwipeEndEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *PointerGestureSwipe) SetListener(l PointerGestureSwipeListener) {
+	if l == nil {
+		i.beginHandler = nil
+		i.updateHandler = nil
+		i.endHandler = nil
+		return
+	}
+	i.beginHandler = l.Begin
+	i.updateHandler = l.Update
+	i.endHandler = l.End
+}
+
 func (i *PointerGestureSwipe) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -269,7 +470,7 @@ func (i *PointerGestureSwipe) Dispatch(opcode uint32, fd int, data []byte) {
 		l += 4
 		e.Time = client.Uint32(data[l : l+4])
 		l += 4
-		e.Surface = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*client.Surface)
+		e.Surface, _ = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*client.Surface)
 		l += 4
 		e.Fingers = client.Uint32(data[l : l+4])
 		l += 4
@@ -283,9 +484,9 @@ func (i *PointerGestureSwipe) Dispatch(opcode uint32, fd int, data []byte) {
 		l := 0
 		e.Time = client.Uint32(data[l : l+4])
 		l += 4
-		e.Dx = client.Fixed(data[l : l+4])
+		e.Dx = client.FixedFloat64(data[l : l+4])
 		l += 4
-		e.Dy = client.Fixed(data[l : l+4])
+		e.Dy = client.FixedFloat64(data[l : l+4])
 		l += 4
 
 		i.updateHandler(e)
@@ -353,6 +554,53 @@ func NewPointerGesturePinch(ctx *client.Context) *PointerGesturePinch {
 	return zwpPointerGesturePinchV1
 }
 
+// PointerGesturePinchInterface describes zwp_pointer_gesture_pinch_v1 at runtime.
+var PointerGesturePinchInterface = &client.Interface{
+	Name:    "zwp_pointer_gesture_pinch_v1",
+	Version: 2,
+	New:     func() client.Proxy { return &PointerGesturePinch{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "begin",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "time", Type: "uint"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+				{Name: "fingers", Type: "uint"},
+			},
+		},
+		{
+			Name: "update",
+			Args: []client.Arg{
+				{Name: "time", Type: "uint"},
+				{Name: "dx", Type: "fixed"},
+				{Name: "dy", Type: "fixed"},
+				{Name: "scale", Type: "fixed"},
+				{Name: "rotation", Type: "fixed"},
+			},
+		},
+		{
+			Name: "end",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "time", Type: "uint"},
+				{Name: "cancelled", Type: "int"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_pointer_gesture_pinch_v1.
+func (i *PointerGesturePinch) Interface() *client.Interface {
+	return PointerGesturePinchInterface
+}
+
 // Destroy : destroy the pinch gesture object
 func (i *PointerGesturePinch) Destroy() error {
 	defer i.Context().Unregister(i)
@@ -385,6 +633,26 @@ func (i *PointerGesturePinch) SetBeginHandler(f PointerGesturePinchBeginHandlerF
 	i.beginHandler = f
 }
 
+// String formats the event as zwp_pointer_gesture_pinch_v1.begin(argument=value, ...).
+func (e PointerGesturePinchBeginEvent) String() string {
+	return "zwp_pointer_gesture_pinch_v1.begin(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", time=" + strconv.FormatUint(uint64(e.Time), 10) +
+		", surface=" + client.ObjectString(e.Surface) +
+		", fingers=" + strconv.FormatUint(uint64(e.Fingers), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PointerGesturePinchBeginEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.Uint64("time", uint64(e.Time)),
+		slog.String("surface", client.ObjectString(e.Surface)),
+		slog.Uint64("fingers", uint64(e.Fingers)),
+	)
+}
+
 // PointerGesturePinchUpdateEvent : multi-finger pinch motion
 //
 // This event is sent when a multi-finger pinch gesture changes the
@@ -413,6 +681,28 @@ func (i *PointerGesturePinch) SetUpdateHandler(f PointerGesturePinchUpdateHandle
 	i.updateHandler = f
 }
 
+// String formats the event as zwp_pointer_gesture_pinch_v1.update(argument=value, ...).
+func (e PointerGesturePinchUpdateEvent) String() string {
+	return "zwp_pointer_gesture_pinch_v1.update(" +
+		"time=" + strconv.FormatUint(uint64(e.Time), 10) +
+		", dx=" + strconv.FormatFloat(e.Dx, 'g', -1, 64) +
+		", dy=" + strconv.FormatFloat(e.Dy, 'g', -1, 64) +
+		", scale=" + strconv.FormatFloat(e.Scale, 'g', -1, 64) +
+		", rotation=" + strconv.FormatFloat(e.Rotation, 'g', -1, 64) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PointerGesturePinchUpdateEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("time", uint64(e.Time)),
+		slog.Float64("dx", e.Dx),
+		slog.Float64("dy", e.Dy),
+		slog.Float64("scale", e.Scale),
+		slog.Float64("rotation", e.Rotation),
+	)
+}
+
 // PointerGesturePinchEndEvent : multi-finger pinch end
 //
 // This event is sent when a multi-finger pinch gesture ceases to
@@ -434,6 +724,59 @@ func (i *PointerGesturePinch) SetEndHandler(f PointerGesturePinchEndHandlerFunc)
 	i.endHandler = f
 }
 
+// String formats the event as zwp_pointer_gesture_pinch_v1.end(argument=value, ...).
+func (e PointerGesturePinchEndEvent) String() string {
+	return "zwp_pointer_gesture_pinch_v1.end(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", time=" + strconv.FormatUint(uint64(e.Time), 10) +
+		", cancelled=" + strconv.FormatInt(int64(e.Cancelled), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PointerGesturePinchEndEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.Uint64("time", uint64(e.Time)),
+		slog.Int64("cancelled", int64(e.Cancelled)),
+	)
+}
+
+// PointerGesturePinchListener receives all the events of PointerGesturePinch, embed
+// PointerGesturePinchNoopListener to implement only some of them.
+type PointerGesturePinchListener interface {
+	Begin(e PointerGesturePinchBeginEvent)
+	Update(e PointerGesturePinchUpdateEvent)
+	End(e PointerGesturePinchEndEvent)
+}
+
+// PointerGesturePinchNoopListener is a PointerGesturePinchListener ignoring
+// all the events, it closes the file descriptors they carry.
+type PointerGesturePinchNoopListener struct{}
+
+func (PointerGesturePinchNoopListener) Begin(PointerGesturePinchBeginEvent) {
+}
+
+func (PointerGesturePinchNoopListener) Update(PointerGesturePinchUpdateEvent) {
+}
+
+func (PointerGesturePinchNoopListener) End(PointerGesturePinchEndEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *PointerGesturePinch) SetListener(l PointerGesturePinchListener) {
+	if l == nil {
+		i.beginHandler = nil
+		i.updateHandler = nil
+		i.endHandler = nil
+		return
+	}
+	i.beginHandler = l.Begin
+	i.updateHandler = l.Update
+	i.endHandler = l.End
+}
+
 func (i *PointerGesturePinch) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -446,7 +789,7 @@ func (i *PointerGesturePinch) Dispatch(opcode uint32, fd int, data []byte) {
 		l += 4
 		e.Time = client.Uint32(data[l : l+4])
 		l += 4
-		e.Surface = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*client.Surface)
+		e.Surface, _ = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*client.Surface)
 		l += 4
 		e.Fingers = client.Uint32(data[l : l+4])
 		l += 4
@@ -460,13 +803,13 @@ func (i *PointerGesturePinch) Dispatch(opcode uint32, fd int, data []byte) {
 		l := 0
 		e.Time = client.Uint32(data[l : l+4])
 		l += 4
-		e.Dx = client.Fixed(data[l : l+4])
+		e.Dx = client.FixedFloat64(data[l : l+4])
 		l += 4
-		e.Dy = client.Fixed(data[l : l+4])
+		e.Dy = client.FixedFloat64(data[l : l+4])
 		l += 4
-		e.Scale = client.Fixed(data[l : l+4])
+		e.Scale = client.FixedFloat64(data[l : l+4])
 		l += 4
-		e.Rotation = client.Fixed(data[l : l+4])
+		e.Rotation = client.FixedFloat64(data[l : l+4])
 		l += 4
 
 		i.updateHandler(e)
@@ -537,6 +880,43 @@ func NewPointerGestureHold(ctx *client.Context) *PointerGestureHold {
 	return zwpPointerGestureHoldV1
 }
 
+// PointerGestureHoldInterface describes zwp_pointer_gesture_hold_v1 at runtime.
+var PointerGestureHoldInterface = &client.Interface{
+	Name:    "zwp_pointer_gesture_hold_v1",
+	Version: 3,
+	New:     func() client.Proxy { return &PointerGestureHold{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "begin",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "time", Type: "uint"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+				{Name: "fingers", Type: "uint"},
+			},
+		},
+		{
+			Name: "end",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "time", Type: "uint"},
+				{Name: "cancelled", Type: "int"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_pointer_gesture_hold_v1.
+func (i *PointerGestureHold) Interface() *client.Interface {
+	return PointerGestureHoldInterface
+}
+
 // Destroy : destroy the hold gesture object
 func (i *PointerGestureHold) Destroy() error {
 	defer i.Context().Unregister(i)
@@ -568,6 +948,26 @@ func (i *PointerGestureHold) SetBeginHandler(f PointerGestureHoldBeginHandlerFun
 	i.beginHandler = f
 }
 
+// String formats the event as zwp_pointer_gesture_hold_v1.begin(argument=value, ...).
+func (e PointerGestureHoldBeginEvent) String() string {
+	return "zwp_pointer_gesture_hold_v1.begin(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", time=" + strconv.FormatUint(uint64(e.Time), 10) +
+		", surface=" + client.ObjectString(e.Surface) +
+		", fingers=" + strconv.FormatUint(uint64(e.Fingers), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PointerGestureHoldBeginEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.Uint64("time", uint64(e.Time)),
+		slog.String("surface", client.ObjectString(e.Surface)),
+		slog.Uint64("fingers", uint64(e.Fingers)),
+	)
+}
+
 // PointerGestureHoldEndEvent : multi-finger hold end
 //
 // This event is sent when a hold gesture ceases to
@@ -591,6 +991,53 @@ func (i *PointerGestureHold) SetEndHandler(f PointerGestureHoldEndHandlerFunc) {
 	i.endHandler = f
 }
 
+// String formats the event as zwp_pointer_gesture_hold_v1.end(argument=value, ...).
+func (e PointerGestureHoldEndEvent) String() string {
+	return "zwp_pointer_gesture_hold_v1.end(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", time=" + strconv.FormatUint(uint64(e.Time), 10) +
+		", cancelled=" + strconv.FormatInt(int64(e.Cancelled), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PointerGestureHoldEndEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.Uint64("time", uint64(e.Time)),
+		slog.Int64("cancelled", int64(e.Cancelled)),
+	)
+}
+
+// PointerGestureHoldListener receives all the events of PointerGestureHold, embed
+// PointerGestureHoldNoopListener to implement only some of them.
+type PointerGestureHoldListener interface {
+	Begin(e PointerGestureHoldBeginEvent)
+	End(e PointerGestureHoldEndEvent)
+}
+
+// PointerGestureHoldNoopListener is a PointerGestureHoldListener ignoring
+// all the events, it closes the file descriptors they carry.
+type PointerGestureHoldNoopListener struct{}
+
+func (PointerGestureHoldNoopListener) Begin(PointerGestureHoldBeginEvent) {
+}
+
+func (PointerGestureHoldNoopListener) End(PointerGestureHoldEndEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *PointerGestureHold) SetListener(l PointerGestureHoldListener) {
+	if l == nil {
+		i.beginHandler = nil
+		i.endHandler = nil
+		return
+	}
+	i.beginHandler = l.Begin
+	i.endHandler = l.End
+}
+
 func (i *PointerGestureHold) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -603,7 +1050,7 @@ func (i *PointerGestureHold) Dispatch(opcode uint32, fd int, data []byte) {
 		l += 4
 		e.Time = client.Uint32(data[l : l+4])
 		l += 4
-		e.Surface = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*client.Surface)
+		e.Surface, _ = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*client.Surface)
 		l += 4
 		e.Fingers = client.Uint32(data[l : l+4])
 		l += 4
@@ -625,3 +1072,10 @@ func (i *PointerGestureHold) Dispatch(opcode uint32, fd int, data []byte) {
 		i.endHandler(e)
 	}
 }
+
+func init() {
+	client.RegisterInterface(PointerGesturesInterface)
+	client.RegisterInterface(PointerGestureSwipeInterface)
+	client.RegisterInterface(PointerGesturePinchInterface)
+	client.RegisterInterface(PointerGestureHoldInterface)
+}
@@ -1,3 +1 @@
 package primary_selection
-
-//go:generate go run github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner -pkg primary_selection -prefix zwp -suffix v1 -o primary_selection.go -i https://raw.githubusercontent.com/wayland-project/wayland-protocols/1.31/unstable/primary-selection/primary-selection-unstable-v1.xml
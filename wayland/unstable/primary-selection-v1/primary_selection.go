@@ -28,6 +28,9 @@
 package primary_selection
 
 import (
+	"log/slog"
+	"strconv"
+
 	"github.com/rajveermalviya/go-wayland/wayland/client"
 	"golang.org/x/sys/unix"
 )
@@ -54,11 +57,43 @@ func NewPrimarySelectionDeviceManager(ctx *client.Context) *PrimarySelectionDevi
 	return zwpPrimarySelectionDeviceManagerV1
 }
 
+// PrimarySelectionDeviceManagerInterface describes zwp_primary_selection_device_manager_v1 at runtime.
+var PrimarySelectionDeviceManagerInterface = &client.Interface{
+	Name:    "zwp_primary_selection_device_manager_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &PrimarySelectionDeviceManager{} },
+	Requests: []client.Message{
+		{
+			Name: "create_source",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zwp_primary_selection_source_v1"},
+			},
+		},
+		{
+			Name: "get_device",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zwp_primary_selection_device_v1"},
+				{Name: "seat", Type: "object", Interface: "wl_seat"},
+			},
+		},
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+}
+
+// Interface returns the description of zwp_primary_selection_device_manager_v1.
+func (i *PrimarySelectionDeviceManager) Interface() *client.Interface {
+	return PrimarySelectionDeviceManagerInterface
+}
+
 // CreateSource : create a new primary selection source
 //
 // Create a new primary selection source.
 func (i *PrimarySelectionDeviceManager) CreateSource() (*PrimarySelectionSource, error) {
 	id := NewPrimarySelectionSource(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 0
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -78,6 +113,7 @@ func (i *PrimarySelectionDeviceManager) CreateSource() (*PrimarySelectionSource,
 // Create a new data device for a given seat.
 func (i *PrimarySelectionDeviceManager) GetDevice(seat *client.Seat) (*PrimarySelectionDevice, error) {
 	id := NewPrimarySelectionDevice(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -125,6 +161,45 @@ func NewPrimarySelectionDevice(ctx *client.Context) *PrimarySelectionDevice {
 	return zwpPrimarySelectionDeviceV1
 }
 
+// PrimarySelectionDeviceInterface describes zwp_primary_selection_device_v1 at runtime.
+var PrimarySelectionDeviceInterface = &client.Interface{
+	Name:    "zwp_primary_selection_device_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &PrimarySelectionDevice{} },
+	Requests: []client.Message{
+		{
+			Name: "set_selection",
+			Args: []client.Arg{
+				{Name: "source", Type: "object", Interface: "zwp_primary_selection_source_v1", Nullable: true},
+				{Name: "serial", Type: "uint"},
+			},
+		},
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "data_offer",
+			Args: []client.Arg{
+				{Name: "offer", Type: "new_id", Interface: "zwp_primary_selection_offer_v1"},
+			},
+		},
+		{
+			Name: "selection",
+			Args: []client.Arg{
+				{Name: "id", Type: "object", Interface: "zwp_primary_selection_offer_v1", Nullable: true},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_primary_selection_device_v1.
+func (i *PrimarySelectionDevice) Interface() *client.Interface {
+	return PrimarySelectionDeviceInterface
+}
+
 // SetSelection : set the primary selection
 //
 // Replaces the current selection. The previous owner of the primary
@@ -189,6 +264,20 @@ func (i *PrimarySelectionDevice) SetDataOfferHandler(f PrimarySelectionDeviceDat
 	i.dataOfferHandler = f
 }
 
+// String formats the event as zwp_primary_selection_device_v1.data_offer(argument=value, ...).
+func (e PrimarySelectionDeviceDataOfferEvent) String() string {
+	return "zwp_primary_selection_device_v1.data_offer(" +
+		"offer=" + client.ObjectString(e.Offer) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PrimarySelectionDeviceDataOfferEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("offer", client.ObjectString(e.Offer)),
+	)
+}
+
 // PrimarySelectionDeviceSelectionEvent : advertise a new primary selection
 //
 // The wp_primary_selection_device.selection event is sent to notify the
@@ -210,25 +299,69 @@ func (i *PrimarySelectionDevice) SetSelectionHandler(f PrimarySelectionDeviceSel
 	i.selectionHandler = f
 }
 
+// String formats the event as zwp_primary_selection_device_v1.selection(argument=value, ...).
+func (e PrimarySelectionDeviceSelectionEvent) String() string {
+	return "zwp_primary_selection_device_v1.selection(" +
+		"id=" + client.ObjectString(e.Id) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PrimarySelectionDeviceSelectionEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", client.ObjectString(e.Id)),
+	)
+}
+
+// PrimarySelectionDeviceListener receives all the events of PrimarySelectionDevice, embed
+// PrimarySelectionDeviceNoopListener to implement only some of them.
+type PrimarySelectionDeviceListener interface {
+	DataOffer(e PrimarySelectionDeviceDataOfferEvent)
+	Selection(e PrimarySelectionDeviceSelectionEvent)
+}
+
+// PrimarySelectionDeviceNoopListener is a PrimarySelectionDeviceListener ignoring
+// all the events, it closes the file descriptors they carry.
+type PrimarySelectionDeviceNoopListener struct{}
+
+func (PrimarySelectionDeviceNoopListener) DataOffer(PrimarySelectionDeviceDataOfferEvent) {
+}
+
+func (PrimarySelectionDeviceNoopListener) Selection(PrimarySelectionDeviceSelectionEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *PrimarySelectionDevice) SetListener(l PrimarySelectionDeviceListener) {
+	if l == nil {
+		i.dataOfferHandler = nil
+		i.selectionHandler = nil
+		return
+	}
+	i.dataOfferHandler = l.DataOffer
+	i.selectionHandler = l.Selection
+}
+
 func (i *PrimarySelectionDevice) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
-		if i.dataOfferHandler == nil {
-			return
-		}
 		var e PrimarySelectionDeviceDataOfferEvent
 		l := 0
-		e.Offer = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*PrimarySelectionOffer)
+		e.Offer = &PrimarySelectionOffer{}
+		i.Context().RegisterWithID(e.Offer, client.Uint32(data[l:l+4]))
+		e.Offer.SetVersion(i.Version())
 		l += 4
 
-		i.dataOfferHandler(e)
+		if i.dataOfferHandler != nil {
+			i.dataOfferHandler(e)
+		}
 	case 1:
 		if i.selectionHandler == nil {
 			return
 		}
 		var e PrimarySelectionDeviceSelectionEvent
 		l := 0
-		e.Id = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*PrimarySelectionOffer)
+		e.Id, _ = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*PrimarySelectionOffer)
 		l += 4
 
 		i.selectionHandler(e)
@@ -260,6 +393,39 @@ func NewPrimarySelectionOffer(ctx *client.Context) *PrimarySelectionOffer {
 	return zwpPrimarySelectionOfferV1
 }
 
+// PrimarySelectionOfferInterface describes zwp_primary_selection_offer_v1 at runtime.
+var PrimarySelectionOfferInterface = &client.Interface{
+	Name:    "zwp_primary_selection_offer_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &PrimarySelectionOffer{} },
+	Requests: []client.Message{
+		{
+			Name: "receive",
+			Args: []client.Arg{
+				{Name: "mime_type", Type: "string"},
+				{Name: "fd", Type: "fd"},
+			},
+		},
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "offer",
+			Args: []client.Arg{
+				{Name: "mime_type", Type: "string"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_primary_selection_offer_v1.
+func (i *PrimarySelectionOffer) Interface() *client.Interface {
+	return PrimarySelectionOfferInterface
+}
+
 // Receive : request that the data is transferred
 //
 // To transfer the contents of the primary selection clipboard, the client
@@ -281,7 +447,7 @@ func (i *PrimarySelectionOffer) Receive(mimeType string, fd int) error {
 	l += 4
 	client.PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
 	l += 4
-	client.PutString(_reqBuf[l:l+(4+mimeTypeLen)], mimeType, mimeTypeLen)
+	client.PutString(_reqBuf[l:l+(4+mimeTypeLen)], mimeType)
 	l += (4 + mimeTypeLen)
 	oob := unix.UnixRights(int(fd))
 	err := i.Context().WriteMsg(_reqBuf, oob)
@@ -321,6 +487,43 @@ func (i *PrimarySelectionOffer) SetOfferHandler(f PrimarySelectionOfferOfferHand
 	i.offerHandler = f
 }
 
+// String formats the event as zwp_primary_selection_offer_v1.offer(argument=value, ...).
+func (e PrimarySelectionOfferOfferEvent) String() string {
+	return "zwp_primary_selection_offer_v1.offer(" +
+		"mime_type=" + strconv.Quote(e.MimeType) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PrimarySelectionOfferOfferEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("mime_type", e.MimeType),
+	)
+}
+
+// PrimarySelectionOfferListener receives all the events of PrimarySelectionOffer, embed
+// PrimarySelectionOfferNoopListener to implement only some of them.
+type PrimarySelectionOfferListener interface {
+	Offer(e PrimarySelectionOfferOfferEvent)
+}
+
+// PrimarySelectionOfferNoopListener is a PrimarySelectionOfferListener ignoring
+// all the events, it closes the file descriptors they carry.
+type PrimarySelectionOfferNoopListener struct{}
+
+func (PrimarySelectionOfferNoopListener) Offer(PrimarySelectionOfferOfferEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *PrimarySelectionOffer) SetListener(l PrimarySelectionOfferListener) {
+	if l == nil {
+		i.offerHandler = nil
+		return
+	}
+	i.offerHandler = l.Offer
+}
+
 func (i *PrimarySelectionOffer) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -360,6 +563,42 @@ func NewPrimarySelectionSource(ctx *client.Context) *PrimarySelectionSource {
 	return zwpPrimarySelectionSourceV1
 }
 
+// PrimarySelectionSourceInterface describes zwp_primary_selection_source_v1 at runtime.
+var PrimarySelectionSourceInterface = &client.Interface{
+	Name:    "zwp_primary_selection_source_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &PrimarySelectionSource{} },
+	Requests: []client.Message{
+		{
+			Name: "offer",
+			Args: []client.Arg{
+				{Name: "mime_type", Type: "string"},
+			},
+		},
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "send",
+			Args: []client.Arg{
+				{Name: "mime_type", Type: "string"},
+				{Name: "fd", Type: "fd"},
+			},
+		},
+		{
+			Name: "cancelled",
+		},
+	},
+}
+
+// Interface returns the description of zwp_primary_selection_source_v1.
+func (i *PrimarySelectionSource) Interface() *client.Interface {
+	return PrimarySelectionSourceInterface
+}
+
 // Offer : add an offered mime type
 //
 // This request adds a mime type to the set of mime types advertised to
@@ -374,7 +613,7 @@ func (i *PrimarySelectionSource) Offer(mimeType string) error {
 	l += 4
 	client.PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
 	l += 4
-	client.PutString(_reqBuf[l:l+(4+mimeTypeLen)], mimeType, mimeTypeLen)
+	client.PutString(_reqBuf[l:l+(4+mimeTypeLen)], mimeType)
 	l += (4 + mimeTypeLen)
 	err := i.Context().WriteMsg(_reqBuf, nil)
 	return err
@@ -413,6 +652,22 @@ func (i *PrimarySelectionSource) SetSendHandler(f PrimarySelectionSourceSendHand
 	i.sendHandler = f
 }
 
+// String formats the event as zwp_primary_selection_source_v1.send(argument=value, ...).
+func (e PrimarySelectionSourceSendEvent) String() string {
+	return "zwp_primary_selection_source_v1.send(" +
+		"mime_type=" + strconv.Quote(e.MimeType) +
+		", fd=" + strconv.Itoa(e.Fd) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PrimarySelectionSourceSendEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("mime_type", e.MimeType),
+		slog.Int("fd", e.Fd),
+	)
+}
+
 // PrimarySelectionSourceCancelledEvent : request for primary selection contents was canceled
 //
 // This primary selection source is no longer valid. The client should
@@ -425,6 +680,46 @@ func (i *PrimarySelectionSource) SetCancelledHandler(f PrimarySelectionSourceCan
 	i.cancelledHandler = f
 }
 
+// String formats the event as zwp_primary_selection_source_v1.cancelled(argument=value, ...).
+func (e PrimarySelectionSourceCancelledEvent) String() string {
+	return "zwp_primary_selection_source_v1.cancelled()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PrimarySelectionSourceCancelledEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
+// PrimarySelectionSourceListener receives all the events of PrimarySelectionSource, embed
+// PrimarySelectionSourceNoopListener to implement only some of them.
+type PrimarySelectionSourceListener interface {
+	Send(e PrimarySelectionSourceSendEvent)
+	Cancelled(e PrimarySelectionSourceCancelledEvent)
+}
+
+// PrimarySelectionSourceNoopListener is a PrimarySelectionSourceListener ignoring
+// all the events, it closes the file descriptors they carry.
+type PrimarySelectionSourceNoopListener struct{}
+
+func (PrimarySelectionSourceNoopListener) Send(e PrimarySelectionSourceSendEvent) {
+	unix.Close(e.Fd)
+}
+
+func (PrimarySelectionSourceNoopListener) Cancelled(PrimarySelectionSourceCancelledEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *PrimarySelectionSource) SetListener(l PrimarySelectionSourceListener) {
+	if l == nil {
+		i.sendHandler = nil
+		i.cancelledHandler = nil
+		return
+	}
+	i.sendHandler = l.Send
+	i.cancelledHandler = l.Cancelled
+}
+
 func (i *PrimarySelectionSource) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -452,3 +747,10 @@ func (i *PrimarySelectionSource) Dispatch(opcode uint32, fd int, data []byte) {
 		i.cancelledHandler(e)
 	}
 }
+
+func init() {
+	client.RegisterInterface(PrimarySelectionDeviceManagerInterface)
+	client.RegisterInterface(PrimarySelectionDeviceInterface)
+	client.RegisterInterface(PrimarySelectionOfferInterface)
+	client.RegisterInterface(PrimarySelectionSourceInterface)
+}
@@ -28,7 +28,12 @@
 
 package relative_pointer
 
-import "github.com/rajveermalviya/go-wayland/wayland/client"
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+)
 
 // RelativePointerManager : get relative pointer objects
 //
@@ -48,6 +53,31 @@ func NewRelativePointerManager(ctx *client.Context) *RelativePointerManager {
 	return zwpRelativePointerManagerV1
 }
 
+// RelativePointerManagerInterface describes zwp_relative_pointer_manager_v1 at runtime.
+var RelativePointerManagerInterface = &client.Interface{
+	Name:    "zwp_relative_pointer_manager_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &RelativePointerManager{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "get_relative_pointer",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zwp_relative_pointer_v1"},
+				{Name: "pointer", Type: "object", Interface: "wl_pointer"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_relative_pointer_manager_v1.
+func (i *RelativePointerManager) Interface() *client.Interface {
+	return RelativePointerManagerInterface
+}
+
 // Destroy : destroy the relative pointer manager object
 //
 // Used by the client to notify the server that it will no longer use this
@@ -72,6 +102,7 @@ func (i *RelativePointerManager) Destroy() error {
 // wp_relative_pointer interface for more details.
 func (i *RelativePointerManager) GetRelativePointer(pointer *client.Pointer) (*RelativePointer, error) {
 	id := NewRelativePointer(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -111,6 +142,37 @@ func NewRelativePointer(ctx *client.Context) *RelativePointer {
 	return zwpRelativePointerV1
 }
 
+// RelativePointerInterface describes zwp_relative_pointer_v1 at runtime.
+var RelativePointerInterface = &client.Interface{
+	Name:    "zwp_relative_pointer_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &RelativePointer{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "relative_motion",
+			Args: []client.Arg{
+				{Name: "utime_hi", Type: "uint"},
+				{Name: "utime_lo", Type: "uint"},
+				{Name: "dx", Type: "fixed"},
+				{Name: "dy", Type: "fixed"},
+				{Name: "dx_unaccel", Type: "fixed"},
+				{Name: "dy_unaccel", Type: "fixed"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_relative_pointer_v1.
+func (i *RelativePointer) Interface() *client.Interface {
+	return RelativePointerInterface
+}
+
 // Destroy : release the relative pointer object
 func (i *RelativePointer) Destroy() error {
 	defer i.Context().Unregister(i)
@@ -173,6 +235,53 @@ func (i *RelativePointer) SetRelativeMotionHandler(f RelativePointerRelativeMoti
 	i.relativeMotionHandler = f
 }
 
+// String formats the event as zwp_relative_pointer_v1.relative_motion(argument=value, ...).
+func (e RelativePointerRelativeMotionEvent) String() string {
+	return "zwp_relative_pointer_v1.relative_motion(" +
+		"utime_hi=" + strconv.FormatUint(uint64(e.UtimeHi), 10) +
+		", utime_lo=" + strconv.FormatUint(uint64(e.UtimeLo), 10) +
+		", dx=" + strconv.FormatFloat(e.Dx, 'g', -1, 64) +
+		", dy=" + strconv.FormatFloat(e.Dy, 'g', -1, 64) +
+		", dx_unaccel=" + strconv.FormatFloat(e.DxUnaccel, 'g', -1, 64) +
+		", dy_unaccel=" + strconv.FormatFloat(e.DyUnaccel, 'g', -1, 64) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e RelativePointerRelativeMotionEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("utime_hi", uint64(e.UtimeHi)),
+		slog.Uint64("utime_lo", uint64(e.UtimeLo)),
+		slog.Float64("dx", e.Dx),
+		slog.Float64("dy", e.Dy),
+		slog.Float64("dx_unaccel", e.DxUnaccel),
+		slog.Float64("dy_unaccel", e.DyUnaccel),
+	)
+}
+
+// RelativePointerListener receives all the events of RelativePointer, embed
+// RelativePointerNoopListener to implement only some of them.
+type RelativePointerListener interface {
+	RelativeMotion(e RelativePointerRelativeMotionEvent)
+}
+
+// RelativePointerNoopListener is a RelativePointerListener ignoring
+// all the events, it closes the file descriptors they carry.
+type RelativePointerNoopListener struct{}
+
+func (RelativePointerNoopListener) RelativeMotion(RelativePointerRelativeMotionEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *RelativePointer) SetListener(l RelativePointerListener) {
+	if l == nil {
+		i.relativeMotionHandler = nil
+		return
+	}
+	i.relativeMotionHandler = l.RelativeMotion
+}
+
 func (i *RelativePointer) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -185,15 +294,20 @@ func (i *RelativePointer) Dispatch(opcode uint32, fd int, data []byte) {
 		l += 4
 		e.UtimeLo = client.Uint32(data[l : l+4])
 		l += 4
-		e.Dx = client.Fixed(data[l : l+4])
+		e.Dx = client.FixedFloat64(data[l : l+4])
 		l += 4
-		e.Dy = client.Fixed(data[l : l+4])
+		e.Dy = client.FixedFloat64(data[l : l+4])
 		l += 4
-		e.DxUnaccel = client.Fixed(data[l : l+4])
+		e.DxUnaccel = client.FixedFloat64(data[l : l+4])
 		l += 4
-		e.DyUnaccel = client.Fixed(data[l : l+4])
+		e.DyUnaccel = client.FixedFloat64(data[l : l+4])
 		l += 4
 
 		i.relativeMotionHandler(e)
 	}
 }
+
+func init() {
+	client.RegisterInterface(RelativePointerManagerInterface)
+	client.RegisterInterface(RelativePointerInterface)
+}
@@ -1,3 +1 @@
 package tablet
-
-//go:generate go run github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner -pkg tablet -prefix zwp -suffix v1 -o tablet.go -i https://raw.githubusercontent.com/wayland-project/wayland-protocols/1.31/unstable/tablet/tablet-unstable-v1.xml
@@ -30,7 +30,12 @@
 
 package tablet
 
-import "github.com/rajveermalviya/go-wayland/wayland/client"
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+)
 
 // TabletManager : controller object for graphic tablet devices
 //
@@ -52,6 +57,31 @@ func NewTabletManager(ctx *client.Context) *TabletManager {
 	return zwpTabletManagerV1
 }
 
+// TabletManagerInterface describes zwp_tablet_manager_v1 at runtime.
+var TabletManagerInterface = &client.Interface{
+	Name:    "zwp_tablet_manager_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &TabletManager{} },
+	Requests: []client.Message{
+		{
+			Name: "get_tablet_seat",
+			Args: []client.Arg{
+				{Name: "tablet_seat", Type: "new_id", Interface: "zwp_tablet_seat_v1"},
+				{Name: "seat", Type: "object", Interface: "wl_seat"},
+			},
+		},
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+}
+
+// Interface returns the description of zwp_tablet_manager_v1.
+func (i *TabletManager) Interface() *client.Interface {
+	return TabletManagerInterface
+}
+
 // GetTabletSeat : get the tablet seat
 //
 // Get the wp_tablet_seat object for the given seat. This object
@@ -60,6 +90,7 @@ func NewTabletManager(ctx *client.Context) *TabletManager {
 //	seat: The wl_seat object to retrieve the tablets for
 func (i *TabletManager) GetTabletSeat(seat *client.Seat) (*TabletSeat, error) {
 	tabletSeat := NewTabletSeat(i.Context())
+	tabletSeat.SetVersion(i.Version())
 	const opcode = 0
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -116,6 +147,38 @@ func NewTabletSeat(ctx *client.Context) *TabletSeat {
 	return zwpTabletSeatV1
 }
 
+// TabletSeatInterface describes zwp_tablet_seat_v1 at runtime.
+var TabletSeatInterface = &client.Interface{
+	Name:    "zwp_tablet_seat_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &TabletSeat{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "tablet_added",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zwp_tablet_v1"},
+			},
+		},
+		{
+			Name: "tool_added",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zwp_tablet_tool_v1"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_tablet_seat_v1.
+func (i *TabletSeat) Interface() *client.Interface {
+	return TabletSeatInterface
+}
+
 // Destroy : release the memory for the tablet seat object
 //
 // Destroy the wp_tablet_seat object. Objects created from this
@@ -150,6 +213,20 @@ func (i *TabletSeat) SetTabletAddedHandler(f TabletSeatTabletAddedHandlerFunc) {
 	i.tabletAddedHandler = f
 }
 
+// String formats the event as zwp_tablet_seat_v1.tablet_added(argument=value, ...).
+func (e TabletSeatTabletAddedEvent) String() string {
+	return "zwp_tablet_seat_v1.tablet_added(" +
+		"id=" + client.ObjectString(e.Id) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletSeatTabletAddedEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", client.ObjectString(e.Id)),
+	)
+}
+
 // TabletSeatToolAddedEvent : a new tool has been used with a tablet
 //
 // This event is sent whenever a tool that has not previously been used
@@ -166,28 +243,73 @@ func (i *TabletSeat) SetToolAddedHandler(f TabletSeatToolAddedHandlerFunc) {
 	i.toolAddedHandler = f
 }
 
+// String formats the event as zwp_tablet_seat_v1.tool_added(argument=value, ...).
+func (e TabletSeatToolAddedEvent) String() string {
+	return "zwp_tablet_seat_v1.tool_added(" +
+		"id=" + client.ObjectString(e.Id) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletSeatToolAddedEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", client.ObjectString(e.Id)),
+	)
+}
+
+// TabletSeatListener receives all the events of TabletSeat, embed
+// TabletSeatNoopListener to implement only some of them.
+type TabletSeatListener interface {
+	TabletAdded(e TabletSeatTabletAddedEvent)
+	ToolAdded(e TabletSeatToolAddedEvent)
+}
+
+// TabletSeatNoopListener is a TabletSeatListener ignoring
+// all the events, it closes the file descriptors they carry.
+type TabletSeatNoopListener struct{}
+
+func (TabletSeatNoopListener) TabletAdded(TabletSeatTabletAddedEvent) {
+}
+
+func (TabletSeatNoopListener) ToolAdded(TabletSeatToolAddedEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *TabletSeat) SetListener(l TabletSeatListener) {
+	if l == nil {
+		i.tabletAddedHandler = nil
+		i.toolAddedHandler = nil
+		return
+	}
+	i.tabletAddedHandler = l.TabletAdded
+	i.toolAddedHandler = l.ToolAdded
+}
+
 func (i *TabletSeat) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
-		if i.tabletAddedHandler == nil {
-			return
-		}
 		var e TabletSeatTabletAddedEvent
 		l := 0
-		e.Id = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*Tablet)
+		e.Id = &Tablet{}
+		i.Context().RegisterWithID(e.Id, client.Uint32(data[l:l+4]))
+		e.Id.SetVersion(i.Version())
 		l += 4
 
-		i.tabletAddedHandler(e)
-	case 1:
-		if i.toolAddedHandler == nil {
-			return
+		if i.tabletAddedHandler != nil {
+			i.tabletAddedHandler(e)
 		}
+	case 1:
 		var e TabletSeatToolAddedEvent
 		l := 0
-		e.Id = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*TabletTool)
+		e.Id = &TabletTool{}
+		i.Context().RegisterWithID(e.Id, client.Uint32(data[l:l+4]))
+		e.Id.SetVersion(i.Version())
 		l += 4
 
-		i.toolAddedHandler(e)
+		if i.toolAddedHandler != nil {
+			i.toolAddedHandler(e)
+		}
 	}
 }
 
@@ -264,6 +386,185 @@ func NewTabletTool(ctx *client.Context) *TabletTool {
 	return zwpTabletToolV1
 }
 
+// TabletToolInterface describes zwp_tablet_tool_v1 at runtime.
+var TabletToolInterface = &client.Interface{
+	Name:    "zwp_tablet_tool_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &TabletTool{} },
+	Requests: []client.Message{
+		{
+			Name: "set_cursor",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "surface", Type: "object", Interface: "wl_surface", Nullable: true},
+				{Name: "hotspot_x", Type: "int"},
+				{Name: "hotspot_y", Type: "int"},
+			},
+		},
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "type",
+			Args: []client.Arg{
+				{Name: "tool_type", Type: "uint", Enum: "zwp_tablet_tool_v1.type"},
+			},
+		},
+		{
+			Name: "hardware_serial",
+			Args: []client.Arg{
+				{Name: "hardware_serial_hi", Type: "uint"},
+				{Name: "hardware_serial_lo", Type: "uint"},
+			},
+		},
+		{
+			Name: "hardware_id_wacom",
+			Args: []client.Arg{
+				{Name: "hardware_id_hi", Type: "uint"},
+				{Name: "hardware_id_lo", Type: "uint"},
+			},
+		},
+		{
+			Name: "capability",
+			Args: []client.Arg{
+				{Name: "capability", Type: "uint", Enum: "zwp_tablet_tool_v1.capability"},
+			},
+		},
+		{
+			Name: "done",
+		},
+		{
+			Name: "removed",
+		},
+		{
+			Name: "proximity_in",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "tablet", Type: "object", Interface: "zwp_tablet_v1"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+			},
+		},
+		{
+			Name: "proximity_out",
+		},
+		{
+			Name: "down",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+			},
+		},
+		{
+			Name: "up",
+		},
+		{
+			Name: "motion",
+			Args: []client.Arg{
+				{Name: "x", Type: "fixed"},
+				{Name: "y", Type: "fixed"},
+			},
+		},
+		{
+			Name: "pressure",
+			Args: []client.Arg{
+				{Name: "pressure", Type: "uint"},
+			},
+		},
+		{
+			Name: "distance",
+			Args: []client.Arg{
+				{Name: "distance", Type: "uint"},
+			},
+		},
+		{
+			Name: "tilt",
+			Args: []client.Arg{
+				{Name: "tilt_x", Type: "int"},
+				{Name: "tilt_y", Type: "int"},
+			},
+		},
+		{
+			Name: "rotation",
+			Args: []client.Arg{
+				{Name: "degrees", Type: "int"},
+			},
+		},
+		{
+			Name: "slider",
+			Args: []client.Arg{
+				{Name: "position", Type: "int"},
+			},
+		},
+		{
+			Name: "wheel",
+			Args: []client.Arg{
+				{Name: "degrees", Type: "int"},
+				{Name: "clicks", Type: "int"},
+			},
+		},
+		{
+			Name: "button",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "button", Type: "uint"},
+				{Name: "state", Type: "uint", Enum: "zwp_tablet_tool_v1.button_state"},
+			},
+		},
+		{
+			Name: "frame",
+			Args: []client.Arg{
+				{Name: "time", Type: "uint"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "type",
+			Entries: []client.EnumEntry{
+				{Name: "pen", Value: 0x140},
+				{Name: "eraser", Value: 0x141},
+				{Name: "brush", Value: 0x142},
+				{Name: "pencil", Value: 0x143},
+				{Name: "airbrush", Value: 0x144},
+				{Name: "finger", Value: 0x145},
+				{Name: "mouse", Value: 0x146},
+				{Name: "lens", Value: 0x147},
+			},
+		},
+		{
+			Name: "capability",
+			Entries: []client.EnumEntry{
+				{Name: "tilt", Value: 1},
+				{Name: "pressure", Value: 2},
+				{Name: "distance", Value: 3},
+				{Name: "rotation", Value: 4},
+				{Name: "slider", Value: 5},
+				{Name: "wheel", Value: 6},
+			},
+		},
+		{
+			Name: "button_state",
+			Entries: []client.EnumEntry{
+				{Name: "released", Value: 0},
+				{Name: "pressed", Value: 1},
+			},
+		},
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "role", Value: 0},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_tablet_tool_v1.
+func (i *TabletTool) Interface() *client.Interface {
+	return TabletToolInterface
+}
+
 // SetCursor : set the tablet tool's surface
 //
 // Sets the surface of the cursor used for this tool on the given
@@ -568,7 +869,7 @@ func (e TabletToolError) String() string {
 // This event is sent in the initial burst of events before the
 // wp_tablet_tool.done event.
 type TabletToolTypeEvent struct {
-	ToolType uint32
+	ToolType TabletToolType
 }
 type TabletToolTypeHandlerFunc func(TabletToolTypeEvent)
 
@@ -577,6 +878,20 @@ func (i *TabletTool) SetTypeHandler(f TabletToolTypeHandlerFunc) {
 	i.typeHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v1.type(argument=value, ...).
+func (e TabletToolTypeEvent) String() string {
+	return "zwp_tablet_tool_v1.type(" +
+		"tool_type=" + client.EnumString(e.ToolType.Name(), uint32(e.ToolType)) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolTypeEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("tool_type", client.EnumString(e.ToolType.Name(), uint32(e.ToolType))),
+	)
+}
+
 // TabletToolHardwareSerialEvent : unique hardware serial number of the tool
 //
 // If the physical tool can be identified by a unique 64-bit serial
@@ -605,6 +920,22 @@ func (i *TabletTool) SetHardwareSerialHandler(f TabletToolHardwareSerialHandlerF
 	i.hardwareSerialHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v1.hardware_serial(argument=value, ...).
+func (e TabletToolHardwareSerialEvent) String() string {
+	return "zwp_tablet_tool_v1.hardware_serial(" +
+		"hardware_serial_hi=" + strconv.FormatUint(uint64(e.HardwareSerialHi), 10) +
+		", hardware_serial_lo=" + strconv.FormatUint(uint64(e.HardwareSerialLo), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolHardwareSerialEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("hardware_serial_hi", uint64(e.HardwareSerialHi)),
+		slog.Uint64("hardware_serial_lo", uint64(e.HardwareSerialLo)),
+	)
+}
+
 // TabletToolHardwareIdWacomEvent : hardware id notification in Wacom's format
 //
 // This event notifies the client of a hardware id available on this tool.
@@ -628,6 +959,22 @@ func (i *TabletTool) SetHardwareIdWacomHandler(f TabletToolHardwareIdWacomHandle
 	i.hardwareIdWacomHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v1.hardware_id_wacom(argument=value, ...).
+func (e TabletToolHardwareIdWacomEvent) String() string {
+	return "zwp_tablet_tool_v1.hardware_id_wacom(" +
+		"hardware_id_hi=" + strconv.FormatUint(uint64(e.HardwareIdHi), 10) +
+		", hardware_id_lo=" + strconv.FormatUint(uint64(e.HardwareIdLo), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolHardwareIdWacomEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("hardware_id_hi", uint64(e.HardwareIdHi)),
+		slog.Uint64("hardware_id_lo", uint64(e.HardwareIdLo)),
+	)
+}
+
 // TabletToolCapabilityEvent : tool capability notification
 //
 // This event notifies the client of any capabilities of this tool,
@@ -638,7 +985,7 @@ func (i *TabletTool) SetHardwareIdWacomHandler(f TabletToolHardwareIdWacomHandle
 // This event is sent in the initial burst of events before the
 // wp_tablet_tool.done event.
 type TabletToolCapabilityEvent struct {
-	Capability uint32
+	Capability TabletToolCapability
 }
 type TabletToolCapabilityHandlerFunc func(TabletToolCapabilityEvent)
 
@@ -647,6 +994,20 @@ func (i *TabletTool) SetCapabilityHandler(f TabletToolCapabilityHandlerFunc) {
 	i.capabilityHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v1.capability(argument=value, ...).
+func (e TabletToolCapabilityEvent) String() string {
+	return "zwp_tablet_tool_v1.capability(" +
+		"capability=" + client.EnumString(e.Capability.Name(), uint32(e.Capability)) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolCapabilityEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("capability", client.EnumString(e.Capability.Name(), uint32(e.Capability))),
+	)
+}
+
 // TabletToolDoneEvent : tool description events sequence complete
 //
 // This event signals the end of the initial burst of descriptive
@@ -660,6 +1021,16 @@ func (i *TabletTool) SetDoneHandler(f TabletToolDoneHandlerFunc) {
 	i.doneHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v1.done(argument=value, ...).
+func (e TabletToolDoneEvent) String() string {
+	return "zwp_tablet_tool_v1.done()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolDoneEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // TabletToolRemovedEvent : tool removed
 //
 // This event is sent when the tool is removed from the system and will
@@ -684,6 +1055,16 @@ func (i *TabletTool) SetRemovedHandler(f TabletToolRemovedHandlerFunc) {
 	i.removedHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v1.removed(argument=value, ...).
+func (e TabletToolRemovedEvent) String() string {
+	return "zwp_tablet_tool_v1.removed()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolRemovedEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // TabletToolProximityInEvent : proximity in event
 //
 // Notification that this tool is focused on a certain surface.
@@ -707,6 +1088,24 @@ func (i *TabletTool) SetProximityInHandler(f TabletToolProximityInHandlerFunc) {
 	i.proximityInHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v1.proximity_in(argument=value, ...).
+func (e TabletToolProximityInEvent) String() string {
+	return "zwp_tablet_tool_v1.proximity_in(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", tablet=" + client.ObjectString(e.Tablet) +
+		", surface=" + client.ObjectString(e.Surface) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolProximityInEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.String("tablet", client.ObjectString(e.Tablet)),
+		slog.String("surface", client.ObjectString(e.Surface)),
+	)
+}
+
 // TabletToolProximityOutEvent : proximity out event
 //
 // Notification that this tool has either left proximity, or is no
@@ -729,6 +1128,16 @@ func (i *TabletTool) SetProximityOutHandler(f TabletToolProximityOutHandlerFunc)
 	i.proximityOutHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v1.proximity_out(argument=value, ...).
+func (e TabletToolProximityOutEvent) String() string {
+	return "zwp_tablet_tool_v1.proximity_out()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolProximityOutEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // TabletToolDownEvent : tablet tool is making contact
 //
 // Sent whenever the tablet tool comes in contact with the surface of the
@@ -753,6 +1162,20 @@ func (i *TabletTool) SetDownHandler(f TabletToolDownHandlerFunc) {
 	i.downHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v1.down(argument=value, ...).
+func (e TabletToolDownEvent) String() string {
+	return "zwp_tablet_tool_v1.down(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolDownEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+	)
+}
+
 // TabletToolUpEvent : tablet tool is no longer making contact
 //
 // Sent whenever the tablet tool stops making contact with the surface of
@@ -779,6 +1202,16 @@ func (i *TabletTool) SetUpHandler(f TabletToolUpHandlerFunc) {
 	i.upHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v1.up(argument=value, ...).
+func (e TabletToolUpEvent) String() string {
+	return "zwp_tablet_tool_v1.up()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolUpEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // TabletToolMotionEvent : motion event
 //
 // Sent whenever a tablet tool moves.
@@ -793,6 +1226,22 @@ func (i *TabletTool) SetMotionHandler(f TabletToolMotionHandlerFunc) {
 	i.motionHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v1.motion(argument=value, ...).
+func (e TabletToolMotionEvent) String() string {
+	return "zwp_tablet_tool_v1.motion(" +
+		"x=" + strconv.FormatFloat(e.X, 'g', -1, 64) +
+		", y=" + strconv.FormatFloat(e.Y, 'g', -1, 64) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolMotionEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Float64("x", e.X),
+		slog.Float64("y", e.Y),
+	)
+}
+
 // TabletToolPressureEvent : pressure change event
 //
 // Sent whenever the pressure axis on a tool changes. The value of this
@@ -810,6 +1259,20 @@ func (i *TabletTool) SetPressureHandler(f TabletToolPressureHandlerFunc) {
 	i.pressureHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v1.pressure(argument=value, ...).
+func (e TabletToolPressureEvent) String() string {
+	return "zwp_tablet_tool_v1.pressure(" +
+		"pressure=" + strconv.FormatUint(uint64(e.Pressure), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolPressureEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("pressure", uint64(e.Pressure)),
+	)
+}
+
 // TabletToolDistanceEvent : distance change event
 //
 // Sent whenever the distance axis on a tool changes. The value of this
@@ -827,6 +1290,20 @@ func (i *TabletTool) SetDistanceHandler(f TabletToolDistanceHandlerFunc) {
 	i.distanceHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v1.distance(argument=value, ...).
+func (e TabletToolDistanceEvent) String() string {
+	return "zwp_tablet_tool_v1.distance(" +
+		"distance=" + strconv.FormatUint(uint64(e.Distance), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolDistanceEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("distance", uint64(e.Distance)),
+	)
+}
+
 // TabletToolTiltEvent : tilt change event
 //
 // Sent whenever one or both of the tilt axes on a tool change. Each tilt
@@ -844,6 +1321,22 @@ func (i *TabletTool) SetTiltHandler(f TabletToolTiltHandlerFunc) {
 	i.tiltHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v1.tilt(argument=value, ...).
+func (e TabletToolTiltEvent) String() string {
+	return "zwp_tablet_tool_v1.tilt(" +
+		"tilt_x=" + strconv.FormatInt(int64(e.TiltX), 10) +
+		", tilt_y=" + strconv.FormatInt(int64(e.TiltY), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolTiltEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int64("tilt_x", int64(e.TiltX)),
+		slog.Int64("tilt_y", int64(e.TiltY)),
+	)
+}
+
 // TabletToolRotationEvent : z-rotation change event
 //
 // Sent whenever the z-rotation axis on the tool changes. The
@@ -859,6 +1352,20 @@ func (i *TabletTool) SetRotationHandler(f TabletToolRotationHandlerFunc) {
 	i.rotationHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v1.rotation(argument=value, ...).
+func (e TabletToolRotationEvent) String() string {
+	return "zwp_tablet_tool_v1.rotation(" +
+		"degrees=" + strconv.FormatInt(int64(e.Degrees), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolRotationEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int64("degrees", int64(e.Degrees)),
+	)
+}
+
 // TabletToolSliderEvent : Slider position change event
 //
 // Sent whenever the slider position on the tool changes. The
@@ -876,6 +1383,20 @@ func (i *TabletTool) SetSliderHandler(f TabletToolSliderHandlerFunc) {
 	i.sliderHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v1.slider(argument=value, ...).
+func (e TabletToolSliderEvent) String() string {
+	return "zwp_tablet_tool_v1.slider(" +
+		"position=" + strconv.FormatInt(int64(e.Position), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolSliderEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int64("position", int64(e.Position)),
+	)
+}
+
 // TabletToolWheelEvent : Wheel delta event
 //
 // Sent whenever the wheel on the tool emits an event. This event
@@ -901,6 +1422,22 @@ func (i *TabletTool) SetWheelHandler(f TabletToolWheelHandlerFunc) {
 	i.wheelHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v1.wheel(argument=value, ...).
+func (e TabletToolWheelEvent) String() string {
+	return "zwp_tablet_tool_v1.wheel(" +
+		"degrees=" + strconv.FormatInt(int64(e.Degrees), 10) +
+		", clicks=" + strconv.FormatInt(int64(e.Clicks), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolWheelEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int64("degrees", int64(e.Degrees)),
+		slog.Int64("clicks", int64(e.Clicks)),
+	)
+}
+
 // TabletToolButtonEvent : button event
 //
 // Sent whenever a button on the tool is pressed or released.
@@ -912,7 +1449,7 @@ func (i *TabletTool) SetWheelHandler(f TabletToolWheelHandlerFunc) {
 type TabletToolButtonEvent struct {
 	Serial uint32
 	Button uint32
-	State  uint32
+	State  TabletToolButtonState
 }
 type TabletToolButtonHandlerFunc func(TabletToolButtonEvent)
 
@@ -921,6 +1458,24 @@ func (i *TabletTool) SetButtonHandler(f TabletToolButtonHandlerFunc) {
 	i.buttonHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v1.button(argument=value, ...).
+func (e TabletToolButtonEvent) String() string {
+	return "zwp_tablet_tool_v1.button(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", button=" + strconv.FormatUint(uint64(e.Button), 10) +
+		", state=" + client.EnumString(e.State.Name(), uint32(e.State)) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolButtonEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.Uint64("button", uint64(e.Button)),
+		slog.String("state", client.EnumString(e.State.Name(), uint32(e.State))),
+	)
+}
+
 // TabletToolFrameEvent : frame event
 //
 // Marks the end of a series of axis and/or button updates from the
@@ -937,6 +1492,151 @@ func (i *TabletTool) SetFrameHandler(f TabletToolFrameHandlerFunc) {
 	i.frameHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v1.frame(argument=value, ...).
+func (e TabletToolFrameEvent) String() string {
+	return "zwp_tablet_tool_v1.frame(" +
+		"time=" + strconv.FormatUint(uint64(e.Time), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolFrameEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("time", uint64(e.Time)),
+	)
+}
+
+// TabletToolListener receives all the events of TabletTool, embed
+// TabletToolNoopListener to implement only some of them.
+type TabletToolListener interface {
+	Type(e TabletToolTypeEvent)
+	HardwareSerial(e TabletToolHardwareSerialEvent)
+	HardwareIdWacom(e TabletToolHardwareIdWacomEvent)
+	Capability(e TabletToolCapabilityEvent)
+	Done(e TabletToolDoneEvent)
+	Removed(e TabletToolRemovedEvent)
+	ProximityIn(e TabletToolProximityInEvent)
+	ProximityOut(e TabletToolProximityOutEvent)
+	Down(e TabletToolDownEvent)
+	Up(e TabletToolUpEvent)
+	Motion(e TabletToolMotionEvent)
+	Pressure(e TabletToolPressureEvent)
+	Distance(e TabletToolDistanceEvent)
+	Tilt(e TabletToolTiltEvent)
+	Rotation(e TabletToolRotationEvent)
+	Slider(e TabletToolSliderEvent)
+	Wheel(e TabletToolWheelEvent)
+	Button(e TabletToolButtonEvent)
+	Frame(e TabletToolFrameEvent)
+}
+
+// TabletToolNoopListener is a TabletToolListener ignoring
+// all the events, it closes the file descriptors they carry.
+type TabletToolNoopListener struct{}
+
+func (TabletToolNoopListener) Type(TabletToolTypeEvent) {
+}
+
+func (TabletToolNoopListener) HardwareSerial(TabletToolHardwareSerialEvent) {
+}
+
+func (TabletToolNoopListener) HardwareIdWacom(TabletToolHardwareIdWacomEvent) {
+}
+
+func (TabletToolNoopListener) Capability(TabletToolCapabilityEvent) {
+}
+
+func (TabletToolNoopListener) Done(TabletToolDoneEvent) {
+}
+
+func (TabletToolNoopListener) Removed(TabletToolRemovedEvent) {
+}
+
+func (TabletToolNoopListener) ProximityIn(TabletToolProximityInEvent) {
+}
+
+func (TabletToolNoopListener) ProximityOut(TabletToolProximityOutEvent) {
+}
+
+func (TabletToolNoopListener) Down(TabletToolDownEvent) {
+}
+
+func (TabletToolNoopListener) Up(TabletToolUpEvent) {
+}
+
+func (TabletToolNoopListener) Motion(TabletToolMotionEvent) {
+}
+
+func (TabletToolNoopListener) Pressure(TabletToolPressureEvent) {
+}
+
+func (TabletToolNoopListener) Distance(TabletToolDistanceEvent) {
+}
+
+func (TabletToolNoopListener) Tilt(TabletToolTiltEvent) {
+}
+
+func (TabletToolNoopListener) Rotation(TabletToolRotationEvent) {
+}
+
+func (TabletToolNoopListener) Slider(TabletToolSliderEvent) {
+}
+
+func (TabletToolNoopListener) Wheel(TabletToolWheelEvent) {
+}
+
+func (TabletToolNoopListener) Button(TabletToolButtonEvent) {
+}
+
+func (TabletToolNoopListener) Frame(TabletToolFrameEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *TabletTool) SetListener(l TabletToolListener) {
+	if l == nil {
+		i.typeHandler = nil
+		i.hardwareSerialHandler = nil
+		i.hardwareIdWacomHandler = nil
+		i.capabilityHandler = nil
+		i.doneHandler = nil
+		i.removedHandler = nil
+		i.proximityInHandler = nil
+		i.proximityOutHandler = nil
+		i.downHandler = nil
+		i.upHandler = nil
+		i.motionHandler = nil
+		i.pressureHandler = nil
+		i.distanceHandler = nil
+		i.tiltHandler = nil
+		i.rotationHandler = nil
+		i.sliderHandler = nil
+		i.wheelHandler = nil
+		i.buttonHandler = nil
+		i.frameHandler = nil
+		return
+	}
+	i.typeHandler = l.Type
+	i.hardwareSerialHandler = l.HardwareSerial
+	i.hardwareIdWacomHandler = l.HardwareIdWacom
+	i.capabilityHandler = l.Capability
+	i.doneHandler = l.Done
+	i.removedHandler = l.Removed
+	i.proximityInHandler = l.ProximityIn
+	i.proximityOutHandler = l.ProximityOut
+	i.downHandler = l.Down
+	i.upHandler = l.Up
+	i.motionHandler = l.Motion
+	i.pressureHandler = l.Pressure
+	i.distanceHandler = l.Distance
+	i.tiltHandler = l.Tilt
+	i.rotationHandler = l.Rotation
+	i.sliderHandler = l.Slider
+	i.wheelHandler = l.Wheel
+	i.buttonHandler = l.Button
+	i.frameHandler = l.Frame
+}
+
 func (i *TabletTool) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -945,7 +1645,7 @@ func (i *TabletTool) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e TabletToolTypeEvent
 		l := 0
-		e.ToolType = client.Uint32(data[l : l+4])
+		e.ToolType = TabletToolType(client.Uint32(data[l : l+4]))
 		l += 4
 
 		i.typeHandler(e)
@@ -979,7 +1679,7 @@ func (i *TabletTool) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e TabletToolCapabilityEvent
 		l := 0
-		e.Capability = client.Uint32(data[l : l+4])
+		e.Capability = TabletToolCapability(client.Uint32(data[l : l+4]))
 		l += 4
 
 		i.capabilityHandler(e)
@@ -1005,9 +1705,9 @@ func (i *TabletTool) Dispatch(opcode uint32, fd int, data []byte) {
 		l := 0
 		e.Serial = client.Uint32(data[l : l+4])
 		l += 4
-		e.Tablet = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*Tablet)
+		e.Tablet, _ = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*Tablet)
 		l += 4
-		e.Surface = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*client.Surface)
+		e.Surface, _ = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*client.Surface)
 		l += 4
 
 		i.proximityInHandler(e)
@@ -1041,9 +1741,9 @@ func (i *TabletTool) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e TabletToolMotionEvent
 		l := 0
-		e.X = client.Fixed(data[l : l+4])
+		e.X = client.FixedFloat64(data[l : l+4])
 		l += 4
-		e.Y = client.Fixed(data[l : l+4])
+		e.Y = client.FixedFloat64(data[l : l+4])
 		l += 4
 
 		i.motionHandler(e)
@@ -1121,7 +1821,7 @@ func (i *TabletTool) Dispatch(opcode uint32, fd int, data []byte) {
 		l += 4
 		e.Button = client.Uint32(data[l : l+4])
 		l += 4
-		e.State = client.Uint32(data[l : l+4])
+		e.State = TabletToolButtonState(client.Uint32(data[l : l+4]))
 		l += 4
 
 		i.buttonHandler(e)
@@ -1173,6 +1873,51 @@ func NewTablet(ctx *client.Context) *Tablet {
 	return zwpTabletV1
 }
 
+// TabletInterface describes zwp_tablet_v1 at runtime.
+var TabletInterface = &client.Interface{
+	Name:    "zwp_tablet_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &Tablet{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "name",
+			Args: []client.Arg{
+				{Name: "name", Type: "string"},
+			},
+		},
+		{
+			Name: "id",
+			Args: []client.Arg{
+				{Name: "vid", Type: "uint"},
+				{Name: "pid", Type: "uint"},
+			},
+		},
+		{
+			Name: "path",
+			Args: []client.Arg{
+				{Name: "path", Type: "string"},
+			},
+		},
+		{
+			Name: "done",
+		},
+		{
+			Name: "removed",
+		},
+	},
+}
+
+// Interface returns the description of zwp_tablet_v1.
+func (i *Tablet) Interface() *client.Interface {
+	return TabletInterface
+}
+
 // Destroy : destroy the tablet object
 //
 // This destroys the client's resource for this tablet object.
@@ -1204,6 +1949,20 @@ func (i *Tablet) SetNameHandler(f TabletNameHandlerFunc) {
 	i.nameHandler = f
 }
 
+// String formats the event as zwp_tablet_v1.name(argument=value, ...).
+func (e TabletNameEvent) String() string {
+	return "zwp_tablet_v1.name(" +
+		"name=" + strconv.Quote(e.Name) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletNameEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("name", e.Name),
+	)
+}
+
 // TabletIdEvent : tablet device USB vendor/product id
 //
 // This event is sent in the initial burst of events before the
@@ -1219,6 +1978,22 @@ func (i *Tablet) SetIdHandler(f TabletIdHandlerFunc) {
 	i.idHandler = f
 }
 
+// String formats the event as zwp_tablet_v1.id(argument=value, ...).
+func (e TabletIdEvent) String() string {
+	return "zwp_tablet_v1.id(" +
+		"vid=" + strconv.FormatUint(uint64(e.Vid), 10) +
+		", pid=" + strconv.FormatUint(uint64(e.Pid), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletIdEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("vid", uint64(e.Vid)),
+		slog.Uint64("pid", uint64(e.Pid)),
+	)
+}
+
 // TabletPathEvent : path to the device
 //
 // A system-specific device path that indicates which device is behind
@@ -1245,6 +2020,20 @@ func (i *Tablet) SetPathHandler(f TabletPathHandlerFunc) {
 	i.pathHandler = f
 }
 
+// String formats the event as zwp_tablet_v1.path(argument=value, ...).
+func (e TabletPathEvent) String() string {
+	return "zwp_tablet_v1.path(" +
+		"path=" + strconv.Quote(e.Path) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletPathEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("path", e.Path),
+	)
+}
+
 // TabletDoneEvent : tablet description events sequence complete
 //
 // This event is sent immediately to signal the end of the initial
@@ -1259,6 +2048,16 @@ func (i *Tablet) SetDoneHandler(f TabletDoneHandlerFunc) {
 	i.doneHandler = f
 }
 
+// String formats the event as zwp_tablet_v1.done(argument=value, ...).
+func (e TabletDoneEvent) String() string {
+	return "zwp_tablet_v1.done()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletDoneEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // TabletRemovedEvent : tablet removed event
 //
 // Sent when the tablet has been removed from the system. When a tablet
@@ -1274,6 +2073,63 @@ func (i *Tablet) SetRemovedHandler(f TabletRemovedHandlerFunc) {
 	i.removedHandler = f
 }
 
+// String formats the event as zwp_tablet_v1.removed(argument=value, ...).
+func (e TabletRemovedEvent) String() string {
+	return "zwp_tablet_v1.removed()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletRemovedEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
+// TabletListener receives all the events of Tablet, embed
+// TabletNoopListener to implement only some of them.
+type TabletListener interface {
+	Name(e TabletNameEvent)
+	Id(e TabletIdEvent)
+	Path(e TabletPathEvent)
+	Done(e TabletDoneEvent)
+	Removed(e TabletRemovedEvent)
+}
+
+// TabletNoopListener is a TabletListener ignoring
+// all the events, it closes the file descriptors they carry.
+type TabletNoopListener struct{}
+
+func (TabletNoopListener) Name(TabletNameEvent) {
+}
+
+func (TabletNoopListener) Id(TabletIdEvent) {
+}
+
+func (TabletNoopListener) Path(TabletPathEvent) {
+}
+
+func (TabletNoopListener) Done(TabletDoneEvent) {
+}
+
+func (TabletNoopListener) Removed(TabletRemovedEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *Tablet) SetListener(l TabletListener) {
+	if l == nil {
+		i.nameHandler = nil
+		i.idHandler = nil
+		i.pathHandler = nil
+		i.doneHandler = nil
+		i.removedHandler = nil
+		return
+	}
+	i.nameHandler = l.Name
+	i.idHandler = l.Id
+	i.pathHandler = l.Path
+	i.doneHandler = l.Done
+	i.removedHandler = l.Removed
+}
+
 func (i *Tablet) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -1328,3 +2184,10 @@ func (i *Tablet) Dispatch(opcode uint32, fd int, data []byte) {
 		i.removedHandler(e)
 	}
 }
+
+func init() {
+	client.RegisterInterface(TabletManagerInterface)
+	client.RegisterInterface(TabletSeatInterface)
+	client.RegisterInterface(TabletToolInterface)
+	client.RegisterInterface(TabletInterface)
+}
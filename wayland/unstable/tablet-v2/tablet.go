@@ -30,7 +30,13 @@
 
 package tablet
 
-import "github.com/rajveermalviya/go-wayland/wayland/client"
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+)
 
 // TabletManager : controller object for graphic tablet devices
 //
@@ -52,6 +58,31 @@ func NewTabletManager(ctx *client.Context) *TabletManager {
 	return zwpTabletManagerV2
 }
 
+// TabletManagerInterface describes zwp_tablet_manager_v2 at runtime.
+var TabletManagerInterface = &client.Interface{
+	Name:    "zwp_tablet_manager_v2",
+	Version: 1,
+	New:     func() client.Proxy { return &TabletManager{} },
+	Requests: []client.Message{
+		{
+			Name: "get_tablet_seat",
+			Args: []client.Arg{
+				{Name: "tablet_seat", Type: "new_id", Interface: "zwp_tablet_seat_v2"},
+				{Name: "seat", Type: "object", Interface: "wl_seat"},
+			},
+		},
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+}
+
+// Interface returns the description of zwp_tablet_manager_v2.
+func (i *TabletManager) Interface() *client.Interface {
+	return TabletManagerInterface
+}
+
 // GetTabletSeat : get the tablet seat
 //
 // Get the wp_tablet_seat object for the given seat. This object
@@ -60,6 +91,7 @@ func NewTabletManager(ctx *client.Context) *TabletManager {
 //	seat: The wl_seat object to retrieve the tablets for
 func (i *TabletManager) GetTabletSeat(seat *client.Seat) (*TabletSeat, error) {
 	tabletSeat := NewTabletSeat(i.Context())
+	tabletSeat.SetVersion(i.Version())
 	const opcode = 0
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -117,6 +149,44 @@ func NewTabletSeat(ctx *client.Context) *TabletSeat {
 	return zwpTabletSeatV2
 }
 
+// TabletSeatInterface describes zwp_tablet_seat_v2 at runtime.
+var TabletSeatInterface = &client.Interface{
+	Name:    "zwp_tablet_seat_v2",
+	Version: 1,
+	New:     func() client.Proxy { return &TabletSeat{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "tablet_added",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zwp_tablet_v2"},
+			},
+		},
+		{
+			Name: "tool_added",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zwp_tablet_tool_v2"},
+			},
+		},
+		{
+			Name: "pad_added",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zwp_tablet_pad_v2"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_tablet_seat_v2.
+func (i *TabletSeat) Interface() *client.Interface {
+	return TabletSeatInterface
+}
+
 // Destroy : release the memory for the tablet seat object
 //
 // Destroy the wp_tablet_seat object. Objects created from this
@@ -151,6 +221,20 @@ func (i *TabletSeat) SetTabletAddedHandler(f TabletSeatTabletAddedHandlerFunc) {
 	i.tabletAddedHandler = f
 }
 
+// String formats the event as zwp_tablet_seat_v2.tablet_added(argument=value, ...).
+func (e TabletSeatTabletAddedEvent) String() string {
+	return "zwp_tablet_seat_v2.tablet_added(" +
+		"id=" + client.ObjectString(e.Id) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletSeatTabletAddedEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", client.ObjectString(e.Id)),
+	)
+}
+
 // TabletSeatToolAddedEvent : a new tool has been used with a tablet
 //
 // This event is sent whenever a tool that has not previously been used
@@ -167,6 +251,20 @@ func (i *TabletSeat) SetToolAddedHandler(f TabletSeatToolAddedHandlerFunc) {
 	i.toolAddedHandler = f
 }
 
+// String formats the event as zwp_tablet_seat_v2.tool_added(argument=value, ...).
+func (e TabletSeatToolAddedEvent) String() string {
+	return "zwp_tablet_seat_v2.tool_added(" +
+		"id=" + client.ObjectString(e.Id) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletSeatToolAddedEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", client.ObjectString(e.Id)),
+	)
+}
+
 // TabletSeatPadAddedEvent : new pad notification
 //
 // This event is sent whenever a new pad is known to the system. Typically,
@@ -189,38 +287,90 @@ func (i *TabletSeat) SetPadAddedHandler(f TabletSeatPadAddedHandlerFunc) {
 	i.padAddedHandler = f
 }
 
+// String formats the event as zwp_tablet_seat_v2.pad_added(argument=value, ...).
+func (e TabletSeatPadAddedEvent) String() string {
+	return "zwp_tablet_seat_v2.pad_added(" +
+		"id=" + client.ObjectString(e.Id) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletSeatPadAddedEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", client.ObjectString(e.Id)),
+	)
+}
+
+// TabletSeatListener receives all the events of TabletSeat, embed
+// TabletSeatNoopListener to implement only some of them.
+type TabletSeatListener interface {
+	TabletAdded(e TabletSeatTabletAddedEvent)
+	ToolAdded(e TabletSeatToolAddedEvent)
+	PadAdded(e TabletSeatPadAddedEvent)
+}
+
+// TabletSeatNoopListener is a TabletSeatListener ignoring
+// all the events, it closes the file descriptors they carry.
+type TabletSeatNoopListener struct{}
+
+func (TabletSeatNoopListener) TabletAdded(TabletSeatTabletAddedEvent) {
+}
+
+func (TabletSeatNoopListener) ToolAdded(TabletSeatToolAddedEvent) {
+}
+
+func (TabletSeatNoopListener) PadAdded(TabletSeatPadAddedEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *TabletSeat) SetListener(l TabletSeatListener) {
+	if l == nil {
+		i.tabletAddedHandler = nil
+		i.toolAddedHandler = nil
+		i.padAddedHandler = nil
+		return
+	}
+	i.tabletAddedHandler = l.TabletAdded
+	i.toolAddedHandler = l.ToolAdded
+	i.padAddedHandler = l.PadAdded
+}
+
 func (i *TabletSeat) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
-		if i.tabletAddedHandler == nil {
-			return
-		}
 		var e TabletSeatTabletAddedEvent
 		l := 0
-		e.Id = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*Tablet)
+		e.Id = &Tablet{}
+		i.Context().RegisterWithID(e.Id, client.Uint32(data[l:l+4]))
+		e.Id.SetVersion(i.Version())
 		l += 4
 
-		i.tabletAddedHandler(e)
-	case 1:
-		if i.toolAddedHandler == nil {
-			return
+		if i.tabletAddedHandler != nil {
+			i.tabletAddedHandler(e)
 		}
+	case 1:
 		var e TabletSeatToolAddedEvent
 		l := 0
-		e.Id = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*TabletTool)
+		e.Id = &TabletTool{}
+		i.Context().RegisterWithID(e.Id, client.Uint32(data[l:l+4]))
+		e.Id.SetVersion(i.Version())
 		l += 4
 
-		i.toolAddedHandler(e)
-	case 2:
-		if i.padAddedHandler == nil {
-			return
+		if i.toolAddedHandler != nil {
+			i.toolAddedHandler(e)
 		}
+	case 2:
 		var e TabletSeatPadAddedEvent
 		l := 0
-		e.Id = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*TabletPad)
+		e.Id = &TabletPad{}
+		i.Context().RegisterWithID(e.Id, client.Uint32(data[l:l+4]))
+		e.Id.SetVersion(i.Version())
 		l += 4
 
-		i.padAddedHandler(e)
+		if i.padAddedHandler != nil {
+			i.padAddedHandler(e)
+		}
 	}
 }
 
@@ -297,6 +447,185 @@ func NewTabletTool(ctx *client.Context) *TabletTool {
 	return zwpTabletToolV2
 }
 
+// TabletToolInterface describes zwp_tablet_tool_v2 at runtime.
+var TabletToolInterface = &client.Interface{
+	Name:    "zwp_tablet_tool_v2",
+	Version: 1,
+	New:     func() client.Proxy { return &TabletTool{} },
+	Requests: []client.Message{
+		{
+			Name: "set_cursor",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "surface", Type: "object", Interface: "wl_surface", Nullable: true},
+				{Name: "hotspot_x", Type: "int"},
+				{Name: "hotspot_y", Type: "int"},
+			},
+		},
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "type",
+			Args: []client.Arg{
+				{Name: "tool_type", Type: "uint", Enum: "zwp_tablet_tool_v2.type"},
+			},
+		},
+		{
+			Name: "hardware_serial",
+			Args: []client.Arg{
+				{Name: "hardware_serial_hi", Type: "uint"},
+				{Name: "hardware_serial_lo", Type: "uint"},
+			},
+		},
+		{
+			Name: "hardware_id_wacom",
+			Args: []client.Arg{
+				{Name: "hardware_id_hi", Type: "uint"},
+				{Name: "hardware_id_lo", Type: "uint"},
+			},
+		},
+		{
+			Name: "capability",
+			Args: []client.Arg{
+				{Name: "capability", Type: "uint", Enum: "zwp_tablet_tool_v2.capability"},
+			},
+		},
+		{
+			Name: "done",
+		},
+		{
+			Name: "removed",
+		},
+		{
+			Name: "proximity_in",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "tablet", Type: "object", Interface: "zwp_tablet_v2"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+			},
+		},
+		{
+			Name: "proximity_out",
+		},
+		{
+			Name: "down",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+			},
+		},
+		{
+			Name: "up",
+		},
+		{
+			Name: "motion",
+			Args: []client.Arg{
+				{Name: "x", Type: "fixed"},
+				{Name: "y", Type: "fixed"},
+			},
+		},
+		{
+			Name: "pressure",
+			Args: []client.Arg{
+				{Name: "pressure", Type: "uint"},
+			},
+		},
+		{
+			Name: "distance",
+			Args: []client.Arg{
+				{Name: "distance", Type: "uint"},
+			},
+		},
+		{
+			Name: "tilt",
+			Args: []client.Arg{
+				{Name: "tilt_x", Type: "fixed"},
+				{Name: "tilt_y", Type: "fixed"},
+			},
+		},
+		{
+			Name: "rotation",
+			Args: []client.Arg{
+				{Name: "degrees", Type: "fixed"},
+			},
+		},
+		{
+			Name: "slider",
+			Args: []client.Arg{
+				{Name: "position", Type: "int"},
+			},
+		},
+		{
+			Name: "wheel",
+			Args: []client.Arg{
+				{Name: "degrees", Type: "fixed"},
+				{Name: "clicks", Type: "int"},
+			},
+		},
+		{
+			Name: "button",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "button", Type: "uint"},
+				{Name: "state", Type: "uint", Enum: "zwp_tablet_tool_v2.button_state"},
+			},
+		},
+		{
+			Name: "frame",
+			Args: []client.Arg{
+				{Name: "time", Type: "uint"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "type",
+			Entries: []client.EnumEntry{
+				{Name: "pen", Value: 0x140},
+				{Name: "eraser", Value: 0x141},
+				{Name: "brush", Value: 0x142},
+				{Name: "pencil", Value: 0x143},
+				{Name: "airbrush", Value: 0x144},
+				{Name: "finger", Value: 0x145},
+				{Name: "mouse", Value: 0x146},
+				{Name: "lens", Value: 0x147},
+			},
+		},
+		{
+			Name: "capability",
+			Entries: []client.EnumEntry{
+				{Name: "tilt", Value: 1},
+				{Name: "pressure", Value: 2},
+				{Name: "distance", Value: 3},
+				{Name: "rotation", Value: 4},
+				{Name: "slider", Value: 5},
+				{Name: "wheel", Value: 6},
+			},
+		},
+		{
+			Name: "button_state",
+			Entries: []client.EnumEntry{
+				{Name: "released", Value: 0},
+				{Name: "pressed", Value: 1},
+			},
+		},
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "role", Value: 0},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_tablet_tool_v2.
+func (i *TabletTool) Interface() *client.Interface {
+	return TabletToolInterface
+}
+
 // SetCursor : set the tablet tool's surface
 //
 // Sets the surface of the cursor used for this tool on the given
@@ -599,7 +928,7 @@ func (e TabletToolError) String() string {
 // This event is sent in the initial burst of events before the
 // wp_tablet_tool.done event.
 type TabletToolTypeEvent struct {
-	ToolType uint32
+	ToolType TabletToolType
 }
 type TabletToolTypeHandlerFunc func(TabletToolTypeEvent)
 
@@ -608,6 +937,20 @@ func (i *TabletTool) SetTypeHandler(f TabletToolTypeHandlerFunc) {
 	i.typeHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v2.type(argument=value, ...).
+func (e TabletToolTypeEvent) String() string {
+	return "zwp_tablet_tool_v2.type(" +
+		"tool_type=" + client.EnumString(e.ToolType.Name(), uint32(e.ToolType)) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolTypeEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("tool_type", client.EnumString(e.ToolType.Name(), uint32(e.ToolType))),
+	)
+}
+
 // TabletToolHardwareSerialEvent : unique hardware serial number of the tool
 //
 // If the physical tool can be identified by a unique 64-bit serial
@@ -636,6 +979,22 @@ func (i *TabletTool) SetHardwareSerialHandler(f TabletToolHardwareSerialHandlerF
 	i.hardwareSerialHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v2.hardware_serial(argument=value, ...).
+func (e TabletToolHardwareSerialEvent) String() string {
+	return "zwp_tablet_tool_v2.hardware_serial(" +
+		"hardware_serial_hi=" + strconv.FormatUint(uint64(e.HardwareSerialHi), 10) +
+		", hardware_serial_lo=" + strconv.FormatUint(uint64(e.HardwareSerialLo), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolHardwareSerialEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("hardware_serial_hi", uint64(e.HardwareSerialHi)),
+		slog.Uint64("hardware_serial_lo", uint64(e.HardwareSerialLo)),
+	)
+}
+
 // TabletToolHardwareIdWacomEvent : hardware id notification in Wacom's format
 //
 // This event notifies the client of a hardware id available on this tool.
@@ -659,6 +1018,22 @@ func (i *TabletTool) SetHardwareIdWacomHandler(f TabletToolHardwareIdWacomHandle
 	i.hardwareIdWacomHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v2.hardware_id_wacom(argument=value, ...).
+func (e TabletToolHardwareIdWacomEvent) String() string {
+	return "zwp_tablet_tool_v2.hardware_id_wacom(" +
+		"hardware_id_hi=" + strconv.FormatUint(uint64(e.HardwareIdHi), 10) +
+		", hardware_id_lo=" + strconv.FormatUint(uint64(e.HardwareIdLo), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolHardwareIdWacomEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("hardware_id_hi", uint64(e.HardwareIdHi)),
+		slog.Uint64("hardware_id_lo", uint64(e.HardwareIdLo)),
+	)
+}
+
 // TabletToolCapabilityEvent : tool capability notification
 //
 // This event notifies the client of any capabilities of this tool,
@@ -669,7 +1044,7 @@ func (i *TabletTool) SetHardwareIdWacomHandler(f TabletToolHardwareIdWacomHandle
 // This event is sent in the initial burst of events before the
 // wp_tablet_tool.done event.
 type TabletToolCapabilityEvent struct {
-	Capability uint32
+	Capability TabletToolCapability
 }
 type TabletToolCapabilityHandlerFunc func(TabletToolCapabilityEvent)
 
@@ -678,6 +1053,20 @@ func (i *TabletTool) SetCapabilityHandler(f TabletToolCapabilityHandlerFunc) {
 	i.capabilityHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v2.capability(argument=value, ...).
+func (e TabletToolCapabilityEvent) String() string {
+	return "zwp_tablet_tool_v2.capability(" +
+		"capability=" + client.EnumString(e.Capability.Name(), uint32(e.Capability)) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolCapabilityEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("capability", client.EnumString(e.Capability.Name(), uint32(e.Capability))),
+	)
+}
+
 // TabletToolDoneEvent : tool description events sequence complete
 //
 // This event signals the end of the initial burst of descriptive
@@ -691,6 +1080,16 @@ func (i *TabletTool) SetDoneHandler(f TabletToolDoneHandlerFunc) {
 	i.doneHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v2.done(argument=value, ...).
+func (e TabletToolDoneEvent) String() string {
+	return "zwp_tablet_tool_v2.done()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolDoneEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // TabletToolRemovedEvent : tool removed
 //
 // This event is sent when the tool is removed from the system and will
@@ -715,6 +1114,16 @@ func (i *TabletTool) SetRemovedHandler(f TabletToolRemovedHandlerFunc) {
 	i.removedHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v2.removed(argument=value, ...).
+func (e TabletToolRemovedEvent) String() string {
+	return "zwp_tablet_tool_v2.removed()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolRemovedEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // TabletToolProximityInEvent : proximity in event
 //
 // Notification that this tool is focused on a certain surface.
@@ -738,6 +1147,24 @@ func (i *TabletTool) SetProximityInHandler(f TabletToolProximityInHandlerFunc) {
 	i.proximityInHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v2.proximity_in(argument=value, ...).
+func (e TabletToolProximityInEvent) String() string {
+	return "zwp_tablet_tool_v2.proximity_in(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", tablet=" + client.ObjectString(e.Tablet) +
+		", surface=" + client.ObjectString(e.Surface) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolProximityInEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.String("tablet", client.ObjectString(e.Tablet)),
+		slog.String("surface", client.ObjectString(e.Surface)),
+	)
+}
+
 // TabletToolProximityOutEvent : proximity out event
 //
 // Notification that this tool has either left proximity, or is no
@@ -760,6 +1187,16 @@ func (i *TabletTool) SetProximityOutHandler(f TabletToolProximityOutHandlerFunc)
 	i.proximityOutHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v2.proximity_out(argument=value, ...).
+func (e TabletToolProximityOutEvent) String() string {
+	return "zwp_tablet_tool_v2.proximity_out()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolProximityOutEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // TabletToolDownEvent : tablet tool is making contact
 //
 // Sent whenever the tablet tool comes in contact with the surface of the
@@ -784,6 +1221,20 @@ func (i *TabletTool) SetDownHandler(f TabletToolDownHandlerFunc) {
 	i.downHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v2.down(argument=value, ...).
+func (e TabletToolDownEvent) String() string {
+	return "zwp_tablet_tool_v2.down(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolDownEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+	)
+}
+
 // TabletToolUpEvent : tablet tool is no longer making contact
 //
 // Sent whenever the tablet tool stops making contact with the surface of
@@ -810,6 +1261,16 @@ func (i *TabletTool) SetUpHandler(f TabletToolUpHandlerFunc) {
 	i.upHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v2.up(argument=value, ...).
+func (e TabletToolUpEvent) String() string {
+	return "zwp_tablet_tool_v2.up()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolUpEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // TabletToolMotionEvent : motion event
 //
 // Sent whenever a tablet tool moves.
@@ -824,6 +1285,22 @@ func (i *TabletTool) SetMotionHandler(f TabletToolMotionHandlerFunc) {
 	i.motionHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v2.motion(argument=value, ...).
+func (e TabletToolMotionEvent) String() string {
+	return "zwp_tablet_tool_v2.motion(" +
+		"x=" + strconv.FormatFloat(e.X, 'g', -1, 64) +
+		", y=" + strconv.FormatFloat(e.Y, 'g', -1, 64) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolMotionEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Float64("x", e.X),
+		slog.Float64("y", e.Y),
+	)
+}
+
 // TabletToolPressureEvent : pressure change event
 //
 // Sent whenever the pressure axis on a tool changes. The value of this
@@ -841,6 +1318,20 @@ func (i *TabletTool) SetPressureHandler(f TabletToolPressureHandlerFunc) {
 	i.pressureHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v2.pressure(argument=value, ...).
+func (e TabletToolPressureEvent) String() string {
+	return "zwp_tablet_tool_v2.pressure(" +
+		"pressure=" + strconv.FormatUint(uint64(e.Pressure), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolPressureEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("pressure", uint64(e.Pressure)),
+	)
+}
+
 // TabletToolDistanceEvent : distance change event
 //
 // Sent whenever the distance axis on a tool changes. The value of this
@@ -858,6 +1349,20 @@ func (i *TabletTool) SetDistanceHandler(f TabletToolDistanceHandlerFunc) {
 	i.distanceHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v2.distance(argument=value, ...).
+func (e TabletToolDistanceEvent) String() string {
+	return "zwp_tablet_tool_v2.distance(" +
+		"distance=" + strconv.FormatUint(uint64(e.Distance), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolDistanceEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("distance", uint64(e.Distance)),
+	)
+}
+
 // TabletToolTiltEvent : tilt change event
 //
 // Sent whenever one or both of the tilt axes on a tool change. Each tilt
@@ -875,6 +1380,22 @@ func (i *TabletTool) SetTiltHandler(f TabletToolTiltHandlerFunc) {
 	i.tiltHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v2.tilt(argument=value, ...).
+func (e TabletToolTiltEvent) String() string {
+	return "zwp_tablet_tool_v2.tilt(" +
+		"tilt_x=" + strconv.FormatFloat(e.TiltX, 'g', -1, 64) +
+		", tilt_y=" + strconv.FormatFloat(e.TiltY, 'g', -1, 64) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolTiltEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Float64("tilt_x", e.TiltX),
+		slog.Float64("tilt_y", e.TiltY),
+	)
+}
+
 // TabletToolRotationEvent : z-rotation change event
 //
 // Sent whenever the z-rotation axis on the tool changes. The
@@ -890,6 +1411,20 @@ func (i *TabletTool) SetRotationHandler(f TabletToolRotationHandlerFunc) {
 	i.rotationHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v2.rotation(argument=value, ...).
+func (e TabletToolRotationEvent) String() string {
+	return "zwp_tablet_tool_v2.rotation(" +
+		"degrees=" + strconv.FormatFloat(e.Degrees, 'g', -1, 64) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolRotationEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Float64("degrees", e.Degrees),
+	)
+}
+
 // TabletToolSliderEvent : Slider position change event
 //
 // Sent whenever the slider position on the tool changes. The
@@ -907,6 +1442,20 @@ func (i *TabletTool) SetSliderHandler(f TabletToolSliderHandlerFunc) {
 	i.sliderHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v2.slider(argument=value, ...).
+func (e TabletToolSliderEvent) String() string {
+	return "zwp_tablet_tool_v2.slider(" +
+		"position=" + strconv.FormatInt(int64(e.Position), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolSliderEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int64("position", int64(e.Position)),
+	)
+}
+
 // TabletToolWheelEvent : Wheel delta event
 //
 // Sent whenever the wheel on the tool emits an event. This event
@@ -932,6 +1481,22 @@ func (i *TabletTool) SetWheelHandler(f TabletToolWheelHandlerFunc) {
 	i.wheelHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v2.wheel(argument=value, ...).
+func (e TabletToolWheelEvent) String() string {
+	return "zwp_tablet_tool_v2.wheel(" +
+		"degrees=" + strconv.FormatFloat(e.Degrees, 'g', -1, 64) +
+		", clicks=" + strconv.FormatInt(int64(e.Clicks), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolWheelEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Float64("degrees", e.Degrees),
+		slog.Int64("clicks", int64(e.Clicks)),
+	)
+}
+
 // TabletToolButtonEvent : button event
 //
 // Sent whenever a button on the tool is pressed or released.
@@ -943,7 +1508,7 @@ func (i *TabletTool) SetWheelHandler(f TabletToolWheelHandlerFunc) {
 type TabletToolButtonEvent struct {
 	Serial uint32
 	Button uint32
-	State  uint32
+	State  TabletToolButtonState
 }
 type TabletToolButtonHandlerFunc func(TabletToolButtonEvent)
 
@@ -952,6 +1517,24 @@ func (i *TabletTool) SetButtonHandler(f TabletToolButtonHandlerFunc) {
 	i.buttonHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v2.button(argument=value, ...).
+func (e TabletToolButtonEvent) String() string {
+	return "zwp_tablet_tool_v2.button(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", button=" + strconv.FormatUint(uint64(e.Button), 10) +
+		", state=" + client.EnumString(e.State.Name(), uint32(e.State)) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolButtonEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.Uint64("button", uint64(e.Button)),
+		slog.String("state", client.EnumString(e.State.Name(), uint32(e.State))),
+	)
+}
+
 // TabletToolFrameEvent : frame event
 //
 // Marks the end of a series of axis and/or button updates from the
@@ -968,6 +1551,151 @@ func (i *TabletTool) SetFrameHandler(f TabletToolFrameHandlerFunc) {
 	i.frameHandler = f
 }
 
+// String formats the event as zwp_tablet_tool_v2.frame(argument=value, ...).
+func (e TabletToolFrameEvent) String() string {
+	return "zwp_tablet_tool_v2.frame(" +
+		"time=" + strconv.FormatUint(uint64(e.Time), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletToolFrameEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("time", uint64(e.Time)),
+	)
+}
+
+// TabletToolListener receives all the events of TabletTool, embed
+// TabletToolNoopListener to implement only some of them.
+type TabletToolListener interface {
+	Type(e TabletToolTypeEvent)
+	HardwareSerial(e TabletToolHardwareSerialEvent)
+	HardwareIdWacom(e TabletToolHardwareIdWacomEvent)
+	Capability(e TabletToolCapabilityEvent)
+	Done(e TabletToolDoneEvent)
+	Removed(e TabletToolRemovedEvent)
+	ProximityIn(e TabletToolProximityInEvent)
+	ProximityOut(e TabletToolProximityOutEvent)
+	Down(e TabletToolDownEvent)
+	Up(e TabletToolUpEvent)
+	Motion(e TabletToolMotionEvent)
+	Pressure(e TabletToolPressureEvent)
+	Distance(e TabletToolDistanceEvent)
+	Tilt(e TabletToolTiltEvent)
+	Rotation(e TabletToolRotationEvent)
+	Slider(e TabletToolSliderEvent)
+	Wheel(e TabletToolWheelEvent)
+	Button(e TabletToolButtonEvent)
+	Frame(e TabletToolFrameEvent)
+}
+
+// TabletToolNoopListener is a TabletToolListener ignoring
+// all the events, it closes the file descriptors they carry.
+type TabletToolNoopListener struct{}
+
+func (TabletToolNoopListener) Type(TabletToolTypeEvent) {
+}
+
+func (TabletToolNoopListener) HardwareSerial(TabletToolHardwareSerialEvent) {
+}
+
+func (TabletToolNoopListener) HardwareIdWacom(TabletToolHardwareIdWacomEvent) {
+}
+
+func (TabletToolNoopListener) Capability(TabletToolCapabilityEvent) {
+}
+
+func (TabletToolNoopListener) Done(TabletToolDoneEvent) {
+}
+
+func (TabletToolNoopListener) Removed(TabletToolRemovedEvent) {
+}
+
+func (TabletToolNoopListener) ProximityIn(TabletToolProximityInEvent) {
+}
+
+func (TabletToolNoopListener) ProximityOut(TabletToolProximityOutEvent) {
+}
+
+func (TabletToolNoopListener) Down(TabletToolDownEvent) {
+}
+
+func (TabletToolNoopListener) Up(TabletToolUpEvent) {
+}
+
+func (TabletToolNoopListener) Motion(TabletToolMotionEvent) {
+}
+
+func (TabletToolNoopListener) Pressure(TabletToolPressureEvent) {
+}
+
+func (TabletToolNoopListener) Distance(TabletToolDistanceEvent) {
+}
+
+func (TabletToolNoopListener) Tilt(TabletToolTiltEvent) {
+}
+
+func (TabletToolNoopListener) Rotation(TabletToolRotationEvent) {
+}
+
+func (TabletToolNoopListener) Slider(TabletToolSliderEvent) {
+}
+
+func (TabletToolNoopListener) Wheel(TabletToolWheelEvent) {
+}
+
+func (TabletToolNoopListener) Button(TabletToolButtonEvent) {
+}
+
+func (TabletToolNoopListener) Frame(TabletToolFrameEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *TabletTool) SetListener(l TabletToolListener) {
+	if l == nil {
+		i.typeHandler = nil
+		i.hardwareSerialHandler = nil
+		i.hardwareIdWacomHandler = nil
+		i.capabilityHandler = nil
+		i.doneHandler = nil
+		i.removedHandler = nil
+		i.proximityInHandler = nil
+		i.proximityOutHandler = nil
+		i.downHandler = nil
+		i.upHandler = nil
+		i.motionHandler = nil
+		i.pressureHandler = nil
+		i.distanceHandler = nil
+		i.tiltHandler = nil
+		i.rotationHandler = nil
+		i.sliderHandler = nil
+		i.wheelHandler = nil
+		i.buttonHandler = nil
+		i.frameHandler = nil
+		return
+	}
+	i.typeHandler = l.Type
+	i.hardwareSerialHandler = l.HardwareSerial
+	i.hardwareIdWacomHandler = l.HardwareIdWacom
+	i.capabilityHandler = l.Capability
+	i.doneHandler = l.Done
+	i.removedHandler = l.Removed
+	i.proximityInHandler = l.ProximityIn
+	i.proximityOutHandler = l.ProximityOut
+	i.downHandler = l.Down
+	i.upHandler = l.Up
+	i.motionHandler = l.Motion
+	i.pressureHandler = l.Pressure
+	i.distanceHandler = l.Distance
+	i.tiltHandler = l.Tilt
+	i.rotationHandler = l.Rotation
+	i.sliderHandler = l.Slider
+	i.wheelHandler = l.Wheel
+	i.buttonHandler = l.Button
+	i.frameHandler = l.Frame
+}
+
 func (i *TabletTool) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -976,7 +1704,7 @@ func (i *TabletTool) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e TabletToolTypeEvent
 		l := 0
-		e.ToolType = client.Uint32(data[l : l+4])
+		e.ToolType = TabletToolType(client.Uint32(data[l : l+4]))
 		l += 4
 
 		i.typeHandler(e)
@@ -1010,7 +1738,7 @@ func (i *TabletTool) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e TabletToolCapabilityEvent
 		l := 0
-		e.Capability = client.Uint32(data[l : l+4])
+		e.Capability = TabletToolCapability(client.Uint32(data[l : l+4]))
 		l += 4
 
 		i.capabilityHandler(e)
@@ -1036,9 +1764,9 @@ func (i *TabletTool) Dispatch(opcode uint32, fd int, data []byte) {
 		l := 0
 		e.Serial = client.Uint32(data[l : l+4])
 		l += 4
-		e.Tablet = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*Tablet)
+		e.Tablet, _ = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*Tablet)
 		l += 4
-		e.Surface = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*client.Surface)
+		e.Surface, _ = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*client.Surface)
 		l += 4
 
 		i.proximityInHandler(e)
@@ -1072,9 +1800,9 @@ func (i *TabletTool) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e TabletToolMotionEvent
 		l := 0
-		e.X = client.Fixed(data[l : l+4])
+		e.X = client.FixedFloat64(data[l : l+4])
 		l += 4
-		e.Y = client.Fixed(data[l : l+4])
+		e.Y = client.FixedFloat64(data[l : l+4])
 		l += 4
 
 		i.motionHandler(e)
@@ -1104,9 +1832,9 @@ func (i *TabletTool) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e TabletToolTiltEvent
 		l := 0
-		e.TiltX = client.Fixed(data[l : l+4])
+		e.TiltX = client.FixedFloat64(data[l : l+4])
 		l += 4
-		e.TiltY = client.Fixed(data[l : l+4])
+		e.TiltY = client.FixedFloat64(data[l : l+4])
 		l += 4
 
 		i.tiltHandler(e)
@@ -1116,7 +1844,7 @@ func (i *TabletTool) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e TabletToolRotationEvent
 		l := 0
-		e.Degrees = client.Fixed(data[l : l+4])
+		e.Degrees = client.FixedFloat64(data[l : l+4])
 		l += 4
 
 		i.rotationHandler(e)
@@ -1136,7 +1864,7 @@ func (i *TabletTool) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e TabletToolWheelEvent
 		l := 0
-		e.Degrees = client.Fixed(data[l : l+4])
+		e.Degrees = client.FixedFloat64(data[l : l+4])
 		l += 4
 		e.Clicks = int32(client.Uint32(data[l : l+4]))
 		l += 4
@@ -1152,7 +1880,7 @@ func (i *TabletTool) Dispatch(opcode uint32, fd int, data []byte) {
 		l += 4
 		e.Button = client.Uint32(data[l : l+4])
 		l += 4
-		e.State = client.Uint32(data[l : l+4])
+		e.State = TabletToolButtonState(client.Uint32(data[l : l+4]))
 		l += 4
 
 		i.buttonHandler(e)
@@ -1204,6 +1932,51 @@ func NewTablet(ctx *client.Context) *Tablet {
 	return zwpTabletV2
 }
 
+// TabletInterface describes zwp_tablet_v2 at runtime.
+var TabletInterface = &client.Interface{
+	Name:    "zwp_tablet_v2",
+	Version: 1,
+	New:     func() client.Proxy { return &Tablet{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "name",
+			Args: []client.Arg{
+				{Name: "name", Type: "string"},
+			},
+		},
+		{
+			Name: "id",
+			Args: []client.Arg{
+				{Name: "vid", Type: "uint"},
+				{Name: "pid", Type: "uint"},
+			},
+		},
+		{
+			Name: "path",
+			Args: []client.Arg{
+				{Name: "path", Type: "string"},
+			},
+		},
+		{
+			Name: "done",
+		},
+		{
+			Name: "removed",
+		},
+	},
+}
+
+// Interface returns the description of zwp_tablet_v2.
+func (i *Tablet) Interface() *client.Interface {
+	return TabletInterface
+}
+
 // Destroy : destroy the tablet object
 //
 // This destroys the client's resource for this tablet object.
@@ -1235,6 +2008,20 @@ func (i *Tablet) SetNameHandler(f TabletNameHandlerFunc) {
 	i.nameHandler = f
 }
 
+// String formats the event as zwp_tablet_v2.name(argument=value, ...).
+func (e TabletNameEvent) String() string {
+	return "zwp_tablet_v2.name(" +
+		"name=" + strconv.Quote(e.Name) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletNameEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("name", e.Name),
+	)
+}
+
 // TabletIdEvent : tablet device USB vendor/product id
 //
 // This event is sent in the initial burst of events before the
@@ -1250,6 +2037,22 @@ func (i *Tablet) SetIdHandler(f TabletIdHandlerFunc) {
 	i.idHandler = f
 }
 
+// String formats the event as zwp_tablet_v2.id(argument=value, ...).
+func (e TabletIdEvent) String() string {
+	return "zwp_tablet_v2.id(" +
+		"vid=" + strconv.FormatUint(uint64(e.Vid), 10) +
+		", pid=" + strconv.FormatUint(uint64(e.Pid), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletIdEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("vid", uint64(e.Vid)),
+		slog.Uint64("pid", uint64(e.Pid)),
+	)
+}
+
 // TabletPathEvent : path to the device
 //
 // A system-specific device path that indicates which device is behind
@@ -1276,6 +2079,20 @@ func (i *Tablet) SetPathHandler(f TabletPathHandlerFunc) {
 	i.pathHandler = f
 }
 
+// String formats the event as zwp_tablet_v2.path(argument=value, ...).
+func (e TabletPathEvent) String() string {
+	return "zwp_tablet_v2.path(" +
+		"path=" + strconv.Quote(e.Path) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletPathEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("path", e.Path),
+	)
+}
+
 // TabletDoneEvent : tablet description events sequence complete
 //
 // This event is sent immediately to signal the end of the initial
@@ -1290,6 +2107,16 @@ func (i *Tablet) SetDoneHandler(f TabletDoneHandlerFunc) {
 	i.doneHandler = f
 }
 
+// String formats the event as zwp_tablet_v2.done(argument=value, ...).
+func (e TabletDoneEvent) String() string {
+	return "zwp_tablet_v2.done()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletDoneEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // TabletRemovedEvent : tablet removed event
 //
 // Sent when the tablet has been removed from the system. When a tablet
@@ -1305,6 +2132,63 @@ func (i *Tablet) SetRemovedHandler(f TabletRemovedHandlerFunc) {
 	i.removedHandler = f
 }
 
+// String formats the event as zwp_tablet_v2.removed(argument=value, ...).
+func (e TabletRemovedEvent) String() string {
+	return "zwp_tablet_v2.removed()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletRemovedEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
+// TabletListener receives all the events of Tablet, embed
+// TabletNoopListener to implement only some of them.
+type TabletListener interface {
+	Name(e TabletNameEvent)
+	Id(e TabletIdEvent)
+	Path(e TabletPathEvent)
+	Done(e TabletDoneEvent)
+	Removed(e TabletRemovedEvent)
+}
+
+// TabletNoopListener is a TabletListener ignoring
+// all the events, it closes the file descriptors they carry.
+type TabletNoopListener struct{}
+
+func (TabletNoopListener) Name(TabletNameEvent) {
+}
+
+func (TabletNoopListener) Id(TabletIdEvent) {
+}
+
+func (TabletNoopListener) Path(TabletPathEvent) {
+}
+
+func (TabletNoopListener) Done(TabletDoneEvent) {
+}
+
+func (TabletNoopListener) Removed(TabletRemovedEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *Tablet) SetListener(l TabletListener) {
+	if l == nil {
+		i.nameHandler = nil
+		i.idHandler = nil
+		i.pathHandler = nil
+		i.doneHandler = nil
+		i.removedHandler = nil
+		return
+	}
+	i.nameHandler = l.Name
+	i.idHandler = l.Id
+	i.pathHandler = l.Path
+	i.doneHandler = l.Done
+	i.removedHandler = l.Removed
+}
+
 func (i *Tablet) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -1388,6 +2272,62 @@ func NewTabletPadRing(ctx *client.Context) *TabletPadRing {
 	return zwpTabletPadRingV2
 }
 
+// TabletPadRingInterface describes zwp_tablet_pad_ring_v2 at runtime.
+var TabletPadRingInterface = &client.Interface{
+	Name:    "zwp_tablet_pad_ring_v2",
+	Version: 1,
+	New:     func() client.Proxy { return &TabletPadRing{} },
+	Requests: []client.Message{
+		{
+			Name: "set_feedback",
+			Args: []client.Arg{
+				{Name: "description", Type: "string"},
+				{Name: "serial", Type: "uint"},
+			},
+		},
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "source",
+			Args: []client.Arg{
+				{Name: "source", Type: "uint", Enum: "zwp_tablet_pad_ring_v2.source"},
+			},
+		},
+		{
+			Name: "angle",
+			Args: []client.Arg{
+				{Name: "degrees", Type: "fixed"},
+			},
+		},
+		{
+			Name: "stop",
+		},
+		{
+			Name: "frame",
+			Args: []client.Arg{
+				{Name: "time", Type: "uint"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "source",
+			Entries: []client.EnumEntry{
+				{Name: "finger", Value: 1},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_tablet_pad_ring_v2.
+func (i *TabletPadRing) Interface() *client.Interface {
+	return TabletPadRingInterface
+}
+
 // SetFeedback : set compositor feedback
 //
 // Request that the compositor use the provided feedback string
@@ -1422,7 +2362,7 @@ func (i *TabletPadRing) SetFeedback(description string, serial uint32) error {
 	l += 4
 	client.PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
 	l += 4
-	client.PutString(_reqBuf[l:l+(4+descriptionLen)], description, descriptionLen)
+	client.PutString(_reqBuf[l:l+(4+descriptionLen)], description)
 	l += (4 + descriptionLen)
 	client.PutUint32(_reqBuf[l:l+4], uint32(serial))
 	l += 4
@@ -1497,7 +2437,7 @@ func (e TabletPadRingSource) String() string {
 // This event is optional. If the source is unknown for an interaction,
 // no event is sent.
 type TabletPadRingSourceEvent struct {
-	Source uint32
+	Source TabletPadRingSource
 }
 type TabletPadRingSourceHandlerFunc func(TabletPadRingSourceEvent)
 
@@ -1506,6 +2446,20 @@ func (i *TabletPadRing) SetSourceHandler(f TabletPadRingSourceHandlerFunc) {
 	i.sourceHandler = f
 }
 
+// String formats the event as zwp_tablet_pad_ring_v2.source(argument=value, ...).
+func (e TabletPadRingSourceEvent) String() string {
+	return "zwp_tablet_pad_ring_v2.source(" +
+		"source=" + client.EnumString(e.Source.Name(), uint32(e.Source)) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletPadRingSourceEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("source", client.EnumString(e.Source.Name(), uint32(e.Source))),
+	)
+}
+
 // TabletPadRingAngleEvent : angle changed
 //
 // Sent whenever the angle on a ring changes.
@@ -1522,6 +2476,20 @@ func (i *TabletPadRing) SetAngleHandler(f TabletPadRingAngleHandlerFunc) {
 	i.angleHandler = f
 }
 
+// String formats the event as zwp_tablet_pad_ring_v2.angle(argument=value, ...).
+func (e TabletPadRingAngleEvent) String() string {
+	return "zwp_tablet_pad_ring_v2.angle(" +
+		"degrees=" + strconv.FormatFloat(e.Degrees, 'g', -1, 64) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletPadRingAngleEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Float64("degrees", e.Degrees),
+	)
+}
+
 // TabletPadRingStopEvent : interaction stopped
 //
 // Stop notification for ring events.
@@ -1542,6 +2510,16 @@ func (i *TabletPadRing) SetStopHandler(f TabletPadRingStopHandlerFunc) {
 	i.stopHandler = f
 }
 
+// String formats the event as zwp_tablet_pad_ring_v2.stop(argument=value, ...).
+func (e TabletPadRingStopEvent) String() string {
+	return "zwp_tablet_pad_ring_v2.stop()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletPadRingStopEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // TabletPadRingFrameEvent : end of a ring event sequence
 //
 // Indicates the end of a set of ring events that logically belong
@@ -1567,6 +2545,61 @@ func (i *TabletPadRing) SetFrameHandler(f TabletPadRingFrameHandlerFunc) {
 	i.frameHandler = f
 }
 
+// String formats the event as zwp_tablet_pad_ring_v2.frame(argument=value, ...).
+func (e TabletPadRingFrameEvent) String() string {
+	return "zwp_tablet_pad_ring_v2.frame(" +
+		"time=" + strconv.FormatUint(uint64(e.Time), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletPadRingFrameEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("time", uint64(e.Time)),
+	)
+}
+
+// TabletPadRingListener receives all the events of TabletPadRing, embed
+// TabletPadRingNoopListener to implement only some of them.
+type TabletPadRingListener interface {
+	Source(e TabletPadRingSourceEvent)
+	Angle(e TabletPadRingAngleEvent)
+	Stop(e TabletPadRingStopEvent)
+	Frame(e TabletPadRingFrameEvent)
+}
+
+// TabletPadRingNoopListener is a TabletPadRingListener ignoring
+// all the events, it closes the file descriptors they carry.
+type TabletPadRingNoopListener struct{}
+
+func (TabletPadRingNoopListener) Source(TabletPadRingSourceEvent) {
+}
+
+func (TabletPadRingNoopListener) Angle(TabletPadRingAngleEvent) {
+}
+
+func (TabletPadRingNoopListener) Stop(TabletPadRingStopEvent) {
+}
+
+func (TabletPadRingNoopListener) Frame(TabletPadRingFrameEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *TabletPadRing) SetListener(l TabletPadRingListener) {
+	if l == nil {
+		i.sourceHandler = nil
+		i.angleHandler = nil
+		i.stopHandler = nil
+		i.frameHandler = nil
+		return
+	}
+	i.sourceHandler = l.Source
+	i.angleHandler = l.Angle
+	i.stopHandler = l.Stop
+	i.frameHandler = l.Frame
+}
+
 func (i *TabletPadRing) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -1575,7 +2608,7 @@ func (i *TabletPadRing) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e TabletPadRingSourceEvent
 		l := 0
-		e.Source = client.Uint32(data[l : l+4])
+		e.Source = TabletPadRingSource(client.Uint32(data[l : l+4]))
 		l += 4
 
 		i.sourceHandler(e)
@@ -1585,7 +2618,7 @@ func (i *TabletPadRing) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e TabletPadRingAngleEvent
 		l := 0
-		e.Degrees = client.Fixed(data[l : l+4])
+		e.Degrees = client.FixedFloat64(data[l : l+4])
 		l += 4
 
 		i.angleHandler(e)
@@ -1637,6 +2670,62 @@ func NewTabletPadStrip(ctx *client.Context) *TabletPadStrip {
 	return zwpTabletPadStripV2
 }
 
+// TabletPadStripInterface describes zwp_tablet_pad_strip_v2 at runtime.
+var TabletPadStripInterface = &client.Interface{
+	Name:    "zwp_tablet_pad_strip_v2",
+	Version: 1,
+	New:     func() client.Proxy { return &TabletPadStrip{} },
+	Requests: []client.Message{
+		{
+			Name: "set_feedback",
+			Args: []client.Arg{
+				{Name: "description", Type: "string"},
+				{Name: "serial", Type: "uint"},
+			},
+		},
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "source",
+			Args: []client.Arg{
+				{Name: "source", Type: "uint", Enum: "zwp_tablet_pad_strip_v2.source"},
+			},
+		},
+		{
+			Name: "position",
+			Args: []client.Arg{
+				{Name: "position", Type: "uint"},
+			},
+		},
+		{
+			Name: "stop",
+		},
+		{
+			Name: "frame",
+			Args: []client.Arg{
+				{Name: "time", Type: "uint"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "source",
+			Entries: []client.EnumEntry{
+				{Name: "finger", Value: 1},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_tablet_pad_strip_v2.
+func (i *TabletPadStrip) Interface() *client.Interface {
+	return TabletPadStripInterface
+}
+
 // SetFeedback : set compositor feedback
 //
 // Requests the compositor to use the provided feedback string
@@ -1671,7 +2760,7 @@ func (i *TabletPadStrip) SetFeedback(description string, serial uint32) error {
 	l += 4
 	client.PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
 	l += 4
-	client.PutString(_reqBuf[l:l+(4+descriptionLen)], description, descriptionLen)
+	client.PutString(_reqBuf[l:l+(4+descriptionLen)], description)
 	l += (4 + descriptionLen)
 	client.PutUint32(_reqBuf[l:l+4], uint32(serial))
 	l += 4
@@ -1746,7 +2835,7 @@ func (e TabletPadStripSource) String() string {
 // This event is optional. If the source is unknown for an interaction,
 // no event is sent.
 type TabletPadStripSourceEvent struct {
-	Source uint32
+	Source TabletPadStripSource
 }
 type TabletPadStripSourceHandlerFunc func(TabletPadStripSourceEvent)
 
@@ -1755,6 +2844,20 @@ func (i *TabletPadStrip) SetSourceHandler(f TabletPadStripSourceHandlerFunc) {
 	i.sourceHandler = f
 }
 
+// String formats the event as zwp_tablet_pad_strip_v2.source(argument=value, ...).
+func (e TabletPadStripSourceEvent) String() string {
+	return "zwp_tablet_pad_strip_v2.source(" +
+		"source=" + client.EnumString(e.Source.Name(), uint32(e.Source)) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletPadStripSourceEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("source", client.EnumString(e.Source.Name(), uint32(e.Source))),
+	)
+}
+
 // TabletPadStripPositionEvent : position changed
 //
 // Sent whenever the position on a strip changes.
@@ -1772,6 +2875,20 @@ func (i *TabletPadStrip) SetPositionHandler(f TabletPadStripPositionHandlerFunc)
 	i.positionHandler = f
 }
 
+// String formats the event as zwp_tablet_pad_strip_v2.position(argument=value, ...).
+func (e TabletPadStripPositionEvent) String() string {
+	return "zwp_tablet_pad_strip_v2.position(" +
+		"position=" + strconv.FormatUint(uint64(e.Position), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletPadStripPositionEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("position", uint64(e.Position)),
+	)
+}
+
 // TabletPadStripStopEvent : interaction stopped
 //
 // Stop notification for strip events.
@@ -1792,6 +2909,16 @@ func (i *TabletPadStrip) SetStopHandler(f TabletPadStripStopHandlerFunc) {
 	i.stopHandler = f
 }
 
+// String formats the event as zwp_tablet_pad_strip_v2.stop(argument=value, ...).
+func (e TabletPadStripStopEvent) String() string {
+	return "zwp_tablet_pad_strip_v2.stop()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletPadStripStopEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // TabletPadStripFrameEvent : end of a strip event sequence
 //
 // Indicates the end of a set of events that represent one logical
@@ -1818,6 +2945,61 @@ func (i *TabletPadStrip) SetFrameHandler(f TabletPadStripFrameHandlerFunc) {
 	i.frameHandler = f
 }
 
+// String formats the event as zwp_tablet_pad_strip_v2.frame(argument=value, ...).
+func (e TabletPadStripFrameEvent) String() string {
+	return "zwp_tablet_pad_strip_v2.frame(" +
+		"time=" + strconv.FormatUint(uint64(e.Time), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletPadStripFrameEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("time", uint64(e.Time)),
+	)
+}
+
+// TabletPadStripListener receives all the events of TabletPadStrip, embed
+// TabletPadStripNoopListener to implement only some of them.
+type TabletPadStripListener interface {
+	Source(e TabletPadStripSourceEvent)
+	Position(e TabletPadStripPositionEvent)
+	Stop(e TabletPadStripStopEvent)
+	Frame(e TabletPadStripFrameEvent)
+}
+
+// TabletPadStripNoopListener is a TabletPadStripListener ignoring
+// all the events, it closes the file descriptors they carry.
+type TabletPadStripNoopListener struct{}
+
+func (TabletPadStripNoopListener) Source(TabletPadStripSourceEvent) {
+}
+
+func (TabletPadStripNoopListener) Position(TabletPadStripPositionEvent) {
+}
+
+func (TabletPadStripNoopListener) Stop(TabletPadStripStopEvent) {
+}
+
+func (TabletPadStripNoopListener) Frame(TabletPadStripFrameEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *TabletPadStrip) SetListener(l TabletPadStripListener) {
+	if l == nil {
+		i.sourceHandler = nil
+		i.positionHandler = nil
+		i.stopHandler = nil
+		i.frameHandler = nil
+		return
+	}
+	i.sourceHandler = l.Source
+	i.positionHandler = l.Position
+	i.stopHandler = l.Stop
+	i.frameHandler = l.Frame
+}
+
 func (i *TabletPadStrip) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -1826,7 +3008,7 @@ func (i *TabletPadStrip) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e TabletPadStripSourceEvent
 		l := 0
-		e.Source = client.Uint32(data[l : l+4])
+		e.Source = TabletPadStripSource(client.Uint32(data[l : l+4]))
 		l += 4
 
 		i.sourceHandler(e)
@@ -1922,6 +3104,61 @@ func NewTabletPadGroup(ctx *client.Context) *TabletPadGroup {
 	return zwpTabletPadGroupV2
 }
 
+// TabletPadGroupInterface describes zwp_tablet_pad_group_v2 at runtime.
+var TabletPadGroupInterface = &client.Interface{
+	Name:    "zwp_tablet_pad_group_v2",
+	Version: 1,
+	New:     func() client.Proxy { return &TabletPadGroup{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "buttons",
+			Args: []client.Arg{
+				{Name: "buttons", Type: "array"},
+			},
+		},
+		{
+			Name: "ring",
+			Args: []client.Arg{
+				{Name: "ring", Type: "new_id", Interface: "zwp_tablet_pad_ring_v2"},
+			},
+		},
+		{
+			Name: "strip",
+			Args: []client.Arg{
+				{Name: "strip", Type: "new_id", Interface: "zwp_tablet_pad_strip_v2"},
+			},
+		},
+		{
+			Name: "modes",
+			Args: []client.Arg{
+				{Name: "modes", Type: "uint"},
+			},
+		},
+		{
+			Name: "done",
+		},
+		{
+			Name: "mode_switch",
+			Args: []client.Arg{
+				{Name: "time", Type: "uint"},
+				{Name: "serial", Type: "uint"},
+				{Name: "mode", Type: "uint"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_tablet_pad_group_v2.
+func (i *TabletPadGroup) Interface() *client.Interface {
+	return TabletPadGroupInterface
+}
+
 // Destroy : destroy the pad object
 //
 // Destroy the wp_tablet_pad_group object. Objects created from this object
@@ -1955,7 +3192,7 @@ func (i *TabletPadGroup) Destroy() error {
 // If the compositor happens to reserve all buttons in a group, this event
 // will be sent with an empty array.
 type TabletPadGroupButtonsEvent struct {
-	Buttons []byte
+	Buttons []uint32
 }
 type TabletPadGroupButtonsHandlerFunc func(TabletPadGroupButtonsEvent)
 
@@ -1964,6 +3201,20 @@ func (i *TabletPadGroup) SetButtonsHandler(f TabletPadGroupButtonsHandlerFunc) {
 	i.buttonsHandler = f
 }
 
+// String formats the event as zwp_tablet_pad_group_v2.buttons(argument=value, ...).
+func (e TabletPadGroupButtonsEvent) String() string {
+	return "zwp_tablet_pad_group_v2.buttons(" +
+		"buttons=" + fmt.Sprint(e.Buttons) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletPadGroupButtonsEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Any("buttons", e.Buttons),
+	)
+}
+
 // TabletPadGroupRingEvent : ring announced
 //
 // Sent on wp_tablet_pad_group initialization to announce available rings.
@@ -1981,6 +3232,20 @@ func (i *TabletPadGroup) SetRingHandler(f TabletPadGroupRingHandlerFunc) {
 	i.ringHandler = f
 }
 
+// String formats the event as zwp_tablet_pad_group_v2.ring(argument=value, ...).
+func (e TabletPadGroupRingEvent) String() string {
+	return "zwp_tablet_pad_group_v2.ring(" +
+		"ring=" + client.ObjectString(e.Ring) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletPadGroupRingEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("ring", client.ObjectString(e.Ring)),
+	)
+}
+
 // TabletPadGroupStripEvent : strip announced
 //
 // Sent on wp_tablet_pad initialization to announce available strips.
@@ -1998,6 +3263,20 @@ func (i *TabletPadGroup) SetStripHandler(f TabletPadGroupStripHandlerFunc) {
 	i.stripHandler = f
 }
 
+// String formats the event as zwp_tablet_pad_group_v2.strip(argument=value, ...).
+func (e TabletPadGroupStripEvent) String() string {
+	return "zwp_tablet_pad_group_v2.strip(" +
+		"strip=" + client.ObjectString(e.Strip) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletPadGroupStripEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("strip", client.ObjectString(e.Strip)),
+	)
+}
+
 // TabletPadGroupModesEvent : mode-switch ability announced
 //
 // Sent on wp_tablet_pad_group initialization to announce that the pad
@@ -2022,6 +3301,20 @@ func (i *TabletPadGroup) SetModesHandler(f TabletPadGroupModesHandlerFunc) {
 	i.modesHandler = f
 }
 
+// String formats the event as zwp_tablet_pad_group_v2.modes(argument=value, ...).
+func (e TabletPadGroupModesEvent) String() string {
+	return "zwp_tablet_pad_group_v2.modes(" +
+		"modes=" + strconv.FormatUint(uint64(e.Modes), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletPadGroupModesEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("modes", uint64(e.Modes)),
+	)
+}
+
 // TabletPadGroupDoneEvent : tablet group description events sequence complete
 //
 // This event is sent immediately to signal the end of the initial
@@ -2036,6 +3329,16 @@ func (i *TabletPadGroup) SetDoneHandler(f TabletPadGroupDoneHandlerFunc) {
 	i.doneHandler = f
 }
 
+// String formats the event as zwp_tablet_pad_group_v2.done(argument=value, ...).
+func (e TabletPadGroupDoneEvent) String() string {
+	return "zwp_tablet_pad_group_v2.done()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletPadGroupDoneEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // TabletPadGroupModeSwitchEvent : mode switch event
 //
 // Notification that the mode was switched.
@@ -2077,6 +3380,77 @@ func (i *TabletPadGroup) SetModeSwitchHandler(f TabletPadGroupModeSwitchHandlerF
 	i.modeSwitchHandler = f
 }
 
+// String formats the event as zwp_tablet_pad_group_v2.mode_switch(argument=value, ...).
+func (e TabletPadGroupModeSwitchEvent) String() string {
+	return "zwp_tablet_pad_group_v2.mode_switch(" +
+		"time=" + strconv.FormatUint(uint64(e.Time), 10) +
+		", serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", mode=" + strconv.FormatUint(uint64(e.Mode), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletPadGroupModeSwitchEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("time", uint64(e.Time)),
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.Uint64("mode", uint64(e.Mode)),
+	)
+}
+
+// TabletPadGroupListener receives all the events of TabletPadGroup, embed
+// TabletPadGroupNoopListener to implement only some of them.
+type TabletPadGroupListener interface {
+	Buttons(e TabletPadGroupButtonsEvent)
+	Ring(e TabletPadGroupRingEvent)
+	Strip(e TabletPadGroupStripEvent)
+	Modes(e TabletPadGroupModesEvent)
+	Done(e TabletPadGroupDoneEvent)
+	ModeSwitch(e TabletPadGroupModeSwitchEvent)
+}
+
+// TabletPadGroupNoopListener is a TabletPadGroupListener ignoring
+// all the events, it closes the file descriptors they carry.
+type TabletPadGroupNoopListener struct{}
+
+func (TabletPadGroupNoopListener) Buttons(TabletPadGroupButtonsEvent) {
+}
+
+func (TabletPadGroupNoopListener) Ring(TabletPadGroupRingEvent) {
+}
+
+func (TabletPadGroupNoopListener) Strip(TabletPadGroupStripEvent) {
+}
+
+func (TabletPadGroupNoopListener) Modes(TabletPadGroupModesEvent) {
+}
+
+func (TabletPadGroupNoopListener) Done(TabletPadGroupDoneEvent) {
+}
+
+func (TabletPadGroupNoopListener) ModeSwitch(TabletPadGroupModeSwitchEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *TabletPadGroup) SetListener(l TabletPadGroupListener) {
+	if l == nil {
+		i.buttonsHandler = nil
+		i.ringHandler = nil
+		i.stripHandler = nil
+		i.modesHandler = nil
+		i.doneHandler = nil
+		i.modeSwitchHandler = nil
+		return
+	}
+	i.buttonsHandler = l.Buttons
+	i.ringHandler = l.Ring
+	i.stripHandler = l.Strip
+	i.modesHandler = l.Modes
+	i.doneHandler = l.Done
+	i.modeSwitchHandler = l.ModeSwitch
+}
+
 func (i *TabletPadGroup) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -2087,31 +3461,32 @@ func (i *TabletPadGroup) Dispatch(opcode uint32, fd int, data []byte) {
 		l := 0
 		buttonsLen := int(client.Uint32(data[l : l+4]))
 		l += 4
-		e.Buttons = make([]byte, buttonsLen)
-		copy(e.Buttons, data[l:l+buttonsLen])
-		l += buttonsLen
+		e.Buttons = client.Array[uint32](data[l : l+buttonsLen])
+		l += client.PaddedLen(buttonsLen)
 
 		i.buttonsHandler(e)
 	case 1:
-		if i.ringHandler == nil {
-			return
-		}
 		var e TabletPadGroupRingEvent
 		l := 0
-		e.Ring = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*TabletPadRing)
+		e.Ring = &TabletPadRing{}
+		i.Context().RegisterWithID(e.Ring, client.Uint32(data[l:l+4]))
+		e.Ring.SetVersion(i.Version())
 		l += 4
 
-		i.ringHandler(e)
-	case 2:
-		if i.stripHandler == nil {
-			return
+		if i.ringHandler != nil {
+			i.ringHandler(e)
 		}
+	case 2:
 		var e TabletPadGroupStripEvent
 		l := 0
-		e.Strip = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*TabletPadStrip)
+		e.Strip = &TabletPadStrip{}
+		i.Context().RegisterWithID(e.Strip, client.Uint32(data[l:l+4]))
+		e.Strip.SetVersion(i.Version())
 		l += 4
 
-		i.stripHandler(e)
+		if i.stripHandler != nil {
+			i.stripHandler(e)
+		}
 	case 3:
 		if i.modesHandler == nil {
 			return
@@ -2212,6 +3587,90 @@ func NewTabletPad(ctx *client.Context) *TabletPad {
 	return zwpTabletPadV2
 }
 
+// TabletPadInterface describes zwp_tablet_pad_v2 at runtime.
+var TabletPadInterface = &client.Interface{
+	Name:    "zwp_tablet_pad_v2",
+	Version: 1,
+	New:     func() client.Proxy { return &TabletPad{} },
+	Requests: []client.Message{
+		{
+			Name: "set_feedback",
+			Args: []client.Arg{
+				{Name: "button", Type: "uint"},
+				{Name: "description", Type: "string"},
+				{Name: "serial", Type: "uint"},
+			},
+		},
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "group",
+			Args: []client.Arg{
+				{Name: "pad_group", Type: "new_id", Interface: "zwp_tablet_pad_group_v2"},
+			},
+		},
+		{
+			Name: "path",
+			Args: []client.Arg{
+				{Name: "path", Type: "string"},
+			},
+		},
+		{
+			Name: "buttons",
+			Args: []client.Arg{
+				{Name: "buttons", Type: "uint"},
+			},
+		},
+		{
+			Name: "done",
+		},
+		{
+			Name: "button",
+			Args: []client.Arg{
+				{Name: "time", Type: "uint"},
+				{Name: "button", Type: "uint"},
+				{Name: "state", Type: "uint", Enum: "zwp_tablet_pad_v2.button_state"},
+			},
+		},
+		{
+			Name: "enter",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "tablet", Type: "object", Interface: "zwp_tablet_v2"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+			},
+		},
+		{
+			Name: "leave",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+			},
+		},
+		{
+			Name: "removed",
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "button_state",
+			Entries: []client.EnumEntry{
+				{Name: "released", Value: 0},
+				{Name: "pressed", Value: 1},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_tablet_pad_v2.
+func (i *TabletPad) Interface() *client.Interface {
+	return TabletPadInterface
+}
+
 // SetFeedback : set compositor feedback
 //
 // Requests the compositor to use the provided feedback string
@@ -2254,7 +3713,7 @@ func (i *TabletPad) SetFeedback(button uint32, description string, serial uint32
 	l += 4
 	client.PutUint32(_reqBuf[l:l+4], uint32(button))
 	l += 4
-	client.PutString(_reqBuf[l:l+(4+descriptionLen)], description, descriptionLen)
+	client.PutString(_reqBuf[l:l+(4+descriptionLen)], description)
 	l += (4 + descriptionLen)
 	client.PutUint32(_reqBuf[l:l+4], uint32(serial))
 	l += 4
@@ -2336,6 +3795,20 @@ func (i *TabletPad) SetGroupHandler(f TabletPadGroupHandlerFunc) {
 	i.groupHandler = f
 }
 
+// String formats the event as zwp_tablet_pad_v2.group(argument=value, ...).
+func (e TabletPadGroupEvent) String() string {
+	return "zwp_tablet_pad_v2.group(" +
+		"pad_group=" + client.ObjectString(e.PadGroup) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletPadGroupEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("pad_group", client.ObjectString(e.PadGroup)),
+	)
+}
+
 // TabletPadPathEvent : path to the device
 //
 // A system-specific device path that indicates which device is behind
@@ -2358,6 +3831,20 @@ func (i *TabletPad) SetPathHandler(f TabletPadPathHandlerFunc) {
 	i.pathHandler = f
 }
 
+// String formats the event as zwp_tablet_pad_v2.path(argument=value, ...).
+func (e TabletPadPathEvent) String() string {
+	return "zwp_tablet_pad_v2.path(" +
+		"path=" + strconv.Quote(e.Path) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletPadPathEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("path", e.Path),
+	)
+}
+
 // TabletPadButtonsEvent : buttons announced
 //
 // Sent on wp_tablet_pad initialization to announce the available
@@ -2376,6 +3863,20 @@ func (i *TabletPad) SetButtonsHandler(f TabletPadButtonsHandlerFunc) {
 	i.buttonsHandler = f
 }
 
+// String formats the event as zwp_tablet_pad_v2.buttons(argument=value, ...).
+func (e TabletPadButtonsEvent) String() string {
+	return "zwp_tablet_pad_v2.buttons(" +
+		"buttons=" + strconv.FormatUint(uint64(e.Buttons), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletPadButtonsEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("buttons", uint64(e.Buttons)),
+	)
+}
+
 // TabletPadDoneEvent : pad description event sequence complete
 //
 // This event signals the end of the initial burst of descriptive
@@ -2389,13 +3890,23 @@ func (i *TabletPad) SetDoneHandler(f TabletPadDoneHandlerFunc) {
 	i.doneHandler = f
 }
 
+// String formats the event as zwp_tablet_pad_v2.done(argument=value, ...).
+func (e TabletPadDoneEvent) String() string {
+	return "zwp_tablet_pad_v2.done()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletPadDoneEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // TabletPadButtonEvent : physical button state
 //
 // Sent whenever the physical state of a button changes.
 type TabletPadButtonEvent struct {
 	Time   uint32
 	Button uint32
-	State  uint32
+	State  TabletPadButtonState
 }
 type TabletPadButtonHandlerFunc func(TabletPadButtonEvent)
 
@@ -2404,6 +3915,24 @@ func (i *TabletPad) SetButtonHandler(f TabletPadButtonHandlerFunc) {
 	i.buttonHandler = f
 }
 
+// String formats the event as zwp_tablet_pad_v2.button(argument=value, ...).
+func (e TabletPadButtonEvent) String() string {
+	return "zwp_tablet_pad_v2.button(" +
+		"time=" + strconv.FormatUint(uint64(e.Time), 10) +
+		", button=" + strconv.FormatUint(uint64(e.Button), 10) +
+		", state=" + client.EnumString(e.State.Name(), uint32(e.State)) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletPadButtonEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("time", uint64(e.Time)),
+		slog.Uint64("button", uint64(e.Button)),
+		slog.String("state", client.EnumString(e.State.Name(), uint32(e.State))),
+	)
+}
+
 // TabletPadEnterEvent : enter event
 //
 // Notification that this pad is focused on the specified surface.
@@ -2419,6 +3948,24 @@ func (i *TabletPad) SetEnterHandler(f TabletPadEnterHandlerFunc) {
 	i.enterHandler = f
 }
 
+// String formats the event as zwp_tablet_pad_v2.enter(argument=value, ...).
+func (e TabletPadEnterEvent) String() string {
+	return "zwp_tablet_pad_v2.enter(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", tablet=" + client.ObjectString(e.Tablet) +
+		", surface=" + client.ObjectString(e.Surface) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletPadEnterEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.String("tablet", client.ObjectString(e.Tablet)),
+		slog.String("surface", client.ObjectString(e.Surface)),
+	)
+}
+
 // TabletPadLeaveEvent : leave event
 //
 // Notification that this pad is no longer focused on the specified
@@ -2434,6 +3981,22 @@ func (i *TabletPad) SetLeaveHandler(f TabletPadLeaveHandlerFunc) {
 	i.leaveHandler = f
 }
 
+// String formats the event as zwp_tablet_pad_v2.leave(argument=value, ...).
+func (e TabletPadLeaveEvent) String() string {
+	return "zwp_tablet_pad_v2.leave(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", surface=" + client.ObjectString(e.Surface) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletPadLeaveEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.String("surface", client.ObjectString(e.Surface)),
+	)
+}
+
 // TabletPadRemovedEvent : pad removed event
 //
 // Sent when the pad has been removed from the system. When a tablet
@@ -2450,18 +4013,94 @@ func (i *TabletPad) SetRemovedHandler(f TabletPadRemovedHandlerFunc) {
 	i.removedHandler = f
 }
 
+// String formats the event as zwp_tablet_pad_v2.removed(argument=value, ...).
+func (e TabletPadRemovedEvent) String() string {
+	return "zwp_tablet_pad_v2.removed()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TabletPadRemovedEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
+// TabletPadListener receives all the events of TabletPad, embed
+// TabletPadNoopListener to implement only some of them.
+type TabletPadListener interface {
+	Group(e TabletPadGroupEvent)
+	Path(e TabletPadPathEvent)
+	Buttons(e TabletPadButtonsEvent)
+	Done(e TabletPadDoneEvent)
+	Button(e TabletPadButtonEvent)
+	Enter(e TabletPadEnterEvent)
+	Leave(e TabletPadLeaveEvent)
+	Removed(e TabletPadRemovedEvent)
+}
+
+// TabletPadNoopListener is a TabletPadListener ignoring
+// all the events, it closes the file descriptors they carry.
+type TabletPadNoopListener struct{}
+
+func (TabletPadNoopListener) Group(TabletPadGroupEvent) {
+}
+
+func (TabletPadNoopListener) Path(TabletPadPathEvent) {
+}
+
+func (TabletPadNoopListener) Buttons(TabletPadButtonsEvent) {
+}
+
+func (TabletPadNoopListener) Done(TabletPadDoneEvent) {
+}
+
+func (TabletPadNoopListener) Button(TabletPadButtonEvent) {
+}
+
+func (TabletPadNoopListener) Enter(TabletPadEnterEvent) {
+}
+
+func (TabletPadNoopListener) Leave(TabletPadLeaveEvent) {
+}
+
+func (TabletPadNoopListener) Removed(TabletPadRemovedEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *TabletPad) SetListener(l TabletPadListener) {
+	if l == nil {
+		i.groupHandler = nil
+		i.pathHandler = nil
+		i.buttonsHandler = nil
+		i.doneHandler = nil
+		i.buttonHandler = nil
+		i.enterHandler = nil
+		i.leaveHandler = nil
+		i.removedHandler = nil
+		return
+	}
+	i.groupHandler = l.Group
+	i.pathHandler = l.Path
+	i.buttonsHandler = l.Buttons
+	i.doneHandler = l.Done
+	i.buttonHandler = l.Button
+	i.enterHandler = l.Enter
+	i.leaveHandler = l.Leave
+	i.removedHandler = l.Removed
+}
+
 func (i *TabletPad) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
-		if i.groupHandler == nil {
-			return
-		}
 		var e TabletPadGroupEvent
 		l := 0
-		e.PadGroup = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*TabletPadGroup)
+		e.PadGroup = &TabletPadGroup{}
+		i.Context().RegisterWithID(e.PadGroup, client.Uint32(data[l:l+4]))
+		e.PadGroup.SetVersion(i.Version())
 		l += 4
 
-		i.groupHandler(e)
+		if i.groupHandler != nil {
+			i.groupHandler(e)
+		}
 	case 1:
 		if i.pathHandler == nil {
 			return
@@ -2501,7 +4140,7 @@ func (i *TabletPad) Dispatch(opcode uint32, fd int, data []byte) {
 		l += 4
 		e.Button = client.Uint32(data[l : l+4])
 		l += 4
-		e.State = client.Uint32(data[l : l+4])
+		e.State = TabletPadButtonState(client.Uint32(data[l : l+4]))
 		l += 4
 
 		i.buttonHandler(e)
@@ -2513,9 +4152,9 @@ func (i *TabletPad) Dispatch(opcode uint32, fd int, data []byte) {
 		l := 0
 		e.Serial = client.Uint32(data[l : l+4])
 		l += 4
-		e.Tablet = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*Tablet)
+		e.Tablet, _ = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*Tablet)
 		l += 4
-		e.Surface = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*client.Surface)
+		e.Surface, _ = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*client.Surface)
 		l += 4
 
 		i.enterHandler(e)
@@ -2527,7 +4166,7 @@ func (i *TabletPad) Dispatch(opcode uint32, fd int, data []byte) {
 		l := 0
 		e.Serial = client.Uint32(data[l : l+4])
 		l += 4
-		e.Surface = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*client.Surface)
+		e.Surface, _ = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*client.Surface)
 		l += 4
 
 		i.leaveHandler(e)
@@ -2540,3 +4179,14 @@ func (i *TabletPad) Dispatch(opcode uint32, fd int, data []byte) {
 		i.removedHandler(e)
 	}
 }
+
+func init() {
+	client.RegisterInterface(TabletManagerInterface)
+	client.RegisterInterface(TabletSeatInterface)
+	client.RegisterInterface(TabletToolInterface)
+	client.RegisterInterface(TabletInterface)
+	client.RegisterInterface(TabletPadRingInterface)
+	client.RegisterInterface(TabletPadStripInterface)
+	client.RegisterInterface(TabletPadGroupInterface)
+	client.RegisterInterface(TabletPadInterface)
+}
@@ -1,3 +1 @@
 package text_input
-
-//go:generate go run github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner -pkg text_input -prefix zwp -suffix v1 -o text_input.go -i https://raw.githubusercontent.com/wayland-project/wayland-protocols/1.31/unstable/text-input/text-input-unstable-v1.xml
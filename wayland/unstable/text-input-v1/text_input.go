@@ -27,7 +27,12 @@
 
 package text_input
 
-import "github.com/rajveermalviya/go-wayland/wayland/client"
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+)
 
 // TextInput : text input
 //
@@ -114,6 +119,234 @@ func NewTextInput(ctx *client.Context) *TextInput {
 	return zwpTextInputV1
 }
 
+// TextInputInterface describes zwp_text_input_v1 at runtime.
+var TextInputInterface = &client.Interface{
+	Name:    "zwp_text_input_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &TextInput{} },
+	Requests: []client.Message{
+		{
+			Name: "activate",
+			Args: []client.Arg{
+				{Name: "seat", Type: "object", Interface: "wl_seat"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+			},
+		},
+		{
+			Name: "deactivate",
+			Args: []client.Arg{
+				{Name: "seat", Type: "object", Interface: "wl_seat"},
+			},
+		},
+		{
+			Name: "show_input_panel",
+		},
+		{
+			Name: "hide_input_panel",
+		},
+		{
+			Name: "reset",
+		},
+		{
+			Name: "set_surrounding_text",
+			Args: []client.Arg{
+				{Name: "text", Type: "string"},
+				{Name: "cursor", Type: "uint"},
+				{Name: "anchor", Type: "uint"},
+			},
+		},
+		{
+			Name: "set_content_type",
+			Args: []client.Arg{
+				{Name: "hint", Type: "uint"},
+				{Name: "purpose", Type: "uint"},
+			},
+		},
+		{
+			Name: "set_cursor_rectangle",
+			Args: []client.Arg{
+				{Name: "x", Type: "int"},
+				{Name: "y", Type: "int"},
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+			},
+		},
+		{
+			Name: "set_preferred_language",
+			Args: []client.Arg{
+				{Name: "language", Type: "string"},
+			},
+		},
+		{
+			Name: "commit_state",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+			},
+		},
+		{
+			Name: "invoke_action",
+			Args: []client.Arg{
+				{Name: "button", Type: "uint"},
+				{Name: "index", Type: "uint"},
+			},
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "enter",
+			Args: []client.Arg{
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+			},
+		},
+		{
+			Name: "leave",
+		},
+		{
+			Name: "modifiers_map",
+			Args: []client.Arg{
+				{Name: "map", Type: "array"},
+			},
+		},
+		{
+			Name: "input_panel_state",
+			Args: []client.Arg{
+				{Name: "state", Type: "uint"},
+			},
+		},
+		{
+			Name: "preedit_string",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "text", Type: "string"},
+				{Name: "commit", Type: "string"},
+			},
+		},
+		{
+			Name: "preedit_styling",
+			Args: []client.Arg{
+				{Name: "index", Type: "uint"},
+				{Name: "length", Type: "uint"},
+				{Name: "style", Type: "uint"},
+			},
+		},
+		{
+			Name: "preedit_cursor",
+			Args: []client.Arg{
+				{Name: "index", Type: "int"},
+			},
+		},
+		{
+			Name: "commit_string",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "text", Type: "string"},
+			},
+		},
+		{
+			Name: "cursor_position",
+			Args: []client.Arg{
+				{Name: "index", Type: "int"},
+				{Name: "anchor", Type: "int"},
+			},
+		},
+		{
+			Name: "delete_surrounding_text",
+			Args: []client.Arg{
+				{Name: "index", Type: "int"},
+				{Name: "length", Type: "uint"},
+			},
+		},
+		{
+			Name: "keysym",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "time", Type: "uint"},
+				{Name: "sym", Type: "uint"},
+				{Name: "state", Type: "uint"},
+				{Name: "modifiers", Type: "uint"},
+			},
+		},
+		{
+			Name: "language",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "language", Type: "string"},
+			},
+		},
+		{
+			Name: "text_direction",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+				{Name: "direction", Type: "uint"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "content_hint",
+			Entries: []client.EnumEntry{
+				{Name: "none", Value: 0x0},
+				{Name: "default", Value: 0x7},
+				{Name: "password", Value: 0xc0},
+				{Name: "auto_completion", Value: 0x1},
+				{Name: "auto_correction", Value: 0x2},
+				{Name: "auto_capitalization", Value: 0x4},
+				{Name: "lowercase", Value: 0x8},
+				{Name: "uppercase", Value: 0x10},
+				{Name: "titlecase", Value: 0x20},
+				{Name: "hidden_text", Value: 0x40},
+				{Name: "sensitive_data", Value: 0x80},
+				{Name: "latin", Value: 0x100},
+				{Name: "multiline", Value: 0x200},
+			},
+		},
+		{
+			Name: "content_purpose",
+			Entries: []client.EnumEntry{
+				{Name: "normal", Value: 0},
+				{Name: "alpha", Value: 1},
+				{Name: "digits", Value: 2},
+				{Name: "number", Value: 3},
+				{Name: "phone", Value: 4},
+				{Name: "url", Value: 5},
+				{Name: "email", Value: 6},
+				{Name: "name", Value: 7},
+				{Name: "password", Value: 8},
+				{Name: "date", Value: 9},
+				{Name: "time", Value: 10},
+				{Name: "datetime", Value: 11},
+				{Name: "terminal", Value: 12},
+			},
+		},
+		{
+			Name: "preedit_style",
+			Entries: []client.EnumEntry{
+				{Name: "default", Value: 0},
+				{Name: "none", Value: 1},
+				{Name: "active", Value: 2},
+				{Name: "inactive", Value: 3},
+				{Name: "highlight", Value: 4},
+				{Name: "underline", Value: 5},
+				{Name: "selection", Value: 6},
+				{Name: "incorrect", Value: 7},
+			},
+		},
+		{
+			Name: "text_direction",
+			Entries: []client.EnumEntry{
+				{Name: "auto", Value: 0},
+				{Name: "ltr", Value: 1},
+				{Name: "rtl", Value: 2},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_text_input_v1.
+func (i *TextInput) Interface() *client.Interface {
+	return TextInputInterface
+}
+
 // Activate : request activation
 //
 // Requests the text_input object to be activated (typically when the
@@ -227,7 +460,7 @@ func (i *TextInput) SetSurroundingText(text string, cursor, anchor uint32) error
 	l += 4
 	client.PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
 	l += 4
-	client.PutString(_reqBuf[l:l+(4+textLen)], text, textLen)
+	client.PutString(_reqBuf[l:l+(4+textLen)], text)
 	l += (4 + textLen)
 	client.PutUint32(_reqBuf[l:l+4], uint32(cursor))
 	l += 4
@@ -304,7 +537,7 @@ func (i *TextInput) SetPreferredLanguage(language string) error {
 	l += 4
 	client.PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
 	l += 4
-	client.PutString(_reqBuf[l:l+(4+languageLen)], language, languageLen)
+	client.PutString(_reqBuf[l:l+(4+languageLen)], language)
 	l += (4 + languageLen)
 	err := i.Context().WriteMsg(_reqBuf, nil)
 	return err
@@ -686,6 +919,20 @@ func (i *TextInput) SetEnterHandler(f TextInputEnterHandlerFunc) {
 	i.enterHandler = f
 }
 
+// String formats the event as zwp_text_input_v1.enter(argument=value, ...).
+func (e TextInputEnterEvent) String() string {
+	return "zwp_text_input_v1.enter(" +
+		"surface=" + client.ObjectString(e.Surface) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TextInputEnterEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("surface", client.ObjectString(e.Surface)),
+	)
+}
+
 // TextInputLeaveEvent : leave event
 //
 // Notify the text_input object when it lost focus. Either in response
@@ -699,6 +946,16 @@ func (i *TextInput) SetLeaveHandler(f TextInputLeaveHandlerFunc) {
 	i.leaveHandler = f
 }
 
+// String formats the event as zwp_text_input_v1.leave(argument=value, ...).
+func (e TextInputLeaveEvent) String() string {
+	return "zwp_text_input_v1.leave()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TextInputLeaveEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // TextInputModifiersMapEvent : modifiers map
 //
 // Transfer an array of 0-terminated modifier names. The position in
@@ -714,6 +971,20 @@ func (i *TextInput) SetModifiersMapHandler(f TextInputModifiersMapHandlerFunc) {
 	i.modifiersMapHandler = f
 }
 
+// String formats the event as zwp_text_input_v1.modifiers_map(argument=value, ...).
+func (e TextInputModifiersMapEvent) String() string {
+	return "zwp_text_input_v1.modifiers_map(" +
+		"map=" + "array[" + strconv.Itoa(len(e.Map)) + "]" +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TextInputModifiersMapEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Any("map", e.Map),
+	)
+}
+
 // TextInputInputPanelStateEvent : state of the input panel
 //
 // Notify when the visibility state of the input panel changed.
@@ -727,6 +998,20 @@ func (i *TextInput) SetInputPanelStateHandler(f TextInputInputPanelStateHandlerF
 	i.inputPanelStateHandler = f
 }
 
+// String formats the event as zwp_text_input_v1.input_panel_state(argument=value, ...).
+func (e TextInputInputPanelStateEvent) String() string {
+	return "zwp_text_input_v1.input_panel_state(" +
+		"state=" + strconv.FormatUint(uint64(e.State), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TextInputInputPanelStateEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("state", uint64(e.State)),
+	)
+}
+
 // TextInputPreeditStringEvent : pre-edit
 //
 // Notify when a new composing text (pre-edit) should be set around the
@@ -750,6 +1035,24 @@ func (i *TextInput) SetPreeditStringHandler(f TextInputPreeditStringHandlerFunc)
 	i.preeditStringHandler = f
 }
 
+// String formats the event as zwp_text_input_v1.preedit_string(argument=value, ...).
+func (e TextInputPreeditStringEvent) String() string {
+	return "zwp_text_input_v1.preedit_string(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", text=" + strconv.Quote(e.Text) +
+		", commit=" + strconv.Quote(e.Commit) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TextInputPreeditStringEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.String("text", e.Text),
+		slog.String("commit", e.Commit),
+	)
+}
+
 // TextInputPreeditStylingEvent : pre-edit styling
 //
 // Sets styling information on composing text. The style is applied for
@@ -771,6 +1074,24 @@ func (i *TextInput) SetPreeditStylingHandler(f TextInputPreeditStylingHandlerFun
 	i.preeditStylingHandler = f
 }
 
+// String formats the event as zwp_text_input_v1.preedit_styling(argument=value, ...).
+func (e TextInputPreeditStylingEvent) String() string {
+	return "zwp_text_input_v1.preedit_styling(" +
+		"index=" + strconv.FormatUint(uint64(e.Index), 10) +
+		", length=" + strconv.FormatUint(uint64(e.Length), 10) +
+		", style=" + strconv.FormatUint(uint64(e.Style), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TextInputPreeditStylingEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("index", uint64(e.Index)),
+		slog.Uint64("length", uint64(e.Length)),
+		slog.Uint64("style", uint64(e.Style)),
+	)
+}
+
 // TextInputPreeditCursorEvent : pre-edit cursor
 //
 // Sets the cursor position inside the composing text (as byte
@@ -788,6 +1109,20 @@ func (i *TextInput) SetPreeditCursorHandler(f TextInputPreeditCursorHandlerFunc)
 	i.preeditCursorHandler = f
 }
 
+// String formats the event as zwp_text_input_v1.preedit_cursor(argument=value, ...).
+func (e TextInputPreeditCursorEvent) String() string {
+	return "zwp_text_input_v1.preedit_cursor(" +
+		"index=" + strconv.FormatInt(int64(e.Index), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TextInputPreeditCursorEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int64("index", int64(e.Index)),
+	)
+}
+
 // TextInputCommitStringEvent : commit
 //
 // Notify when text should be inserted into the editor widget. The text to
@@ -808,6 +1143,22 @@ func (i *TextInput) SetCommitStringHandler(f TextInputCommitStringHandlerFunc) {
 	i.commitStringHandler = f
 }
 
+// String formats the event as zwp_text_input_v1.commit_string(argument=value, ...).
+func (e TextInputCommitStringEvent) String() string {
+	return "zwp_text_input_v1.commit_string(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", text=" + strconv.Quote(e.Text) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TextInputCommitStringEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.String("text", e.Text),
+	)
+}
+
 // TextInputCursorPositionEvent : set cursor to new position
 //
 // Notify when the cursor or anchor position should be modified.
@@ -825,6 +1176,22 @@ func (i *TextInput) SetCursorPositionHandler(f TextInputCursorPositionHandlerFun
 	i.cursorPositionHandler = f
 }
 
+// String formats the event as zwp_text_input_v1.cursor_position(argument=value, ...).
+func (e TextInputCursorPositionEvent) String() string {
+	return "zwp_text_input_v1.cursor_position(" +
+		"index=" + strconv.FormatInt(int64(e.Index), 10) +
+		", anchor=" + strconv.FormatInt(int64(e.Anchor), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TextInputCursorPositionEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int64("index", int64(e.Index)),
+		slog.Int64("anchor", int64(e.Anchor)),
+	)
+}
+
 // TextInputDeleteSurroundingTextEvent : delete surrounding text
 //
 // Notify when the text around the current cursor position should be
@@ -846,6 +1213,22 @@ func (i *TextInput) SetDeleteSurroundingTextHandler(f TextInputDeleteSurrounding
 	i.deleteSurroundingTextHandler = f
 }
 
+// String formats the event as zwp_text_input_v1.delete_surrounding_text(argument=value, ...).
+func (e TextInputDeleteSurroundingTextEvent) String() string {
+	return "zwp_text_input_v1.delete_surrounding_text(" +
+		"index=" + strconv.FormatInt(int64(e.Index), 10) +
+		", length=" + strconv.FormatUint(uint64(e.Length), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TextInputDeleteSurroundingTextEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int64("index", int64(e.Index)),
+		slog.Uint64("length", uint64(e.Length)),
+	)
+}
+
 // TextInputKeysymEvent : keysym
 //
 // Notify when a key event was sent. Key events should not be used
@@ -868,6 +1251,28 @@ func (i *TextInput) SetKeysymHandler(f TextInputKeysymHandlerFunc) {
 	i.keysymHandler = f
 }
 
+// String formats the event as zwp_text_input_v1.keysym(argument=value, ...).
+func (e TextInputKeysymEvent) String() string {
+	return "zwp_text_input_v1.keysym(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", time=" + strconv.FormatUint(uint64(e.Time), 10) +
+		", sym=" + strconv.FormatUint(uint64(e.Sym), 10) +
+		", state=" + strconv.FormatUint(uint64(e.State), 10) +
+		", modifiers=" + strconv.FormatUint(uint64(e.Modifiers), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TextInputKeysymEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.Uint64("time", uint64(e.Time)),
+		slog.Uint64("sym", uint64(e.Sym)),
+		slog.Uint64("state", uint64(e.State)),
+		slog.Uint64("modifiers", uint64(e.Modifiers)),
+	)
+}
+
 // TextInputLanguageEvent : language
 //
 // Sets the language of the input text. The "language" argument is an
@@ -883,6 +1288,22 @@ func (i *TextInput) SetLanguageHandler(f TextInputLanguageHandlerFunc) {
 	i.languageHandler = f
 }
 
+// String formats the event as zwp_text_input_v1.language(argument=value, ...).
+func (e TextInputLanguageEvent) String() string {
+	return "zwp_text_input_v1.language(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", language=" + strconv.Quote(e.Language) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TextInputLanguageEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.String("language", e.Language),
+	)
+}
+
 // TextInputTextDirectionEvent : text direction
 //
 // Sets the text direction of input text.
@@ -901,6 +1322,117 @@ func (i *TextInput) SetTextDirectionHandler(f TextInputTextDirectionHandlerFunc)
 	i.textDirectionHandler = f
 }
 
+// String formats the event as zwp_text_input_v1.text_direction(argument=value, ...).
+func (e TextInputTextDirectionEvent) String() string {
+	return "zwp_text_input_v1.text_direction(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		", direction=" + strconv.FormatUint(uint64(e.Direction), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TextInputTextDirectionEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+		slog.Uint64("direction", uint64(e.Direction)),
+	)
+}
+
+// TextInputListener receives all the events of TextInput, embed
+// TextInputNoopListener to implement only some of them.
+type TextInputListener interface {
+	Enter(e TextInputEnterEvent)
+	Leave(e TextInputLeaveEvent)
+	ModifiersMap(e TextInputModifiersMapEvent)
+	InputPanelState(e TextInputInputPanelStateEvent)
+	PreeditString(e TextInputPreeditStringEvent)
+	PreeditStyling(e TextInputPreeditStylingEvent)
+	PreeditCursor(e TextInputPreeditCursorEvent)
+	CommitString(e TextInputCommitStringEvent)
+	CursorPosition(e TextInputCursorPositionEvent)
+	DeleteSurroundingText(e TextInputDeleteSurroundingTextEvent)
+	Keysym(e TextInputKeysymEvent)
+	Language(e TextInputLanguageEvent)
+	TextDirection(e TextInputTextDirectionEvent)
+}
+
+// TextInputNoopListener is a TextInputListener ignoring
+// all the events, it closes the file descriptors they carry.
+type TextInputNoopListener struct{}
+
+func (TextInputNoopListener) Enter(TextInputEnterEvent) {
+}
+
+func (TextInputNoopListener) Leave(TextInputLeaveEvent) {
+}
+
+func (TextInputNoopListener) ModifiersMap(TextInputModifiersMapEvent) {
+}
+
+func (TextInputNoopListener) InputPanelState(TextInputInputPanelStateEvent) {
+}
+
+func (TextInputNoopListener) PreeditString(TextInputPreeditStringEvent) {
+}
+
+func (TextInputNoopListener) PreeditStyling(TextInputPreeditStylingEvent) {
+}
+
+func (TextInputNoopListener) PreeditCursor(TextInputPreeditCursorEvent) {
+}
+
+func (TextInputNoopListener) CommitString(TextInputCommitStringEvent) {
+}
+
+func (TextInputNoopListener) CursorPosition(TextInputCursorPositionEvent) {
+}
+
+func (TextInputNoopListener) DeleteSurroundingText(TextInputDeleteSurroundingTextEvent) {
+}
+
+func (TextInputNoopListener) Keysym(TextInputKeysymEvent) {
+}
+
+func (TextInputNoopListener) Language(TextInputLanguageEvent) {
+}
+
+func (TextInputNoopListener) TextDirection(TextInputTextDirectionEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *TextInput) SetListener(l TextInputListener) {
+	if l == nil {
+		i.enterHandler = nil
+		i.leaveHandler = nil
+		i.modifiersMapHandler = nil
+		i.inputPanelStateHandler = nil
+		i.preeditStringHandler = nil
+		i.preeditStylingHandler = nil
+		i.preeditCursorHandler = nil
+		i.commitStringHandler = nil
+		i.cursorPositionHandler = nil
+		i.deleteSurroundingTextHandler = nil
+		i.keysymHandler = nil
+		i.languageHandler = nil
+		i.textDirectionHandler = nil
+		return
+	}
+	i.enterHandler = l.Enter
+	i.leaveHandler = l.Leave
+	i.modifiersMapHandler = l.ModifiersMap
+	i.inputPanelStateHandler = l.InputPanelState
+	i.preeditStringHandler = l.PreeditString
+	i.preeditStylingHandler = l.PreeditStyling
+	i.preeditCursorHandler = l.PreeditCursor
+	i.commitStringHandler = l.CommitString
+	i.cursorPositionHandler = l.CursorPosition
+	i.deleteSurroundingTextHandler = l.DeleteSurroundingText
+	i.keysymHandler = l.Keysym
+	i.languageHandler = l.Language
+	i.textDirectionHandler = l.TextDirection
+}
+
 func (i *TextInput) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -909,7 +1441,7 @@ func (i *TextInput) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e TextInputEnterEvent
 		l := 0
-		e.Surface = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*client.Surface)
+		e.Surface, _ = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*client.Surface)
 		l += 4
 
 		i.enterHandler(e)
@@ -930,7 +1462,7 @@ func (i *TextInput) Dispatch(opcode uint32, fd int, data []byte) {
 		l += 4
 		e.Map = make([]byte, _mapLen)
 		copy(e.Map, data[l:l+_mapLen])
-		l += _mapLen
+		l += client.PaddedLen(_mapLen)
 
 		i.modifiersMapHandler(e)
 	case 3:
@@ -1086,11 +1618,32 @@ func NewTextInputManager(ctx *client.Context) *TextInputManager {
 	return zwpTextInputManagerV1
 }
 
+// TextInputManagerInterface describes zwp_text_input_manager_v1 at runtime.
+var TextInputManagerInterface = &client.Interface{
+	Name:    "zwp_text_input_manager_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &TextInputManager{} },
+	Requests: []client.Message{
+		{
+			Name: "create_text_input",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zwp_text_input_v1"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_text_input_manager_v1.
+func (i *TextInputManager) Interface() *client.Interface {
+	return TextInputManagerInterface
+}
+
 // CreateTextInput : create text input
 //
 // Creates a new text_input object.
 func (i *TextInputManager) CreateTextInput() (*TextInput, error) {
 	id := NewTextInput(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 0
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -1109,3 +1662,8 @@ func (i *TextInputManager) Destroy() error {
 	i.Context().Unregister(i)
 	return nil
 }
+
+func init() {
+	client.RegisterInterface(TextInputInterface)
+	client.RegisterInterface(TextInputManagerInterface)
+}
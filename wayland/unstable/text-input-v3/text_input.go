@@ -32,7 +32,13 @@
 
 package text_input
 
-import "github.com/rajveermalviya/go-wayland/wayland/client"
+import (
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+)
 
 // TextInput : text input
 //
@@ -106,6 +112,149 @@ func NewTextInput(ctx *client.Context) *TextInput {
 	return zwpTextInputV3
 }
 
+// TextInputInterface describes zwp_text_input_v3 at runtime.
+var TextInputInterface = &client.Interface{
+	Name:    "zwp_text_input_v3",
+	Version: 1,
+	New:     func() client.Proxy { return &TextInput{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "enable",
+		},
+		{
+			Name: "disable",
+		},
+		{
+			Name: "set_surrounding_text",
+			Args: []client.Arg{
+				{Name: "text", Type: "string"},
+				{Name: "cursor", Type: "int"},
+				{Name: "anchor", Type: "int"},
+			},
+		},
+		{
+			Name: "set_text_change_cause",
+			Args: []client.Arg{
+				{Name: "cause", Type: "uint", Enum: "zwp_text_input_v3.change_cause"},
+			},
+		},
+		{
+			Name: "set_content_type",
+			Args: []client.Arg{
+				{Name: "hint", Type: "uint", Enum: "zwp_text_input_v3.content_hint"},
+				{Name: "purpose", Type: "uint", Enum: "zwp_text_input_v3.content_purpose"},
+			},
+		},
+		{
+			Name: "set_cursor_rectangle",
+			Args: []client.Arg{
+				{Name: "x", Type: "int"},
+				{Name: "y", Type: "int"},
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+			},
+		},
+		{
+			Name: "commit",
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "enter",
+			Args: []client.Arg{
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+			},
+		},
+		{
+			Name: "leave",
+			Args: []client.Arg{
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+			},
+		},
+		{
+			Name: "preedit_string",
+			Args: []client.Arg{
+				{Name: "text", Type: "string", Nullable: true},
+				{Name: "cursor_begin", Type: "int"},
+				{Name: "cursor_end", Type: "int"},
+			},
+		},
+		{
+			Name: "commit_string",
+			Args: []client.Arg{
+				{Name: "text", Type: "string", Nullable: true},
+			},
+		},
+		{
+			Name: "delete_surrounding_text",
+			Args: []client.Arg{
+				{Name: "before_length", Type: "uint"},
+				{Name: "after_length", Type: "uint"},
+			},
+		},
+		{
+			Name: "done",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "change_cause",
+			Entries: []client.EnumEntry{
+				{Name: "input_method", Value: 0},
+				{Name: "other", Value: 1},
+			},
+		},
+		{
+			Name:     "content_hint",
+			Bitfield: true,
+			Entries: []client.EnumEntry{
+				{Name: "none", Value: 0x0},
+				{Name: "completion", Value: 0x1},
+				{Name: "spellcheck", Value: 0x2},
+				{Name: "auto_capitalization", Value: 0x4},
+				{Name: "lowercase", Value: 0x8},
+				{Name: "uppercase", Value: 0x10},
+				{Name: "titlecase", Value: 0x20},
+				{Name: "hidden_text", Value: 0x40},
+				{Name: "sensitive_data", Value: 0x80},
+				{Name: "latin", Value: 0x100},
+				{Name: "multiline", Value: 0x200},
+			},
+		},
+		{
+			Name: "content_purpose",
+			Entries: []client.EnumEntry{
+				{Name: "normal", Value: 0},
+				{Name: "alpha", Value: 1},
+				{Name: "digits", Value: 2},
+				{Name: "number", Value: 3},
+				{Name: "phone", Value: 4},
+				{Name: "url", Value: 5},
+				{Name: "email", Value: 6},
+				{Name: "name", Value: 7},
+				{Name: "password", Value: 8},
+				{Name: "pin", Value: 9},
+				{Name: "date", Value: 10},
+				{Name: "time", Value: 11},
+				{Name: "datetime", Value: 12},
+				{Name: "terminal", Value: 13},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_text_input_v3.
+func (i *TextInput) Interface() *client.Interface {
+	return TextInputInterface
+}
+
 // Destroy : Destroy the wp_text_input
 //
 // Destroy the wp_text_input object. Also disables all surfaces enabled
@@ -231,7 +380,7 @@ func (i *TextInput) SetSurroundingText(text string, cursor, anchor int32) error
 	l += 4
 	client.PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
 	l += 4
-	client.PutString(_reqBuf[l:l+(4+textLen)], text, textLen)
+	client.PutString(_reqBuf[l:l+(4+textLen)], text)
 	l += (4 + textLen)
 	client.PutUint32(_reqBuf[l:l+4], uint32(cursor))
 	l += 4
@@ -257,7 +406,7 @@ func (i *TextInput) SetSurroundingText(text string, cursor, anchor int32) error
 // and reset to initial at the next zwp_text_input_v3.commit request.
 //
 // The initial value of cause is input_method.
-func (i *TextInput) SetTextChangeCause(cause uint32) error {
+func (i *TextInput) SetTextChangeCause(cause TextInputChangeCause) error {
 	const opcode = 4
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -285,7 +434,7 @@ func (i *TextInput) SetTextChangeCause(cause uint32) error {
 //
 // The initial value for hint is none, and the initial value for purpose
 // is normal.
-func (i *TextInput) SetContentType(hint, purpose uint32) error {
+func (i *TextInput) SetContentType(hint TextInputContentHint, purpose TextInputContentPurpose) error {
 	const opcode = 5
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -507,8 +656,29 @@ func (e TextInputContentHint) Value() string {
 	}
 }
 
+func (e TextInputContentHint) Has(f TextInputContentHint) bool {
+	return e&f == f
+}
+
+func (e TextInputContentHint) Set(f TextInputContentHint) TextInputContentHint {
+	return e | f
+}
+
 func (e TextInputContentHint) String() string {
-	return e.Name() + "=" + e.Value()
+	if e == 0 {
+		return "none"
+	}
+	var names []string
+	for _, f := range []TextInputContentHint{TextInputContentHintCompletion, TextInputContentHintSpellcheck, TextInputContentHintAutoCapitalization, TextInputContentHintLowercase, TextInputContentHintUppercase, TextInputContentHintTitlecase, TextInputContentHintHiddenText, TextInputContentHintSensitiveData, TextInputContentHintLatin, TextInputContentHintMultiline} {
+		if e.Has(f) {
+			names = append(names, f.Name())
+			e &^= f
+		}
+	}
+	if e != 0 {
+		names = append(names, "0x"+strconv.FormatUint(uint64(e), 16))
+	}
+	return strings.Join(names, "|")
 }
 
 type TextInputContentPurpose uint32
@@ -645,6 +815,20 @@ func (i *TextInput) SetEnterHandler(f TextInputEnterHandlerFunc) {
 	i.enterHandler = f
 }
 
+// String formats the event as zwp_text_input_v3.enter(argument=value, ...).
+func (e TextInputEnterEvent) String() string {
+	return "zwp_text_input_v3.enter(" +
+		"surface=" + client.ObjectString(e.Surface) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TextInputEnterEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("surface", client.ObjectString(e.Surface)),
+	)
+}
+
 // TextInputLeaveEvent : leave event
 //
 // Notification that this seat's text-input focus is no longer on a
@@ -668,6 +852,20 @@ func (i *TextInput) SetLeaveHandler(f TextInputLeaveHandlerFunc) {
 	i.leaveHandler = f
 }
 
+// String formats the event as zwp_text_input_v3.leave(argument=value, ...).
+func (e TextInputLeaveEvent) String() string {
+	return "zwp_text_input_v3.leave(" +
+		"surface=" + client.ObjectString(e.Surface) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TextInputLeaveEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("surface", client.ObjectString(e.Surface)),
+	)
+}
+
 // TextInputPreeditStringEvent : pre-edit
 //
 // Notify when a new composing text (pre-edit) should be set at the
@@ -689,7 +887,7 @@ func (i *TextInput) SetLeaveHandler(f TextInputLeaveHandlerFunc) {
 // The initial value of text is an empty string, and cursor_begin,
 // cursor_end and cursor_hidden are all 0.
 type TextInputPreeditStringEvent struct {
-	Text        string
+	Text        *string
 	CursorBegin int32
 	CursorEnd   int32
 }
@@ -700,6 +898,24 @@ func (i *TextInput) SetPreeditStringHandler(f TextInputPreeditStringHandlerFunc)
 	i.preeditStringHandler = f
 }
 
+// String formats the event as zwp_text_input_v3.preedit_string(argument=value, ...).
+func (e TextInputPreeditStringEvent) String() string {
+	return "zwp_text_input_v3.preedit_string(" +
+		"text=" + client.NullableString(e.Text) +
+		", cursor_begin=" + strconv.FormatInt(int64(e.CursorBegin), 10) +
+		", cursor_end=" + strconv.FormatInt(int64(e.CursorEnd), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TextInputPreeditStringEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Any("text", client.NullableValue(e.Text)),
+		slog.Int64("cursor_begin", int64(e.CursorBegin)),
+		slog.Int64("cursor_end", int64(e.CursorEnd)),
+	)
+}
+
 // TextInputCommitStringEvent : text commit
 //
 // Notify when text should be inserted into the editor widget. The text to
@@ -711,7 +927,7 @@ func (i *TextInput) SetPreeditStringHandler(f TextInputPreeditStringHandlerFunc)
 //
 // The initial value of text is an empty string.
 type TextInputCommitStringEvent struct {
-	Text string
+	Text *string
 }
 type TextInputCommitStringHandlerFunc func(TextInputCommitStringEvent)
 
@@ -720,6 +936,20 @@ func (i *TextInput) SetCommitStringHandler(f TextInputCommitStringHandlerFunc) {
 	i.commitStringHandler = f
 }
 
+// String formats the event as zwp_text_input_v3.commit_string(argument=value, ...).
+func (e TextInputCommitStringEvent) String() string {
+	return "zwp_text_input_v3.commit_string(" +
+		"text=" + client.NullableString(e.Text) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TextInputCommitStringEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Any("text", client.NullableValue(e.Text)),
+	)
+}
+
 // TextInputDeleteSurroundingTextEvent : delete surrounding text
 //
 // Notify when the text around the current cursor position should be
@@ -747,6 +977,22 @@ func (i *TextInput) SetDeleteSurroundingTextHandler(f TextInputDeleteSurrounding
 	i.deleteSurroundingTextHandler = f
 }
 
+// String formats the event as zwp_text_input_v3.delete_surrounding_text(argument=value, ...).
+func (e TextInputDeleteSurroundingTextEvent) String() string {
+	return "zwp_text_input_v3.delete_surrounding_text(" +
+		"before_length=" + strconv.FormatUint(uint64(e.BeforeLength), 10) +
+		", after_length=" + strconv.FormatUint(uint64(e.AfterLength), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TextInputDeleteSurroundingTextEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("before_length", uint64(e.BeforeLength)),
+		slog.Uint64("after_length", uint64(e.AfterLength)),
+	)
+}
+
 // TextInputDoneEvent : apply changes
 //
 // Instruct the application to apply changes to state requested by the
@@ -786,6 +1032,73 @@ func (i *TextInput) SetDoneHandler(f TextInputDoneHandlerFunc) {
 	i.doneHandler = f
 }
 
+// String formats the event as zwp_text_input_v3.done(argument=value, ...).
+func (e TextInputDoneEvent) String() string {
+	return "zwp_text_input_v3.done(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e TextInputDoneEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+	)
+}
+
+// TextInputListener receives all the events of TextInput, embed
+// TextInputNoopListener to implement only some of them.
+type TextInputListener interface {
+	Enter(e TextInputEnterEvent)
+	Leave(e TextInputLeaveEvent)
+	PreeditString(e TextInputPreeditStringEvent)
+	CommitString(e TextInputCommitStringEvent)
+	DeleteSurroundingText(e TextInputDeleteSurroundingTextEvent)
+	Done(e TextInputDoneEvent)
+}
+
+// TextInputNoopListener is a TextInputListener ignoring
+// all the events, it closes the file descriptors they carry.
+type TextInputNoopListener struct{}
+
+func (TextInputNoopListener) Enter(TextInputEnterEvent) {
+}
+
+func (TextInputNoopListener) Leave(TextInputLeaveEvent) {
+}
+
+func (TextInputNoopListener) PreeditString(TextInputPreeditStringEvent) {
+}
+
+func (TextInputNoopListener) CommitString(TextInputCommitStringEvent) {
+}
+
+func (TextInputNoopListener) DeleteSurroundingText(TextInputDeleteSurroundingTextEvent) {
+}
+
+func (TextInputNoopListener) Done(TextInputDoneEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *TextInput) SetListener(l TextInputListener) {
+	if l == nil {
+		i.enterHandler = nil
+		i.leaveHandler = nil
+		i.preeditStringHandler = nil
+		i.commitStringHandler = nil
+		i.deleteSurroundingTextHandler = nil
+		i.doneHandler = nil
+		return
+	}
+	i.enterHandler = l.Enter
+	i.leaveHandler = l.Leave
+	i.preeditStringHandler = l.PreeditString
+	i.commitStringHandler = l.CommitString
+	i.deleteSurroundingTextHandler = l.DeleteSurroundingText
+	i.doneHandler = l.Done
+}
+
 func (i *TextInput) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -794,7 +1107,7 @@ func (i *TextInput) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e TextInputEnterEvent
 		l := 0
-		e.Surface = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*client.Surface)
+		e.Surface, _ = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*client.Surface)
 		l += 4
 
 		i.enterHandler(e)
@@ -804,7 +1117,7 @@ func (i *TextInput) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e TextInputLeaveEvent
 		l := 0
-		e.Surface = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*client.Surface)
+		e.Surface, _ = i.Context().GetProxy(client.Uint32(data[l : l+4])).(*client.Surface)
 		l += 4
 
 		i.leaveHandler(e)
@@ -816,7 +1129,10 @@ func (i *TextInput) Dispatch(opcode uint32, fd int, data []byte) {
 		l := 0
 		textLen := client.PaddedLen(int(client.Uint32(data[l : l+4])))
 		l += 4
-		e.Text = client.String(data[l : l+textLen])
+		if textLen != 0 {
+			text := client.String(data[l : l+textLen])
+			e.Text = &text
+		}
 		l += textLen
 		e.CursorBegin = int32(client.Uint32(data[l : l+4]))
 		l += 4
@@ -832,7 +1148,10 @@ func (i *TextInput) Dispatch(opcode uint32, fd int, data []byte) {
 		l := 0
 		textLen := client.PaddedLen(int(client.Uint32(data[l : l+4])))
 		l += 4
-		e.Text = client.String(data[l : l+textLen])
+		if textLen != 0 {
+			text := client.String(data[l : l+textLen])
+			e.Text = &text
+		}
 		l += textLen
 
 		i.commitStringHandler(e)
@@ -877,6 +1196,31 @@ func NewTextInputManager(ctx *client.Context) *TextInputManager {
 	return zwpTextInputManagerV3
 }
 
+// TextInputManagerInterface describes zwp_text_input_manager_v3 at runtime.
+var TextInputManagerInterface = &client.Interface{
+	Name:    "zwp_text_input_manager_v3",
+	Version: 1,
+	New:     func() client.Proxy { return &TextInputManager{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "get_text_input",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zwp_text_input_v3"},
+				{Name: "seat", Type: "object", Interface: "wl_seat"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zwp_text_input_manager_v3.
+func (i *TextInputManager) Interface() *client.Interface {
+	return TextInputManagerInterface
+}
+
 // Destroy : Destroy the wp_text_input_manager
 //
 // Destroy the wp_text_input_manager object.
@@ -899,6 +1243,7 @@ func (i *TextInputManager) Destroy() error {
 // Creates a new text-input object for a given seat.
 func (i *TextInputManager) GetTextInput(seat *client.Seat) (*TextInput, error) {
 	id := NewTextInput(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -914,3 +1259,8 @@ func (i *TextInputManager) GetTextInput(seat *client.Seat) (*TextInput, error) {
 	err := i.Context().WriteMsg(_reqBuf[:], nil)
 	return id, err
 }
+
+func init() {
+	client.RegisterInterface(TextInputInterface)
+	client.RegisterInterface(TextInputManagerInterface)
+}
@@ -1,3 +1 @@
 package xdg_decoration
-
-//go:generate go run github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner -pkg xdg_decoration -prefix zxdg -suffix v1 -o xdg_decoration.go -i https://raw.githubusercontent.com/wayland-project/wayland-protocols/1.31/unstable/xdg-decoration/xdg-decoration-unstable-v1.xml
@@ -28,6 +28,8 @@
 package xdg_decoration
 
 import (
+	"log/slog"
+
 	"github.com/rajveermalviya/go-wayland/wayland/client"
 	xdg_shell "github.com/rajveermalviya/go-wayland/wayland/stable/xdg-shell"
 )
@@ -90,6 +92,31 @@ func NewDecorationManager(ctx *client.Context) *DecorationManager {
 	return zxdgDecorationManagerV1
 }
 
+// DecorationManagerInterface describes zxdg_decoration_manager_v1 at runtime.
+var DecorationManagerInterface = &client.Interface{
+	Name:    "zxdg_decoration_manager_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &DecorationManager{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "get_toplevel_decoration",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zxdg_toplevel_decoration_v1"},
+				{Name: "toplevel", Type: "object", Interface: "xdg_toplevel"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zxdg_decoration_manager_v1.
+func (i *DecorationManager) Interface() *client.Interface {
+	return DecorationManagerInterface
+}
+
 // Destroy : destroy the decoration manager object
 //
 // Destroy the decoration manager. This doesn't destroy objects created
@@ -119,6 +146,7 @@ func (i *DecorationManager) Destroy() error {
 // errors.
 func (i *DecorationManager) GetToplevelDecoration(toplevel *xdg_shell.Toplevel) (*ToplevelDecoration, error) {
 	id := NewToplevelDecoration(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -162,6 +190,58 @@ func NewToplevelDecoration(ctx *client.Context) *ToplevelDecoration {
 	return zxdgToplevelDecorationV1
 }
 
+// ToplevelDecorationInterface describes zxdg_toplevel_decoration_v1 at runtime.
+var ToplevelDecorationInterface = &client.Interface{
+	Name:    "zxdg_toplevel_decoration_v1",
+	Version: 1,
+	New:     func() client.Proxy { return &ToplevelDecoration{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "set_mode",
+			Args: []client.Arg{
+				{Name: "mode", Type: "uint", Enum: "zxdg_toplevel_decoration_v1.mode"},
+			},
+		},
+		{
+			Name: "unset_mode",
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "configure",
+			Args: []client.Arg{
+				{Name: "mode", Type: "uint", Enum: "zxdg_toplevel_decoration_v1.mode"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "unconfigured_buffer", Value: 0},
+				{Name: "already_constructed", Value: 1},
+				{Name: "orphaned", Value: 2},
+			},
+		},
+		{
+			Name: "mode",
+			Entries: []client.EnumEntry{
+				{Name: "client_side", Value: 1},
+				{Name: "server_side", Value: 2},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zxdg_toplevel_decoration_v1.
+func (i *ToplevelDecoration) Interface() *client.Interface {
+	return ToplevelDecorationInterface
+}
+
 // Destroy : destroy the decoration object
 //
 // Switch back to a mode without any server-side decorations at the next
@@ -202,7 +282,7 @@ func (i *ToplevelDecoration) Destroy() error {
 // same decoration mode.
 //
 //	mode: the decoration mode
-func (i *ToplevelDecoration) SetMode(mode uint32) error {
+func (i *ToplevelDecoration) SetMode(mode ToplevelDecorationMode) error {
 	const opcode = 1
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -326,7 +406,7 @@ func (e ToplevelDecorationMode) String() string {
 // A configure event can be sent at any time. The specified mode must be
 // obeyed by the client.
 type ToplevelDecorationConfigureEvent struct {
-	Mode uint32
+	Mode ToplevelDecorationMode
 }
 type ToplevelDecorationConfigureHandlerFunc func(ToplevelDecorationConfigureEvent)
 
@@ -335,6 +415,43 @@ func (i *ToplevelDecoration) SetConfigureHandler(f ToplevelDecorationConfigureHa
 	i.configureHandler = f
 }
 
+// String formats the event as zxdg_toplevel_decoration_v1.configure(argument=value, ...).
+func (e ToplevelDecorationConfigureEvent) String() string {
+	return "zxdg_toplevel_decoration_v1.configure(" +
+		"mode=" + client.EnumString(e.Mode.Name(), uint32(e.Mode)) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e ToplevelDecorationConfigureEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("mode", client.EnumString(e.Mode.Name(), uint32(e.Mode))),
+	)
+}
+
+// ToplevelDecorationListener receives all the events of ToplevelDecoration, embed
+// ToplevelDecorationNoopListener to implement only some of them.
+type ToplevelDecorationListener interface {
+	Configure(e ToplevelDecorationConfigureEvent)
+}
+
+// ToplevelDecorationNoopListener is a ToplevelDecorationListener ignoring
+// all the events, it closes the file descriptors they carry.
+type ToplevelDecorationNoopListener struct{}
+
+func (ToplevelDecorationNoopListener) Configure(ToplevelDecorationConfigureEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *ToplevelDecoration) SetListener(l ToplevelDecorationListener) {
+	if l == nil {
+		i.configureHandler = nil
+		return
+	}
+	i.configureHandler = l.Configure
+}
+
 func (i *ToplevelDecoration) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -343,9 +460,14 @@ func (i *ToplevelDecoration) Dispatch(opcode uint32, fd int, data []byte) {
 		}
 		var e ToplevelDecorationConfigureEvent
 		l := 0
-		e.Mode = client.Uint32(data[l : l+4])
+		e.Mode = ToplevelDecorationMode(client.Uint32(data[l : l+4]))
 		l += 4
 
 		i.configureHandler(e)
 	}
 }
+
+func init() {
+	client.RegisterInterface(DecorationManagerInterface)
+	client.RegisterInterface(ToplevelDecorationInterface)
+}
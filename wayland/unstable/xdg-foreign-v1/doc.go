@@ -1,3 +1 @@
 package xdg_foreign
-
-//go:generate go run github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner -pkg xdg_foreign -prefix zxdg -suffix v1 -o xdg_foreign.go -i https://raw.githubusercontent.com/wayland-project/wayland-protocols/1.31/unstable/xdg-foreign/xdg-foreign-unstable-v1.xml
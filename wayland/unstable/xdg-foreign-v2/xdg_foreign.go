@@ -27,7 +27,12 @@
 
 package xdg_foreign
 
-import "github.com/rajveermalviya/go-wayland/wayland/client"
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+)
 
 // Exporter : interface for exporting surfaces
 //
@@ -47,6 +52,39 @@ func NewExporter(ctx *client.Context) *Exporter {
 	return zxdgExporterV2
 }
 
+// ExporterInterface describes zxdg_exporter_v2 at runtime.
+var ExporterInterface = &client.Interface{
+	Name:    "zxdg_exporter_v2",
+	Version: 1,
+	New:     func() client.Proxy { return &Exporter{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "export_toplevel",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zxdg_exported_v2"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "invalid_surface", Value: 0},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zxdg_exporter_v2.
+func (i *Exporter) Interface() *client.Interface {
+	return ExporterInterface
+}
+
 // Destroy : destroy the xdg_exporter object
 //
 // Notify the compositor that the xdg_exporter object will no longer be
@@ -80,6 +118,7 @@ func (i *Exporter) Destroy() error {
 //	surface: the surface to export
 func (i *Exporter) ExportToplevel(surface *client.Surface) (*Exported, error) {
 	id := NewExported(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -149,6 +188,31 @@ func NewImporter(ctx *client.Context) *Importer {
 	return zxdgImporterV2
 }
 
+// ImporterInterface describes zxdg_importer_v2 at runtime.
+var ImporterInterface = &client.Interface{
+	Name:    "zxdg_importer_v2",
+	Version: 1,
+	New:     func() client.Proxy { return &Importer{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "import_toplevel",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zxdg_imported_v2"},
+				{Name: "handle", Type: "string"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zxdg_importer_v2.
+func (i *Importer) Interface() *client.Interface {
+	return ImporterInterface
+}
+
 // Destroy : destroy the xdg_importer object
 //
 // Notify the compositor that the xdg_importer object will no longer be
@@ -178,6 +242,7 @@ func (i *Importer) Destroy() error {
 //	handle: the exported surface handle
 func (i *Importer) ImportToplevel(handle string) (*Imported, error) {
 	id := NewImported(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	handleLen := client.PaddedLen(len(handle) + 1)
 	_reqBufLen := 8 + 4 + (4 + handleLen)
@@ -189,7 +254,7 @@ func (i *Importer) ImportToplevel(handle string) (*Imported, error) {
 	l += 4
 	client.PutUint32(_reqBuf[l:l+4], id.ID())
 	l += 4
-	client.PutString(_reqBuf[l:l+(4+handleLen)], handle, handleLen)
+	client.PutString(_reqBuf[l:l+(4+handleLen)], handle)
 	l += (4 + handleLen)
 	err := i.Context().WriteMsg(_reqBuf, nil)
 	return id, err
@@ -218,6 +283,32 @@ func NewExported(ctx *client.Context) *Exported {
 	return zxdgExportedV2
 }
 
+// ExportedInterface describes zxdg_exported_v2 at runtime.
+var ExportedInterface = &client.Interface{
+	Name:    "zxdg_exported_v2",
+	Version: 1,
+	New:     func() client.Proxy { return &Exported{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "handle",
+			Args: []client.Arg{
+				{Name: "handle", Type: "string"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zxdg_exported_v2.
+func (i *Exported) Interface() *client.Interface {
+	return ExportedInterface
+}
+
 // Destroy : unexport the exported surface
 //
 // Revoke the previously exported surface. This invalidates any
@@ -253,6 +344,43 @@ func (i *Exported) SetHandleHandler(f ExportedHandleHandlerFunc) {
 	i.handleHandler = f
 }
 
+// String formats the event as zxdg_exported_v2.handle(argument=value, ...).
+func (e ExportedHandleEvent) String() string {
+	return "zxdg_exported_v2.handle(" +
+		"handle=" + strconv.Quote(e.Handle) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e ExportedHandleEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("handle", e.Handle),
+	)
+}
+
+// ExportedListener receives all the events of Exported, embed
+// ExportedNoopListener to implement only some of them.
+type ExportedListener interface {
+	Handle(e ExportedHandleEvent)
+}
+
+// ExportedNoopListener is a ExportedListener ignoring
+// all the events, it closes the file descriptors they carry.
+type ExportedNoopListener struct{}
+
+func (ExportedNoopListener) Handle(ExportedHandleEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *Exported) SetListener(l ExportedListener) {
+	if l == nil {
+		i.handleHandler = nil
+		return
+	}
+	i.handleHandler = l.Handle
+}
+
 func (i *Exported) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -291,6 +419,43 @@ func NewImported(ctx *client.Context) *Imported {
 	return zxdgImportedV2
 }
 
+// ImportedInterface describes zxdg_imported_v2 at runtime.
+var ImportedInterface = &client.Interface{
+	Name:    "zxdg_imported_v2",
+	Version: 1,
+	New:     func() client.Proxy { return &Imported{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "set_parent_of",
+			Args: []client.Arg{
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+			},
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "destroyed",
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "invalid_surface", Value: 0},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zxdg_imported_v2.
+func (i *Imported) Interface() *client.Interface {
+	return ImportedInterface
+}
+
 // Destroy : destroy the xdg_imported object
 //
 // Notify the compositor that it will no longer use the xdg_imported
@@ -381,6 +546,39 @@ func (i *Imported) SetDestroyedHandler(f ImportedDestroyedHandlerFunc) {
 	i.destroyedHandler = f
 }
 
+// String formats the event as zxdg_imported_v2.destroyed(argument=value, ...).
+func (e ImportedDestroyedEvent) String() string {
+	return "zxdg_imported_v2.destroyed()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e ImportedDestroyedEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
+// ImportedListener receives all the events of Imported, embed
+// ImportedNoopListener to implement only some of them.
+type ImportedListener interface {
+	Destroyed(e ImportedDestroyedEvent)
+}
+
+// ImportedNoopListener is a ImportedListener ignoring
+// all the events, it closes the file descriptors they carry.
+type ImportedNoopListener struct{}
+
+func (ImportedNoopListener) Destroyed(ImportedDestroyedEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *Imported) SetListener(l ImportedListener) {
+	if l == nil {
+		i.destroyedHandler = nil
+		return
+	}
+	i.destroyedHandler = l.Destroyed
+}
+
 func (i *Imported) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -392,3 +590,10 @@ func (i *Imported) Dispatch(opcode uint32, fd int, data []byte) {
 		i.destroyedHandler(e)
 	}
 }
+
+func init() {
+	client.RegisterInterface(ExporterInterface)
+	client.RegisterInterface(ImporterInterface)
+	client.RegisterInterface(ExportedInterface)
+	client.RegisterInterface(ImportedInterface)
+}
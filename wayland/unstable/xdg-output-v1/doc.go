@@ -1,3 +1 @@
 package xdg_output
-
-//go:generate go run github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner -pkg xdg_output -prefix zxdg -suffix v1 -o xdg_output.go -i https://raw.githubusercontent.com/wayland-project/wayland-protocols/1.31/unstable/xdg-output/xdg-output-unstable-v1.xml
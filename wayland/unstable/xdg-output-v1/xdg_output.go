@@ -27,7 +27,12 @@
 
 package xdg_output
 
-import "github.com/rajveermalviya/go-wayland/wayland/client"
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+)
 
 // OutputManager : manage xdg_output objects
 //
@@ -45,6 +50,31 @@ func NewOutputManager(ctx *client.Context) *OutputManager {
 	return zxdgOutputManagerV1
 }
 
+// OutputManagerInterface describes zxdg_output_manager_v1 at runtime.
+var OutputManagerInterface = &client.Interface{
+	Name:    "zxdg_output_manager_v1",
+	Version: 3,
+	New:     func() client.Proxy { return &OutputManager{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "get_xdg_output",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zxdg_output_v1"},
+				{Name: "output", Type: "object", Interface: "wl_output"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zxdg_output_manager_v1.
+func (i *OutputManager) Interface() *client.Interface {
+	return OutputManagerInterface
+}
+
 // Destroy : destroy the xdg_output_manager object
 //
 // Using this request a client can tell the server that it is not
@@ -70,6 +100,7 @@ func (i *OutputManager) Destroy() error {
 // This creates a new xdg_output object for the given wl_output.
 func (i *OutputManager) GetXdgOutput(output *client.Output) (*Output, error) {
 	id := NewOutput(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -123,6 +154,57 @@ func NewOutput(ctx *client.Context) *Output {
 	return zxdgOutputV1
 }
 
+// OutputInterface describes zxdg_output_v1 at runtime.
+var OutputInterface = &client.Interface{
+	Name:    "zxdg_output_v1",
+	Version: 3,
+	New:     func() client.Proxy { return &Output{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "logical_position",
+			Args: []client.Arg{
+				{Name: "x", Type: "int"},
+				{Name: "y", Type: "int"},
+			},
+		},
+		{
+			Name: "logical_size",
+			Args: []client.Arg{
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+			},
+		},
+		{
+			Name: "done",
+		},
+		{
+			Name:  "name",
+			Since: 2,
+			Args: []client.Arg{
+				{Name: "name", Type: "string"},
+			},
+		},
+		{
+			Name:  "description",
+			Since: 2,
+			Args: []client.Arg{
+				{Name: "description", Type: "string"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zxdg_output_v1.
+func (i *Output) Interface() *client.Interface {
+	return OutputInterface
+}
+
 // Destroy : destroy the xdg_output object
 //
 // Using this request a client can tell the server that it is not
@@ -160,6 +242,22 @@ func (i *Output) SetLogicalPositionHandler(f OutputLogicalPositionHandlerFunc) {
 	i.logicalPositionHandler = f
 }
 
+// String formats the event as zxdg_output_v1.logical_position(argument=value, ...).
+func (e OutputLogicalPositionEvent) String() string {
+	return "zxdg_output_v1.logical_position(" +
+		"x=" + strconv.FormatInt(int64(e.X), 10) +
+		", y=" + strconv.FormatInt(int64(e.Y), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e OutputLogicalPositionEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int64("x", int64(e.X)),
+		slog.Int64("y", int64(e.Y)),
+	)
+}
+
 // OutputLogicalSizeEvent : size of the output in the global compositor space
 //
 // The logical_size event describes the size of the output in the
@@ -207,6 +305,22 @@ func (i *Output) SetLogicalSizeHandler(f OutputLogicalSizeHandlerFunc) {
 	i.logicalSizeHandler = f
 }
 
+// String formats the event as zxdg_output_v1.logical_size(argument=value, ...).
+func (e OutputLogicalSizeEvent) String() string {
+	return "zxdg_output_v1.logical_size(" +
+		"width=" + strconv.FormatInt(int64(e.Width), 10) +
+		", height=" + strconv.FormatInt(int64(e.Height), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e OutputLogicalSizeEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int64("width", int64(e.Width)),
+		slog.Int64("height", int64(e.Height)),
+	)
+}
+
 // OutputDoneEvent : all information about the output have been sent
 //
 // This event is sent after all other properties of an xdg_output
@@ -226,6 +340,16 @@ func (i *Output) SetDoneHandler(f OutputDoneHandlerFunc) {
 	i.doneHandler = f
 }
 
+// String formats the event as zxdg_output_v1.done(argument=value, ...).
+func (e OutputDoneEvent) String() string {
+	return "zxdg_output_v1.done()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e OutputDoneEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
 // OutputNameEvent : name of this output
 //
 // Many compositors will assign names to their outputs, show them to the
@@ -256,6 +380,20 @@ func (i *Output) SetNameHandler(f OutputNameHandlerFunc) {
 	i.nameHandler = f
 }
 
+// String formats the event as zxdg_output_v1.name(argument=value, ...).
+func (e OutputNameEvent) String() string {
+	return "zxdg_output_v1.name(" +
+		"name=" + strconv.Quote(e.Name) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e OutputNameEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("name", e.Name),
+	)
+}
+
 // OutputDescriptionEvent : human-readable description of this output
 //
 // Many compositors can produce human-readable descriptions of their
@@ -283,6 +421,67 @@ func (i *Output) SetDescriptionHandler(f OutputDescriptionHandlerFunc) {
 	i.descriptionHandler = f
 }
 
+// String formats the event as zxdg_output_v1.description(argument=value, ...).
+func (e OutputDescriptionEvent) String() string {
+	return "zxdg_output_v1.description(" +
+		"description=" + strconv.Quote(e.Description) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e OutputDescriptionEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("description", e.Description),
+	)
+}
+
+// OutputListener receives all the events of Output, embed
+// OutputNoopListener to implement only some of them.
+type OutputListener interface {
+	LogicalPosition(e OutputLogicalPositionEvent)
+	LogicalSize(e OutputLogicalSizeEvent)
+	Done(e OutputDoneEvent)
+	Name(e OutputNameEvent)
+	Description(e OutputDescriptionEvent)
+}
+
+// OutputNoopListener is a OutputListener ignoring
+// all the events, it closes the file descriptors they carry.
+type OutputNoopListener struct{}
+
+func (OutputNoopListener) LogicalPosition(OutputLogicalPositionEvent) {
+}
+
+func (OutputNoopListener) LogicalSize(OutputLogicalSizeEvent) {
+}
+
+func (OutputNoopListener) Done(OutputDoneEvent) {
+}
+
+func (OutputNoopListener) Name(OutputNameEvent) {
+}
+
+func (OutputNoopListener) Description(OutputDescriptionEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *Output) SetListener(l OutputListener) {
+	if l == nil {
+		i.logicalPositionHandler = nil
+		i.logicalSizeHandler = nil
+		i.doneHandler = nil
+		i.nameHandler = nil
+		i.descriptionHandler = nil
+		return
+	}
+	i.logicalPositionHandler = l.LogicalPosition
+	i.logicalSizeHandler = l.LogicalSize
+	i.doneHandler = l.Done
+	i.nameHandler = l.Name
+	i.descriptionHandler = l.Description
+}
+
 func (i *Output) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -342,3 +541,8 @@ func (i *Output) Dispatch(opcode uint32, fd int, data []byte) {
 		i.descriptionHandler(e)
 	}
 }
+
+func init() {
+	client.RegisterInterface(OutputManagerInterface)
+	client.RegisterInterface(OutputInterface)
+}
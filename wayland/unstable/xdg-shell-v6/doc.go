@@ -1,3 +1 @@
 package xdg_shell
-
-//go:generate go run github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner -pkg xdg_shell -prefix zxdg -suffix v6 -o xdg_shell.go -i https://raw.githubusercontent.com/wayland-project/wayland-protocols/1.31/unstable/xdg-shell/xdg-shell-unstable-v6.xml
@@ -30,7 +30,14 @@
 
 package xdg_shell
 
-import "github.com/rajveermalviya/go-wayland/wayland/client"
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+)
 
 // Shell : create desktop-style surfaces
 //
@@ -55,6 +62,64 @@ func NewShell(ctx *client.Context) *Shell {
 	return zxdgShellV6
 }
 
+// ShellInterface describes zxdg_shell_v6 at runtime.
+var ShellInterface = &client.Interface{
+	Name:    "zxdg_shell_v6",
+	Version: 1,
+	New:     func() client.Proxy { return &Shell{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "create_positioner",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zxdg_positioner_v6"},
+			},
+		},
+		{
+			Name: "get_xdg_surface",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zxdg_surface_v6"},
+				{Name: "surface", Type: "object", Interface: "wl_surface"},
+			},
+		},
+		{
+			Name: "pong",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+			},
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "ping",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "role", Value: 0},
+				{Name: "defunct_surfaces", Value: 1},
+				{Name: "not_the_topmost_popup", Value: 2},
+				{Name: "invalid_popup_parent", Value: 3},
+				{Name: "invalid_surface_state", Value: 4},
+				{Name: "invalid_positioner", Value: 5},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zxdg_shell_v6.
+func (i *Shell) Interface() *client.Interface {
+	return ShellInterface
+}
+
 // Destroy : destroy xdg_shell
 //
 // Destroy this xdg_shell object.
@@ -83,6 +148,7 @@ func (i *Shell) Destroy() error {
 // and xdg_surface.get_popup for details.
 func (i *Shell) CreatePositioner() (*Positioner, error) {
 	id := NewPositioner(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -112,6 +178,7 @@ func (i *Shell) CreatePositioner() (*Positioner, error) {
 // xdg_surface is and how it is used.
 func (i *Shell) GetXdgSurface(surface *client.Surface) (*Surface, error) {
 	id := NewSurface(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 2
 	const _reqBufLen = 8 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -232,6 +299,43 @@ func (i *Shell) SetPingHandler(f ShellPingHandlerFunc) {
 	i.pingHandler = f
 }
 
+// String formats the event as zxdg_shell_v6.ping(argument=value, ...).
+func (e ShellPingEvent) String() string {
+	return "zxdg_shell_v6.ping(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e ShellPingEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+	)
+}
+
+// ShellListener receives all the events of Shell, embed
+// ShellNoopListener to implement only some of them.
+type ShellListener interface {
+	Ping(e ShellPingEvent)
+}
+
+// ShellNoopListener is a ShellListener ignoring
+// all the events, it closes the file descriptors they carry.
+type ShellNoopListener struct{}
+
+func (ShellNoopListener) Ping(ShellPingEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *Shell) SetListener(l ShellListener) {
+	if l == nil {
+		i.pingHandler = nil
+		return
+	}
+	i.pingHandler = l.Ping
+}
+
 func (i *Shell) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -299,6 +403,108 @@ func NewPositioner(ctx *client.Context) *Positioner {
 	return zxdgPositionerV6
 }
 
+// PositionerInterface describes zxdg_positioner_v6 at runtime.
+var PositionerInterface = &client.Interface{
+	Name:    "zxdg_positioner_v6",
+	Version: 1,
+	New:     func() client.Proxy { return &Positioner{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "set_size",
+			Args: []client.Arg{
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+			},
+		},
+		{
+			Name: "set_anchor_rect",
+			Args: []client.Arg{
+				{Name: "x", Type: "int"},
+				{Name: "y", Type: "int"},
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+			},
+		},
+		{
+			Name: "set_anchor",
+			Args: []client.Arg{
+				{Name: "anchor", Type: "uint", Enum: "zxdg_positioner_v6.anchor"},
+			},
+		},
+		{
+			Name: "set_gravity",
+			Args: []client.Arg{
+				{Name: "gravity", Type: "uint", Enum: "zxdg_positioner_v6.gravity"},
+			},
+		},
+		{
+			Name: "set_constraint_adjustment",
+			Args: []client.Arg{
+				{Name: "constraint_adjustment", Type: "uint", Enum: "zxdg_positioner_v6.constraint_adjustment"},
+			},
+		},
+		{
+			Name: "set_offset",
+			Args: []client.Arg{
+				{Name: "x", Type: "int"},
+				{Name: "y", Type: "int"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "invalid_input", Value: 0},
+			},
+		},
+		{
+			Name:     "anchor",
+			Bitfield: true,
+			Entries: []client.EnumEntry{
+				{Name: "none", Value: 0},
+				{Name: "top", Value: 1},
+				{Name: "bottom", Value: 2},
+				{Name: "left", Value: 4},
+				{Name: "right", Value: 8},
+			},
+		},
+		{
+			Name:     "gravity",
+			Bitfield: true,
+			Entries: []client.EnumEntry{
+				{Name: "none", Value: 0},
+				{Name: "top", Value: 1},
+				{Name: "bottom", Value: 2},
+				{Name: "left", Value: 4},
+				{Name: "right", Value: 8},
+			},
+		},
+		{
+			Name:     "constraint_adjustment",
+			Bitfield: true,
+			Entries: []client.EnumEntry{
+				{Name: "none", Value: 0},
+				{Name: "slide_x", Value: 1},
+				{Name: "slide_y", Value: 2},
+				{Name: "flip_x", Value: 4},
+				{Name: "flip_y", Value: 8},
+				{Name: "resize_x", Value: 16},
+				{Name: "resize_y", Value: 32},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zxdg_positioner_v6.
+func (i *Positioner) Interface() *client.Interface {
+	return PositionerInterface
+}
+
 // Destroy : destroy the xdg_positioner object
 //
 // Notify the compositor that the xdg_positioner will no longer be used.
@@ -395,7 +601,7 @@ func (i *Positioner) SetAnchorRect(x, y, width, height int32) error {
 // the invalid_input error is raised.
 //
 //	anchor: bit mask of anchor edges
-func (i *Positioner) SetAnchor(anchor uint32) error {
+func (i *Positioner) SetAnchor(anchor PositionerAnchor) error {
 	const opcode = 3
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -423,7 +629,7 @@ func (i *Positioner) SetAnchor(anchor uint32) error {
 // invalid_input error is raised.
 //
 //	gravity: bit mask of gravity directions
-func (i *Positioner) SetGravity(gravity uint32) error {
+func (i *Positioner) SetGravity(gravity PositionerGravity) error {
 	const opcode = 4
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -455,7 +661,7 @@ func (i *Positioner) SetGravity(gravity uint32) error {
 // The default adjustment is none.
 //
 //	constraintAdjustment: bit mask of constraint adjustments
-func (i *Positioner) SetConstraintAdjustment(constraintAdjustment uint32) error {
+func (i *Positioner) SetConstraintAdjustment(constraintAdjustment PositionerConstraintAdjustment) error {
 	const opcode = 5
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -583,8 +789,29 @@ func (e PositionerAnchor) Value() string {
 	}
 }
 
+func (e PositionerAnchor) Has(f PositionerAnchor) bool {
+	return e&f == f
+}
+
+func (e PositionerAnchor) Set(f PositionerAnchor) PositionerAnchor {
+	return e | f
+}
+
 func (e PositionerAnchor) String() string {
-	return e.Name() + "=" + e.Value()
+	if e == 0 {
+		return "none"
+	}
+	var names []string
+	for _, f := range []PositionerAnchor{PositionerAnchorTop, PositionerAnchorBottom, PositionerAnchorLeft, PositionerAnchorRight} {
+		if e.Has(f) {
+			names = append(names, f.Name())
+			e &^= f
+		}
+	}
+	if e != 0 {
+		names = append(names, "0x"+strconv.FormatUint(uint64(e), 16))
+	}
+	return strings.Join(names, "|")
 }
 
 type PositionerGravity uint32
@@ -637,8 +864,29 @@ func (e PositionerGravity) Value() string {
 	}
 }
 
+func (e PositionerGravity) Has(f PositionerGravity) bool {
+	return e&f == f
+}
+
+func (e PositionerGravity) Set(f PositionerGravity) PositionerGravity {
+	return e | f
+}
+
 func (e PositionerGravity) String() string {
-	return e.Name() + "=" + e.Value()
+	if e == 0 {
+		return "none"
+	}
+	var names []string
+	for _, f := range []PositionerGravity{PositionerGravityTop, PositionerGravityBottom, PositionerGravityLeft, PositionerGravityRight} {
+		if e.Has(f) {
+			names = append(names, f.Name())
+			e &^= f
+		}
+	}
+	if e != 0 {
+		names = append(names, "0x"+strconv.FormatUint(uint64(e), 16))
+	}
+	return strings.Join(names, "|")
 }
 
 type PositionerConstraintAdjustment uint32
@@ -708,8 +956,29 @@ func (e PositionerConstraintAdjustment) Value() string {
 	}
 }
 
+func (e PositionerConstraintAdjustment) Has(f PositionerConstraintAdjustment) bool {
+	return e&f == f
+}
+
+func (e PositionerConstraintAdjustment) Set(f PositionerConstraintAdjustment) PositionerConstraintAdjustment {
+	return e | f
+}
+
 func (e PositionerConstraintAdjustment) String() string {
-	return e.Name() + "=" + e.Value()
+	if e == 0 {
+		return "none"
+	}
+	var names []string
+	for _, f := range []PositionerConstraintAdjustment{PositionerConstraintAdjustmentSlideX, PositionerConstraintAdjustmentSlideY, PositionerConstraintAdjustmentFlipX, PositionerConstraintAdjustmentFlipY, PositionerConstraintAdjustmentResizeX, PositionerConstraintAdjustmentResizeY} {
+		if e.Has(f) {
+			names = append(names, f.Name())
+			e &^= f
+		}
+	}
+	if e != 0 {
+		names = append(names, "0x"+strconv.FormatUint(uint64(e), 16))
+	}
+	return strings.Join(names, "|")
 }
 
 // Surface : desktop user interface surface base interface
@@ -787,6 +1056,71 @@ func NewSurface(ctx *client.Context) *Surface {
 	return zxdgSurfaceV6
 }
 
+// SurfaceInterface describes zxdg_surface_v6 at runtime.
+var SurfaceInterface = &client.Interface{
+	Name:    "zxdg_surface_v6",
+	Version: 1,
+	New:     func() client.Proxy { return &Surface{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "get_toplevel",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zxdg_toplevel_v6"},
+			},
+		},
+		{
+			Name: "get_popup",
+			Args: []client.Arg{
+				{Name: "id", Type: "new_id", Interface: "zxdg_popup_v6"},
+				{Name: "parent", Type: "object", Interface: "zxdg_surface_v6"},
+				{Name: "positioner", Type: "object", Interface: "zxdg_positioner_v6"},
+			},
+		},
+		{
+			Name: "set_window_geometry",
+			Args: []client.Arg{
+				{Name: "x", Type: "int"},
+				{Name: "y", Type: "int"},
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+			},
+		},
+		{
+			Name: "ack_configure",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+			},
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "configure",
+			Args: []client.Arg{
+				{Name: "serial", Type: "uint"},
+			},
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "not_constructed", Value: 1},
+				{Name: "already_constructed", Value: 2},
+				{Name: "unconfigured_buffer", Value: 3},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zxdg_surface_v6.
+func (i *Surface) Interface() *client.Interface {
+	return SurfaceInterface
+}
+
 // Destroy : destroy the xdg_surface
 //
 // Destroy the xdg_surface object. An xdg_surface must only be destroyed
@@ -814,6 +1148,7 @@ func (i *Surface) Destroy() error {
 // xdg_toplevel is and how it is used.
 func (i *Surface) GetToplevel() (*Toplevel, error) {
 	id := NewToplevel(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 1
 	const _reqBufLen = 8 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -837,6 +1172,7 @@ func (i *Surface) GetToplevel() (*Toplevel, error) {
 // xdg_popup is and how it is used.
 func (i *Surface) GetPopup(parent *Surface, positioner *Positioner) (*Popup, error) {
 	id := NewPopup(i.Context())
+	id.SetVersion(i.Version())
 	const opcode = 2
 	const _reqBufLen = 8 + 4 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -1007,6 +1343,43 @@ func (i *Surface) SetConfigureHandler(f SurfaceConfigureHandlerFunc) {
 	i.configureHandler = f
 }
 
+// String formats the event as zxdg_surface_v6.configure(argument=value, ...).
+func (e SurfaceConfigureEvent) String() string {
+	return "zxdg_surface_v6.configure(" +
+		"serial=" + strconv.FormatUint(uint64(e.Serial), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e SurfaceConfigureEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Uint64("serial", uint64(e.Serial)),
+	)
+}
+
+// SurfaceListener receives all the events of Surface, embed
+// SurfaceNoopListener to implement only some of them.
+type SurfaceListener interface {
+	Configure(e SurfaceConfigureEvent)
+}
+
+// SurfaceNoopListener is a SurfaceListener ignoring
+// all the events, it closes the file descriptors they carry.
+type SurfaceNoopListener struct{}
+
+func (SurfaceNoopListener) Configure(SurfaceConfigureEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *Surface) SetListener(l SurfaceListener) {
+	if l == nil {
+		i.configureHandler = nil
+		return
+	}
+	i.configureHandler = l.Configure
+}
+
 func (i *Surface) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -1048,6 +1421,136 @@ func NewToplevel(ctx *client.Context) *Toplevel {
 	return zxdgToplevelV6
 }
 
+// ToplevelInterface describes zxdg_toplevel_v6 at runtime.
+var ToplevelInterface = &client.Interface{
+	Name:    "zxdg_toplevel_v6",
+	Version: 1,
+	New:     func() client.Proxy { return &Toplevel{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "set_parent",
+			Args: []client.Arg{
+				{Name: "parent", Type: "object", Interface: "zxdg_toplevel_v6", Nullable: true},
+			},
+		},
+		{
+			Name: "set_title",
+			Args: []client.Arg{
+				{Name: "title", Type: "string"},
+			},
+		},
+		{
+			Name: "set_app_id",
+			Args: []client.Arg{
+				{Name: "app_id", Type: "string"},
+			},
+		},
+		{
+			Name: "show_window_menu",
+			Args: []client.Arg{
+				{Name: "seat", Type: "object", Interface: "wl_seat"},
+				{Name: "serial", Type: "uint"},
+				{Name: "x", Type: "int"},
+				{Name: "y", Type: "int"},
+			},
+		},
+		{
+			Name: "move",
+			Args: []client.Arg{
+				{Name: "seat", Type: "object", Interface: "wl_seat"},
+				{Name: "serial", Type: "uint"},
+			},
+		},
+		{
+			Name: "resize",
+			Args: []client.Arg{
+				{Name: "seat", Type: "object", Interface: "wl_seat"},
+				{Name: "serial", Type: "uint"},
+				{Name: "edges", Type: "uint", Enum: "zxdg_toplevel_v6.resize_edge"},
+			},
+		},
+		{
+			Name: "set_max_size",
+			Args: []client.Arg{
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+			},
+		},
+		{
+			Name: "set_min_size",
+			Args: []client.Arg{
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+			},
+		},
+		{
+			Name: "set_maximized",
+		},
+		{
+			Name: "unset_maximized",
+		},
+		{
+			Name: "set_fullscreen",
+			Args: []client.Arg{
+				{Name: "output", Type: "object", Interface: "wl_output", Nullable: true},
+			},
+		},
+		{
+			Name: "unset_fullscreen",
+		},
+		{
+			Name: "set_minimized",
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "configure",
+			Args: []client.Arg{
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+				{Name: "states", Type: "array"},
+			},
+		},
+		{
+			Name: "close",
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "resize_edge",
+			Entries: []client.EnumEntry{
+				{Name: "none", Value: 0},
+				{Name: "top", Value: 1},
+				{Name: "bottom", Value: 2},
+				{Name: "left", Value: 4},
+				{Name: "top_left", Value: 5},
+				{Name: "bottom_left", Value: 6},
+				{Name: "right", Value: 8},
+				{Name: "top_right", Value: 9},
+				{Name: "bottom_right", Value: 10},
+			},
+		},
+		{
+			Name: "state",
+			Entries: []client.EnumEntry{
+				{Name: "maximized", Value: 1},
+				{Name: "fullscreen", Value: 2},
+				{Name: "resizing", Value: 3},
+				{Name: "activated", Value: 4},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zxdg_toplevel_v6.
+func (i *Toplevel) Interface() *client.Interface {
+	return ToplevelInterface
+}
+
 // Destroy : destroy the xdg_toplevel
 //
 // Unmap and destroy the window. The window will be effectively
@@ -1115,7 +1618,7 @@ func (i *Toplevel) SetTitle(title string) error {
 	l += 4
 	client.PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
 	l += 4
-	client.PutString(_reqBuf[l:l+(4+titleLen)], title, titleLen)
+	client.PutString(_reqBuf[l:l+(4+titleLen)], title)
 	l += (4 + titleLen)
 	err := i.Context().WriteMsg(_reqBuf, nil)
 	return err
@@ -1153,7 +1656,7 @@ func (i *Toplevel) SetAppId(appId string) error {
 	l += 4
 	client.PutUint32(_reqBuf[l:l+4], uint32(_reqBufLen<<16|opcode&0x0000ffff))
 	l += 4
-	client.PutString(_reqBuf[l:l+(4+appIdLen)], appId, appIdLen)
+	client.PutString(_reqBuf[l:l+(4+appIdLen)], appId)
 	l += (4 + appIdLen)
 	err := i.Context().WriteMsg(_reqBuf, nil)
 	return err
@@ -1272,7 +1775,7 @@ func (i *Toplevel) Move(seat *client.Seat, serial uint32) error {
 //	seat: the wl_seat of the user event
 //	serial: the serial of the user event
 //	edges: which edge or corner is being dragged
-func (i *Toplevel) Resize(seat *client.Seat, serial, edges uint32) error {
+func (i *Toplevel) Resize(seat *client.Seat, serial uint32, edges ToplevelResizeEdge) error {
 	const opcode = 6
 	const _reqBufLen = 8 + 4 + 4 + 4
 	var _reqBuf [_reqBufLen]byte
@@ -1680,7 +2183,7 @@ func (e ToplevelState) String() string {
 type ToplevelConfigureEvent struct {
 	Width  int32
 	Height int32
-	States []byte
+	States []ToplevelState
 }
 type ToplevelConfigureHandlerFunc func(ToplevelConfigureEvent)
 
@@ -1689,6 +2192,24 @@ func (i *Toplevel) SetConfigureHandler(f ToplevelConfigureHandlerFunc) {
 	i.configureHandler = f
 }
 
+// String formats the event as zxdg_toplevel_v6.configure(argument=value, ...).
+func (e ToplevelConfigureEvent) String() string {
+	return "zxdg_toplevel_v6.configure(" +
+		"width=" + strconv.FormatInt(int64(e.Width), 10) +
+		", height=" + strconv.FormatInt(int64(e.Height), 10) +
+		", states=" + fmt.Sprint(e.States) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e ToplevelConfigureEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int64("width", int64(e.Width)),
+		slog.Int64("height", int64(e.Height)),
+		slog.Any("states", e.States),
+	)
+}
+
 // ToplevelCloseEvent : surface wants to be closed
 //
 // The close event is sent by the compositor when the user
@@ -1707,6 +2228,45 @@ func (i *Toplevel) SetCloseHandler(f ToplevelCloseHandlerFunc) {
 	i.closeHandler = f
 }
 
+// String formats the event as zxdg_toplevel_v6.close(argument=value, ...).
+func (e ToplevelCloseEvent) String() string {
+	return "zxdg_toplevel_v6.close()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e ToplevelCloseEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
+// ToplevelListener receives all the events of Toplevel, embed
+// ToplevelNoopListener to implement only some of them.
+type ToplevelListener interface {
+	Configure(e ToplevelConfigureEvent)
+	Close(e ToplevelCloseEvent)
+}
+
+// ToplevelNoopListener is a ToplevelListener ignoring
+// all the events, it closes the file descriptors they carry.
+type ToplevelNoopListener struct{}
+
+func (ToplevelNoopListener) Configure(ToplevelConfigureEvent) {
+}
+
+func (ToplevelNoopListener) Close(ToplevelCloseEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *Toplevel) SetListener(l ToplevelListener) {
+	if l == nil {
+		i.configureHandler = nil
+		i.closeHandler = nil
+		return
+	}
+	i.configureHandler = l.Configure
+	i.closeHandler = l.Close
+}
+
 func (i *Toplevel) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -1721,9 +2281,8 @@ func (i *Toplevel) Dispatch(opcode uint32, fd int, data []byte) {
 		l += 4
 		statesLen := int(client.Uint32(data[l : l+4]))
 		l += 4
-		e.States = make([]byte, statesLen)
-		copy(e.States, data[l:l+statesLen])
-		l += statesLen
+		e.States = client.Array[ToplevelState](data[l : l+statesLen])
+		l += client.PaddedLen(statesLen)
 
 		i.configureHandler(e)
 	case 1:
@@ -1818,6 +2377,53 @@ func NewPopup(ctx *client.Context) *Popup {
 	return zxdgPopupV6
 }
 
+// PopupInterface describes zxdg_popup_v6 at runtime.
+var PopupInterface = &client.Interface{
+	Name:    "zxdg_popup_v6",
+	Version: 1,
+	New:     func() client.Proxy { return &Popup{} },
+	Requests: []client.Message{
+		{
+			Name:       "destroy",
+			Destructor: true,
+		},
+		{
+			Name: "grab",
+			Args: []client.Arg{
+				{Name: "seat", Type: "object", Interface: "wl_seat"},
+				{Name: "serial", Type: "uint"},
+			},
+		},
+	},
+	Events: []client.Message{
+		{
+			Name: "configure",
+			Args: []client.Arg{
+				{Name: "x", Type: "int"},
+				{Name: "y", Type: "int"},
+				{Name: "width", Type: "int"},
+				{Name: "height", Type: "int"},
+			},
+		},
+		{
+			Name: "popup_done",
+		},
+	},
+	Enums: []client.Enum{
+		{
+			Name: "error",
+			Entries: []client.EnumEntry{
+				{Name: "invalid_grab", Value: 0},
+			},
+		},
+	},
+}
+
+// Interface returns the description of zxdg_popup_v6.
+func (i *Popup) Interface() *client.Interface {
+	return PopupInterface
+}
+
 // Destroy : remove xdg_popup interface
 //
 // This destroys the popup. Explicitly destroying the xdg_popup
@@ -1954,6 +2560,26 @@ func (i *Popup) SetConfigureHandler(f PopupConfigureHandlerFunc) {
 	i.configureHandler = f
 }
 
+// String formats the event as zxdg_popup_v6.configure(argument=value, ...).
+func (e PopupConfigureEvent) String() string {
+	return "zxdg_popup_v6.configure(" +
+		"x=" + strconv.FormatInt(int64(e.X), 10) +
+		", y=" + strconv.FormatInt(int64(e.Y), 10) +
+		", width=" + strconv.FormatInt(int64(e.Width), 10) +
+		", height=" + strconv.FormatInt(int64(e.Height), 10) +
+		")"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PopupConfigureEvent) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int64("x", int64(e.X)),
+		slog.Int64("y", int64(e.Y)),
+		slog.Int64("width", int64(e.Width)),
+		slog.Int64("height", int64(e.Height)),
+	)
+}
+
 // PopupPopupDoneEvent : popup interaction is done
 //
 // The popup_done event is sent out when a popup is dismissed by the
@@ -1967,6 +2593,45 @@ func (i *Popup) SetPopupDoneHandler(f PopupPopupDoneHandlerFunc) {
 	i.popupDoneHandler = f
 }
 
+// String formats the event as zxdg_popup_v6.popup_done(argument=value, ...).
+func (e PopupPopupDoneEvent) String() string {
+	return "zxdg_popup_v6.popup_done()"
+}
+
+// LogValue groups the arguments of the event for log/slog.
+func (e PopupPopupDoneEvent) LogValue() slog.Value {
+	return slog.GroupValue()
+}
+
+// PopupListener receives all the events of Popup, embed
+// PopupNoopListener to implement only some of them.
+type PopupListener interface {
+	Configure(e PopupConfigureEvent)
+	PopupDone(e PopupPopupDoneEvent)
+}
+
+// PopupNoopListener is a PopupListener ignoring
+// all the events, it closes the file descriptors they carry.
+type PopupNoopListener struct{}
+
+func (PopupNoopListener) Configure(PopupConfigureEvent) {
+}
+
+func (PopupNoopListener) PopupDone(PopupPopupDoneEvent) {
+}
+
+// SetListener sets the handlers of all the events to the methods of l, a
+// nil listener removes them.
+func (i *Popup) SetListener(l PopupListener) {
+	if l == nil {
+		i.configureHandler = nil
+		i.popupDoneHandler = nil
+		return
+	}
+	i.configureHandler = l.Configure
+	i.popupDoneHandler = l.PopupDone
+}
+
 func (i *Popup) Dispatch(opcode uint32, fd int, data []byte) {
 	switch opcode {
 	case 0:
@@ -1994,3 +2659,11 @@ func (i *Popup) Dispatch(opcode uint32, fd int, data []byte) {
 		i.popupDoneHandler(e)
 	}
 }
+
+func init() {
+	client.RegisterInterface(ShellInterface)
+	client.RegisterInterface(PositionerInterface)
+	client.RegisterInterface(SurfaceInterface)
+	client.RegisterInterface(ToplevelInterface)
+	client.RegisterInterface(PopupInterface)
+}
@@ -0,0 +1,97 @@
+package waylandtest
+
+import (
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+
+	// xdg-shell is known to every Server, along with the core protocol
+	_ "github.com/rajveermalviya/go-wayland/wayland/stable/xdg-shell"
+)
+
+// Interface describes the wire format of a wayland interface, the same
+// information libwayland keeps in a wl_interface.
+type Interface struct {
+	Name     string
+	Version  uint32
+	Requests []Message
+	Events   []Message
+}
+
+// Message describes a single request or event.
+//
+// Signature uses libwayland's notation: one character per argument
+// ('i' int, 'u' uint, 'f' fixed, 's' string, 'o' object, 'n' new_id,
+// 'a' array, 'h' fd), prefixed with '?' when the argument is nullable.
+// Types holds the interface name of each object and new_id argument and
+// is empty for the other ones. A new_id without interface (as used by
+// wl_registry.bind) is sent as interface name, version and id.
+type Message struct {
+	Name       string
+	Signature  string
+	Types      []string
+	Destructor bool
+}
+
+func (m *Message) args() []argSpec {
+	var specs []argSpec
+	nullable := false
+	for _, c := range m.Signature {
+		if c == '?' {
+			nullable = true
+			continue
+		}
+		spec := argSpec{kind: byte(c), nullable: nullable}
+		if len(m.Types) > len(specs) {
+			spec.iface = m.Types[len(specs)]
+		}
+		specs = append(specs, spec)
+		nullable = false
+	}
+	return specs
+}
+
+type argSpec struct {
+	kind     byte
+	iface    string
+	nullable bool
+}
+
+// interfaceOf converts the description generated for an interface into
+// its wire format.
+func interfaceOf(ci *client.Interface) *Interface {
+	iface := &Interface{Name: ci.Name, Version: ci.Version}
+	for _, m := range ci.Requests {
+		iface.Requests = append(iface.Requests, messageOf(m))
+	}
+	for _, m := range ci.Events {
+		iface.Events = append(iface.Events, messageOf(m))
+	}
+	return iface
+}
+
+func messageOf(cm client.Message) Message {
+	m := Message{Name: cm.Name, Destructor: cm.Destructor}
+	typed := false
+	for _, arg := range cm.Args {
+		if arg.Nullable {
+			m.Signature += "?"
+		}
+		m.Signature += string(signatures[arg.Type])
+		m.Types = append(m.Types, arg.Interface)
+		typed = typed || arg.Interface != ""
+	}
+	if !typed {
+		m.Types = nil
+	}
+	return m
+}
+
+var signatures = map[string]byte{
+	"int":    'i',
+	"uint":   'u',
+	"fixed":  'f',
+	"string": 's',
+	"object": 'o',
+	"new_id": 'n',
+	"array":  'a',
+	"fd":     'h',
+}
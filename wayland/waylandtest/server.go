@@ -0,0 +1,570 @@
+// Package waylandtest provides a scriptable in-process fake compositor,
+// for testing code built on wayland/client without a running compositor.
+//
+// A Server is connected to a client.Display through a socketpair. Tests
+// drive both ends from the same goroutine: the code under test sends
+// requests on the client side, Expect reads and checks them on the
+// server side, Send emits events and Roundtrip lets the client dispatch
+// them.
+//
+//	s := waylandtest.NewServer(t)
+//	s.AddGlobal("wl_compositor", 5)
+//	registry, _ := s.Display().GetRegistry()
+//	registry.SetGlobalHandler(app.HandleRegistryGlobal)
+//	s.Roundtrip()
+//	s.Expect("wl_display", "get_registry", registry)
+//	s.Expect("wl_registry", "bind", uint32(1), "wl_compositor", uint32(5), waylandtest.Any)
+//
+// The server takes care of the core plumbing on its own: it advertises
+// globals on wl_display.get_registry, tracks objects created through
+// new_id arguments and wl_registry.bind, answers wl_display.sync and
+// sends wl_display.delete_id for destroyed objects.
+package waylandtest
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+	"github.com/rajveermalviya/go-wayland/wayland/wire"
+	"golang.org/x/sys/unix"
+)
+
+// serverIDStart is the first object id allocated by the server side.
+const serverIDStart = 0xff000000
+
+// Server is a fake compositor speaking to a single client.
+type Server struct {
+	// Timeout bounds how long Next and Expect wait for a request.
+	// Defaults to five seconds.
+	Timeout time.Duration
+
+	t       testing.TB
+	conn    *net.UnixConn
+	display *client.Display
+	closed  bool
+
+	ifaces     map[string]*Interface
+	objects    map[uint32]*Object
+	created    []*Object
+	registries []*Object
+	globals    []Global
+	nextName   uint32
+	nextID     uint32
+	serial     uint32
+
+	buf     []byte
+	fds     []int
+	pending []*Request
+}
+
+// Global is a global advertised through wl_registry.
+type Global struct {
+	Name      uint32
+	Interface string
+	Version   uint32
+}
+
+// Object is the server side of a protocol object.
+type Object struct {
+	ID        uint32
+	Interface string
+	Version   uint32
+
+	server *Server
+}
+
+// Send emits an event from this object, see Server.Send.
+func (o *Object) Send(event string, args ...any) {
+	o.server.t.Helper()
+	o.server.Send(o, event, args...)
+}
+
+func (o *Object) String() string {
+	if o == nil {
+		return "nil"
+	}
+	if o.Interface == "" {
+		return fmt.Sprintf("unknown@%d", o.ID)
+	}
+	return fmt.Sprintf("%s@%d", o.Interface, o.ID)
+}
+
+// Request is a decoded request received from the client.
+//
+// Args holds one value per argument: int32, uint32, float64, string (nil
+// when null), []byte, int for fds and *Object for objects and new_ids.
+// A new_id without interface is expanded to the interface name, the
+// version and the new *Object.
+type Request struct {
+	Object *Object
+	Opcode uint16
+	Name   string
+	Args   []any
+
+	message *Message
+}
+
+func (r *Request) String() string {
+	args := make([]string, len(r.Args))
+	for i, arg := range r.Args {
+		switch arg := arg.(type) {
+		case string:
+			args[i] = fmt.Sprintf("%q", arg)
+		default:
+			args[i] = fmt.Sprint(arg)
+		}
+	}
+	return fmt.Sprintf("%v.%s(%s)", r.Object, r.Name, strings.Join(args, ", "))
+}
+
+type anyArg struct{}
+
+func (anyArg) String() string { return "<any>" }
+
+// Any matches every argument value in Expect.
+var Any any = anyArg{}
+
+// NewServer creates a fake compositor and a client.Display connected to
+// it. Both ends are closed when the test finishes.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	fds, err := unix.Socketpair(unix.AF_UNIX, unix.SOCK_STREAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		t.Fatalf("waylandtest: unable to create socketpair: %v", err)
+	}
+
+	s := &Server{
+		Timeout:  5 * time.Second,
+		t:        t,
+		conn:     fileConn(t, fds[1], "waylandtest-server"),
+		ifaces:   map[string]*Interface{},
+		objects:  map[uint32]*Object{},
+		nextName: 1,
+		nextID:   serverIDStart,
+	}
+	s.display = client.ConnectConn(fileConn(t, fds[0], "waylandtest-client"))
+	s.addObject(&Object{ID: s.display.ID(), Interface: "wl_display", Version: 1, server: s})
+
+	t.Cleanup(s.Close)
+	return s
+}
+
+func fileConn(t testing.TB, fd int, name string) *net.UnixConn {
+	t.Helper()
+
+	f := os.NewFile(uintptr(fd), name)
+	defer f.Close()
+
+	conn, err := net.FileConn(f)
+	if err != nil {
+		t.Fatalf("waylandtest: unable to create conn: %v", err)
+	}
+	return conn.(*net.UnixConn)
+}
+
+// Display returns the client side of the connection.
+func (s *Server) Display() *client.Display {
+	return s.display
+}
+
+// Close closes both ends of the connection.
+func (s *Server) Close() {
+	if s.closed {
+		return
+	}
+	s.closed = true
+
+	_ = s.conn.Close()
+	_ = s.display.Context().Close()
+	for _, fd := range s.fds {
+		_ = unix.Close(fd)
+	}
+	s.fds = nil
+}
+
+// RegisterInterface teaches the server the wire format of additional
+// interfaces. The interfaces of the generated packages linked in the
+// test, registered with client.RegisterInterface, are known without it:
+// the core protocol, xdg-shell and the packages the test imports.
+func (s *Server) RegisterInterface(ifaces ...*Interface) {
+	for _, iface := range ifaces {
+		s.ifaces[iface.Name] = iface
+	}
+}
+
+// iface returns the wire format of the interface with the given name, or
+// nil when it is unknown.
+func (s *Server) iface(name string) *Interface {
+	if iface := s.ifaces[name]; iface != nil {
+		return iface
+	}
+	ci := client.LookupInterface(name)
+	if ci == nil {
+		return nil
+	}
+	iface := interfaceOf(ci)
+	s.ifaces[name] = iface
+	return iface
+}
+
+// AddGlobal advertises a global to current and future registries and
+// returns its name.
+func (s *Server) AddGlobal(iface string, version uint32) uint32 {
+	s.t.Helper()
+
+	if s.iface(iface) == nil {
+		s.t.Fatalf("waylandtest: unknown interface %q, use RegisterInterface", iface)
+	}
+
+	g := Global{Name: s.nextName, Interface: iface, Version: version}
+	s.nextName++
+	s.globals = append(s.globals, g)
+	for _, registry := range s.registries {
+		s.Send(registry, "global", g.Name, g.Interface, g.Version)
+	}
+	return g.Name
+}
+
+// RemoveGlobal withdraws a global added with AddGlobal.
+func (s *Server) RemoveGlobal(name uint32) {
+	s.t.Helper()
+
+	for i, g := range s.globals {
+		if g.Name == name {
+			s.globals = append(s.globals[:i], s.globals[i+1:]...)
+			for _, registry := range s.registries {
+				s.Send(registry, "global_remove", name)
+			}
+			return
+		}
+	}
+	s.t.Fatalf("waylandtest: no global with name %d", name)
+}
+
+// NewObject allocates a server side object, for new_id arguments of
+// events such as wl_data_device.data_offer.
+func (s *Server) NewObject(iface string, version uint32) *Object {
+	obj := &Object{ID: s.nextID, Interface: iface, Version: version, server: s}
+	s.nextID++
+	s.addObject(obj)
+	return obj
+}
+
+// Object returns the live object with the given id, or nil.
+func (s *Server) Object(id uint32) *Object {
+	return s.objects[id]
+}
+
+// Last returns the most recently created live object implementing
+// iface, or nil.
+func (s *Server) Last(iface string) *Object {
+	for i := len(s.created) - 1; i >= 0; i-- {
+		obj := s.created[i]
+		if obj.Interface == iface && s.objects[obj.ID] == obj {
+			return obj
+		}
+	}
+	return nil
+}
+
+// NextSerial returns a new event serial.
+func (s *Server) NextSerial() uint32 {
+	s.serial++
+	return s.serial
+}
+
+// Send emits an event from obj, which can be an *Object, a client proxy
+// or an object id. Arguments follow the event signature: integers of any
+// type (including generated enums) for int and uint, float64 for fixed,
+// string or nil for strings, []byte for arrays, int for fds and *Object,
+// client proxies, ids or nil for objects.
+func (s *Server) Send(obj any, event string, args ...any) {
+	s.t.Helper()
+
+	id, _ := objectID(obj)
+	o := s.objects[id]
+	if o == nil {
+		s.t.Fatalf("waylandtest: send %s: unknown object %v", event, obj)
+	}
+	iface := s.iface(o.Interface)
+	if iface == nil {
+		s.t.Fatalf("waylandtest: send %s: unknown interface %q", event, o.Interface)
+	}
+
+	opcode := -1
+	for i := range iface.Events {
+		if iface.Events[i].Name == event {
+			opcode = i
+			break
+		}
+	}
+	if opcode == -1 {
+		s.t.Fatalf("waylandtest: %s has no event %q", o.Interface, event)
+	}
+
+	m := &iface.Events[opcode]
+	msg, fds, err := s.encode(o.ID, opcode, m, args)
+	if err != nil {
+		s.t.Fatalf("waylandtest: %v: %v", o, err)
+	}
+
+	var oob []byte
+	if len(fds) > 0 {
+		oob = unix.UnixRights(fds...)
+	}
+	if _, _, err := s.conn.WriteMsgUnix(msg, oob, nil); err != nil {
+		s.t.Fatalf("waylandtest: unable to send %v.%s: %v", o, event, err)
+	}
+
+	if m.Destructor {
+		s.destroy(o)
+	}
+}
+
+// Next returns the next request sent by the client, waiting up to
+// Timeout for it.
+func (s *Server) Next() *Request {
+	s.t.Helper()
+
+	if len(s.pending) > 0 {
+		r := s.pending[0]
+		s.pending = s.pending[1:]
+		return r
+	}
+	return s.read()
+}
+
+// Expect reads the next request and fails the test unless it is the
+// given request. If args are given, they are compared with the decoded
+// arguments: integers by value whatever their type, objects by id (so
+// client proxies can be used) and Any or a func(any) bool match
+// anything they accept.
+func (s *Server) Expect(iface, request string, args ...any) *Request {
+	s.t.Helper()
+
+	r := s.Next()
+	if r.Object.Interface != iface || r.Name != request {
+		s.t.Fatalf("waylandtest: got request %v, want %s.%s", r, iface, request)
+	}
+	if len(args) == 0 {
+		return r
+	}
+	if len(args) != len(r.Args) {
+		s.t.Fatalf("waylandtest: %v: got %d arguments, want %d", r, len(r.Args), len(args))
+	}
+	for i, want := range args {
+		if !match(want, r.Args[i]) {
+			s.t.Fatalf("waylandtest: %v: argument %d is %v, want %v", r, i, r.Args[i], want)
+		}
+	}
+	return r
+}
+
+// Roundtrip makes the client send a wl_display.sync, reads all requests
+// sent before it, which stay available to Next and Expect, and then
+// dispatches events on the client until the sync callback is done.
+func (s *Server) Roundtrip() {
+	s.t.Helper()
+
+	callback, err := s.display.Sync()
+	if err != nil {
+		s.t.Fatalf("waylandtest: unable to send sync: %v", err)
+	}
+	done := false
+	callback.SetDoneHandler(func(client.CallbackDoneEvent) {
+		done = true
+	})
+
+	var queued []*Request
+	for {
+		r := s.read()
+		if r.Object.ID == s.display.ID() && r.Name == "sync" && r.Args[0].(*Object).ID == callback.ID() {
+			break
+		}
+		queued = append(queued, r)
+	}
+	s.pending = append(s.pending, queued...)
+
+	for !done {
+		if err := s.display.Context().Dispatch(); err != nil {
+			s.t.Fatalf("waylandtest: client dispatch failed: %v", err)
+		}
+	}
+}
+
+func (s *Server) read() *Request {
+	s.t.Helper()
+
+	sender, opcode, data := s.readMessage()
+	obj := s.objects[sender]
+	if obj == nil {
+		s.t.Fatalf("waylandtest: request to unknown object %d (opcode %d)", sender, opcode)
+	}
+	r, err := s.decode(obj, opcode, data)
+	if err != nil {
+		s.t.Fatalf("waylandtest: unable to decode request: %v", err)
+	}
+	for _, arg := range r.Args {
+		if o, ok := arg.(*Object); ok && o != nil && o.server == s && s.objects[o.ID] == o && !s.known(o) {
+			s.created = append(s.created, o)
+		}
+	}
+	s.handle(r)
+	return r
+}
+
+func (s *Server) known(o *Object) bool {
+	for _, c := range s.created {
+		if c == o {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) readMessage() (sender uint32, opcode uint16, data []byte) {
+	s.t.Helper()
+
+	for {
+		if sender, opcode, size, ok := wire.Header(s.buf); ok {
+			if size < wire.HeaderSize {
+				s.t.Fatalf("waylandtest: invalid message size %d", size)
+			}
+			if len(s.buf) >= size {
+				data = append([]byte(nil), s.buf[wire.HeaderSize:size]...)
+				s.buf = s.buf[size:]
+				return sender, opcode, data
+			}
+		}
+
+		if err := s.conn.SetReadDeadline(time.Now().Add(s.Timeout)); err != nil {
+			s.t.Fatalf("waylandtest: %v", err)
+		}
+		buf := make([]byte, 4096)
+		oob := make([]byte, unix.CmsgSpace(28*4))
+		n, oobn, _, _, err := s.conn.ReadMsgUnix(buf, oob)
+		if err != nil {
+			s.t.Fatalf("waylandtest: unable to read request: %v", err)
+		}
+		s.buf = append(s.buf, buf[:n]...)
+
+		if oobn > 0 {
+			scms, err := unix.ParseSocketControlMessage(oob[:oobn])
+			if err != nil {
+				s.t.Fatalf("waylandtest: unable to parse control message: %v", err)
+			}
+			for i := range scms {
+				fds, err := unix.ParseUnixRights(&scms[i])
+				if err != nil {
+					s.t.Fatalf("waylandtest: unable to parse unix rights: %v", err)
+				}
+				s.fds = append(s.fds, fds...)
+			}
+		}
+	}
+}
+
+// handle implements the parts of the core protocol every compositor
+// provides.
+func (s *Server) handle(r *Request) {
+	s.t.Helper()
+
+	switch r.Object.Interface + "." + r.Name {
+	case "wl_display.get_registry":
+		registry := r.Args[0].(*Object)
+		s.registries = append(s.registries, registry)
+		for _, g := range s.globals {
+			s.Send(registry, "global", g.Name, g.Interface, g.Version)
+		}
+
+	case "wl_display.sync":
+		s.Send(r.Args[0], "done", s.serial)
+
+	case "wl_registry.bind":
+		name := r.Args[0].(uint32)
+		iface := r.Args[1].(string)
+		version := r.Args[2].(uint32)
+		var global *Global
+		for i := range s.globals {
+			if s.globals[i].Name == name {
+				global = &s.globals[i]
+			}
+		}
+		switch {
+		case global == nil:
+			s.t.Errorf("waylandtest: %v: no global with name %d", r, name)
+		case global.Interface != iface:
+			s.t.Errorf("waylandtest: %v: global %d is %s", r, name, global.Interface)
+		case version == 0 || version > global.Version:
+			s.t.Errorf("waylandtest: %v: global %d only supports version %d", r, name, global.Version)
+		}
+	}
+
+	if r.message.Destructor {
+		s.destroy(r.Object)
+	}
+}
+
+func (s *Server) addObject(obj *Object) {
+	s.objects[obj.ID] = obj
+	s.created = append(s.created, obj)
+}
+
+func (s *Server) destroy(obj *Object) {
+	s.t.Helper()
+
+	delete(s.objects, obj.ID)
+	for i, registry := range s.registries {
+		if registry == obj {
+			s.registries = append(s.registries[:i], s.registries[i+1:]...)
+			break
+		}
+	}
+	if obj.ID < serverIDStart {
+		s.Send(s.display, "delete_id", obj.ID)
+	}
+}
+
+func match(want, got any) bool {
+	switch want := want.(type) {
+	case anyArg:
+		return true
+	case func(any) bool:
+		return want(got)
+	case nil:
+		if got == nil {
+			return true
+		}
+		o, ok := got.(*Object)
+		return ok && o == nil
+	case *Object, client.Proxy:
+		wantID, _ := objectID(want)
+		o, ok := got.(*Object)
+		if !ok {
+			return false
+		}
+		gotID, _ := objectID(o)
+		return wantID == gotID
+	case string:
+		return got == want
+	case []byte:
+		b, ok := got.([]byte)
+		return ok && bytes.Equal(b, want)
+	case float64:
+		return got == want
+	}
+
+	w, ok := toInt(want)
+	if !ok {
+		return false
+	}
+	g, ok := toInt(got)
+	return ok && w == g
+}
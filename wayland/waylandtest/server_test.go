@@ -0,0 +1,232 @@
+package waylandtest_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+	xdg_shell "github.com/rajveermalviya/go-wayland/wayland/stable/xdg-shell"
+	"github.com/rajveermalviya/go-wayland/wayland/waylandtest"
+)
+
+type app struct {
+	registry   *client.Registry
+	compositor *client.Compositor
+	seat       *client.Seat
+	wmBase     *xdg_shell.WmBase
+}
+
+func (a *app) HandleRegistryGlobal(e client.RegistryGlobalEvent) {
+	ctx := a.registry.Context()
+	switch e.Interface {
+	case "wl_compositor":
+		a.compositor = client.NewCompositor(ctx)
+		_ = a.registry.Bind(e.Name, e.Interface, e.Version, a.compositor)
+	case "wl_seat":
+		a.seat = client.NewSeat(ctx)
+		_ = a.registry.Bind(e.Name, e.Interface, e.Version, a.seat)
+	case "xdg_wm_base":
+		a.wmBase = xdg_shell.NewWmBase(ctx)
+		_ = a.registry.Bind(e.Name, e.Interface, e.Version, a.wmBase)
+	}
+}
+
+func setup(t *testing.T, s *waylandtest.Server) *app {
+	t.Helper()
+
+	s.AddGlobal("wl_compositor", 4)
+	s.AddGlobal("wl_seat", 7)
+	s.AddGlobal("xdg_wm_base", 2)
+
+	a := &app{}
+	registry, err := s.Display().GetRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.registry = registry
+	registry.SetGlobalHandler(a.HandleRegistryGlobal)
+	s.Roundtrip()
+
+	s.Expect("wl_display", "get_registry", registry)
+	s.Expect("wl_registry", "bind", uint32(1), "wl_compositor", uint32(4), a.compositor)
+	s.Expect("wl_registry", "bind", uint32(2), "wl_seat", uint32(7), a.seat)
+	s.Expect("wl_registry", "bind", uint32(3), "xdg_wm_base", uint32(2), a.wmBase)
+	return a
+}
+
+func TestToplevel(t *testing.T) {
+	s := waylandtest.NewServer(t)
+	a := setup(t, s)
+
+	surface, err := a.compositor.CreateSurface()
+	if err != nil {
+		t.Fatal(err)
+	}
+	xdgSurface, err := a.wmBase.GetXdgSurface(surface)
+	if err != nil {
+		t.Fatal(err)
+	}
+	toplevel, err := xdgSurface.GetToplevel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := toplevel.SetTitle("hello"); err != nil {
+		t.Fatal(err)
+	}
+	if err := surface.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	var width, height int32
+	var states []xdg_shell.ToplevelState
+	var serial uint32
+	toplevel.SetConfigureHandler(func(e xdg_shell.ToplevelConfigureEvent) {
+		width, height = e.Width, e.Height
+		states = e.States
+	})
+	xdgSurface.SetConfigureHandler(func(e xdg_shell.SurfaceConfigureEvent) {
+		serial = e.Serial
+		_ = xdgSurface.AckConfigure(e.Serial)
+	})
+
+	s.Expect("wl_compositor", "create_surface", surface)
+	s.Expect("xdg_wm_base", "get_xdg_surface", xdgSurface, surface)
+	s.Expect("xdg_surface", "get_toplevel", toplevel)
+	s.Expect("xdg_toplevel", "set_title", "hello")
+	s.Expect("wl_surface", "commit")
+
+	s.Send(toplevel, "configure", 640, 480, client.ArrayBytes([]xdg_shell.ToplevelState{
+		xdg_shell.ToplevelStateMaximized,
+		xdg_shell.ToplevelStateActivated,
+	}))
+	s.Last("xdg_surface").Send("configure", s.NextSerial())
+	s.Roundtrip()
+
+	if width != 640 || height != 480 {
+		t.Errorf("got configure %dx%d, want 640x480", width, height)
+	}
+	if len(states) != 2 || states[0] != xdg_shell.ToplevelStateMaximized || states[1] != xdg_shell.ToplevelStateActivated {
+		t.Errorf("got states %v, want [maximized activated]", states)
+	}
+	if serial != 1 {
+		t.Errorf("got serial %d, want 1", serial)
+	}
+	s.Expect("xdg_surface", "ack_configure", uint32(1))
+
+	if err := toplevel.Destroy(); err != nil {
+		t.Fatal(err)
+	}
+	s.Expect("xdg_toplevel", "destroy")
+	if obj := s.Object(toplevel.ID()); obj != nil {
+		t.Errorf("destroyed object %v still alive", obj)
+	}
+}
+
+func TestKeyboard(t *testing.T) {
+	s := waylandtest.NewServer(t)
+	a := setup(t, s)
+
+	var keyboard *client.Keyboard
+	a.seat.SetCapabilitiesHandler(func(e client.SeatCapabilitiesEvent) {
+		if e.Capabilities.Has(client.SeatCapabilityKeyboard) {
+			keyboard, _ = a.seat.GetKeyboard()
+		}
+	})
+	s.Send(a.seat, "capabilities", client.SeatCapabilityKeyboard)
+	s.Roundtrip()
+	if keyboard == nil {
+		t.Fatal("keyboard was not created")
+	}
+	s.Expect("wl_seat", "get_keyboard", keyboard)
+
+	var keymap []byte
+	var keys []uint32
+	keyboard.SetKeymapHandler(func(e client.KeyboardKeymapEvent) {
+		f := os.NewFile(uintptr(e.Fd), "keymap")
+		defer f.Close()
+		keymap = make([]byte, e.Size)
+		_, _ = f.Read(keymap)
+	})
+	keyboard.SetKeyHandler(func(e client.KeyboardKeyEvent) {
+		keys = append(keys, e.Key)
+	})
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if _, err := w.WriteString("xkb"); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	s.Send(keyboard, "keymap", client.KeyboardKeymapFormatXkbV1, int(r.Fd()), 3)
+	s.Send(keyboard, "key", s.NextSerial(), 0, 30, client.KeyboardKeyStatePressed)
+	s.Send(keyboard, "key", s.NextSerial(), 0, 30, client.KeyboardKeyStateReleased)
+	s.Roundtrip()
+
+	if string(keymap) != "xkb" {
+		t.Errorf("got keymap %q, want %q", keymap, "xkb")
+	}
+	if len(keys) != 2 || keys[0] != 30 || keys[1] != 30 {
+		t.Errorf("got keys %v, want [30 30]", keys)
+	}
+}
+
+func TestCallbackDestroyed(t *testing.T) {
+	s := waylandtest.NewServer(t)
+
+	callback, err := s.Display().Sync()
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := false
+	callback.SetDoneHandler(func(client.CallbackDoneEvent) {
+		done = true
+	})
+	s.Roundtrip()
+
+	if !done {
+		t.Fatal("callback is not done")
+	}
+	if p := s.Display().Context().GetProxy(callback.ID()); p != nil {
+		t.Errorf("%v still registered after done", p)
+	}
+}
+
+func TestBind(t *testing.T) {
+	s := waylandtest.NewServer(t)
+	s.AddGlobal("wl_compositor", 100)
+	s.AddGlobal("wl_seat", 7)
+
+	registry, err := s.Display().GetRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var compositor *client.Compositor
+	var seat *client.Seat
+	registry.SetGlobalHandler(func(e client.RegistryGlobalEvent) {
+		switch e.Interface {
+		case "wl_compositor":
+			compositor, err = client.Bind[client.Compositor](registry, e.Name, e.Version)
+		case "wl_seat":
+			seat, err = client.Bind[client.Seat](registry, e.Name, e.Version)
+		}
+		if err != nil {
+			t.Error(err)
+		}
+	})
+	s.Roundtrip()
+
+	// Versions above the generated code are lowered
+	s.Expect("wl_display", "get_registry", registry)
+	s.Expect("wl_registry", "bind", uint32(1), "wl_compositor", client.CompositorInterface.Version, compositor)
+	s.Expect("wl_registry", "bind", uint32(2), "wl_seat", uint32(7), seat)
+	if v := seat.Version(); v != 7 {
+		t.Errorf("seat has version %d, want 7", v)
+	}
+	if p := registry.Context().GetProxy(seat.ID()); p != seat {
+		t.Errorf("got %v registered for the seat id, want the seat", p)
+	}
+}
@@ -0,0 +1,195 @@
+package waylandtest
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+	"github.com/rajveermalviya/go-wayland/wayland/wire"
+)
+
+// encode builds an event message, returned with the fds to send along.
+func (s *Server) encode(sender uint32, opcode int, m *Message, args []any) ([]byte, []int, error) {
+	specs := m.args()
+	if len(args) != len(specs) {
+		return nil, nil, fmt.Errorf("%s: got %d arguments, want %d", m.Name, len(args), len(specs))
+	}
+
+	e := &wire.Encoder{}
+	for i, spec := range specs {
+		arg := args[i]
+		switch spec.kind {
+		case 'i', 'u':
+			v, ok := toInt(arg)
+			if !ok {
+				return nil, nil, fmt.Errorf("%s: argument %d: %T is not an integer", m.Name, i, arg)
+			}
+			e.Uint32(uint32(v))
+		case 'f':
+			v, ok := toFloat(arg)
+			if !ok {
+				return nil, nil, fmt.Errorf("%s: argument %d: %T is not a number", m.Name, i, arg)
+			}
+			f, err := client.FixedFromFloat64(v)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: argument %d: %w", m.Name, i, err)
+			}
+			e.Fixed(f)
+		case 's':
+			switch v := arg.(type) {
+			case nil:
+				e.Uint32(0)
+			case string:
+				e.String(v)
+			default:
+				return nil, nil, fmt.Errorf("%s: argument %d: %T is not a string", m.Name, i, arg)
+			}
+		case 'a':
+			v, ok := arg.([]byte)
+			if !ok {
+				return nil, nil, fmt.Errorf("%s: argument %d: %T is not a []byte", m.Name, i, arg)
+			}
+			e.Array(v)
+		case 'o', 'n':
+			id, ok := objectID(arg)
+			if !ok {
+				return nil, nil, fmt.Errorf("%s: argument %d: %T is not an object", m.Name, i, arg)
+			}
+			obj := s.objects[id]
+			if spec.kind == 'n' && id != 0 && obj == nil {
+				return nil, nil, fmt.Errorf("%s: argument %d: new_id %d was not created with NewObject", m.Name, i, id)
+			}
+			if spec.kind == 'n' && spec.iface == "" {
+				// sent with the interface name and version, as by
+				// wl_registry.bind
+				if obj == nil {
+					e.Uint32(0)
+					e.Uint32(0)
+				} else {
+					e.String(obj.Interface)
+					e.Uint32(obj.Version)
+				}
+			}
+			e.Uint32(id)
+		case 'h':
+			fd, ok := arg.(int)
+			if !ok {
+				return nil, nil, fmt.Errorf("%s: argument %d: %T is not a fd", m.Name, i, arg)
+			}
+			e.Fd(fd)
+		}
+	}
+	msg, fds := e.Message(sender, uint16(opcode))
+	return msg, fds, nil
+}
+
+func (s *Server) decode(obj *Object, opcode uint16, data []byte) (*Request, error) {
+	iface := s.iface(obj.Interface)
+	if iface == nil {
+		return nil, fmt.Errorf("unknown interface %q of object %d", obj.Interface, obj.ID)
+	}
+	if int(opcode) >= len(iface.Requests) {
+		return nil, fmt.Errorf("%s@%d: invalid opcode %d", obj.Interface, obj.ID, opcode)
+	}
+	m := &iface.Requests[opcode]
+	r := &Request{Object: obj, Opcode: opcode, Name: m.Name, message: m}
+
+	d := wire.NewDecoder(data, &s.fds)
+	for _, spec := range m.args() {
+		switch spec.kind {
+		case 'i':
+			r.Args = append(r.Args, d.Int32())
+		case 'u':
+			r.Args = append(r.Args, d.Uint32())
+		case 'f':
+			r.Args = append(r.Args, d.Fixed().Float64())
+		case 's':
+			if v, ok := d.String(); ok {
+				r.Args = append(r.Args, v)
+			} else {
+				r.Args = append(r.Args, nil)
+			}
+		case 'a':
+			r.Args = append(r.Args, d.Array())
+		case 'o':
+			id := d.Uint32()
+			if id == 0 {
+				r.Args = append(r.Args, (*Object)(nil))
+			} else {
+				r.Args = append(r.Args, s.objectOrPlaceholder(id))
+			}
+		case 'n':
+			ifaceName, version := spec.iface, obj.Version
+			if ifaceName == "" {
+				ifaceName, _ = d.String()
+				version = d.Uint32()
+				r.Args = append(r.Args, ifaceName, version)
+			}
+			id := d.Uint32()
+			if d.Err != nil {
+				break
+			}
+			newObj := &Object{ID: id, Interface: ifaceName, Version: version, server: s}
+			s.objects[id] = newObj
+			r.Args = append(r.Args, newObj)
+		case 'h':
+			r.Args = append(r.Args, d.Fd())
+		}
+	}
+	if d.Err != nil {
+		return nil, fmt.Errorf("%s@%d.%s: %w", obj.Interface, obj.ID, m.Name, d.Err)
+	}
+	return r, nil
+}
+
+func (s *Server) objectOrPlaceholder(id uint32) *Object {
+	if obj := s.objects[id]; obj != nil {
+		return obj
+	}
+	return &Object{ID: id, server: s}
+}
+
+func objectID(v any) (uint32, bool) {
+	switch v := v.(type) {
+	case nil:
+		return 0, true
+	case *Object:
+		if v == nil {
+			return 0, true
+		}
+		return v.ID, true
+	case client.Proxy:
+		if reflect.ValueOf(v).IsNil() {
+			return 0, true
+		}
+		return v.ID(), true
+	case uint32:
+		return v, true
+	}
+	return 0, false
+}
+
+func toInt(v any) (int64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(rv.Uint()), true
+	}
+	return 0, false
+}
+
+func toFloat(v any) (float64, bool) {
+	if f, ok := v.(float64); ok {
+		return f, true
+	}
+	if f, ok := v.(client.Fixed); ok {
+		return f.Float64(), true
+	}
+	if f, ok := v.(float32); ok {
+		return float64(f), true
+	}
+	i, ok := toInt(v)
+	return float64(i), ok
+}
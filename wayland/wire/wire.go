@@ -0,0 +1,172 @@
+// Package wire reads and writes wayland messages, for the code which
+// speaks the protocol without generated code: the dynamic package,
+// waylandtest and the commands built on them.
+//
+// A message is a header of 8 bytes, the id of the sender followed by the
+// size of the message and the opcode, then its arguments. Everything is
+// in host byte order, strings and arrays are prefixed with their length
+// and padded to 4 bytes. File descriptors are not in the message, they
+// are sent as ancillary data along with it.
+package wire
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+)
+
+// HeaderSize is the size of the header of every message.
+const HeaderSize = 8
+
+// Header reads the header at the start of b, ok is false when b is
+// shorter than the header. The size includes the header.
+func Header(b []byte) (sender uint32, opcode uint16, size int, ok bool) {
+	if len(b) < HeaderSize {
+		return 0, 0, 0, false
+	}
+	v := client.Uint32(b[4:8])
+	return client.Uint32(b[0:4]), uint16(v), int(v >> 16), true
+}
+
+// Encoder builds a message, the arguments are written in order.
+type Encoder struct {
+	buf []byte
+	fds []int
+}
+
+func (e *Encoder) Uint32(v uint32) {
+	if e.buf == nil {
+		e.buf = make([]byte, HeaderSize, 64)
+	}
+	var b [4]byte
+	client.PutUint32(b[:], v)
+	e.buf = append(e.buf, b[:]...)
+}
+
+func (e *Encoder) Int32(v int32) {
+	e.Uint32(uint32(v))
+}
+
+func (e *Encoder) Fixed(v client.Fixed) {
+	e.Uint32(uint32(v))
+}
+
+// String writes a string, a null string is written with Uint32(0).
+func (e *Encoder) String(s string) {
+	e.bytes(s, len(s)+1)
+}
+
+func (e *Encoder) Array(b []byte) {
+	e.bytes(string(b), len(b))
+}
+
+// bytes writes b with the length l, the NUL of strings is part of the
+// padding.
+func (e *Encoder) bytes(b string, l int) {
+	e.Uint32(uint32(l))
+	e.buf = append(e.buf, b...)
+	e.buf = append(e.buf, make([]byte, client.PaddedLen(l)-len(b))...)
+}
+
+// Fd adds a file descriptor to the ones sent with the message.
+func (e *Encoder) Fd(fd int) {
+	e.fds = append(e.fds, fd)
+}
+
+// Message returns the message with its header, and the file descriptors
+// to send with it.
+func (e *Encoder) Message(sender uint32, opcode uint16) ([]byte, []int) {
+	if e.buf == nil {
+		e.buf = make([]byte, HeaderSize)
+	}
+	client.PutUint32(e.buf[0:4], sender)
+	client.PutUint32(e.buf[4:8], uint32(len(e.buf))<<16|uint32(opcode))
+	return e.buf, e.fds
+}
+
+// Decoder reads the arguments of a message. The first error is kept in
+// Err and every following read returns a zero value.
+type Decoder struct {
+	data []byte
+	fds  *[]int
+	Err  error
+}
+
+// NewDecoder returns a decoder of the arguments of a message, without
+// its header. File descriptors are taken from the start of fds, which
+// can be nil when the message has none.
+func NewDecoder(args []byte, fds *[]int) *Decoder {
+	return &Decoder{data: args, fds: fds}
+}
+
+func (d *Decoder) Uint32() uint32 {
+	if d.Err != nil {
+		return 0
+	}
+	if len(d.data) < 4 {
+		d.Err = errors.New("message too short")
+		return 0
+	}
+	v := client.Uint32(d.data)
+	d.data = d.data[4:]
+	return v
+}
+
+func (d *Decoder) Int32() int32 {
+	return int32(d.Uint32())
+}
+
+func (d *Decoder) Fixed() client.Fixed {
+	return client.Fixed(d.Uint32())
+}
+
+// Array reads an array, which shares the memory of the message.
+func (d *Decoder) Array() []byte {
+	n := int(d.Uint32())
+	if d.Err != nil {
+		return nil
+	}
+	padded := client.PaddedLen(n)
+	if padded > len(d.data) || padded < n {
+		d.Err = fmt.Errorf("array of %d bytes overflows message", n)
+		return nil
+	}
+	b := d.data[:n:n]
+	d.data = d.data[padded:]
+	return b
+}
+
+// String reads a string, ok is false for a null string. Like libwayland,
+// the string must end with a NUL and stops at the first one.
+func (d *Decoder) String() (s string, ok bool) {
+	b := d.Array()
+	if len(b) == 0 {
+		return "", false
+	}
+	if b[len(b)-1] != 0 {
+		d.Err = errors.New("string is not null terminated")
+		return "", false
+	}
+	for i, c := range b {
+		if c == 0 {
+			b = b[:i]
+			break
+		}
+	}
+	return string(b), true
+}
+
+// Fd takes the next file descriptor sent with the message.
+func (d *Decoder) Fd() int {
+	if d.Err != nil {
+		return -1
+	}
+	if d.fds == nil || len(*d.fds) == 0 {
+		d.Err = errors.New("missing file descriptor")
+		return -1
+	}
+	fd := (*d.fds)[0]
+	*d.fds = (*d.fds)[1:]
+	return fd
+}
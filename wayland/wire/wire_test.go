@@ -0,0 +1,108 @@
+package wire
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rajveermalviya/go-wayland/wayland/client"
+)
+
+func TestRoundtrip(t *testing.T) {
+	e := &Encoder{}
+	e.Uint32(7)
+	e.Int32(-2)
+	e.Fixed(client.Fixed(-3*256 + 128))
+	e.String("wl_shm")
+	e.String("")
+	e.Uint32(0) // null string
+	e.Array([]byte{1, 2, 3})
+	e.Fd(42)
+	e.Array(nil)
+	msg, fds := e.Message(3, 5)
+
+	sender, opcode, size, ok := Header(msg)
+	if !ok || sender != 3 || opcode != 5 || size != len(msg) {
+		t.Fatalf("got header %d, %d, %d, %v, want 3, 5, %d", sender, opcode, size, ok, len(msg))
+	}
+	// header, 3 words, "wl_shm" in 2 words and "" in 1 with their
+	// lengths, null string, 3 bytes in 1 word and empty array
+	if want := HeaderSize + 4*(3+3+2+1+2+1); size != want {
+		t.Errorf("got size %d, want %d", size, want)
+	}
+
+	d := NewDecoder(msg[HeaderSize:], &fds)
+	if v := d.Uint32(); v != 7 {
+		t.Errorf("got uint %d, want 7", v)
+	}
+	if v := d.Int32(); v != -2 {
+		t.Errorf("got int %d, want -2", v)
+	}
+	if v := d.Fixed(); v.Float64() != -2.5 {
+		t.Errorf("got fixed %v, want -2.5", v)
+	}
+	for _, want := range []struct {
+		s  string
+		ok bool
+	}{{"wl_shm", true}, {"", true}, {"", false}} {
+		if s, ok := d.String(); s != want.s || ok != want.ok {
+			t.Errorf("got string %q, %v, want %q, %v", s, ok, want.s, want.ok)
+		}
+	}
+	if b := d.Array(); !bytes.Equal(b, []byte{1, 2, 3}) {
+		t.Errorf("got array %v, want [1 2 3]", b)
+	}
+	if fd := d.Fd(); fd != 42 || len(fds) != 0 {
+		t.Errorf("got fd %d with %d left, want 42 and none left", fd, len(fds))
+	}
+	if b := d.Array(); len(b) != 0 {
+		t.Errorf("got array %v, want an empty one", b)
+	}
+	if d.Err != nil {
+		t.Fatal(d.Err)
+	}
+
+	// reading past the end keeps the first error
+	d.Uint32()
+	d.Fd()
+	if d.Err == nil || d.Err.Error() != "message too short" {
+		t.Errorf("got error %v, want message too short", d.Err)
+	}
+}
+
+func TestDecoderErrors(t *testing.T) {
+	word := func(v uint32) []byte {
+		b := make([]byte, 4)
+		client.PutUint32(b, v)
+		return b
+	}
+
+	for _, tt := range []struct {
+		name string
+		read func(d *Decoder)
+		data []byte
+		err  string
+	}{
+		{"short", func(d *Decoder) { d.Uint32() }, []byte{1, 2}, "message too short"},
+		{"array", func(d *Decoder) { d.Array() }, append(word(5), 1, 2, 3, 4), "array of 5 bytes overflows message"},
+		{"huge array", func(d *Decoder) { d.Array() }, word(0xffffffff), "array of 4294967295 bytes overflows message"},
+		{"string", func(d *Decoder) { d.String() }, append(word(3), 'a', 'b', 'c', 0), "string is not null terminated"},
+		{"fd", func(d *Decoder) { d.Fd() }, nil, "missing file descriptor"},
+	} {
+		d := NewDecoder(tt.data, nil)
+		tt.read(d)
+		if d.Err == nil || d.Err.Error() != tt.err {
+			t.Errorf("%s: got error %v, want %q", tt.name, d.Err, tt.err)
+		}
+	}
+
+	// A string stops at its first NUL, as some clients include the
+	// padding in the length
+	d := NewDecoder(append(word(4), 'a', 0, 0, 0), nil)
+	if s, ok := d.String(); s != "a" || !ok || d.Err != nil {
+		t.Errorf("got %q, %v, %v, want %q", s, ok, d.Err, "a")
+	}
+
+	if _, _, _, ok := Header(make([]byte, HeaderSize-1)); ok {
+		t.Error("got a header from a short message")
+	}
+}